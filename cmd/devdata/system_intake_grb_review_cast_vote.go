@@ -17,6 +17,7 @@ func castSystemIntakeGRBReviewerVote(
 		ctx,
 		store,
 		nil,
+		nil,
 		input,
 	)
 
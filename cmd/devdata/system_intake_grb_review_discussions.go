@@ -20,6 +20,7 @@ func createSystemIntakeGRBDiscussionPost(
 		ctx,
 		store,
 		nil, // email client
+		nil, // pubsub
 		models.CreateSystemIntakeGRBDiscussionPostInput{
 			SystemIntakeID:      intake.ID,
 			Content:             content,
@@ -42,6 +43,7 @@ func createSystemIntakeGRBDiscussionReply(
 		ctx,
 		store,
 		nil, // email client
+		nil, // pubsub
 		models.CreateSystemIntakeGRBDiscussionReplyInput{
 			InitialPostID:       initialPostID,
 			Content:             content,
@@ -16,7 +16,7 @@ func createSystemIntakeGRBReviewers(
 	intake *models.SystemIntake,
 	reviewers []*models.CreateGRBReviewerInput,
 ) {
-	_, err := resolvers.CreateSystemIntakeGRBReviewers(ctx, store, nil, userhelpers.GetUserInfoAccountInfosWrapperFunc(mock.FetchUserInfosMock), &models.CreateSystemIntakeGRBReviewersInput{
+	_, err := resolvers.CreateSystemIntakeGRBReviewers(ctx, store, nil, nil, userhelpers.GetUserInfoAccountInfosWrapperFunc(mock.FetchUserInfosMock), &models.CreateSystemIntakeGRBReviewersInput{
 		SystemIntakeID: intake.ID,
 		Reviewers:      reviewers,
 	})
@@ -14,11 +14,13 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/appconfig"
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
 	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/local"
 	"github.com/cms-enterprise/easi-app/pkg/local/cedarcoremock"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null"
 	"go.uber.org/zap"
 )
 
@@ -75,6 +77,21 @@ func sendTRBEmails(ctx context.Context, client *email.Client) {
 			ConsultDate:    &consultDate,
 			CopyTRBMailbox: true,
 			Recipients:     emailRecipients,
+			Letter: &models.TRBGuidanceLetter{
+				MeetingSummary:        models.HTMLPointer("<p>We talked about the request.</p>"),
+				NextSteps:             models.HTMLPointer("<ul><li>Do the first thing</li><li>Do the <strong>second</strong> thing</li></ul>"),
+				IsFollowupRecommended: helpers.PointerTo(true),
+				FollowupPoint:         helpers.PointerTo("In 6 months"),
+				DateSent:              &submissionDate,
+			},
+			Insights: []*models.TRBGuidanceLetterInsight{
+				{
+					Title:    "Use the cloud",
+					Insight:  "<p>The cloud is where it's at.</p>",
+					Links:    []string{"https://cloud.cms.gov"},
+					Category: models.TRBGuidanceLetterInsightCategoryRecommendation,
+				},
+			},
 		},
 	)
 	noErr(err)
@@ -540,6 +557,16 @@ func sendITGovEmails(ctx context.Context, client *email.Client) {
 		true,  // isDraft
 	)
 	noErr(err)
+	businessCase := &models.BusinessCaseWithCosts{
+		BusinessCase: models.BusinessCase{
+			ProjectName:      null.StringFrom("Candy Corn"),
+			Requester:        null.StringFrom("Dexter"),
+			BusinessNeed:     null.StringFrom("Everyone needs more candy corn"),
+			PreferredTitle:   null.StringFrom("Grow more corn"),
+			PreferredSummary: null.StringFrom("Plant corn, then make it into candy"),
+		},
+	}
+	businessCase.ID = uuid.New()
 	err = client.SystemIntake.SendSubmitBizCaseReviewerNotification(
 		ctx,
 		intakeID,
@@ -547,6 +574,7 @@ func sendITGovEmails(ctx context.Context, client *email.Client) {
 		"Candy Corn",
 		false, // isResubmitted
 		true,  // isDraft
+		businessCase,
 	)
 	noErr(err)
 	// resubmitted biz case draft
@@ -566,6 +594,7 @@ func sendITGovEmails(ctx context.Context, client *email.Client) {
 		"Candy Corn",
 		true, // isResubmitted
 		true, // isDraft
+		nil,
 	)
 	noErr(err)
 	// initial biz case final
@@ -585,6 +614,7 @@ func sendITGovEmails(ctx context.Context, client *email.Client) {
 		"Bit o Honey",
 		false, // isResubmitted
 		false, // isDraft
+		nil,
 	)
 	noErr(err)
 	// resubmitted biz case final
@@ -604,6 +634,7 @@ func sendITGovEmails(ctx context.Context, client *email.Client) {
 		"Bit o Honey",
 		true,  // isResubmitted
 		false, // isDraft
+		nil,
 	)
 	noErr(err)
 	err = client.SendLCIDExpirationAlertEmail(
@@ -739,6 +770,33 @@ func sendITGovEmails(ctx context.Context, client *email.Client) {
 	)
 	noErr(err)
 
+	grbDate := time.Now().AddDate(0, 0, 14)
+	err = client.SystemIntake.SendSystemIntakeGRBMeetingEmail(
+		ctx,
+		email.SendSystemIntakeGRBMeetingEmailInput{
+			SystemIntakeID:     intakeID,
+			RequestName:        "GRB Meeting",
+			RequesterName:      "Wouldn't you like to know",
+			RequesterComponent: "OFW",
+			GRBDate:            &grbDate,
+			Recipients:         emailNotificationRecipients.RegularRecipientEmails,
+		},
+	)
+	noErr(err)
+
+	err = client.SystemIntake.SendSystemIntakeGRBMeetingEmail(
+		ctx,
+		email.SendSystemIntakeGRBMeetingEmailInput{
+			SystemIntakeID:         intakeID,
+			RequestName:            "GRB Meeting",
+			RequesterName:          "Wouldn't you like to know",
+			RequesterComponent:     "OFW",
+			Recipients:             emailNotificationRecipients.RegularRecipientEmails,
+			CalendarInviteSequence: 1,
+		},
+	)
+	noErr(err)
+
 	err = client.SystemIntake.SendGRBReviewPresentationLinksUpdatedEmail(
 		ctx,
 		email.SendGRBReviewPresentationLinksUpdatedEmailInput{
@@ -0,0 +1,21 @@
+CREATE TYPE system_profile_lockable_section AS ENUM (
+    'BUSINESS_INFORMATION',
+    'IMPLEMENTATION_DETAILS',
+    'DATA',
+    'TOOLS_AND_SOFTWARE',
+    'SUB_SYSTEMS',
+    'TEAM'
+);
+
+CREATE TABLE IF NOT EXISTS system_profile_section_locks (
+    cedar_system_id UUID NOT NULL,
+    section system_profile_lockable_section NOT NULL,
+    locked_by UUID NOT NULL REFERENCES user_account(id),
+    locked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (cedar_system_id, section)
+);
+
+CREATE INDEX IF NOT EXISTS system_profile_section_locks_expires_at_idx ON system_profile_section_locks (expires_at);
+
+COMMENT ON TABLE system_profile_section_locks IS 'Holds the editing locks on system profile sections. A lock is only considered held while expires_at is in the future, and is renewed while the holder remains connected';
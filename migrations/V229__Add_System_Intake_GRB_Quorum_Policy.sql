@@ -0,0 +1,15 @@
+CREATE TYPE grb_quorum_required_roles_rule AS ENUM (
+    'ANY_ROLE',
+    'ALL_ROLES'
+);
+
+ALTER TABLE system_intakes
+ADD COLUMN grb_quorum_minimum_votes INTEGER NOT NULL DEFAULT 1 CHECK (grb_quorum_minimum_votes >= 1),
+ADD COLUMN grb_quorum_voting_percentage INTEGER CHECK (grb_quorum_voting_percentage BETWEEN 1 AND 100),
+ADD COLUMN grb_quorum_required_roles grb_reviewer_role_type[] NOT NULL DEFAULT '{}',
+ADD COLUMN grb_quorum_required_roles_rule grb_quorum_required_roles_rule NOT NULL DEFAULT 'ANY_ROLE';
+
+COMMENT ON COLUMN system_intakes.grb_quorum_minimum_votes IS 'The minimum number of votes that must be cast by voting GRB reviewers for an async GRB review to reach quorum';
+COMMENT ON COLUMN system_intakes.grb_quorum_voting_percentage IS 'When set, the percentage of voting GRB reviewers that must cast a vote for an async GRB review to reach quorum';
+COMMENT ON COLUMN system_intakes.grb_quorum_required_roles IS 'GRB reviewer roles whose votes are required for an async GRB review to reach quorum';
+COMMENT ON COLUMN system_intakes.grb_quorum_required_roles_rule IS 'Whether a vote from any, or from all, of the required roles is needed to reach quorum';
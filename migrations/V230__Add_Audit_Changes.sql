@@ -0,0 +1,30 @@
+CREATE TYPE audit_entity_type AS ENUM (
+    'SYSTEM_INTAKE',
+    'BUSINESS_CASE',
+    'TRB_REQUEST_FORM'
+);
+
+CREATE TYPE audit_change_action AS ENUM (
+    'INSERT',
+    'UPDATE',
+    'DELETE'
+);
+
+CREATE TABLE IF NOT EXISTS audit_changes (
+    id UUID PRIMARY KEY NOT NULL,
+    entity_type audit_entity_type NOT NULL,
+    entity_id UUID NOT NULL, -- not a foreign key, so the history of deleted entities is kept
+    parent_id UUID,
+    action audit_change_action NOT NULL,
+    fields JSONB NOT NULL DEFAULT '[]',
+    modified_by UUID REFERENCES user_account(id),
+    modified_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS audit_changes_entity_id_idx ON audit_changes (entity_id, modified_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS audit_changes_parent_id_idx ON audit_changes (parent_id, modified_at DESC, id DESC);
+
+COMMENT ON TABLE audit_changes IS 'Field level history of changes made to system intakes, business cases and TRB request forms';
+COMMENT ON COLUMN audit_changes.parent_id IS 'The entity the changed entity belongs to, such as the system intake of a business case, or the TRB request of a TRB request form';
+COMMENT ON COLUMN audit_changes.fields IS 'The changed fields, as an array of objects holding the fieldName and its old and new values';
+COMMENT ON COLUMN audit_changes.modified_by IS 'The user who made the change, or NULL for changes made by the system';
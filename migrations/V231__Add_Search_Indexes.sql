@@ -0,0 +1,122 @@
+/*
+ * Full text search indexes for the content searched by the search query.
+ * Each searchable table has a function building its document from its text columns, and an expression index on that function,
+ * so the queries in pkg/sqlqueries/SQL/search must call the same functions with the same columns to use the indexes
+ */
+
+-- strips the tags from rich text, so only the text users see is searched and shown in snippets
+CREATE FUNCTION search_document_text(document TEXT) RETURNS TEXT
+LANGUAGE SQL IMMUTABLE PARALLEL SAFE
+RETURN regexp_replace(COALESCE(document, ''), '<[^>]*>', ' ', 'g');
+
+-- weights matches in the title of a document above matches in its body
+CREATE FUNCTION search_document_vector(title TEXT, body TEXT) RETURNS TSVECTOR
+LANGUAGE SQL IMMUTABLE PARALLEL SAFE
+RETURN setweight(to_tsvector('english', search_document_text(title)), 'A')
+    || setweight(to_tsvector('english', search_document_text(body)), 'B');
+
+CREATE FUNCTION system_intake_search_body(business_need TEXT, solution TEXT) RETURNS TEXT
+LANGUAGE SQL IMMUTABLE PARALLEL SAFE
+RETURN search_document_text(COALESCE(business_need, '') || E'\n' || COALESCE(solution, ''));
+
+CREATE INDEX IF NOT EXISTS system_intakes_search_idx ON system_intakes USING GIN (
+    search_document_vector(
+        COALESCE(project_name, '') || ' ' || COALESCE(project_acronym, ''),
+        system_intake_search_body(business_need, solution)
+    )
+);
+
+CREATE FUNCTION business_case_search_body(
+    business_need TEXT,
+    current_solution_summary TEXT,
+    cms_benefit TEXT,
+    success_indicators TEXT,
+    collaboration_needed TEXT,
+    response_to_grt_feedback TEXT,
+    preferred_title TEXT,
+    preferred_summary TEXT,
+    alternative_a_title TEXT,
+    alternative_a_summary TEXT,
+    alternative_b_title TEXT,
+    alternative_b_summary TEXT
+) RETURNS TEXT
+LANGUAGE SQL IMMUTABLE PARALLEL SAFE
+RETURN search_document_text(
+    COALESCE(business_need, '') || E'\n'
+    || COALESCE(current_solution_summary, '') || E'\n'
+    || COALESCE(cms_benefit, '') || E'\n'
+    || COALESCE(success_indicators, '') || E'\n'
+    || COALESCE(collaboration_needed, '') || E'\n'
+    || COALESCE(response_to_grt_feedback, '') || E'\n'
+    || COALESCE(preferred_title, '') || E'\n'
+    || COALESCE(preferred_summary, '') || E'\n'
+    || COALESCE(alternative_a_title, '') || E'\n'
+    || COALESCE(alternative_a_summary, '') || E'\n'
+    || COALESCE(alternative_b_title, '') || E'\n'
+    || COALESCE(alternative_b_summary, '')
+);
+
+CREATE INDEX IF NOT EXISTS business_cases_search_idx ON business_cases USING GIN (
+    search_document_vector(
+        COALESCE(project_name, '') || ' ' || COALESCE(project_acronym, ''),
+        business_case_search_body(
+            business_need,
+            current_solution_summary,
+            cms_benefit,
+            success_indicators,
+            collaboration_needed,
+            response_to_grt_feedback,
+            preferred_title,
+            preferred_summary,
+            alternative_a_title,
+            alternative_a_summary,
+            alternative_b_title,
+            alternative_b_summary
+        )
+    )
+);
+
+CREATE FUNCTION trb_request_form_search_body(
+    component TEXT,
+    needs_assistance_with TEXT,
+    proposed_solution TEXT,
+    where_in_process_other TEXT,
+    subject_area_option_other TEXT,
+    collab_group_other TEXT
+) RETURNS TEXT
+LANGUAGE SQL IMMUTABLE PARALLEL SAFE
+RETURN search_document_text(
+    COALESCE(component, '') || E'\n'
+    || COALESCE(needs_assistance_with, '') || E'\n'
+    || COALESCE(proposed_solution, '') || E'\n'
+    || COALESCE(where_in_process_other, '') || E'\n'
+    || COALESCE(subject_area_option_other, '') || E'\n'
+    || COALESCE(collab_group_other, '')
+);
+
+-- the name of a TRB request is on trb_request, which an index on trb_request_forms can't include
+CREATE INDEX IF NOT EXISTS trb_request_forms_search_idx ON trb_request_forms USING GIN (
+    search_document_vector(
+        '',
+        trb_request_form_search_body(
+            component,
+            needs_assistance_with,
+            proposed_solution,
+            where_in_process_other,
+            subject_area_option_other,
+            collab_group_other
+        )
+    )
+);
+
+CREATE INDEX IF NOT EXISTS trb_guidance_letter_insights_search_idx ON trb_guidance_letter_insights USING GIN (
+    search_document_vector(title, insight)
+);
+
+CREATE INDEX IF NOT EXISTS notes_search_idx ON notes USING GIN (
+    search_document_vector('', content)
+);
+
+CREATE INDEX IF NOT EXISTS trb_admin_notes_search_idx ON trb_admin_notes USING GIN (
+    search_document_vector('', note_text)
+);
@@ -0,0 +1,32 @@
+CREATE TYPE email_outbox_status AS ENUM (
+    'PENDING',
+    'SENT',
+    'DEAD_LETTER'
+);
+
+CREATE TABLE IF NOT EXISTS email_outbox (
+    id UUID PRIMARY KEY NOT NULL,
+    to_addresses TEXT[] NOT NULL DEFAULT '{}',
+    cc_addresses TEXT[] NOT NULL DEFAULT '{}',
+    bcc_addresses TEXT[] NOT NULL DEFAULT '{}',
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status email_outbox_status NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES user_account(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_by UUID REFERENCES user_account(id),
+    modified_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS email_outbox_pending_idx ON email_outbox (next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS email_outbox_status_idx ON email_outbox (status, created_at DESC, id DESC);
+
+COMMENT ON TABLE email_outbox IS 'Emails waiting to be sent, or that have been sent, by the email outbox dispatcher. Emails are written in the same transaction as the change that caused them';
+COMMENT ON COLUMN email_outbox.status IS 'PENDING emails are sent once next_attempt_at has passed. Emails that fail to send too many times are moved to DEAD_LETTER until an admin re-sends them';
+COMMENT ON COLUMN email_outbox.attempts IS 'The number of times sending the email has been attempted';
+COMMENT ON COLUMN email_outbox.last_error IS 'The error returned by the most recent failed attempt to send the email';
+COMMENT ON COLUMN email_outbox.created_by IS 'The user whose change caused the email, or NULL for emails sent by the system, such as by scheduled jobs';
@@ -0,0 +1,39 @@
+CREATE TYPE notification_category AS ENUM (
+    'GRB_DISCUSSIONS',
+    'GRB_REVIEWS',
+    'TRB_REQUESTS',
+    'SYSTEM_WORKSPACES'
+);
+
+CREATE TYPE notification_frequency AS ENUM (
+    'IMMEDIATE',
+    'DAILY_DIGEST',
+    'OFF'
+);
+
+CREATE TABLE IF NOT EXISTS user_notification_preferences (
+    user_id UUID NOT NULL REFERENCES user_account(id),
+    category notification_category NOT NULL,
+    frequency notification_frequency NOT NULL,
+    created_by UUID NOT NULL REFERENCES user_account(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_by UUID REFERENCES user_account(id),
+    modified_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (user_id, category)
+);
+
+CREATE TABLE IF NOT EXISTS notification_digest_items (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL REFERENCES user_account(id),
+    category notification_category NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS notification_digest_items_pending_idx ON notification_digest_items (user_id, created_at) WHERE sent_at IS NULL;
+
+COMMENT ON TABLE user_notification_preferences IS 'How each user wants to receive each category of notification. Users without a preference for a category receive its notifications immediately';
+COMMENT ON TABLE notification_digest_items IS 'Notifications held back to be sent in a user''s daily digest email';
+COMMENT ON COLUMN notification_digest_items.sent_at IS 'When the digest containing the notification was sent, or NULL if it is waiting for the next digest';
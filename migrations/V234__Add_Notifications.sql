@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL REFERENCES user_account(id),
+    category notification_category,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS notifications_user_id_idx ON notifications (user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS notifications_unread_idx ON notifications (user_id) WHERE read_at IS NULL;
+
+COMMENT ON TABLE notifications IS 'The in-app notification inbox of each user. A notification is created for every user account an email is sent to';
+COMMENT ON COLUMN notifications.category IS 'The category of notification, or NULL for notifications that are always sent immediately, such as legally required notices';
+COMMENT ON COLUMN notifications.read_at IS 'When the user marked the notification read, or NULL if it is unread';
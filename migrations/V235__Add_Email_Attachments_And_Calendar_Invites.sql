@@ -0,0 +1,14 @@
+ALTER TABLE email_outbox ADD COLUMN attachments JSONB NOT NULL DEFAULT '[]';
+
+COMMENT ON COLUMN email_outbox.attachments IS 'The files attached to the email, with their contents base64 encoded';
+
+CREATE TABLE IF NOT EXISTS calendar_invites (
+    uid TEXT PRIMARY KEY NOT NULL,
+    sequence INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE calendar_invites IS 'The calendar invites that have been sent, so updates to an event can be sent with the same UID and a higher sequence';
+COMMENT ON COLUMN calendar_invites.uid IS 'The iCalendar UID of the event, which is derived from the request the event belongs to';
+COMMENT ON COLUMN calendar_invites.sequence IS 'The iCalendar SEQUENCE of the most recent invite sent for the event. Calendar clients replace an event with an invite that has a higher sequence';
@@ -0,0 +1,3 @@
+ALTER TABLE email_outbox ADD COLUMN reply_to_address TEXT;
+
+COMMENT ON COLUMN email_outbox.reply_to_address IS 'The address replies to the email are sent to, if they are not sent to the address it is sent from';
@@ -0,0 +1,60 @@
+CREATE TYPE webhook_event_type AS ENUM (
+    'LCID_ISSUED',
+    'LCID_EXPIRED',
+    'LCID_RETIRED',
+    'GRB_REVIEW_ENDED',
+    'TRB_GUIDANCE_LETTER_SENT'
+);
+
+CREATE TYPE webhook_delivery_status AS ENUM (
+    'PENDING',
+    'DELIVERED',
+    'DEAD_LETTER'
+);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY NOT NULL,
+    name TEXT NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types webhook_event_type[] NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID NOT NULL REFERENCES user_account(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_by UUID REFERENCES user_account(id),
+    modified_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY NOT NULL,
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type webhook_event_type NOT NULL,
+    payload JSONB NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_response_status INTEGER,
+    last_error TEXT,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES user_account(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_by UUID REFERENCES user_account(id),
+    modified_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS webhook_deliveries_subscription_idx ON webhook_deliveries (subscription_id, created_at DESC, id DESC);
+
+COMMENT ON TABLE webhook_subscriptions IS 'Downstream systems that are sent governance lifecycle events, such as LCIDs being issued, as signed HTTP requests';
+COMMENT ON COLUMN webhook_subscriptions.secret IS 'The key each request to the subscriber is signed with, so it can check requests came from EASi';
+COMMENT ON COLUMN webhook_subscriptions.event_types IS 'The types of events the subscriber is sent';
+COMMENT ON COLUMN webhook_subscriptions.is_active IS 'Inactive subscriptions are not sent new events, and their pending deliveries are held until they are reactivated';
+
+COMMENT ON TABLE webhook_deliveries IS 'The log of events sent, or waiting to be sent, to webhook subscribers by the webhook dispatcher';
+COMMENT ON COLUMN webhook_deliveries.event_id IS 'Identifies the event across the deliveries of it to each subscriber, so subscribers can ignore events they have already received';
+COMMENT ON COLUMN webhook_deliveries.payload IS 'The JSON body sent to the subscriber';
+COMMENT ON COLUMN webhook_deliveries.status IS 'PENDING deliveries are sent once next_attempt_at has passed. Deliveries that fail too many times are moved to DEAD_LETTER until an admin redelivers them';
+COMMENT ON COLUMN webhook_deliveries.last_response_status IS 'The HTTP status the subscriber responded to the most recent attempt with, if it responded';
+COMMENT ON COLUMN webhook_deliveries.last_error IS 'The error from the most recent failed attempt to deliver the event';
+COMMENT ON COLUMN webhook_deliveries.created_by IS 'The user whose action caused the event, or NULL for events caused by the system, such as by scheduled jobs';
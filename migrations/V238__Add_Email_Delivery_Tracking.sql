@@ -0,0 +1,42 @@
+CREATE TYPE email_delivery_event_type AS ENUM (
+    'DELIVERY',
+    'BOUNCE',
+    'COMPLAINT'
+);
+
+ALTER TABLE email_outbox ADD COLUMN provider_message_id TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS email_outbox_provider_message_id_idx ON email_outbox (provider_message_id);
+
+COMMENT ON COLUMN email_outbox.provider_message_id IS 'The ID SES gave the email when it was sent, which its delivery notifications refer to it by';
+
+CREATE TABLE IF NOT EXISTS email_delivery_events (
+    id UUID PRIMARY KEY NOT NULL,
+    notification_id TEXT NOT NULL,
+    provider_message_id TEXT NOT NULL,
+    email_outbox_id UUID REFERENCES email_outbox(id) ON DELETE SET NULL,
+    event_type email_delivery_event_type NOT NULL,
+    recipient TEXT NOT NULL,
+    bounce_type TEXT,
+    bounce_sub_type TEXT,
+    diagnostic TEXT,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (notification_id, recipient)
+);
+
+CREATE INDEX IF NOT EXISTS email_delivery_events_email_outbox_idx ON email_delivery_events (email_outbox_id);
+CREATE INDEX IF NOT EXISTS email_delivery_events_recipient_idx ON email_delivery_events (LOWER(recipient), occurred_at DESC);
+
+COMMENT ON TABLE email_delivery_events IS 'The delivery, bounce, and complaint notifications SES sends for each recipient of the emails it sends';
+COMMENT ON COLUMN email_delivery_events.notification_id IS 'The ID of the SNS notification the event was received in. SNS can send a notification more than once, so events are only recorded once per notification and recipient';
+COMMENT ON COLUMN email_delivery_events.email_outbox_id IS 'The email in the outbox the event is about, if it was sent from the outbox';
+COMMENT ON COLUMN email_delivery_events.bounce_type IS 'For bounces, whether SES considers the bounce Permanent, Transient, or Undetermined';
+COMMENT ON COLUMN email_delivery_events.diagnostic IS 'The response from the recipient''s mail server, or the feedback type of a complaint';
+
+ALTER TABLE user_account
+ADD COLUMN email_bounce_count INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN email_undeliverable_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN user_account.email_bounce_count IS 'The number of emails to the user that have bounced since one was last delivered to them';
+COMMENT ON COLUMN user_account.email_undeliverable_at IS 'When emails to the user started bouncing repeatedly. Cleared when an email is delivered to them, or their email address changes';
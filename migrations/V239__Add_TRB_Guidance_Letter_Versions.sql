@@ -0,0 +1,26 @@
+CREATE TYPE trb_guidance_letter_version_reason AS ENUM (
+    'REVIEW_REQUESTED',
+    'SENT',
+    'AMENDED'
+);
+
+CREATE TABLE IF NOT EXISTS trb_guidance_letter_versions (
+    id UUID PRIMARY KEY NOT NULL,
+    trb_request_id UUID NOT NULL REFERENCES trb_request(id),
+    trb_guidance_letter_id UUID NOT NULL REFERENCES trb_guidance_letters(id),
+    version_number INTEGER NOT NULL,
+    reason trb_guidance_letter_version_reason NOT NULL,
+    meeting_summary TEXT,
+    next_steps TEXT,
+    is_followup_recommended BOOLEAN,
+    followup_point TEXT,
+    insights JSONB NOT NULL DEFAULT '[]',
+    amendment_summary TEXT,
+    created_by TEXT NOT NULL CHECK (created_by ~ '^[A-Z0-9]{4}$'),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (trb_guidance_letter_id, version_number)
+);
+
+COMMENT ON TABLE trb_guidance_letter_versions IS 'Snapshots of a TRB guidance letter taken each time it''s sent for review, sent, or amended. Versions are never changed once they''re taken';
+COMMENT ON COLUMN trb_guidance_letter_versions.insights IS 'The letter''s insights when the version was taken, in the order they appeared in the letter';
+COMMENT ON COLUMN trb_guidance_letter_versions.amendment_summary IS 'For amended letters, the explanation of what changed that was sent to the letter''s recipients';
@@ -0,0 +1,13 @@
+ALTER TYPE system_profile_lockable_section ADD VALUE 'CONTRACTS';
+ALTER TYPE system_profile_lockable_section ADD VALUE 'FUNDING_AND_BUDGET';
+
+ALTER TYPE audit_entity_type ADD VALUE 'CEDAR_DEPLOYMENT';
+ALTER TYPE audit_entity_type ADD VALUE 'CEDAR_EXCHANGE';
+ALTER TYPE audit_entity_type ADD VALUE 'CEDAR_CONTRACT';
+ALTER TYPE audit_entity_type ADD VALUE 'CEDAR_BUDGET';
+ALTER TYPE audit_entity_type ADD VALUE 'CEDAR_URL';
+
+ALTER TABLE audit_changes ADD COLUMN IF NOT EXISTS external_id TEXT;
+
+COMMENT ON TABLE audit_changes IS 'Field level history of changes made to system intakes, business cases and TRB request forms, and of the changes made to CEDAR system profiles through EASi';
+COMMENT ON COLUMN audit_changes.external_id IS 'For entities kept outside of EASi, such as CEDAR records, the ID of the entity in that system. entity_id is then the EASi-facing ID the entity belongs to, such as the CEDAR system ID';
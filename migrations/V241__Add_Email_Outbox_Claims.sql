@@ -0,0 +1,3 @@
+ALTER TABLE email_outbox ADD COLUMN claim_id UUID;
+
+COMMENT ON COLUMN email_outbox.claim_id IS 'Identifies the dispatcher attempt that claimed the email to send it. While it is set, next_attempt_at is when the claim expires, after which another dispatcher may claim the email again';
@@ -0,0 +1,3 @@
+ALTER TABLE webhook_deliveries ADD COLUMN claim_id UUID;
+
+COMMENT ON COLUMN webhook_deliveries.claim_id IS 'Identifies the dispatcher attempt that claimed the delivery to send it. While it is set, next_attempt_at is when the claim expires, after which another dispatcher may claim the delivery again';
@@ -125,6 +125,13 @@ const AWSSESSourceARNKey = "AWS_SES_SOURCE_ARN"
 // AWSSESSourceKey is the key for the sender for sending email
 const AWSSESSourceKey = "AWS_SES_SOURCE"
 
+// AWSSESConfigurationSetKey is the key for the SES configuration set emails are sent with, which publishes their delivery, bounce, and complaint events
+const AWSSESConfigurationSetKey = "AWS_SES_CONFIGURATION_SET"
+
+// AWSSESNotificationTopicARNKey is the key for the ARN of the SNS topic SES publishes delivery, bounce, and complaint notifications to.
+// Notifications are only accepted from this topic, and aren't accepted at all if it's not set
+const AWSSESNotificationTopicARNKey = "AWS_SES_NOTIFICATION_TOPIC_ARN"
+
 // GRTEmailKey is the key for the receiving email for the GRT
 const GRTEmailKey = "GRT_EMAIL"
 
@@ -152,6 +159,22 @@ const ClientAddressKey = "CLIENT_ADDRESS"
 // EmailTemplateDirectoryKey is the key for getting the email template directory
 const EmailTemplateDirectoryKey = "EMAIL_TEMPLATE_DIR"
 
+// EmailReplyAddressKey is the key for the inbound address that replies to GRB discussion emails are sent to.
+// Replying to discussions by email is turned off when it isn't set
+const EmailReplyAddressKey = "EMAIL_REPLY_ADDRESS"
+
+// EmailReplyTokenSecretKey is the key for the secret that GRB discussion Reply-To addresses are signed with
+const EmailReplyTokenSecretKey = "EMAIL_REPLY_TOKEN_SECRET" // #nosec
+
+// AWSS3InboundEmailBucketKey is the key for the bucket SES stores emails received at the reply address in
+const AWSS3InboundEmailBucketKey = "AWS_S3_INBOUND_EMAIL_BUCKET"
+
+// AWSS3InboundEmailPrefixKey is the key for the prefix SES stores received emails under in the inbound email bucket
+const AWSS3InboundEmailPrefixKey = "AWS_S3_INBOUND_EMAIL_PREFIX"
+
+// LocalInboundEmailDirectoryKey is the key for the directory that stands in for the inbound email bucket locally
+const LocalInboundEmailDirectoryKey = "LOCAL_INBOUND_EMAIL_DIR"
+
 // SESRecipientAllowListRegexKey is the key for getting the regex that Email recipients (SES specifically) must match
 const SESRecipientAllowListRegexKey = "SES_RECIPIENT_ALLOWLIST_REGEX"
 
@@ -187,6 +210,40 @@ const CEDARCoreAPIVersion = "CEDAR_CORE_API_VERSION"
 // If set to false, real calls to the CEDAR Core API will be made
 const CEDARCoreMock = "CEDAR_CORE_MOCK"
 
+// CEDARCoreTimeoutSeconds is the key for how long a single attempt at a call to the CEDAR Core API can take
+const CEDARCoreTimeoutSeconds = "CEDAR_CORE_TIMEOUT_SECONDS"
+
+// CEDARCoreMaxRetries is the key for how many times a failed GET to the CEDAR Core API is retried; 0 turns off retries
+const CEDARCoreMaxRetries = "CEDAR_CORE_MAX_RETRIES"
+
+// CEDARCoreCircuitBreakerThreshold is the key for how many calls to the CEDAR Core API in a row can fail before calls fail fast
+const CEDARCoreCircuitBreakerThreshold = "CEDAR_CORE_CIRCUIT_BREAKER_THRESHOLD"
+
+// CEDARCoreCircuitBreakerCooldownSeconds is the key for how long calls to the CEDAR Core API fail fast before CEDAR is tried again
+const CEDARCoreCircuitBreakerCooldownSeconds = "CEDAR_CORE_CIRCUIT_BREAKER_COOLDOWN_SECONDS"
+
+// CEDARCoreCacheDisabled is the key for the environment variable that turns off caching of CEDAR Core API responses
+const CEDARCoreCacheDisabled = "CEDAR_CORE_CACHE_DISABLED"
+
+// CEDARCoreSystemSummaryCacheTTLSeconds is the key for how long cached CEDAR Core system summaries are fresh
+const CEDARCoreSystemSummaryCacheTTLSeconds = "CEDAR_CORE_SYSTEM_SUMMARY_CACHE_TTL_SECONDS"
+
+// CEDARCoreSystemDetailCacheTTLSeconds is the key for how long cached CEDAR Core system details are fresh
+const CEDARCoreSystemDetailCacheTTLSeconds = "CEDAR_CORE_SYSTEM_DETAIL_CACHE_TTL_SECONDS"
+
+// CEDARCoreRoleCacheTTLSeconds is the key for how long cached CEDAR Core role assignments are fresh
+const CEDARCoreRoleCacheTTLSeconds = "CEDAR_CORE_ROLE_CACHE_TTL_SECONDS"
+
+// CEDARCoreRoleTypeCacheTTLSeconds is the key for how long cached CEDAR Core role types are fresh
+const CEDARCoreRoleTypeCacheTTLSeconds = "CEDAR_CORE_ROLE_TYPE_CACHE_TTL_SECONDS"
+
+// CEDARCoreCacheTTLSeconds is the key for how long responses from the other cached CEDAR Core endpoints are fresh
+const CEDARCoreCacheTTLSeconds = "CEDAR_CORE_CACHE_TTL_SECONDS"
+
+// CEDARCoreCacheStaleSeconds is the key for how long after a cached CEDAR Core response stops being fresh it can still be used
+// while it's refreshed in the background
+const CEDARCoreCacheStaleSeconds = "CEDAR_CORE_CACHE_STALE_SECONDS"
+
 // CEDARIntakeEnabled is the key for the environment variable that determines if the CEDAR Intake API should enabled
 // If set to true, real calls to the CEDAR Intake API will be made
 // If set to false, the Intake API Client methods will do nothing
@@ -244,3 +301,17 @@ const OKTAAPIToken = "OKTA_API_TOKEN"
 
 // OktaLocalEnabled is the key for enabling OKTA on local dev
 const OktaLocalEnabled = "USE_OKTA_LOCAL"
+
+// PubSubBackendKey is the key for choosing which pubsub implementation delivers real-time subscription events
+const PubSubBackendKey = "PUBSUB_BACKEND"
+
+// PubSubBackendOption represents a pubsub implementation
+type PubSubBackendOption string
+
+const (
+	// PubSubBackendLocal is LOCAL, which only delivers events to subscribers connected to the same instance
+	PubSubBackendLocal PubSubBackendOption = "LOCAL"
+
+	// PubSubBackendPostgres is POSTGRES, which delivers events to subscribers on every instance using Postgres LISTEN/NOTIFY
+	PubSubBackendPostgres PubSubBackendOption = "POSTGRES"
+)
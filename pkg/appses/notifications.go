@@ -0,0 +1,109 @@
+package appses
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// sesNotification is a delivery, bounce, or complaint notification SES publishes to SNS, either as an identity notification,
+// which sets notificationType, or as a configuration set event, which sets eventType
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Mail             struct {
+		MessageID string `json:"messageId"`
+	} `json:"mail"`
+	Bounce *struct {
+		BounceType        string    `json:"bounceType"`
+		BounceSubType     string    `json:"bounceSubType"`
+		Timestamp         time.Time `json:"timestamp"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint *struct {
+		ComplaintFeedbackType string    `json:"complaintFeedbackType"`
+		Timestamp             time.Time `json:"timestamp"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+	Delivery *struct {
+		Timestamp    time.Time `json:"timestamp"`
+		Recipients   []string  `json:"recipients"`
+		SMTPResponse string    `json:"smtpResponse"`
+	} `json:"delivery"`
+}
+
+// ParseSESNotification returns the delivery events in the SES notification message, which was received in the SNS notification notificationID.
+// Notifications about anything other than deliveries, bounces, and complaints have no events
+func ParseSESNotification(notificationID string, message string) ([]*models.EmailDeliveryEvent, error) {
+	var notification sesNotification
+	if err := json.Unmarshal([]byte(message), &notification); err != nil {
+		return nil, fmt.Errorf("problem parsing SES notification: %w", err)
+	}
+
+	notificationType := notification.NotificationType
+	if notificationType == "" {
+		notificationType = notification.EventType
+	}
+	if notification.Mail.MessageID == "" {
+		return nil, fmt.Errorf("SES notification has no message ID")
+	}
+
+	newEvent := func(eventType models.EmailDeliveryEventType, recipient string, occurredAt time.Time) *models.EmailDeliveryEvent {
+		return &models.EmailDeliveryEvent{
+			NotificationID:    notificationID,
+			ProviderMessageID: notification.Mail.MessageID,
+			EventType:         eventType,
+			Recipient:         models.NewEmailAddress(strings.TrimSpace(recipient)),
+			OccurredAt:        occurredAt,
+		}
+	}
+
+	var events []*models.EmailDeliveryEvent
+	switch notificationType {
+	case "Bounce":
+		if notification.Bounce == nil {
+			return nil, fmt.Errorf("SES bounce notification has no bounce")
+		}
+		for _, recipient := range notification.Bounce.BouncedRecipients {
+			event := newEvent(models.EmailDeliveryEventTypeBounce, recipient.EmailAddress, notification.Bounce.Timestamp)
+			event.BounceType = optionalString(notification.Bounce.BounceType)
+			event.BounceSubType = optionalString(notification.Bounce.BounceSubType)
+			event.Diagnostic = optionalString(recipient.DiagnosticCode)
+			events = append(events, event)
+		}
+	case "Complaint":
+		if notification.Complaint == nil {
+			return nil, fmt.Errorf("SES complaint notification has no complaint")
+		}
+		for _, recipient := range notification.Complaint.ComplainedRecipients {
+			event := newEvent(models.EmailDeliveryEventTypeComplaint, recipient.EmailAddress, notification.Complaint.Timestamp)
+			event.Diagnostic = optionalString(notification.Complaint.ComplaintFeedbackType)
+			events = append(events, event)
+		}
+	case "Delivery":
+		if notification.Delivery == nil {
+			return nil, fmt.Errorf("SES delivery notification has no delivery")
+		}
+		for _, recipient := range notification.Delivery.Recipients {
+			event := newEvent(models.EmailDeliveryEventTypeDelivery, recipient, notification.Delivery.Timestamp)
+			event.Diagnostic = optionalString(notification.Delivery.SMTPResponse)
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
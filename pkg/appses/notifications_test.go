@@ -0,0 +1,78 @@
+package appses
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func TestParseSESNotification(t *testing.T) {
+	const messageID = "0100019438e4a1b2-5d1c7a8e-1f2b-4c3d-9e8f-0a1b2c3d4e5f-000000"
+
+	t.Run("parses bounces", func(t *testing.T) {
+		message := loadSNSFixture(t, "sns_bounce.json")
+		events, err := ParseSESNotification(message.MessageID, message.Message)
+		assert.NoError(t, err)
+
+		if assert.Len(t, events, 1) {
+			event := events[0]
+			assert.Equal(t, message.MessageID, event.NotificationID)
+			assert.Equal(t, messageID, event.ProviderMessageID)
+			assert.Equal(t, models.EmailDeliveryEventTypeBounce, event.EventType)
+			assert.Equal(t, models.EmailAddress("rock.lee@cms.fake"), event.Recipient)
+			assert.Equal(t, "Permanent", *event.BounceType)
+			assert.Equal(t, "General", *event.BounceSubType)
+			assert.Equal(t, "smtp; 550 5.1.1 user unknown", *event.Diagnostic)
+			assert.Equal(t, time.Date(2025, 1, 6, 14, 0, 2, 0, time.UTC), event.OccurredAt.UTC())
+		}
+	})
+
+	t.Run("parses complaints published as configuration set events", func(t *testing.T) {
+		message := loadSNSFixture(t, "sns_complaint.json")
+		events, err := ParseSESNotification(message.MessageID, message.Message)
+		assert.NoError(t, err)
+
+		if assert.Len(t, events, 1) {
+			assert.Equal(t, models.EmailDeliveryEventTypeComplaint, events[0].EventType)
+			assert.Equal(t, models.EmailAddress("audrey.abrams@cms.fake"), events[0].Recipient)
+			assert.Equal(t, "abuse", *events[0].Diagnostic)
+			assert.Nil(t, events[0].BounceType)
+		}
+	})
+
+	t.Run("parses a delivery event for each recipient", func(t *testing.T) {
+		message := loadSNSFixture(t, "sns_delivery.json")
+		events, err := ParseSESNotification(message.MessageID, message.Message)
+		assert.NoError(t, err)
+
+		if assert.Len(t, events, 2) {
+			for _, event := range events {
+				assert.Equal(t, models.EmailDeliveryEventTypeDelivery, event.EventType)
+				assert.Equal(t, messageID, event.ProviderMessageID)
+				assert.Equal(t, "250 2.0.0 OK", *event.Diagnostic)
+			}
+			assert.Equal(t, models.EmailAddress("rock.lee@cms.fake"), events[0].Recipient)
+			assert.Equal(t, models.EmailAddress("audrey.abrams@cms.fake"), events[1].Recipient)
+		}
+	})
+
+	t.Run("ignores other notifications", func(t *testing.T) {
+		events, err := ParseSESNotification("id", `{"eventType":"Open","mail":{"messageId":"abc"},"open":{}}`)
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("rejects malformed notifications", func(t *testing.T) {
+		for _, message := range []string{
+			`not json`,
+			`{"notificationType":"Bounce","bounce":{}}`,
+			`{"notificationType":"Bounce","mail":{"messageId":"abc"}}`,
+		} {
+			_, err := ParseSESNotification("id", message)
+			assert.Error(t, err, message)
+		}
+	})
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ses"
 	"github.com/aws/aws-sdk-go-v2/service/ses/types"
+	jwemail "github.com/jordan-wright/email"
 	"github.com/samber/lo"
 
 	"github.com/cms-enterprise/easi-app/pkg/appconfig"
@@ -22,6 +25,8 @@ type Config struct {
 	SourceARN               string
 	Source                  string
 	RecipientAllowListRegex *regexp.Regexp // a regex that a recipient must match in order to be sent to
+	// ConfigurationSetName is the configuration set emails are sent with, which publishes their delivery events. Optional
+	ConfigurationSetName string
 }
 
 // Sender is an implementation for sending email with the SES Go SDK
@@ -58,6 +63,13 @@ func filterAddresses(emails []models.EmailAddress, regex *regexp.Regexp) []model
 
 // Send sends an email. It will only return an error if there's an error connecting to SES; an invalid address/bounced email will *not* return an error.
 func (s Sender) Send(ctx context.Context, emailData email.Email) error {
+	_, err := s.SendTracked(ctx, emailData)
+	return err
+}
+
+// SendTracked sends an email, returning the ID SES gave it, which SES's delivery, bounce, and complaint notifications refer to it by.
+// No ID is returned if the email wasn't sent because none of its recipients are allowed
+func (s Sender) SendTracked(ctx context.Context, emailData email.Email) (string, error) {
 	// Filter out any addresses that don't match the configured regex
 	// If the env var that populates this is empty (""), everything will be allowed through (since the regex "" matches all strings)
 	emailData.ToAddresses = filterAddresses(emailData.ToAddresses, s.config.RecipientAllowListRegex)
@@ -67,10 +79,14 @@ func (s Sender) Send(ctx context.Context, emailData email.Email) error {
 	// Don't send an email if there are no recipients (post-filter)
 	if len(emailData.ToAddresses) == 0 && len(emailData.CcAddresses) == 0 && len(emailData.BccAddresses) == 0 {
 		appcontext.ZLogger(ctx).Warn("attempted to send an email with no recipients")
-		return nil
+		return "", nil
 	}
-	input := &ses.SendEmailInput{
 
+	if len(emailData.Attachments) > 0 {
+		return s.sendRaw(ctx, emailData)
+	}
+
+	input := &ses.SendEmailInput{
 		Destination: &types.Destination{
 			ToAddresses:  models.EmailAddressesToStrings(emailData.ToAddresses),
 			CcAddresses:  models.EmailAddressesToStrings(emailData.CcAddresses),
@@ -91,6 +107,55 @@ func (s Sender) Send(ctx context.Context, emailData email.Email) error {
 		Source:    &s.config.Source,
 		SourceArn: &s.config.SourceARN,
 	}
-	_, err := s.client.SendEmail(ctx, input)
-	return err
+	if emailData.ReplyToAddress != "" {
+		input.ReplyToAddresses = []string{emailData.ReplyToAddress.String()}
+	}
+	if s.config.ConfigurationSetName != "" {
+		input.ConfigurationSetName = &s.config.ConfigurationSetName
+	}
+	output, err := s.client.SendEmail(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(output.MessageId), nil
+}
+
+// sendRaw sends an email as a raw MIME message, which SES requires for emails with attachments
+func (s Sender) sendRaw(ctx context.Context, emailData email.Email) (string, error) {
+	message := jwemail.Email{
+		From:    s.config.Source,
+		To:      models.EmailAddressesToStrings(emailData.ToAddresses),
+		Cc:      models.EmailAddressesToStrings(emailData.CcAddresses),
+		Subject: email.AddNonProdEnvToSubject(emailData.Subject, s.environment),
+		HTML:    []byte(emailData.Body),
+	}
+	if emailData.ReplyToAddress != "" {
+		message.ReplyTo = []string{emailData.ReplyToAddress.String()}
+	}
+	if err := email.AttachToMessage(&message, emailData.Attachments); err != nil {
+		return "", err
+	}
+
+	// BCC recipients are left out of the message's headers, and are only given as destinations
+	raw, err := message.Bytes()
+	if err != nil {
+		return "", err
+	}
+
+	input := &ses.SendRawEmailInput{
+		Destinations: models.EmailAddressesToStrings(slices.Concat(emailData.ToAddresses, emailData.CcAddresses, emailData.BccAddresses)),
+		RawMessage: &types.RawMessage{
+			Data: raw,
+		},
+		Source:    &s.config.Source,
+		SourceArn: &s.config.SourceARN,
+	}
+	if s.config.ConfigurationSetName != "" {
+		input.ConfigurationSetName = &s.config.ConfigurationSetName
+	}
+	output, err := s.client.SendRawEmail(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(output.MessageId), nil
 }
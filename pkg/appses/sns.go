@@ -0,0 +1,224 @@
+package appses
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec // SNS signs version 1 messages with SHA1
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// These are the types of messages SNS posts to an HTTP subscription
+const (
+	SNSMessageTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	SNSMessageTypeNotification             = "Notification"
+	SNSMessageTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// ErrInvalidSNSMessage is returned for messages that can't be verified as coming from SNS
+var ErrInvalidSNSMessage = errors.New("invalid SNS message")
+
+// snsHostPattern matches the hosts SNS's signing certificates and subscription confirmation URLs are served from
+var snsHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// maxSNSCertificateSize limits how much of a signing certificate is downloaded
+const maxSNSCertificateSize = 64 << 10
+
+// SNSMessage is a message SNS posts to an HTTP subscription
+type SNSMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicARN         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL"`
+}
+
+// stringToSign returns the text SNS signed the message by signing, which is a set of the message's fields, depending on its type
+func (m *SNSMessage) stringToSign() string {
+	var fields [][2]string
+	switch m.Type {
+	case SNSMessageTypeNotification:
+		fields = [][2]string{{"Message", m.Message}, {"MessageId", m.MessageID}}
+		if m.Subject != "" {
+			fields = append(fields, [2]string{"Subject", m.Subject})
+		}
+		fields = append(fields, [][2]string{{"Timestamp", m.Timestamp}, {"TopicArn", m.TopicARN}, {"Type", m.Type}}...)
+	default:
+		fields = [][2]string{
+			{"Message", m.Message},
+			{"MessageId", m.MessageID},
+			{"SubscribeURL", m.SubscribeURL},
+			{"Timestamp", m.Timestamp},
+			{"Token", m.Token},
+			{"TopicArn", m.TopicARN},
+			{"Type", m.Type},
+		}
+	}
+
+	var builder strings.Builder
+	for _, field := range fields {
+		builder.WriteString(field[0] + "\n" + field[1] + "\n")
+	}
+	return builder.String()
+}
+
+// SNSVerifier checks that messages were sent by SNS, from the topic EASi is subscribed to
+type SNSVerifier struct {
+	topicARN         string
+	httpClient       *http.Client
+	fetchCertificate func(ctx context.Context, certURL string) (*x509.Certificate, error)
+	certificates     sync.Map
+}
+
+// NewSNSVerifier returns a verifier for messages from the SNS topic topicARN
+func NewSNSVerifier(topicARN string) *SNSVerifier {
+	verifier := &SNSVerifier{
+		topicARN:   topicARN,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	verifier.fetchCertificate = verifier.downloadCertificate
+	return verifier
+}
+
+// Verify checks that a message is from the verifier's topic, and is signed by SNS.
+// It returns an error wrapping ErrInvalidSNSMessage if it isn't
+func (v *SNSVerifier) Verify(ctx context.Context, message *SNSMessage) error {
+	if message.TopicARN != v.topicARN {
+		return fmt.Errorf("%w: message is from unexpected topic %q", ErrInvalidSNSMessage, message.TopicARN)
+	}
+
+	var hash crypto.Hash
+	switch message.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return fmt.Errorf("%w: unsupported signature version %q", ErrInvalidSNSMessage, message.SignatureVersion)
+	}
+
+	if err := validateSNSURL(message.SigningCertURL); err != nil {
+		return fmt.Errorf("%w: signing certificate %w", ErrInvalidSNSMessage, err)
+	}
+	if !strings.HasSuffix(message.SigningCertURL, ".pem") {
+		return fmt.Errorf("%w: signing certificate URL is not a certificate", ErrInvalidSNSMessage)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(message.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: signature is not base64: %w", ErrInvalidSNSMessage, err)
+	}
+
+	certificate, err := v.certificate(ctx, message.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: signing certificate does not have an RSA key", ErrInvalidSNSMessage)
+	}
+
+	if err := rsa.VerifyPKCS1v15(publicKey, hash, digest(hash, message.stringToSign()), signature); err != nil {
+		return fmt.Errorf("%w: signature does not match: %w", ErrInvalidSNSMessage, err)
+	}
+	return nil
+}
+
+// ConfirmSubscription confirms EASi's subscription to the topic a verified SubscriptionConfirmation message is from
+func (v *SNSVerifier) ConfirmSubscription(ctx context.Context, message *SNSMessage) error {
+	if err := validateSNSURL(message.SubscribeURL); err != nil {
+		return fmt.Errorf("%w: subscription confirmation %w", ErrInvalidSNSMessage, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, message.SubscribeURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("problem confirming SNS subscription: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("problem confirming SNS subscription: SNS responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// certificate returns the signing certificate at certURL, which is downloaded the first time it's used
+func (v *SNSVerifier) certificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	if cached, ok := v.certificates.Load(certURL); ok {
+		return cached.(*x509.Certificate), nil
+	}
+
+	certificate, err := v.fetchCertificate(ctx, certURL)
+	if err != nil {
+		return nil, err
+	}
+	v.certificates.Store(certURL, certificate)
+	return certificate, nil
+}
+
+// downloadCertificate downloads and parses the PEM encoded certificate at certURL
+func (v *SNSVerifier) downloadCertificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("problem downloading SNS signing certificate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("problem downloading SNS signing certificate: SNS responded with %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSNSCertificateSize))
+	if err != nil {
+		return nil, fmt.Errorf("problem downloading SNS signing certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(body)
+	if block == nil {
+		return nil, fmt.Errorf("%w: signing certificate is not PEM encoded", ErrInvalidSNSMessage)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// validateSNSURL checks that a URL in a message is served by SNS, so messages can't make EASi request other URLs
+func validateSNSURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || !snsHostPattern.MatchString(parsed.Host) {
+		return fmt.Errorf("URL %q is not an SNS URL", rawURL)
+	}
+	return nil
+}
+
+func digest(hash crypto.Hash, text string) []byte {
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum([]byte(text)) //nolint:gosec // SNS signs version 1 messages with SHA1
+		return sum[:]
+	}
+	sum := sha256.Sum256([]byte(text))
+	return sum[:]
+}
@@ -0,0 +1,151 @@
+package appses
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTopicARN = "arn:aws:sns:us-east-1:123456789012:easi-ses-notifications"
+
+// snsSigner signs fixtures the way SNS does, with a key generated for the test
+type snsSigner struct {
+	key         *rsa.PrivateKey
+	certificate *x509.Certificate
+}
+
+func newSNSSigner(t *testing.T) *snsSigner {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	return &snsSigner{key: key, certificate: certificate}
+}
+
+func (s *snsSigner) sign(t *testing.T, message *SNSMessage) {
+	hash := crypto.SHA1
+	if message.SignatureVersion == "2" {
+		hash = crypto.SHA256
+	}
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, hash, digest(hash, message.stringToSign()))
+	assert.NoError(t, err)
+	message.Signature = base64.StdEncoding.EncodeToString(signature)
+}
+
+// verifier returns a verifier that trusts the signer's certificate, counting how many times it's fetched
+func (s *snsSigner) verifier(fetches *int) *SNSVerifier {
+	verifier := NewSNSVerifier(testTopicARN)
+	verifier.fetchCertificate = func(ctx context.Context, certURL string) (*x509.Certificate, error) {
+		*fetches++
+		return s.certificate, nil
+	}
+	return verifier
+}
+
+// loadSNSFixture loads a message recorded from SNS, from the testdata directory
+func loadSNSFixture(t *testing.T, name string) *SNSMessage {
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	assert.NoError(t, err)
+
+	var message SNSMessage
+	assert.NoError(t, json.Unmarshal(raw, &message))
+	return &message
+}
+
+func TestSNSVerifier(t *testing.T) {
+	ctx := context.Background()
+	signer := newSNSSigner(t)
+
+	t.Run("verifies signed notifications and subscription confirmations", func(t *testing.T) {
+		fetches := 0
+		verifier := signer.verifier(&fetches)
+
+		for _, fixture := range []string{"sns_bounce.json", "sns_complaint.json", "sns_delivery.json", "sns_subscription_confirmation.json"} {
+			message := loadSNSFixture(t, fixture)
+			signer.sign(t, message)
+			assert.NoError(t, verifier.Verify(ctx, message), fixture)
+		}
+
+		// the signing certificate is only downloaded once
+		assert.Equal(t, 1, fetches)
+	})
+
+	t.Run("verifies version 2 signatures", func(t *testing.T) {
+		fetches := 0
+		message := loadSNSFixture(t, "sns_bounce.json")
+		message.SignatureVersion = "2"
+		signer.sign(t, message)
+		assert.NoError(t, signer.verifier(&fetches).Verify(ctx, message))
+	})
+
+	t.Run("rejects invalid messages", func(t *testing.T) {
+		otherSigner := newSNSSigner(t)
+
+		testCases := map[string]func(message *SNSMessage){
+			"changed after signing": func(message *SNSMessage) {
+				signer.sign(t, message)
+				message.Message = `{"notificationType":"Delivery"}`
+			},
+			"signed by another key": func(message *SNSMessage) {
+				otherSigner.sign(t, message)
+			},
+			"from another topic": func(message *SNSMessage) {
+				message.TopicARN = "arn:aws:sns:us-east-1:123456789012:another-topic"
+				signer.sign(t, message)
+			},
+			"with a certificate from another host": func(message *SNSMessage) {
+				message.SigningCertURL = "https://sns.us-east-1.amazonaws.com.attacker.fake/cert.pem"
+				signer.sign(t, message)
+			},
+			"with a certificate served over HTTP": func(message *SNSMessage) {
+				message.SigningCertURL = "http://sns.us-east-1.amazonaws.com/cert.pem"
+				signer.sign(t, message)
+			},
+			"with an unknown signature version": func(message *SNSMessage) {
+				message.SignatureVersion = "3"
+				signer.sign(t, message)
+			},
+			"with an unsigned message": func(message *SNSMessage) {
+				message.Signature = ""
+			},
+		}
+
+		for name, modify := range testCases {
+			t.Run(name, func(t *testing.T) {
+				fetches := 0
+				message := loadSNSFixture(t, "sns_delivery.json")
+				modify(message)
+				assert.ErrorIs(t, signer.verifier(&fetches).Verify(ctx, message), ErrInvalidSNSMessage)
+			})
+		}
+	})
+
+	t.Run("only confirms subscriptions from SNS", func(t *testing.T) {
+		fetches := 0
+		message := loadSNSFixture(t, "sns_subscription_confirmation.json")
+		message.SubscribeURL = "https://internal.cms.fake/admin"
+		assert.ErrorIs(t, signer.verifier(&fetches).ConfirmSubscription(ctx, message), ErrInvalidSNSMessage)
+	})
+}
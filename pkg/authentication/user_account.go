@@ -2,6 +2,7 @@ package authentication
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -17,6 +18,10 @@ type UserAccount struct {
 	FamilyName  string    `json:"family_name" db:"family_name"`
 	ZoneInfo    string    `json:"zoneinfo" db:"zone_info"`
 	HasLoggedIn bool      `json:"hasLoggedIn" db:"has_logged_in"`
+	// EmailBounceCount is the number of emails to the user that have bounced since one was last delivered to them
+	EmailBounceCount int `json:"emailBounceCount" db:"email_bounce_count"`
+	// EmailUndeliverableAt is when emails to the user started bouncing repeatedly, if they have
+	EmailUndeliverableAt *time.Time `json:"emailUndeliverableAt" db:"email_undeliverable_at"`
 }
 
 // GetUserAccountFromDBFunc defines a function that returns a user account from the database
@@ -31,6 +31,13 @@ func (c *Client) GetAuthorityToOperate(ctx context.Context, cedarSystemID uuid.U
 		return nil, cedarcoremock.NoSystemFoundError()
 	}
 
+	return cachedRead(ctx, c.cache, cacheKey{endpoint: cacheEndpointATO, systemID: cedarSystemID}, func(ctx context.Context) ([]*models.CedarAuthorityToOperate, error) {
+		return c.fetchAuthorityToOperate(ctx, cedarSystemID)
+	})
+}
+
+// fetchAuthorityToOperate calls CEDAR for a system's ATOs
+func (c *Client) fetchAuthorityToOperate(ctx context.Context, cedarSystemID uuid.UUID) ([]*models.CedarAuthorityToOperate, error) {
 	// Construct the parameters
 	params := apiauthority.NewAuthorityToOperateFindListParams()
 	params.SetSystemID(helpers.PointerTo(formatIDForCEDAR(cedarSystemID)))
@@ -2,13 +2,17 @@ package cedarcore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/client/budget"
+	apimodels "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/models"
 	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/local/cedarcoremock"
 	"github.com/cms-enterprise/easi-app/pkg/models"
@@ -19,11 +23,38 @@ func (c *Client) GetBudgetBySystem(ctx context.Context, cedarSystemID uuid.UUID)
 	if c.mockEnabled {
 		appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
 		if cedarcoremock.IsMockSystem(cedarSystemID) {
-			return cedarcoremock.GetBudgets(), nil
+			return cedarcoremock.GetBudgets(cedarSystemID), nil
 		}
 		return nil, cedarcoremock.NoSystemFoundError()
 	}
 
+	return cachedRead(ctx, c.cache, cacheKey{endpoint: cacheEndpointBudget, systemID: cedarSystemID}, func(ctx context.Context) ([]*models.CedarBudget, error) {
+		return c.fetchBudgetBySystem(ctx, cedarSystemID)
+	})
+}
+
+// fetchBudgetBySystem calls CEDAR for a system's budgets
+func (c *Client) fetchBudgetBySystem(ctx context.Context, cedarSystemID uuid.UUID) ([]*models.CedarBudget, error) {
+	cedarBudgets, err := c.fetchCEDARBudgets(cedarSystemID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the auto-generated struct to our own pkg/models struct
+	retVal := make([]*models.CedarBudget, 0, len(cedarBudgets))
+
+	for _, budget := range cedarBudgets {
+		retBudget, err := budgetFromCEDAR(budget)
+		if err != nil {
+			return nil, err
+		}
+		retVal = append(retVal, retBudget)
+	}
+	return retVal, nil
+}
+
+// fetchCEDARBudgets calls CEDAR for a system's budgets, returning them as CEDAR sent them
+func (c *Client) fetchCEDARBudgets(cedarSystemID uuid.UUID) ([]*apimodels.Budget, error) {
 	params := budget.NewBudgetFindParams()
 
 	// Construct the parameters
@@ -36,26 +67,215 @@ func (c *Client) GetBudgetBySystem(ctx context.Context, cedarSystemID uuid.UUID)
 		return nil, err
 	}
 
-	// Convert the auto-generated struct to our own pkg/models struct
-	retVal := make([]*models.CedarBudget, 0, len(resp.Payload.Budgets))
+	if resp.Payload == nil {
+		return nil, fmt.Errorf("no body received")
+	}
+
+	return resp.Payload.Budgets, nil
+}
+
+// budgetFromCEDAR converts a budget from the auto-generated struct to our own pkg/models struct
+func budgetFromCEDAR(budget *apimodels.Budget) (*models.CedarBudget, error) {
+	parsedUUID, err := uuid.Parse(budget.SystemID)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing system id: %w", err)
+	}
 
-	for _, budget := range resp.Payload.Budgets {
-		parsedUUID, err := uuid.Parse(budget.SystemID)
+	return &models.CedarBudget{
+		FiscalYear:    zero.StringFrom(budget.FiscalYear),
+		Funding:       zero.StringFrom(budget.Funding),
+		FundingID:     zero.StringFrom(budget.FundingID),
+		FundingSource: zero.StringFrom(budget.FundingSource),
+		ID:            zero.StringFrom(budget.ID),
+		Name:          zero.StringFrom(budget.Name),
+		ProjectID:     zero.StringFromPtr(budget.ProjectID),
+		ProjectTitle:  zero.StringFrom(budget.ProjectTitle),
+		SystemID:      &parsedUUID,
+	}, nil
+}
+
+// AddBudget makes a POST call to the /budget endpoint to add a budget to a system, and returns the budget as it was sent.
+// CEDAR assigns the new budget's ID, so any ID on the budget is ignored; CEDAR doesn't return the ID, so the returned budget
+// only has one when CEDAR is mocked.
+func (c *Client) AddBudget(ctx context.Context, cedarSystemID uuid.UUID, cedarBudget *models.CedarBudget) (*models.CedarBudget, error) {
+	// the caller's budget is copied rather than modified
+	added := *cedarBudget
+	added.ID = zero.StringFrom("")
+	added.SystemID = &cedarSystemID
+
+	return writeAndEvict(c, budgetsEviction(cedarSystemID), func() (*models.CedarBudget, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if !cedarcoremock.IsMockSystem(cedarSystemID) {
+				return nil, cedarcoremock.NoSystemFoundError()
+			}
+			return cedarcoremock.AddBudget(cedarSystemID, &added), nil
+		}
+
+		params := budget.NewBudgetAddParams()
+		params.SetBudgetAddRequest(&apimodels.BudgetAddRequest{
+			Budgets: []*apimodels.Budget{budgetToCEDAR(cedarSystemID, &added, nil)},
+		})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Budget.BudgetAdd(params, c.auth)
 		if err != nil {
-			return nil, fmt.Errorf("problem parsing system id: %w", err)
-		}
-
-		retVal = append(retVal, &models.CedarBudget{
-			FiscalYear:    zero.StringFrom(budget.FiscalYear),
-			Funding:       zero.StringFrom(budget.Funding),
-			FundingID:     zero.StringFrom(budget.FundingID),
-			FundingSource: zero.StringFrom(budget.FundingSource),
-			ID:            zero.StringFrom(budget.ID),
-			Name:          zero.StringFrom(budget.Name),
-			ProjectID:     zero.StringFromPtr(budget.ProjectID),
-			ProjectTitle:  zero.StringFrom(budget.ProjectTitle),
-			SystemID:      &parsedUUID,
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
+		}
+
+		return &added, nil
+	})
+}
+
+// UpdateBudget makes a PUT call to the /budget endpoint to change one of a system's budgets, and returns the updated budget.
+// update is applied to a copy of the budget as it currently is in CEDAR. concurrencyToken is the token of the budget the change was
+// based on; if the budget has changed in CEDAR since, the update is rejected with a ResourceConflictError.
+func (c *Client) UpdateBudget(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	budgetID string,
+	concurrencyToken string,
+	update func(cedarBudget *models.CedarBudget),
+) (*models.CedarBudget, error) {
+	current, cedarBudget, err := c.currentBudget(ctx, cedarSystemID, budgetID, concurrencyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// the current budget may be shared with other readers, so it's copied rather than modified
+	updated := *current
+	update(&updated)
+	updated.ID = current.ID
+	updated.SystemID = &cedarSystemID
+
+	return writeAndEvict(c, budgetsEviction(cedarSystemID), func() (*models.CedarBudget, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if err := cedarcoremock.UpdateBudget(cedarSystemID, &updated); err != nil {
+				return nil, err
+			}
+			return &updated, nil
+		}
+
+		params := budget.NewBudgetUpdateParams()
+		params.SetBudgetUpdateRequest(&apimodels.BudgetUpdateRequest{
+			Budgets: []*apimodels.Budget{budgetToCEDAR(cedarSystemID, &updated, cedarBudget)},
 		})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Budget.BudgetUpdate(params, c.auth)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
+		}
+
+		return &updated, nil
+	})
+}
+
+// DeleteBudget makes a DELETE call to the /budget endpoint to remove one of a system's budgets, and returns the removed budget.
+// concurrencyToken is the token of the budget the caller read; if the budget has changed in CEDAR since, the delete is rejected with
+// a ResourceConflictError.
+func (c *Client) DeleteBudget(ctx context.Context, cedarSystemID uuid.UUID, budgetID string, concurrencyToken string) (*models.CedarBudget, error) {
+	deleted, _, err := c.currentBudget(ctx, cedarSystemID, budgetID, concurrencyToken)
+	if err != nil {
+		return nil, err
 	}
-	return retVal, nil
+
+	return writeAndEvict(c, budgetsEviction(cedarSystemID), func() (*models.CedarBudget, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if err := cedarcoremock.DeleteBudgets(cedarSystemID, []string{budgetID}); err != nil {
+				return nil, err
+			}
+			return deleted, nil
+		}
+
+		params := budget.NewBudgetDeleteListParams()
+		params.SetID([]string{budgetID})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Budget.BudgetDeleteList(params, c.auth)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
+		}
+
+		return deleted, nil
+	})
+}
+
+// currentBudget fetches one of a system's budgets straight from CEDAR, bypassing the cache, and checks that it still matches concurrencyToken.
+// The budget is also returned as CEDAR sent it (unless CEDAR is mocked), so the fields EASi doesn't read are kept when it's written back.
+func (c *Client) currentBudget(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	budgetID string,
+	concurrencyToken string,
+) (*models.CedarBudget, *apimodels.Budget, error) {
+	var retBudget *models.CedarBudget
+	var cedarBudget *apimodels.Budget
+
+	if c.mockEnabled {
+		budgets, err := c.GetBudgetBySystem(ctx, cedarSystemID)
+		if err != nil {
+			return nil, nil, err
+		}
+		retBudget, _ = lo.Find(budgets, func(cedarBudget *models.CedarBudget) bool {
+			return cedarBudget.ID.String == budgetID
+		})
+	} else {
+		cedarBudgets, err := c.fetchCEDARBudgets(cedarSystemID)
+		if err != nil {
+			return nil, nil, err
+		}
+		cedarBudget, _ = lo.Find(cedarBudgets, func(cedarBudget *apimodels.Budget) bool {
+			return cedarBudget.ID == budgetID
+		})
+		if cedarBudget != nil {
+			if retBudget, err = budgetFromCEDAR(cedarBudget); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if budgetID == "" || retBudget == nil {
+		return nil, nil, &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no budget found"), Resource: models.CedarBudget{}}
+	}
+
+	if retBudget.ConcurrencyToken() != concurrencyToken {
+		return nil, nil, &apperrors.ResourceConflictError{
+			Err:        errors.New("the budget has changed since it was read"),
+			Resource:   models.CedarBudget{},
+			ResourceID: budgetID,
+		}
+	}
+
+	return retBudget, cedarBudget, nil
+}
+
+// budgetToCEDAR converts a budget into the auto-generated struct CEDAR accepts. The fields EASi doesn't read, and the funding IDs it
+// doesn't edit, are copied from base, if it's given.
+func budgetToCEDAR(cedarSystemID uuid.UUID, cedarBudget *models.CedarBudget, base *apimodels.Budget) *apimodels.Budget {
+	retBudget := &apimodels.Budget{}
+	if base != nil {
+		*retBudget = *base
+	}
+
+	retBudget.ID = cedarBudget.ID.String
+	retBudget.SystemID = formatIDForCEDAR(cedarSystemID)
+	retBudget.ProjectID = lo.ToPtr(cedarBudget.ProjectID.String)
+	retBudget.ProjectTitle = cedarBudget.ProjectTitle.String
+	retBudget.Name = cedarBudget.Name.String
+	retBudget.FundingSource = cedarBudget.FundingSource.String
+	retBudget.Funding = cedarBudget.Funding.String
+
+	return retBudget
 }
@@ -30,6 +30,13 @@ func (c *Client) GetBudgetSystemCostBySystem(ctx context.Context, cedarSystemID
 		return nil, cedarcoremock.NoSystemFoundError()
 	}
 
+	return cachedRead(ctx, c.cache, cacheKey{endpoint: cacheEndpointBudgetSystemCost, systemID: cedarSystemID}, func(ctx context.Context) (*models.CedarBudgetSystemCost, error) {
+		return c.fetchBudgetSystemCostBySystem(ctx, cedarSystemID)
+	})
+}
+
+// fetchBudgetSystemCostBySystem calls CEDAR for a system's budget system cost
+func (c *Client) fetchBudgetSystemCostBySystem(ctx context.Context, cedarSystemID uuid.UUID) (*models.CedarBudgetSystemCost, error) {
 	params := budget_system_cost.NewBudgetSystemCostFindParams()
 
 	// Construct the parameters
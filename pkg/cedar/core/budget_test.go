@@ -0,0 +1,135 @@
+package cedarcore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	apimodels "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/models"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+type BudgetTestSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func TestBudgetTestSuite(t *testing.T) {
+	tests := &BudgetTestSuite{
+		Suite:  suite.Suite{},
+		logger: zap.NewNop(),
+	}
+	suite.Run(t, tests)
+}
+
+func (s *BudgetTestSuite) TestMockedBudgetWrites() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+	c := NewClient(ctx, "fake", "fake", "1.0.0", true, TransportConfig{}, CacheConfig{})
+	cedarSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC3D}")
+	otherSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC4E}")
+
+	before, err := c.GetBudgetBySystem(ctx, cedarSystemID)
+	s.NoError(err)
+
+	findBudget := func(projectID string) *models.CedarBudget {
+		budgets, err := c.GetBudgetBySystem(ctx, cedarSystemID)
+		s.NoError(err)
+		budget, _ := lo.Find(budgets, func(budget *models.CedarBudget) bool {
+			return budget.ProjectID.String == projectID
+		})
+		return budget
+	}
+
+	s.Run("a budget can be added", func() {
+		added, err := c.AddBudget(ctx, cedarSystemID, &models.CedarBudget{
+			ProjectID: zero.StringFrom("67890"),
+			Funding:   zero.StringFrom("Most of this funding is directly and only for this system (over 80%)"),
+		})
+		s.NoError(err)
+
+		budget := findBudget("67890")
+		s.NotNil(budget)
+		s.NotEmpty(budget.ID.String)
+		s.Equal(added.ID, budget.ID)
+		s.Equal(cedarSystemID, *budget.SystemID)
+
+		otherBudgets, err := c.GetBudgetBySystem(ctx, otherSystemID)
+		s.NoError(err)
+		s.Len(otherBudgets, len(before))
+	})
+
+	s.Run("a budget can be updated using its current concurrency token", func() {
+		budget := findBudget("67890")
+		_, err := c.UpdateBudget(ctx, cedarSystemID, budget.ID.String, budget.ConcurrencyToken(), func(budget *models.CedarBudget) {
+			budget.Funding = zero.StringFrom("Only part of this funding is directly for this system (less than 40%)")
+		})
+		s.NoError(err)
+
+		updated := findBudget("67890")
+		s.Equal(budget.ID, updated.ID)
+		s.EqualValues("Only part of this funding is directly for this system (less than 40%)", updated.Funding.String)
+		s.NotEqual(budget.ConcurrencyToken(), updated.ConcurrencyToken())
+	})
+
+	s.Run("updates based on an outdated budget are rejected", func() {
+		budget := findBudget("67890")
+		staleToken := (&models.CedarBudget{ID: budget.ID, ProjectID: budget.ProjectID}).ConcurrencyToken()
+
+		_, err := c.UpdateBudget(ctx, cedarSystemID, budget.ID.String, staleToken, func(budget *models.CedarBudget) {
+			budget.Funding = zero.StringFrom("")
+		})
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+
+		_, err = c.DeleteBudget(ctx, cedarSystemID, budget.ID.String, staleToken)
+		s.ErrorAs(err, &conflictErr)
+		s.NotNil(findBudget("67890"))
+	})
+
+	s.Run("budgets that aren't on the system can't be changed", func() {
+		_, err := c.DeleteBudget(ctx, cedarSystemID, "not-a-budget", "token")
+		var notFoundErr *apperrors.ResourceNotFoundError
+		s.ErrorAs(err, &notFoundErr)
+	})
+
+	s.Run("a budget can be deleted", func() {
+		budget := findBudget("67890")
+		_, err := c.DeleteBudget(ctx, cedarSystemID, budget.ID.String, budget.ConcurrencyToken())
+		s.NoError(err)
+		s.Nil(findBudget("67890"))
+
+		after, err := c.GetBudgetBySystem(ctx, cedarSystemID)
+		s.NoError(err)
+		s.Len(after, len(before))
+	})
+}
+
+func (s *BudgetTestSuite) TestBudgetToCEDARKeepsFieldsEASiDoesNotEdit() {
+	cedarSystemID := uuid.New()
+	base := &apimodels.Budget{
+		ID:           "485f8040-b008-4ad2-9b49-bd5fdf79a45c",
+		FundingID:    "b9b5568a-6ef5-4a7f-94e2-5bfc76ffd4a3",
+		FiscalYear:   "2023",
+		ProjectID:    lo.ToPtr("12345"),
+		ProjectTitle: "Budget X",
+	}
+	budget, err := budgetFromCEDAR(&apimodels.Budget{ID: base.ID, ProjectID: base.ProjectID, ProjectTitle: base.ProjectTitle, SystemID: cedarSystemID.String()})
+	s.NoError(err)
+	budget.Funding = zero.StringFrom("Most of this funding is directly and only for this system (over 80%)")
+
+	cedarBudget := budgetToCEDAR(cedarSystemID, budget, base)
+	s.Equal(base.ID, cedarBudget.ID)
+	s.Equal(formatIDForCEDAR(cedarSystemID), cedarBudget.SystemID)
+	s.Equal("12345", *cedarBudget.ProjectID)
+	s.Equal("Budget X", cedarBudget.ProjectTitle)
+	s.Equal(budget.Funding.String, cedarBudget.Funding)
+	s.Equal(base.FundingID, cedarBudget.FundingID)
+	s.Equal(base.FiscalYear, cedarBudget.FiscalYear)
+}
@@ -0,0 +1,315 @@
+package cedarcore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+const (
+	defaultSystemSummaryCacheTTL = 15 * time.Minute
+	defaultSystemDetailCacheTTL  = 10 * time.Minute
+	defaultRoleCacheTTL          = 5 * time.Minute
+	defaultRoleTypeCacheTTL      = 24 * time.Hour
+	defaultCacheTTL              = 10 * time.Minute
+	defaultCacheStaleFor         = 1 * time.Hour
+)
+
+// CacheConfig configures how long responses from CEDAR Core are cached. Any TTL that's left unset uses its default.
+type CacheConfig struct {
+	// Disabled turns off caching, so every read calls CEDAR
+	Disabled bool
+	// SystemSummaryTTL is how long the system summary (and the systems looked up from it) are fresh
+	SystemSummaryTTL time.Duration
+	// SystemDetailTTL is how long a system's detail is fresh
+	SystemDetailTTL time.Duration
+	// RoleTTL is how long a system's role assignments are fresh
+	RoleTTL time.Duration
+	// RoleTypeTTL is how long the list of role types is fresh
+	RoleTypeTTL time.Duration
+	// TTL is how long responses from the other read endpoints (ATOs, budgets, contracts, deployments, etc.) are fresh
+	TTL time.Duration
+	// StaleFor is how long after a response stops being fresh it can still be returned while it's refreshed in the background.
+	// Responses older than this are fetched from CEDAR before returning.
+	StaleFor time.Duration
+}
+
+func (cfg CacheConfig) withDefaults() CacheConfig {
+	withDefault := func(ttl time.Duration, defaultTTL time.Duration) time.Duration {
+		if ttl <= 0 {
+			return defaultTTL
+		}
+		return ttl
+	}
+
+	cfg.SystemSummaryTTL = withDefault(cfg.SystemSummaryTTL, defaultSystemSummaryCacheTTL)
+	cfg.SystemDetailTTL = withDefault(cfg.SystemDetailTTL, defaultSystemDetailCacheTTL)
+	cfg.RoleTTL = withDefault(cfg.RoleTTL, defaultRoleCacheTTL)
+	cfg.RoleTypeTTL = withDefault(cfg.RoleTypeTTL, defaultRoleTypeCacheTTL)
+	cfg.TTL = withDefault(cfg.TTL, defaultCacheTTL)
+	cfg.StaleFor = withDefault(cfg.StaleFor, defaultCacheStaleFor)
+	return cfg
+}
+
+// ttl is how long a response from an endpoint is fresh
+func (cfg CacheConfig) ttl(endpoint cacheEndpoint) time.Duration {
+	switch endpoint {
+	case cacheEndpointSystemSummary:
+		return cfg.SystemSummaryTTL
+	case cacheEndpointSystemDetail:
+		return cfg.SystemDetailTTL
+	case cacheEndpointRoles:
+		return cfg.RoleTTL
+	case cacheEndpointRoleTypes:
+		return cfg.RoleTypeTTL
+	default:
+		return cfg.TTL
+	}
+}
+
+// cacheEndpoint identifies which CEDAR endpoint a cached response came from
+type cacheEndpoint string
+
+const (
+	cacheEndpointSystemSummary    cacheEndpoint = "systemSummary"
+	cacheEndpointSystemDetail     cacheEndpoint = "systemDetail"
+	cacheEndpointRoles            cacheEndpoint = "roles"
+	cacheEndpointRoleTypes        cacheEndpoint = "roleTypes"
+	cacheEndpointATO              cacheEndpoint = "authorityToOperate"
+	cacheEndpointBudget           cacheEndpoint = "budget"
+	cacheEndpointBudgetSystemCost cacheEndpoint = "budgetSystemCost"
+	cacheEndpointContract         cacheEndpoint = "contract"
+	cacheEndpointDeployments      cacheEndpoint = "deployments"
+	cacheEndpointExchanges        cacheEndpoint = "exchanges"
+	cacheEndpointSoftwareProducts cacheEndpoint = "softwareProducts"
+	cacheEndpointThreats          cacheEndpoint = "threats"
+	cacheEndpointURLs             cacheEndpoint = "urls"
+)
+
+// cacheKey identifies a cached response. systemID and euaUserID are set when the response is about a single system or user,
+// so those responses can be evicted when the system or user changes; params holds any other parameters the response depends on.
+type cacheKey struct {
+	endpoint  cacheEndpoint
+	systemID  uuid.UUID
+	euaUserID string
+	params    string
+}
+
+func (k cacheKey) String() string {
+	return fmt.Sprintf("%s|%s|%s|%s", k.endpoint, k.systemID, k.euaUserID, k.params)
+}
+
+type cacheEntry struct {
+	value      any
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+// responseCache is a read-through cache of CEDAR Core responses. Fresh responses are returned as-is; stale responses are returned
+// while they're refreshed in the background; anything older is fetched before returning. Concurrent fetches of the same key share one call to CEDAR.
+// Responses are shared between callers, so they mustn't be modified.
+type responseCache struct {
+	config CacheConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+	// generation is incremented on every eviction, so responses fetched before an eviction aren't stored after it
+	generation uint64
+
+	group singleflight.Group
+}
+
+func newResponseCache(config CacheConfig) *responseCache {
+	return &responseCache{
+		config:  config.withDefaults(),
+		now:     time.Now,
+		entries: map[cacheKey]*cacheEntry{},
+	}
+}
+
+// cachedRead returns the cached response for key, calling fetch when there isn't a fresh enough one. A nil cache always calls fetch.
+// Errors are never cached.
+func cachedRead[T any](ctx context.Context, cache *responseCache, key cacheKey, fetch func(context.Context) (T, error)) (T, error) {
+	if cache == nil {
+		return fetch(ctx)
+	}
+
+	ttl := cache.config.ttl(key.endpoint)
+	cache.mu.Lock()
+	entry, found := cache.entries[key]
+	if found {
+		age := cache.now().Sub(entry.fetchedAt)
+		if age < ttl {
+			cache.mu.Unlock()
+			return entry.value.(T), nil
+		}
+
+		if age < ttl+cache.config.StaleFor {
+			startRefresh := !entry.refreshing
+			entry.refreshing = true
+			cache.mu.Unlock()
+
+			if startRefresh {
+				go cache.refresh(context.WithoutCancel(ctx), key, func(ctx context.Context) (any, error) {
+					return fetch(ctx)
+				})
+			}
+			return entry.value.(T), nil
+		}
+	}
+	cache.mu.Unlock()
+
+	value, err := cache.fetch(ctx, key, func(ctx context.Context) (any, error) {
+		return fetch(ctx)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.(T), nil
+}
+
+// fetch calls CEDAR for key, sharing the call with any concurrent fetches of the same key, and stores the response
+func (c *responseCache) fetch(ctx context.Context, key cacheKey, fetch func(context.Context) (any, error)) (any, error) {
+	c.mu.Lock()
+	generation := c.generation
+	c.mu.Unlock()
+
+	// the generation is part of the key so a caller that arrives after an eviction doesn't share a call that started before it
+	value, err, _ := c.group.Do(fmt.Sprintf("%d|%s", generation, key), func() (any, error) {
+		value, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.generation == generation {
+			c.entries[key] = &cacheEntry{
+				value:     value,
+				fetchedAt: c.now(),
+			}
+		}
+		return value, nil
+	})
+	return value, err
+}
+
+// refresh re-fetches a stale response in the background. If the fetch fails the stale response is kept, and is refreshed again on its next read.
+func (c *responseCache) refresh(ctx context.Context, key cacheKey, fetch func(context.Context) (any, error)) {
+	if _, err := c.fetch(ctx, key, fetch); err != nil {
+		appcontext.ZLogger(ctx).Warn("failed to refresh cached CEDAR Core response", zap.Error(err), zap.Stringer("key", key))
+
+		c.mu.Lock()
+		if entry, found := c.entries[key]; found {
+			entry.refreshing = false
+		}
+		c.mu.Unlock()
+	}
+}
+
+// evict removes every cached response whose key matches
+func (c *responseCache) evict(matches func(key cacheKey) bool) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for key := range c.entries {
+		if matches(key) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// evictionMatches returns whether a cached response matches any of the eviction rules
+func evictionMatches(rules []models.CEDARCacheEvictionRule) func(key cacheKey) bool {
+	return func(key cacheKey) bool {
+		return lo.ContainsBy(rules, func(rule models.CEDARCacheEvictionRule) bool {
+			return (rule.Endpoint == "" || rule.Endpoint == string(key.endpoint)) &&
+				(rule.SystemID == uuid.Nil || rule.SystemID == key.systemID) &&
+				(rule.EUAUserID == "" || rule.EUAUserID == key.euaUserID)
+		})
+	}
+}
+
+// evict removes the cached responses matching any of the rules, and has every other instance remove them from its cache too
+func (c *Client) evict(rules ...models.CEDARCacheEvictionRule) {
+	if c.cache == nil {
+		return
+	}
+
+	c.cache.evict(evictionMatches(rules))
+	c.broadcastEviction(rules)
+}
+
+// InvalidateCache removes every cached CEDAR Core response, so the next reads call CEDAR
+func (c *Client) InvalidateCache(ctx context.Context) {
+	c.evict(models.CEDARCacheEvictionRule{})
+	appcontext.ZLogger(ctx).Info("invalidated CEDAR Core cache")
+}
+
+// InvalidateSystemCache removes the cached CEDAR Core responses about a system. The system summary is removed too, since it includes the system.
+func (c *Client) InvalidateSystemCache(ctx context.Context, cedarSystemID uuid.UUID) {
+	c.evict(
+		models.CEDARCacheEvictionRule{SystemID: cedarSystemID},
+		models.CEDARCacheEvictionRule{Endpoint: string(cacheEndpointSystemSummary)},
+	)
+	appcontext.ZLogger(ctx).Info("invalidated CEDAR Core cache for system", zap.String("cedarSystemID", cedarSystemID.String()))
+}
+
+// evictRolesForUser removes the cached responses that change when a user's roles on a system change: the system's roles and detail,
+// and the systems the user is a member of
+func (c *Client) evictRolesForUser(cedarSystemID uuid.UUID, euaUserID string) {
+	c.evict(
+		models.CEDARCacheEvictionRule{Endpoint: string(cacheEndpointRoles), SystemID: cedarSystemID},
+		models.CEDARCacheEvictionRule{Endpoint: string(cacheEndpointSystemDetail), SystemID: cedarSystemID},
+		models.CEDARCacheEvictionRule{Endpoint: string(cacheEndpointSystemSummary), EUAUserID: euaUserID},
+	)
+}
+
+// writeAndEvict makes a write to CEDAR, then evicts the cached responses it changes, which match rules. They're evicted even if the write
+// fails, since CEDAR may have made the change before failing (e.g. if the call timed out after CEDAR received it), and the next read
+// should show whatever CEDAR has
+func writeAndEvict[T any](c *Client, rules []models.CEDARCacheEvictionRule, write func() (T, error)) (T, error) {
+	defer c.evict(rules...)
+	return write()
+}
+
+// deploymentsEviction matches the cached responses that change when a system's deployments are written to
+func deploymentsEviction(cedarSystemID uuid.UUID) []models.CEDARCacheEvictionRule {
+	return []models.CEDARCacheEvictionRule{{Endpoint: string(cacheEndpointDeployments), SystemID: cedarSystemID}}
+}
+
+// exchangesEviction matches the cached responses that change when exchanges are written to. An exchange shows up on the systems at both
+// ends of it, so every system's exchanges are matched, not just those of the system that was edited.
+func exchangesEviction() []models.CEDARCacheEvictionRule {
+	return []models.CEDARCacheEvictionRule{{Endpoint: string(cacheEndpointExchanges)}}
+}
+
+// contractsEviction matches the cached responses that change when a system's contracts are written to
+func contractsEviction(cedarSystemID uuid.UUID) []models.CEDARCacheEvictionRule {
+	return []models.CEDARCacheEvictionRule{{Endpoint: string(cacheEndpointContract), SystemID: cedarSystemID}}
+}
+
+// budgetsEviction matches the cached responses that change when a system's budgets are written to
+func budgetsEviction(cedarSystemID uuid.UUID) []models.CEDARCacheEvictionRule {
+	return []models.CEDARCacheEvictionRule{{Endpoint: string(cacheEndpointBudget), SystemID: cedarSystemID}}
+}
+
+// urlsEviction matches the cached responses that change when a system's URLs are written to
+func urlsEviction(cedarSystemID uuid.UUID) []models.CEDARCacheEvictionRule {
+	return []models.CEDARCacheEvictionRule{{Endpoint: string(cacheEndpointURLs), SystemID: cedarSystemID}}
+}
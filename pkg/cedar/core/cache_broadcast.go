@@ -0,0 +1,84 @@
+package cedarcore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/models/pubsubevents"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+)
+
+// cacheEvictionSessionID is the pubsub session evictions are published in. Evictions aren't about any one session, so every instance
+// publishes and subscribes to them in the same one
+var cacheEvictionSessionID = uuid.Nil
+
+// BroadcastCacheEvictions has the client publish the responses it evicts from its cache through ps, and evict the responses other
+// instances publish from its own cache, so a change made through one instance, or an admin clearing the cache, isn't hidden by another
+// instance's cache. It should be called once, before the client is used. It does nothing if the client doesn't cache responses.
+func (c *Client) BroadcastCacheEvictions(ctx context.Context, ps pubsub.PubSub) {
+	if c.cache == nil {
+		return
+	}
+
+	c.evictionPubSub = ps
+	// the subscription lasts as long as the instance does, so it's never disconnected
+	ps.Subscribe(cacheEvictionSessionID, pubsubevents.CEDARCacheEvicted, &cacheEvictionSubscriber{
+		client: c,
+		logger: appcontext.ZLogger(ctx),
+	}, nil)
+}
+
+// broadcastEviction publishes responses that were evicted from this instance's cache, so the other instances evict them too
+func (c *Client) broadcastEviction(rules []models.CEDARCacheEvictionRule) {
+	if c.evictionPubSub == nil {
+		return
+	}
+
+	c.evictionPubSub.Publish(cacheEvictionSessionID, pubsubevents.CEDARCacheEvicted, models.CEDARCacheEvictedEvent{
+		InstanceID: c.instanceID,
+		Rules:      rules,
+	})
+}
+
+// cacheEvictionSubscriber evicts the responses other instances have evicted from the client's cache
+type cacheEvictionSubscriber struct {
+	client *Client
+	logger *zap.Logger
+}
+
+// GetID returns this Subscriber's unique identifying token
+func (s *cacheEvictionSubscriber) GetID() string {
+	return s.client.instanceID.String()
+}
+
+// GetPrincipal returns nil, since evictions aren't received on behalf of a user
+func (s *cacheEvictionSubscriber) GetPrincipal() authentication.Principal {
+	return nil
+}
+
+// Notify will be called by the PubSub service when an instance evicts responses from its cache
+func (s *cacheEvictionSubscriber) Notify(payload interface{}) {
+	event, ok := payload.(models.CEDARCacheEvictedEvent)
+	if !ok {
+		s.logger.Error("Invalid payload type in Notify",
+			zap.String("expected", "CEDARCacheEvictedEvent"),
+			zap.String("got", fmt.Sprintf("%T", payload)),
+		)
+		return
+	}
+
+	// this instance evicted its own responses before publishing them
+	if event.InstanceID == s.client.instanceID {
+		return
+	}
+	s.client.cache.evict(evictionMatches(event.Rules))
+}
+
+// NotifyUnsubscribed will be called by the PubSub service when this Subscriber is unsubscribed
+func (s *cacheEvictionSubscriber) NotifyUnsubscribed(ps pubsub.PubSub, sessionID uuid.UUID) {}
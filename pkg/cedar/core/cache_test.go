@@ -0,0 +1,264 @@
+package cedarcore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+)
+
+type CacheTestSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func TestCacheTestSuite(t *testing.T) {
+	tests := &CacheTestSuite{
+		Suite:  suite.Suite{},
+		logger: zap.NewNop(),
+	}
+	suite.Run(t, tests)
+}
+
+// newTestCache returns a cache with a clock the test controls
+func newTestCache(config CacheConfig) (*responseCache, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newResponseCache(config)
+	cache.now = func() time.Time {
+		return now
+	}
+	return cache, &now
+}
+
+func (s *CacheTestSuite) TestCachedRead() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+	key := cacheKey{endpoint: cacheEndpointThreats, systemID: uuid.New()}
+	config := CacheConfig{TTL: time.Minute, StaleFor: time.Hour}
+
+	// countingFetch returns how many times it's been called
+	countingFetch := func(calls *atomic.Int32) func(context.Context) (int32, error) {
+		return func(context.Context) (int32, error) {
+			return calls.Add(1), nil
+		}
+	}
+
+	s.Run("fresh responses are returned without calling CEDAR", func() {
+		cache, now := newTestCache(config)
+		calls := &atomic.Int32{}
+
+		value, err := cachedRead(ctx, cache, key, countingFetch(calls))
+		s.NoError(err)
+		s.EqualValues(1, value)
+
+		*now = now.Add(30 * time.Second)
+		value, err = cachedRead(ctx, cache, key, countingFetch(calls))
+		s.NoError(err)
+		s.EqualValues(1, value)
+		s.EqualValues(1, calls.Load())
+	})
+
+	s.Run("stale responses are returned while they're refreshed", func() {
+		cache, now := newTestCache(config)
+		calls := &atomic.Int32{}
+
+		_, err := cachedRead(ctx, cache, key, countingFetch(calls))
+		s.NoError(err)
+
+		*now = now.Add(2 * time.Minute)
+		value, err := cachedRead(ctx, cache, key, countingFetch(calls))
+		s.NoError(err)
+		s.EqualValues(1, value)
+
+		s.Eventually(func() bool {
+			value, err := cachedRead(ctx, cache, key, countingFetch(calls))
+			return err == nil && value == 2
+		}, time.Second, 10*time.Millisecond)
+		s.EqualValues(2, calls.Load())
+	})
+
+	s.Run("responses too old to be served stale are fetched before returning", func() {
+		cache, now := newTestCache(config)
+		calls := &atomic.Int32{}
+
+		_, err := cachedRead(ctx, cache, key, countingFetch(calls))
+		s.NoError(err)
+
+		*now = now.Add(2 * time.Hour)
+		value, err := cachedRead(ctx, cache, key, countingFetch(calls))
+		s.NoError(err)
+		s.EqualValues(2, value)
+	})
+
+	s.Run("errors aren't cached", func() {
+		cache, _ := newTestCache(config)
+		fetchErr := errors.New("CEDAR is down")
+
+		_, err := cachedRead(ctx, cache, key, func(context.Context) (int32, error) {
+			return 0, fetchErr
+		})
+		s.ErrorIs(err, fetchErr)
+
+		calls := &atomic.Int32{}
+		value, err := cachedRead(ctx, cache, key, countingFetch(calls))
+		s.NoError(err)
+		s.EqualValues(1, value)
+	})
+
+	s.Run("a nil cache always calls CEDAR", func() {
+		calls := &atomic.Int32{}
+		for i := 0; i < 2; i++ {
+			_, err := cachedRead(ctx, nil, key, countingFetch(calls))
+			s.NoError(err)
+		}
+		s.EqualValues(2, calls.Load())
+	})
+
+	s.Run("responses fetched before an eviction aren't stored", func() {
+		cache, _ := newTestCache(config)
+		fetching := make(chan struct{})
+		release := make(chan struct{})
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, err := cachedRead(ctx, cache, key, func(context.Context) (int32, error) {
+				close(fetching)
+				<-release
+				return 1, nil
+			})
+			s.NoError(err)
+		}()
+
+		<-fetching
+		cache.evict(func(cacheKey) bool {
+			return true
+		})
+		close(release)
+		<-done
+
+		calls := &atomic.Int32{}
+		_, err := cachedRead(ctx, cache, key, countingFetch(calls))
+		s.NoError(err)
+		s.EqualValues(1, calls.Load())
+	})
+}
+
+func (s *CacheTestSuite) TestEvictRolesForUser() {
+	systemID := uuid.New()
+	otherSystemID := uuid.New()
+	c := &Client{cache: newResponseCache(CacheConfig{})}
+
+	keys := map[cacheKey]bool{
+		{endpoint: cacheEndpointRoles, systemID: systemID}:                         true,
+		{endpoint: cacheEndpointRoles, systemID: systemID, params: "roleTypeID"}:   true,
+		{endpoint: cacheEndpointSystemDetail, systemID: systemID}:                  true,
+		{endpoint: cacheEndpointSystemSummary, euaUserID: "ABCD"}:                  true,
+		{endpoint: cacheEndpointRoles, systemID: otherSystemID}:                    false,
+		{endpoint: cacheEndpointSystemSummary}:                                     false,
+		{endpoint: cacheEndpointSystemSummary, euaUserID: "USR1"}:                  false,
+		{endpoint: cacheEndpointBudget, systemID: systemID}:                        false,
+		{endpoint: cacheEndpointRoleTypes}:                                         false,
+		{endpoint: cacheEndpointSystemDetail, systemID: otherSystemID, params: ""}: false,
+	}
+	for key := range keys {
+		c.cache.entries[key] = &cacheEntry{value: struct{}{}}
+	}
+
+	c.evictRolesForUser(systemID, "ABCD")
+
+	for key, evicted := range keys {
+		_, found := c.cache.entries[key]
+		s.Equal(!evicted, found, key.String())
+	}
+}
+
+func (s *CacheTestSuite) TestBroadcastCacheEvictions() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+	ps := pubsub.NewServicePubSub()
+	systemID := uuid.New()
+	deployments := cacheKey{endpoint: cacheEndpointDeployments, systemID: systemID}
+	contracts := cacheKey{endpoint: cacheEndpointContract, systemID: systemID}
+
+	instances := make([]*Client, 2)
+	for i := range instances {
+		instances[i] = NewClient(ctx, "fake", "fake", "1.0.0", false, TransportConfig{}, CacheConfig{})
+		instances[i].BroadcastCacheEvictions(ctx, ps)
+		instances[i].cache.entries[deployments] = &cacheEntry{value: struct{}{}}
+		instances[i].cache.entries[contracts] = &cacheEntry{value: struct{}{}}
+	}
+
+	instances[0].evict(deploymentsEviction(systemID)...)
+	for _, instance := range instances {
+		s.NotContains(instance.cache.entries, deployments)
+		s.Contains(instance.cache.entries, contracts)
+	}
+
+	instances[1].InvalidateCache(ctx)
+	for _, instance := range instances {
+		s.Empty(instance.cache.entries)
+	}
+
+	// clients that don't cache responses don't publish or receive evictions
+	mocked := NewClient(ctx, "fake", "fake", "1.0.0", true, TransportConfig{}, CacheConfig{})
+	mocked.BroadcastCacheEvictions(ctx, ps)
+	mocked.InvalidateCache(ctx)
+}
+
+func (s *CacheTestSuite) TestClientCachesResponses() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		s.Equal("/gateway/CEDAR Core API/1.0.0/role/type/alfabet", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		s.NoError(json.NewEncoder(w).Encode(map[string]any{
+			"RoleTypes": []map[string]any{
+				{
+					"application": "alfabet",
+					"id":          "{FAKE12AB-12A3-12a1-1AB2-ROLETYPEID01}",
+					"name":        "Business Owner",
+				},
+			},
+			"count": 1,
+		}))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	s.NoError(err)
+
+	c := NewClient(ctx, serverURL.Host, "fake", "1.0.0", false, TransportConfig{}, CacheConfig{})
+
+	for i := 0; i < 2; i++ {
+		roleTypes, err := c.GetRoleTypes(ctx)
+		s.NoError(err)
+		s.Len(roleTypes, 1)
+	}
+	s.EqualValues(1, calls.Load())
+
+	c.InvalidateCache(ctx)
+	_, err = c.GetRoleTypes(ctx)
+	s.NoError(err)
+	s.EqualValues(2, calls.Load())
+
+	uncached := NewClient(ctx, serverURL.Host, "fake", "1.0.0", false, TransportConfig{}, CacheConfig{Disabled: true})
+	for i := 0; i < 2; i++ {
+		_, err := uncached.GetRoleTypes(ctx)
+		s.NoError(err)
+	}
+	s.EqualValues(4, calls.Load())
+}
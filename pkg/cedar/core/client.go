@@ -2,16 +2,21 @@ package cedarcore
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/go-openapi/runtime"
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
 	apiclient "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/client"
+	apimodels "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 )
 
 type loggingTransport struct {
@@ -41,12 +46,21 @@ func (t *loggingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	return resp, err
 }
 
-// NewClient builds the type that holds a connection to the CEDAR Core API
-func NewClient(ctx context.Context, cedarHost string, cedarAPIKey string, cedarAPIVersion string, mockEnabled bool) *Client {
+// NewClient builds the type that holds a connection to the CEDAR Core API. Calls are timed out, retried, and short-circuited as configured by transportConfig,
+// and responses are cached as configured by cacheConfig, unless CEDAR Core is mocked.
+func NewClient(
+	ctx context.Context,
+	cedarHost string,
+	cedarAPIKey string,
+	cedarAPIVersion string,
+	mockEnabled bool,
+	transportConfig TransportConfig,
+	cacheConfig CacheConfig,
+) *Client {
+	logger := appcontext.ZLogger(ctx)
+	transport := newResilientTransport(&loggingTransport{logger: logger}, transportConfig, logger)
 	hc := http.Client{
-		Transport: &loggingTransport{
-			logger: appcontext.ZLogger(ctx),
-		},
+		Transport: transport,
 	}
 
 	basePath := "/gateway/CEDAR Core API/" + cedarAPIVersion
@@ -67,7 +81,12 @@ func NewClient(ctx context.Context, cedarHost string, cedarAPIKey string, cedarA
 			),
 			strfmt.Default,
 		),
-		hc: &hc,
+		hc:         &hc,
+		transport:  transport,
+		instanceID: uuid.New(),
+	}
+	if !mockEnabled && !cacheConfig.Disabled {
+		client.cache = newResponseCache(cacheConfig)
 	}
 	return client
 }
@@ -79,4 +98,33 @@ type Client struct {
 	auth        runtime.ClientAuthInfoWriter
 	sdk         *apiclient.CEDARCoreAPI
 	hc          *http.Client
+	transport   *resilientTransport
+	cache       *responseCache
+	// instanceID identifies this client's cache in the evictions it publishes; see BroadcastCacheEvictions
+	instanceID     uuid.UUID
+	evictionPubSub pubsub.PubSub
+}
+
+// CheckHealth returns an error if calls to CEDAR Core are failing fast because too many calls in a row have failed
+func (c *Client) CheckHealth() error {
+	if c.mockEnabled {
+		return nil
+	}
+	return c.transport.breaker.status()
+}
+
+// writeResponseError returns the error CEDAR reported in the body of its response to a write, if there was one
+func writeResponseError(payload *apimodels.Response) error {
+	if payload == nil {
+		return fmt.Errorf("no body received")
+	}
+
+	if payload.Result == "error" {
+		if len(payload.Message) > 0 {
+			return errors.New(payload.Message[0])
+		}
+		return fmt.Errorf("unknown error")
+	}
+
+	return nil
 }
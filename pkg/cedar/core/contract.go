@@ -2,15 +2,19 @@ package cedarcore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/client/contract"
+	apimodels "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/models"
 	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/local/cedarcoremock"
 	"github.com/cms-enterprise/easi-app/pkg/models"
@@ -26,6 +30,33 @@ func (c *Client) GetContractBySystem(ctx context.Context, cedarSystemID uuid.UUI
 		return nil, cedarcoremock.NoSystemFoundError()
 	}
 
+	return cachedRead(ctx, c.cache, cacheKey{endpoint: cacheEndpointContract, systemID: cedarSystemID}, func(ctx context.Context) ([]*models.CedarContract, error) {
+		return c.fetchContractBySystem(ctx, cedarSystemID)
+	})
+}
+
+// fetchContractBySystem calls CEDAR for a system's contracts
+func (c *Client) fetchContractBySystem(ctx context.Context, cedarSystemID uuid.UUID) ([]*models.CedarContract, error) {
+	cedarContracts, err := c.fetchCEDARContracts(cedarSystemID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the auto-generated struct to our own pkg/models struct
+	retVal := make([]*models.CedarContract, 0, len(cedarContracts))
+
+	for _, contractData := range cedarContracts {
+		retContract, err := contractFromCEDAR(contractData)
+		if err != nil {
+			return nil, err
+		}
+		retVal = append(retVal, retContract)
+	}
+	return retVal, nil
+}
+
+// fetchCEDARContracts calls CEDAR for a system's contracts, returning them as CEDAR sent them
+func (c *Client) fetchCEDARContracts(cedarSystemID uuid.UUID) ([]*apimodels.Contract, error) {
 	params := contract.NewContractFindParams()
 
 	// Construct the parameters
@@ -38,40 +69,255 @@ func (c *Client) GetContractBySystem(ctx context.Context, cedarSystemID uuid.UUI
 		return nil, err
 	}
 
-	// Convert the auto-generated struct to our own pkg/models struct
-	retVal := make([]*models.CedarContract, 0, len(resp.Payload.Contracts))
+	if resp.Payload == nil {
+		return nil, fmt.Errorf("no body received")
+	}
 
-	for _, contractData := range resp.Payload.Contracts {
-		parsedUUID, err := uuid.Parse(contractData.SystemID)
+	return resp.Payload.Contracts, nil
+}
+
+// contractFromCEDAR converts a contract from the auto-generated struct to our own pkg/models struct
+func contractFromCEDAR(contractData *apimodels.Contract) (*models.CedarContract, error) {
+	parsedUUID, err := uuid.Parse(contractData.SystemID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse UUID when getting contract: %w", err)
+	}
+
+	var isDeliveryOrg bool
+	if strings.ToLower(contractData.IsDeliveryOrg) == "yes" {
+		isDeliveryOrg = true
+	}
+
+	endDate, err := time.Parse(time.RFC3339, contractData.POPEndDate)
+	if err != nil {
+		endDate = time.Time{}
+	}
+	startDate, err := time.Parse(time.RFC3339, contractData.POPStartDate)
+	if err != nil {
+		startDate = time.Time{}
+	}
+
+	return &models.CedarContract{
+		ID:              zero.StringFromPtr(contractData.ID),
+		EndDate:         zero.TimeFrom(endDate),
+		StartDate:       zero.TimeFrom(startDate),
+		ContractNumber:  zero.StringFrom(contractData.ContractNumber),
+		ContractName:    zero.StringFrom(contractData.ProjectTitle),
+		Description:     zero.StringFrom(contractData.Description),
+		OrderNumber:     zero.StringFrom(contractData.OrderNumber),
+		ServiceProvided: zero.StringFrom(contractData.ServiceProvided),
+		IsDeliveryOrg:   isDeliveryOrg,
+		SystemID:        &parsedUUID,
+	}, nil
+}
+
+// AddContract makes a POST call to the /contract endpoint to add a contract to a system, and returns the contract as it was sent.
+// CEDAR assigns the new contract's ID, so any ID on the contract is ignored; CEDAR doesn't return the ID, so the returned contract
+// only has one when CEDAR is mocked.
+func (c *Client) AddContract(ctx context.Context, cedarSystemID uuid.UUID, cedarContract *models.CedarContract) (*models.CedarContract, error) {
+	// the caller's contract is copied rather than modified
+	added := *cedarContract
+	added.ID = zero.StringFrom("")
+	added.SystemID = &cedarSystemID
+
+	return writeAndEvict(c, contractsEviction(cedarSystemID), func() (*models.CedarContract, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if !cedarcoremock.IsMockSystem(cedarSystemID) {
+				return nil, cedarcoremock.NoSystemFoundError()
+			}
+			return cedarcoremock.AddContract(cedarSystemID, &added), nil
+		}
+
+		params := contract.NewContractAddParams()
+		params.SetContractAddRequest(&apimodels.ContractAddRequest{
+			Contracts: []*apimodels.Contract{contractToCEDAR(cedarSystemID, &added, nil)},
+		})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Contract.ContractAdd(params, c.auth)
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse UUID when getting contract: %w", err)
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
 		}
 
-		var isDeliveryOrg bool
-		if strings.ToLower(contractData.IsDeliveryOrg) == "yes" {
-			isDeliveryOrg = true
+		return &added, nil
+	})
+}
+
+// UpdateContract makes a PUT call to the /contract endpoint to change one of a system's contracts, and returns the updated contract.
+// update is applied to a copy of the contract as it currently is in CEDAR. concurrencyToken is the token of the contract the change was
+// based on; if the contract has changed in CEDAR since, the update is rejected with a ResourceConflictError.
+func (c *Client) UpdateContract(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	contractID string,
+	concurrencyToken string,
+	update func(cedarContract *models.CedarContract),
+) (*models.CedarContract, error) {
+	current, cedarContract, err := c.currentContract(ctx, cedarSystemID, contractID, concurrencyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// the current contract may be shared with other readers, so it's copied rather than modified
+	updated := *current
+	update(&updated)
+	updated.ID = current.ID
+	updated.SystemID = &cedarSystemID
+
+	return writeAndEvict(c, contractsEviction(cedarSystemID), func() (*models.CedarContract, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if err := cedarcoremock.UpdateContract(cedarSystemID, &updated); err != nil {
+				return nil, err
+			}
+			return &updated, nil
 		}
 
-		endDate, err := time.Parse(time.RFC3339, contractData.POPEndDate)
+		params := contract.NewContractUpdateParams()
+		// the contract's deliverable records hold its link to the system, so they're updated along with it
+		params.SetBudgetsOnly(lo.ToPtr(false))
+		params.SetContractUpdateRequest(&apimodels.ContractUpdateRequest{
+			Contracts: []*apimodels.Contract{contractToCEDAR(cedarSystemID, &updated, cedarContract)},
+		})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Contract.ContractUpdate(params, c.auth)
 		if err != nil {
-			endDate = time.Time{}
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
+		}
+
+		return &updated, nil
+	})
+}
+
+// DeleteContract makes a DELETE call to the /contract endpoint to remove one of a system's contracts, and returns the removed contract.
+// concurrencyToken is the token of the contract the caller read; if the contract has changed in CEDAR since, the delete is rejected with
+// a ResourceConflictError.
+func (c *Client) DeleteContract(ctx context.Context, cedarSystemID uuid.UUID, contractID string, concurrencyToken string) (*models.CedarContract, error) {
+	deleted, _, err := c.currentContract(ctx, cedarSystemID, contractID, concurrencyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeAndEvict(c, contractsEviction(cedarSystemID), func() (*models.CedarContract, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if err := cedarcoremock.DeleteContracts(cedarSystemID, []string{contractID}); err != nil {
+				return nil, err
+			}
+			return deleted, nil
 		}
-		startDate, err := time.Parse(time.RFC3339, contractData.POPStartDate)
+
+		params := contract.NewContractDeleteListParams()
+		params.SetID([]string{contractID})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Contract.ContractDeleteList(params, c.auth)
 		if err != nil {
-			startDate = time.Time{}
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
 		}
 
-		retVal = append(retVal, &models.CedarContract{
-			EndDate:         zero.TimeFrom(endDate),
-			StartDate:       zero.TimeFrom(startDate),
-			ContractNumber:  zero.StringFrom(contractData.ContractNumber),
-			ContractName:    zero.StringFrom(contractData.ProjectTitle),
-			Description:     zero.StringFrom(contractData.Description),
-			OrderNumber:     zero.StringFrom(contractData.OrderNumber),
-			ServiceProvided: zero.StringFrom(contractData.ServiceProvided),
-			IsDeliveryOrg:   isDeliveryOrg,
-			SystemID:        &parsedUUID,
+		return deleted, nil
+	})
+}
+
+// currentContract fetches one of a system's contracts straight from CEDAR, bypassing the cache, and checks that it still matches concurrencyToken.
+// The contract is also returned as CEDAR sent it (unless CEDAR is mocked), so the fields EASi doesn't read are kept when it's written back.
+// As with deployments, CEDAR can't make the write itself conditional, so the section locks are what keep edits made through EASi from overlapping.
+func (c *Client) currentContract(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	contractID string,
+	concurrencyToken string,
+) (*models.CedarContract, *apimodels.Contract, error) {
+	var retContract *models.CedarContract
+	var cedarContract *apimodels.Contract
+
+	if c.mockEnabled {
+		contracts, err := c.GetContractBySystem(ctx, cedarSystemID)
+		if err != nil {
+			return nil, nil, err
+		}
+		retContract, _ = lo.Find(contracts, func(cedarContract *models.CedarContract) bool {
+			return cedarContract.ID.String == contractID
+		})
+	} else {
+		cedarContracts, err := c.fetchCEDARContracts(cedarSystemID)
+		if err != nil {
+			return nil, nil, err
+		}
+		cedarContract, _ = lo.Find(cedarContracts, func(cedarContract *apimodels.Contract) bool {
+			return cedarContract.ID != nil && *cedarContract.ID == contractID
 		})
+		if cedarContract != nil {
+			if retContract, err = contractFromCEDAR(cedarContract); err != nil {
+				return nil, nil, err
+			}
+		}
 	}
-	return retVal, nil
+
+	if contractID == "" || retContract == nil {
+		return nil, nil, &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no contract found"), Resource: models.CedarContract{}}
+	}
+
+	if retContract.ConcurrencyToken() != concurrencyToken {
+		return nil, nil, &apperrors.ResourceConflictError{
+			Err:        errors.New("the contract has changed since it was read"),
+			Resource:   models.CedarContract{},
+			ResourceID: contractID,
+		}
+	}
+
+	return retContract, cedarContract, nil
+}
+
+// contractToCEDAR converts a contract into the auto-generated struct CEDAR accepts. The fields EASi doesn't read are copied from base,
+// if it's given. The contract number is both the contract's award ID and its number, and its name is also its project title, which is
+// what EASi reads it from.
+func contractToCEDAR(cedarSystemID uuid.UUID, cedarContract *models.CedarContract, base *apimodels.Contract) *apimodels.Contract {
+	retContract := &apimodels.Contract{}
+	if base != nil {
+		*retContract = *base
+	}
+
+	retContract.ID = lo.ToPtr(cedarContract.ID.String)
+	retContract.SystemID = formatIDForCEDAR(cedarSystemID)
+	retContract.AwardID = lo.ToPtr(cedarContract.ContractNumber.String)
+	retContract.ContractNumber = cedarContract.ContractNumber.String
+	retContract.OrderNumber = cedarContract.OrderNumber.String
+	retContract.ContractName = cedarContract.ContractName.String
+	retContract.ProjectTitle = cedarContract.ContractName.String
+	retContract.Description = cedarContract.Description.String
+	retContract.ServiceProvided = cedarContract.ServiceProvided.String
+	retContract.IsDeliveryOrg = "No"
+	if cedarContract.IsDeliveryOrg {
+		retContract.IsDeliveryOrg = "Yes"
+	}
+	retContract.POPStartDate = formatContractDate(cedarContract.StartDate)
+	retContract.POPEndDate = formatContractDate(cedarContract.EndDate)
+
+	// the parent award ID is required, but EASi doesn't track it, so new contracts are sent without one
+	if retContract.ParentAwardID == nil {
+		retContract.ParentAwardID = lo.ToPtr("")
+	}
+
+	return retContract
+}
+
+// formatContractDate formats a contract's period of performance date the way it's read, or as an empty string if it isn't set
+func formatContractDate(date zero.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Time.Format(time.RFC3339)
 }
@@ -0,0 +1,201 @@
+package cedarcore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	apimodels "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/models"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+type ContractTestSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func TestContractTestSuite(t *testing.T) {
+	tests := &ContractTestSuite{
+		Suite:  suite.Suite{},
+		logger: zap.NewNop(),
+	}
+	suite.Run(t, tests)
+}
+
+func (s *ContractTestSuite) TestMockedContractWrites() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+	c := NewClient(ctx, "fake", "fake", "1.0.0", true, TransportConfig{}, CacheConfig{})
+	cedarSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC3D}")
+	otherSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC4E}")
+
+	before, err := c.GetContractBySystem(ctx, cedarSystemID)
+	s.NoError(err)
+
+	findContract := func(contractNumber string) *models.CedarContract {
+		contracts, err := c.GetContractBySystem(ctx, cedarSystemID)
+		s.NoError(err)
+		contract, _ := lo.Find(contracts, func(contract *models.CedarContract) bool {
+			return contract.ContractNumber.String == contractNumber
+		})
+		return contract
+	}
+
+	s.Run("a contract can be added", func() {
+		added, err := c.AddContract(ctx, cedarSystemID, &models.CedarContract{
+			ContractNumber: zero.StringFrom("NEW-CONTRACT"),
+			ContractName:   zero.StringFrom("New Contract"),
+		})
+		s.NoError(err)
+		s.NotEmpty(added.ID.String)
+
+		contract := findContract("NEW-CONTRACT")
+		s.NotNil(contract)
+		s.Equal(added.ID, contract.ID)
+		s.Equal(cedarSystemID, *contract.SystemID)
+
+		otherContracts, err := c.GetContractBySystem(ctx, otherSystemID)
+		s.NoError(err)
+		s.Len(otherContracts, len(before))
+	})
+
+	s.Run("a contract can be updated using its current concurrency token", func() {
+		contract := findContract("NEW-CONTRACT")
+		updated, err := c.UpdateContract(ctx, cedarSystemID, contract.ID.String, contract.ConcurrencyToken(), func(contract *models.CedarContract) {
+			contract.IsDeliveryOrg = true
+		})
+		s.NoError(err)
+		s.True(updated.IsDeliveryOrg)
+
+		s.Equal(updated, findContract("NEW-CONTRACT"))
+		s.NotEqual(contract.ConcurrencyToken(), updated.ConcurrencyToken())
+
+		// the contract that was read before the update isn't modified
+		s.False(contract.IsDeliveryOrg)
+	})
+
+	s.Run("updates based on an outdated contract are rejected", func() {
+		contract := findContract("NEW-CONTRACT")
+		staleToken := (&models.CedarContract{ID: contract.ID, ContractNumber: contract.ContractNumber}).ConcurrencyToken()
+
+		_, err := c.UpdateContract(ctx, cedarSystemID, contract.ID.String, staleToken, func(contract *models.CedarContract) {
+			contract.IsDeliveryOrg = false
+		})
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+		s.True(findContract("NEW-CONTRACT").IsDeliveryOrg)
+
+		_, err = c.DeleteContract(ctx, cedarSystemID, contract.ID.String, staleToken)
+		s.ErrorAs(err, &conflictErr)
+		s.NotNil(findContract("NEW-CONTRACT"))
+	})
+
+	s.Run("contracts that aren't on the system can't be changed", func() {
+		_, err := c.DeleteContract(ctx, cedarSystemID, "{NOT-A-CONTRACT}", "token")
+		var notFoundErr *apperrors.ResourceNotFoundError
+		s.ErrorAs(err, &notFoundErr)
+	})
+
+	s.Run("a contract can be deleted", func() {
+		contract := findContract("NEW-CONTRACT")
+		deleted, err := c.DeleteContract(ctx, cedarSystemID, contract.ID.String, contract.ConcurrencyToken())
+		s.NoError(err)
+		s.Equal(contract, deleted)
+		s.Nil(findContract("NEW-CONTRACT"))
+
+		after, err := c.GetContractBySystem(ctx, cedarSystemID)
+		s.NoError(err)
+		s.Len(after, len(before))
+	})
+}
+
+func (s *ContractTestSuite) TestUpdateContractCallsCEDAR() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+	cedarSystemID := uuid.New()
+	contractID := "{11AB1A00-1234-5678-ABC1-1A001B00CON1}"
+	startDate := time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	var gets atomic.Int32
+	var updated *apimodels.Contract
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/gateway/CEDAR Core API/1.0.0/contract", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			gets.Add(1)
+			contract := map[string]any{
+				"id":             contractID,
+				"systemId":       formatIDForCEDAR(cedarSystemID),
+				"awardId":        "12ABCD34E0001",
+				"parentAwardId":  "12ABCD34E0000",
+				"ContractNumber": "12ABCD34E0001",
+				"ProjectTitle":   "Cool Products & Tools",
+				"IsDeliveryOrg":  "No",
+				"POPStartDate":   startDate.Format(time.RFC3339),
+				"contractADO":    "Yes",
+			}
+			if updated != nil {
+				contract["IsDeliveryOrg"] = updated.IsDeliveryOrg
+			}
+			s.NoError(json.NewEncoder(w).Encode(map[string]any{
+				"Contracts": []map[string]any{contract},
+				"count":     1,
+			}))
+		case http.MethodPut:
+			s.Equal("false", r.URL.Query().Get("budgetsOnly"))
+			var body apimodels.ContractUpdateRequest
+			s.NoError(json.NewDecoder(r.Body).Decode(&body))
+			s.Len(body.Contracts, 1)
+			updated = body.Contracts[0]
+			s.NoError(json.NewEncoder(w).Encode(apimodels.Response{Result: "success"}))
+		default:
+			s.Failf("unexpected call to CEDAR", "method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	s.NoError(err)
+	c := NewClient(ctx, serverURL.Host, "fake", "1.0.0", false, TransportConfig{}, CacheConfig{})
+
+	contracts, err := c.GetContractBySystem(ctx, cedarSystemID)
+	s.NoError(err)
+	s.Len(contracts, 1)
+	s.EqualValues(contractID, contracts[0].ID.String)
+
+	_, err = c.UpdateContract(ctx, cedarSystemID, contractID, contracts[0].ConcurrencyToken(), func(contract *models.CedarContract) {
+		contract.IsDeliveryOrg = true
+	})
+	s.NoError(err)
+
+	s.Equal(contractID, *updated.ID)
+	s.Equal(formatIDForCEDAR(cedarSystemID), updated.SystemID)
+	s.Equal("12ABCD34E0001", updated.ContractNumber)
+	s.Equal("Cool Products & Tools", updated.ProjectTitle)
+	s.Equal("Yes", updated.IsDeliveryOrg)
+	s.Equal(startDate.Format(time.RFC3339), updated.POPStartDate)
+	s.Empty(updated.POPEndDate)
+
+	// fields EASi doesn't read are sent back unchanged
+	s.Equal("12ABCD34E0000", *updated.ParentAwardID)
+	s.Equal("Yes", updated.ContractADO)
+
+	// the contract is read from CEDAR to check it hasn't changed, and again after the update since the cached contracts are out of date
+	contracts, err = c.GetContractBySystem(ctx, cedarSystemID)
+	s.NoError(err)
+	s.True(contracts[0].IsDeliveryOrg)
+	s.EqualValues(3, gets.Load())
+}
@@ -2,15 +2,20 @@ package cedarcore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	apideployments "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/client/deployment"
+	apimodels "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/models"
 	"github.com/cms-enterprise/easi-app/pkg/local/cedarcoremock"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 )
@@ -27,11 +32,47 @@ func (c *Client) GetDeployments(ctx context.Context, cedarSystemID uuid.UUID, op
 	if c.mockEnabled {
 		appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
 		if cedarcoremock.IsMockSystem(cedarSystemID) {
-			return cedarcoremock.GetDeployments(), nil
+			return cedarcoremock.GetDeployments(cedarSystemID), nil
 		}
 		return nil, cedarcoremock.NoSystemFoundError()
 	}
 
+	key := cacheKey{endpoint: cacheEndpointDeployments, systemID: cedarSystemID}
+	if optionalParams != nil {
+		key.params = fmt.Sprintf(
+			"deploymentType=%s&state=%s&status=%s",
+			lo.FromPtr(optionalParams.DeploymentType),
+			lo.FromPtr(optionalParams.State),
+			lo.FromPtr(optionalParams.Status),
+		)
+	}
+	return cachedRead(ctx, c.cache, key, func(ctx context.Context) ([]*models.CedarDeployment, error) {
+		return c.fetchDeployments(ctx, cedarSystemID, optionalParams)
+	})
+}
+
+// fetchDeployments calls CEDAR for a system's deployments
+func (c *Client) fetchDeployments(ctx context.Context, cedarSystemID uuid.UUID, optionalParams *GetDeploymentsOptionalParams) ([]*models.CedarDeployment, error) {
+	deployments, err := c.fetchCEDARDeployments(cedarSystemID, optionalParams)
+	if err != nil {
+		return []*models.CedarDeployment{}, err
+	}
+
+	// Convert the auto-generated struct to our own pkg/models struct
+	retVal := []*models.CedarDeployment{}
+	for _, deployment := range deployments {
+		retDeployment, ok := deploymentFromCEDAR(ctx, cedarSystemID, deployment)
+		if !ok {
+			continue
+		}
+		retVal = append(retVal, retDeployment)
+	}
+
+	return retVal, nil
+}
+
+// fetchCEDARDeployments calls CEDAR for a system's deployments, returning them as CEDAR sent them
+func (c *Client) fetchCEDARDeployments(cedarSystemID uuid.UUID, optionalParams *GetDeploymentsOptionalParams) ([]*apimodels.Deployment, error) {
 	// Construct the parameters
 	params := apideployments.NewDeploymentFindListParams()
 	params.SetSystemID(formatIDForCEDAR(cedarSystemID))
@@ -54,84 +95,294 @@ func (c *Client) GetDeployments(ctx context.Context, cedarSystemID uuid.UUID, op
 	// Make the API call
 	resp, err := c.sdk.Deployment.DeploymentFindList(params, c.auth)
 	if err != nil {
-		return []*models.CedarDeployment{}, err
+		return nil, err
 	}
 
 	if resp.Payload == nil {
-		return []*models.CedarDeployment{}, fmt.Errorf("no body received")
+		return nil, fmt.Errorf("no body received")
 	}
 
-	// Convert the auto-generated struct to our own pkg/models struct
-	retVal := []*models.CedarDeployment{}
+	return resp.Payload.Deployments, nil
+}
 
-	// Populate the Deployment field by converting each item in resp.Payload.Deployments
+// deploymentFromCEDAR converts a deployment from the auto-generated struct to our own pkg/models struct, returning false if CEDAR sent bad data
+func deploymentFromCEDAR(ctx context.Context, cedarSystemID uuid.UUID, deployment *apimodels.Deployment) (*models.CedarDeployment, bool) {
 	// generated swagger client turns JSON nulls into Go zero values, so use null/zero package to convert them back to nullable values
-	for _, deployment := range resp.Payload.Deployments {
-		if deployment.ID == nil {
-			appcontext.ZLogger(ctx).Error("Error decoding deployment; deployment ID was null", zap.String("systemID", cedarSystemID.String()))
-			continue
+	if deployment.ID == nil {
+		appcontext.ZLogger(ctx).Error("Error decoding deployment; deployment ID was null", zap.String("systemID", cedarSystemID.String()))
+		return nil, false
+	}
+
+	if deployment.Name == nil {
+		appcontext.ZLogger(ctx).Error("Error decoding deployment; deployment name was null", zap.String("systemID", cedarSystemID.String()))
+		return nil, false
+	}
+
+	if deployment.SystemID == nil {
+		appcontext.ZLogger(ctx).Error("Error decoding deployment; deployment system ID was null", zap.String("systemID", cedarSystemID.String()))
+		return nil, false
+	}
+
+	parsedUUID, err := uuid.Parse(*deployment.SystemID)
+	if err != nil {
+		appcontext.ZLogger(ctx).Error("problem parsing deployment system id", zap.Error(err), zap.String("systemID", cedarSystemID.String()))
+		return nil, false
+	}
+
+	retDeployment := &models.CedarDeployment{
+		ID:                zero.StringFromPtr(deployment.ID),
+		Name:              zero.StringFromPtr(deployment.Name),
+		SystemID:          &parsedUUID,
+		StartDate:         zero.TimeFrom(time.Time(deployment.StartDate)),
+		EndDate:           zero.TimeFrom(time.Time(deployment.EndDate)),
+		IsHotSite:         zero.StringFrom(deployment.IsHotSite),
+		Description:       zero.StringFrom(deployment.Description),
+		ContractorName:    zero.StringFrom(deployment.ContractorName),
+		SystemVersion:     zero.StringFrom(deployment.SystemVersion),
+		HasProductionData: zero.StringFrom(deployment.HasProductionData),
+
+		// TODO - assumes no nulls in array returned from query
+		ReplicatedSystemElements: deployment.ReplicatedSystemElements,
+
+		DeploymentType:      zero.StringFrom(deployment.DeploymentType),
+		SystemName:          zero.StringFrom(deployment.SystemName),
+		DeploymentElementID: zero.StringFrom(deployment.DeploymentElementID),
+		State:               zero.StringFrom(deployment.State),
+		Status:              zero.StringFrom(deployment.Status),
+		WanType:             zero.StringFrom(deployment.WanType),
+	}
+
+	if deployment.DataCenter != nil {
+		retDataCenter := &models.CedarDataCenter{
+			ID:           zero.StringFrom(deployment.DataCenter.ID),
+			Name:         zero.StringFrom(deployment.DataCenter.Name),
+			Version:      zero.StringFrom(deployment.DataCenter.Version),
+			Description:  zero.StringFrom(deployment.DataCenter.Description),
+			State:        zero.StringFrom(deployment.DataCenter.State),
+			Status:       zero.StringFrom(deployment.DataCenter.Status),
+			StartDate:    zero.TimeFrom(time.Time(deployment.DataCenter.StartDate)),
+			EndDate:      zero.TimeFrom(time.Time(deployment.DataCenter.EndDate)),
+			Address1:     zero.StringFrom(deployment.DataCenter.Address1),
+			Address2:     zero.StringFrom(deployment.DataCenter.Address2),
+			City:         zero.StringFrom(deployment.DataCenter.City),
+			AddressState: zero.StringFrom(deployment.DataCenter.AddressState),
+			Zip:          zero.StringFrom(deployment.DataCenter.Zip),
 		}
+		retDeployment.DataCenter = retDataCenter
+	}
 
-		if deployment.Name == nil {
-			appcontext.ZLogger(ctx).Error("Error decoding deployment; deployment name was null", zap.String("systemID", cedarSystemID.String()))
-			continue
+	return retDeployment, true
+}
+
+// AddDeployment makes a POST call to the /deployment endpoint to add a deployment to a system, and returns the deployment as it was sent.
+// CEDAR assigns the new deployment's ID, so any ID on the deployment is ignored; CEDAR doesn't return the ID, so the returned deployment
+// only has one when CEDAR is mocked.
+func (c *Client) AddDeployment(ctx context.Context, cedarSystemID uuid.UUID, deployment *models.CedarDeployment) (*models.CedarDeployment, error) {
+	body := deploymentToCEDAR(cedarSystemID, deployment, nil)
+	body.ID = lo.ToPtr("")
+	if err := body.Validate(strfmt.Default); err != nil {
+		return nil, &apperrors.BadRequestError{Err: err}
+	}
+
+	return writeAndEvict(c, deploymentsEviction(cedarSystemID), func() (*models.CedarDeployment, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if !cedarcoremock.IsMockSystem(cedarSystemID) {
+				return nil, cedarcoremock.NoSystemFoundError()
+			}
+			return cedarcoremock.AddDeployment(cedarSystemID, deployment), nil
 		}
 
-		if deployment.SystemID == nil {
-			appcontext.ZLogger(ctx).Error("Error decoding deployment; deployment system ID was null", zap.String("systemID", cedarSystemID.String()))
-			continue
+		params := apideployments.NewDeploymentAddParams()
+		params.SetDeploymentAddRequest(&apimodels.DeploymentAddRequest{
+			Deployments: []*apimodels.Deployment{body},
+		})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Deployment.DeploymentAdd(params, c.auth)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
 		}
 
-		parsedUUID, err := uuid.Parse(*deployment.SystemID)
+		added := *deployment
+		added.ID = zero.StringFrom("")
+		added.SystemID = &cedarSystemID
+		return &added, nil
+	})
+}
+
+// UpdateDeployment makes a PUT call to the /deployment endpoint to change one of a system's deployments, and returns the updated deployment.
+// update is applied to a copy of the deployment as it currently is in CEDAR. concurrencyToken is the token of the deployment the change was based on; if the deployment has changed in CEDAR since,
+// the update is rejected with a ResourceConflictError.
+func (c *Client) UpdateDeployment(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	deploymentID string,
+	concurrencyToken string,
+	update func(deployment *models.CedarDeployment),
+) (*models.CedarDeployment, error) {
+	current, cedarDeployment, err := c.currentDeployment(ctx, cedarSystemID, deploymentID, concurrencyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// the current deployment may be shared with other readers, so it's copied rather than modified
+	deployment := *current
+	update(&deployment)
+	deployment.ID = current.ID
+
+	body := deploymentToCEDAR(cedarSystemID, &deployment, cedarDeployment)
+	if err := body.Validate(strfmt.Default); err != nil {
+		return nil, &apperrors.BadRequestError{Err: err}
+	}
+
+	return writeAndEvict(c, deploymentsEviction(cedarSystemID), func() (*models.CedarDeployment, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if err := cedarcoremock.UpdateDeployment(cedarSystemID, &deployment); err != nil {
+				return nil, err
+			}
+			return &deployment, nil
+		}
+
+		params := apideployments.NewDeploymentUpdateParams()
+		params.SetDeploymentUpdateRequest(&apimodels.DeploymentUpdateRequest{
+			Deployments: []*apimodels.Deployment{body},
+		})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Deployment.DeploymentUpdate(params, c.auth)
 		if err != nil {
-			appcontext.ZLogger(ctx).Error("problem parsing deployment system id", zap.Error(err), zap.String("systemID", cedarSystemID.String()))
-			continue
+			return nil, err
 		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
+		}
+
+		return &deployment, nil
+	})
+}
+
+// DeleteDeployment makes a DELETE call to the /deployment endpoint to remove one of a system's deployments, and returns the removed deployment.
+// concurrencyToken is the token of the deployment the caller read; if the deployment has changed in CEDAR since, the delete is rejected with
+// a ResourceConflictError.
+func (c *Client) DeleteDeployment(ctx context.Context, cedarSystemID uuid.UUID, deploymentID string, concurrencyToken string) (*models.CedarDeployment, error) {
+	deleted, _, err := c.currentDeployment(ctx, cedarSystemID, deploymentID, concurrencyToken)
+	if err != nil {
+		return nil, err
+	}
 
-		retDeployment := &models.CedarDeployment{
-			ID:                zero.StringFromPtr(deployment.ID),
-			Name:              zero.StringFromPtr(deployment.Name),
-			SystemID:          &parsedUUID,
-			StartDate:         zero.TimeFrom(time.Time(deployment.StartDate)),
-			EndDate:           zero.TimeFrom(time.Time(deployment.EndDate)),
-			IsHotSite:         zero.StringFrom(deployment.IsHotSite),
-			Description:       zero.StringFrom(deployment.Description),
-			ContractorName:    zero.StringFrom(deployment.ContractorName),
-			SystemVersion:     zero.StringFrom(deployment.SystemVersion),
-			HasProductionData: zero.StringFrom(deployment.HasProductionData),
-
-			// TODO - assumes no nulls in array returned from query
-			ReplicatedSystemElements: deployment.ReplicatedSystemElements,
-
-			DeploymentType:      zero.StringFrom(deployment.DeploymentType),
-			SystemName:          zero.StringFrom(deployment.SystemName),
-			DeploymentElementID: zero.StringFrom(deployment.DeploymentElementID),
-			State:               zero.StringFrom(deployment.State),
-			Status:              zero.StringFrom(deployment.Status),
-			WanType:             zero.StringFrom(deployment.WanType),
-		}
-
-		if deployment.DataCenter != nil {
-			retDataCenter := &models.CedarDataCenter{
-				ID:           zero.StringFrom(deployment.DataCenter.ID),
-				Name:         zero.StringFrom(deployment.DataCenter.Name),
-				Version:      zero.StringFrom(deployment.DataCenter.Version),
-				Description:  zero.StringFrom(deployment.DataCenter.Description),
-				State:        zero.StringFrom(deployment.DataCenter.State),
-				Status:       zero.StringFrom(deployment.DataCenter.Status),
-				StartDate:    zero.TimeFrom(time.Time(deployment.DataCenter.StartDate)),
-				EndDate:      zero.TimeFrom(time.Time(deployment.DataCenter.EndDate)),
-				Address1:     zero.StringFrom(deployment.DataCenter.Address1),
-				Address2:     zero.StringFrom(deployment.DataCenter.Address2),
-				City:         zero.StringFrom(deployment.DataCenter.City),
-				AddressState: zero.StringFrom(deployment.DataCenter.AddressState),
-				Zip:          zero.StringFrom(deployment.DataCenter.Zip),
+	return writeAndEvict(c, deploymentsEviction(cedarSystemID), func() (*models.CedarDeployment, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if err := cedarcoremock.DeleteDeployments(cedarSystemID, []string{deploymentID}); err != nil {
+				return nil, err
 			}
-			retDeployment.DataCenter = retDataCenter
+			return deleted, nil
 		}
 
-		retVal = append(retVal, retDeployment)
+		params := apideployments.NewDeploymentDeleteListParams()
+		params.SetID([]string{deploymentID})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Deployment.DeploymentDeleteList(params, c.auth)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
+		}
+
+		return deleted, nil
+	})
+}
+
+// currentDeployment fetches one of a system's deployments straight from CEDAR, bypassing the cache, and checks that it still matches concurrencyToken.
+// The deployment is also returned as CEDAR sent it (unless CEDAR is mocked), so the fields EASi doesn't read are kept when it's written back.
+//
+// CEDAR can't make a write conditional on a deployment's version, so there's still a short window in which a change made directly in CEDAR can be overwritten;
+// changes made through EASi are serialized by the system profile's section locks.
+func (c *Client) currentDeployment(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	deploymentID string,
+	concurrencyToken string,
+) (*models.CedarDeployment, *apimodels.Deployment, error) {
+	var deployment *models.CedarDeployment
+	var cedarDeployment *apimodels.Deployment
+
+	if c.mockEnabled {
+		deployments, err := c.GetDeployments(ctx, cedarSystemID, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		deployment, _ = lo.Find(deployments, func(deployment *models.CedarDeployment) bool {
+			return deployment.ID.String == deploymentID
+		})
+	} else {
+		cedarDeployments, err := c.fetchCEDARDeployments(cedarSystemID, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		cedarDeployment, _ = lo.Find(cedarDeployments, func(deployment *apimodels.Deployment) bool {
+			return deployment.ID != nil && *deployment.ID == deploymentID
+		})
+		if cedarDeployment != nil {
+			deployment, _ = deploymentFromCEDAR(ctx, cedarSystemID, cedarDeployment)
+		}
 	}
 
-	return retVal, nil
+	if deployment == nil {
+		return nil, nil, &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no deployment found"), Resource: models.CedarDeployment{}}
+	}
+
+	if deployment.ConcurrencyToken() != concurrencyToken {
+		return nil, nil, &apperrors.ResourceConflictError{
+			Err:        errors.New("the deployment has changed since it was read"),
+			Resource:   models.CedarDeployment{},
+			ResourceID: deploymentID,
+		}
+	}
+
+	return deployment, cedarDeployment, nil
+}
+
+// deploymentToCEDAR converts a deployment into the auto-generated struct CEDAR accepts. The fields EASi doesn't read are copied from base,
+// if it's given. Dates that aren't set are sent as zero dates, which are read back as unset.
+func deploymentToCEDAR(cedarSystemID uuid.UUID, deployment *models.CedarDeployment, base *apimodels.Deployment) *apimodels.Deployment {
+	retDeployment := &apimodels.Deployment{}
+	if base != nil {
+		*retDeployment = *base
+	}
+
+	retDeployment.ID = lo.ToPtr(deployment.ID.String)
+	retDeployment.Name = lo.ToPtr(deployment.Name.String)
+	retDeployment.SystemID = lo.ToPtr(formatIDForCEDAR(cedarSystemID))
+	retDeployment.StartDate = strfmt.Date(deployment.StartDate.Time)
+	retDeployment.EndDate = strfmt.Date(deployment.EndDate.Time)
+	retDeployment.IsHotSite = deployment.IsHotSite.String
+	retDeployment.Description = deployment.Description.String
+	retDeployment.ContractorName = deployment.ContractorName.String
+	retDeployment.SystemVersion = deployment.SystemVersion.String
+	retDeployment.HasProductionData = deployment.HasProductionData.String
+	retDeployment.ReplicatedSystemElements = deployment.ReplicatedSystemElements
+	retDeployment.DeploymentType = deployment.DeploymentType.String
+	retDeployment.DeploymentElementID = deployment.DeploymentElementID.String
+	retDeployment.State = deployment.State.String
+	retDeployment.Status = deployment.Status.String
+	retDeployment.WanType = deployment.WanType.String
+
+	switch {
+	case deployment.DataCenter == nil:
+		retDeployment.DataCenter = nil
+	case retDeployment.DataCenter == nil || retDeployment.DataCenter.ID != deployment.DataCenter.ID.String:
+		retDeployment.DataCenter = &apimodels.DataCenter{
+			ID: deployment.DataCenter.ID.String,
+		}
+	}
+
+	return retDeployment
 }
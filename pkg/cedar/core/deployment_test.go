@@ -0,0 +1,201 @@
+package cedarcore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	apimodels "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/models"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+type DeploymentTestSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func TestDeploymentTestSuite(t *testing.T) {
+	tests := &DeploymentTestSuite{
+		Suite:  suite.Suite{},
+		logger: zap.NewNop(),
+	}
+	suite.Run(t, tests)
+}
+
+func (s *DeploymentTestSuite) TestMockedDeploymentWrites() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+	c := NewClient(ctx, "fake", "fake", "1.0.0", true, TransportConfig{}, CacheConfig{})
+	cedarSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC3D}")
+	otherSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC4E}")
+
+	before, err := c.GetDeployments(ctx, cedarSystemID, nil)
+	s.NoError(err)
+
+	findDeployment := func(name string) *models.CedarDeployment {
+		deployments, err := c.GetDeployments(ctx, cedarSystemID, nil)
+		s.NoError(err)
+		deployment, _ := lo.Find(deployments, func(deployment *models.CedarDeployment) bool {
+			return deployment.Name.String == name
+		})
+		return deployment
+	}
+
+	s.Run("a deployment can be added", func() {
+		_, err := c.AddDeployment(ctx, cedarSystemID, &models.CedarDeployment{
+			Name:           zero.StringFrom("New Deployment"),
+			DeploymentType: zero.StringFrom("Production"),
+		})
+		s.NoError(err)
+
+		added := findDeployment("New Deployment")
+		s.NotNil(added)
+		s.NotEmpty(added.ID.String)
+		s.Equal(cedarSystemID, *added.SystemID)
+
+		otherDeployments, err := c.GetDeployments(ctx, otherSystemID, nil)
+		s.NoError(err)
+		s.Len(otherDeployments, len(before))
+	})
+
+	s.Run("invalid deployments are rejected", func() {
+		_, err := c.AddDeployment(ctx, cedarSystemID, &models.CedarDeployment{
+			Name:           zero.StringFrom("Invalid Deployment"),
+			DeploymentType: zero.StringFrom("Not a deployment type"),
+		})
+		var badRequestErr *apperrors.BadRequestError
+		s.ErrorAs(err, &badRequestErr)
+		s.Nil(findDeployment("Invalid Deployment"))
+	})
+
+	s.Run("a deployment can be updated using its current concurrency token", func() {
+		deployment := findDeployment("New Deployment")
+		_, err := c.UpdateDeployment(ctx, cedarSystemID, deployment.ID.String, deployment.ConcurrencyToken(), func(deployment *models.CedarDeployment) {
+			deployment.ContractorName = zero.StringFrom("Contractor")
+		})
+		s.NoError(err)
+
+		updated := findDeployment("New Deployment")
+		s.Equal(deployment.ID, updated.ID)
+		s.EqualValues("Contractor", updated.ContractorName.String)
+		s.NotEqual(deployment.ConcurrencyToken(), updated.ConcurrencyToken())
+
+		// the deployment that was read before the update isn't modified
+		s.False(deployment.ContractorName.Valid)
+	})
+
+	s.Run("updates based on an outdated deployment are rejected", func() {
+		deployment := findDeployment("New Deployment")
+		staleToken := (&models.CedarDeployment{ID: deployment.ID, Name: deployment.Name}).ConcurrencyToken()
+
+		_, err := c.UpdateDeployment(ctx, cedarSystemID, deployment.ID.String, staleToken, func(deployment *models.CedarDeployment) {
+			deployment.ContractorName = zero.StringFrom("Someone Else")
+		})
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+		s.EqualValues("Contractor", findDeployment("New Deployment").ContractorName.String)
+
+		_, err = c.DeleteDeployment(ctx, cedarSystemID, deployment.ID.String, staleToken)
+		s.ErrorAs(err, &conflictErr)
+		s.NotNil(findDeployment("New Deployment"))
+	})
+
+	s.Run("deployments that aren't on the system can't be changed", func() {
+		_, err := c.DeleteDeployment(ctx, cedarSystemID, "{NOT-A-DEPLOYMENT}", "token")
+		var notFoundErr *apperrors.ResourceNotFoundError
+		s.ErrorAs(err, &notFoundErr)
+	})
+
+	s.Run("a deployment can be deleted", func() {
+		deployment := findDeployment("New Deployment")
+		_, err := c.DeleteDeployment(ctx, cedarSystemID, deployment.ID.String, deployment.ConcurrencyToken())
+		s.NoError(err)
+		s.Nil(findDeployment("New Deployment"))
+
+		after, err := c.GetDeployments(ctx, cedarSystemID, nil)
+		s.NoError(err)
+		s.Len(after, len(before))
+	})
+}
+
+func (s *DeploymentTestSuite) TestUpdateDeploymentCallsCEDAR() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+	cedarSystemID := uuid.New()
+	deploymentID := "{11AB1A00-1234-5678-ABC1-1A001B00DEP1}"
+
+	var gets atomic.Int32
+	var updated *apimodels.Deployment
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/gateway/CEDAR Core API/1.0.0/deployment", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			gets.Add(1)
+			deployment := map[string]any{
+				"id":             deploymentID,
+				"name":           "Production",
+				"systemId":       formatIDForCEDAR(cedarSystemID),
+				"deploymentType": "Production",
+				"awsEnclave":     "AWS GovCloud",
+				"DataCenter":     map[string]any{"id": "{DATACENTER}", "name": "Data Center"},
+			}
+			if updated != nil {
+				deployment["contractorName"] = updated.ContractorName
+			}
+			s.NoError(json.NewEncoder(w).Encode(map[string]any{
+				"Deployments": []map[string]any{deployment},
+				"count":       1,
+			}))
+		case http.MethodPut:
+			var body apimodels.DeploymentUpdateRequest
+			s.NoError(json.NewDecoder(r.Body).Decode(&body))
+			s.Len(body.Deployments, 1)
+			updated = body.Deployments[0]
+			s.NoError(json.NewEncoder(w).Encode(apimodels.Response{Result: "success"}))
+		default:
+			s.Failf("unexpected call to CEDAR", "method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	s.NoError(err)
+	c := NewClient(ctx, serverURL.Host, "fake", "1.0.0", false, TransportConfig{}, CacheConfig{})
+
+	deployments, err := c.GetDeployments(ctx, cedarSystemID, nil)
+	s.NoError(err)
+	s.Len(deployments, 1)
+
+	_, err = c.UpdateDeployment(ctx, cedarSystemID, deploymentID, deployments[0].ConcurrencyToken(), func(deployment *models.CedarDeployment) {
+		deployment.ContractorName = zero.StringFrom("Contractor")
+	})
+	s.NoError(err)
+
+	s.Equal(deploymentID, *updated.ID)
+	s.Equal(formatIDForCEDAR(cedarSystemID), *updated.SystemID)
+	s.Equal("Production", *updated.Name)
+	s.Equal("Contractor", updated.ContractorName)
+	s.Equal("{DATACENTER}", updated.DataCenter.ID)
+	s.Equal("Data Center", updated.DataCenter.Name)
+
+	// fields EASi doesn't read are sent back unchanged
+	s.Equal("AWS GovCloud", updated.AwsEnclave)
+
+	// the deployment is read from CEDAR to check it hasn't changed, and again after the update since the cached deployments are out of date
+	deployments, err = c.GetDeployments(ctx, cedarSystemID, nil)
+	s.NoError(err)
+	s.EqualValues("Contractor", deployments[0].ContractorName.String)
+	s.EqualValues(3, gets.Load())
+}
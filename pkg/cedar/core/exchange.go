@@ -2,17 +2,27 @@ package cedarcore
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/client/exchange"
+	apimodels "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/models"
 	"github.com/cms-enterprise/easi-app/pkg/local/cedarcoremock"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 )
 
+// cedarExchangeOwnerTypeApplication is the owner type of a system on either side of an exchange
+const cedarExchangeOwnerTypeApplication = "application"
+
 // GetExchangesBySystem fetches a list of CEDAR exchange records for a given system
 func (c *Client) GetExchangesBySystem(ctx context.Context, cedarSystemID uuid.UUID) ([]*models.CedarExchange, error) {
 	if c.mockEnabled {
@@ -23,6 +33,13 @@ func (c *Client) GetExchangesBySystem(ctx context.Context, cedarSystemID uuid.UU
 		return nil, cedarcoremock.NoSystemFoundError()
 	}
 
+	return cachedRead(ctx, c.cache, cacheKey{endpoint: cacheEndpointExchanges, systemID: cedarSystemID}, func(ctx context.Context) ([]*models.CedarExchange, error) {
+		return c.fetchExchangesBySystem(ctx, cedarSystemID)
+	})
+}
+
+// fetchExchangesBySystem calls CEDAR for a system's exchanges
+func (c *Client) fetchExchangesBySystem(ctx context.Context, cedarSystemID uuid.UUID) ([]*models.CedarExchange, error) {
 	// Construct the parameters
 	params := exchange.NewExchangeFindListParams()
 	params.SetSystemID(formatIDForCEDAR(cedarSystemID))
@@ -39,56 +56,311 @@ func (c *Client) GetExchangesBySystem(ctx context.Context, cedarSystemID uuid.UU
 	retVal := make([]*models.CedarExchange, 0, len(resp.Payload.Exchanges))
 
 	for _, exch := range resp.Payload.Exchanges {
-		typeOfData := make([]*models.CedarExchangeTypeOfDataItem, 0, len(exch.TypeOfData))
-		for _, item := range exch.TypeOfData {
-			typeOfData = append(typeOfData, &models.CedarExchangeTypeOfDataItem{
-				ID:   zero.StringFrom(item.ID),
-				Name: zero.StringFrom(item.Name),
-			})
+		retVal = append(retVal, exchangeFromCEDAR(cedarSystemID, exch))
+	}
+	return retVal, nil
+}
+
+// exchangeFromCEDAR converts an exchange from the auto-generated struct to our own pkg/models struct, with its direction relative to the given system
+func exchangeFromCEDAR(cedarSystemID uuid.UUID, exch *apimodels.Exchange) *models.CedarExchange {
+	typeOfData := make([]*models.CedarExchangeTypeOfDataItem, 0, len(exch.TypeOfData))
+	for _, item := range exch.TypeOfData {
+		typeOfData = append(typeOfData, &models.CedarExchangeTypeOfDataItem{
+			ID:   zero.StringFrom(item.ID),
+			Name: zero.StringFrom(item.Name),
+		})
+	}
+
+	var direction models.ExchangeDirection
+	if exch.FromOwnerID == formatIDForCEDAR(cedarSystemID) {
+		direction = models.ExchangeDirectionSender
+	} else if exch.ToOwnerID == formatIDForCEDAR(cedarSystemID) {
+		direction = models.ExchangeDirectionReceiver
+	}
+
+	connectionFrequency := []zero.String{}
+	for _, v := range exch.ConnectionFrequency {
+		connectionFrequency = append(connectionFrequency, zero.StringFrom(v))
+	}
+
+	return &models.CedarExchange{
+		ConnectionFrequency:         connectionFrequency,
+		ContainsBankingData:         exch.ContainsBankingData,
+		ContainsBeneficiaryAddress:  exch.ContainsBeneficiaryAddress,
+		ContainsPhi:                 exch.ContainsPhi,
+		ContainsPii:                 exch.ContainsPii,
+		ContainsHealthDisparityData: exch.ContainsHealthDisparityData,
+		DataExchangeAgreement:       zero.StringFrom(exch.DataExchangeAgreement),
+		DataFormat:                  zero.StringFrom(exch.DataFormat),
+		DataFormatOther:             zero.StringFrom(exch.DataFormatOther),
+		ExchangeDescription:         zero.StringFrom(exch.ExchangeDescription),
+		ExchangeEndDate:             zero.TimeFrom(time.Time(exch.ExchangeEndDate)),
+		ExchangeID:                  zero.StringFrom(exch.ExchangeID),
+		ExchangeName:                zero.StringFrom(exch.ExchangeName),
+		ExchangeRetiredDate:         zero.TimeFrom(time.Time(exch.ExchangeRetiredDate)),
+		ExchangeStartDate:           zero.TimeFrom(time.Time(exch.ExchangeStartDate)),
+		ExchangeState:               zero.StringFrom(exch.ExchangeState),
+		ExchangeVersion:             zero.StringFrom(exch.ExchangeVersion),
+		ExchangeDirection:           direction,
+		FromOwnerID:                 zero.StringFrom(exch.FromOwnerID),
+		FromOwnerName:               zero.StringFrom(exch.FromOwnerName),
+		FromOwnerType:               zero.StringFrom(exch.FromOwnerType),
+		IsBeneficiaryMailingFile:    exch.IsBeneficiaryMailingFile,
+		NumOfRecords:                zero.StringFrom(exch.NumOfRecords),
+		SharedViaAPI:                exch.SharedViaAPI,
+		ToOwnerID:                   zero.StringFrom(exch.ToOwnerID),
+		ToOwnerName:                 zero.StringFrom(exch.ToOwnerName),
+		ToOwnerType:                 zero.StringFrom(exch.ToOwnerType),
+		TypeOfData:                  typeOfData,
+	}
+}
+
+// AddExchange makes a POST call to the /exchange endpoint to add an exchange to a system, and returns the exchange as it was sent.
+// The system is filled in as the sender or receiver, depending on the exchange's direction, and CEDAR assigns the new exchange's ID,
+// so any ID on the exchange is ignored; CEDAR doesn't return the ID, so the returned exchange only has one when CEDAR is mocked.
+func (c *Client) AddExchange(ctx context.Context, cedarSystemID uuid.UUID, exch *models.CedarExchange) (*models.CedarExchange, error) {
+	// the caller's exchange is copied rather than modified
+	added := *exch
+	added.ExchangeID = zero.StringFrom("")
+	if err := c.setExchangeOwner(ctx, cedarSystemID, &added); err != nil {
+		return nil, err
+	}
+
+	return writeAndEvict(c, exchangesEviction(), func() (*models.CedarExchange, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			return cedarcoremock.AddExchange(cedarSystemID, &added), nil
 		}
 
-		var direction models.ExchangeDirection
-		if exch.FromOwnerID == formatIDForCEDAR(cedarSystemID) {
-			direction = models.ExchangeDirectionSender
-		} else if exch.ToOwnerID == formatIDForCEDAR(cedarSystemID) {
-			direction = models.ExchangeDirectionReceiver
+		params := exchange.NewExchangeAddParams()
+		params.SetExchangeAddRequest(&apimodels.ExchangeAddRequest{
+			Exchanges: []*apimodels.Exchange{exchangeToCEDAR(&added, nil)},
+		})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Exchange.ExchangeAdd(params, c.auth)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
 		}
 
-		connectionFrequency := []zero.String{}
-		for _, v := range exch.ConnectionFrequency {
-			connectionFrequency = append(connectionFrequency, zero.StringFrom(v))
+		return &added, nil
+	})
+}
+
+// UpdateExchange makes a PUT call to the /exchange endpoint to change one of a system's exchanges, and returns the updated exchange. update is
+// applied to a copy of the exchange as it currently is in CEDAR, after which the system is filled in as the sender or receiver, depending on
+// the exchange's direction.
+// concurrencyToken is the token of the exchange the change was based on; if the exchange has changed in CEDAR since, the update is rejected
+// with a ResourceConflictError.
+func (c *Client) UpdateExchange(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	exchangeID string,
+	concurrencyToken string,
+	update func(exch *models.CedarExchange),
+) (*models.CedarExchange, error) {
+	current, cedarExchange, err := c.currentExchange(ctx, cedarSystemID, exchangeID, concurrencyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// the current exchange may be shared with other readers, so it's copied rather than modified
+	updated := *current
+	update(&updated)
+	updated.ExchangeID = current.ExchangeID
+	if err := c.setExchangeOwner(ctx, cedarSystemID, &updated); err != nil {
+		return nil, err
+	}
+
+	return writeAndEvict(c, exchangesEviction(), func() (*models.CedarExchange, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if err := cedarcoremock.UpdateExchange(cedarSystemID, &updated); err != nil {
+				return nil, err
+			}
+			return &updated, nil
 		}
 
-		retVal = append(retVal, &models.CedarExchange{
-			ConnectionFrequency:         connectionFrequency,
-			ContainsBankingData:         exch.ContainsBankingData,
-			ContainsBeneficiaryAddress:  exch.ContainsBeneficiaryAddress,
-			ContainsPhi:                 exch.ContainsPhi,
-			ContainsPii:                 exch.ContainsPii,
-			ContainsHealthDisparityData: exch.ContainsHealthDisparityData,
-			DataExchangeAgreement:       zero.StringFrom(exch.DataExchangeAgreement),
-			DataFormat:                  zero.StringFrom(exch.DataFormat),
-			DataFormatOther:             zero.StringFrom(exch.DataFormatOther),
-			ExchangeDescription:         zero.StringFrom(exch.ExchangeDescription),
-			ExchangeEndDate:             zero.TimeFrom(time.Time(exch.ExchangeEndDate)),
-			ExchangeID:                  zero.StringFrom(exch.ExchangeID),
-			ExchangeName:                zero.StringFrom(exch.ExchangeName),
-			ExchangeRetiredDate:         zero.TimeFrom(time.Time(exch.ExchangeRetiredDate)),
-			ExchangeStartDate:           zero.TimeFrom(time.Time(exch.ExchangeStartDate)),
-			ExchangeState:               zero.StringFrom(exch.ExchangeState),
-			ExchangeVersion:             zero.StringFrom(exch.ExchangeVersion),
-			ExchangeDirection:           direction,
-			FromOwnerID:                 zero.StringFrom(exch.FromOwnerID),
-			FromOwnerName:               zero.StringFrom(exch.FromOwnerName),
-			FromOwnerType:               zero.StringFrom(exch.FromOwnerType),
-			IsBeneficiaryMailingFile:    exch.IsBeneficiaryMailingFile,
-			NumOfRecords:                zero.StringFrom(exch.NumOfRecords),
-			SharedViaAPI:                exch.SharedViaAPI,
-			ToOwnerID:                   zero.StringFrom(exch.ToOwnerID),
-			ToOwnerName:                 zero.StringFrom(exch.ToOwnerName),
-			ToOwnerType:                 zero.StringFrom(exch.ToOwnerType),
-			TypeOfData:                  typeOfData,
+		params := exchange.NewExchangeUpdateParams()
+		params.SetExchangeUpdateRequest(&apimodels.ExchangeUpdateRequest{
+			Exchanges: []*apimodels.Exchange{exchangeToCEDAR(&updated, cedarExchange)},
 		})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Exchange.ExchangeUpdate(params, c.auth)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
+		}
+
+		return &updated, nil
+	})
+}
+
+// DeleteExchange makes a DELETE call to the /exchange endpoint to remove one of a system's exchanges, and returns the removed exchange.
+// concurrencyToken is the token of the exchange the caller read; if the exchange has changed in CEDAR since, the delete is rejected with
+// a ResourceConflictError.
+func (c *Client) DeleteExchange(ctx context.Context, cedarSystemID uuid.UUID, exchangeID string, concurrencyToken string) (*models.CedarExchange, error) {
+	deleted, _, err := c.currentExchange(ctx, cedarSystemID, exchangeID, concurrencyToken)
+	if err != nil {
+		return nil, err
 	}
-	return retVal, nil
+
+	return writeAndEvict(c, exchangesEviction(), func() (*models.CedarExchange, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if err := cedarcoremock.DeleteExchanges(cedarSystemID, []string{exchangeID}); err != nil {
+				return nil, err
+			}
+			return deleted, nil
+		}
+
+		params := exchange.NewExchangeDeleteListParams()
+		params.SetID([]string{exchangeID})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Exchange.ExchangeDeleteList(params, c.auth)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
+		}
+
+		return deleted, nil
+	})
+}
+
+// currentExchange fetches one of a system's exchanges straight from CEDAR, bypassing the cache, and checks that it still matches concurrencyToken.
+// The exchange is also returned as CEDAR sent it (unless CEDAR is mocked), so the fields EASi doesn't read are kept when it's written back.
+// As with deployments, CEDAR can't make the write itself conditional, so the section locks are what keep edits made through EASi from overlapping.
+func (c *Client) currentExchange(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	exchangeID string,
+	concurrencyToken string,
+) (*models.CedarExchange, *apimodels.Exchange, error) {
+	var exch *models.CedarExchange
+	var cedarExchange *apimodels.Exchange
+
+	if c.mockEnabled {
+		exchanges, err := c.GetExchangesBySystem(ctx, cedarSystemID)
+		if err != nil {
+			return nil, nil, err
+		}
+		exch, _ = lo.Find(exchanges, func(exch *models.CedarExchange) bool {
+			return exch.ExchangeID.String == exchangeID
+		})
+	} else {
+		params := exchange.NewExchangeFindByIDParams()
+		params.SetID(exchangeID)
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.Exchange.ExchangeFindByID(params, c.auth)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// the exchange has to be one of the system's, not just any exchange
+		if resp.Payload != nil && resp.Payload.ExchangeID != "" && exchangeBelongsToSystem(cedarSystemID, resp.Payload) {
+			cedarExchange = resp.Payload
+			exch = exchangeFromCEDAR(cedarSystemID, cedarExchange)
+		}
+	}
+
+	if exch == nil {
+		return nil, nil, &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no exchange found"), Resource: models.CedarExchange{}}
+	}
+
+	if exch.ConcurrencyToken() != concurrencyToken {
+		return nil, nil, &apperrors.ResourceConflictError{
+			Err:        errors.New("the exchange has changed since it was read"),
+			Resource:   models.CedarExchange{},
+			ResourceID: exchangeID,
+		}
+	}
+
+	return exch, cedarExchange, nil
+}
+
+func exchangeBelongsToSystem(cedarSystemID uuid.UUID, exch *apimodels.Exchange) bool {
+	systemID := formatIDForCEDAR(cedarSystemID)
+	return strings.EqualFold(exch.FromOwnerID, systemID) || strings.EqualFold(exch.ToOwnerID, systemID)
+}
+
+// setExchangeOwner fills in the system as the sender or receiver of an exchange, depending on the exchange's direction
+func (c *Client) setExchangeOwner(ctx context.Context, cedarSystemID uuid.UUID, exch *models.CedarExchange) error {
+	system, err := c.GetSystem(ctx, cedarSystemID)
+	if err != nil {
+		return err
+	}
+	if system == nil {
+		return &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no system found"), Resource: models.CedarSystem{}}
+	}
+
+	switch exch.ExchangeDirection {
+	case models.ExchangeDirectionSender:
+		exch.FromOwnerID = zero.StringFrom(formatIDForCEDAR(cedarSystemID))
+		exch.FromOwnerName = system.Name
+		exch.FromOwnerType = zero.StringFrom(cedarExchangeOwnerTypeApplication)
+	case models.ExchangeDirectionReceiver:
+		exch.ToOwnerID = zero.StringFrom(formatIDForCEDAR(cedarSystemID))
+		exch.ToOwnerName = system.Name
+		exch.ToOwnerType = zero.StringFrom(cedarExchangeOwnerTypeApplication)
+	default:
+		return &apperrors.BadRequestError{Err: errors.New("an exchange's direction must be SENDER or RECEIVER")}
+	}
+
+	return nil
+}
+
+// exchangeToCEDAR converts an exchange into the auto-generated struct CEDAR accepts. The fields EASi doesn't read are copied from base,
+// if it's given. Dates that aren't set are sent as zero dates, which are read back as unset.
+func exchangeToCEDAR(exch *models.CedarExchange, base *apimodels.Exchange) *apimodels.Exchange {
+	retExchange := &apimodels.Exchange{}
+	if base != nil {
+		*retExchange = *base
+	}
+
+	retExchange.ExchangeID = exch.ExchangeID.String
+	retExchange.ExchangeName = exch.ExchangeName.String
+	retExchange.ExchangeDescription = exch.ExchangeDescription.String
+	retExchange.ExchangeState = exch.ExchangeState.String
+	retExchange.ExchangeVersion = exch.ExchangeVersion.String
+	retExchange.ExchangeStartDate = strfmt.Date(exch.ExchangeStartDate.Time)
+	retExchange.ExchangeEndDate = strfmt.Date(exch.ExchangeEndDate.Time)
+	retExchange.ExchangeRetiredDate = strfmt.Date(exch.ExchangeRetiredDate.Time)
+	retExchange.FromOwnerID = exch.FromOwnerID.String
+	retExchange.FromOwnerName = exch.FromOwnerName.String
+	retExchange.FromOwnerType = exch.FromOwnerType.String
+	retExchange.ToOwnerID = exch.ToOwnerID.String
+	retExchange.ToOwnerName = exch.ToOwnerName.String
+	retExchange.ToOwnerType = exch.ToOwnerType.String
+	retExchange.ConnectionFrequency = lo.Map(exch.ConnectionFrequency, func(frequency zero.String, _ int) string {
+		return frequency.String
+	})
+	retExchange.DataExchangeAgreement = exch.DataExchangeAgreement.String
+	retExchange.DataFormat = exch.DataFormat.String
+	retExchange.DataFormatOther = exch.DataFormatOther.String
+	retExchange.NumOfRecords = exch.NumOfRecords.String
+	retExchange.ContainsBankingData = exch.ContainsBankingData
+	retExchange.ContainsBeneficiaryAddress = exch.ContainsBeneficiaryAddress
+	retExchange.ContainsPhi = exch.ContainsPhi
+	retExchange.ContainsPii = exch.ContainsPii
+	retExchange.ContainsHealthDisparityData = exch.ContainsHealthDisparityData
+	retExchange.IsBeneficiaryMailingFile = exch.IsBeneficiaryMailingFile
+	retExchange.SharedViaAPI = exch.SharedViaAPI
+	retExchange.TypeOfData = lo.Map(exch.TypeOfData, func(item *models.CedarExchangeTypeOfDataItem, _ int) *apimodels.ExchangeTypeOfDataItems0 {
+		return &apimodels.ExchangeTypeOfDataItems0{
+			ID:   item.ID.String,
+			Name: item.Name.String,
+		}
+	})
+
+	return retExchange
 }
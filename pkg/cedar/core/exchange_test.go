@@ -0,0 +1,222 @@
+package cedarcore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	apimodels "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/models"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+type ExchangeTestSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func TestExchangeTestSuite(t *testing.T) {
+	tests := &ExchangeTestSuite{
+		Suite:  suite.Suite{},
+		logger: zap.NewNop(),
+	}
+	suite.Run(t, tests)
+}
+
+func (s *ExchangeTestSuite) TestMockedExchangeWrites() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+	c := NewClient(ctx, "fake", "fake", "1.0.0", true, TransportConfig{}, CacheConfig{})
+	cedarSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC3D}")
+	partnerSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC4E}")
+
+	before, err := c.GetExchangesBySystem(ctx, cedarSystemID)
+	s.NoError(err)
+
+	findExchange := func(name string) *models.CedarExchange {
+		exchanges, err := c.GetExchangesBySystem(ctx, cedarSystemID)
+		s.NoError(err)
+		exchange, _ := lo.Find(exchanges, func(exchange *models.CedarExchange) bool {
+			return exchange.ExchangeName.String == name
+		})
+		return exchange
+	}
+
+	s.Run("an exchange can be added, with the system filled in as the sender", func() {
+		_, err := c.AddExchange(ctx, cedarSystemID, &models.CedarExchange{
+			ExchangeName:      zero.StringFrom("New Exchange"),
+			ExchangeDirection: models.ExchangeDirectionSender,
+			ToOwnerID:         zero.StringFrom(formatIDForCEDAR(partnerSystemID)),
+			ToOwnerType:       zero.StringFrom("application"),
+		})
+		s.NoError(err)
+
+		added := findExchange("New Exchange")
+		s.NotNil(added)
+		s.NotEmpty(added.ExchangeID.String)
+		s.EqualValues(formatIDForCEDAR(cedarSystemID), added.FromOwnerID.String)
+		s.EqualValues("Strategic Work Information Management System", added.FromOwnerName.String)
+		s.EqualValues("application", added.FromOwnerType.String)
+	})
+
+	s.Run("exchanges must have a direction", func() {
+		_, err := c.AddExchange(ctx, cedarSystemID, &models.CedarExchange{
+			ExchangeName: zero.StringFrom("Undirected Exchange"),
+		})
+		var badRequestErr *apperrors.BadRequestError
+		s.ErrorAs(err, &badRequestErr)
+		s.Nil(findExchange("Undirected Exchange"))
+	})
+
+	s.Run("an exchange can be updated using its current concurrency token", func() {
+		exchange := findExchange("New Exchange")
+		_, err := c.UpdateExchange(ctx, cedarSystemID, exchange.ExchangeID.String, exchange.ConcurrencyToken(), func(exchange *models.CedarExchange) {
+			exchange.ExchangeDirection = models.ExchangeDirectionReceiver
+			exchange.FromOwnerID, exchange.ToOwnerID = exchange.ToOwnerID, zero.String{}
+			exchange.FromOwnerType, exchange.ToOwnerType = exchange.ToOwnerType, zero.String{}
+			exchange.DataFormat = zero.StringFrom("JSON")
+		})
+		s.NoError(err)
+
+		updated := findExchange("New Exchange")
+		s.Equal(exchange.ExchangeID, updated.ExchangeID)
+		s.EqualValues("JSON", updated.DataFormat.String)
+		s.EqualValues(formatIDForCEDAR(cedarSystemID), updated.ToOwnerID.String)
+		s.EqualValues(formatIDForCEDAR(partnerSystemID), updated.FromOwnerID.String)
+		s.NotEqual(exchange.ConcurrencyToken(), updated.ConcurrencyToken())
+
+		// the exchange that was read before the update isn't modified
+		s.False(exchange.DataFormat.Valid)
+	})
+
+	s.Run("updates based on an outdated exchange are rejected", func() {
+		exchange := findExchange("New Exchange")
+		staleToken := (&models.CedarExchange{ExchangeID: exchange.ExchangeID, ExchangeName: exchange.ExchangeName}).ConcurrencyToken()
+
+		_, err := c.UpdateExchange(ctx, cedarSystemID, exchange.ExchangeID.String, staleToken, func(exchange *models.CedarExchange) {
+			exchange.DataFormat = zero.StringFrom("XML")
+		})
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+		s.EqualValues("JSON", findExchange("New Exchange").DataFormat.String)
+
+		_, err = c.DeleteExchange(ctx, cedarSystemID, exchange.ExchangeID.String, staleToken)
+		s.ErrorAs(err, &conflictErr)
+		s.NotNil(findExchange("New Exchange"))
+	})
+
+	s.Run("exchanges that aren't on the system can't be changed", func() {
+		_, err := c.DeleteExchange(ctx, cedarSystemID, "{NOT-AN-EXCHANGE}", "token")
+		var notFoundErr *apperrors.ResourceNotFoundError
+		s.ErrorAs(err, &notFoundErr)
+	})
+
+	s.Run("an exchange can be deleted", func() {
+		exchange := findExchange("New Exchange")
+		_, err := c.DeleteExchange(ctx, cedarSystemID, exchange.ExchangeID.String, exchange.ConcurrencyToken())
+		s.NoError(err)
+		s.Nil(findExchange("New Exchange"))
+
+		after, err := c.GetExchangesBySystem(ctx, cedarSystemID)
+		s.NoError(err)
+		s.Len(after, len(before))
+	})
+}
+
+func (s *ExchangeTestSuite) TestUpdateExchangeCallsCEDAR() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+	cedarSystemID := uuid.New()
+	otherSystemID := uuid.New()
+	exchangeID := "{11AB1A00-1234-5678-ABC1-1A001B00EXC1}"
+
+	var updated *apimodels.Exchange
+	cedarExchange := func() map[string]any {
+		exchange := map[string]any{
+			"exchangeId":              exchangeID,
+			"exchangeName":            "Exchange",
+			"fromOwnerId":             formatIDForCEDAR(cedarSystemID),
+			"fromOwnerName":           "System",
+			"fromOwnerType":           "application",
+			"toOwnerId":               "{PARTNER}",
+			"toOwnerName":             "Partner",
+			"toOwnerType":             "organization",
+			"exchangeNetworkProtocol": []string{"HTTPS"},
+		}
+		if updated != nil {
+			exchange["dataFormat"] = updated.DataFormat
+		}
+		return exchange
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/gateway/CEDAR Core API/1.0.0")
+
+		switch {
+		case r.Method == http.MethodGet && path == "/system/summary":
+			s.NoError(json.NewEncoder(w).Encode(map[string]any{
+				"SystemSummary": []map[string]any{{"id": formatIDForCEDAR(cedarSystemID), "ictObjectId": formatIDForCEDAR(cedarSystemID), "name": "System"}},
+				"count":         1,
+			}))
+		case r.Method == http.MethodGet && path == "/exchange":
+			s.NoError(json.NewEncoder(w).Encode(map[string]any{
+				"Exchanges": []map[string]any{cedarExchange()},
+				"count":     1,
+			}))
+		case r.Method == http.MethodGet && path == "/exchange/"+exchangeID:
+			s.NoError(json.NewEncoder(w).Encode(cedarExchange()))
+		case r.Method == http.MethodPut && path == "/exchange":
+			var body apimodels.ExchangeUpdateRequest
+			s.NoError(json.NewDecoder(r.Body).Decode(&body))
+			s.Len(body.Exchanges, 1)
+			updated = body.Exchanges[0]
+			s.NoError(json.NewEncoder(w).Encode(apimodels.Response{Result: "success"}))
+		default:
+			s.Failf("unexpected call to CEDAR", "%s %s", r.Method, path)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	s.NoError(err)
+	c := NewClient(ctx, serverURL.Host, "fake", "1.0.0", false, TransportConfig{}, CacheConfig{})
+
+	exchanges, err := c.GetExchangesBySystem(ctx, cedarSystemID)
+	s.NoError(err)
+	s.Len(exchanges, 1)
+
+	s.Run("exchanges on other systems can't be changed", func() {
+		_, err := c.DeleteExchange(ctx, otherSystemID, exchangeID, exchanges[0].ConcurrencyToken())
+		var notFoundErr *apperrors.ResourceNotFoundError
+		s.ErrorAs(err, &notFoundErr)
+	})
+
+	_, err = c.UpdateExchange(ctx, cedarSystemID, exchangeID, exchanges[0].ConcurrencyToken(), func(exchange *models.CedarExchange) {
+		exchange.DataFormat = zero.StringFrom("JSON")
+	})
+	s.NoError(err)
+
+	s.Equal(exchangeID, updated.ExchangeID)
+	s.Equal("JSON", updated.DataFormat)
+	s.Equal(formatIDForCEDAR(cedarSystemID), updated.FromOwnerID)
+	s.Equal("{PARTNER}", updated.ToOwnerID)
+	s.Equal("organization", updated.ToOwnerType)
+
+	// fields EASi doesn't read are sent back unchanged
+	s.Equal([]string{"HTTPS"}, updated.ExchangeNetworkProtocol)
+
+	// the cached exchanges are out of date after the update
+	exchanges, err = c.GetExchangesBySystem(ctx, cedarSystemID)
+	s.NoError(err)
+	s.EqualValues("JSON", exchanges[0].DataFormat.String)
+}
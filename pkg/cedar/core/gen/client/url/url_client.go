@@ -30,11 +30,99 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	URLAdd(params *URLAddParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*URLAddOK, error)
+
+	URLDeleteList(params *URLDeleteListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*URLDeleteListOK, error)
+
 	URLFindList(params *URLFindListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*URLFindListOK, error)
 
+	URLUpdate(params *URLUpdateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*URLUpdateOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+URLAdd adds a list of new u r ls to an object in c e d a r
+
+Add a list of new URLs to an object in CEDAR. This interface takes in an array of URLs.
+*/
+func (a *Client) URLAdd(params *URLAddParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*URLAddOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewURLAddParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "urlAdd",
+		Method:             "POST",
+		PathPattern:        "/url/{id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &URLAddReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*URLAddOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for urlAdd: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+URLDeleteList deletes a list of an object s u r ls based on the url ids passed in
+
+Delete a list of an object's URLs based on the urlIds passed in. This interface takes an array of urlIds.
+*/
+func (a *Client) URLDeleteList(params *URLDeleteListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*URLDeleteListOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewURLDeleteListParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "urlDeleteList",
+		Method:             "DELETE",
+		PathPattern:        "/url/{id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &URLDeleteListReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*URLDeleteListOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for urlDeleteList: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 URLFindList retrieves a list of u r ls associated with an object in c e d a r
 
@@ -76,6 +164,47 @@ func (a *Client) URLFindList(params *URLFindListParams, authInfo runtime.ClientA
 	panic(msg)
 }
 
+/*
+URLUpdate updates a list of an object s u r ls in c e d a r
+
+Update a list of an object's URLs in CEDAR. This interface takes in an array of URLs.
+*/
+func (a *Client) URLUpdate(params *URLUpdateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*URLUpdateOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewURLUpdateParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "urlUpdate",
+		Method:             "PUT",
+		PathPattern:        "/url/{id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &URLUpdateReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*URLUpdateOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for urlUpdate: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
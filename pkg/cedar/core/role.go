@@ -32,22 +32,15 @@ const (
 	cedarBusinessOwnerRoleName = "Business Owner"
 )
 
-// TODO: cache this properly
-var cedarBusinessOwnerRoleTypeID string
-
 // getCedarBusinessOwnerRoleTypeID is a helper for fetching the Business Owner role type ID because role type IDs will differ per ENV
 func getCedarBusinessOwnerRoleTypeID(ctx context.Context, c *Client) (string, error) {
-	// grab global and return role ID to prevent additional calls to CEDAR
-	if cedarBusinessOwnerRoleTypeID != "" {
-		return cedarBusinessOwnerRoleTypeID, nil
-	}
+	// role types are cached, so this doesn't call CEDAR each time
 	roleTypes, err := c.GetRoleTypes(ctx)
 	if err != nil {
 		return "", err
 	}
 	for _, role := range roleTypes {
 		if role.Name.String == cedarBusinessOwnerRoleName {
-			cedarBusinessOwnerRoleTypeID = role.ID.String
 			return role.ID.String, nil
 		}
 	}
@@ -117,6 +110,15 @@ func (c *Client) getRolesByObjectID(ctx context.Context, cedarSystemID uuid.UUID
 		return nil, errors.New("missing cedar system version id")
 	}
 
+	key := cacheKey{endpoint: cacheEndpointRoles, systemID: cedarSystemID, params: lo.FromPtr(roleTypeID)}
+	return cachedRead(ctx, c.cache, key, func(ctx context.Context) ([]*models.CedarRole, error) {
+		return c.fetchRolesByObjectID(ctx, cedarSystemID, objectID, roleTypeID)
+	})
+}
+
+// fetchRolesByObjectID calls CEDAR for a system's roles, optionally only those of one role type
+func (c *Client) fetchRolesByObjectID(ctx context.Context, cedarSystemID uuid.UUID, objectID string, roleTypeID *string) ([]*models.CedarRole, error) {
+
 	// Construct the parameters
 	params := apiroles.NewRoleFindByIDParams()
 	params.SetApplication(cedarRoleApplication)
@@ -200,6 +202,11 @@ func (c *Client) GetRoleTypes(ctx context.Context) ([]*models.CedarRoleType, err
 		return cedarcoremock.GetRoleTypes(), nil
 	}
 
+	return cachedRead(ctx, c.cache, cacheKey{endpoint: cacheEndpointRoleTypes}, c.fetchRoleTypes)
+}
+
+// fetchRoleTypes calls CEDAR for the list of supported role types
+func (c *Client) fetchRoleTypes(ctx context.Context) ([]*models.CedarRoleType, error) {
 	// Construct the parameters
 	params := apiroles.NewRoleTypeFindParams()
 	params.SetApplication(cedarRoleApplication)
@@ -270,6 +277,9 @@ func (c *Client) SetRolesForUser(ctx context.Context, cedarSystemID uuid.UUID, e
 		appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
 	}
 
+	// the user's current roles need to come from CEDAR, not the cache, to work out which roles to add and delete
+	c.evictRolesForUser(cedarSystemID, euaUserID)
+
 	roleTypesBefore := []models.CedarRoleType{}
 	roleTypesAfter := []models.CedarRoleType{}
 
@@ -359,7 +369,10 @@ func (c *Client) SetRolesForUser(ctx context.Context, cedarSystemID uuid.UUID, e
 		return nil
 	})
 
-	if err := g.Wait(); err != nil {
+	err = g.Wait()
+	// evict even if only some of the changes were made, since the cached roles may no longer match CEDAR's
+	c.evictRolesForUser(cedarSystemID, euaUserID)
+	if err != nil {
 		return nil, err
 	}
 
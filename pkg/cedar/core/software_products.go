@@ -22,6 +22,14 @@ func (c *Client) GetSoftwareProductsBySystem(ctx context.Context, cedarSystemID
 		}
 		return nil, cedarcoremock.NoSystemFoundError()
 	}
+
+	return cachedRead(ctx, c.cache, cacheKey{endpoint: cacheEndpointSoftwareProducts, systemID: cedarSystemID}, func(ctx context.Context) (*models.CedarSoftwareProducts, error) {
+		return c.fetchSoftwareProductsBySystem(ctx, cedarSystemID)
+	})
+}
+
+// fetchSoftwareProductsBySystem calls CEDAR for a system's software products
+func (c *Client) fetchSoftwareProductsBySystem(ctx context.Context, cedarSystemID uuid.UUID) (*models.CedarSoftwareProducts, error) {
 	cedarSystem, err := c.GetSystem(ctx, cedarSystemID)
 	if err != nil {
 		return nil, err
@@ -32,6 +32,13 @@ func (c *Client) GetSystemDetail(ctx context.Context, cedarSystemID uuid.UUID) (
 		}, nil
 	}
 
+	return cachedRead(ctx, c.cache, cacheKey{endpoint: cacheEndpointSystemDetail, systemID: cedarSystemID}, func(ctx context.Context) (*models.CedarSystemDetails, error) {
+		return c.fetchSystemDetail(ctx, cedarSystemID)
+	})
+}
+
+// fetchSystemDetail calls CEDAR for a system's detail
+func (c *Client) fetchSystemDetail(ctx context.Context, cedarSystemID uuid.UUID) (*models.CedarSystemDetails, error) {
 	cedarSystem, err := c.GetSystem(ctx, cedarSystemID)
 	if err != nil {
 		return nil, err
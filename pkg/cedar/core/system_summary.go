@@ -3,10 +3,12 @@ package cedarcore
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
@@ -17,10 +19,8 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/models"
 )
 
-// GetSystemSummary makes a GET call to the /system/summary endpoint
-// If `tryCache` is true and `euaUserID` is nil, we will try to hit the cache. Otherwise, we will make an API call as we cannot filter on EUA on our end
+// GetSystemSummary makes a GET call to the /system/summary endpoint. Responses are cached per combination of filters.
 func (c *Client) GetSystemSummary(ctx context.Context, opts ...systemSummaryParamFilterOpt) ([]*models.CedarSystem, error) {
-	logger := appcontext.ZLogger(ctx)
 	// Construct the parameters
 	params := apisystems.NewSystemSummaryFindListParams()
 
@@ -55,6 +55,25 @@ func (c *Client) GetSystemSummary(ctx context.Context, opts ...systemSummaryPara
 		return cedarcoremock.GetActiveSystems(), nil
 	}
 
+	key := cacheKey{
+		endpoint:  cacheEndpointSystemSummary,
+		euaUserID: lo.FromPtr(params.UserName),
+		params: fmt.Sprintf(
+			"state=%s&includeInSurvey=%s&belongsTo=%s",
+			lo.FromPtr(params.State),
+			formatOptionalBool(params.IncludeInSurvey),
+			lo.FromPtr(params.BelongsTo),
+		),
+	}
+	filtered := len(opts) > 0
+	return cachedRead(ctx, c.cache, key, func(ctx context.Context) ([]*models.CedarSystem, error) {
+		return c.fetchSystemSummary(ctx, params, filtered)
+	})
+}
+
+// fetchSystemSummary calls CEDAR for the system summary. filtered is whether any filters other than the defaults were set.
+func (c *Client) fetchSystemSummary(ctx context.Context, params *apisystems.SystemSummaryFindListParams, filtered bool) ([]*models.CedarSystem, error) {
+	logger := appcontext.ZLogger(ctx)
 	params.HTTPClient = c.hc
 
 	// Make the API call
@@ -70,7 +89,7 @@ func (c *Client) GetSystemSummary(ctx context.Context, opts ...systemSummaryPara
 	// This may look like an odd block of code, but should never expect an empty response from CEDAR with the
 	// hard-coded parameters we have set when we are not filtering.
 	// This is defensive programming against this case.
-	if len(resp.Payload.SystemSummary) == 0 && len(opts) < 1 {
+	if len(resp.Payload.SystemSummary) == 0 && !filtered {
 		return []*models.CedarSystem{}, fmt.Errorf("empty response array received")
 	}
 
@@ -114,7 +133,7 @@ func (c *Client) GetSystemSummary(ctx context.Context, opts ...systemSummaryPara
 	return retVal, nil
 }
 
-// GetSystem retrieves a CEDAR system by ID (IctObjectID)
+// GetSystem retrieves a CEDAR system by ID (IctObjectID) from the system summary, including deactivated systems
 func (c *Client) GetSystem(ctx context.Context, systemID uuid.UUID) (*models.CedarSystem, error) {
 	if c.mockEnabled {
 		appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
@@ -139,6 +158,14 @@ func (c *Client) GetSystem(ctx context.Context, systemID uuid.UUID) (*models.Ced
 	return nil, &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no system found"), Resource: models.CedarSystem{}}
 }
 
+// formatOptionalBool formats an optional filter for a cache key, distinguishing an unset filter from false
+func formatOptionalBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
 type systemSummaryParamFilterOpt func(*apisystems.SystemSummaryFindListParams)
 
 type systemSummaryOpts struct{}
@@ -23,6 +23,13 @@ func (c *Client) GetThreat(ctx context.Context, cedarSystemID uuid.UUID) ([]*mod
 		return nil, cedarcoremock.NoSystemFoundError()
 	}
 
+	return cachedRead(ctx, c.cache, cacheKey{endpoint: cacheEndpointThreats, systemID: cedarSystemID}, func(ctx context.Context) ([]*models.CedarThreat, error) {
+		return c.fetchThreat(ctx, cedarSystemID)
+	})
+}
+
+// fetchThreat calls CEDAR for a system's threats
+func (c *Client) fetchThreat(ctx context.Context, cedarSystemID uuid.UUID) ([]*models.CedarThreat, error) {
 	// NOTE: We do not need to use the GetSystem call or check the cache here b/c
 	//   the GetAuthorityToOperate call will do that when called below
 
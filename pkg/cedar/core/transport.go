@@ -0,0 +1,303 @@
+package cedarcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+)
+
+const (
+	defaultAttemptTimeout   = 10 * time.Second
+	defaultMaxRetries       = 2
+	defaultRetryBaseDelay   = 200 * time.Millisecond
+	defaultRetryMaxDelay    = 2 * time.Second
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// ErrCEDARUnavailable is returned without calling CEDAR while the circuit breaker is open, after too many calls to CEDAR in a row have failed
+var ErrCEDARUnavailable = errors.New("CEDAR Core is unavailable")
+
+// TransportConfig configures how calls to CEDAR Core are timed out, retried, and cut off when CEDAR is failing.
+// Anything that's left unset uses its default.
+type TransportConfig struct {
+	// AttemptTimeout is how long a single attempt at a call to CEDAR can take
+	AttemptTimeout time.Duration
+	// MaxRetries is how many times a failed GET is retried; 0 turns off retries, and nil uses the default.
+	// Other methods aren't retried, since they may not be safe to repeat.
+	MaxRetries *int
+	// RetryBaseDelay and RetryMaxDelay bound the jittered, exponentially increasing wait between retries
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// BreakerThreshold is how many calls in a row can fail before the circuit breaker opens and calls fail fast
+	BreakerThreshold int
+	// BreakerCooldown is how long the circuit breaker stays open before a single call is let through to check whether CEDAR has recovered
+	BreakerCooldown time.Duration
+}
+
+func (cfg TransportConfig) withDefaults() TransportConfig {
+	if cfg.AttemptTimeout <= 0 {
+		cfg.AttemptTimeout = defaultAttemptTimeout
+	}
+	if cfg.MaxRetries == nil || *cfg.MaxRetries < 0 {
+		cfg.MaxRetries = helpers.PointerTo(defaultMaxRetries)
+	}
+	if cfg.RetryBaseDelay <= 0 {
+		cfg.RetryBaseDelay = defaultRetryBaseDelay
+	}
+	if cfg.RetryMaxDelay <= 0 {
+		cfg.RetryMaxDelay = defaultRetryMaxDelay
+	}
+	if cfg.BreakerThreshold <= 0 {
+		cfg.BreakerThreshold = defaultBreakerThreshold
+	}
+	if cfg.BreakerCooldown <= 0 {
+		cfg.BreakerCooldown = defaultBreakerCooldown
+	}
+	return cfg
+}
+
+// resilientTransport times out, retries, and short-circuits calls to CEDAR, handing each attempt to next
+type resilientTransport struct {
+	next    http.RoundTripper
+	config  TransportConfig
+	breaker *circuitBreaker
+	logger  *zap.Logger
+	// sleep waits between retries, returning early if ctx is done; it's swapped out in tests
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newResilientTransport(next http.RoundTripper, config TransportConfig, logger *zap.Logger) *resilientTransport {
+	config = config.withDefaults()
+	return &resilientTransport{
+		next:    next,
+		config:  config,
+		breaker: newCircuitBreaker(config.BreakerThreshold, config.BreakerCooldown, logger),
+		logger:  logger,
+		sleep:   sleepContext,
+	}
+}
+
+func (t *resilientTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if err := t.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	maxAttempts := 1
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		maxAttempts += *t.config.MaxRetries
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.attempt(r)
+
+		// calls the caller gave up on don't say anything about CEDAR's health
+		if r.Context().Err() != nil {
+			t.breaker.release()
+			return resp, err
+		}
+
+		if !isCEDARFailure(resp, err) {
+			t.breaker.recordSuccess()
+			return resp, err
+		}
+
+		if attempt >= maxAttempts {
+			t.breaker.recordFailure()
+			return resp, err
+		}
+
+		t.logger.Warn(
+			"Retrying failed call to CEDAR core",
+			zap.String("service", "cedarcore"),
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.Int("attempt", attempt),
+			zap.Int("status", statusCode(resp)),
+			zap.Error(err),
+		)
+
+		// the failed response is thrown away, so its connection needs to be freed up for reuse
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if sleepErr := t.sleep(r.Context(), t.retryDelay(attempt)); sleepErr != nil {
+			t.breaker.release()
+			return nil, sleepErr
+		}
+	}
+}
+
+// attempt makes a single call to CEDAR, which is cut off if it takes longer than the attempt timeout
+func (t *resilientTransport) attempt(r *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(r.Context(), t.config.AttemptTimeout)
+	resp, err := t.next.RoundTrip(r.Clone(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// the timeout covers reading the body too, so it can't be cancelled until the body's closed
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// retryDelay is a random wait of up to RetryBaseDelay * 2^(attempt-1), capped at RetryMaxDelay, so retries from many requests don't all land on CEDAR at once
+func (t *resilientTransport) retryDelay(attempt int) time.Duration {
+	ceiling := t.config.RetryBaseDelay << (attempt - 1)
+	if ceiling <= 0 || ceiling > t.config.RetryMaxDelay {
+		ceiling = t.config.RetryMaxDelay
+	}
+	return rand.N(ceiling) + 1 // #nosec G404 -- jitter doesn't need a secure random number
+}
+
+// isCEDARFailure is whether a call failed because of CEDAR (or the network) rather than because of the request, and so is worth retrying
+func isCEDARFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return resp.StatusCode >= http.StatusInternalServerError
+	}
+}
+
+func statusCode(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// circuitBreaker fails calls fast once too many calls in a row have failed. After a cooldown it lets a single call through;
+// if that call succeeds the breaker closes, otherwise it opens for another cooldown.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	logger    *zap.Logger
+	now       func() time.Time
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	openedAt            time.Time
+	open                bool
+	probing             bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration, logger *zap.Logger) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// allow returns ErrCEDARUnavailable if a call shouldn't be made. Every allowed call must be followed by recordSuccess, recordFailure, or release.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return nil
+	}
+
+	retryAt := b.openedAt.Add(b.cooldown)
+	if b.now().Before(retryAt) || b.probing {
+		return fmt.Errorf("%w: too many calls have failed, retrying after %s", ErrCEDARUnavailable, retryAt.Format(time.RFC3339))
+	}
+
+	// let this call through to check whether CEDAR has recovered
+	b.probing = true
+	return nil
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.open {
+		b.logger.Info("CEDAR core has recovered; closing circuit breaker", zap.String("service", "cedarcore"))
+	}
+	b.consecutiveFailures = 0
+	b.open = false
+	b.probing = false
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFailures++
+	if b.probing || (!b.open && b.consecutiveFailures >= b.threshold) {
+		b.logger.Error(
+			"Too many calls to CEDAR core have failed; opening circuit breaker",
+			zap.String("service", "cedarcore"),
+			zap.Int("consecutive-failures", b.consecutiveFailures),
+			zap.Duration("cooldown", b.cooldown),
+		)
+		b.open = true
+		b.openedAt = b.now()
+	}
+	b.probing = false
+}
+
+// release gives up an allowed call without recording whether CEDAR is healthy, e.g. when the caller cancelled it
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// status returns an error describing why CEDAR is degraded, or nil if it isn't
+func (b *circuitBreaker) status() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return nil
+	}
+	return fmt.Errorf(
+		"%w: circuit breaker opened at %s after %d failed calls in a row",
+		ErrCEDARUnavailable,
+		b.openedAt.Format(time.RFC3339),
+		b.consecutiveFailures,
+	)
+}
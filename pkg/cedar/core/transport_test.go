@@ -0,0 +1,234 @@
+package cedarcore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+)
+
+type TransportTestSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func TestTransportTestSuite(t *testing.T) {
+	tests := &TransportTestSuite{
+		Suite:  suite.Suite{},
+		logger: zap.NewNop(),
+	}
+	suite.Run(t, tests)
+}
+
+// newTestTransport returns a transport that doesn't wait between retries, and a server standing in for CEDAR that responds with
+// the given status codes in order, repeating the last one
+func (s *TransportTestSuite) newTestTransport(config TransportConfig, statuses ...int) (*resilientTransport, *httptest.Server, *atomic.Int32) {
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(calls.Add(1))
+		w.WriteHeader(statuses[min(call, len(statuses))-1])
+	}))
+	s.T().Cleanup(server.Close)
+
+	transport := newResilientTransport(http.DefaultTransport, config, s.logger)
+	transport.sleep = func(ctx context.Context, d time.Duration) error {
+		return ctx.Err()
+	}
+	return transport, server, calls
+}
+
+func (s *TransportTestSuite) roundTrip(transport http.RoundTripper, method string, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method, target, strings.NewReader(""))
+	s.NoError(err)
+
+	resp, err := transport.RoundTrip(req)
+	if resp != nil {
+		s.NoError(resp.Body.Close())
+	}
+	return resp, err
+}
+
+func (s *TransportTestSuite) TestRetries() {
+	s.Run("failed GETs are retried", func() {
+		transport, server, calls := s.newTestTransport(TransportConfig{MaxRetries: helpers.PointerTo(2)}, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+
+		resp, err := s.roundTrip(transport, http.MethodGet, server.URL)
+		s.NoError(err)
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.EqualValues(3, calls.Load())
+	})
+
+	s.Run("GETs stop being retried after the max retries", func() {
+		transport, server, calls := s.newTestTransport(TransportConfig{MaxRetries: helpers.PointerTo(2)}, http.StatusServiceUnavailable)
+
+		resp, err := s.roundTrip(transport, http.MethodGet, server.URL)
+		s.NoError(err)
+		s.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+		s.EqualValues(3, calls.Load())
+	})
+
+	s.Run("GETs aren't retried when max retries is 0", func() {
+		transport, server, calls := s.newTestTransport(TransportConfig{MaxRetries: helpers.PointerTo(0)}, http.StatusServiceUnavailable, http.StatusOK)
+
+		resp, err := s.roundTrip(transport, http.MethodGet, server.URL)
+		s.NoError(err)
+		s.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+		s.EqualValues(1, calls.Load())
+	})
+
+	s.Run("GETs use the default max retries when it's unset", func() {
+		transport, server, calls := s.newTestTransport(TransportConfig{}, http.StatusServiceUnavailable)
+
+		resp, err := s.roundTrip(transport, http.MethodGet, server.URL)
+		s.NoError(err)
+		s.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+		s.EqualValues(1+defaultMaxRetries, calls.Load())
+	})
+
+	s.Run("writes aren't retried", func() {
+		transport, server, calls := s.newTestTransport(TransportConfig{MaxRetries: helpers.PointerTo(2)}, http.StatusServiceUnavailable, http.StatusOK)
+
+		resp, err := s.roundTrip(transport, http.MethodPost, server.URL)
+		s.NoError(err)
+		s.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+		s.EqualValues(1, calls.Load())
+	})
+
+	s.Run("client errors aren't retried", func() {
+		transport, server, calls := s.newTestTransport(TransportConfig{MaxRetries: helpers.PointerTo(2)}, http.StatusNotFound, http.StatusOK)
+
+		resp, err := s.roundTrip(transport, http.MethodGet, server.URL)
+		s.NoError(err)
+		s.Equal(http.StatusNotFound, resp.StatusCode)
+		s.EqualValues(1, calls.Load())
+	})
+
+	s.Run("retry delays are jittered and capped", func() {
+		transport := newResilientTransport(http.DefaultTransport, TransportConfig{RetryBaseDelay: 100 * time.Millisecond, RetryMaxDelay: 300 * time.Millisecond}, s.logger)
+		for attempt := 1; attempt <= 5; attempt++ {
+			delay := transport.retryDelay(attempt)
+			s.Positive(delay)
+			s.LessOrEqual(delay, min(100*time.Millisecond<<(attempt-1), 300*time.Millisecond))
+		}
+	})
+}
+
+func (s *TransportTestSuite) TestAttemptTimeout() {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first call hangs until it's cut off
+		if calls.Add(1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newResilientTransport(http.DefaultTransport, TransportConfig{AttemptTimeout: 50 * time.Millisecond}, s.logger)
+	transport.sleep = func(context.Context, time.Duration) error {
+		return nil
+	}
+
+	resp, err := s.roundTrip(transport, http.MethodGet, server.URL)
+	s.NoError(err)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.EqualValues(2, calls.Load())
+}
+
+func (s *TransportTestSuite) TestCircuitBreaker() {
+	config := TransportConfig{MaxRetries: helpers.PointerTo(1), BreakerThreshold: 2, BreakerCooldown: time.Minute}
+
+	s.Run("the breaker opens after too many failed calls, and fails fast until the cooldown's over", func() {
+		transport, server, calls := s.newTestTransport(config, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK)
+		now := time.Now()
+		transport.breaker.now = func() time.Time {
+			return now
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := s.roundTrip(transport, http.MethodGet, server.URL)
+			s.NoError(err)
+		}
+		s.EqualValues(4, calls.Load())
+		s.ErrorIs(transport.breaker.status(), ErrCEDARUnavailable)
+
+		_, err := s.roundTrip(transport, http.MethodGet, server.URL)
+		s.ErrorIs(err, ErrCEDARUnavailable)
+		s.EqualValues(4, calls.Load())
+
+		// once the cooldown's over, a successful call closes the breaker
+		now = now.Add(2 * time.Minute)
+		resp, err := s.roundTrip(transport, http.MethodGet, server.URL)
+		s.NoError(err)
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.NoError(transport.breaker.status())
+	})
+
+	s.Run("a failed call after the cooldown opens the breaker again", func() {
+		transport, server, calls := s.newTestTransport(config, http.StatusServiceUnavailable)
+		now := time.Now()
+		transport.breaker.now = func() time.Time {
+			return now
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := s.roundTrip(transport, http.MethodGet, server.URL)
+			s.NoError(err)
+		}
+
+		now = now.Add(2 * time.Minute)
+		_, err := s.roundTrip(transport, http.MethodGet, server.URL)
+		s.NoError(err)
+		s.EqualValues(6, calls.Load())
+
+		_, err = s.roundTrip(transport, http.MethodGet, server.URL)
+		s.ErrorIs(err, ErrCEDARUnavailable)
+		s.EqualValues(6, calls.Load())
+	})
+
+	s.Run("a success resets the count of failed calls", func() {
+		transport, server, _ := s.newTestTransport(config, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK, http.StatusServiceUnavailable)
+
+		for i := 0; i < 3; i++ {
+			_, err := s.roundTrip(transport, http.MethodGet, server.URL)
+			s.NoError(err)
+		}
+		s.NoError(transport.breaker.status())
+	})
+}
+
+func (s *TransportTestSuite) TestClientCheckHealth() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	s.NoError(err)
+
+	c := NewClient(ctx, serverURL.Host, "fake", "1.0.0", false, TransportConfig{MaxRetries: helpers.PointerTo(1), BreakerThreshold: 1, RetryBaseDelay: time.Millisecond}, CacheConfig{})
+	s.NoError(c.CheckHealth())
+
+	_, err = c.GetRoleTypes(ctx)
+	s.Error(err)
+	s.ErrorIs(c.CheckHealth(), ErrCEDARUnavailable)
+
+	_, err = c.GetRoleTypes(ctx)
+	s.ErrorIs(err, ErrCEDARUnavailable)
+
+	mocked := NewClient(ctx, "fake", "fake", "1.0.0", true, TransportConfig{}, CacheConfig{})
+	s.NoError(mocked.CheckHealth())
+}
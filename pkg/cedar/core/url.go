@@ -2,13 +2,18 @@ package cedarcore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	apiurl "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/client/url"
+	apimodels "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/models"
 	"github.com/cms-enterprise/easi-app/pkg/local/cedarcoremock"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 )
@@ -22,45 +27,276 @@ func (c *Client) GetURLsForSystem(ctx context.Context, cedarSystemID uuid.UUID)
 	if c.mockEnabled {
 		appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
 		if cedarcoremock.IsMockSystem(cedarSystemID) {
-			return cedarcoremock.GetURLs(), nil
+			return cedarcoremock.GetURLs(cedarSystemID), nil
 		}
 		return nil, cedarcoremock.NoSystemFoundError()
 	}
 
-	cedarSystem, err := c.GetSystem(ctx, cedarSystemID)
+	return cachedRead(ctx, c.cache, cacheKey{endpoint: cacheEndpointURLs, systemID: cedarSystemID}, func(ctx context.Context) ([]*models.CedarURL, error) {
+		return c.fetchURLsForSystem(ctx, cedarSystemID)
+	})
+}
+
+// fetchURLsForSystem calls CEDAR for a system's URLs
+func (c *Client) fetchURLsForSystem(ctx context.Context, cedarSystemID uuid.UUID) ([]*models.CedarURL, error) {
+	urls, err := c.fetchCEDARURLs(ctx, cedarSystemID)
+	if err != nil {
+		return []*models.CedarURL{}, err
+	}
+
+	// convert items in response payload to our models
+	retVal := []*models.CedarURL{}
+	for _, url := range urls {
+		retVal = append(retVal, urlFromCEDAR(url))
+	}
+
+	return retVal, nil
+}
+
+// fetchCEDARURLs calls CEDAR for a system's URLs, returning them as CEDAR sent them
+func (c *Client) fetchCEDARURLs(ctx context.Context, cedarSystemID uuid.UUID) ([]*apimodels.URL, error) {
+	versionID, err := c.systemVersionID(ctx, cedarSystemID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Construct the parameters
 	params := apiurl.NewURLFindListParams()
-	params.SetID(cedarSystem.VersionID.String)
+	params.SetID(versionID)
 	params.HTTPClient = c.hc
 
 	// Make the API call
 	resp, err := c.sdk.URL.URLFindList(params, c.auth)
 	if err != nil {
-		return []*models.CedarURL{}, err
+		return nil, err
 	}
 
 	if resp.Payload == nil {
-		return []*models.CedarURL{}, fmt.Errorf("no body received")
+		return nil, fmt.Errorf("no body received")
 	}
 
-	// Convert the auto-generated struct to our own pkg/models struct
-	retVal := []*models.CedarURL{}
+	return resp.Payload.URLList, nil
+}
 
-	// convert items in response payload to our models
-	for _, url := range resp.Payload.URLList {
-		retVal = append(retVal, &models.CedarURL{
-			ID:                             zero.StringFromPtr(url.URLID),
-			Address:                        zero.StringFrom(url.Address),
-			IsBehindWebApplicationFirewall: url.IsBehindWebApplicationFirewall,
-			IsAPIEndpoint:                  url.IsAPIEndpoint,
-			IsVersionCodeRepository:        url.IsVersionCodeRepository,
-			URLHostingEnv:                  zero.StringFrom(url.URLHostingEnv),
+// systemVersionID returns the ID of the current version of a system, which is the object CEDAR associates URLs with
+func (c *Client) systemVersionID(ctx context.Context, cedarSystemID uuid.UUID) (string, error) {
+	cedarSystem, err := c.GetSystem(ctx, cedarSystemID)
+	if err != nil {
+		return "", err
+	}
+	return cedarSystem.VersionID.String, nil
+}
+
+// urlFromCEDAR converts the auto-generated struct to our own pkg/models struct
+func urlFromCEDAR(url *apimodels.URL) *models.CedarURL {
+	return &models.CedarURL{
+		ID:                             zero.StringFromPtr(url.URLID),
+		Address:                        zero.StringFrom(url.Address),
+		IsBehindWebApplicationFirewall: url.IsBehindWebApplicationFirewall,
+		IsAPIEndpoint:                  url.IsAPIEndpoint,
+		IsVersionCodeRepository:        url.IsVersionCodeRepository,
+		URLHostingEnv:                  zero.StringFrom(url.URLHostingEnv),
+	}
+}
+
+// AddURL makes a POST call to the /url/{id} endpoint to add a URL to a system, and returns the URL as it was sent.
+// CEDAR assigns the new URL's ID, so any ID on the URL is ignored; CEDAR doesn't return the ID, so the returned URL
+// only has one when CEDAR is mocked.
+func (c *Client) AddURL(ctx context.Context, cedarSystemID uuid.UUID, url *models.CedarURL) (*models.CedarURL, error) {
+	// the caller's URL is copied rather than modified
+	added := *url
+	added.ID = zero.StringFrom("")
+
+	body := urlToCEDAR(&added)
+	if err := body.Validate(strfmt.Default); err != nil {
+		return nil, &apperrors.BadRequestError{Err: err}
+	}
+
+	return writeAndEvict(c, urlsEviction(cedarSystemID), func() (*models.CedarURL, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if !cedarcoremock.IsMockSystem(cedarSystemID) {
+				return nil, cedarcoremock.NoSystemFoundError()
+			}
+			return cedarcoremock.AddURL(cedarSystemID, &added), nil
+		}
+
+		versionID, err := c.systemVersionID(ctx, cedarSystemID)
+		if err != nil {
+			return nil, err
+		}
+
+		params := apiurl.NewURLAddParams()
+		params.SetID(versionID)
+		params.SetURLAddRequest(&apimodels.URLAddRequest{
+			Urls: []*apimodels.URL{body},
 		})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.URL.URLAdd(params, c.auth)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
+		}
+
+		return &added, nil
+	})
+}
+
+// UpdateURL makes a PUT call to the /url/{id} endpoint to change one of a system's URLs, and returns the updated URL.
+// update is applied to a copy of the URL as it currently is in CEDAR. concurrencyToken is the token of the URL the change was
+// based on; if the URL has changed in CEDAR since, the update is rejected with a ResourceConflictError.
+func (c *Client) UpdateURL(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	urlID string,
+	concurrencyToken string,
+	update func(url *models.CedarURL),
+) (*models.CedarURL, error) {
+	current, err := c.currentURL(ctx, cedarSystemID, urlID, concurrencyToken)
+	if err != nil {
+		return nil, err
 	}
 
-	return retVal, nil
+	// the current URL may be shared with other readers, so it's copied rather than modified
+	updated := *current
+	update(&updated)
+	updated.ID = current.ID
+
+	body := urlToCEDAR(&updated)
+	if err := body.Validate(strfmt.Default); err != nil {
+		return nil, &apperrors.BadRequestError{Err: err}
+	}
+
+	return writeAndEvict(c, urlsEviction(cedarSystemID), func() (*models.CedarURL, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if err := cedarcoremock.UpdateURL(cedarSystemID, &updated); err != nil {
+				return nil, err
+			}
+			return &updated, nil
+		}
+
+		versionID, err := c.systemVersionID(ctx, cedarSystemID)
+		if err != nil {
+			return nil, err
+		}
+
+		params := apiurl.NewURLUpdateParams()
+		params.SetID(versionID)
+		params.SetURLUpdateRequest(&apimodels.URLUpdateRequest{
+			Urls: []*apimodels.URL{body},
+		})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.URL.URLUpdate(params, c.auth)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
+		}
+
+		return &updated, nil
+	})
+}
+
+// DeleteURL makes a DELETE call to the /url/{id} endpoint to remove one of a system's URLs, and returns the removed URL.
+// concurrencyToken is the token of the URL the caller read; if the URL has changed in CEDAR since, the delete is rejected with
+// a ResourceConflictError.
+func (c *Client) DeleteURL(ctx context.Context, cedarSystemID uuid.UUID, urlID string, concurrencyToken string) (*models.CedarURL, error) {
+	deleted, err := c.currentURL(ctx, cedarSystemID, urlID, concurrencyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeAndEvict(c, urlsEviction(cedarSystemID), func() (*models.CedarURL, error) {
+		if c.mockEnabled {
+			appcontext.ZLogger(ctx).Info("CEDAR Core is disabled")
+			if err := cedarcoremock.DeleteURLs(cedarSystemID, []string{urlID}); err != nil {
+				return nil, err
+			}
+			return deleted, nil
+		}
+
+		versionID, err := c.systemVersionID(ctx, cedarSystemID)
+		if err != nil {
+			return nil, err
+		}
+
+		params := apiurl.NewURLDeleteListParams()
+		params.SetID(versionID)
+		params.SetURLID([]string{urlID})
+		params.HTTPClient = c.hc
+
+		resp, err := c.sdk.URL.URLDeleteList(params, c.auth)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeResponseError(resp.Payload); err != nil {
+			return nil, err
+		}
+
+		return deleted, nil
+	})
+}
+
+// currentURL fetches one of a system's URLs straight from CEDAR, bypassing the cache, and checks that it still matches concurrencyToken
+func (c *Client) currentURL(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	urlID string,
+	concurrencyToken string,
+) (*models.CedarURL, error) {
+	var retURL *models.CedarURL
+
+	if c.mockEnabled {
+		urls, err := c.GetURLsForSystem(ctx, cedarSystemID)
+		if err != nil {
+			return nil, err
+		}
+		retURL, _ = lo.Find(urls, func(url *models.CedarURL) bool {
+			return url.ID.String == urlID
+		})
+	} else {
+		cedarURLs, err := c.fetchCEDARURLs(ctx, cedarSystemID)
+		if err != nil {
+			return nil, err
+		}
+		cedarURL, _ := lo.Find(cedarURLs, func(url *apimodels.URL) bool {
+			return url.URLID != nil && *url.URLID == urlID
+		})
+		if cedarURL != nil {
+			retURL = urlFromCEDAR(cedarURL)
+		}
+	}
+
+	if urlID == "" || retURL == nil {
+		return nil, &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no URL found"), Resource: models.CedarURL{}}
+	}
+
+	if retURL.ConcurrencyToken() != concurrencyToken {
+		return nil, &apperrors.ResourceConflictError{
+			Err:        errors.New("the URL has changed since it was read"),
+			Resource:   models.CedarURL{},
+			ResourceID: urlID,
+		}
+	}
+
+	return retURL, nil
+}
+
+// urlToCEDAR converts a URL into the auto-generated struct CEDAR accepts. EASi reads every field of a URL, so there's nothing to
+// carry over from the URL as CEDAR sent it.
+func urlToCEDAR(url *models.CedarURL) *apimodels.URL {
+	return &apimodels.URL{
+		URLID:                          lo.ToPtr(url.ID.String),
+		Address:                        url.Address.String,
+		IsBehindWebApplicationFirewall: url.IsBehindWebApplicationFirewall,
+		IsAPIEndpoint:                  url.IsAPIEndpoint,
+		IsVersionCodeRepository:        url.IsVersionCodeRepository,
+		URLHostingEnv:                  url.URLHostingEnv.String,
+	}
 }
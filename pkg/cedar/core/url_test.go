@@ -0,0 +1,196 @@
+package cedarcore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	apimodels "github.com/cms-enterprise/easi-app/pkg/cedar/core/gen/models"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+type URLTestSuite struct {
+	suite.Suite
+	logger *zap.Logger
+}
+
+func TestURLTestSuite(t *testing.T) {
+	tests := &URLTestSuite{
+		Suite:  suite.Suite{},
+		logger: zap.NewNop(),
+	}
+	suite.Run(t, tests)
+}
+
+func (s *URLTestSuite) TestMockedURLWrites() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+	c := NewClient(ctx, "fake", "fake", "1.0.0", true, TransportConfig{}, CacheConfig{})
+	cedarSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC3D}")
+	otherSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC4E}")
+
+	before, err := c.GetURLsForSystem(ctx, cedarSystemID)
+	s.NoError(err)
+
+	findURL := func(address string) *models.CedarURL {
+		urls, err := c.GetURLsForSystem(ctx, cedarSystemID)
+		s.NoError(err)
+		found, _ := lo.Find(urls, func(cedarURL *models.CedarURL) bool {
+			return cedarURL.Address.String == address
+		})
+		return found
+	}
+
+	s.Run("a URL can be added", func() {
+		added, err := c.AddURL(ctx, cedarSystemID, &models.CedarURL{
+			Address:       zero.StringFrom("https://new.cms.gov"),
+			IsAPIEndpoint: true,
+			URLHostingEnv: zero.StringFrom("Production"),
+		})
+		s.NoError(err)
+
+		cedarURL := findURL("https://new.cms.gov")
+		s.NotNil(cedarURL)
+		s.NotEmpty(cedarURL.ID.String)
+		s.Equal(added.ID, cedarURL.ID)
+		s.True(cedarURL.IsAPIEndpoint)
+
+		otherURLs, err := c.GetURLsForSystem(ctx, otherSystemID)
+		s.NoError(err)
+		s.Len(otherURLs, len(before))
+	})
+
+	s.Run("a URL can be updated using its current concurrency token", func() {
+		cedarURL := findURL("https://new.cms.gov")
+		_, err := c.UpdateURL(ctx, cedarSystemID, cedarURL.ID.String, cedarURL.ConcurrencyToken(), func(cedarURL *models.CedarURL) {
+			cedarURL.IsBehindWebApplicationFirewall = true
+		})
+		s.NoError(err)
+
+		updated := findURL("https://new.cms.gov")
+		s.Equal(cedarURL.ID, updated.ID)
+		s.True(updated.IsBehindWebApplicationFirewall)
+		s.NotEqual(cedarURL.ConcurrencyToken(), updated.ConcurrencyToken())
+	})
+
+	s.Run("updates based on an outdated URL are rejected", func() {
+		cedarURL := findURL("https://new.cms.gov")
+		staleToken := (&models.CedarURL{ID: cedarURL.ID, Address: cedarURL.Address}).ConcurrencyToken()
+
+		_, err := c.UpdateURL(ctx, cedarSystemID, cedarURL.ID.String, staleToken, func(cedarURL *models.CedarURL) {
+			cedarURL.IsAPIEndpoint = false
+		})
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+
+		_, err = c.DeleteURL(ctx, cedarSystemID, cedarURL.ID.String, staleToken)
+		s.ErrorAs(err, &conflictErr)
+		s.NotNil(findURL("https://new.cms.gov"))
+	})
+
+	s.Run("URLs that aren't on the system can't be changed", func() {
+		_, err := c.DeleteURL(ctx, cedarSystemID, "not-a-url", "token")
+		var notFoundErr *apperrors.ResourceNotFoundError
+		s.ErrorAs(err, &notFoundErr)
+	})
+
+	s.Run("a URL can be deleted", func() {
+		cedarURL := findURL("https://new.cms.gov")
+		_, err := c.DeleteURL(ctx, cedarSystemID, cedarURL.ID.String, cedarURL.ConcurrencyToken())
+		s.NoError(err)
+		s.Nil(findURL("https://new.cms.gov"))
+
+		after, err := c.GetURLsForSystem(ctx, cedarSystemID)
+		s.NoError(err)
+		s.Len(after, len(before))
+	})
+}
+
+func (s *URLTestSuite) TestURLWritesCallCEDAR() {
+	ctx := appcontext.WithLogger(context.Background(), s.logger)
+	cedarSystemID := uuid.New()
+	versionID := formatIDForCEDAR(cedarSystemID)
+	urlID := "{11AB1A00-1234-5678-ABC1-1A001B00URL1}"
+
+	var updated *apimodels.URL
+	var deletedIDs []string
+	cedarURL := func() map[string]any {
+		cedarURL := map[string]any{
+			"urlId":         urlID,
+			"address":       "https://system.cms.gov",
+			"urlHostingEnv": "Production",
+		}
+		if updated != nil {
+			cedarURL["isApiEndpoint"] = updated.IsAPIEndpoint
+		}
+		return cedarURL
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/gateway/CEDAR Core API/1.0.0")
+
+		switch {
+		case r.Method == http.MethodGet && path == "/system/summary":
+			s.NoError(json.NewEncoder(w).Encode(map[string]any{
+				"SystemSummary": []map[string]any{{"id": versionID, "ictObjectId": versionID, "name": "System"}},
+				"count":         1,
+			}))
+		case r.Method == http.MethodGet && path == "/url/"+versionID:
+			s.NoError(json.NewEncoder(w).Encode(map[string]any{
+				"UrlList": []map[string]any{cedarURL()},
+				"count":   1,
+			}))
+		case r.Method == http.MethodPut && path == "/url/"+versionID:
+			var body apimodels.URLUpdateRequest
+			s.NoError(json.NewDecoder(r.Body).Decode(&body))
+			s.Len(body.Urls, 1)
+			updated = body.Urls[0]
+			s.NoError(json.NewEncoder(w).Encode(apimodels.Response{Result: "success"}))
+		case r.Method == http.MethodDelete && path == "/url/"+versionID:
+			deletedIDs = r.URL.Query()["urlId"]
+			s.NoError(json.NewEncoder(w).Encode(apimodels.Response{Result: "success"}))
+		default:
+			s.Failf("unexpected call to CEDAR", "%s %s", r.Method, path)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	s.NoError(err)
+	c := NewClient(ctx, serverURL.Host, "fake", "1.0.0", false, TransportConfig{}, CacheConfig{})
+
+	urls, err := c.GetURLsForSystem(ctx, cedarSystemID)
+	s.NoError(err)
+	s.Len(urls, 1)
+
+	_, err = c.UpdateURL(ctx, cedarSystemID, urlID, urls[0].ConcurrencyToken(), func(cedarURL *models.CedarURL) {
+		cedarURL.IsAPIEndpoint = true
+	})
+	s.NoError(err)
+
+	s.Equal(urlID, *updated.URLID)
+	s.Equal("https://system.cms.gov", updated.Address)
+	s.Equal("Production", updated.URLHostingEnv)
+	s.True(updated.IsAPIEndpoint)
+
+	// the cached URLs are out of date after the update
+	urls, err = c.GetURLsForSystem(ctx, cedarSystemID)
+	s.NoError(err)
+	s.True(urls[0].IsAPIEndpoint)
+
+	_, err = c.DeleteURL(ctx, cedarSystemID, urlID, urls[0].ConcurrencyToken())
+	s.NoError(err)
+	s.Equal([]string{urlID}, deletedIDs)
+}
@@ -0,0 +1,100 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"regexp"
+	"strings"
+
+	jwemail "github.com/jordan-wright/email"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// MaxAttachmentsSize is the most data, in bytes, that can be attached to a single email.
+// SES rejects messages larger than 10 MB, and attachments grow by a third when they're base64 encoded,
+// which leaves room for the body and headers
+const MaxAttachmentsSize = 7 * 1024 * 1024
+
+// allowedAttachmentContentTypes are the types of file that can be attached to emails
+var allowedAttachmentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":       true,
+	"image/gif":     true,
+	"image/jpeg":    true,
+	"image/png":     true,
+	"text/calendar": true,
+	"text/csv":      true,
+	"text/plain":    true,
+}
+
+// inlineAttachmentContentTypes are the types of file that can be shown in the body of emails
+var inlineAttachmentContentTypes = map[string]bool{
+	"image/gif":  true,
+	"image/jpeg": true,
+	"image/png":  true,
+}
+
+// contentIDPattern matches the content IDs inline attachments can have, which are written to the Content-ID header as is
+var contentIDPattern = regexp.MustCompile(`^[A-Za-z0-9._@-]+$`)
+
+// ValidateAttachments returns an error if attachments can't be sent, because one is missing a filename or is a type of file
+// that can't be attached or shown inline, or because together they're larger than MaxAttachmentsSize
+func ValidateAttachments(attachments []models.EmailAttachment) error {
+	size := 0
+	for _, attachment := range attachments {
+		// filenames are written to the Content-Disposition header unescaped
+		if attachment.Filename == "" || strings.ContainsAny(attachment.Filename, "\"\\/\r\n") {
+			return fmt.Errorf("attachment has an invalid filename %q", attachment.Filename)
+		}
+
+		mediaType, _, err := mime.ParseMediaType(attachment.ContentType)
+		if err != nil {
+			return fmt.Errorf("attachment %s has an invalid content type: %w", attachment.Filename, err)
+		}
+		if !allowedAttachmentContentTypes[mediaType] {
+			return fmt.Errorf("attachment %s is a %s, which can't be attached to emails", attachment.Filename, mediaType)
+		}
+
+		if attachment.ContentID != "" {
+			if !contentIDPattern.MatchString(attachment.ContentID) {
+				return fmt.Errorf("attachment %s has an invalid content ID %q", attachment.Filename, attachment.ContentID)
+			}
+			if !inlineAttachmentContentTypes[mediaType] {
+				return fmt.Errorf("attachment %s is a %s, which can't be shown inline", attachment.Filename, mediaType)
+			}
+		}
+
+		size += len(attachment.Data)
+	}
+
+	if size > MaxAttachmentsSize {
+		return fmt.Errorf("attachments are %d bytes, which is more than the limit of %d bytes", size, MaxAttachmentsSize)
+	}
+
+	return nil
+}
+
+// AttachToMessage validates attachments, then adds them to a MIME message.
+// Inline attachments are related to the message's HTML body, so they can be shown in it with a cid: URL of their content ID
+func AttachToMessage(message *jwemail.Email, attachments []models.EmailAttachment) error {
+	if err := ValidateAttachments(attachments); err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		attached, err := message.Attach(bytes.NewReader(attachment.Data), attachment.Filename, attachment.ContentType)
+		if err != nil {
+			return fmt.Errorf("problem attaching %s: %w", attachment.Filename, err)
+		}
+
+		if attachment.ContentID != "" {
+			attached.HTMLRelated = true
+			attached.Header.Set("Content-ID", fmt.Sprintf("<%s>", attachment.ContentID))
+		}
+	}
+
+	return nil
+}
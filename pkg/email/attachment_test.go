@@ -0,0 +1,86 @@
+package email
+
+import (
+	"strings"
+
+	jwemail "github.com/jordan-wright/email"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *EmailTestSuite) TestValidateAttachments() {
+	pdf := models.EmailAttachment{Filename: "letter.pdf", ContentType: "application/pdf", Data: []byte("%PDF-")}
+	logo := models.EmailAttachment{Filename: "logo.png", ContentType: "image/png", Data: []byte("png"), ContentID: "logo"}
+
+	s.NoError(ValidateAttachments(nil))
+	s.NoError(ValidateAttachments([]models.EmailAttachment{pdf, logo}))
+
+	s.Run("content types can have parameters", func() {
+		invite := models.EmailAttachment{Filename: "invite.ics", ContentType: "text/calendar; charset=UTF-8; method=REQUEST"}
+		s.NoError(ValidateAttachments([]models.EmailAttachment{invite}))
+	})
+
+	s.Run("rejects attachments without a usable filename", func() {
+		for _, filename := range []string{"", "../letter.pdf", "letter\".pdf", "letter\r\nBcc: someone@local.fake"} {
+			attachment := pdf
+			attachment.Filename = filename
+			s.Error(ValidateAttachments([]models.EmailAttachment{attachment}), filename)
+		}
+	})
+
+	s.Run("rejects types of file that can't be attached", func() {
+		attachment := pdf
+		attachment.ContentType = "application/x-msdownload"
+		s.ErrorContains(ValidateAttachments([]models.EmailAttachment{attachment}), "can't be attached")
+
+		attachment.ContentType = "not a content type"
+		s.ErrorContains(ValidateAttachments([]models.EmailAttachment{attachment}), "invalid content type")
+	})
+
+	s.Run("only images can be shown inline", func() {
+		attachment := pdf
+		attachment.ContentID = "letter"
+		s.ErrorContains(ValidateAttachments([]models.EmailAttachment{attachment}), "can't be shown inline")
+
+		attachment = logo
+		attachment.ContentID = "<logo>"
+		s.ErrorContains(ValidateAttachments([]models.EmailAttachment{attachment}), "invalid content ID")
+	})
+
+	s.Run("rejects attachments that are too large together", func() {
+		half := pdf
+		half.Data = make([]byte, MaxAttachmentsSize/2+1)
+		s.NoError(ValidateAttachments([]models.EmailAttachment{half}))
+		s.ErrorContains(ValidateAttachments([]models.EmailAttachment{half, half}), "more than the limit")
+	})
+}
+
+func (s *EmailTestSuite) TestAttachToMessage() {
+	email := NewEmail().
+		WithBody(`<p><img src="cid:logo"></p>`).
+		WithAttachment(models.EmailAttachment{Filename: "letter.pdf", ContentType: "application/pdf", Data: []byte("%PDF-")}).
+		WithInlineImage("logo", models.EmailAttachment{Filename: "logo.png", ContentType: "image/png", Data: []byte("png")})
+
+	message := &jwemail.Email{
+		From: "sender@local.fake",
+		To:   []string{"to@local.fake"},
+		HTML: []byte(email.Body),
+	}
+	s.NoError(AttachToMessage(message, email.Attachments))
+
+	raw, err := message.Bytes()
+	s.NoError(err)
+	mime := string(raw)
+
+	s.Contains(mime, "Content-Type: multipart/related")
+	s.Contains(mime, "Content-Disposition: attachment;\r\n filename=\"letter.pdf\"")
+	s.Contains(mime, "Content-Disposition: inline;\r\n filename=\"logo.png\"")
+	s.Contains(mime, "Content-Id: <logo>")
+
+	// the inline image is part of the HTML body, and the other attachment is alongside it
+	s.Less(strings.Index(mime, "multipart/related"), strings.Index(mime, "logo.png"))
+
+	s.Run("rejects invalid attachments", func() {
+		s.Error(AttachToMessage(&jwemail.Email{}, []models.EmailAttachment{{Filename: "virus.exe", ContentType: "application/x-msdownload"}}))
+	})
+}
@@ -0,0 +1,375 @@
+package email
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pdf"
+)
+
+// documentDateLayout formats dates the way they're shown in EASi's forms
+const documentDateLayout = "January 2, 2006"
+
+// documentNotProvided is shown for fields that haven't been filled in
+const documentNotProvided = "Not provided"
+
+type businessCaseDocument struct {
+	Title       string
+	ProjectName string
+	UpdatedAt   string
+	Sections    []businessCaseDocumentSection
+}
+
+type businessCaseDocumentSection struct {
+	Heading string
+	Fields  []documentField
+	Costs   *businessCaseDocumentCosts
+}
+
+type documentField struct {
+	Label string
+	Value string
+}
+
+// businessCaseDocumentCosts is a solution's estimated lifecycle costs, with a row for each phase and a column for each year
+type businessCaseDocumentCosts struct {
+	Years  []models.LifecycleCostYear
+	Rows   []businessCaseDocumentCostRow
+	Totals []string
+	Total  string
+}
+
+type businessCaseDocumentCostRow struct {
+	Phase string
+	Costs []string
+	Total string
+}
+
+// businessCaseSolution holds the fields the business case has for each of the solutions it proposes
+type businessCaseSolution struct {
+	costSolution            models.LifecycleCostSolution
+	heading                 string
+	includeWhenNotFilledIn  bool
+	title                   null.String
+	summary                 null.String
+	acquisitionApproach     null.String
+	targetContractAwardDate *time.Time
+	targetCompletionDate    *time.Time
+	securityIsApproved      null.Bool
+	securityIsBeingReviewed null.String
+	zeroTrustAlignment      null.String
+	hostingType             null.String
+	hostingLocation         null.String
+	hostingCloudStrategy    null.String
+	hostingCloudServiceType null.String
+	hasUI                   null.String
+	pros                    null.String
+	cons                    null.String
+	costSavings             null.String
+	workforceTrainingReqs   null.String
+}
+
+var (
+	// lifecycleCostYears are the years costs are estimated for, in the order they're shown
+	lifecycleCostYears = []models.LifecycleCostYear{
+		models.LifecycleCostYear1,
+		models.LifecycleCostYear2,
+		models.LifecycleCostYear3,
+		models.LifecycleCostYear4,
+		models.LifecycleCostYear5,
+	}
+	// lifecycleCostPhases are the phases costs are estimated for, in the order they're shown on the business case form
+	lifecycleCostPhases = []models.LifecycleCostPhase{
+		models.LifecycleCostPhaseDEVELOPMENT,
+		models.LifecycleCostPhaseOPERATIONMAINTENANCE,
+		models.LifecycleCostPhaseHELPDESK,
+		models.LifecycleCostPhaseSOFTWARE,
+		models.LifecycleCostPhasePLANNING,
+		models.LifecycleCostPhaseINFRASTRUCTURE,
+		models.LifecycleCostPhaseOIT,
+		models.LifecycleCostPhaseOTHER,
+	}
+)
+
+// BusinessCasePDF renders a printable PDF of a business case, with the details and estimated lifecycle costs of the
+// preferred solution and each alternative
+func (c Client) BusinessCasePDF(businessCase *models.BusinessCaseWithCosts) ([]byte, error) {
+	document, err := c.businessCaseDocumentHTML(businessCase)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := pdf.FromHTML(&b, bytes.NewReader(document)); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// BusinessCasePDFAttachment returns a PDF of the business case with the given ID, from BusinessCasePDF, as an email attachment
+func BusinessCasePDFAttachment(businessCaseID uuid.UUID, document []byte) models.EmailAttachment {
+	return models.EmailAttachment{
+		Filename:    BusinessCasePDFFilename(businessCaseID),
+		ContentType: "application/pdf",
+		Data:        document,
+	}
+}
+
+// BusinessCasePDFFilename is the name a PDF of a business case is downloaded or attached with
+func BusinessCasePDFFilename(businessCaseID uuid.UUID) string {
+	return fmt.Sprintf("business_case_%s.pdf", businessCaseID)
+}
+
+func (c Client) businessCaseDocumentHTML(businessCase *models.BusinessCaseWithCosts) ([]byte, error) {
+	if c.templates.businessCaseDocumentTemplate == nil {
+		return nil, errors.New("business case document template is nil")
+	}
+
+	var b bytes.Buffer
+	if err := c.templates.businessCaseDocumentTemplate.Execute(&b, newBusinessCaseDocument(businessCase)); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func newBusinessCaseDocument(businessCase *models.BusinessCaseWithCosts) businessCaseDocument {
+	projectName := businessCase.ProjectName.ValueOrZero()
+	if projectName == "" {
+		projectName = "Draft Business Case"
+	}
+
+	document := businessCaseDocument{
+		Title:       "Business Case: " + projectName,
+		ProjectName: projectName,
+		UpdatedAt:   formatDocumentDate(businessCase.UpdatedAt),
+		Sections: []businessCaseDocumentSection{
+			{
+				Heading: "General request information",
+				Fields: []documentField{
+					businessCaseDocumentStringField("Project name", businessCase.ProjectName),
+					businessCaseDocumentStringField("Project acronym", businessCase.ProjectAcronym),
+					businessCaseDocumentStringField("Requester", businessCase.Requester),
+					businessCaseDocumentStringField("Requester phone number", businessCase.RequesterPhoneNumber),
+					businessCaseDocumentStringField("Business owner", businessCase.BusinessOwner),
+				},
+			},
+			{
+				Heading: "Request description",
+				Fields: []documentField{
+					businessCaseDocumentStringField("What is your business or user need?", businessCase.BusinessNeed),
+					businessCaseDocumentStringField("Current state", businessCase.CurrentSolutionSummary),
+					businessCaseDocumentStringField("How will CMS benefit from this effort?", businessCase.CMSBenefit),
+					businessCaseDocumentStringField("Internal collaboration", businessCase.CollaborationNeeded),
+					businessCaseDocumentStringField("How will you determine whether or not this effort is successful?", businessCase.SuccessIndicators),
+					businessCaseDocumentStringField("Response to GRT feedback", businessCase.ResponseToGRTFeedback),
+				},
+			},
+		},
+	}
+
+	costsBySolution := lo.GroupBy(businessCase.LifecycleCostLines, func(cost models.EstimatedLifecycleCost) models.LifecycleCostSolution {
+		return cost.Solution
+	})
+
+	for _, solution := range businessCaseSolutions(businessCase) {
+		costs := costsBySolution[solution.costSolution]
+		// alternatives are optional, so they're left out until the requester starts filling them in
+		if !solution.includeWhenNotFilledIn && solution.title.ValueOrZero() == "" && solution.summary.ValueOrZero() == "" && len(costs) == 0 {
+			continue
+		}
+
+		heading := solution.heading
+		if title := solution.title.ValueOrZero(); title != "" {
+			heading += ": " + title
+		}
+
+		document.Sections = append(document.Sections, businessCaseDocumentSection{
+			Heading: heading,
+			Fields: []documentField{
+				businessCaseDocumentStringField("Summary", solution.summary),
+				businessCaseDocumentStringField("Acquisition approach", solution.acquisitionApproach),
+				{Label: "Target contract award date", Value: lo.CoalesceOrEmpty(formatDocumentDate(solution.targetContractAwardDate), documentNotProvided)},
+				{Label: "Target completion date", Value: lo.CoalesceOrEmpty(formatDocumentDate(solution.targetCompletionDate), documentNotProvided)},
+				businessCaseDocumentBoolField("Is your solution approved by IT Security for use at CMS?", solution.securityIsApproved),
+				businessCaseDocumentStringField("Is it in the process of CMS IT Security approval?", solution.securityIsBeingReviewed),
+				businessCaseDocumentStringField("Zero trust alignment", solution.zeroTrustAlignment),
+				businessCaseDocumentStringField("Hosting type", solution.hostingType),
+				businessCaseDocumentStringField("Hosting location", solution.hostingLocation),
+				businessCaseDocumentStringField("Cloud strategy", solution.hostingCloudStrategy),
+				businessCaseDocumentStringField("Cloud service type", solution.hostingCloudServiceType),
+				businessCaseDocumentStringField("Will your solution have a user interface?", solution.hasUI),
+				businessCaseDocumentStringField("Pros", solution.pros),
+				businessCaseDocumentStringField("Cons", solution.cons),
+				businessCaseDocumentStringField("Cost savings", solution.costSavings),
+				businessCaseDocumentStringField("Workforce training requirements", solution.workforceTrainingReqs),
+			},
+			Costs: newBusinessCaseDocumentCosts(costs),
+		})
+	}
+
+	return document
+}
+
+func businessCaseSolutions(businessCase *models.BusinessCaseWithCosts) []businessCaseSolution {
+	return []businessCaseSolution{
+		{
+			costSolution:            models.LifecycleCostSolutionPREFERRED,
+			heading:                 "Preferred solution",
+			includeWhenNotFilledIn:  true,
+			title:                   businessCase.PreferredTitle,
+			summary:                 businessCase.PreferredSummary,
+			acquisitionApproach:     businessCase.PreferredAcquisitionApproach,
+			targetContractAwardDate: businessCase.PreferredTargetContractAwardDate,
+			targetCompletionDate:    businessCase.PreferredTargetCompletionDate,
+			securityIsApproved:      businessCase.PreferredSecurityIsApproved,
+			securityIsBeingReviewed: businessCase.PreferredSecurityIsBeingReviewed,
+			zeroTrustAlignment:      businessCase.PreferredZeroTrustAlignment,
+			hostingType:             businessCase.PreferredHostingType,
+			hostingLocation:         businessCase.PreferredHostingLocation,
+			hostingCloudStrategy:    businessCase.PreferredHostingCloudStrategy,
+			hostingCloudServiceType: businessCase.PreferredHostingCloudServiceType,
+			hasUI:                   businessCase.PreferredHasUI,
+			pros:                    businessCase.PreferredPros,
+			cons:                    businessCase.PreferredCons,
+			costSavings:             businessCase.PreferredCostSavings,
+			workforceTrainingReqs:   businessCase.PreferredWorkforceTrainingReqs,
+		},
+		{
+			costSolution:            models.LifecycleCostSolutionA,
+			heading:                 "Alternative A",
+			title:                   businessCase.AlternativeATitle,
+			summary:                 businessCase.AlternativeASummary,
+			acquisitionApproach:     businessCase.AlternativeAAcquisitionApproach,
+			targetContractAwardDate: businessCase.AlternativeATargetContractAwardDate,
+			targetCompletionDate:    businessCase.AlternativeATargetCompletionDate,
+			securityIsApproved:      businessCase.AlternativeASecurityIsApproved,
+			securityIsBeingReviewed: businessCase.AlternativeASecurityIsBeingReviewed,
+			zeroTrustAlignment:      businessCase.AlternativeAZeroTrustAlignment,
+			hostingType:             businessCase.AlternativeAHostingType,
+			hostingLocation:         businessCase.AlternativeAHostingLocation,
+			hostingCloudStrategy:    businessCase.AlternativeAHostingCloudStrategy,
+			hostingCloudServiceType: businessCase.AlternativeAHostingCloudServiceType,
+			hasUI:                   businessCase.AlternativeAHasUI,
+			pros:                    businessCase.AlternativeAPros,
+			cons:                    businessCase.AlternativeACons,
+			costSavings:             businessCase.AlternativeACostSavings,
+			workforceTrainingReqs:   businessCase.AlternativeAWorkforceTrainingReqs,
+		},
+		{
+			costSolution:            models.LifecycleCostSolutionB,
+			heading:                 "Alternative B",
+			title:                   businessCase.AlternativeBTitle,
+			summary:                 businessCase.AlternativeBSummary,
+			acquisitionApproach:     businessCase.AlternativeBAcquisitionApproach,
+			targetContractAwardDate: businessCase.AlternativeBTargetContractAwardDate,
+			targetCompletionDate:    businessCase.AlternativeBTargetCompletionDate,
+			securityIsApproved:      businessCase.AlternativeBSecurityIsApproved,
+			securityIsBeingReviewed: businessCase.AlternativeBSecurityIsBeingReviewed,
+			zeroTrustAlignment:      businessCase.AlternativeBZeroTrustAlignment,
+			hostingType:             businessCase.AlternativeBHostingType,
+			hostingLocation:         businessCase.AlternativeBHostingLocation,
+			hostingCloudStrategy:    businessCase.AlternativeBHostingCloudStrategy,
+			hostingCloudServiceType: businessCase.AlternativeBHostingCloudServiceType,
+			hasUI:                   businessCase.AlternativeBHasUI,
+			pros:                    businessCase.AlternativeBPros,
+			cons:                    businessCase.AlternativeBCons,
+			costSavings:             businessCase.AlternativeBCostSavings,
+			workforceTrainingReqs:   businessCase.AlternativeBWorkforceTrainingReqs,
+		},
+	}
+}
+
+// newBusinessCaseDocumentCosts totals a solution's costs by phase and year. Phases without any costs are left out
+func newBusinessCaseDocumentCosts(costs []models.EstimatedLifecycleCost) *businessCaseDocumentCosts {
+	table := &businessCaseDocumentCosts{
+		Years: lifecycleCostYears,
+	}
+
+	yearTotals := make([]int64, len(lifecycleCostYears))
+	var total int64
+	for _, phase := range lifecycleCostPhases {
+		phaseCosts := make([]int64, len(lifecycleCostYears))
+		hasCosts := false
+		for _, cost := range costs {
+			yearIndex := slices.Index(lifecycleCostYears, cost.Year)
+			if cost.Phase == nil || *cost.Phase != phase || yearIndex < 0 || cost.Cost == nil {
+				continue
+			}
+			phaseCosts[yearIndex] += *cost.Cost
+			hasCosts = true
+		}
+		if !hasCosts {
+			continue
+		}
+
+		var phaseTotal int64
+		for i, cost := range phaseCosts {
+			phaseTotal += cost
+			yearTotals[i] += cost
+		}
+		total += phaseTotal
+
+		table.Rows = append(table.Rows, businessCaseDocumentCostRow{
+			Phase: string(phase),
+			Costs: lo.Map(phaseCosts, func(cost int64, _ int) string { return formatDollars(cost) }),
+			Total: formatDollars(phaseTotal),
+		})
+	}
+
+	table.Totals = lo.Map(yearTotals, func(cost int64, _ int) string { return formatDollars(cost) })
+	table.Total = formatDollars(total)
+	return table
+}
+
+func businessCaseDocumentStringField(label string, value null.String) documentField {
+	return documentField{
+		Label: label,
+		Value: lo.CoalesceOrEmpty(value.ValueOrZero(), documentNotProvided),
+	}
+}
+
+func businessCaseDocumentBoolField(label string, value null.Bool) documentField {
+	field := documentField{
+		Label: label,
+		Value: documentNotProvided,
+	}
+	if value.Valid {
+		field.Value = lo.Ternary(value.Bool, "Yes", "No")
+	}
+	return field
+}
+
+func formatDocumentDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(documentDateLayout)
+}
+
+// formatDollars formats a whole number of dollars with thousands separators, e.g. $1,234,567
+func formatDollars(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	var grouped []byte
+	for i, digit := range []byte(digits) {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped = append(grouped, ',')
+		}
+		grouped = append(grouped, digit)
+	}
+	return fmt.Sprintf("%s$%s", sign, grouped)
+}
@@ -0,0 +1,75 @@
+package email
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *EmailTestSuite) TestBusinessCasePDF() {
+	client, err := NewClient(s.config, &mockSender{})
+	s.NoError(err)
+
+	updatedAt := time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+	businessCase := &models.BusinessCaseWithCosts{
+		BusinessCase: models.BusinessCase{
+			ProjectName:                 null.StringFrom("Mock Project"),
+			Requester:                   null.StringFrom("Jane Doe"),
+			PreferredTitle:              null.StringFrom("Build it"),
+			PreferredSummary:            null.StringFrom("First line\nSecond line"),
+			PreferredSecurityIsApproved: null.BoolFrom(true),
+			AlternativeATitle:           null.StringFrom("Buy it"),
+		},
+		LifecycleCostLines: models.EstimatedLifecycleCosts{
+			{
+				Solution: models.LifecycleCostSolutionPREFERRED,
+				Phase:    lo.ToPtr(models.LifecycleCostPhaseDEVELOPMENT),
+				Year:     models.LifecycleCostYear1,
+				Cost:     lo.ToPtr(int64(1000)),
+			},
+			{
+				Solution: models.LifecycleCostSolutionPREFERRED,
+				Phase:    lo.ToPtr(models.LifecycleCostPhaseOPERATIONMAINTENANCE),
+				Year:     models.LifecycleCostYear2,
+				Cost:     lo.ToPtr(int64(234)),
+			},
+		},
+	}
+	businessCase.UpdatedAt = &updatedAt
+
+	s.Run("renders each solution with its lifecycle costs", func() {
+		document, err := client.businessCaseDocumentHTML(businessCase)
+		s.NoError(err)
+
+		html := string(document)
+		s.Contains(html, "<title>Business Case: Mock Project</title>")
+		s.Contains(html, "last updated March 14, 2024")
+		s.Contains(html, "<h2>Preferred solution: Build it</h2>")
+		s.Contains(html, "<h2>Alternative A: Buy it</h2>")
+		s.Contains(html, "<dd style=\"white-space: pre-line\">Yes</dd>")
+		s.Contains(html, "<td>Development</td>")
+		s.Contains(html, "<td>Operations and Maintenance</td>")
+		s.Contains(html, "<th align=\"right\">$1,234</th>")
+
+		// Alternative B hasn't been started, so it's left out
+		s.NotContains(html, "Alternative B")
+	})
+
+	s.Run("renders a PDF", func() {
+		document, err := client.BusinessCasePDF(businessCase)
+		s.NoError(err)
+		s.True(bytes.HasPrefix(document, []byte("%PDF-")))
+	})
+}
+
+func (s *EmailTestSuite) TestFormatDollars() {
+	s.Equal("$0", formatDollars(0))
+	s.Equal("$999", formatDollars(999))
+	s.Equal("$1,000", formatDollars(1000))
+	s.Equal("$1,234,567", formatDollars(1234567))
+	s.Equal("-$12,345", formatDollars(-12345))
+}
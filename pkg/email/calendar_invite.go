@@ -0,0 +1,158 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// CalendarInviteMethod is the iCalendar METHOD of an invite, which tells calendar clients what to do with its event
+type CalendarInviteMethod string
+
+// These are the iCalendar methods EASi sends invites with
+const (
+	// CalendarInviteMethodRequest adds the event to recipients' calendars, or updates it if they already have it
+	CalendarInviteMethodRequest CalendarInviteMethod = "REQUEST"
+	// CalendarInviteMethodCancel removes the event from recipients' calendars
+	CalendarInviteMethodCancel CalendarInviteMethod = "CANCEL"
+)
+
+const (
+	icsDateFormat     = "20060102"
+	icsDateTimeFormat = "20060102T150405Z"
+
+	// icsMaxLineOctets is the longest a line of an iCalendar file can be before it must be folded onto the next line
+	icsMaxLineOctets = 75
+)
+
+// icsTextEscaper escapes the characters with special meaning in an iCalendar TEXT value
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// CalendarInvite is an RFC 5545 invite for a single event.
+// Calendar clients match invites to events by UID, and replace an event with an invite that has a higher Sequence,
+// so an event is rescheduled or cancelled by sending a new invite with the same UID and the next sequence
+type CalendarInvite struct {
+	UID         string
+	Sequence    int
+	Method      CalendarInviteMethod
+	Summary     string
+	Description string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	// AllDay events only use the dates of Start and End, and End is the day after the event's last day
+	AllDay    bool
+	Organizer models.EmailAddress
+	Attendees []models.EmailAddress
+}
+
+// TRBConsultMeetingInviteUID returns the UID of the calendar invite for a TRB request's consult meeting, which stays the same when it's rescheduled
+func (c Client) TRBConsultMeetingInviteUID(trbRequestID uuid.UUID) string {
+	return c.calendarInviteUID("trb-consult-meeting", trbRequestID)
+}
+
+// GRBMeetingInviteUID returns the UID of the calendar invite for a system intake's GRB meeting, which stays the same when it's rescheduled
+func (c Client) GRBMeetingInviteUID(systemIntakeID uuid.UUID) string {
+	return c.calendarInviteUID("grb-meeting", systemIntakeID)
+}
+
+// calendarInviteUID includes the host, so invites sent by different environments never update each other's events
+func (c Client) calendarInviteUID(eventType string, id uuid.UUID) string {
+	return fmt.Sprintf("%s-%s@%s", eventType, id, c.config.URLHost)
+}
+
+// Attachment returns the invite as an email attachment, stamped with the current time
+func (i CalendarInvite) Attachment() models.EmailAttachment {
+	return models.EmailAttachment{
+		Filename:    "invite.ics",
+		ContentType: fmt.Sprintf("text/calendar; charset=UTF-8; method=%s", i.Method),
+		Data:        i.ics(time.Now()),
+	}
+}
+
+// ics returns the invite as the contents of an iCalendar file, stamped with now
+func (i CalendarInvite) ics(now time.Time) []byte {
+	status := "CONFIRMED"
+	if i.Method == CalendarInviteMethodCancel {
+		status = "CANCELLED"
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//CMS//EASi//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:" + string(i.Method),
+		"BEGIN:VEVENT",
+		"UID:" + i.UID,
+		fmt.Sprintf("SEQUENCE:%d", i.Sequence),
+		"DTSTAMP:" + now.UTC().Format(icsDateTimeFormat),
+	}
+
+	if i.AllDay {
+		lines = append(lines,
+			"DTSTART;VALUE=DATE:"+i.Start.Format(icsDateFormat),
+			"DTEND;VALUE=DATE:"+i.End.Format(icsDateFormat),
+		)
+	} else {
+		lines = append(lines,
+			"DTSTART:"+i.Start.UTC().Format(icsDateTimeFormat),
+			"DTEND:"+i.End.UTC().Format(icsDateTimeFormat),
+		)
+	}
+
+	lines = append(lines, "SUMMARY:"+icsTextEscaper.Replace(i.Summary))
+	if i.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+icsTextEscaper.Replace(i.Description))
+	}
+	if i.URL != "" {
+		lines = append(lines, "URL:"+i.URL)
+	}
+	if i.Organizer != "" {
+		lines = append(lines, "ORGANIZER:mailto:"+i.Organizer.String())
+	}
+	for _, attendee := range i.Attendees {
+		lines = append(lines, "ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:"+attendee.String())
+	}
+
+	lines = append(lines,
+		"STATUS:"+status,
+		"TRANSP:OPAQUE",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	)
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICSLine(line))
+		b.WriteString("\r\n")
+	}
+	return []byte(b.String())
+}
+
+// foldICSLine splits a line longer than icsMaxLineOctets onto continuation lines, which start with a space.
+// Lines are only split between characters, so multi-byte characters aren't broken up
+func foldICSLine(line string) string {
+	if len(line) <= icsMaxLineOctets {
+		return line
+	}
+
+	var b strings.Builder
+	lineOctets := 0
+	for _, r := range line {
+		runeOctets := utf8.RuneLen(r)
+		if lineOctets+runeOctets > icsMaxLineOctets {
+			b.WriteString("\r\n ")
+			// the leading space counts towards the continuation line's length
+			lineOctets = 1
+		}
+		b.WriteRune(r)
+		lineOctets += runeOctets
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package email
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *EmailTestSuite) TestCalendarInvite() {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2024, 3, 4, 15, 30, 0, 0, time.UTC)
+
+	invite := CalendarInvite{
+		UID:         "trb-consult-meeting-1@localhost",
+		Sequence:    2,
+		Method:      CalendarInviteMethodRequest,
+		Summary:     "Consult for Project, Phase 1; Part A",
+		Description: "Line one\nLine two with a \\ backslash",
+		URL:         "http://localhost/trb",
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Organizer:   "trb@local.fake",
+		Attendees:   []models.EmailAddress{"requester@local.fake"},
+	}
+
+	s.Run("a timed invite has the right content", func() {
+		ics := string(invite.ics(now))
+		s.Equal(strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//CMS//EASi//EN",
+			"CALSCALE:GREGORIAN",
+			"METHOD:REQUEST",
+			"BEGIN:VEVENT",
+			"UID:trb-consult-meeting-1@localhost",
+			"SEQUENCE:2",
+			"DTSTAMP:20240301T120000Z",
+			"DTSTART:20240304T153000Z",
+			"DTEND:20240304T163000Z",
+			`SUMMARY:Consult for Project\, Phase 1\; Part A`,
+			`DESCRIPTION:Line one\nLine two with a \\ backslash`,
+			"URL:http://localhost/trb",
+			"ORGANIZER:mailto:trb@local.fake",
+			"ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:reques",
+			" ter@local.fake",
+			"STATUS:CONFIRMED",
+			"TRANSP:OPAQUE",
+			"END:VEVENT",
+			"END:VCALENDAR",
+			"",
+		}, "\r\n"), ics)
+	})
+
+	s.Run("an all day cancellation has the right content", func() {
+		cancellation := invite
+		cancellation.Method = CalendarInviteMethodCancel
+		cancellation.AllDay = true
+		cancellation.End = start.AddDate(0, 0, 1)
+
+		ics := string(cancellation.ics(now))
+		s.Contains(ics, "\r\nMETHOD:CANCEL\r\n")
+		s.Contains(ics, "\r\nDTSTART;VALUE=DATE:20240304\r\n")
+		s.Contains(ics, "\r\nDTEND;VALUE=DATE:20240305\r\n")
+		s.Contains(ics, "\r\nSTATUS:CANCELLED\r\n")
+
+		attachment := cancellation.Attachment()
+		s.Equal("invite.ics", attachment.Filename)
+		s.Equal("text/calendar; charset=UTF-8; method=CANCEL", attachment.ContentType)
+	})
+
+	s.Run("long lines are folded without splitting characters", func() {
+		long := invite
+		long.Summary = strings.Repeat("é", 50)
+
+		for _, line := range strings.Split(string(long.ics(now)), "\r\n") {
+			s.LessOrEqual(len(line), icsMaxLineOctets)
+		}
+		s.Contains(string(long.ics(now)), "SUMMARY:"+strings.Repeat("é", 33)+"\r\n "+strings.Repeat("é", 17)+"\r\n")
+	})
+
+	s.Run("UIDs are stable and include the host", func() {
+		client, err := NewClient(s.config, &mockSender{})
+		s.NoError(err)
+
+		id := uuid.New()
+		s.Equal(client.TRBConsultMeetingInviteUID(id), client.TRBConsultMeetingInviteUID(id))
+		s.Equal("grb-meeting-"+id.String()+"@"+s.config.URLHost, client.GRBMeetingInviteUID(id))
+		s.NotEqual(client.TRBConsultMeetingInviteUID(id), client.GRBMeetingInviteUID(id))
+	})
+}
@@ -101,6 +101,7 @@ func (c Client) SendCedarNewTeamMemberEmail(
 	return c.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategorySystemWorkspaces).
 			WithToAddresses(recipients).
 			WithSubject(subject).
 			WithBody(body),
@@ -68,6 +68,7 @@ func (c Client) SendCedarRolesChangedEmail(
 	return c.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategorySystemWorkspaces).
 			WithToAddresses([]models.EmailAddress{c.config.CEDARTeamEmail}).
 			WithSubject(subject).
 			WithBody(body),
@@ -60,6 +60,7 @@ func (c Client) SendCedarYouHaveBeenAddedEmail(
 	return c.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategorySystemWorkspaces).
 			WithToAddresses([]models.EmailAddress{teamMemberEmail}).
 			WithSubject(subject).
 			WithBody(body),
@@ -0,0 +1,140 @@
+package email
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// discussionReplyTokenLifetime is how long after a GRB discussion email is sent that it can be replied to by email
+const discussionReplyTokenLifetime = 90 * 24 * time.Hour
+
+// discussionReplyMACSize is how many bytes of the HMAC are kept in a reply token, so Reply-To addresses stay under the 64 character limit on local parts
+const discussionReplyMACSize = 12
+
+// reply tokens are lowercase base32, since mail servers don't always preserve the case of local parts
+var discussionReplyTokenEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ErrInvalidDiscussionReplyAddress is returned for a reply to an address that isn't a valid Reply-To address for the sender,
+// because it was changed, it has expired, or it was sent to someone else
+var ErrInvalidDiscussionReplyAddress = errors.New("invalid GRB discussion reply address")
+
+// DiscussionRepliesEnabled returns whether GRB discussion emails can be replied to by email
+func (c Client) DiscussionRepliesEnabled() bool {
+	return c.config.ReplyToAddress != "" && c.config.ReplyTokenSecret != ""
+}
+
+// discussionReplyAddress returns the Reply-To address for a GRB discussion email sent to recipient,
+// which identifies the discussion and can only be used by recipient
+func (c Client) discussionReplyAddress(discussionID uuid.UUID, recipient models.EmailAddress, now time.Time) models.EmailAddress {
+	expiresAt := uint32(now.Add(discussionReplyTokenLifetime).Unix())
+
+	token := make([]byte, 0, 16+4+discussionReplyMACSize)
+	token = append(token, discussionID[:]...)
+	token = binary.BigEndian.AppendUint32(token, expiresAt)
+	token = append(token, c.discussionReplyMAC(discussionID, expiresAt, recipient)...)
+
+	localPart, domain, _ := strings.Cut(c.config.ReplyToAddress.String(), "@")
+	return models.EmailAddress(localPart + "+" + strings.ToLower(discussionReplyTokenEncoding.EncodeToString(token)) + "@" + domain)
+}
+
+// discussionReplyMAC signs a reply token's discussion and expiry for the recipient it's sent to
+func (c Client) discussionReplyMAC(discussionID uuid.UUID, expiresAt uint32, recipient models.EmailAddress) []byte {
+	mac := hmac.New(sha256.New, []byte(c.config.ReplyTokenSecret))
+	mac.Write([]byte("grb-discussion-reply"))
+	mac.Write(discussionID[:])
+	mac.Write(binary.BigEndian.AppendUint32(nil, expiresAt))
+	mac.Write([]byte(strings.ToLower(recipient.String())))
+	return mac.Sum(nil)[:discussionReplyMACSize]
+}
+
+// VerifyDiscussionReplyAddress returns the discussion a reply from sender was sent to, if address is a Reply-To address
+// that was given to sender and hasn't expired. It returns ErrInvalidDiscussionReplyAddress otherwise
+func (c Client) VerifyDiscussionReplyAddress(address models.EmailAddress, sender models.EmailAddress, now time.Time) (uuid.UUID, error) {
+	if !c.DiscussionRepliesEnabled() {
+		return uuid.Nil, ErrInvalidDiscussionReplyAddress
+	}
+
+	token, ok := c.discussionReplyToken(address)
+	if !ok {
+		return uuid.Nil, ErrInvalidDiscussionReplyAddress
+	}
+
+	decoded, err := discussionReplyTokenEncoding.DecodeString(strings.ToUpper(token))
+	if err != nil || len(decoded) != 16+4+discussionReplyMACSize {
+		return uuid.Nil, ErrInvalidDiscussionReplyAddress
+	}
+
+	discussionID, err := uuid.FromBytes(decoded[:16])
+	if err != nil {
+		return uuid.Nil, ErrInvalidDiscussionReplyAddress
+	}
+	expiresAt := binary.BigEndian.Uint32(decoded[16:20])
+
+	if !hmac.Equal(decoded[20:], c.discussionReplyMAC(discussionID, expiresAt, sender)) {
+		return uuid.Nil, ErrInvalidDiscussionReplyAddress
+	}
+	if now.Unix() > int64(expiresAt) {
+		return uuid.Nil, ErrInvalidDiscussionReplyAddress
+	}
+
+	return discussionID, nil
+}
+
+// discussionReplyToken returns the token in a Reply-To address, if it's one of the client's Reply-To addresses
+func (c Client) discussionReplyToken(address models.EmailAddress) (string, bool) {
+	replyLocalPart, replyDomain, _ := strings.Cut(c.config.ReplyToAddress.String(), "@")
+
+	localPart, domain, ok := strings.Cut(address.String(), "@")
+	if !ok || !strings.EqualFold(domain, replyDomain) {
+		return "", false
+	}
+
+	prefix, token, ok := strings.Cut(localPart, "+")
+	if !ok || !strings.EqualFold(prefix, replyLocalPart) || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// sendDiscussionEmail sends a GRB discussion email. When discussions can be replied to by email, each of replyRecipients is left out of email
+// and sent their own copy instead, with a Reply-To address that posts their reply to the discussion. Anyone else the email is addressed to,
+// such as a shared mailbox, is sent it without a Reply-To address
+func (c Client) sendDiscussionEmail(ctx context.Context, email Email, discussionID uuid.UUID, replyRecipients []models.EmailAddress) error {
+	if !c.DiscussionRepliesEnabled() {
+		return c.sender.Send(ctx, email)
+	}
+
+	email.ToAddresses = lo.Without(email.ToAddresses, replyRecipients...)
+	email.CcAddresses = lo.Without(email.CcAddresses, replyRecipients...)
+	email.BccAddresses = lo.Without(email.BccAddresses, replyRecipients...)
+	if len(email.ToAddresses) > 0 || len(email.CcAddresses) > 0 || len(email.BccAddresses) > 0 {
+		if err := c.sender.Send(ctx, email); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	for _, recipient := range lo.Uniq(replyRecipients) {
+		err := c.sender.Send(ctx, email.
+			WithToAddresses([]models.EmailAddress{recipient}).
+			WithCCAddresses(nil).
+			WithBCCAddresses(nil).
+			WithReplyToAddress(c.discussionReplyAddress(discussionID, recipient, now)),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package email
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *EmailTestSuite) discussionReplyConfig() Config {
+	config := s.config
+	config.ReplyToAddress = models.NewEmailAddress("reply@inbound.cms.fake")
+	config.ReplyTokenSecret = "test-reply-token-secret"
+	return config
+}
+
+func (s *EmailTestSuite) TestDiscussionReplyAddress() {
+	client, err := NewClient(s.discussionReplyConfig(), &mockSender{})
+	s.NoError(err)
+
+	discussionID := uuid.MustParse("a5689bec-e4cf-4f2b-a7de-72020e8d65be")
+	recipient := models.NewEmailAddress("reviewer@cms.fake")
+	now := time.Now()
+
+	address := client.discussionReplyAddress(discussionID, recipient, now)
+
+	s.Run("addresses are the reply address with a lowercase token that fits in a local part", func() {
+		localPart, domain, ok := strings.Cut(address.String(), "@")
+		s.True(ok)
+		s.Equal("inbound.cms.fake", domain)
+		s.True(strings.HasPrefix(localPart, "reply+"))
+		s.Equal(strings.ToLower(localPart), localPart)
+		s.LessOrEqual(len(localPart), 64)
+	})
+
+	s.Run("the recipient's reply is verified", func() {
+		verifiedID, err := client.VerifyDiscussionReplyAddress(address, recipient, now)
+		s.NoError(err)
+		s.Equal(discussionID, verifiedID)
+	})
+
+	s.Run("addresses are verified regardless of case", func() {
+		verifiedID, err := client.VerifyDiscussionReplyAddress(
+			models.EmailAddress(strings.ToUpper(address.String())),
+			models.EmailAddress(strings.ToUpper(recipient.String())),
+			now,
+		)
+		s.NoError(err)
+		s.Equal(discussionID, verifiedID)
+	})
+
+	s.Run("a reply from someone else isn't verified", func() {
+		_, err := client.VerifyDiscussionReplyAddress(address, models.NewEmailAddress("someone.else@cms.fake"), now)
+		s.ErrorIs(err, ErrInvalidDiscussionReplyAddress)
+	})
+
+	s.Run("an expired address isn't verified", func() {
+		_, err := client.VerifyDiscussionReplyAddress(address, recipient, now.Add(discussionReplyTokenLifetime+time.Hour))
+		s.ErrorIs(err, ErrInvalidDiscussionReplyAddress)
+	})
+
+	s.Run("a changed token isn't verified", func() {
+		otherAddress := client.discussionReplyAddress(uuid.New(), recipient, now)
+		localPart, domain, _ := strings.Cut(address.String(), "@")
+		otherLocalPart, _, _ := strings.Cut(otherAddress.String(), "@")
+
+		// the discussion ID from another address, with this address's signature
+		spliced := otherLocalPart[:len("reply+")+26] + localPart[len("reply+")+26:]
+		_, err := client.VerifyDiscussionReplyAddress(models.EmailAddress(spliced+"@"+domain), recipient, now)
+		s.ErrorIs(err, ErrInvalidDiscussionReplyAddress)
+	})
+
+	s.Run("addresses signed with another secret aren't verified", func() {
+		config := s.discussionReplyConfig()
+		config.ReplyTokenSecret = "another-secret"
+		otherClient, err := NewClient(config, &mockSender{})
+		s.NoError(err)
+
+		_, err = otherClient.VerifyDiscussionReplyAddress(address, recipient, now)
+		s.ErrorIs(err, ErrInvalidDiscussionReplyAddress)
+	})
+
+	s.Run("other addresses aren't verified", func() {
+		for _, other := range []models.EmailAddress{"reply@inbound.cms.fake", "reply+@inbound.cms.fake", "other+abc@inbound.cms.fake", "reviewer@cms.fake"} {
+			_, err := client.VerifyDiscussionReplyAddress(other, recipient, now)
+			s.ErrorIs(err, ErrInvalidDiscussionReplyAddress)
+		}
+	})
+
+	s.Run("nothing is verified when replies are turned off", func() {
+		disabledClient, err := NewClient(s.config, &mockSender{})
+		s.NoError(err)
+		s.False(disabledClient.DiscussionRepliesEnabled())
+
+		_, err = disabledClient.VerifyDiscussionReplyAddress(address, recipient, now)
+		s.ErrorIs(err, ErrInvalidDiscussionReplyAddress)
+	})
+}
+
+func (s *EmailTestSuite) TestSendDiscussionEmail() {
+	ctx := context.Background()
+	discussionID := uuid.New()
+	reviewers := []models.EmailAddress{"reviewer.one@cms.fake", "reviewer.two@cms.fake"}
+	email := NewEmail().
+		WithToAddresses(append([]models.EmailAddress{s.config.GRTEmail}, reviewers...)).
+		WithSubject("subject").
+		WithBody("body")
+
+	s.Run("sends the email unchanged when replies are turned off", func() {
+		sender := &previewSender{}
+		client, err := NewClient(s.config, sender)
+		s.NoError(err)
+
+		s.NoError(client.sendDiscussionEmail(ctx, email, discussionID, reviewers))
+		s.Len(sender.emails, 1)
+		s.ElementsMatch(email.ToAddresses, sender.emails[0].ToAddresses)
+		s.Empty(sender.emails[0].ReplyToAddress)
+	})
+
+	s.Run("sends each reply recipient their own copy with their own reply address", func() {
+		sender := &previewSender{}
+		client, err := NewClient(s.discussionReplyConfig(), sender)
+		s.NoError(err)
+
+		s.NoError(client.sendDiscussionEmail(ctx, email, discussionID, reviewers))
+		s.Len(sender.emails, 3)
+
+		// the governance inbox can't reply by email
+		s.Equal([]models.EmailAddress{s.config.GRTEmail}, sender.emails[0].ToAddresses)
+		s.Empty(sender.emails[0].ReplyToAddress)
+
+		for i, reviewer := range reviewers {
+			sent := sender.emails[i+1]
+			s.Equal([]models.EmailAddress{reviewer}, sent.ToAddresses)
+			s.Empty(sent.CcAddresses)
+			s.Empty(sent.BccAddresses)
+			s.Equal(email.Subject, sent.Subject)
+
+			verifiedID, err := client.VerifyDiscussionReplyAddress(sent.ReplyToAddress, reviewer, time.Now())
+			s.NoError(err)
+			s.Equal(discussionID, verifiedID)
+		}
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/url"
 	"path"
+	"slices"
 	"strings"
 
 	"github.com/cms-enterprise/easi-app/pkg/models"
@@ -23,6 +24,12 @@ type Config struct {
 	URLHost                     string
 	URLScheme                   string
 	TemplateDirectory           string
+	// ReplyToAddress is the inbound address that replies to GRB discussion emails are sent to, so they can be posted to the discussion.
+	// Each recipient's Reply-To address adds a signed token to it, such as reply+<token>@inbound.easi.cms.gov.
+	// Replying by email is turned off when it's empty
+	ReplyToAddress models.EmailAddress
+	// ReplyTokenSecret is the key the tokens in Reply-To addresses are signed with
+	ReplyTokenSecret string
 }
 
 // templateCaller is an interface to helping with testing template dependencies
@@ -53,12 +60,14 @@ type templates struct {
 	trbEditsNeededOnForm                            templateCaller
 	trbRequestReopened                              templateCaller
 	trbGuidanceLetterSubmitted                      templateCaller
+	trbGuidanceLetterAmended                        templateCaller
 	trbRequestClosed                                templateCaller
 	cedarRolesChanged                               templateCaller
 	cedarYouHaveBeenAdded                           templateCaller
 	cedarNewTeamMember                              templateCaller
 	systemIntakeAdminUploadDocTemplate              templateCaller
 	systemIntakeGRBReviewDeadlineExtendedTemplate   templateCaller
+	systemIntakeGRBMeetingTemplate                  templateCaller
 	systemIntakeGRBReviewRestartedTemplate          templateCaller
 	systemIntakeGRBReviewRestartedAdminTemplate     templateCaller
 	systemIntakeGRBReviewTimeAddedTemplate          templateCaller
@@ -98,6 +107,9 @@ type templates struct {
 	grbReviewVoteSubmitted                          templateCaller
 	grbReviewVoteSubmittedAdmin                     templateCaller
 	grbReviewVoteChangedAdmin                       templateCaller
+	businessCaseDocumentTemplate                    templateCaller
+	trbGuidanceLetterDocumentTemplate               templateCaller
+	notificationDigest                              templateCaller
 }
 
 // sender is an interface for swapping out email provider implementations
@@ -252,6 +264,13 @@ func NewClient(config Config, sender sender) (Client, error) {
 	}
 	appTemplates.trbGuidanceLetterSubmitted = trbGuidanceLetterSubmittedTemplate
 
+	trbGuidanceLetterAmendedTemplateName := "trb_guidance_letter_amended.gohtml"
+	trbGuidanceLetterAmendedTemplate := rawTemplates.Lookup(trbGuidanceLetterAmendedTemplateName)
+	if trbGuidanceLetterAmendedTemplate == nil {
+		return Client{}, templateError(trbGuidanceLetterAmendedTemplateName)
+	}
+	appTemplates.trbGuidanceLetterAmended = trbGuidanceLetterAmendedTemplate
+
 	trbEditsNeededOnFormTemplateName := "trb_edits_needed_on_form.gohtml"
 	trbEditsNeededOnFormTemplate := rawTemplates.Lookup(trbEditsNeededOnFormTemplateName)
 	if trbEditsNeededOnFormTemplate == nil {
@@ -308,6 +327,13 @@ func NewClient(config Config, sender sender) (Client, error) {
 	}
 	appTemplates.systemIntakeGRBReviewDeadlineExtendedTemplate = sisGRBReviewDeadlineExtendedTemplate
 
+	sisGRBMeetingTemplateName := "system_intake_grb_meeting.gohtml"
+	sisGRBMeetingTemplate := rawTemplates.Lookup(sisGRBMeetingTemplateName)
+	if sisGRBMeetingTemplate == nil {
+		return Client{}, templateError(sisGRBMeetingTemplateName)
+	}
+	appTemplates.systemIntakeGRBMeetingTemplate = sisGRBMeetingTemplate
+
 	sisGRBReviewRestartedTemplateName := "system_intake_grb_review_restarted.gohtml"
 	sisGRBReviewRestartedTemplate := rawTemplates.Lookup(sisGRBReviewRestartedTemplateName)
 	if sisGRBReviewRestartedTemplate == nil {
@@ -581,6 +607,27 @@ func NewClient(config Config, sender sender) (Client, error) {
 	}
 	appTemplates.grbReviewVoteChangedAdmin = grbReviewVoteChangedAdmin
 
+	businessCaseDocumentTemplateName := "business_case_document.gohtml"
+	businessCaseDocumentTemplate := rawTemplates.Lookup(businessCaseDocumentTemplateName)
+	if businessCaseDocumentTemplate == nil {
+		return Client{}, templateError(businessCaseDocumentTemplateName)
+	}
+	appTemplates.businessCaseDocumentTemplate = businessCaseDocumentTemplate
+
+	trbGuidanceLetterDocumentTemplateName := "trb_guidance_letter_document.gohtml"
+	trbGuidanceLetterDocumentTemplate := rawTemplates.Lookup(trbGuidanceLetterDocumentTemplateName)
+	if trbGuidanceLetterDocumentTemplate == nil {
+		return Client{}, templateError(trbGuidanceLetterDocumentTemplateName)
+	}
+	appTemplates.trbGuidanceLetterDocumentTemplate = trbGuidanceLetterDocumentTemplate
+
+	notificationDigestTemplateName := "notification_digest.gohtml"
+	notificationDigestTemplate := rawTemplates.Lookup(notificationDigestTemplateName)
+	if notificationDigestTemplate == nil {
+		return Client{}, templateError(notificationDigestTemplateName)
+	}
+	appTemplates.notificationDigest = notificationDigestTemplate
+
 	client := Client{
 		config:    config,
 		templates: appTemplates,
@@ -667,6 +714,15 @@ type Email struct {
 	BccAddresses []models.EmailAddress
 	Subject      string
 	Body         string
+	Attachments  []models.EmailAttachment
+	// ReplyToAddress is where replies to the email are sent, instead of the address it's sent from. It's empty for emails that can't be replied to
+	ReplyToAddress models.EmailAddress
+	// NotificationCategory is the category of notification the email belongs to, which recipients can choose how to receive.
+	// It is empty for emails that are always sent immediately, such as legally required notices
+	NotificationCategory models.NotificationCategory
+	// excludeFromNotificationCenter is set on emails that don't belong in recipients' in-app notifications,
+	// such as daily digests, which only repeat notifications already there
+	excludeFromNotificationCenter bool
 }
 
 // NewEmail returns an empty email object
@@ -703,3 +759,28 @@ func (e Email) WithBody(body string) Email {
 	e.Body = body
 	return e
 }
+
+// WithReplyToAddress sets the address replies to an email are sent to
+func (e Email) WithReplyToAddress(replyToAddress models.EmailAddress) Email {
+	e.ReplyToAddress = replyToAddress
+	return e
+}
+
+// WithAttachment adds a file attachment to an email. Attachments are validated when the email is sent, by ValidateAttachments
+func (e Email) WithAttachment(attachment models.EmailAttachment) Email {
+	// clip so emails built from the same base email don't share attachments
+	e.Attachments = append(slices.Clip(e.Attachments), attachment)
+	return e
+}
+
+// WithInlineImage adds an image to an email that's shown in its body, where it's referenced by an <img> with a src of cid:<contentID>
+func (e Email) WithInlineImage(contentID string, image models.EmailAttachment) Email {
+	image.ContentID = contentID
+	return e.WithAttachment(image)
+}
+
+// WithNotificationCategory sets the category of notification an email belongs to
+func (e Email) WithNotificationCategory(category models.NotificationCategory) Email {
+	e.NotificationCategory = category
+	return e
+}
@@ -0,0 +1,835 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/zero"
+
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// EmailPreviewData is the request an email preview is rendered for. Fields that don't come from the request,
+// such as feedback and LCID details, are filled in with sample content
+type EmailPreviewData struct {
+	SystemIntakeID     uuid.UUID
+	ProjectName        string
+	RequesterName      string
+	RequesterComponent string
+}
+
+// SampleEmailPreviewData returns preview data for a made up request, for previewing emails without a real one
+func SampleEmailPreviewData() EmailPreviewData {
+	return EmailPreviewData{
+		SystemIntakeID:     uuid.New(),
+		ProjectName:        "Sample Project",
+		RequesterName:      "Sample Requester",
+		RequesterComponent: "Office of Information Technology",
+	}
+}
+
+// EmailPreview is an email rendered from one of the email templates, without sending it
+type EmailPreview struct {
+	TemplateName string
+	Subject      string
+	Body         string
+	Attachments  []models.EmailAttachment
+}
+
+// previewSender is a sender that keeps the emails sent with it instead of sending them, so they can be previewed
+type previewSender struct {
+	mu     sync.Mutex
+	emails []Email
+}
+
+func (s *previewSender) Send(ctx context.Context, email Email) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emails = append(s.emails, email)
+	return nil
+}
+
+// emailPreviewRenderer sends the email rendered by a template with sample content, using a client whose sender keeps it for previewing
+type emailPreviewRenderer func(ctx context.Context, c Client, data EmailPreviewData) error
+
+// sample content shared by email previews
+const (
+	previewRecipient     = models.EmailAddress("preview@local.fake")
+	previewAdminName     = "Sample Admin"
+	previewLCID          = "123456"
+	previewCostBaseline  = "Sample cost baseline"
+	previewTRBLeadName   = "Sample TRB Lead"
+	previewGRBMemberName = "Sample GRB Member"
+	previewSystemName    = "Sample System"
+	previewRichText      = "<p>This is sample text, in place of what the person taking the action would write.</p><ul><li>It can include lists</li><li>and <strong>formatting</strong></li></ul>"
+	previewDiscussion    = `<p>This is a sample discussion post, <span data-type="mention" tag-type="USER_ACCOUNT" class="mention">@Sample Reviewer</span>.</p>`
+)
+
+func previewRecipients() models.EmailNotificationRecipients {
+	return models.EmailNotificationRecipients{
+		RegularRecipientEmails: []models.EmailAddress{previewRecipient},
+	}
+}
+
+// emailPreviewRenderers are the email templates that can be previewed, by the name of their template file
+var emailPreviewRenderers = map[string]emailPreviewRenderer{
+	"cedar_new_team_member": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendCedarNewTeamMemberEmail(ctx, data.RequesterName, "new.member@local.fake", previewSystemName, data.SystemIntakeID, []string{"System Manager"}, []*models.CedarRole{
+			{
+				RoleTypeName:  zero.StringFrom(models.BusinessOwnerRole.String()),
+				AssigneeEmail: zero.StringFrom(previewRecipient.String()),
+			},
+		})
+	},
+	"cedar_roles_changed": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendCedarRolesChangedEmail(ctx, previewAdminName, data.RequesterName, true, false, []string{"Business Owner"}, []string{"Business Owner", "System Manager"}, previewSystemName, time.Now())
+	},
+	"cedar_you_have_been_added": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendCedarYouHaveBeenAddedEmail(ctx, previewSystemName, data.SystemIntakeID, []string{"System Manager"}, previewRecipient)
+	},
+	"grb_review_complete_quorum_met": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewCompleteQuorumMet(ctx, SendGRBReviewCompleteQuorumMetInput{
+			SystemIntakeID:     data.SystemIntakeID,
+			ProjectTitle:       data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			StartDate:          time.Now().AddDate(0, 0, -7),
+			EndDate:            time.Now(),
+			NoObjectionVotes:   5,
+			ObjectionVotes:     1,
+			NotYetVoted:        0,
+		})
+	},
+	"grb_review_discussion_group_tagged": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewDiscussionGroupTaggedEmail(ctx, SendGRBReviewDiscussionGroupTaggedEmailInput{
+			SystemIntakeID:    data.SystemIntakeID,
+			UserName:          previewGRBMemberName,
+			RequestName:       data.ProjectName,
+			Role:              "Voting Member",
+			GroupName:         "Governance Review Board",
+			DiscussionContent: previewDiscussion,
+			Recipients:        previewRecipients(),
+		})
+	},
+	"grb_review_discussion_individual_tagged": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewDiscussionIndividualTaggedEmail(ctx, SendGRBReviewDiscussionIndividualTaggedEmailInput{
+			SystemIntakeID:    data.SystemIntakeID,
+			UserName:          previewGRBMemberName,
+			RequestName:       data.ProjectName,
+			Role:              "Voting Member",
+			DiscussionContent: previewDiscussion,
+			Recipients:        []models.EmailAddress{previewRecipient},
+		})
+	},
+	"grb_review_discussion_project_team_individual_tagged": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewDiscussionProjectTeamIndividualTaggedEmail(ctx, SendGRBReviewDiscussionProjectTeamIndividualTaggedInput{
+			SystemIntakeID:    data.SystemIntakeID,
+			UserName:          previewGRBMemberName,
+			RequestName:       data.ProjectName,
+			Role:              "Voting Member",
+			DiscussionID:      uuid.New(),
+			DiscussionContent: previewDiscussion,
+			DiscussionBoard:   models.SystemIntakeGRBDiscussionBoardTypePrimary,
+			Recipient:         previewRecipient,
+		})
+	},
+	"grb_review_discussion_reply": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewDiscussionReplyEmail(ctx, SendGRBReviewDiscussionReplyEmailInput{
+			SystemIntakeID:    data.SystemIntakeID,
+			UserName:          previewGRBMemberName,
+			RequestName:       data.ProjectName,
+			Role:              "Voting Member",
+			DiscussionContent: previewDiscussion,
+			Recipient:         previewRecipient,
+		})
+	},
+	"grb_review_discussion_reply_requester": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewDiscussionReplyRequesterEmail(ctx, SendGRBReviewDiscussionReplyRequesterEmailInput{
+			SystemIntakeID:    data.SystemIntakeID,
+			RequestName:       data.ProjectName,
+			ReplierName:       previewGRBMemberName,
+			VotingRole:        "Voting Member",
+			GRBRole:           "CIO",
+			DiscussionContent: previewDiscussion,
+			Recipient:         previewRecipient,
+		})
+	},
+	"grb_review_halfway_done": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewHalfwayThrough(ctx, SendGRBReviewHalfwayThroughInput{
+			SystemIntakeID:     data.SystemIntakeID,
+			ProjectTitle:       data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			StartDate:          time.Now().AddDate(0, 0, -3),
+			EndDate:            time.Now().AddDate(0, 0, 3),
+			NoObjectionVotes:   2,
+			ObjectionVotes:     1,
+			NotYetVoted:        3,
+		})
+	},
+	"grb_review_past_due_no_quorum": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewPastDueNoQuorum(ctx, SendGRBReviewPastDueNoQuorumInput{
+			SystemIntakeID:     data.SystemIntakeID,
+			ProjectTitle:       data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			StartDate:          time.Now().AddDate(0, 0, -8),
+			EndDate:            time.Now().AddDate(0, 0, -1),
+			NoObjectionVotes:   1,
+			ObjectionVotes:     0,
+			NotYetVoted:        5,
+		})
+	},
+	"grb_review_presentation_links_updated": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewPresentationLinksUpdatedEmail(ctx, SendGRBReviewPresentationLinksUpdatedEmailInput{
+			SystemIntakeID:     data.SystemIntakeID,
+			ProjectName:        data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			Recipients:         []models.EmailAddress{previewRecipient},
+		})
+	},
+	"grb_review_vote_changed_admin": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewVoteChangedAdmin(ctx, SendGRBReviewVoteChangedAdminInput{
+			SystemIntakeID:     data.SystemIntakeID,
+			GRBMemberName:      previewGRBMemberName,
+			ProjectTitle:       data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			StartDate:          time.Now().AddDate(0, 0, -3),
+			EndDate:            time.Now().AddDate(0, 0, 3),
+			Vote:               models.SystemIntakeAsyncGRBVotingOptionObjection,
+			AdditionalComments: "This is a sample comment on the vote.",
+			NoObjectionVotes:   2,
+			ObjectionVotes:     1,
+			NotYetVoted:        3,
+		})
+	},
+	"grb_review_vote_submitted": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewVoteSubmitted(ctx, SendGRBReviewVoteSubmittedInput{
+			Recipient:          previewRecipient,
+			SystemIntakeID:     data.SystemIntakeID,
+			ProjectTitle:       data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			StartDate:          time.Now().AddDate(0, 0, -3),
+			EndDate:            time.Now().AddDate(0, 0, 3),
+			Vote:               models.SystemIntakeAsyncGRBVotingOptionNoObjection,
+		})
+	},
+	"grb_review_vote_submitted_admin": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewVoteSubmittedAdmin(ctx, SendGRBReviewVoteSubmittedAdminInput{
+			SystemIntakeID:     data.SystemIntakeID,
+			GRBMemberName:      previewGRBMemberName,
+			ProjectTitle:       data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			StartDate:          time.Now().AddDate(0, 0, -3),
+			EndDate:            time.Now().AddDate(0, 0, 3),
+			Vote:               models.SystemIntakeAsyncGRBVotingOptionNoObjection,
+			AdditionalComments: "This is a sample comment on the vote.",
+			NoObjectionVotes:   3,
+			ObjectionVotes:     0,
+			NotYetVoted:        3,
+		})
+	},
+	"grb_reviewer_invited_to_vote": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendGRBReviewerInvitedToVoteEmail(ctx, SendGRBReviewerInvitedToVoteInput{
+			Recipient:          previewRecipient,
+			StartDate:          time.Now(),
+			EndDate:            time.Now().AddDate(0, 0, 7),
+			SystemIntakeID:     data.SystemIntakeID,
+			ProjectName:        data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+		})
+	},
+	"help_cant_find_something": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendCantFindSomethingEmail(ctx, SendCantFindSomethingEmailInput{
+			Name:  data.RequesterName,
+			Email: previewRecipient.String(),
+			Body:  "I can't find the form to request a Life Cycle ID.",
+		})
+	},
+	"help_report_a_problem": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendReportAProblemEmail(ctx, SendReportAProblemEmailInput{
+			ReporterName:           data.RequesterName,
+			ReporterEmail:          previewRecipient.String(),
+			CanBeContacted:         true,
+			EasiService:            "IT Governance",
+			WhatWereYouDoing:       "Submitting my intake request",
+			WhatWentWrong:          "The page didn't load",
+			HowSevereWasTheProblem: "It prevented me from completing my task",
+		})
+	},
+	"help_send_feedback": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendFeedbackEmail(ctx, SendFeedbackEmailInput{
+			ReporterName:           data.RequesterName,
+			ReporterEmail:          previewRecipient.String(),
+			CanBeContacted:         true,
+			EasiServicesUsed:       []string{"IT Governance", "Technical Review Board"},
+			CmsRole:                "Project Lead",
+			SystemEasyToUse:        "Agree",
+			DidntNeedHelpAnswering: "Agree",
+			QuestionsWereRelevant:  "Agree",
+			HadAccessToInformation: "Agree",
+			HowSatisfied:           "Satisfied",
+			HowCanWeImprove:        "Keep up the good work",
+		})
+	},
+	"named_request_withdrawal": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendWithdrawRequestEmail(ctx, data.ProjectName)
+	},
+	"notification_digest": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendNotificationDigestEmail(ctx, previewRecipient, []*models.NotificationDigestItem{
+			{
+				Category:  models.NotificationCategoryGRBDiscussions,
+				Subject:   "New reply in the discussion for " + data.ProjectName,
+				Body:      models.HTML(previewDiscussion),
+				CreatedAt: time.Now().AddDate(0, 0, -1),
+			},
+			{
+				Category:  models.NotificationCategoryGRBReviews,
+				Subject:   "Voting is halfway done for " + data.ProjectName,
+				Body:      models.HTML(previewRichText),
+				CreatedAt: time.Now(),
+			},
+		})
+	},
+	"system_intake_admin_upload_doc": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSystemIntakeAdminUploadDocEmail(ctx, SendSystemIntakeAdminUploadDocEmailInput{
+			SystemIntakeID:     data.SystemIntakeID,
+			RequestName:        data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			Recipients:         []models.EmailAddress{previewRecipient},
+		})
+	},
+	"system_intake_change_lcid_retirement_date": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendChangeLCIDRetirementDateNotification(
+			ctx,
+			previewRecipients(),
+			previewLCID,
+			helpers.PointerTo(time.Now().AddDate(2, 0, 0)),
+			helpers.PointerTo(time.Now().AddDate(5, 0, 0)),
+			helpers.PointerTo(time.Now().AddDate(-1, 0, 0)),
+			models.HTMLPointer(previewRichText),
+			previewCostBaseline,
+			models.HTMLPointer(previewRichText),
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_close_request": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendCloseRequestNotification(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			data.ProjectName,
+			data.RequesterName,
+			models.HTMLPointer(previewRichText),
+			helpers.PointerTo(time.Now().AddDate(0, -1, 0)),
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_confirm_lcid": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendConfirmLCIDNotification(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			data.ProjectName,
+			previewLCID,
+			helpers.PointerTo(time.Now().AddDate(5, 0, 0)),
+			helpers.PointerTo(time.Now()),
+			models.HTML(previewRichText),
+			helpers.PointerTo(previewCostBaseline),
+			models.HTML(previewRichText),
+			models.TRBFRStronglyRecommended,
+			data.RequesterName,
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_create_grb_reviewer": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendCreateGRBReviewerNotification(
+			ctx,
+			[]models.EmailAddress{previewRecipient},
+			data.SystemIntakeID,
+			data.ProjectName,
+			data.RequesterName,
+			data.RequesterComponent,
+		)
+	},
+	"system_intake_expire_lcid": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendExpireLCIDNotification(
+			ctx,
+			previewRecipients(),
+			previewLCID,
+			helpers.PointerTo(time.Now()),
+			helpers.PointerTo(time.Now().AddDate(-5, 0, 0)),
+			models.HTMLPointer(previewRichText),
+			previewCostBaseline,
+			models.HTML(previewRichText),
+			models.HTMLPointer(previewRichText),
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_grb_meeting": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSystemIntakeGRBMeetingEmail(ctx, SendSystemIntakeGRBMeetingEmailInput{
+			SystemIntakeID:     data.SystemIntakeID,
+			RequestName:        data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			GRBDate:            helpers.PointerTo(time.Now().AddDate(0, 0, 14)),
+			Recipients:         []models.EmailAddress{previewRecipient},
+		})
+	},
+	"system_intake_grb_review_deadline_extended": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSystemIntakeGRBReviewDeadlineExtended(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			data.ProjectName,
+			data.RequesterName,
+			data.RequesterComponent,
+			time.Now().AddDate(0, 0, 2),
+			time.Now().AddDate(0, 0, 9),
+		)
+	},
+	"system_intake_grb_review_last_day_reminder": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSystemIntakeGRBReviewLastDay(ctx, SendSystemIntakeGRBReviewLastDayInput{
+			Recipient:          previewRecipient,
+			SystemIntakeID:     data.SystemIntakeID,
+			ProjectName:        data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			GRBReviewStart:     time.Now().AddDate(0, 0, -6),
+			GRBReviewDeadline:  time.Now().AddDate(0, 0, 1),
+		})
+	},
+	"system_intake_grb_review_restarted": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSystemIntakeGRBReviewRestarted(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			data.ProjectName,
+			data.RequesterName,
+			data.RequesterComponent,
+			time.Now().AddDate(0, 0, -7),
+			time.Now().AddDate(0, 0, 7),
+		)
+	},
+	"system_intake_grb_review_restarted_admin": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSystemIntakeGRBReviewRestartedAdmin(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			previewAdminName,
+			data.ProjectName,
+			data.RequesterName,
+			data.RequesterComponent,
+			time.Now().AddDate(0, 0, -7),
+			time.Now().AddDate(0, 0, -1),
+			time.Now().AddDate(0, 0, 7),
+		)
+	},
+	"system_intake_grb_review_time_added": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSystemIntakeGRBReviewTimeAdded(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			previewAdminName,
+			"2 days",
+			data.ProjectName,
+			data.RequesterName,
+			data.RequesterComponent,
+			time.Now().AddDate(0, 0, -5),
+			time.Now(),
+			time.Now().AddDate(0, 0, 2),
+			5,
+		)
+	},
+	"system_intake_grb_review_voting_ended": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSystemIntakeGRBReviewEnded(ctx, SendSystemIntakeGRBReviewEndedInput{
+			Recipient:          previewRecipient,
+			SystemIntakeID:     data.SystemIntakeID,
+			ProjectName:        data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			GRBReviewStart:     time.Now().AddDate(0, 0, -7),
+			GRBReviewDeadline:  time.Now(),
+		})
+	},
+	"system_intake_grb_review_voting_ended_early": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSystemIntakeGRBReviewEndedEarly(ctx, SendSystemIntakeGRBReviewEndedEarlyInput{
+			Recipient:          previewRecipient,
+			SystemIntakeID:     data.SystemIntakeID,
+			ProjectTitle:       data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			StartDate:          time.Now().AddDate(0, 0, -3),
+			EndDate:            time.Now().AddDate(0, 0, 4),
+		})
+	},
+	"system_intake_grb_reviewer_reminder": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSystemIntakeGRBReviewerReminder(ctx, SendSystemIntakeGRBReviewerReminderInput{
+			Recipient:          previewRecipient,
+			SystemIntakeID:     data.SystemIntakeID,
+			RequestName:        data.ProjectName,
+			RequesterName:      data.RequesterName,
+			RequesterComponent: data.RequesterComponent,
+			StartDate:          time.Now().AddDate(0, 0, -3),
+			EndDate:            time.Now().AddDate(0, 0, 4),
+		})
+	},
+	"system_intake_issue_lcid": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendIssueLCIDNotification(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			data.ProjectName,
+			previewLCID,
+			time.Now(),
+			helpers.PointerTo(time.Now().AddDate(5, 0, 0)),
+			models.HTML(previewRichText),
+			helpers.PointerTo(previewCostBaseline),
+			models.HTML(previewRichText),
+			models.TRBFRNotRecommended,
+			data.RequesterName,
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_lcid_expiration_alert": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendLCIDExpirationAlertEmail(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			data.ProjectName,
+			data.RequesterName,
+			previewLCID,
+			helpers.PointerTo(time.Now().AddDate(-5, 0, 0)),
+			helpers.PointerTo(time.Now().AddDate(0, 0, 60)),
+			models.HTML(previewRichText),
+			previewCostBaseline,
+			models.HTML(previewRichText),
+		)
+	},
+	"system_intake_not_approved": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendNotApprovedNotification(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			data.ProjectName,
+			data.RequesterName,
+			models.HTML(previewRichText),
+			models.HTML(previewRichText),
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_not_it_gov_request": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendNotITGovRequestNotification(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			data.ProjectName,
+			data.RequesterName,
+			models.HTMLPointer(previewRichText),
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_presentation_deck_upload_reminder": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendPresentationDeckUploadReminder(ctx, previewRecipients(), data.SystemIntakeID, data.ProjectName)
+	},
+	"system_intake_progress_to_new_step": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendProgressToNewStepNotification(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			models.SystemIntakeStepToProgressToGrbMeeting,
+			data.ProjectName,
+			data.RequesterName,
+			models.HTMLPointer(previewRichText),
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_reopen_request": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendReopenRequestNotification(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			data.ProjectName,
+			data.RequesterName,
+			models.HTMLPointer(previewRichText),
+			helpers.PointerTo(time.Now().AddDate(0, -1, 0)),
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_request_edits_on_form": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendRequestEditsNotification(
+			ctx,
+			previewRecipients(),
+			data.SystemIntakeID,
+			models.GRFTFIntakeRequest,
+			data.ProjectName,
+			data.RequesterName,
+			models.HTML(previewRichText),
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_retire_lcid": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendRetireLCIDNotification(
+			ctx,
+			previewRecipients(),
+			previewLCID,
+			helpers.PointerTo(time.Now().AddDate(0, 3, 0)),
+			helpers.PointerTo(time.Now().AddDate(5, 0, 0)),
+			helpers.PointerTo(time.Now().AddDate(-1, 0, 0)),
+			models.HTMLPointer(previewRichText),
+			previewCostBaseline,
+			models.HTMLPointer(previewRichText),
+			models.HTMLPointer(previewRichText),
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_submit_business_case_requester": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSubmitBizCaseRequesterNotification(ctx, previewRecipient, data.ProjectName, data.SystemIntakeID, false, false)
+	},
+	"system_intake_submit_business_case_reviewer": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSubmitBizCaseReviewerNotification(ctx, data.SystemIntakeID, data.RequesterName, data.ProjectName, false, false, nil)
+	},
+	"system_intake_submit_initial_form_requester": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSubmitInitialFormRequesterNotification(ctx, previewRecipient, data.SystemIntakeID, data.ProjectName, false)
+	},
+	"system_intake_submit_initial_form_reviewer": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendSubmitInitialFormReviewerNotification(
+			ctx,
+			data.SystemIntakeID,
+			data.ProjectName,
+			data.RequesterName,
+			data.RequesterComponent,
+			models.SystemIntakeRequestTypeNEW,
+			"Just an idea",
+			false,
+		)
+	},
+	"system_intake_unretire_lcid": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendUnretireLCIDNotification(
+			ctx,
+			previewRecipients(),
+			previewLCID,
+			helpers.PointerTo(time.Now().AddDate(5, 0, 0)),
+			helpers.PointerTo(time.Now().AddDate(-1, 0, 0)),
+			models.HTMLPointer(previewRichText),
+			previewCostBaseline,
+			models.HTMLPointer(previewRichText),
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"system_intake_update_lcid": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SystemIntake.SendUpdateLCIDNotification(
+			ctx,
+			previewRecipients(),
+			previewLCID,
+			helpers.PointerTo(time.Now().AddDate(-1, 0, 0)),
+			helpers.PointerTo(time.Now().AddDate(4, 0, 0)),
+			helpers.PointerTo(time.Now().AddDate(5, 0, 0)),
+			models.HTMLPointer("<p>The previous scope</p>"),
+			models.HTMLPointer(previewRichText),
+			"The previous cost baseline",
+			previewCostBaseline,
+			models.HTMLPointer("<p>The previous next steps</p>"),
+			models.HTMLPointer(previewRichText),
+			time.Now(),
+			models.HTMLPointer(previewRichText),
+			models.HTMLPointer(previewRichText),
+		)
+	},
+	"trb_attendee_added": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendTRBAttendeeAddedNotification(ctx, previewRecipient, data.ProjectName, data.RequesterName)
+	},
+	"trb_edits_needed_on_form": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendTRBEditsNeededOnFormNotification(
+			ctx,
+			[]models.EmailAddress{previewRecipient},
+			true,
+			data.SystemIntakeID,
+			data.ProjectName,
+			data.RequesterName,
+			models.HTML(previewRichText),
+		)
+	},
+	"trb_guidance_letter_internal_review": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendTRBGuidanceLetterInternalReviewEmail(ctx, SendTRBGuidanceLetterInternalReviewEmailInput{
+			TRBRequestID:   data.SystemIntakeID,
+			TRBRequestName: data.ProjectName,
+			TRBLeadName:    previewTRBLeadName,
+		})
+	},
+	"trb_guidance_letter_amended": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		letter := &models.TRBGuidanceLetter{
+			TRBRequestID: data.SystemIntakeID,
+			NextSteps:    helpers.PointerTo(models.HTML("<p>Schedule a follow-up session with the TRB</p>")),
+		}
+		sent := models.NewTRBGuidanceLetterVersion(letter, nil, models.TRBGuidanceLetterVersionReasonSent, "")
+		letter.NextSteps = helpers.PointerTo(models.HTML("<p>Schedule a follow-up session with the TRB in March</p>"))
+		amended := models.NewTRBGuidanceLetterVersion(letter, nil, models.TRBGuidanceLetterVersionReasonAmended, "")
+
+		return c.SendTRBGuidanceLetterAmendedEmail(ctx, SendTRBGuidanceLetterAmendedEmailInput{
+			TRBRequestID:     data.SystemIntakeID,
+			RequestName:      data.ProjectName,
+			RequesterName:    data.RequesterName,
+			CopyTRBMailbox:   true,
+			Recipients:       []models.EmailAddress{previewRecipient},
+			AmendmentSummary: models.HTML(previewRichText),
+			Diff:             models.NewTRBGuidanceLetterVersionDiff(sent, amended),
+		})
+	},
+	"trb_guidance_letter_submitted": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		submissionDate := time.Now()
+		return c.SendTRBGuidanceLetterSubmittedEmail(ctx, SendTRBGuidanceLetterSubmittedEmailInput{
+			TRBRequestID:   data.SystemIntakeID,
+			RequestName:    data.ProjectName,
+			RequestType:    string(models.TRBTBrainstorm),
+			RequesterName:  data.RequesterName,
+			Component:      data.RequesterComponent,
+			SubmissionDate: &submissionDate,
+			ConsultDate:    helpers.PointerTo(time.Now().AddDate(0, 0, -14)),
+			CopyTRBMailbox: true,
+			Recipients:     []models.EmailAddress{previewRecipient},
+		})
+	},
+	"trb_ready_for_consult": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendTRBReadyForConsultNotification(
+			ctx,
+			[]models.EmailAddress{previewRecipient},
+			true,
+			data.SystemIntakeID,
+			data.ProjectName,
+			data.RequesterName,
+			models.HTML(previewRichText),
+		)
+	},
+	"trb_request_closed": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendTRBRequestClosedEmail(ctx, SendTRBRequestClosedEmailInput{
+			TRBRequestID:   data.SystemIntakeID,
+			TRBRequestName: data.ProjectName,
+			RequesterName:  data.RequesterName,
+			CopyTRBMailbox: true,
+			ReasonClosed:   models.HTML(previewRichText),
+			Recipients:     []models.EmailAddress{previewRecipient},
+		})
+	},
+	"trb_request_consult_meeting": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendTRBRequestConsultMeetingEmail(ctx, SendTRBRequestConsultMeetingEmailInput{
+			TRBRequestID:       data.SystemIntakeID,
+			ConsultMeetingTime: time.Now().AddDate(0, 0, 10),
+			CopyTRBMailbox:     true,
+			NotifyEmails:       []models.EmailAddress{previewRecipient},
+			TRBRequestName:     data.ProjectName,
+			Notes:              "This is a sample note for the consult meeting.",
+			RequesterName:      data.RequesterName,
+		})
+	},
+	"trb_request_form_submission_admin": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendTRBFormSubmissionNotificationToAdmins(ctx, data.SystemIntakeID, data.ProjectName, data.RequesterName, data.RequesterComponent)
+	},
+	"trb_request_form_submission_requester": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendTRBFormSubmissionNotificationToRequester(ctx, data.SystemIntakeID, data.ProjectName, previewRecipient, data.RequesterName)
+	},
+	"trb_request_reopened": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendTRBRequestReopenedEmail(ctx, SendTRBRequestReopenedEmailInput{
+			TRBRequestID:   data.SystemIntakeID,
+			TRBRequestName: data.ProjectName,
+			RequesterName:  data.RequesterName,
+			CopyTRBMailbox: true,
+			ReasonReopened: models.HTML(previewRichText),
+			Recipients:     []models.EmailAddress{previewRecipient},
+		})
+	},
+	"trb_request_trb_lead_admin": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.sendTRBRequestTRBLeadAdminEmail(ctx, previewTRBLeadInput(data))
+	},
+	"trb_request_trb_lead_assignee": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.sendTRBRequestTRBLeadAssigneeEmail(ctx, previewTRBLeadInput(data))
+	},
+	"unnamed_request_withdrawal": func(ctx context.Context, c Client, data EmailPreviewData) error {
+		return c.SendWithdrawRequestEmail(ctx, "")
+	},
+}
+
+func previewTRBLeadInput(data EmailPreviewData) SendTRBRequestTRBLeadEmailInput {
+	return SendTRBRequestTRBLeadEmailInput{
+		TRBRequestID:   data.SystemIntakeID,
+		TRBRequestName: data.ProjectName,
+		RequesterName:  data.RequesterName,
+		TRBLeadName:    previewTRBLeadName,
+		Component:      data.RequesterComponent,
+		TRBLeadEmail:   previewRecipient,
+	}
+}
+
+// EmailPreviewTemplateNames returns the names of the email templates that can be previewed, in alphabetical order
+func EmailPreviewTemplateNames() []string {
+	names := make([]string, 0, len(emailPreviewRenderers))
+	for name := range emailPreviewRenderers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// PreviewEmail renders an email template for the request in data, without sending it
+func (c Client) PreviewEmail(ctx context.Context, templateName string, data EmailPreviewData) (*EmailPreview, error) {
+	render, ok := emailPreviewRenderers[templateName]
+	if !ok {
+		return nil, fmt.Errorf("there is no email template named %q to preview", templateName)
+	}
+
+	sender := &previewSender{}
+	previewClient := c
+	previewClient.sender = sender
+	previewClient.SystemIntake = &systemIntakeEmails{
+		client: &previewClient,
+	}
+
+	if err := render(ctx, previewClient, data); err != nil {
+		return nil, err
+	}
+	if len(sender.emails) == 0 {
+		return nil, fmt.Errorf("email template %q didn't render an email to preview", templateName)
+	}
+
+	email := sender.emails[0]
+	return &EmailPreview{
+		TemplateName: templateName,
+		Subject:      email.Subject,
+		Body:         email.Body,
+		Attachments:  email.Attachments,
+	}, nil
+}
+
+// SendEmailPreview renders an email template for the request in data, and sends it only to recipient, so it can be proofread.
+// The subject is marked as a test, and the email isn't added to the recipient's notification center
+func (c Client) SendEmailPreview(ctx context.Context, templateName string, data EmailPreviewData, recipient models.EmailAddress) (*EmailPreview, error) {
+	preview, err := c.PreviewEmail(ctx, templateName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	email := NewEmail().
+		WithToAddresses([]models.EmailAddress{recipient}).
+		WithSubject("[TEST] " + preview.Subject).
+		WithBody(preview.Body)
+	for _, attachment := range preview.Attachments {
+		email = email.WithAttachment(attachment)
+	}
+	email.excludeFromNotificationCenter = true
+
+	if err := c.sender.Send(ctx, email); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
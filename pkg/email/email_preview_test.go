@@ -0,0 +1,89 @@
+package email
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *EmailTestSuite) TestPreviewEmail() {
+	ctx := context.Background()
+	sender := mockSender{}
+	client, err := NewClient(s.config, &sender)
+	s.NoError(err)
+
+	s.Run("every email template can be previewed", func() {
+		// templates that aren't emails, or that no email is sent with anymore
+		notPreviewed := map[string]bool{
+			"business_case_document":       true,
+			"easi_header":                  true,
+			"new_document":                 true,
+			"reject_request":               true,
+			"system_intake_review":         true,
+			"trb_guidance_letter_document": true,
+		}
+
+		files, err := filepath.Glob(filepath.Join(s.config.TemplateDirectory, "*.gohtml"))
+		s.NoError(err)
+
+		var expectedNames []string
+		for _, file := range files {
+			name := strings.TrimSuffix(filepath.Base(file), ".gohtml")
+			if !notPreviewed[name] {
+				expectedNames = append(expectedNames, name)
+			}
+		}
+		s.Equal(expectedNames, EmailPreviewTemplateNames())
+	})
+
+	s.Run("renders every template without sending it", func() {
+		data := SampleEmailPreviewData()
+		for _, name := range EmailPreviewTemplateNames() {
+			preview, err := client.PreviewEmail(ctx, name, data)
+			if !s.NoError(err, name) {
+				continue
+			}
+			s.Equal(name, preview.TemplateName)
+			s.NotEmpty(preview.Subject, name)
+			s.NotEmpty(preview.Body, name)
+		}
+		s.Empty(sender.subject)
+	})
+
+	s.Run("renders the request in the preview data", func() {
+		data := SampleEmailPreviewData()
+		data.ProjectName = "Preview Project"
+
+		preview, err := client.PreviewEmail(ctx, "system_intake_submit_initial_form_requester", data)
+		s.NoError(err)
+		s.Contains(preview.Subject, "Preview Project")
+		s.Contains(preview.Body, data.SystemIntakeID.String())
+	})
+
+	s.Run("returns an error for a template that doesn't exist", func() {
+		_, err := client.PreviewEmail(ctx, "not_a_template", SampleEmailPreviewData())
+		s.Error(err)
+	})
+}
+
+func (s *EmailTestSuite) TestSendEmailPreview() {
+	ctx := context.Background()
+	sender := mockSender{}
+	client, err := NewClient(s.config, &sender)
+	s.NoError(err)
+
+	recipient := models.NewEmailAddress("proofreader@local.fake")
+	data := SampleEmailPreviewData()
+
+	preview, err := client.SendEmailPreview(ctx, "system_intake_grb_meeting", data, recipient)
+	s.NoError(err)
+
+	s.Equal([]models.EmailAddress{recipient}, sender.toAddresses)
+	s.Empty(sender.ccAddresses)
+	s.Empty(sender.bccAddresses)
+	s.Equal("[TEST] "+preview.Subject, sender.subject)
+	s.Equal(preview.Body, sender.body)
+	s.Len(sender.attachments, 1)
+}
@@ -53,6 +53,7 @@ type mockSender struct {
 	bccAddresses []models.EmailAddress
 	subject      string
 	body         string
+	attachments  []models.EmailAttachment
 }
 
 func (s *mockSender) Send(ctx context.Context, emailData Email) error {
@@ -61,6 +62,7 @@ func (s *mockSender) Send(ctx context.Context, emailData Email) error {
 	s.bccAddresses = emailData.BccAddresses
 	s.subject = emailData.Subject
 	s.body = emailData.Body
+	s.attachments = emailData.Attachments
 	return nil
 }
 
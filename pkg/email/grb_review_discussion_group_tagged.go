@@ -81,6 +81,7 @@ func (sie systemIntakeEmails) SendGRBReviewDiscussionGroupTaggedEmail(ctx contex
 		return err
 	}
 	email := NewEmail().
+		WithNotificationCategory(models.NotificationCategoryGRBDiscussions).
 		// use BCC as this is going to multiple recipients
 		WithBCCAddresses(input.Recipients.RegularRecipientEmails).
 		WithSubject(subject).
@@ -90,8 +91,5 @@ func (sie systemIntakeEmails) SendGRBReviewDiscussionGroupTaggedEmail(ctx contex
 		email = email.WithCCAddresses([]models.EmailAddress{sie.client.config.GRTEmail})
 	}
 
-	return sie.client.sender.Send(
-		ctx,
-		email,
-	)
+	return sie.client.sendDiscussionEmail(ctx, email, input.DiscussionID, input.Recipients.RegularRecipientEmails)
 }
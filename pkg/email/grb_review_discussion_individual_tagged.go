@@ -77,9 +77,10 @@ func (sie systemIntakeEmails) SendGRBReviewDiscussionIndividualTaggedEmail(ctx c
 	}
 
 	mail := NewEmail().
+		WithNotificationCategory(models.NotificationCategoryGRBDiscussions).
 		WithBCCAddresses(input.Recipients).
 		WithSubject(subject).
 		WithBody(body)
 
-	return sie.client.sender.Send(ctx, mail)
+	return sie.client.sendDiscussionEmail(ctx, mail, input.DiscussionID, input.Recipients)
 }
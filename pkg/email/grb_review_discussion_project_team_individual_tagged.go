@@ -66,10 +66,13 @@ func (sie systemIntakeEmails) SendGRBReviewDiscussionProjectTeamIndividualTagged
 
 	subject := fmt.Sprintf("You were tagged in a GRB Review discussion for %s", input.RequestName)
 
-	return sie.client.sender.Send(
+	return sie.client.sendDiscussionEmail(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBDiscussions).
 			WithToAddresses([]models.EmailAddress{input.Recipient}).
 			WithSubject(subject).WithBody(body),
+		input.DiscussionID,
+		[]models.EmailAddress{input.Recipient},
 	)
 }
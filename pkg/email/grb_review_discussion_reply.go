@@ -75,11 +75,14 @@ func (sie systemIntakeEmails) SendGRBReviewDiscussionReplyEmail(ctx context.Cont
 		return err
 	}
 
-	return sie.client.sender.Send(
+	return sie.client.sendDiscussionEmail(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBDiscussions).
 			WithToAddresses([]models.EmailAddress{input.Recipient}).
 			WithSubject(subject).
 			WithBody(body),
+		input.DiscussionID,
+		[]models.EmailAddress{input.Recipient},
 	)
 }
@@ -13,11 +13,13 @@ import (
 )
 
 type SendGRBReviewDiscussionReplyRequesterEmailInput struct {
-	SystemIntakeID    uuid.UUID
-	RequestName       string
-	ReplierName       string
-	VotingRole        string
-	GRBRole           string
+	SystemIntakeID uuid.UUID
+	RequestName    string
+	ReplierName    string
+	VotingRole     string
+	GRBRole        string
+	// DiscussionID is the initial post of the discussion that was replied to
+	DiscussionID      uuid.UUID
 	DiscussionContent template.HTML
 	Recipient         models.EmailAddress
 }
@@ -71,11 +73,14 @@ func (sie systemIntakeEmails) SendGRBReviewDiscussionReplyRequesterEmail(
 
 	subject := fmt.Sprintf("New reply to your discussion in the GRB review for %s", input.RequestName)
 
-	return sie.client.sender.Send(
+	return sie.client.sendDiscussionEmail(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBDiscussions).
 			WithToAddresses([]models.EmailAddress{input.Recipient}).
 			WithSubject(subject).
 			WithBody(body),
+		input.DiscussionID,
+		[]models.EmailAddress{input.Recipient},
 	)
 }
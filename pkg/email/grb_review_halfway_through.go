@@ -74,6 +74,7 @@ func (sie systemIntakeEmails) SendGRBReviewHalfwayThrough(ctx context.Context, i
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses([]models.EmailAddress{sie.client.config.GRTEmail}).
 			WithSubject(subject).
 			WithBody(body),
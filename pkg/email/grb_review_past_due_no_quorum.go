@@ -72,6 +72,7 @@ func (sie systemIntakeEmails) SendGRBReviewPastDueNoQuorum(ctx context.Context,
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses([]models.EmailAddress{sie.client.config.GRTEmail}).
 			WithSubject(subject).
 			WithBody(body),
@@ -62,6 +62,7 @@ func (sie systemIntakeEmails) SendGRBReviewPresentationLinksUpdatedEmail(ctx con
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses(input.Recipients).
 			WithCCAddresses([]models.EmailAddress{sie.client.config.GRTEmail}).
 			WithSubject(subject).
@@ -84,6 +84,7 @@ func (sie systemIntakeEmails) SendGRBReviewVoteSubmitted(ctx context.Context, in
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses([]models.EmailAddress{input.Recipient}).
 			WithSubject(subject).
 			WithBody(body),
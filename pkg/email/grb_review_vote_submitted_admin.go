@@ -92,6 +92,7 @@ func (sie systemIntakeEmails) SendGRBReviewVoteSubmittedAdmin(ctx context.Contex
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses([]models.EmailAddress{sie.client.config.GRTEmail}).
 			WithSubject(subject).
 			WithBody(body),
@@ -0,0 +1,269 @@
+package email
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sanitization"
+)
+
+// maxInboundReplyPartSize limits how much of each part of an inbound email is read, so a large attachment can't exhaust memory
+const maxInboundReplyPartSize = 1 << 20
+
+// ErrInboundReplyRejected is returned for an inbound email that SES flagged as spam or as containing a virus,
+// or that failed DMARC, so its From address can't be trusted
+var ErrInboundReplyRejected = errors.New("inbound email was flagged by SES")
+
+// ErrEmptyInboundReply is returned for an inbound email that has nothing left once the quoted email it replies to is removed
+var ErrEmptyInboundReply = errors.New("inbound email has no reply content")
+
+// InboundReply is a reply to an email, parsed from the raw MIME message that was received
+type InboundReply struct {
+	// From is the address the reply was sent from
+	From models.EmailAddress
+	// Recipients are the addresses the reply was sent to, one of which should be a Reply-To address
+	Recipients []models.EmailAddress
+	// Content is the text the sender wrote, without the email it quotes, as sanitized HTML
+	Content models.HTML
+}
+
+// ParseInboundReply parses a raw MIME email that was sent in reply to an email from EASi. The reply's text part is preferred over its HTML part;
+// in either, the quoted email being replied to and anything after it is removed, and the result is sanitized
+func ParseInboundReply(raw io.Reader) (*InboundReply, error) {
+	msg, err := mail.ReadMessage(raw)
+	if err != nil {
+		return nil, fmt.Errorf("problem reading inbound email: %w", err)
+	}
+
+	// SES adds verdict headers to the emails it receives
+	for _, verdictHeader := range []string{"X-SES-Spam-Verdict", "X-SES-Virus-Verdict"} {
+		if strings.EqualFold(strings.TrimSpace(msg.Header.Get(verdictHeader)), "FAIL") {
+			return nil, ErrInboundReplyRejected
+		}
+	}
+	for _, result := range msg.Header["Authentication-Results"] {
+		if strings.Contains(strings.ToLower(result), "dmarc=fail") {
+			return nil, ErrInboundReplyRejected
+		}
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) != 1 {
+		return nil, fmt.Errorf("inbound email doesn't have a single From address: %w", err)
+	}
+
+	reply := &InboundReply{
+		From: models.NewEmailAddress(from[0].Address),
+	}
+	for _, header := range []string{"To", "Cc"} {
+		addresses, err := msg.Header.AddressList(header)
+		if err != nil && !errors.Is(err, mail.ErrHeaderNotPresent) {
+			return nil, fmt.Errorf("problem reading inbound email %s addresses: %w", header, err)
+		}
+		for _, address := range addresses {
+			reply.Recipients = append(reply.Recipients, models.NewEmailAddress(address.Address))
+		}
+	}
+
+	body, err := decodeTransferEncoding(msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var textBody, htmlBody string
+	if err := readInboundReplyPart(msg.Header.Get("Content-Type"), body, &textBody, &htmlBody); err != nil {
+		return nil, err
+	}
+
+	var content string
+	if textBody != "" {
+		content = inboundTextToHTML(stripQuotedText(textBody))
+	} else {
+		content = stripQuotedHTML(htmlBody)
+	}
+
+	reply.Content = models.HTML(strings.TrimSpace(sanitization.SanitizeHTML(content)))
+	if !hasText(reply.Content) {
+		return nil, ErrEmptyInboundReply
+	}
+	return reply, nil
+}
+
+// readInboundReplyPart reads the first text and HTML bodies out of a part of an inbound email, descending into multipart parts.
+// Attachments are skipped
+func readInboundReplyPart(contentType string, body io.Reader, textBody *string, htmlBody *string) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		// emails without a Content-Type are plain text
+		mediaType = "text/plain"
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			// NextPart decodes quoted-printable parts
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("problem reading inbound email part: %w", err)
+			}
+
+			if disposition, _, _ := mime.ParseMediaType(part.Header.Get("Content-Disposition")); disposition == "attachment" {
+				continue
+			}
+
+			partBody, err := decodeTransferEncoding(part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return err
+			}
+			if err := readInboundReplyPart(part.Header.Get("Content-Type"), partBody, textBody, htmlBody); err != nil {
+				return err
+			}
+		}
+
+	case mediaType == "text/plain" && *textBody == "":
+		text, err := io.ReadAll(io.LimitReader(body, maxInboundReplyPartSize))
+		if err != nil {
+			return fmt.Errorf("problem reading inbound email text: %w", err)
+		}
+		*textBody = string(text)
+
+	case mediaType == "text/html" && *htmlBody == "":
+		text, err := io.ReadAll(io.LimitReader(body, maxInboundReplyPartSize))
+		if err != nil {
+			return fmt.Errorf("problem reading inbound email HTML: %w", err)
+		}
+		*htmlBody = string(text)
+	}
+	return nil
+}
+
+// decodeTransferEncoding decodes a part of an email by its Content-Transfer-Encoding
+func decodeTransferEncoding(encoding string, body io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "7bit", "8bit", "binary":
+		return body, nil
+	case "quoted-printable":
+		return quotedprintable.NewReader(body), nil
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body), nil
+	default:
+		return nil, fmt.Errorf("unsupported inbound email transfer encoding %q", encoding)
+	}
+}
+
+var (
+	// "On Mon, Jan 6, 2025 at 9:00 AM EASi <no-reply@...> wrote:", as Gmail, Apple Mail, and Thunderbird introduce quotes.
+	// Long ones are sometimes wrapped onto a second line
+	quoteAttributionStart = regexp.MustCompile(`^On\s.+`)
+	quoteAttributionEnd   = regexp.MustCompile(`wrote:\s*$`)
+
+	// lines that start the quoted email, or a signature, in replies without quote markers
+	quoteStartLines = []*regexp.Regexp{
+		// Outlook
+		regexp.MustCompile(`(?i)^-+\s*original message\s*-+$`),
+		regexp.MustCompile(`^_{10,}$`),
+		regexp.MustCompile(`(?i)^from:\s.*`),
+		// signature delimiter
+		regexp.MustCompile(`^--\s?$`),
+		// mobile clients
+		regexp.MustCompile(`(?i)^sent from my\s`),
+	}
+)
+
+// stripQuotedText removes the email being replied to from a plain text reply, along with the reply's signature
+func stripQuotedText(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, ">") {
+			return strings.Join(lines[:i], "\n")
+		}
+
+		if quoteAttributionStart.MatchString(trimmed) {
+			if quoteAttributionEnd.MatchString(trimmed) ||
+				(i+1 < len(lines) && quoteAttributionEnd.MatchString(strings.TrimSpace(lines[i+1]))) {
+				return strings.Join(lines[:i], "\n")
+			}
+		}
+
+		for _, pattern := range quoteStartLines {
+			if pattern.MatchString(trimmed) {
+				return strings.Join(lines[:i], "\n")
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// inboundTextToHTML converts a plain text reply to HTML, with a paragraph for each block of lines separated by blank lines
+func inboundTextToHTML(text string) string {
+	var paragraphs []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, "<p>"+strings.Join(current, "<br>")+"</p>")
+			current = nil
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxInboundReplyPartSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			flush()
+			continue
+		}
+		current = append(current, html.EscapeString(line))
+	}
+	flush()
+
+	return strings.Join(paragraphs, "")
+}
+
+// elements that mail clients wrap the quoted email in, in HTML replies
+var quotedHTMLMarkers = []*regexp.Regexp{
+	// Gmail
+	regexp.MustCompile(`(?i)<div[^>]*class="[^"]*gmail_quote`),
+	// Apple Mail, Thunderbird
+	regexp.MustCompile(`(?i)<blockquote`),
+	// Outlook
+	regexp.MustCompile(`(?i)<div[^>]*id="(divRplyFwdMsg|appendonsend)"`),
+	regexp.MustCompile(`(?i)<hr[\s/>]`),
+	regexp.MustCompile(`(?i)-+\s*original message\s*-+`),
+}
+
+// stripQuotedHTML removes the email being replied to from an HTML reply
+func stripQuotedHTML(body string) string {
+	cut := len(body)
+	for _, marker := range quotedHTMLMarkers {
+		if loc := marker.FindStringIndex(body); loc != nil && loc[0] < cut {
+			cut = loc[0]
+		}
+	}
+	return body[:cut]
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// hasText returns whether sanitized HTML has any text in it, not just empty paragraphs and line breaks
+func hasText(content models.HTML) bool {
+	return strings.TrimSpace(html.UnescapeString(htmlTag.ReplaceAllString(string(content), ""))) != ""
+}
@@ -0,0 +1,143 @@
+package email
+
+import (
+	"strings"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// inboundEmail builds a raw email from lines, with the CRLF line endings mail is sent with
+func inboundEmail(lines ...string) *strings.Reader {
+	return strings.NewReader(strings.Join(lines, "\r\n"))
+}
+
+func (s *EmailTestSuite) TestParseInboundReply() {
+	s.Run("parses a plain text reply and strips the quoted email", func() {
+		reply, err := ParseInboundReply(inboundEmail(
+			`From: Rock Lee <rock.lee@cms.fake>`,
+			`To: reply+abc@inbound.cms.fake`,
+			`Cc: Audrey Abrams <audrey.abrams@cms.fake>`,
+			`Subject: Re: New reply`,
+			`Content-Type: text/plain; charset="UTF-8"`,
+			``,
+			`Sounds good, I'll look into it.`,
+			`Thanks <3`,
+			``,
+			`Second paragraph`,
+			``,
+			`On Mon, Jan 6, 2025 at 9:00 AM EASi <no-reply@cms.fake> wrote:`,
+			`> Someone replied to your discussion`,
+		))
+		s.NoError(err)
+		s.Equal(models.EmailAddress("rock.lee@cms.fake"), reply.From)
+		s.Equal([]models.EmailAddress{"reply+abc@inbound.cms.fake", "audrey.abrams@cms.fake"}, reply.Recipients)
+		s.Equal(models.HTML(`<p>Sounds good, I&#39;ll look into it.<br>Thanks &lt;3</p><p>Second paragraph</p>`), reply.Content)
+	})
+
+	s.Run("prefers the text part of a multipart reply, decoding quoted-printable", func() {
+		reply, err := ParseInboundReply(inboundEmail(
+			`From: rock.lee@cms.fake`,
+			`To: reply+abc@inbound.cms.fake`,
+			`Content-Type: multipart/alternative; boundary="b1"`,
+			``,
+			`--b1`,
+			`Content-Type: text/plain; charset="UTF-8"`,
+			`Content-Transfer-Encoding: quoted-printable`,
+			``,
+			`Caf=C3=A9 budget looks =`,
+			`fine.`,
+			``,
+			`On Mon, Jan 6, 2025 at 9:00 AM EASi <no-reply@cms.fake>`,
+			`wrote:`,
+			`> quoted`,
+			`--b1`,
+			`Content-Type: text/html; charset="UTF-8"`,
+			``,
+			`<div>HTML version</div>`,
+			`--b1--`,
+		))
+		s.NoError(err)
+		s.Equal(models.HTML(`<p>Café budget looks fine.</p>`), reply.Content)
+	})
+
+	s.Run("uses and sanitizes the HTML part when there's no text part", func() {
+		reply, err := ParseInboundReply(inboundEmail(
+			`From: rock.lee@cms.fake`,
+			`To: reply+abc@inbound.cms.fake`,
+			`Content-Type: multipart/mixed; boundary="b1"`,
+			``,
+			`--b1`,
+			`Content-Type: text/html; charset="UTF-8"`,
+			`Content-Transfer-Encoding: base64`,
+			``,
+			// <p>Looks <strong>good</strong><script>alert(1)</script></p><div id="divRplyFwdMsg">From: EASi</div>
+			`PHA+TG9va3MgPHN0cm9uZz5nb29kPC9zdHJvbmc+PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pjwv`,
+			`cD48ZGl2IGlkPSJkaXZScGx5RndkTXNnIj5Gcm9tOiBFQVNpPC9kaXY+`,
+			`--b1`,
+			`Content-Type: text/plain`,
+			`Content-Disposition: attachment; filename="notes.txt"`,
+			``,
+			`an attachment, not the reply`,
+			`--b1--`,
+		))
+		s.NoError(err)
+		s.Equal(models.HTML(`<p>Looks <strong>good</strong></p>`), reply.Content)
+	})
+
+	s.Run("strips Outlook quotes and signatures", func() {
+		for _, quote := range []string{"-----Original Message-----", "From: EASi <no-reply@cms.fake>", "________________________________", "-- ", "Sent from my iPhone"} {
+			reply, err := ParseInboundReply(inboundEmail(
+				`From: rock.lee@cms.fake`,
+				`To: reply+abc@inbound.cms.fake`,
+				``,
+				`Approved.`,
+				quote,
+				`quoted text`,
+			))
+			s.NoError(err)
+			s.Equal(models.HTML(`<p>Approved.</p>`), reply.Content, quote)
+		}
+	})
+
+	s.Run("rejects replies with nothing but the quoted email", func() {
+		_, err := ParseInboundReply(inboundEmail(
+			`From: rock.lee@cms.fake`,
+			`To: reply+abc@inbound.cms.fake`,
+			``,
+			`On Mon, Jan 6, 2025 at 9:00 AM EASi <no-reply@cms.fake> wrote:`,
+			`> quoted`,
+		))
+		s.ErrorIs(err, ErrEmptyInboundReply)
+	})
+
+	s.Run("rejects replies SES flagged as spam", func() {
+		_, err := ParseInboundReply(inboundEmail(
+			`From: rock.lee@cms.fake`,
+			`To: reply+abc@inbound.cms.fake`,
+			`X-SES-Spam-Verdict: FAIL`,
+			``,
+			`Buy now`,
+		))
+		s.ErrorIs(err, ErrInboundReplyRejected)
+	})
+
+	s.Run("rejects replies that failed DMARC", func() {
+		_, err := ParseInboundReply(inboundEmail(
+			`From: rock.lee@cms.fake`,
+			`To: reply+abc@inbound.cms.fake`,
+			`Authentication-Results: amazonses.com; spf=fail; dkim=fail; dmarc=fail header.from=cms.fake;`,
+			``,
+			`Approved.`,
+		))
+		s.ErrorIs(err, ErrInboundReplyRejected)
+	})
+
+	s.Run("rejects emails without a sender", func() {
+		_, err := ParseInboundReply(inboundEmail(
+			`To: reply+abc@inbound.cms.fake`,
+			``,
+			`Hello`,
+		))
+		s.Error(err)
+	})
+}
@@ -0,0 +1,120 @@
+package email
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/models/pubsubevents"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// easiHeaderPattern matches the markup rendered by easi_header.gohtml at the start of every email
+var easiHeaderPattern = regexp.MustCompile(`^\s*<head>[\s\S]*?</head>\s*<h1 class="header-title">[\s\S]*?</h1>\s*<p class="header-subtitle">[\s\S]*?</p>`)
+
+// emailContent returns the body of an email without the EASi header, for showing it somewhere other than an email client
+func emailContent(body string) models.HTML {
+	return models.HTML(strings.TrimSpace(easiHeaderPattern.ReplaceAllString(body, "")))
+}
+
+type deferredNotificationsKey struct{}
+
+// deferredNotifications holds the notifications added while ctx was decorated by WithDeferredNotifications, until they're published
+type deferredNotifications struct {
+	mutex   sync.Mutex
+	publish []func()
+}
+
+// WithDeferredNotifications returns a copy of ctx in which the notifications a NotificationCenterSender adds are held rather than
+// published, and a function that publishes them. Use it around a transaction passed to WithOutboxTransaction, and call the function
+// once the transaction has committed: events are published outside of the transaction, so subscribers that hear about a notification
+// before it's committed can't load it, and skip it
+func WithDeferredNotifications(ctx context.Context) (context.Context, func()) {
+	deferred := &deferredNotifications{}
+	publish := func() {
+		deferred.mutex.Lock()
+		publish := deferred.publish
+		deferred.publish = nil
+		deferred.mutex.Unlock()
+
+		for _, p := range publish {
+			p()
+		}
+	}
+	return context.WithValue(ctx, deferredNotificationsKey{}, deferred), publish
+}
+
+// notificationCenterStore is the storage the NotificationCenterSender adds notifications to users' inboxes in
+type notificationCenterStore interface {
+	sqlutils.NamedPreparer
+	CreateNotificationsForRecipients(ctx context.Context, np sqlutils.NamedPreparer, emails []models.EmailAddress, notification *models.Notification) ([]*models.Notification, error)
+}
+
+// NotificationCenterSender is a sender that adds every email to the in-app notification inbox of each user account it's sent to,
+// then passes it on to another sender. Notifications are added regardless of how recipients choose to receive emails
+type NotificationCenterSender struct {
+	store  notificationCenterStore
+	pubsub pubsub.PubSub
+	sender sender
+}
+
+// NewNotificationCenterSender returns a sender that adds emails to recipients' notification inboxes, publishing each new notification
+// with ps, before sending them with sender
+func NewNotificationCenterSender(store notificationCenterStore, ps pubsub.PubSub, sender sender) NotificationCenterSender {
+	return NotificationCenterSender{
+		store:  store,
+		pubsub: ps,
+		sender: sender,
+	}
+}
+
+// Send adds the email to recipients' notification inboxes, then sends it.
+// If ctx was decorated by WithOutboxTransaction, the notifications are added as part of that transaction, and if it was decorated by
+// WithDeferredNotifications, they're published when its function is called rather than straight away
+func (s NotificationCenterSender) Send(ctx context.Context, email Email) error {
+	if email.excludeFromNotificationCenter {
+		return s.sender.Send(ctx, email)
+	}
+
+	var category *models.NotificationCategory
+	if email.NotificationCategory != "" {
+		category = &email.NotificationCategory
+	}
+
+	var recipients []models.EmailAddress
+	recipients = append(recipients, email.ToAddresses...)
+	recipients = append(recipients, email.CcAddresses...)
+	recipients = append(recipients, email.BccAddresses...)
+
+	notifications, err := s.store.CreateNotificationsForRecipients(ctx, outboxNamedPreparer(ctx, s.store), recipients, &models.Notification{
+		Category: category,
+		Subject:  email.Subject,
+		Body:     emailContent(email.Body),
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.pubsub != nil {
+		publish := func() {
+			for _, notification := range notifications {
+				s.pubsub.Publish(notification.UserID, pubsubevents.NotificationCreated, models.NotificationCreatedEvent{
+					NotificationID: notification.ID,
+				})
+			}
+		}
+
+		if deferred, ok := ctx.Value(deferredNotificationsKey{}).(*deferredNotifications); ok {
+			deferred.mutex.Lock()
+			deferred.publish = append(deferred.publish, publish)
+			deferred.mutex.Unlock()
+		} else {
+			publish()
+		}
+	}
+
+	return s.sender.Send(ctx, email)
+}
@@ -0,0 +1,137 @@
+package email
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// mockNotificationCenterStore creates a notification for each recipient, and records which NamedPreparer they were created with
+type mockNotificationCenterStore struct {
+	mockNamedPreparer
+	createdWith []sqlutils.NamedPreparer
+	created     []*models.Notification
+}
+
+func (s *mockNotificationCenterStore) CreateNotificationsForRecipients(
+	ctx context.Context,
+	np sqlutils.NamedPreparer,
+	emails []models.EmailAddress,
+	notification *models.Notification,
+) ([]*models.Notification, error) {
+	var notifications []*models.Notification
+	for range emails {
+		created := *notification
+		created.ID = uuid.New()
+		created.UserID = uuid.New()
+		notifications = append(notifications, &created)
+		s.createdWith = append(s.createdWith, np)
+	}
+	s.created = append(s.created, notifications...)
+	return notifications, nil
+}
+
+// mockPublisher records the events published to it
+type mockPublisher struct {
+	pubsub.PubSub
+	sessionIDs []uuid.UUID
+	payloads   []interface{}
+}
+
+func (p *mockPublisher) Publish(sessionID uuid.UUID, eventType pubsub.EventType, payload interface{}) {
+	p.sessionIDs = append(p.sessionIDs, sessionID)
+	p.payloads = append(p.payloads, payload)
+}
+
+func (s *EmailTestSuite) TestEmailContent() {
+	sender := &mockSender{}
+	client, err := NewClient(s.config, sender)
+	s.NoError(err)
+
+	s.NoError(client.SendCedarYouHaveBeenAddedEmail(context.Background(), "Mock System", uuid.New(), nil, "member@local.fake"))
+
+	content := string(emailContent(sender.body))
+	s.NotContains(content, "<head>")
+	s.NotContains(content, "Easy Access to System Information")
+	s.Regexp(`^<p>You are now listed as Team Member for Mock System`, content)
+}
+
+func (s *EmailTestSuite) TestNotificationCenterSender() {
+	ctx := context.Background()
+	store := &mockNotificationCenterStore{mockNamedPreparer: mockNamedPreparer{name: "db"}}
+	publisher := &mockPublisher{}
+	sender := &mockSender{}
+	centerSender := NewNotificationCenterSender(store, publisher, sender)
+
+	s.Run("adds the email to each recipient's inbox and sends it", func() {
+		tx := &mockNamedPreparer{name: "tx"}
+		s.NoError(centerSender.Send(WithOutboxTransaction(ctx, tx), NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBDiscussions).
+			WithToAddresses([]models.EmailAddress{"to@local.fake"}).
+			WithBCCAddresses([]models.EmailAddress{"bcc@local.fake"}).
+			WithSubject("subject").
+			WithBody("<p>body</p>"),
+		))
+
+		s.Len(store.created, 2)
+		s.Equal(tx, store.createdWith[0])
+		s.Equal(models.NotificationCategoryGRBDiscussions, *store.created[0].Category)
+		s.Equal("subject", store.created[0].Subject)
+		s.EqualValues("<p>body</p>", store.created[0].Body)
+
+		s.Equal([]uuid.UUID{store.created[0].UserID, store.created[1].UserID}, publisher.sessionIDs)
+		s.Equal(models.NotificationCreatedEvent{NotificationID: store.created[0].ID}, publisher.payloads[0])
+
+		s.Equal("subject", sender.subject)
+	})
+
+	s.Run("notifications held by WithDeferredNotifications are only published when they're released", func() {
+		deferredCtx, publishNotifications := WithDeferredNotifications(ctx)
+		tx := &mockNamedPreparer{name: "tx"}
+		s.NoError(centerSender.Send(WithOutboxTransaction(deferredCtx, tx), NewEmail().
+			WithToAddresses([]models.EmailAddress{"to@local.fake"}).
+			WithSubject("held").
+			WithBody("<p>body</p>"),
+		))
+
+		s.Len(store.created, 3)
+		s.Len(publisher.sessionIDs, 2)
+		s.Equal("held", sender.subject)
+
+		publishNotifications()
+		s.Len(publisher.sessionIDs, 3)
+		s.Equal(store.created[2].UserID, publisher.sessionIDs[2])
+		s.Equal(models.NotificationCreatedEvent{NotificationID: store.created[2].ID}, publisher.payloads[2])
+
+		// they're only published once
+		publishNotifications()
+		s.Len(publisher.sessionIDs, 3)
+	})
+
+	s.Run("uncategorized emails are added without a category", func() {
+		s.NoError(centerSender.Send(ctx, NewEmail().
+			WithToAddresses([]models.EmailAddress{"to@local.fake"}).
+			WithSubject("legally required").
+			WithBody("<p>body</p>"),
+		))
+
+		s.Len(store.created, 4)
+		s.Nil(store.created[3].Category)
+	})
+
+	s.Run("digests aren't added", func() {
+		client, err := NewClient(s.config, centerSender)
+		s.NoError(err)
+
+		s.NoError(client.SendNotificationDigestEmail(ctx, "to@local.fake", []*models.NotificationDigestItem{
+			{Category: models.NotificationCategoryTRBRequests, Subject: "held", Body: "<p>held</p>"},
+		}))
+
+		s.Len(store.created, 4)
+		s.Equal("Your EASi daily digest (1 notification)", sender.subject)
+	})
+}
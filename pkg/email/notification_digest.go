@@ -0,0 +1,86 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+type notificationDigestNotification struct {
+	Subject string
+	Body    template.HTML
+}
+
+type notificationDigestCategory struct {
+	Name          string
+	Notifications []notificationDigestNotification
+}
+
+type notificationDigestEmailParameters struct {
+	Categories                  []notificationDigestCategory
+	NotificationPreferencesLink string
+}
+
+func (c Client) notificationDigestEmailBody(items []*models.NotificationDigestItem) (string, error) {
+	if c.templates.notificationDigest == nil {
+		return "", errors.New("notification digest template is nil")
+	}
+
+	notificationsByCategory := map[models.NotificationCategory][]notificationDigestNotification{}
+	for _, item := range items {
+		body := emailContent(string(item.Body))
+		notificationsByCategory[item.Category] = append(notificationsByCategory[item.Category], notificationDigestNotification{
+			Subject: item.Subject,
+			Body:    body.ToTemplate(),
+		})
+	}
+
+	data := notificationDigestEmailParameters{
+		NotificationPreferencesLink: c.urlFromPath("notification-preferences"),
+	}
+	for _, category := range models.AllNotificationCategories {
+		if notifications, ok := notificationsByCategory[category]; ok {
+			data.Categories = append(data.Categories, notificationDigestCategory{
+				Name:          category.Humanize(),
+				Notifications: notifications,
+			})
+		}
+	}
+
+	var b bytes.Buffer
+	if err := c.templates.notificationDigest.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// SendNotificationDigestEmail sends a user a single email with every notification held back for their daily digest.
+// The digest itself doesn't belong to a notification category, so it's always sent
+func (c Client) SendNotificationDigestEmail(ctx context.Context, recipient models.EmailAddress, items []*models.NotificationDigestItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	subject := fmt.Sprintf("Your EASi daily digest (%d notifications)", len(items))
+	if len(items) == 1 {
+		subject = "Your EASi daily digest (1 notification)"
+	}
+
+	body, err := c.notificationDigestEmailBody(items)
+	if err != nil {
+		return err
+	}
+
+	digest := NewEmail().
+		WithToAddresses([]models.EmailAddress{recipient}).
+		WithSubject(subject).
+		WithBody(body)
+	digest.excludeFromNotificationCenter = true
+
+	return c.sender.Send(ctx, digest)
+}
@@ -0,0 +1,96 @@
+package email
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// notificationPreferencesStore is the storage the NotificationPreferencesSender reads preferences from, and holds digest notifications in
+type notificationPreferencesStore interface {
+	sqlutils.NamedPreparer
+	GetNotificationRecipientPreferences(ctx context.Context, np sqlutils.NamedPreparer, category models.NotificationCategory, emails []models.EmailAddress) ([]*models.NotificationRecipientPreference, error)
+	CreateNotificationDigestItem(ctx context.Context, np sqlutils.NamedPreparer, item *models.NotificationDigestItem) error
+}
+
+// NotificationPreferencesSender is a sender that applies recipients' notification preferences before passing emails on to another sender.
+// Recipients who want a category of notification in their daily digest have it held back for the digest instead, and recipients
+// who have turned a category off don't receive it. Emails without a notification category are always passed on unchanged
+type NotificationPreferencesSender struct {
+	store  notificationPreferencesStore
+	sender sender
+}
+
+// NewNotificationPreferencesSender returns a sender that applies recipients' notification preferences before sending emails with sender
+func NewNotificationPreferencesSender(store notificationPreferencesStore, sender sender) NotificationPreferencesSender {
+	return NotificationPreferencesSender{
+		store:  store,
+		sender: sender,
+	}
+}
+
+// Send removes the recipients who don't want the email immediately, holding it for the digests of those who want it in their digest,
+// then sends it to the remaining recipients. If ctx was decorated by WithOutboxTransaction, the digest notifications are held as part of that transaction
+func (s NotificationPreferencesSender) Send(ctx context.Context, email Email) error {
+	if email.NotificationCategory == "" {
+		return s.sender.Send(ctx, email)
+	}
+
+	np := outboxNamedPreparer(ctx, s.store)
+
+	var recipients []models.EmailAddress
+	recipients = append(recipients, email.ToAddresses...)
+	recipients = append(recipients, email.CcAddresses...)
+	recipients = append(recipients, email.BccAddresses...)
+
+	preferences, err := s.store.GetNotificationRecipientPreferences(ctx, np, email.NotificationCategory, recipients)
+	if err != nil {
+		return err
+	}
+
+	frequencies := map[string]models.NotificationFrequency{}
+	digestUserIDs := map[uuid.UUID]struct{}{}
+	for _, preference := range preferences {
+		frequencies[strings.ToLower(preference.Email.String())] = preference.Frequency
+		if preference.Frequency == models.NotificationFrequencyDailyDigest {
+			digestUserIDs[preference.UserID] = struct{}{}
+		}
+	}
+
+	for userID := range digestUserIDs {
+		err = s.store.CreateNotificationDigestItem(ctx, np, &models.NotificationDigestItem{
+			UserID:   userID,
+			Category: email.NotificationCategory,
+			Subject:  email.Subject,
+			Body:     models.HTML(email.Body),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// addresses without a preference, such as shared mailboxes, receive every notification immediately
+	immediate := func(addresses []models.EmailAddress) []models.EmailAddress {
+		var kept []models.EmailAddress
+		for _, address := range addresses {
+			frequency, ok := frequencies[strings.ToLower(address.String())]
+			if !ok || frequency == models.NotificationFrequencyImmediate {
+				kept = append(kept, address)
+			}
+		}
+		return kept
+	}
+
+	email.ToAddresses = immediate(email.ToAddresses)
+	email.CcAddresses = immediate(email.CcAddresses)
+	email.BccAddresses = immediate(email.BccAddresses)
+	if len(email.ToAddresses) == 0 && len(email.CcAddresses) == 0 && len(email.BccAddresses) == 0 {
+		return nil
+	}
+
+	return s.sender.Send(ctx, email)
+}
@@ -0,0 +1,135 @@
+package email
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// mockNotificationPreferencesStore returns the preferences it was created with, and records the notifications held back in it
+type mockNotificationPreferencesStore struct {
+	mockNamedPreparer
+	preferences []*models.NotificationRecipientPreference
+	heldWith    []sqlutils.NamedPreparer
+	held        []*models.NotificationDigestItem
+}
+
+func (s *mockNotificationPreferencesStore) GetNotificationRecipientPreferences(
+	ctx context.Context,
+	np sqlutils.NamedPreparer,
+	category models.NotificationCategory,
+	emails []models.EmailAddress,
+) ([]*models.NotificationRecipientPreference, error) {
+	var preferences []*models.NotificationRecipientPreference
+	for _, preference := range s.preferences {
+		for _, email := range emails {
+			if strings.EqualFold(preference.Email.String(), email.String()) {
+				preferences = append(preferences, preference)
+				break
+			}
+		}
+	}
+	return preferences, nil
+}
+
+func (s *mockNotificationPreferencesStore) CreateNotificationDigestItem(ctx context.Context, np sqlutils.NamedPreparer, item *models.NotificationDigestItem) error {
+	s.heldWith = append(s.heldWith, np)
+	s.held = append(s.held, item)
+	return nil
+}
+
+func (s *EmailTestSuite) TestNotificationPreferencesSender() {
+	ctx := context.Background()
+	digestUserID := uuid.New()
+	store := &mockNotificationPreferencesStore{
+		mockNamedPreparer: mockNamedPreparer{name: "db"},
+		preferences: []*models.NotificationRecipientPreference{
+			{UserID: uuid.New(), Email: "immediate@local.fake", Frequency: models.NotificationFrequencyImmediate},
+			{UserID: digestUserID, Email: "digest@local.fake", Frequency: models.NotificationFrequencyDailyDigest},
+			{UserID: uuid.New(), Email: "off@local.fake", Frequency: models.NotificationFrequencyOff},
+		},
+	}
+
+	newEmail := func(category models.NotificationCategory) Email {
+		return NewEmail().
+			WithNotificationCategory(category).
+			WithToAddresses([]models.EmailAddress{"Immediate@local.fake", "DIGEST@local.fake"}).
+			WithCCAddresses([]models.EmailAddress{"shared-mailbox@local.fake"}).
+			WithBCCAddresses([]models.EmailAddress{"off@local.fake"}).
+			WithSubject("subject").
+			WithBody("<p>body</p>")
+	}
+
+	s.Run("sends uncategorized emails to every recipient", func() {
+		sender := &mockSender{}
+		s.NoError(NewNotificationPreferencesSender(store, sender).Send(ctx, newEmail("")))
+
+		s.ElementsMatch([]models.EmailAddress{"Immediate@local.fake", "DIGEST@local.fake"}, sender.toAddresses)
+		s.ElementsMatch([]models.EmailAddress{"shared-mailbox@local.fake"}, sender.ccAddresses)
+		s.ElementsMatch([]models.EmailAddress{"off@local.fake"}, sender.bccAddresses)
+		s.Empty(store.held)
+	})
+
+	s.Run("holds categorized emails for digest recipients and drops them for recipients who turned them off", func() {
+		sender := &mockSender{}
+		tx := &mockNamedPreparer{name: "tx"}
+		s.NoError(NewNotificationPreferencesSender(store, sender).Send(
+			WithOutboxTransaction(ctx, tx),
+			newEmail(models.NotificationCategoryGRBDiscussions),
+		))
+
+		s.ElementsMatch([]models.EmailAddress{"Immediate@local.fake"}, sender.toAddresses)
+		// addresses without a user account, like shared mailboxes, still get the email
+		s.ElementsMatch([]models.EmailAddress{"shared-mailbox@local.fake"}, sender.ccAddresses)
+		s.Empty(sender.bccAddresses)
+
+		s.Len(store.held, 1)
+		s.Equal(tx, store.heldWith[0])
+		s.Equal(digestUserID, store.held[0].UserID)
+		s.Equal(models.NotificationCategoryGRBDiscussions, store.held[0].Category)
+		s.Equal("subject", store.held[0].Subject)
+		s.EqualValues("<p>body</p>", store.held[0].Body)
+	})
+
+	s.Run("doesn't send an email without any remaining recipients", func() {
+		sender := &mockSender{}
+		s.NoError(NewNotificationPreferencesSender(store, sender).Send(ctx, NewEmail().
+			WithNotificationCategory(models.NotificationCategoryTRBRequests).
+			WithToAddresses([]models.EmailAddress{"off@local.fake"}).
+			WithSubject("subject").
+			WithBody("body"),
+		))
+		s.Empty(sender.subject)
+	})
+}
+
+func (s *EmailTestSuite) TestSendNotificationDigestEmail() {
+	ctx := context.Background()
+	sender := &mockSender{}
+	client, err := NewClient(s.config, sender)
+	s.NoError(err)
+
+	// held back notifications are rendered from their own templates, header included
+	header := "<head><style>p { color: red; }</style></head>\n<h1 class=\"header-title\">EASi</h1>\n<p class=\"header-subtitle\">Easy Access to System Information</p>\n"
+
+	items := []*models.NotificationDigestItem{
+		{Category: models.NotificationCategoryTRBRequests, Subject: "TRB subject", Body: models.HTML(header + "<p>TRB body</p>")},
+		{Category: models.NotificationCategoryGRBDiscussions, Subject: "Discussion subject", Body: models.HTML(header + "<p>Discussion body</p>")},
+	}
+
+	s.NoError(client.SendNotificationDigestEmail(ctx, "digest@local.fake", items))
+	s.ElementsMatch([]models.EmailAddress{"digest@local.fake"}, sender.toAddresses)
+	s.Equal("Your EASi daily digest (2 notifications)", sender.subject)
+
+	// categories are listed in the order they're shown to users, and the header is only included once
+	s.Equal(1, strings.Count(sender.body, "Easy Access to System Information"))
+	s.Less(strings.Index(sender.body, "GRB discussions"), strings.Index(sender.body, "TRB requests"))
+	s.Contains(sender.body, "<h3>Discussion subject</h3>")
+	s.Contains(sender.body, "<p>Discussion body</p>")
+	s.Contains(sender.body, "<p>TRB body</p>")
+	s.Contains(sender.body, s.config.URLScheme+"://"+s.config.URLHost+"/notification-preferences")
+}
@@ -0,0 +1,107 @@
+package email
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// outboxStore is the storage the OutboxSender queues emails in
+type outboxStore interface {
+	sqlutils.NamedPreparer
+	CreateEmailOutboxMessage(ctx context.Context, np sqlutils.NamedPreparer, message *models.EmailOutboxMessage) error
+}
+
+type outboxTransactionKey struct{}
+
+// WithOutboxTransaction returns a copy of ctx in which emails sent through an OutboxSender are queued as part of tx,
+// so they are only sent if tx is committed, and aren't lost if sending fails after it is committed.
+// Notifications added for the emails should be held until tx is committed; see WithDeferredNotifications
+func WithOutboxTransaction(ctx context.Context, tx sqlutils.NamedPreparer) context.Context {
+	return context.WithValue(ctx, outboxTransactionKey{}, tx)
+}
+
+// OutboxSender is a sender that queues emails in the outbox instead of sending them directly.
+// The outbox dispatcher in the scheduler package sends them, retrying ones that fail
+type OutboxSender struct {
+	store outboxStore
+}
+
+// NewOutboxSender returns a sender that queues emails in the outbox
+func NewOutboxSender(store outboxStore) OutboxSender {
+	return OutboxSender{
+		store: store,
+	}
+}
+
+// Send queues an email in the outbox. If ctx was decorated by WithOutboxTransaction, it is queued as part of that transaction.
+// Emails with attachments that can't be sent are rejected here, rather than failing every time the dispatcher tries to send them
+func (s OutboxSender) Send(ctx context.Context, email Email) error {
+	if err := ValidateAttachments(email.Attachments); err != nil {
+		return err
+	}
+
+	return s.store.CreateEmailOutboxMessage(ctx, outboxNamedPreparer(ctx, s.store), NewOutboxMessage(ctx, email))
+}
+
+// outboxNamedPreparer returns the transaction set in ctx by WithOutboxTransaction, or np if there isn't one
+func outboxNamedPreparer(ctx context.Context, np sqlutils.NamedPreparer) sqlutils.NamedPreparer {
+	if tx, ok := ctx.Value(outboxTransactionKey{}).(sqlutils.NamedPreparer); ok {
+		return tx
+	}
+	return np
+}
+
+// NewOutboxMessage returns the outbox representation of an email, attributed to the principal in ctx
+func NewOutboxMessage(ctx context.Context, email Email) *models.EmailOutboxMessage {
+	// emails sent without a signed in user, such as by scheduled jobs, are queued without a creator
+	var createdBy *uuid.UUID
+	if account := appcontext.Principal(ctx).Account(); account != nil && account.ID != uuid.Nil {
+		createdBy = &account.ID
+	}
+
+	var replyTo *models.EmailAddress
+	if email.ReplyToAddress != "" {
+		replyTo = &email.ReplyToAddress
+	}
+
+	return &models.EmailOutboxMessage{
+		ID:             uuid.New(),
+		ToAddresses:    email.ToAddresses,
+		CcAddresses:    email.CcAddresses,
+		BccAddresses:   email.BccAddresses,
+		Subject:        email.Subject,
+		Body:           models.HTML(email.Body),
+		Attachments:    email.Attachments,
+		ReplyToAddress: replyTo,
+		Status:         models.EmailOutboxMessageStatusPending,
+		CreatedBy:      createdBy,
+	}
+}
+
+// OutboxMessageEmail returns the email queued by an outbox message, so it can be sent
+func OutboxMessageEmail(message *models.EmailOutboxMessage) Email {
+	email := NewEmail().
+		WithToAddresses(message.ToAddresses).
+		WithCCAddresses(message.CcAddresses).
+		WithBCCAddresses(message.BccAddresses).
+		WithSubject(message.Subject).
+		WithBody(string(message.Body))
+	if message.ReplyToAddress != nil {
+		email = email.WithReplyToAddress(*message.ReplyToAddress)
+	}
+	for _, attachment := range message.Attachments {
+		email = email.WithAttachment(attachment)
+	}
+	return email
+}
+
+// TrackedSender is a sender that returns the ID its email provider gave each email it sends, so the provider's delivery
+// notifications can be matched to the email in the outbox it was sent from
+type TrackedSender interface {
+	SendTracked(ctx context.Context, email Email) (messageID string, err error)
+}
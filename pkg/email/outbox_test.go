@@ -0,0 +1,74 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// mockNamedPreparer is a stand-in for a database connection or transaction
+type mockNamedPreparer struct {
+	name string
+}
+
+func (np *mockNamedPreparer) PrepareNamed(query string) (*sqlx.NamedStmt, error) {
+	return nil, nil
+}
+
+func (np *mockNamedPreparer) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	return nil, nil
+}
+
+// mockOutboxStore records the emails queued in it, and which NamedPreparer they were queued with
+type mockOutboxStore struct {
+	mockNamedPreparer
+	queuedWith []sqlutils.NamedPreparer
+	queued     []*models.EmailOutboxMessage
+}
+
+func (s *mockOutboxStore) CreateEmailOutboxMessage(ctx context.Context, np sqlutils.NamedPreparer, message *models.EmailOutboxMessage) error {
+	s.queuedWith = append(s.queuedWith, np)
+	s.queued = append(s.queued, message)
+	return nil
+}
+
+func (s *EmailTestSuite) TestOutboxSender() {
+	ctx := context.Background()
+	store := &mockOutboxStore{mockNamedPreparer: mockNamedPreparer{name: "db"}}
+	sender := NewOutboxSender(store)
+
+	email := NewEmail().
+		WithToAddresses([]models.EmailAddress{"to@local.fake"}).
+		WithCCAddresses([]models.EmailAddress{"cc@local.fake"}).
+		WithBCCAddresses([]models.EmailAddress{"bcc@local.fake"}).
+		WithSubject("subject").
+		WithBody("<p>body</p>")
+
+	s.Run("queues the email without a transaction", func() {
+		s.NoError(sender.Send(ctx, email))
+		s.Len(store.queued, 1)
+		s.Equal(store, store.queuedWith[0])
+
+		message := store.queued[0]
+		s.Equal(models.EmailOutboxMessageStatusPending, message.Status)
+		s.Nil(message.CreatedBy)
+		s.Equal(email, OutboxMessageEmail(message))
+	})
+
+	s.Run("queues the email in the transaction in the context", func() {
+		tx := &mockNamedPreparer{name: "tx"}
+		s.NoError(sender.Send(WithOutboxTransaction(ctx, tx), email))
+		s.Len(store.queued, 2)
+		s.Equal(tx, store.queuedWith[1])
+	})
+
+	s.Run("rejects emails with attachments that can't be sent", func() {
+		err := sender.Send(ctx, email.WithAttachment(models.EmailAttachment{Filename: "virus.exe", ContentType: "application/x-msdownload"}))
+		s.Error(err)
+		s.Len(store.queued, 2)
+	})
+}
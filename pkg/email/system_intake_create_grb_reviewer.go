@@ -69,6 +69,7 @@ func (sie systemIntakeEmails) SendCreateGRBReviewerNotification(
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithBCCAddresses(recipients).
 			WithSubject(subject).
 			WithBody(body),
@@ -0,0 +1,113 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// SendSystemIntakeGRBMeetingEmailInput contains the data needed to send GRB reviewers an invite to, or a cancellation of, a GRB meeting
+type SendSystemIntakeGRBMeetingEmailInput struct {
+	SystemIntakeID     uuid.UUID
+	RequestName        string
+	RequesterName      string
+	RequesterComponent string
+	// GRBDate is the date of the meeting, or nil if a previously scheduled meeting has been cancelled
+	GRBDate    *time.Time
+	Recipients []models.EmailAddress
+	// CalendarInviteSequence is the sequence of the calendar invite attached to the email, from store.NextCalendarInviteSequence
+	CalendarInviteSequence int
+}
+
+type systemIntakeGRBMeetingBody struct {
+	RequestName              string
+	RequesterName            string
+	RequestComponent         string
+	GRBDate                  string
+	Cancelled                bool
+	Link                     string
+	ITGovernanceInboxAddress string
+}
+
+func (sie systemIntakeEmails) systemIntakeGRBMeetingBody(input SendSystemIntakeGRBMeetingEmailInput) (string, error) {
+	if sie.client.templates.systemIntakeGRBMeetingTemplate == nil {
+		return "", errors.New("system intake GRB meeting template is nil")
+	}
+
+	data := systemIntakeGRBMeetingBody{
+		RequestName:              input.RequestName,
+		RequesterName:            input.RequesterName,
+		RequestComponent:         input.RequesterComponent,
+		Cancelled:                input.GRBDate == nil,
+		Link:                     sie.client.urlFromPath(path.Join("it-governance", input.SystemIntakeID.String(), "grb-review")),
+		ITGovernanceInboxAddress: sie.client.config.GRTEmail.String(),
+	}
+	if input.GRBDate != nil {
+		data.GRBDate = input.GRBDate.Format("01/02/2006")
+	}
+
+	var b bytes.Buffer
+	if err := sie.client.templates.systemIntakeGRBMeetingTemplate.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// SendSystemIntakeGRBMeetingEmail sends GRB reviewers a calendar invite for the GRB meeting of a system intake when it's scheduled or rescheduled,
+// or a cancellation of it when its date is removed
+func (sie systemIntakeEmails) SendSystemIntakeGRBMeetingEmail(ctx context.Context, input SendSystemIntakeGRBMeetingEmailInput) error {
+	if len(input.RequestName) < 1 {
+		input.RequestName = "Draft System Intake"
+	}
+
+	subject := fmt.Sprintf("GRB meeting scheduled for %s", input.RequestName)
+	if input.GRBDate == nil {
+		subject = fmt.Sprintf("GRB meeting cancelled for %s", input.RequestName)
+	}
+
+	body, err := sie.systemIntakeGRBMeetingBody(input)
+	if err != nil {
+		return err
+	}
+
+	link := sie.client.urlFromPath(path.Join("it-governance", input.SystemIntakeID.String(), "grb-review"))
+
+	// reviewers are BCC'd so they can't see each other, so they aren't listed as attendees either
+	invite := CalendarInvite{
+		UID:         sie.client.GRBMeetingInviteUID(input.SystemIntakeID),
+		Sequence:    input.CalendarInviteSequence,
+		Method:      CalendarInviteMethodRequest,
+		Summary:     "GRB meeting for " + input.RequestName,
+		Description: "View this request in EASi: " + link,
+		URL:         link,
+		AllDay:      true,
+		Organizer:   sie.client.config.GRTEmail,
+	}
+	if input.GRBDate != nil {
+		invite.Start = *input.GRBDate
+		invite.End = input.GRBDate.AddDate(0, 0, 1)
+	} else {
+		// cancellations only need to match the UID of the event, but the dates are still required, so use the day it was cancelled
+		invite.Method = CalendarInviteMethodCancel
+		invite.Start = time.Now()
+		invite.End = invite.Start.AddDate(0, 0, 1)
+	}
+
+	return sie.client.sender.Send(ctx,
+		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
+			WithCCAddresses([]models.EmailAddress{sie.client.config.GRTEmail}).
+			WithBCCAddresses(input.Recipients).
+			WithSubject(subject).
+			WithBody(body).
+			WithAttachment(invite.Attachment()),
+	)
+}
@@ -0,0 +1,124 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *EmailTestSuite) TestSendSystemIntakeGRBMeetingEmail() {
+	ms := mockSender{}
+
+	ctx := context.Background()
+	intakeID := uuid.MustParse("24dd7736-e4c2-4f67-8844-51187de49069")
+	requestName := "Hotdog/Not Hotdog Program"
+	requester := "Dr Fishopolis"
+	requestComponent := "DOC"
+	grbDate := time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+
+	requestLink := fmt.Sprintf(
+		"%s://%s/it-governance/%s/grb-review",
+		s.config.URLScheme,
+		s.config.URLHost,
+		intakeID.String(),
+	)
+
+	recipient := models.NewEmailAddress("fake@fake.com")
+
+	getExpectedEmail := func(message string) string {
+		return fmt.Sprintf(`
+		<h1 class="header-title">EASi</h1>
+		<p class="header-subtitle">Easy Access to System Information</p>
+
+		%[6]s
+
+		<br>
+		<p><strong><a href="%[2]s">View this request in EASi</a></strong></p>
+
+		<br>
+		<div class="no-margin">
+		  <p><strong>Request summary:</strong></p>
+		  <p>Project title: %[1]s</p>
+		  <p>Requester: %[3]s, %[4]s</p>
+		</div>
+
+		<br>
+		<p>If you have questions, please contact the Governance Team at <a
+		    href="mailto:%[5]s">%[5]s</a>.</p>
+		<hr>
+		<p>You will continue to receive email notifications about this request until it is closed.</p>`,
+			requestName,
+			requestLink,
+			requester,
+			requestComponent,
+			s.config.GRTEmail.String(),
+			message,
+		)
+	}
+
+	s.Run("scheduling a GRB meeting sends an invite", func() {
+		client, err := NewClient(s.config, &ms)
+		s.NoError(err)
+		err = client.SystemIntake.SendSystemIntakeGRBMeetingEmail(ctx, SendSystemIntakeGRBMeetingEmailInput{
+			SystemIntakeID:         intakeID,
+			RequestName:            requestName,
+			RequesterName:          requester,
+			RequesterComponent:     requestComponent,
+			GRBDate:                &grbDate,
+			Recipients:             []models.EmailAddress{recipient},
+			CalendarInviteSequence: 1,
+		})
+		s.NoError(err)
+
+		s.Equal(fmt.Sprintf("GRB meeting scheduled for %s", requestName), ms.subject)
+		s.EqualHTML(getExpectedEmail(fmt.Sprintf(
+			`<p>The GRB meeting for %s has been scheduled for 06/14/2024. Add it to your calendar using the attached invite. If the meeting is rescheduled, you will receive an updated invite.</p>`,
+			requestName,
+		)), ms.body)
+
+		s.ElementsMatch([]models.EmailAddress{s.config.GRTEmail}, ms.ccAddresses)
+		s.ElementsMatch([]models.EmailAddress{recipient}, ms.bccAddresses)
+
+		s.Len(ms.attachments, 1)
+		s.Equal("text/calendar; charset=UTF-8; method=REQUEST", ms.attachments[0].ContentType)
+		invite := string(ms.attachments[0].Data)
+		s.Contains(invite, "\r\nUID:"+client.GRBMeetingInviteUID(intakeID)+"\r\n")
+		s.Contains(invite, "\r\nSEQUENCE:1\r\n")
+		s.Contains(invite, "\r\nDTSTART;VALUE=DATE:20240614\r\n")
+		s.Contains(invite, "\r\nDTEND;VALUE=DATE:20240615\r\n")
+		s.Contains(invite, "\r\nORGANIZER:mailto:"+s.config.GRTEmail.String()+"\r\n")
+		// reviewers are BCC'd, so they aren't revealed to each other in the invite
+		s.NotContains(invite, "ATTENDEE")
+	})
+
+	s.Run("removing the GRB date sends a cancellation", func() {
+		client, err := NewClient(s.config, &ms)
+		s.NoError(err)
+		err = client.SystemIntake.SendSystemIntakeGRBMeetingEmail(ctx, SendSystemIntakeGRBMeetingEmailInput{
+			SystemIntakeID:         intakeID,
+			RequestName:            requestName,
+			RequesterName:          requester,
+			RequesterComponent:     requestComponent,
+			Recipients:             []models.EmailAddress{recipient},
+			CalendarInviteSequence: 2,
+		})
+		s.NoError(err)
+
+		s.Equal(fmt.Sprintf("GRB meeting cancelled for %s", requestName), ms.subject)
+		s.EqualHTML(getExpectedEmail(fmt.Sprintf(
+			`<p>The GRB meeting for %s has been cancelled. The Governance Admin Team will let you know when it is rescheduled. The attached calendar invite removes the meeting from your calendar.</p>`,
+			requestName,
+		)), ms.body)
+
+		s.Len(ms.attachments, 1)
+		s.Equal("text/calendar; charset=UTF-8; method=CANCEL", ms.attachments[0].ContentType)
+		invite := string(ms.attachments[0].Data)
+		s.Contains(invite, "\r\nUID:"+client.GRBMeetingInviteUID(intakeID)+"\r\n")
+		s.Contains(invite, "\r\nSEQUENCE:2\r\n")
+		s.Contains(invite, "\r\nSTATUS:CANCELLED\r\n")
+	})
+}
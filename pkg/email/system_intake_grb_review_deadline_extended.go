@@ -85,6 +85,7 @@ func (sie systemIntakeEmails) SendSystemIntakeGRBReviewDeadlineExtended(
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses(sie.client.listAllRecipients(recipients)).
 			WithSubject(subject).
 			WithBody(body),
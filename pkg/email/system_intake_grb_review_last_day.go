@@ -75,6 +75,7 @@ func (sie systemIntakeEmails) SendSystemIntakeGRBReviewLastDay(
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses([]models.EmailAddress{input.Recipient}).
 			WithSubject(subject).
 			WithBody(body),
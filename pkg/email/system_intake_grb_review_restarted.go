@@ -81,6 +81,7 @@ func (sie systemIntakeEmails) SendSystemIntakeGRBReviewRestarted(
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses(sie.client.listAllRecipients(recipients)).
 			WithSubject(subject).
 			WithBody(body),
@@ -91,6 +91,7 @@ func (sie systemIntakeEmails) SendSystemIntakeGRBReviewRestartedAdmin(
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses(sie.client.listAllRecipients(recipients)).
 			WithSubject(subject).
 			WithBody(body),
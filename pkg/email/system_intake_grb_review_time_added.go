@@ -101,6 +101,7 @@ func (sie systemIntakeEmails) SendSystemIntakeGRBReviewTimeAdded(
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses(sie.client.listAllRecipients(recipients)).
 			WithSubject(subject).
 			WithBody(body),
@@ -65,6 +65,7 @@ func (sie systemIntakeEmails) SendSystemIntakeGRBReviewEnded(ctx context.Context
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses([]models.EmailAddress{input.Recipient}).
 			WithSubject(subject).
 			WithBody(body),
@@ -73,6 +73,7 @@ func (sie systemIntakeEmails) SendSystemIntakeGRBReviewerReminder(ctx context.Co
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses([]models.EmailAddress{input.Recipient}).
 			WithSubject(subject).
 			WithBody(body),
@@ -66,6 +66,7 @@ func (sie systemIntakeEmails) SendSystemIntakeGRBReviewEndedEarly(ctx context.Co
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses([]models.EmailAddress{input.Recipient}).
 			WithSubject(subject).
 			WithBody(body),
@@ -59,6 +59,7 @@ func (sie systemIntakeEmails) SendPresentationDeckUploadReminder(
 	return sie.client.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBReviews).
 			WithToAddresses(sie.client.listAllRecipients(recipients)).
 			WithSubject(subject).
 			WithBody(body),
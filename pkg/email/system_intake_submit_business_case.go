@@ -126,7 +126,7 @@ func (sie systemIntakeEmails) businessCaseSubmissionReviewerBody(
 	return b.String(), nil
 }
 
-// SendSubmitBizCaseReviewerNotification sends an email for a submitted Business Case
+// SendSubmitBizCaseReviewerNotification sends an email for a submitted Business Case, with a PDF of businessCase attached if it's given
 func (sie systemIntakeEmails) SendSubmitBizCaseReviewerNotification(
 	ctx context.Context,
 	systemIntakeID uuid.UUID,
@@ -134,6 +134,7 @@ func (sie systemIntakeEmails) SendSubmitBizCaseReviewerNotification(
 	requestName string,
 	isResubmitted bool,
 	isDraft bool,
+	businessCase *models.BusinessCaseWithCosts,
 ) error {
 	draftOrFinal := "final"
 	if isDraft {
@@ -163,11 +164,18 @@ func (sie systemIntakeEmails) SendSubmitBizCaseReviewerNotification(
 		return err
 	}
 
-	return sie.client.sender.Send(
-		ctx,
-		NewEmail().
-			WithToAddresses([]models.EmailAddress{sie.client.config.GRTEmail}).
-			WithSubject(subject).
-			WithBody(body),
-	)
+	email := NewEmail().
+		WithToAddresses([]models.EmailAddress{sie.client.config.GRTEmail}).
+		WithSubject(subject).
+		WithBody(body)
+
+	if businessCase != nil {
+		document, err := sie.client.BusinessCasePDF(businessCase)
+		if err != nil {
+			return err
+		}
+		email = email.WithAttachment(BusinessCasePDFAttachment(businessCase.ID, document))
+	}
+
+	return sie.client.sender.Send(ctx, email)
 }
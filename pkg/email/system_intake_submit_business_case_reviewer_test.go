@@ -1,10 +1,12 @@
 package email
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null"
 
 	"github.com/cms-enterprise/easi-app/pkg/models"
 )
@@ -88,6 +90,7 @@ func (s *EmailTestSuite) TestSubmitBizCaseReviewer() {
 			requestName,
 			isResubmitted,
 			isDraft,
+			nil,
 		)
 
 		s.NoError(err)
@@ -111,6 +114,7 @@ func (s *EmailTestSuite) TestSubmitBizCaseReviewer() {
 			requestName,
 			isResubmitted,
 			isDraft,
+			nil,
 		)
 
 		s.NoError(err)
@@ -134,6 +138,7 @@ func (s *EmailTestSuite) TestSubmitBizCaseReviewer() {
 			requestName,
 			isResubmitted,
 			isDraft,
+			nil,
 		)
 
 		s.NoError(err)
@@ -157,6 +162,7 @@ func (s *EmailTestSuite) TestSubmitBizCaseReviewer() {
 			requestName,
 			isResubmitted,
 			isDraft,
+			nil,
 		)
 
 		s.NoError(err)
@@ -177,6 +183,7 @@ func (s *EmailTestSuite) TestSubmitBizCaseReviewer() {
 			requestName,
 			false,
 			false,
+			nil,
 		)
 
 		s.Error(err)
@@ -195,12 +202,41 @@ func (s *EmailTestSuite) TestSubmitBizCaseReviewer() {
 			requestName,
 			false,
 			false,
+			nil,
 		)
 
 		s.Error(err)
 		s.Equal("template caller had an error", err.Error())
 	})
 
+	s.Run("attaches a PDF of the business case when it's given", func() {
+		client, err := NewClient(s.config, &sender)
+		s.NoError(err)
+
+		businessCase := &models.BusinessCaseWithCosts{
+			BusinessCase: models.BusinessCase{
+				ProjectName: null.StringFrom(requestName),
+			},
+		}
+		businessCase.ID = uuid.New()
+
+		err = client.SystemIntake.SendSubmitBizCaseReviewerNotification(
+			ctx,
+			intakeID,
+			requesterName,
+			requestName,
+			false,
+			true,
+			businessCase,
+		)
+		s.NoError(err)
+
+		s.Len(sender.attachments, 1)
+		s.Equal(fmt.Sprintf("business_case_%s.pdf", businessCase.ID), sender.attachments[0].Filename)
+		s.Equal("application/pdf", sender.attachments[0].ContentType)
+		s.True(bytes.HasPrefix(sender.attachments[0].Data, []byte("%PDF-")))
+	})
+
 	s.Run("if the sender fails, we get the error from it", func() {
 		sender := mockFailedSender{}
 
@@ -214,6 +250,7 @@ func (s *EmailTestSuite) TestSubmitBizCaseReviewer() {
 			requestName,
 			false,
 			false,
+			nil,
 		)
 
 		s.Error(err)
@@ -51,6 +51,7 @@ func (c Client) SendTRBAttendeeAddedNotification(
 	return c.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryTRBRequests).
 			WithToAddresses([]models.EmailAddress{attendeeEmail}).
 			WithSubject(subject).
 			WithBody(body),
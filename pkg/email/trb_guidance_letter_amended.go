@@ -0,0 +1,160 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sanitization"
+)
+
+// SendTRBGuidanceLetterAmendedEmailInput contains the data needed to send the email telling a guidance letter's recipients it was amended
+type SendTRBGuidanceLetterAmendedEmailInput struct {
+	TRBRequestID     uuid.UUID
+	RequestName      string
+	RequestType      string
+	RequesterName    string
+	Component        string
+	ConsultDate      *time.Time
+	CopyTRBMailbox   bool
+	CopyITGovMailbox bool
+	Recipients       []models.EmailAddress
+	AmendmentSummary models.HTML
+	// Diff is the difference between the letter as it was last sent and the amended letter
+	Diff *models.TRBGuidanceLetterVersionDiff
+	// Letter and its Insights are attached to the email as a PDF, if Letter is set
+	Letter   *models.TRBGuidanceLetter
+	Insights []*models.TRBGuidanceLetterInsight
+}
+
+// trbGuidanceLetterAmendedEmailTemplateParams contains the data needed for interpolation in the TRB guidance letter amended email template
+type trbGuidanceLetterAmendedEmailTemplateParams struct {
+	RequestName           string
+	RequesterName         string
+	AmendmentSummary      template.HTML
+	Changes               []trbGuidanceLetterAmendedChange
+	TRBGuidanceLetterLink string
+	TRBAdminRequestLink   string
+	TRBRequestLink        string
+	TRBEmail              models.EmailAddress
+	LetterAttached        bool
+}
+
+// trbGuidanceLetterAmendedChange is a section of an amended guidance letter that changed
+type trbGuidanceLetterAmendedChange struct {
+	Heading string
+	Diff    template.HTML
+}
+
+// SendTRBGuidanceLetterAmendedEmail notifies the recipients of a guidance letter that it was amended, and what changed
+func (c Client) SendTRBGuidanceLetterAmendedEmail(ctx context.Context, input SendTRBGuidanceLetterAmendedEmailInput) error {
+	subject := "Guidance letter amended for " + input.RequestName
+
+	allRecipients := input.Recipients
+	if input.CopyTRBMailbox {
+		allRecipients = append(allRecipients, c.config.TRBEmail)
+	}
+	if input.CopyITGovMailbox {
+		allRecipients = append(allRecipients, c.config.GRTEmail)
+	}
+
+	templateParams := trbGuidanceLetterAmendedEmailTemplateParams{
+		RequestName:           input.RequestName,
+		RequesterName:         input.RequesterName,
+		AmendmentSummary:      input.AmendmentSummary.ToTemplate(),
+		Changes:               trbGuidanceLetterAmendedChanges(input.Diff),
+		TRBGuidanceLetterLink: c.urlFromPath(path.Join("trb", "guidance-letter", input.TRBRequestID.String())),
+		TRBAdminRequestLink:   c.urlFromPath(path.Join("trb", input.TRBRequestID.String(), "request")),
+		TRBRequestLink:        c.urlFromPath(path.Join("trb", "task-list", input.TRBRequestID.String())),
+		TRBEmail:              c.config.TRBEmail,
+		LetterAttached:        input.Letter != nil,
+	}
+
+	var b bytes.Buffer
+	if err := c.templates.trbGuidanceLetterAmended.Execute(&b, templateParams); err != nil {
+		return err
+	}
+
+	email := NewEmail().
+		WithToAddresses(allRecipients).
+		WithSubject(subject).
+		WithBody(b.String())
+
+	if input.Letter != nil {
+		document, err := c.TRBGuidanceLetterPDF(TRBGuidanceLetterDocumentInput{
+			RequestName:   input.RequestName,
+			RequestType:   input.RequestType,
+			RequesterName: input.RequesterName,
+			Component:     input.Component,
+			ConsultDate:   input.ConsultDate,
+			Letter:        input.Letter,
+			Insights:      input.Insights,
+		})
+		if err != nil {
+			return err
+		}
+		email = email.WithAttachment(TRBGuidanceLetterPDFAttachment(input.TRBRequestID, document))
+	}
+
+	return c.sender.Send(ctx, email)
+}
+
+// trbGuidanceLetterAmendedChanges lists the sections of the letter that changed, in the order they appear in the letter
+func trbGuidanceLetterAmendedChanges(diff *models.TRBGuidanceLetterVersionDiff) []trbGuidanceLetterAmendedChange {
+	if diff == nil {
+		return nil
+	}
+
+	var changes []trbGuidanceLetterAmendedChange
+	addChange := func(heading string, diffHTML models.HTML) {
+		changes = append(changes, trbGuidanceLetterAmendedChange{
+			Heading: heading,
+			Diff:    template.HTML(sanitization.SanitizeHTMLDiff(diffHTML)), //nolint //the diff is sanitized in the same statement
+		})
+	}
+
+	if diff.MeetingSummaryChanged {
+		addChange("Meeting summary", diff.MeetingSummary)
+	}
+
+	for _, insight := range diff.Insights {
+		var change string
+		switch insight.ChangeType {
+		case models.TRBGuidanceLetterInsightChangeTypeAdded:
+			change = "added"
+		case models.TRBGuidanceLetterInsightChangeTypeRemoved:
+			change = "removed"
+		case models.TRBGuidanceLetterInsightChangeTypeChanged:
+			change = "changed"
+		default:
+			continue
+		}
+
+		heading := "Additional guidance"
+		for _, categoryHeading := range trbGuidanceLetterInsightHeadings {
+			if categoryHeading.category == insight.Category {
+				heading = categoryHeading.heading
+			}
+		}
+		addChange(heading+": "+change, "<p><strong>"+insight.Title+"</strong></p>"+insight.Insight+insight.Links)
+	}
+
+	if diff.NextStepsChanged {
+		addChange("Next steps", diff.NextSteps)
+	}
+	if diff.IsFollowupRecommendedChanged || diff.FollowupPointChanged {
+		followup := models.HTML("<p>Follow-up is not recommended</p>")
+		if lo.FromPtr(diff.To.IsFollowupRecommended) {
+			followup = "<p>Follow-up is recommended</p>" + diff.FollowupPoint
+		}
+		addChange("Follow-up", followup)
+	}
+
+	return changes
+}
@@ -0,0 +1,66 @@
+package email
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *EmailTestSuite) TestTRBGuidanceLetterAmendedEmail() {
+	ctx := context.Background()
+	sender := mockSender{}
+	client, err := NewClient(s.config, &sender)
+	s.NoError(err)
+
+	letter := &models.TRBGuidanceLetter{
+		TRBRequestID:          uuid.New(),
+		MeetingSummary:        helpers.PointerTo(models.HTML("<p>We discussed the migration</p>")),
+		NextSteps:             helpers.PointerTo(models.HTML("<p>Schedule a follow-up</p>")),
+		IsFollowupRecommended: helpers.PointerTo(false),
+	}
+	insight := &models.TRBGuidanceLetterInsight{
+		Title:            "Use the enterprise cloud",
+		Insight:          "<p>Migrate to AWS</p>",
+		Category:         models.TRBGuidanceLetterInsightCategoryRecommendation,
+		PositionInLetter: null.IntFrom(0),
+	}
+	insight.ID = uuid.New()
+	sent := models.NewTRBGuidanceLetterVersion(letter, []*models.TRBGuidanceLetterInsight{insight}, models.TRBGuidanceLetterVersionReasonSent, "ABCD")
+
+	insight.Insight = "<p>Migrate to Azure</p>"
+	letter.IsFollowupRecommended = helpers.PointerTo(true)
+	letter.FollowupPoint = helpers.PointerTo("In 6 months")
+	amended := models.NewTRBGuidanceLetterVersion(letter, []*models.TRBGuidanceLetterInsight{insight}, models.TRBGuidanceLetterVersionReasonAmended, "ABCD")
+
+	recipient := models.NewEmailAddress("requester@local.fake")
+	err = client.SendTRBGuidanceLetterAmendedEmail(ctx, SendTRBGuidanceLetterAmendedEmailInput{
+		TRBRequestID:     letter.TRBRequestID,
+		RequestName:      "Cloud migration",
+		RequesterName:    "Rock Lee",
+		CopyTRBMailbox:   true,
+		Recipients:       []models.EmailAddress{recipient},
+		AmendmentSummary: "<p>The cloud provider was corrected</p><script>alert(1)</script>",
+		Diff:             models.NewTRBGuidanceLetterVersionDiff(sent, amended),
+		Letter:           letter,
+		Insights:         []*models.TRBGuidanceLetterInsight{insight},
+	})
+	s.NoError(err)
+
+	s.ElementsMatch([]models.EmailAddress{recipient, s.config.TRBEmail}, sender.toAddresses)
+	s.Equal("Guidance letter amended for Cloud migration", sender.subject)
+	s.Len(sender.attachments, 1)
+
+	s.Contains(sender.body, "<p>The cloud provider was corrected</p>")
+	s.NotContains(sender.body, "<script>")
+	s.Contains(sender.body, "<p><strong>Recommendations: changed</strong></p>")
+	s.Contains(sender.body, "<p>Migrate to <del>AWS</del><ins>Azure</ins></p>")
+	s.Contains(sender.body, "<p>Follow-up is recommended</p><ins>In 6 months</ins>")
+
+	// sections that didn't change aren't listed
+	s.NotContains(sender.body, "Meeting summary")
+	s.NotContains(sender.body, "Next steps")
+}
@@ -0,0 +1,145 @@
+package email
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/email/translation"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pdf"
+)
+
+// TRBGuidanceLetterDocumentInput contains the data needed to render a TRB guidance letter as a document
+type TRBGuidanceLetterDocumentInput struct {
+	RequestName   string
+	RequestType   string
+	RequesterName string
+	Component     string
+	ConsultDate   *time.Time
+	Letter        *models.TRBGuidanceLetter
+	Insights      []*models.TRBGuidanceLetterInsight
+}
+
+type trbGuidanceLetterDocument struct {
+	Title                 string
+	RequestName           string
+	DateSent              string
+	Fields                []documentField
+	MeetingSummary        template.HTML
+	InsightSections       []trbGuidanceLetterDocumentInsightSection
+	NextSteps             template.HTML
+	IsFollowupRecommended bool
+	FollowupPoint         string
+}
+
+type trbGuidanceLetterDocumentInsightSection struct {
+	Heading  string
+	Insights []trbGuidanceLetterDocumentInsight
+}
+
+type trbGuidanceLetterDocumentInsight struct {
+	Title   string
+	Insight template.HTML
+	Links   []string
+}
+
+// trbGuidanceLetterInsightHeadings are the headings insights are grouped under, in the order they're shown in the letter
+var trbGuidanceLetterInsightHeadings = []struct {
+	category models.TRBGuidanceLetterInsightCategory
+	heading  string
+}{
+	{category: models.TRBGuidanceLetterInsightCategoryRequirement, heading: "Requirements"},
+	{category: models.TRBGuidanceLetterInsightCategoryRecommendation, heading: "Recommendations"},
+	{category: models.TRBGuidanceLetterInsightCategoryConsideration, heading: "Considerations"},
+	{category: models.TRBGuidanceLetterInsightCategoryUncategorized, heading: "Additional guidance"},
+}
+
+// TRBGuidanceLetterPDF renders a printable PDF of a TRB guidance letter, with its meeting summary, insights and next steps
+func (c Client) TRBGuidanceLetterPDF(input TRBGuidanceLetterDocumentInput) ([]byte, error) {
+	if c.templates.trbGuidanceLetterDocumentTemplate == nil {
+		return nil, errors.New("TRB guidance letter document template is nil")
+	}
+
+	var document bytes.Buffer
+	if err := c.templates.trbGuidanceLetterDocumentTemplate.Execute(&document, newTRBGuidanceLetterDocument(input)); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := pdf.FromHTML(&b, &document); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// TRBGuidanceLetterPDFAttachment returns a PDF of the guidance letter for the TRB request with the given ID, from TRBGuidanceLetterPDF,
+// as an email attachment
+func TRBGuidanceLetterPDFAttachment(trbRequestID uuid.UUID, document []byte) models.EmailAttachment {
+	return models.EmailAttachment{
+		Filename:    TRBGuidanceLetterPDFFilename(trbRequestID),
+		ContentType: "application/pdf",
+		Data:        document,
+	}
+}
+
+// TRBGuidanceLetterPDFFilename is the name a PDF of a TRB request's guidance letter is attached with
+func TRBGuidanceLetterPDFFilename(trbRequestID uuid.UUID) string {
+	return fmt.Sprintf("guidance_letter_%s.pdf", trbRequestID)
+}
+
+func newTRBGuidanceLetterDocument(input TRBGuidanceLetterDocumentInput) trbGuidanceLetterDocument {
+	requestName := lo.CoalesceOrEmpty(input.RequestName, "Draft TRB request")
+
+	document := trbGuidanceLetterDocument{
+		Title:       "TRB guidance letter: " + requestName,
+		RequestName: requestName,
+		Fields: []documentField{
+			{Label: "Requester", Value: lo.CoalesceOrEmpty(input.RequesterName, documentNotProvided)},
+			{Label: "Component", Value: lo.CoalesceOrEmpty(translation.GetComponentAcronym(input.Component), "None selected")},
+			{Label: "Request type", Value: lo.CoalesceOrEmpty(translation.GetTRBResponseType(input.RequestType), documentNotProvided)},
+			{Label: "Date of TRB consult", Value: lo.CoalesceOrEmpty(formatDocumentDate(input.ConsultDate), documentNotProvided)},
+		},
+	}
+
+	if letter := input.Letter; letter != nil {
+		document.DateSent = formatDocumentDate(letter.DateSent)
+		document.MeetingSummary = letter.MeetingSummary.ToTemplate()
+		document.NextSteps = letter.NextSteps.ToTemplate()
+		document.IsFollowupRecommended = lo.FromPtr(letter.IsFollowupRecommended)
+		document.FollowupPoint = lo.FromPtr(letter.FollowupPoint)
+	}
+
+	insights := slices.Clone(input.Insights)
+	slices.SortStableFunc(insights, func(a *models.TRBGuidanceLetterInsight, b *models.TRBGuidanceLetterInsight) int {
+		return int(a.PositionInLetter.ValueOrZero() - b.PositionInLetter.ValueOrZero())
+	})
+	insightsByCategory := lo.GroupBy(insights, func(insight *models.TRBGuidanceLetterInsight) models.TRBGuidanceLetterInsightCategory {
+		return insight.Category
+	})
+
+	for _, heading := range trbGuidanceLetterInsightHeadings {
+		categoryInsights := insightsByCategory[heading.category]
+		if len(categoryInsights) == 0 {
+			continue
+		}
+
+		section := trbGuidanceLetterDocumentInsightSection{Heading: heading.heading}
+		for _, insight := range categoryInsights {
+			section.Insights = append(section.Insights, trbGuidanceLetterDocumentInsight{
+				Title:   insight.Title,
+				Insight: insight.Insight.ToTemplate(),
+				Links:   insight.Links,
+			})
+		}
+		document.InsightSections = append(document.InsightSections, section)
+	}
+
+	return document
+}
@@ -0,0 +1,70 @@
+package email
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/guregu/null"
+
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *EmailTestSuite) TestTRBGuidanceLetterPDF() {
+	client, err := NewClient(s.config, &mockSender{})
+	s.NoError(err)
+
+	dateSent := time.Date(2024, 3, 14, 12, 0, 0, 0, time.UTC)
+	input := TRBGuidanceLetterDocumentInput{
+		RequestName:   "Mock TRB Request",
+		RequestType:   string(models.TRBTNeedHelp),
+		RequesterName: "Jane Doe",
+		Letter: &models.TRBGuidanceLetter{
+			MeetingSummary:        models.HTMLPointer("<p>We discussed the <strong>architecture</strong></p>"),
+			IsFollowupRecommended: helpers.PointerTo(true),
+			FollowupPoint:         helpers.PointerTo("In 6 months"),
+			DateSent:              &dateSent,
+		},
+		Insights: []*models.TRBGuidanceLetterInsight{
+			{
+				Title:            "Second recommendation",
+				Insight:          "<p>Do this second</p>",
+				PositionInLetter: null.IntFrom(1),
+				Category:         models.TRBGuidanceLetterInsightCategoryRecommendation,
+			},
+			{
+				Title:            "First recommendation",
+				Insight:          "<p>Do this first</p>",
+				Links:            []string{"https://www.cms.gov"},
+				PositionInLetter: null.IntFrom(0),
+				Category:         models.TRBGuidanceLetterInsightCategoryRecommendation,
+			},
+			{
+				Title:    "A requirement",
+				Insight:  "<p>You must do this</p>",
+				Category: models.TRBGuidanceLetterInsightCategoryRequirement,
+			},
+		},
+	}
+
+	s.Run("groups insights by category in the order they're shown in the letter", func() {
+		document := newTRBGuidanceLetterDocument(input)
+
+		s.Equal("TRB guidance letter: Mock TRB Request", document.Title)
+		s.Equal("March 14, 2024", document.DateSent)
+		s.Len(document.InsightSections, 2)
+		s.Equal("Requirements", document.InsightSections[0].Heading)
+		s.Equal("Recommendations", document.InsightSections[1].Heading)
+		s.Equal("First recommendation", document.InsightSections[1].Insights[0].Title)
+		s.Equal([]string{"https://www.cms.gov"}, document.InsightSections[1].Insights[0].Links)
+		s.Equal("Second recommendation", document.InsightSections[1].Insights[1].Title)
+		s.True(document.IsFollowupRecommended)
+		s.Equal("In 6 months", document.FollowupPoint)
+	})
+
+	s.Run("renders a PDF", func() {
+		document, err := client.TRBGuidanceLetterPDF(input)
+		s.NoError(err)
+		s.True(bytes.HasPrefix(document, []byte("%PDF-")))
+	})
+}
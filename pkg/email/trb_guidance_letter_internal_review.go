@@ -47,6 +47,7 @@ func (c Client) SendTRBGuidanceLetterInternalReviewEmail(ctx context.Context, in
 	return c.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryTRBRequests).
 			WithToAddresses([]models.EmailAddress{c.config.TRBEmail}).
 			WithSubject(subject).
 			WithBody(b.String()),
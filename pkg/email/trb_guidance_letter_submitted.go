@@ -25,6 +25,9 @@ type SendTRBGuidanceLetterSubmittedEmailInput struct {
 	CopyTRBMailbox   bool
 	CopyITGovMailbox bool
 	Recipients       []models.EmailAddress
+	// Letter and its Insights are attached to the email as a PDF, if Letter is set
+	Letter   *models.TRBGuidanceLetter
+	Insights []*models.TRBGuidanceLetterInsight
 }
 
 // trbGuidanceLetterSubmittedEmailTemplateParams contains the data needed for interpolation in
@@ -41,6 +44,7 @@ type trbGuidanceLetterSubmittedEmailTemplateParams struct {
 	TRBRequestLink        string
 	TRBInboxAddress       string
 	TRBEmail              models.EmailAddress
+	LetterAttached        bool
 }
 
 // SendTRBGuidanceLetterSubmittedEmail sends an email to the EASI admin team indicating that a guidance letter
@@ -77,6 +81,7 @@ func (c Client) SendTRBGuidanceLetterSubmittedEmail(ctx context.Context, input S
 		TRBAdminRequestLink:   c.urlFromPath(path.Join("trb", input.TRBRequestID.String(), "request")),
 		TRBRequestLink:        c.urlFromPath(path.Join("trb", "task-list", input.TRBRequestID.String())),
 		TRBEmail:              c.config.TRBEmail,
+		LetterAttached:        input.Letter != nil,
 	}
 
 	var b bytes.Buffer
@@ -86,11 +91,26 @@ func (c Client) SendTRBGuidanceLetterSubmittedEmail(ctx context.Context, input S
 		return err
 	}
 
-	return c.sender.Send(
-		ctx,
-		NewEmail().
-			WithToAddresses(allRecipients).
-			WithSubject(subject).
-			WithBody(b.String()),
-	)
+	email := NewEmail().
+		WithToAddresses(allRecipients).
+		WithSubject(subject).
+		WithBody(b.String())
+
+	if input.Letter != nil {
+		document, err := c.TRBGuidanceLetterPDF(TRBGuidanceLetterDocumentInput{
+			RequestName:   input.RequestName,
+			RequestType:   input.RequestType,
+			RequesterName: input.RequesterName,
+			Component:     input.Component,
+			ConsultDate:   input.ConsultDate,
+			Letter:        input.Letter,
+			Insights:      input.Insights,
+		})
+		if err != nil {
+			return err
+		}
+		email = email.WithAttachment(TRBGuidanceLetterPDFAttachment(input.TRBRequestID, document))
+	}
+
+	return c.sender.Send(ctx, email)
 }
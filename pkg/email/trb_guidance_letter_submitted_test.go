@@ -1,6 +1,7 @@
 package email
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"path"
@@ -242,4 +243,30 @@ func (s *EmailTestSuite) TestTRBGuidanceLetterSubmittedEmail() {
 		s.ElementsMatch(sender.toAddresses, recipients)
 		s.EqualHTML(expectedBody, sender.body)
 	})
+
+	s.Run("attaches a PDF of the guidance letter when it's given", func() {
+		client, err := NewClient(s.config, &sender)
+		s.NoError(err)
+
+		input := SendTRBGuidanceLetterSubmittedEmailInput{
+			TRBRequestID:   trbID,
+			RequestName:    "Test TRB Request",
+			RequestType:    string(models.TRBTNeedHelp),
+			RequesterName:  "Mc Lovin",
+			SubmissionDate: &submissionDate,
+			ConsultDate:    &consultDate,
+			Recipients:     recipients,
+			Letter: &models.TRBGuidanceLetter{
+				MeetingSummary: models.HTMLPointer("<p>We met</p>"),
+			},
+		}
+		err = client.SendTRBGuidanceLetterSubmittedEmail(ctx, input)
+		s.NoError(err)
+
+		s.Contains(sender.body, "A copy of the guidance letter is also attached to this email.")
+		s.Len(sender.attachments, 1)
+		s.Equal(fmt.Sprintf("guidance_letter_%s.pdf", trbID), sender.attachments[0].Filename)
+		s.Equal("application/pdf", sender.attachments[0].ContentType)
+		s.True(bytes.HasPrefix(sender.attachments[0].Data, []byte("%PDF-")))
+	})
 }
@@ -75,6 +75,7 @@ func (c Client) SendTRBReadyForConsultNotification(
 	return c.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryTRBRequests).
 			WithToAddresses(allRecipients).
 			WithSubject(subject).
 			WithBody(body),
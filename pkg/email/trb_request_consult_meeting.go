@@ -20,8 +20,13 @@ type SendTRBRequestConsultMeetingEmailInput struct {
 	TRBRequestName     string
 	Notes              string
 	RequesterName      string
+	// CalendarInviteSequence is the sequence of the calendar invite attached to the email, from store.NextCalendarInviteSequence
+	CalendarInviteSequence int
 }
 
+// trbConsultMeetingDuration is how long the calendar invite for a TRB consult meeting blocks out
+const trbConsultMeetingDuration = time.Hour
+
 // trbConsultMeetingEmailTemplateParams contains the data needed for interpolation in the TRB consult meeting
 // email template
 type trbConsultMeetingEmailTemplateParams struct {
@@ -65,11 +70,32 @@ func (c Client) SendTRBRequestConsultMeetingEmail(ctx context.Context, input Sen
 		recipients = append(recipients, c.config.TRBEmail)
 	}
 
+	description := "TRB consult session for " + input.TRBRequestName
+	if input.Notes != "" {
+		description += "\n\n" + input.Notes
+	}
+	description += "\n\nView this request in EASi: " + templateParams.TRBRequestLink
+
+	invite := CalendarInvite{
+		UID:         c.TRBConsultMeetingInviteUID(input.TRBRequestID),
+		Sequence:    input.CalendarInviteSequence,
+		Method:      CalendarInviteMethodRequest,
+		Summary:     "TRB consult session for " + input.TRBRequestName,
+		Description: description,
+		URL:         templateParams.TRBRequestLink,
+		Start:       input.ConsultMeetingTime,
+		End:         input.ConsultMeetingTime.Add(trbConsultMeetingDuration),
+		Organizer:   c.config.TRBEmail,
+		Attendees:   recipients,
+	}
+
 	return c.sender.Send(
 		ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryTRBRequests).
 			WithToAddresses(recipients).
 			WithSubject(subject).
-			WithBody(b.String()),
+			WithBody(b.String()).
+			WithAttachment(invite.Attachment()),
 	)
 }
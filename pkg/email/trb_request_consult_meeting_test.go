@@ -56,7 +56,7 @@ func (s *EmailTestSuite) TestTRBRequestConsultMeetingEmail() {
 			`<h1 class="header-title">EASi</h1>
 			<p class="header-subtitle">Easy Access to System Information</p>
 
-			<p>The Technical Review Board (TRB) has scheduled a consult session for %s on %s. You should receive a calendar invite shortly if you have not already.</p>
+			<p>The Technical Review Board (TRB) has scheduled a consult session for %s on %s. Add it to your calendar using the attached invite, which will be updated if the consult session is rescheduled.</p>
 
 			%s
 
@@ -67,7 +67,7 @@ func (s *EmailTestSuite) TestTRBRequestConsultMeetingEmail() {
 				<li>%s and the project team should make sure to upload any documentation to EASi that should be reviewed as a part of this request.</li>
 				<li>Attendees may also review guidance about <a href="%s">preparing for the TRB consult session</a>.</li>
 				<li>TRB members may continue to review the initial request form and supporting documents in EASi.</li>
-				<li>If a calendar invite has not already been sent, the TRB lead will send one with a remote video conferencing meeting link.</li>
+				<li>If a remote video conferencing meeting link has not already been shared, the TRB lead will send one.</li>
 			  </ul>
 			</div>
 
@@ -116,6 +116,31 @@ func (s *EmailTestSuite) TestTRBRequestConsultMeetingEmail() {
 		s.EqualHTML(expectedBody, sender.body)
 	})
 
+	s.Run("attaches a calendar invite for the meeting", func() {
+		input := SendTRBRequestConsultMeetingEmailInput{
+			TRBRequestName:         "Test TRB Request",
+			ConsultMeetingTime:     meetingTime,
+			NotifyEmails:           []models.EmailAddress{"McLovin@example.com"},
+			Notes:                  "Some notes",
+			RequesterName:          "Mc Lovin",
+			TRBRequestID:           trbID,
+			CalendarInviteSequence: 3,
+		}
+		client, err := NewClient(s.config, &sender)
+		s.NoError(err)
+		err = client.SendTRBRequestConsultMeetingEmail(ctx, input)
+		s.NoError(err)
+
+		s.Len(sender.attachments, 1)
+		invite := string(sender.attachments[0].Data)
+		s.Equal("text/calendar; charset=UTF-8; method=REQUEST", sender.attachments[0].ContentType)
+		s.Contains(invite, "\r\nUID:"+client.TRBConsultMeetingInviteUID(trbID)+"\r\n")
+		s.Contains(invite, "\r\nSEQUENCE:3\r\n")
+		s.Contains(invite, "\r\nDTSTART:20220101T183300Z\r\n")
+		s.Contains(invite, "\r\nDTEND:20220101T193300Z\r\n")
+		s.Contains(invite, "\r\nATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:McLovi\r\n n@example.com\r\n")
+	})
+
 	s.Run("omits notes if blank", func() {
 		input := SendTRBRequestConsultMeetingEmailInput{
 			TRBRequestName:     "Test TRB Request",
@@ -76,6 +76,7 @@ func (c Client) sendTRBRequestTRBLeadAdminEmail(ctx context.Context, input SendT
 
 	return c.sender.Send(ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryTRBRequests).
 			WithToAddresses([]models.EmailAddress{c.config.TRBEmail}).
 			WithSubject(subject).
 			WithBody(b.String()),
@@ -104,6 +105,7 @@ func (c Client) sendTRBRequestTRBLeadAssigneeEmail(ctx context.Context, input Se
 
 	return c.sender.Send(ctx,
 		NewEmail().
+			WithNotificationCategory(models.NotificationCategoryTRBRequests).
 			WithToAddresses([]models.EmailAddress{input.TRBLeadEmail}).
 			WithSubject(subject).
 			WithBody(b.String()),
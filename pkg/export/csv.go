@@ -0,0 +1,67 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvTimeLayout is recognized as a date and time by spreadsheet applications when the file is opened
+const csvTimeLayout = "2006-01-02 15:04:05"
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) WriteRow(values ...any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		cell, err := csvCell(value)
+		if err != nil {
+			return err
+		}
+		record[i] = cell
+	}
+
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func csvCell(value any) (string, error) {
+	switch v := dereference(value).(type) {
+	case nil:
+		return "", nil
+	case string:
+		return escapeCSVFormula(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return v.UTC().Format(csvTimeLayout), nil
+	default:
+		return "", fmt.Errorf("unsupported export value of type %T", value)
+	}
+}
+
+// escapeCSVFormula prefixes text that a spreadsheet application would evaluate as a formula with a quote, so user entered
+// text can't run formulas when the export is opened
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
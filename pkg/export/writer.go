@@ -0,0 +1,90 @@
+// Package export writes tabular reports as CSV or XLSX spreadsheets, one row at a time so large reports can be streamed
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Format is the file format of an export
+type Format string
+
+const (
+	// FormatCSV exports comma separated values
+	FormatCSV Format = "csv"
+	// FormatXLSX exports an Excel workbook with a single sheet
+	FormatXLSX Format = "xlsx"
+)
+
+// IsValid returns whether the format is supported
+func (f Format) IsValid() bool {
+	switch f {
+	case FormatCSV, FormatXLSX:
+		return true
+	}
+	return false
+}
+
+// ContentType returns the MIME type of files in this format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Writer writes the rows of a report.
+// Values can be strings, integers, floats, times, or nil for an empty cell, and pointers to any of those
+type Writer interface {
+	WriteRow(values ...any) error
+	// Close finishes writing the report, but does not close the underlying io.Writer
+	Close() error
+}
+
+// NewWriter returns a Writer that writes the report to w in the given format.
+// sheetName is the name of the worksheet in formats that have them
+func NewWriter(format Format, w io.Writer, sheetName string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, sheetName)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// dereference returns the value a pointer points to, or nil for a nil pointer, so writers only have to handle plain values
+func dereference(value any) any {
+	switch v := value.(type) {
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *int:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *int64:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *float64:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return *v
+	}
+	return value
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
+
+func (s *ExportTestSuite) TestCSVWriter() {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatCSV, &buf, "Ignored")
+	s.NoError(err)
+
+	cost := int64(1200)
+	var missing *string
+	submittedAt := time.Date(2024, time.March, 4, 15, 30, 0, 0, time.UTC)
+
+	s.NoError(w.WriteRow("Name", "Cost", "Submitted at", "Notes"))
+	s.NoError(w.WriteRow("Project, with comma", &cost, &submittedAt, missing))
+	s.NoError(w.WriteRow("=HYPERLINK(\"http://example.com\")", 3, nil, "-1"))
+	s.NoError(w.Close())
+
+	s.Equal(
+		"Name,Cost,Submitted at,Notes\n"+
+			"\"Project, with comma\",1200,2024-03-04 15:30:00,\n"+
+			"\"'=HYPERLINK(\"\"http://example.com\"\")\",3,,'-1\n",
+		buf.String(),
+	)
+
+	s.Error(w.WriteRow(struct{}{}))
+}
+
+func (s *ExportTestSuite) TestXLSXWriter() {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &buf, "System intakes & costs")
+	s.NoError(err)
+
+	submittedAt := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	s.NoError(w.WriteRow("Name", "Cost", "Submitted at"))
+	s.NoError(w.WriteRow("<Project> & \"friends\"", int64(1200), &submittedAt))
+	s.NoError(w.Close())
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	s.NoError(err)
+
+	parts := map[string]string{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		s.NoError(err)
+		content, err := io.ReadAll(rc)
+		s.NoError(err)
+		s.NoError(rc.Close())
+		parts[file.Name] = string(content)
+	}
+
+	s.Contains(parts, "[Content_Types].xml")
+	s.Contains(parts, "_rels/.rels")
+	s.Contains(parts, "xl/_rels/workbook.xml.rels")
+	s.Contains(parts, "xl/styles.xml")
+	s.Contains(parts["xl/workbook.xml"], `<sheet name="System intakes &amp; costs"`)
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	s.Contains(sheet, `<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c>`)
+	s.Contains(sheet, `<t xml:space="preserve">&lt;Project&gt; &amp; &#34;friends&#34;</t>`)
+	s.Contains(sheet, `<c r="B2"><v>1200</v></c>`)
+	s.Contains(sheet, `<c r="C2" s="1"><v>45292.5</v></c>`)
+	s.Contains(sheet, `</sheetData></worksheet>`)
+}
+
+func (s *ExportTestSuite) TestXLSXColumnName() {
+	s.Equal("A", xlsxColumnName(0))
+	s.Equal("Z", xlsxColumnName(25))
+	s.Equal("AA", xlsxColumnName(26))
+	s.Equal("AZ", xlsxColumnName(51))
+	s.Equal("BA", xlsxColumnName(52))
+}
+
+func (s *ExportTestSuite) TestFormat() {
+	s.True(FormatCSV.IsValid())
+	s.True(FormatXLSX.IsValid())
+	s.False(Format("pdf").IsValid())
+
+	_, err := NewWriter(Format("pdf"), io.Discard, "")
+	s.Error(err)
+}
@@ -0,0 +1,72 @@
+package subscribers
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+)
+
+// GRBVotingInformationChangedSubscriber is a Subscriber definition to receive GRBVotingInformationChangedEvent payloads
+type GRBVotingInformationChangedSubscriber struct {
+	ID             uuid.UUID
+	Principal      authentication.Principal
+	SystemIntakeID uuid.UUID
+	Channel        chan *models.GRBVotingInformationChangedEvent
+	Logger         *zap.Logger
+}
+
+// NewGRBVotingInformationChangedSubscriber is a constructor to create a new GRBVotingInformationChangedSubscriber
+func NewGRBVotingInformationChangedSubscriber(principal authentication.Principal, systemIntakeID uuid.UUID, logger *zap.Logger) *GRBVotingInformationChangedSubscriber {
+	// Guard against nil logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &GRBVotingInformationChangedSubscriber{
+		ID:             uuid.New(),
+		Principal:      principal,
+		SystemIntakeID: systemIntakeID,
+		Channel:        make(chan *models.GRBVotingInformationChangedEvent, 10), // Buffered to prevent blocking publishers
+		Logger:         logger,
+	}
+}
+
+// GetID returns this Subscriber's unique identifying token
+func (s *GRBVotingInformationChangedSubscriber) GetID() string {
+	return s.ID.String()
+}
+
+// GetPrincipal returns this Subscriber's associated principal
+func (s *GRBVotingInformationChangedSubscriber) GetPrincipal() authentication.Principal {
+	return s.Principal
+}
+
+// Notify will be called by the PubSub service when an event this Subscriber is registered for is dispatched
+func (s *GRBVotingInformationChangedSubscriber) Notify(payload interface{}) {
+	typedPayload, ok := payload.(models.GRBVotingInformationChangedEvent)
+
+	// Log error if invalid payload type
+	if !ok {
+		s.Logger.Error("Invalid payload type in Notify",
+			zap.String("expected", "GRBVotingInformationChangedEvent"),
+			zap.String("got", fmt.Sprintf("%T", payload)),
+		)
+		return
+	}
+
+	s.Channel <- &typedPayload
+}
+
+// NotifyUnsubscribed will be called by the PubSub service when this Subscriber is unsubscribed
+func (s *GRBVotingInformationChangedSubscriber) NotifyUnsubscribed(ps pubsub.PubSub, sessionID uuid.UUID) {
+}
+
+// GetChannel provides this Subscriber's feedback channel
+func (s *GRBVotingInformationChangedSubscriber) GetChannel() <-chan *models.GRBVotingInformationChangedEvent {
+	return s.Channel
+}
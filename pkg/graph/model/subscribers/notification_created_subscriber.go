@@ -0,0 +1,70 @@
+package subscribers
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+)
+
+// NotificationCreatedSubscriber is a Subscriber definition to receive NotificationCreatedEvent payloads
+type NotificationCreatedSubscriber struct {
+	ID        uuid.UUID
+	Principal authentication.Principal
+	Channel   chan *models.NotificationCreatedEvent
+	Logger    *zap.Logger
+}
+
+// NewNotificationCreatedSubscriber is a constructor to create a new NotificationCreatedSubscriber
+func NewNotificationCreatedSubscriber(principal authentication.Principal, logger *zap.Logger) *NotificationCreatedSubscriber {
+	// Guard against nil logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &NotificationCreatedSubscriber{
+		ID:        uuid.New(),
+		Principal: principal,
+		Channel:   make(chan *models.NotificationCreatedEvent, 10), // Buffered to prevent blocking publishers
+		Logger:    logger,
+	}
+}
+
+// GetID returns this Subscriber's unique identifying token
+func (s *NotificationCreatedSubscriber) GetID() string {
+	return s.ID.String()
+}
+
+// GetPrincipal returns this Subscriber's associated principal
+func (s *NotificationCreatedSubscriber) GetPrincipal() authentication.Principal {
+	return s.Principal
+}
+
+// Notify will be called by the PubSub service when an event this Subscriber is registered for is dispatched
+func (s *NotificationCreatedSubscriber) Notify(payload interface{}) {
+	typedPayload, ok := payload.(models.NotificationCreatedEvent)
+
+	// Log error if invalid payload type
+	if !ok {
+		s.Logger.Error("Invalid payload type in Notify",
+			zap.String("expected", "NotificationCreatedEvent"),
+			zap.String("got", fmt.Sprintf("%T", payload)),
+		)
+		return
+	}
+
+	s.Channel <- &typedPayload
+}
+
+// NotifyUnsubscribed will be called by the PubSub service when this Subscriber is unsubscribed
+func (s *NotificationCreatedSubscriber) NotifyUnsubscribed(ps pubsub.PubSub, sessionID uuid.UUID) {
+}
+
+// GetChannel provides this Subscriber's feedback channel
+func (s *NotificationCreatedSubscriber) GetChannel() <-chan *models.NotificationCreatedEvent {
+	return s.Channel
+}
@@ -0,0 +1,85 @@
+package subscribers
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+)
+
+// SystemIntakeGRBDiscussionChangedSubscriber is a Subscriber definition to receive SystemIntakeGRBDiscussionChanged payloads
+type SystemIntakeGRBDiscussionChangedSubscriber struct {
+	ID             uuid.UUID
+	Principal      authentication.Principal
+	SystemIntakeID uuid.UUID
+	// CanViewInternalBoard determines if changes to the Internal discussion board are sent to this Subscriber
+	CanViewInternalBoard bool
+	Channel              chan *models.SystemIntakeGRBDiscussionChanged
+	Logger               *zap.Logger
+}
+
+// NewSystemIntakeGRBDiscussionChangedSubscriber is a constructor to create a new SystemIntakeGRBDiscussionChangedSubscriber
+func NewSystemIntakeGRBDiscussionChangedSubscriber(
+	principal authentication.Principal,
+	systemIntakeID uuid.UUID,
+	canViewInternalBoard bool,
+	logger *zap.Logger,
+) *SystemIntakeGRBDiscussionChangedSubscriber {
+	// Guard against nil logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &SystemIntakeGRBDiscussionChangedSubscriber{
+		ID:                   uuid.New(),
+		Principal:            principal,
+		SystemIntakeID:       systemIntakeID,
+		CanViewInternalBoard: canViewInternalBoard,
+		Channel:              make(chan *models.SystemIntakeGRBDiscussionChanged, 10), // Buffered to prevent blocking publishers
+		Logger:               logger,
+	}
+}
+
+// GetID returns this Subscriber's unique identifying token
+func (s *SystemIntakeGRBDiscussionChangedSubscriber) GetID() string {
+	return s.ID.String()
+}
+
+// GetPrincipal returns this Subscriber's associated principal
+func (s *SystemIntakeGRBDiscussionChangedSubscriber) GetPrincipal() authentication.Principal {
+	return s.Principal
+}
+
+// Notify will be called by the PubSub service when an event this Subscriber is registered for is dispatched
+func (s *SystemIntakeGRBDiscussionChangedSubscriber) Notify(payload interface{}) {
+	typedPayload, ok := payload.(models.SystemIntakeGRBDiscussionChanged)
+
+	// Log error if invalid payload type
+	if !ok {
+		s.Logger.Error("Invalid payload type in Notify",
+			zap.String("expected", "SystemIntakeGRBDiscussionChanged"),
+			zap.String("got", fmt.Sprintf("%T", payload)),
+		)
+		return
+	}
+
+	// Internal board changes are only sent to subscribers allowed to view the Internal board
+	if typedPayload.DiscussionBoardType == models.SystemIntakeGRBDiscussionBoardTypeInternal && !s.CanViewInternalBoard {
+		return
+	}
+
+	s.Channel <- &typedPayload
+}
+
+// NotifyUnsubscribed will be called by the PubSub service when this Subscriber is unsubscribed
+func (s *SystemIntakeGRBDiscussionChangedSubscriber) NotifyUnsubscribed(ps pubsub.PubSub, sessionID uuid.UUID) {
+}
+
+// GetChannel provides this Subscriber's feedback channel
+func (s *SystemIntakeGRBDiscussionChangedSubscriber) GetChannel() <-chan *models.SystemIntakeGRBDiscussionChanged {
+	return s.Channel
+}
@@ -72,7 +72,7 @@ func (s *SystemProfileLockChangedSubscriber) Notify(payload interface{}) {
 }
 
 // NotifyUnsubscribed will be called by the PubSub service when this Subscriber is unsubscribed
-func (s *SystemProfileLockChangedSubscriber) NotifyUnsubscribed(ps *pubsub.ServicePubSub, sessionID uuid.UUID) {
+func (s *SystemProfileLockChangedSubscriber) NotifyUnsubscribed(ps pubsub.PubSub, sessionID uuid.UUID) {
 	if s.onUnsubscribed != nil {
 		s.onUnsubscribed(ps, s)
 	}
@@ -0,0 +1,100 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// cedarSystemProfileAuditEntityTypes are the types of CEDAR record whose changes are recorded when they're edited through the system profile
+var cedarSystemProfileAuditEntityTypes = []models.AuditEntityType{
+	models.AuditEntityTypeCedarDeployment,
+	models.AuditEntityTypeCedarExchange,
+	models.AuditEntityTypeCedarContract,
+	models.AuditEntityTypeCedarBudget,
+	models.AuditEntityTypeCedarURL,
+}
+
+// GetAuditHistory returns a page of the changes made to an entity and the entities that belong to it, newest first.
+// Only the types of entity the principal administers are included
+func GetAuditHistory(ctx context.Context, store *storage.Store, entityID uuid.UUID, first int, after *string) (*models.AuditChangeConnection, error) {
+	principal := appcontext.Principal(ctx)
+
+	var entityTypes []models.AuditEntityType
+	if principal.AllowGRT() {
+		entityTypes = append(entityTypes, models.AuditEntityTypeSystemIntake, models.AuditEntityTypeBusinessCase)
+	}
+	if principal.AllowTRBAdmin() {
+		entityTypes = append(entityTypes, models.AuditEntityTypeTrbRequestForm)
+	}
+	// CEDAR system profiles can be read by any EASi user, so the changes made to them can be too
+	if principal.AllowEASi() {
+		entityTypes = append(entityTypes, cedarSystemProfileAuditEntityTypes...)
+	}
+	if len(entityTypes) == 0 {
+		return nil, &apperrors.UnauthorizedError{Err: errors.New("unauthorized to fetch audit history")}
+	}
+
+	cursor, err := decodePageArgs(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, hasNextPage, err := fetchPage(
+		first,
+		cursor,
+		func(limit int, after *models.PageCursor) ([]*models.AuditChange, error) {
+			return store.GetAuditChangesByEntityID(ctx, entityID, entityTypes, limit, after)
+		},
+		(*models.AuditChange).Cursor,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*models.AuditChangeEdge, len(changes))
+	cursors := make([]string, len(changes))
+	for i, change := range changes {
+		cursors[i] = change.Cursor().Encode()
+		edges[i] = &models.AuditChangeEdge{
+			Cursor: cursors[i],
+			Node:   change,
+		}
+	}
+
+	return &models.AuditChangeConnection{
+		Edges:    edges,
+		PageInfo: newPageInfo(cursors, hasNextPage),
+	}, nil
+}
+
+// recordCEDARSystemProfileChange records a change made to one of a CEDAR system's records in the audit history. The change has already been
+// made in CEDAR by the time it's recorded, so a failure to record it is logged rather than returned; returning it would tell the user the
+// change failed, and retrying it would then be rejected as a conflict.
+func recordCEDARSystemProfileChange(
+	ctx context.Context,
+	store *storage.Store,
+	entityType models.AuditEntityType,
+	action models.AuditChangeAction,
+	cedarSystemID uuid.UUID,
+	externalID string,
+	before any,
+	after any,
+) {
+	if err := store.RecordCEDARSystemProfileChange(ctx, entityType, action, cedarSystemID, externalID, before, after); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to record change to CEDAR system profile",
+			zap.Error(err),
+			zap.String("entityType", string(entityType)),
+			zap.String("cedarSystemID", cedarSystemID.String()),
+			zap.String("externalID", externalID),
+		)
+	}
+}
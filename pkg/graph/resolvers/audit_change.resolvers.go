@@ -0,0 +1,30 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/graph/generated"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// Fields is the resolver for the fields field.
+func (r *auditChangeResolver) Fields(ctx context.Context, obj *models.AuditChange) ([]*models.AuditFieldChange, error) {
+	return lo.ToSlicePtr(obj.Fields), nil
+}
+
+// AuditHistory is the resolver for the auditHistory field.
+func (r *queryResolver) AuditHistory(ctx context.Context, entityID uuid.UUID, first int, after *string) (*models.AuditChangeConnection, error) {
+	return GetAuditHistory(ctx, r.store, entityID, first, after)
+}
+
+// AuditChange returns generated.AuditChangeResolver implementation.
+func (r *Resolver) AuditChange() generated.AuditChangeResolver { return &auditChangeResolver{r} }
+
+type auditChangeResolver struct{ *Resolver }
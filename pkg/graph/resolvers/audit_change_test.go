@@ -0,0 +1,88 @@
+package resolvers
+
+import (
+	"time"
+
+	"github.com/guregu/null"
+
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+func (s *ResolverSuite) TestGetAuditHistory() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+
+	intake := s.createNewIntake()
+
+	intake.ProjectName = null.StringFrom("Updated project name")
+	_, err := store.UpdateSystemIntake(ctx, intake)
+	s.NoError(err)
+
+	s.Run("returns the newest changes first, attributed to the principal", func() {
+		history, err := GetAuditHistory(ctx, store, intake.ID, 25, nil)
+		s.NoError(err)
+		s.Len(history.Edges, 2)
+		s.False(history.PageInfo.HasNextPage)
+
+		update := history.Edges[0].Node
+		s.Equal(models.AuditChangeActionUpdate, update.Action)
+		s.Equal(models.AuditEntityTypeSystemIntake, update.EntityType)
+		s.Equal(s.testConfigs.Principal.Account().ID, *update.ModifiedBy)
+
+		s.Len(update.Fields, 1)
+		s.Equal("projectName", update.Fields[0].FieldName)
+		s.Equal(`"TEST"`, *update.Fields[0].OldValue())
+		s.Equal(`"Updated project name"`, *update.Fields[0].NewValue())
+
+		s.Equal(models.AuditChangeActionInsert, history.Edges[1].Node.Action)
+	})
+
+	s.Run("pages through the history with the end cursor", func() {
+		firstPage, err := GetAuditHistory(ctx, store, intake.ID, 1, nil)
+		s.NoError(err)
+		s.Len(firstPage.Edges, 1)
+		s.True(firstPage.PageInfo.HasNextPage)
+		s.Equal(models.AuditChangeActionUpdate, firstPage.Edges[0].Node.Action)
+
+		secondPage, err := GetAuditHistory(ctx, store, intake.ID, 1, firstPage.PageInfo.EndCursor)
+		s.NoError(err)
+		s.Len(secondPage.Edges, 1)
+		s.False(secondPage.PageInfo.HasNextPage)
+		s.Equal(models.AuditChangeActionInsert, secondPage.Edges[0].Node.Action)
+	})
+
+	s.Run("does not record updates that change nothing", func() {
+		_, err := store.UpdateSystemIntake(ctx, intake)
+		s.NoError(err)
+
+		history, err := GetAuditHistory(ctx, store, intake.ID, 25, nil)
+		s.NoError(err)
+		s.Len(history.Edges, 2)
+	})
+
+	s.Run("does not record GRB reviewer reminders", func() {
+		err := storage.SetSystemIntakeGRBReviewerReminderSent(ctx, store, intake.ID, time.Now())
+		s.NoError(err)
+
+		history, err := GetAuditHistory(ctx, store, intake.ID, 25, nil)
+		s.NoError(err)
+		s.Len(history.Edges, 2)
+	})
+
+	s.Run("rejects invalid page sizes and cursors", func() {
+		_, err := GetAuditHistory(ctx, store, intake.ID, 0, nil)
+		s.Error(err)
+
+		_, err = GetAuditHistory(ctx, store, intake.ID, 25, helpers.PointerTo("not a cursor"))
+		s.Error(err)
+	})
+
+	s.Run("requires an admin", func() {
+		requesterCtx, _ := s.getTestContextWithPrincipal("USR1", false)
+
+		_, err := GetAuditHistory(requesterCtx, store, intake.ID, 25, nil)
+		s.Error(err)
+	})
+}
@@ -0,0 +1,109 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/guregu/null/zero"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// AddCedarBudget adds a budget to a CEDAR system and returns the system's budgets
+func AddCedarBudget(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.AddCedarBudgetInput,
+) ([]*models.CedarBudget, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionFundingAndBudget); err != nil {
+		return nil, err
+	}
+
+	if err := validateCedarBudgetInput(input.Budget); err != nil {
+		return nil, err
+	}
+
+	budget := &models.CedarBudget{}
+	applyCedarBudgetInput(budget, input.Budget)
+	added, err := cedarCoreClient.AddBudget(ctx, input.CedarSystemID, budget)
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarBudget, models.AuditChangeActionInsert, input.CedarSystemID, added.ID.String, nil, added)
+
+	return cedarCoreClient.GetBudgetBySystem(ctx, input.CedarSystemID)
+}
+
+// UpdateCedarBudget edits one of a CEDAR system's budgets and returns the system's budgets
+func UpdateCedarBudget(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.UpdateCedarBudgetInput,
+) ([]*models.CedarBudget, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionFundingAndBudget); err != nil {
+		return nil, err
+	}
+
+	if err := validateCedarBudgetInput(input.Budget); err != nil {
+		return nil, err
+	}
+
+	var previous models.CedarBudget
+	updated, err := cedarCoreClient.UpdateBudget(ctx, input.CedarSystemID, input.BudgetID, input.ConcurrencyToken, func(budget *models.CedarBudget) {
+		previous = *budget
+		applyCedarBudgetInput(budget, input.Budget)
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarBudget, models.AuditChangeActionUpdate, input.CedarSystemID, input.BudgetID, &previous, updated)
+
+	return cedarCoreClient.GetBudgetBySystem(ctx, input.CedarSystemID)
+}
+
+// DeleteCedarBudget removes one of a CEDAR system's budgets and returns the system's remaining budgets
+func DeleteCedarBudget(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.DeleteCedarBudgetInput,
+) ([]*models.CedarBudget, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionFundingAndBudget); err != nil {
+		return nil, err
+	}
+
+	deleted, err := cedarCoreClient.DeleteBudget(ctx, input.CedarSystemID, input.BudgetID, input.ConcurrencyToken)
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarBudget, models.AuditChangeActionDelete, input.CedarSystemID, input.BudgetID, deleted, nil)
+
+	return cedarCoreClient.GetBudgetBySystem(ctx, input.CedarSystemID)
+}
+
+func validateCedarBudgetInput(input *models.CedarBudgetInput) error {
+	if strings.TrimSpace(input.ProjectID) == "" {
+		return &apperrors.BadRequestError{Err: errors.New("budget project ID is required")}
+	}
+
+	return nil
+}
+
+// applyCedarBudgetInput sets the editable fields of a budget from the input. The project's details are only kept if the project is
+// unchanged, since CEDAR fills them in from the project.
+func applyCedarBudgetInput(budget *models.CedarBudget, input *models.CedarBudgetInput) {
+	projectID := strings.TrimSpace(input.ProjectID)
+	if budget.ProjectID.String != projectID {
+		budget.ProjectTitle = zero.StringFrom("")
+		budget.FundingSource = zero.StringFrom("")
+		budget.Name = zero.StringFrom("")
+	}
+	budget.ProjectID = zero.StringFrom(projectID)
+	budget.Funding = zero.StringFromPtr(input.Funding)
+}
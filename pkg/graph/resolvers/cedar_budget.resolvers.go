@@ -0,0 +1,26 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// AddCedarBudget is the resolver for the addCedarBudget field.
+func (r *mutationResolver) AddCedarBudget(ctx context.Context, input models.AddCedarBudgetInput) ([]*models.CedarBudget, error) {
+	return AddCedarBudget(ctx, r.store, r.cedarCoreClient, input)
+}
+
+// UpdateCedarBudget is the resolver for the updateCedarBudget field.
+func (r *mutationResolver) UpdateCedarBudget(ctx context.Context, input models.UpdateCedarBudgetInput) ([]*models.CedarBudget, error) {
+	return UpdateCedarBudget(ctx, r.store, r.cedarCoreClient, input)
+}
+
+// DeleteCedarBudget is the resolver for the deleteCedarBudget field.
+func (r *mutationResolver) DeleteCedarBudget(ctx context.Context, input models.DeleteCedarBudgetInput) ([]*models.CedarBudget, error) {
+	return DeleteCedarBudget(ctx, r.store, r.cedarCoreClient, input)
+}
@@ -0,0 +1,81 @@
+package resolvers
+
+import (
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+)
+
+func (s *ResolverSuite) TestCedarBudgetWrites() {
+	store := s.testConfigs.Store
+	cedarCoreClient := s.cedarMutationResolver().cedarCoreClient
+	ps := pubsub.NewServicePubSub()
+	cedarSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC0A}")
+	section := models.SystemProfileLockableSectionFundingAndBudget
+
+	teamMemberCtx, teamMember := s.getTestContextWithPrincipal("ABCD", false)
+
+	addInput := models.AddCedarBudgetInput{
+		CedarSystemID: cedarSystemID,
+		Budget: &models.CedarBudgetInput{
+			ProjectID: "RESOLVER-TEST-PROJECT",
+			Funding:   lo.ToPtr("Most of this funding is directly and only for this system (over 80%)"),
+		},
+	}
+	findBudget := func(budgets []*models.CedarBudget) *models.CedarBudget {
+		budget, _ := lo.Find(budgets, func(budget *models.CedarBudget) bool {
+			return budget.ProjectID.String == "RESOLVER-TEST-PROJECT"
+		})
+		return budget
+	}
+
+	s.Run("budgets can't be edited without holding the section lock", func() {
+		_, err := AddCedarBudget(teamMemberCtx, store, cedarCoreClient, addInput)
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+	})
+
+	locked, err := LockSystemProfileSection(teamMemberCtx, store, ps, cedarSystemID, section, teamMember)
+	s.NoError(err)
+	s.True(locked)
+
+	s.Run("budgets must have a project", func() {
+		_, err := AddCedarBudget(teamMemberCtx, store, cedarCoreClient, models.AddCedarBudgetInput{
+			CedarSystemID: cedarSystemID,
+			Budget:        &models.CedarBudgetInput{ProjectID: " "},
+		})
+		var badRequestErr *apperrors.BadRequestError
+		s.ErrorAs(err, &badRequestErr)
+	})
+
+	s.Run("the lock holder can add, edit, and remove budgets", func() {
+		budgets, err := AddCedarBudget(teamMemberCtx, store, cedarCoreClient, addInput)
+		s.NoError(err)
+		added := findBudget(budgets)
+		s.NotNil(added)
+
+		budgets, err = UpdateCedarBudget(teamMemberCtx, store, cedarCoreClient, models.UpdateCedarBudgetInput{
+			CedarSystemID:    cedarSystemID,
+			BudgetID:         added.ID.String,
+			ConcurrencyToken: added.ConcurrencyToken(),
+			Budget: &models.CedarBudgetInput{
+				ProjectID: "RESOLVER-TEST-PROJECT",
+				Funding:   lo.ToPtr("Only part of this funding is directly for this system (less than 40%)"),
+			},
+		})
+		s.NoError(err)
+		updated := findBudget(budgets)
+		s.EqualValues("Only part of this funding is directly for this system (less than 40%)", updated.Funding.String)
+
+		budgets, err = DeleteCedarBudget(teamMemberCtx, store, cedarCoreClient, models.DeleteCedarBudgetInput{
+			CedarSystemID:    cedarSystemID,
+			BudgetID:         updated.ID.String,
+			ConcurrencyToken: updated.ConcurrencyToken(),
+		})
+		s.NoError(err)
+		s.Nil(findBudget(budgets))
+	})
+}
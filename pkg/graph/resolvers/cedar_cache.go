@@ -0,0 +1,20 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
+)
+
+// InvalidateCedarCache clears cached CEDAR Core responses, either for a single system or, if no system is given, all of them
+func InvalidateCedarCache(ctx context.Context, cedarCoreClient *cedarcore.Client, cedarSystemID *uuid.UUID) (bool, error) {
+	if cedarSystemID == nil {
+		cedarCoreClient.InvalidateCache(ctx)
+		return true, nil
+	}
+
+	cedarCoreClient.InvalidateSystemCache(ctx, *cedarSystemID)
+	return true, nil
+}
@@ -0,0 +1,113 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// AddCedarContract adds a contract to a CEDAR system and returns the system's contracts
+func AddCedarContract(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.AddCedarContractInput,
+) ([]*models.CedarContract, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionContracts); err != nil {
+		return nil, err
+	}
+
+	if err := validateCedarContractInput(input.Contract); err != nil {
+		return nil, err
+	}
+
+	contract := &models.CedarContract{}
+	applyCedarContractInput(contract, input.Contract)
+	added, err := cedarCoreClient.AddContract(ctx, input.CedarSystemID, contract)
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarContract, models.AuditChangeActionInsert, input.CedarSystemID, added.ID.String, nil, added)
+
+	return cedarCoreClient.GetContractBySystem(ctx, input.CedarSystemID)
+}
+
+// UpdateCedarContract edits one of a CEDAR system's contracts and returns the system's contracts
+func UpdateCedarContract(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.UpdateCedarContractInput,
+) ([]*models.CedarContract, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionContracts); err != nil {
+		return nil, err
+	}
+
+	if err := validateCedarContractInput(input.Contract); err != nil {
+		return nil, err
+	}
+
+	var previous models.CedarContract
+	updated, err := cedarCoreClient.UpdateContract(ctx, input.CedarSystemID, input.ContractID, input.ConcurrencyToken, func(contract *models.CedarContract) {
+		previous = *contract
+		applyCedarContractInput(contract, input.Contract)
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarContract, models.AuditChangeActionUpdate, input.CedarSystemID, input.ContractID, &previous, updated)
+
+	return cedarCoreClient.GetContractBySystem(ctx, input.CedarSystemID)
+}
+
+// DeleteCedarContract removes one of a CEDAR system's contracts and returns the system's remaining contracts
+func DeleteCedarContract(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.DeleteCedarContractInput,
+) ([]*models.CedarContract, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionContracts); err != nil {
+		return nil, err
+	}
+
+	deleted, err := cedarCoreClient.DeleteContract(ctx, input.CedarSystemID, input.ContractID, input.ConcurrencyToken)
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarContract, models.AuditChangeActionDelete, input.CedarSystemID, input.ContractID, deleted, nil)
+
+	return cedarCoreClient.GetContractBySystem(ctx, input.CedarSystemID)
+}
+
+func validateCedarContractInput(input *models.CedarContractInput) error {
+	if strings.TrimSpace(input.ContractNumber) == "" {
+		return &apperrors.BadRequestError{Err: errors.New("contract number is required")}
+	}
+
+	if input.StartDate != nil && input.EndDate != nil && input.EndDate.Before(*input.StartDate) {
+		return &apperrors.BadRequestError{Err: errors.New("contract end date must not be before its start date")}
+	}
+
+	return nil
+}
+
+// applyCedarContractInput sets the editable fields of a contract from the input
+func applyCedarContractInput(contract *models.CedarContract, input *models.CedarContractInput) {
+	contract.ContractNumber = zero.StringFrom(strings.TrimSpace(input.ContractNumber))
+	contract.OrderNumber = zero.StringFromPtr(input.OrderNumber)
+	contract.ContractName = zero.StringFromPtr(input.ContractName)
+	contract.Description = zero.StringFromPtr(input.Description)
+	contract.ServiceProvided = zero.StringFromPtr(input.ServiceProvided)
+	contract.IsDeliveryOrg = lo.FromPtr(input.IsDeliveryOrg)
+	contract.StartDate = zero.TimeFromPtr(input.StartDate)
+	contract.EndDate = zero.TimeFromPtr(input.EndDate)
+}
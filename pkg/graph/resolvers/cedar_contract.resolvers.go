@@ -0,0 +1,26 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// AddCedarContract is the resolver for the addCedarContract field.
+func (r *mutationResolver) AddCedarContract(ctx context.Context, input models.AddCedarContractInput) ([]*models.CedarContract, error) {
+	return AddCedarContract(ctx, r.store, r.cedarCoreClient, input)
+}
+
+// UpdateCedarContract is the resolver for the updateCedarContract field.
+func (r *mutationResolver) UpdateCedarContract(ctx context.Context, input models.UpdateCedarContractInput) ([]*models.CedarContract, error) {
+	return UpdateCedarContract(ctx, r.store, r.cedarCoreClient, input)
+}
+
+// DeleteCedarContract is the resolver for the deleteCedarContract field.
+func (r *mutationResolver) DeleteCedarContract(ctx context.Context, input models.DeleteCedarContractInput) ([]*models.CedarContract, error) {
+	return DeleteCedarContract(ctx, r.store, r.cedarCoreClient, input)
+}
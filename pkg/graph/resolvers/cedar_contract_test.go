@@ -0,0 +1,135 @@
+package resolvers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+)
+
+func (s *ResolverSuite) TestCedarContractWrites() {
+	store := s.testConfigs.Store
+	cedarCoreClient := s.cedarMutationResolver().cedarCoreClient
+	ps := pubsub.NewServicePubSub()
+	cedarSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC0A}")
+	section := models.SystemProfileLockableSectionContracts
+
+	teamMemberCtx, teamMember := s.getTestContextWithPrincipal("ABCD", false)
+	otherUserCtx, otherUser := s.getTestContextWithPrincipal("ZZZZ", false)
+
+	addInput := models.AddCedarContractInput{
+		CedarSystemID: cedarSystemID,
+		Contract: &models.CedarContractInput{
+			ContractNumber: "RESOLVER-TEST-CONTRACT",
+			ContractName:   lo.ToPtr("Resolver Test Contract"),
+		},
+	}
+	findContract := func(contracts []*models.CedarContract) *models.CedarContract {
+		contract, _ := lo.Find(contracts, func(contract *models.CedarContract) bool {
+			return contract.ContractNumber.String == "RESOLVER-TEST-CONTRACT"
+		})
+		return contract
+	}
+
+	s.Run("contracts can't be edited without holding the section lock", func() {
+		_, err := AddCedarContract(teamMemberCtx, store, cedarCoreClient, addInput)
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+	})
+
+	s.Run("users who aren't on the system's team can't edit contracts", func() {
+		locked, err := LockSystemProfileSection(otherUserCtx, store, ps, cedarSystemID, section, otherUser)
+		s.NoError(err)
+		s.True(locked)
+
+		_, err = AddCedarContract(otherUserCtx, store, cedarCoreClient, addInput)
+		var unauthorizedErr *apperrors.UnauthorizedError
+		s.ErrorAs(err, &unauthorizedErr)
+
+		_, err = UnlockSystemProfileSection(otherUserCtx, store, ps, cedarSystemID, section, otherUser.Account().ID)
+		s.NoError(err)
+	})
+
+	locked, err := LockSystemProfileSection(teamMemberCtx, store, ps, cedarSystemID, section, teamMember)
+	s.NoError(err)
+	s.True(locked)
+
+	s.Run("contracts must have a contract number and end after they start", func() {
+		var badRequestErr *apperrors.BadRequestError
+
+		_, err := AddCedarContract(teamMemberCtx, store, cedarCoreClient, models.AddCedarContractInput{
+			CedarSystemID: cedarSystemID,
+			Contract:      &models.CedarContractInput{ContractNumber: " "},
+		})
+		s.ErrorAs(err, &badRequestErr)
+
+		_, err = AddCedarContract(teamMemberCtx, store, cedarCoreClient, models.AddCedarContractInput{
+			CedarSystemID: cedarSystemID,
+			Contract: &models.CedarContractInput{
+				ContractNumber: "RESOLVER-TEST-CONTRACT",
+				StartDate:      lo.ToPtr(time.Now()),
+				EndDate:        lo.ToPtr(time.Now().AddDate(0, -1, 0)),
+			},
+		})
+		s.ErrorAs(err, &badRequestErr)
+	})
+
+	s.Run("the lock holder can add, edit, and remove contracts, and the changes are in the system's audit history", func() {
+		contracts, err := AddCedarContract(teamMemberCtx, store, cedarCoreClient, addInput)
+		s.NoError(err)
+		added := findContract(contracts)
+		s.NotNil(added)
+
+		contracts, err = UpdateCedarContract(teamMemberCtx, store, cedarCoreClient, models.UpdateCedarContractInput{
+			CedarSystemID:    cedarSystemID,
+			ContractID:       added.ID.String,
+			ConcurrencyToken: added.ConcurrencyToken(),
+			Contract: &models.CedarContractInput{
+				ContractNumber: "RESOLVER-TEST-CONTRACT",
+				ContractName:   lo.ToPtr("Resolver Test Contract"),
+				IsDeliveryOrg:  lo.ToPtr(true),
+			},
+		})
+		s.NoError(err)
+		updated := findContract(contracts)
+		s.True(updated.IsDeliveryOrg)
+
+		// the token from before the update is out of date
+		_, err = DeleteCedarContract(teamMemberCtx, store, cedarCoreClient, models.DeleteCedarContractInput{
+			CedarSystemID:    cedarSystemID,
+			ContractID:       added.ID.String,
+			ConcurrencyToken: added.ConcurrencyToken(),
+		})
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+
+		contracts, err = DeleteCedarContract(teamMemberCtx, store, cedarCoreClient, models.DeleteCedarContractInput{
+			CedarSystemID:    cedarSystemID,
+			ContractID:       updated.ID.String,
+			ConcurrencyToken: updated.ConcurrencyToken(),
+		})
+		s.NoError(err)
+		s.Nil(findContract(contracts))
+
+		history, err := GetAuditHistory(teamMemberCtx, store, cedarSystemID, 25, nil)
+		s.NoError(err)
+		changes := lo.Filter(history.Edges, func(edge *models.AuditChangeEdge, _ int) bool {
+			return edge.Node.EntityType == models.AuditEntityTypeCedarContract && lo.FromPtr(edge.Node.ExternalID) == added.ID.String
+		})
+		s.Len(changes, 3)
+		s.Equal(models.AuditChangeActionDelete, changes[0].Node.Action)
+		s.Equal(models.AuditChangeActionInsert, changes[2].Node.Action)
+
+		update := changes[1].Node
+		s.Equal(models.AuditChangeActionUpdate, update.Action)
+		s.Equal(teamMember.Account().ID, *update.ModifiedBy)
+		s.Len(update.Fields, 1)
+		s.Equal("isDeliveryOrg", update.Fields[0].FieldName)
+		s.Equal("false", *update.Fields[0].OldValue())
+		s.Equal("true", *update.Fields[0].NewValue())
+	})
+}
@@ -0,0 +1,128 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/guregu/null/zero"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// AddCedarDeployment adds a deployment to a CEDAR system and returns the system's deployments
+func AddCedarDeployment(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.AddCedarDeploymentInput,
+) ([]*models.CedarDeployment, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionImplementationDetails); err != nil {
+		return nil, err
+	}
+
+	if err := validateCedarDeploymentInput(input.Deployment); err != nil {
+		return nil, err
+	}
+
+	deployment := &models.CedarDeployment{}
+	applyCedarDeploymentInput(deployment, input.Deployment)
+	added, err := cedarCoreClient.AddDeployment(ctx, input.CedarSystemID, deployment)
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarDeployment, models.AuditChangeActionInsert, input.CedarSystemID, added.ID.String, nil, added)
+
+	return cedarCoreClient.GetDeployments(ctx, input.CedarSystemID, nil)
+}
+
+// UpdateCedarDeployment edits one of a CEDAR system's deployments and returns the system's deployments
+func UpdateCedarDeployment(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.UpdateCedarDeploymentInput,
+) ([]*models.CedarDeployment, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionImplementationDetails); err != nil {
+		return nil, err
+	}
+
+	if err := validateCedarDeploymentInput(input.Deployment); err != nil {
+		return nil, err
+	}
+
+	var previous models.CedarDeployment
+	updated, err := cedarCoreClient.UpdateDeployment(ctx, input.CedarSystemID, input.DeploymentID, input.ConcurrencyToken, func(deployment *models.CedarDeployment) {
+		previous = *deployment
+		applyCedarDeploymentInput(deployment, input.Deployment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarDeployment, models.AuditChangeActionUpdate, input.CedarSystemID, input.DeploymentID, &previous, updated)
+
+	return cedarCoreClient.GetDeployments(ctx, input.CedarSystemID, nil)
+}
+
+// DeleteCedarDeployment removes one of a CEDAR system's deployments and returns the system's remaining deployments
+func DeleteCedarDeployment(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.DeleteCedarDeploymentInput,
+) ([]*models.CedarDeployment, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionImplementationDetails); err != nil {
+		return nil, err
+	}
+
+	deleted, err := cedarCoreClient.DeleteDeployment(ctx, input.CedarSystemID, input.DeploymentID, input.ConcurrencyToken)
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarDeployment, models.AuditChangeActionDelete, input.CedarSystemID, input.DeploymentID, deleted, nil)
+
+	return cedarCoreClient.GetDeployments(ctx, input.CedarSystemID, nil)
+}
+
+func validateCedarDeploymentInput(input *models.CedarDeploymentInput) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return &apperrors.BadRequestError{Err: errors.New("deployment name is required")}
+	}
+
+	if input.StartDate != nil && input.EndDate != nil && input.EndDate.Before(*input.StartDate) {
+		return &apperrors.BadRequestError{Err: errors.New("deployment end date must not be before its start date")}
+	}
+
+	return nil
+}
+
+// applyCedarDeploymentInput sets the editable fields of a deployment from the input. The data center is only replaced if it changed,
+// so the details CEDAR returned for it are kept.
+func applyCedarDeploymentInput(deployment *models.CedarDeployment, input *models.CedarDeploymentInput) {
+	deployment.Name = zero.StringFrom(strings.TrimSpace(input.Name))
+	deployment.DeploymentType = zero.StringFromPtr(input.DeploymentType)
+	deployment.Description = zero.StringFromPtr(input.Description)
+	deployment.ContractorName = zero.StringFromPtr(input.ContractorName)
+	deployment.SystemVersion = zero.StringFromPtr(input.SystemVersion)
+	deployment.HasProductionData = zero.StringFromPtr(input.HasProductionData)
+	deployment.IsHotSite = zero.StringFromPtr(input.IsHotSite)
+	deployment.StartDate = zero.TimeFromPtr(input.StartDate)
+	deployment.EndDate = zero.TimeFromPtr(input.EndDate)
+	deployment.State = zero.StringFromPtr(input.State)
+	deployment.Status = zero.StringFromPtr(input.Status)
+	deployment.WanType = zero.StringFromPtr(input.WanType)
+	deployment.ReplicatedSystemElements = input.ReplicatedSystemElements
+	if deployment.ReplicatedSystemElements == nil {
+		deployment.ReplicatedSystemElements = []string{}
+	}
+
+	switch {
+	case input.DataCenterID == nil || *input.DataCenterID == "":
+		deployment.DataCenter = nil
+	case deployment.DataCenter == nil || deployment.DataCenter.ID.String != *input.DataCenterID:
+		deployment.DataCenter = &models.CedarDataCenter{ID: zero.StringFrom(*input.DataCenterID)}
+	}
+}
@@ -0,0 +1,26 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// AddCedarDeployment is the resolver for the addCedarDeployment field.
+func (r *mutationResolver) AddCedarDeployment(ctx context.Context, input models.AddCedarDeploymentInput) ([]*models.CedarDeployment, error) {
+	return AddCedarDeployment(ctx, r.store, r.cedarCoreClient, input)
+}
+
+// UpdateCedarDeployment is the resolver for the updateCedarDeployment field.
+func (r *mutationResolver) UpdateCedarDeployment(ctx context.Context, input models.UpdateCedarDeploymentInput) ([]*models.CedarDeployment, error) {
+	return UpdateCedarDeployment(ctx, r.store, r.cedarCoreClient, input)
+}
+
+// DeleteCedarDeployment is the resolver for the deleteCedarDeployment field.
+func (r *mutationResolver) DeleteCedarDeployment(ctx context.Context, input models.DeleteCedarDeploymentInput) ([]*models.CedarDeployment, error) {
+	return DeleteCedarDeployment(ctx, r.store, r.cedarCoreClient, input)
+}
@@ -0,0 +1,106 @@
+package resolvers
+
+import (
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+)
+
+func (s *ResolverSuite) TestCedarDeploymentWrites() {
+	store := s.testConfigs.Store
+	cedarCoreClient := s.cedarMutationResolver().cedarCoreClient
+	ps := pubsub.NewServicePubSub()
+	cedarSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC0A}")
+	section := models.SystemProfileLockableSectionImplementationDetails
+
+	teamMemberCtx, teamMember := s.getTestContextWithPrincipal("ABCD", false)
+	otherUserCtx, otherUser := s.getTestContextWithPrincipal("ZZZZ", false)
+
+	addInput := models.AddCedarDeploymentInput{
+		CedarSystemID: cedarSystemID,
+		Deployment: &models.CedarDeploymentInput{
+			Name:           "Resolver Test Deployment",
+			DeploymentType: lo.ToPtr("Testing"),
+		},
+	}
+	findDeployment := func(deployments []*models.CedarDeployment) *models.CedarDeployment {
+		deployment, _ := lo.Find(deployments, func(deployment *models.CedarDeployment) bool {
+			return deployment.Name.String == "Resolver Test Deployment"
+		})
+		return deployment
+	}
+
+	s.Run("deployments can't be edited without holding the section lock", func() {
+		_, err := AddCedarDeployment(teamMemberCtx, store, cedarCoreClient, addInput)
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+	})
+
+	s.Run("users who aren't on the system's team can't edit deployments", func() {
+		locked, err := LockSystemProfileSection(otherUserCtx, store, ps, cedarSystemID, section, otherUser)
+		s.NoError(err)
+		s.True(locked)
+
+		_, err = AddCedarDeployment(otherUserCtx, store, cedarCoreClient, addInput)
+		var unauthorizedErr *apperrors.UnauthorizedError
+		s.ErrorAs(err, &unauthorizedErr)
+
+		_, err = UnlockSystemProfileSection(otherUserCtx, store, ps, cedarSystemID, section, otherUser.Account().ID)
+		s.NoError(err)
+	})
+
+	locked, err := LockSystemProfileSection(teamMemberCtx, store, ps, cedarSystemID, section, teamMember)
+	s.NoError(err)
+	s.True(locked)
+
+	s.Run("deployments must have a name", func() {
+		_, err := AddCedarDeployment(teamMemberCtx, store, cedarCoreClient, models.AddCedarDeploymentInput{
+			CedarSystemID: cedarSystemID,
+			Deployment:    &models.CedarDeploymentInput{Name: " "},
+		})
+		var badRequestErr *apperrors.BadRequestError
+		s.ErrorAs(err, &badRequestErr)
+	})
+
+	s.Run("the lock holder can add, edit, and remove deployments", func() {
+		deployments, err := AddCedarDeployment(teamMemberCtx, store, cedarCoreClient, addInput)
+		s.NoError(err)
+		added := findDeployment(deployments)
+		s.NotNil(added)
+
+		deployments, err = UpdateCedarDeployment(teamMemberCtx, store, cedarCoreClient, models.UpdateCedarDeploymentInput{
+			CedarSystemID:    cedarSystemID,
+			DeploymentID:     added.ID.String,
+			ConcurrencyToken: added.ConcurrencyToken(),
+			Deployment: &models.CedarDeploymentInput{
+				Name:           "Resolver Test Deployment",
+				DeploymentType: lo.ToPtr("Production"),
+				ContractorName: lo.ToPtr("Contractor"),
+			},
+		})
+		s.NoError(err)
+		updated := findDeployment(deployments)
+		s.EqualValues("Production", updated.DeploymentType.String)
+		s.EqualValues("Contractor", updated.ContractorName.String)
+
+		// the token from before the update is out of date
+		_, err = DeleteCedarDeployment(teamMemberCtx, store, cedarCoreClient, models.DeleteCedarDeploymentInput{
+			CedarSystemID:    cedarSystemID,
+			DeploymentID:     added.ID.String,
+			ConcurrencyToken: added.ConcurrencyToken(),
+		})
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+
+		deployments, err = DeleteCedarDeployment(teamMemberCtx, store, cedarCoreClient, models.DeleteCedarDeploymentInput{
+			CedarSystemID:    cedarSystemID,
+			DeploymentID:     updated.ID.String,
+			ConcurrencyToken: updated.ConcurrencyToken(),
+		})
+		s.NoError(err)
+		s.Nil(findDeployment(deployments))
+	})
+}
@@ -0,0 +1,240 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// the kinds of partner CEDAR allows on the other end of an exchange
+const (
+	cedarExchangePartnerTypeApplication  = "application"
+	cedarExchangePartnerTypeOrganization = "organization"
+)
+
+// cedarExchangePartner is the system or organization on the other end of an exchange
+type cedarExchangePartner struct {
+	id        zero.String
+	name      zero.String
+	ownerType zero.String
+}
+
+// AddCedarExchange adds an exchange to a CEDAR system and returns the system's exchanges
+func AddCedarExchange(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.AddCedarExchangeInput,
+) ([]*models.CedarExchange, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionData); err != nil {
+		return nil, err
+	}
+
+	partner, err := validateCedarExchangeInput(ctx, cedarCoreClient, input.CedarSystemID, input.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	exchange := &models.CedarExchange{}
+	applyCedarExchangeInput(exchange, input.Exchange, partner)
+	added, err := cedarCoreClient.AddExchange(ctx, input.CedarSystemID, exchange)
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarExchange, models.AuditChangeActionInsert, input.CedarSystemID, added.ExchangeID.String, nil, added)
+
+	return cedarCoreClient.GetExchangesBySystem(ctx, input.CedarSystemID)
+}
+
+// UpdateCedarExchange edits one of a CEDAR system's exchanges and returns the system's exchanges
+func UpdateCedarExchange(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.UpdateCedarExchangeInput,
+) ([]*models.CedarExchange, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionData); err != nil {
+		return nil, err
+	}
+
+	partner, err := validateCedarExchangeInput(ctx, cedarCoreClient, input.CedarSystemID, input.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous models.CedarExchange
+	updated, err := cedarCoreClient.UpdateExchange(ctx, input.CedarSystemID, input.ExchangeID, input.ConcurrencyToken, func(exchange *models.CedarExchange) {
+		previous = *exchange
+		applyCedarExchangeInput(exchange, input.Exchange, partner)
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarExchange, models.AuditChangeActionUpdate, input.CedarSystemID, input.ExchangeID, &previous, updated)
+
+	return cedarCoreClient.GetExchangesBySystem(ctx, input.CedarSystemID)
+}
+
+// DeleteCedarExchange removes one of a CEDAR system's exchanges and returns the system's remaining exchanges
+func DeleteCedarExchange(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.DeleteCedarExchangeInput,
+) ([]*models.CedarExchange, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionData); err != nil {
+		return nil, err
+	}
+
+	deleted, err := cedarCoreClient.DeleteExchange(ctx, input.CedarSystemID, input.ExchangeID, input.ConcurrencyToken)
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarExchange, models.AuditChangeActionDelete, input.CedarSystemID, input.ExchangeID, deleted, nil)
+
+	return cedarCoreClient.GetExchangesBySystem(ctx, input.CedarSystemID)
+}
+
+// validateCedarExchangeInput checks the exchange's fields and returns its partner. A partner that's a system must be another system in CEDAR,
+// and is named after it.
+func validateCedarExchangeInput(
+	ctx context.Context,
+	cedarCoreClient *cedarcore.Client,
+	cedarSystemID uuid.UUID,
+	input *models.CedarExchangeInput,
+) (cedarExchangePartner, error) {
+	if strings.TrimSpace(input.ExchangeName) == "" {
+		return cedarExchangePartner{}, &apperrors.BadRequestError{Err: errors.New("exchange name is required")}
+	}
+
+	if input.ExchangeStartDate != nil {
+		if input.ExchangeEndDate != nil && input.ExchangeEndDate.Before(*input.ExchangeStartDate) {
+			return cedarExchangePartner{}, &apperrors.BadRequestError{Err: errors.New("exchange end date must not be before its start date")}
+		}
+		if input.ExchangeRetiredDate != nil && input.ExchangeRetiredDate.Before(*input.ExchangeStartDate) {
+			return cedarExchangePartner{}, &apperrors.BadRequestError{Err: errors.New("exchange retired date must not be before its start date")}
+		}
+	}
+
+	if lo.ContainsBy(input.ConnectionFrequency, func(frequency string) bool {
+		return strings.TrimSpace(frequency) == ""
+	}) {
+		return cedarExchangePartner{}, &apperrors.BadRequestError{Err: errors.New("exchange connection frequencies must not be blank")}
+	}
+
+	if lo.ContainsBy(input.TypeOfData, func(item *models.CedarExchangeTypeOfDataItemInput) bool {
+		return strings.TrimSpace(item.ID) == ""
+	}) {
+		return cedarExchangePartner{}, &apperrors.BadRequestError{Err: errors.New("exchange types of data must have an ID")}
+	}
+
+	return resolveCedarExchangePartner(ctx, cedarCoreClient, cedarSystemID, input)
+}
+
+func resolveCedarExchangePartner(
+	ctx context.Context,
+	cedarCoreClient *cedarcore.Client,
+	cedarSystemID uuid.UUID,
+	input *models.CedarExchangeInput,
+) (cedarExchangePartner, error) {
+	partnerID := strings.TrimSpace(lo.FromPtr(input.PartnerID))
+	partnerType := strings.ToLower(strings.TrimSpace(lo.FromPtr(input.PartnerType)))
+
+	if partnerID == "" {
+		if partnerType != "" {
+			return cedarExchangePartner{}, &apperrors.BadRequestError{Err: errors.New("exchange partner type must not be set without a partner")}
+		}
+		return cedarExchangePartner{}, nil
+	}
+
+	partner := cedarExchangePartner{
+		id:        zero.StringFrom(partnerID),
+		ownerType: zero.StringFrom(partnerType),
+	}
+
+	switch partnerType {
+	case cedarExchangePartnerTypeOrganization:
+		return partner, nil
+	case cedarExchangePartnerTypeApplication:
+	default:
+		return cedarExchangePartner{}, &apperrors.BadRequestError{Err: errors.New("exchange partner type must be application or organization")}
+	}
+
+	partnerSystemID, err := uuid.Parse(partnerID)
+	if err != nil {
+		return cedarExchangePartner{}, &apperrors.BadRequestError{Err: errors.New("exchange partner must be a CEDAR system ID")}
+	}
+	if partnerSystemID == cedarSystemID {
+		return cedarExchangePartner{}, &apperrors.BadRequestError{Err: errors.New("a system can't exchange data with itself")}
+	}
+
+	partnerSystem, err := cedarCoreClient.GetSystem(ctx, partnerSystemID)
+	var notFoundErr *apperrors.ResourceNotFoundError
+	if errors.As(err, &notFoundErr) || (err == nil && partnerSystem == nil) {
+		return cedarExchangePartner{}, &apperrors.BadRequestError{Err: errors.New("exchange partner system not found")}
+	}
+	if err != nil {
+		return cedarExchangePartner{}, err
+	}
+
+	partner.name = partnerSystem.Name
+	return partner, nil
+}
+
+// applyCedarExchangeInput sets the editable fields of an exchange from the input. The partner is set on the end of the exchange opposite
+// the system, which the client fills in; an organization partner's name is kept if the partner hasn't changed.
+func applyCedarExchangeInput(exchange *models.CedarExchange, input *models.CedarExchangeInput, partner cedarExchangePartner) {
+	currentPartnerID, currentPartnerName := exchange.ToOwnerID, exchange.ToOwnerName
+	if exchange.ExchangeDirection == models.ExchangeDirectionReceiver {
+		currentPartnerID, currentPartnerName = exchange.FromOwnerID, exchange.FromOwnerName
+	}
+	if !partner.name.Valid && partner.id.Valid && strings.EqualFold(currentPartnerID.String, partner.id.String) {
+		partner.name = currentPartnerName
+	}
+
+	exchange.ExchangeName = zero.StringFrom(strings.TrimSpace(input.ExchangeName))
+	exchange.ExchangeDescription = zero.StringFromPtr(input.ExchangeDescription)
+	exchange.ExchangeDirection = input.ExchangeDirection
+	exchange.ExchangeState = zero.StringFromPtr(input.ExchangeState)
+	exchange.ExchangeStartDate = zero.TimeFromPtr(input.ExchangeStartDate)
+	exchange.ExchangeEndDate = zero.TimeFromPtr(input.ExchangeEndDate)
+	exchange.ExchangeRetiredDate = zero.TimeFromPtr(input.ExchangeRetiredDate)
+	exchange.ConnectionFrequency = lo.Map(input.ConnectionFrequency, func(frequency string, _ int) zero.String {
+		return zero.StringFrom(strings.TrimSpace(frequency))
+	})
+	exchange.DataExchangeAgreement = zero.StringFromPtr(input.DataExchangeAgreement)
+	exchange.DataFormat = zero.StringFromPtr(input.DataFormat)
+	exchange.DataFormatOther = zero.StringFromPtr(input.DataFormatOther)
+	exchange.NumOfRecords = zero.StringFromPtr(input.NumOfRecords)
+	exchange.ContainsBankingData = lo.FromPtr(input.ContainsBankingData)
+	exchange.ContainsBeneficiaryAddress = lo.FromPtr(input.ContainsBeneficiaryAddress)
+	exchange.ContainsPhi = lo.FromPtr(input.ContainsPhi)
+	exchange.ContainsPii = lo.FromPtr(input.ContainsPii)
+	exchange.ContainsHealthDisparityData = lo.FromPtr(input.ContainsHealthDisparityData)
+	exchange.IsBeneficiaryMailingFile = lo.FromPtr(input.IsBeneficiaryMailingFile)
+	exchange.SharedViaAPI = lo.FromPtr(input.SharedViaAPI)
+	exchange.TypeOfData = lo.Map(input.TypeOfData, func(item *models.CedarExchangeTypeOfDataItemInput, _ int) *models.CedarExchangeTypeOfDataItem {
+		return &models.CedarExchangeTypeOfDataItem{
+			ID:   zero.StringFrom(strings.TrimSpace(item.ID)),
+			Name: zero.StringFromPtr(item.Name),
+		}
+	})
+
+	// the system's own end is filled in by the client, so only the partner's end is set here
+	if exchange.ExchangeDirection == models.ExchangeDirectionSender {
+		exchange.ToOwnerID, exchange.ToOwnerName, exchange.ToOwnerType = partner.id, partner.name, partner.ownerType
+		exchange.FromOwnerID, exchange.FromOwnerName, exchange.FromOwnerType = zero.String{}, zero.String{}, zero.String{}
+	} else {
+		exchange.FromOwnerID, exchange.FromOwnerName, exchange.FromOwnerType = partner.id, partner.name, partner.ownerType
+		exchange.ToOwnerID, exchange.ToOwnerName, exchange.ToOwnerType = zero.String{}, zero.String{}, zero.String{}
+	}
+}
@@ -0,0 +1,26 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// AddCedarExchange is the resolver for the addCedarExchange field.
+func (r *mutationResolver) AddCedarExchange(ctx context.Context, input models.AddCedarExchangeInput) ([]*models.CedarExchange, error) {
+	return AddCedarExchange(ctx, r.store, r.cedarCoreClient, input)
+}
+
+// UpdateCedarExchange is the resolver for the updateCedarExchange field.
+func (r *mutationResolver) UpdateCedarExchange(ctx context.Context, input models.UpdateCedarExchangeInput) ([]*models.CedarExchange, error) {
+	return UpdateCedarExchange(ctx, r.store, r.cedarCoreClient, input)
+}
+
+// DeleteCedarExchange is the resolver for the deleteCedarExchange field.
+func (r *mutationResolver) DeleteCedarExchange(ctx context.Context, input models.DeleteCedarExchangeInput) ([]*models.CedarExchange, error) {
+	return DeleteCedarExchange(ctx, r.store, r.cedarCoreClient, input)
+}
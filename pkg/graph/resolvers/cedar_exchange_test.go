@@ -0,0 +1,153 @@
+package resolvers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+)
+
+func (s *ResolverSuite) TestCedarExchangeWrites() {
+	store := s.testConfigs.Store
+	cedarCoreClient := s.cedarMutationResolver().cedarCoreClient
+	ps := pubsub.NewServicePubSub()
+	cedarSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC0A}")
+	partnerSystemID := "{11AB1A00-1234-5678-ABC1-1A001B00CC1B}"
+	section := models.SystemProfileLockableSectionData
+
+	teamMemberCtx, teamMember := s.getTestContextWithPrincipal("ABCD", false)
+	otherUserCtx, otherUser := s.getTestContextWithPrincipal("ZZZZ", false)
+
+	exchangeInput := func(change func(input *models.CedarExchangeInput)) *models.CedarExchangeInput {
+		input := &models.CedarExchangeInput{
+			ExchangeName:      "Resolver Test Exchange",
+			ExchangeDirection: models.ExchangeDirectionSender,
+			PartnerID:         lo.ToPtr(partnerSystemID),
+			PartnerType:       lo.ToPtr("Application"),
+		}
+		if change != nil {
+			change(input)
+		}
+		return input
+	}
+	addInput := models.AddCedarExchangeInput{
+		CedarSystemID: cedarSystemID,
+		Exchange:      exchangeInput(nil),
+	}
+	findExchange := func(exchanges []*models.CedarExchange) *models.CedarExchange {
+		exchange, _ := lo.Find(exchanges, func(exchange *models.CedarExchange) bool {
+			return exchange.ExchangeName.String == "Resolver Test Exchange"
+		})
+		return exchange
+	}
+
+	s.Run("exchanges can't be edited without holding the section lock", func() {
+		_, err := AddCedarExchange(teamMemberCtx, store, cedarCoreClient, addInput)
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+	})
+
+	s.Run("users who aren't on the system's team can't edit exchanges", func() {
+		locked, err := LockSystemProfileSection(otherUserCtx, store, ps, cedarSystemID, section, otherUser)
+		s.NoError(err)
+		s.True(locked)
+
+		_, err = AddCedarExchange(otherUserCtx, store, cedarCoreClient, addInput)
+		var unauthorizedErr *apperrors.UnauthorizedError
+		s.ErrorAs(err, &unauthorizedErr)
+
+		_, err = UnlockSystemProfileSection(otherUserCtx, store, ps, cedarSystemID, section, otherUser.Account().ID)
+		s.NoError(err)
+	})
+
+	locked, err := LockSystemProfileSection(teamMemberCtx, store, ps, cedarSystemID, section, teamMember)
+	s.NoError(err)
+	s.True(locked)
+
+	s.Run("invalid exchanges are rejected", func() {
+		invalidInputs := map[string]*models.CedarExchangeInput{
+			"blank name": exchangeInput(func(input *models.CedarExchangeInput) {
+				input.ExchangeName = " "
+			}),
+			"unknown partner type": exchangeInput(func(input *models.CedarExchangeInput) {
+				input.PartnerType = lo.ToPtr("person")
+			}),
+			"partner type without a partner": exchangeInput(func(input *models.CedarExchangeInput) {
+				input.PartnerID = nil
+			}),
+			"exchange with itself": exchangeInput(func(input *models.CedarExchangeInput) {
+				input.PartnerID = lo.ToPtr(cedarSystemID.String())
+			}),
+			"partner system that doesn't exist": exchangeInput(func(input *models.CedarExchangeInput) {
+				input.PartnerID = lo.ToPtr(uuid.NewString())
+			}),
+			"retired before it started": exchangeInput(func(input *models.CedarExchangeInput) {
+				input.ExchangeStartDate = lo.ToPtr(time.Now())
+				input.ExchangeRetiredDate = lo.ToPtr(time.Now().AddDate(0, 0, -1))
+			}),
+		}
+
+		for name, input := range invalidInputs {
+			_, err := AddCedarExchange(teamMemberCtx, store, cedarCoreClient, models.AddCedarExchangeInput{
+				CedarSystemID: cedarSystemID,
+				Exchange:      input,
+			})
+			var badRequestErr *apperrors.BadRequestError
+			s.ErrorAs(err, &badRequestErr, name)
+		}
+	})
+
+	s.Run("the lock holder can add, edit, and remove exchanges", func() {
+		exchanges, err := AddCedarExchange(teamMemberCtx, store, cedarCoreClient, addInput)
+		s.NoError(err)
+		added := findExchange(exchanges)
+		s.NotNil(added)
+		s.Equal(models.ExchangeDirectionSender, added.ExchangeDirection)
+		s.EqualValues("Centers for Management Services", added.FromOwnerName.String)
+		s.EqualValues(partnerSystemID, added.ToOwnerID.String)
+		s.EqualValues("Office of Funny Walks", added.ToOwnerName.String)
+		s.EqualValues("application", added.ToOwnerType.String)
+
+		exchanges, err = UpdateCedarExchange(teamMemberCtx, store, cedarCoreClient, models.UpdateCedarExchangeInput{
+			CedarSystemID:    cedarSystemID,
+			ExchangeID:       added.ExchangeID.String,
+			ConcurrencyToken: added.ConcurrencyToken(),
+			Exchange: exchangeInput(func(input *models.CedarExchangeInput) {
+				input.ExchangeDirection = models.ExchangeDirectionReceiver
+				input.PartnerID = lo.ToPtr("{ORGANIZATION}")
+				input.PartnerType = lo.ToPtr("organization")
+				input.ContainsPii = lo.ToPtr(true)
+				input.TypeOfData = []*models.CedarExchangeTypeOfDataItemInput{{ID: "{TYPEOFDATA}", Name: lo.ToPtr("Beneficiary Data")}}
+			}),
+		})
+		s.NoError(err)
+		updated := findExchange(exchanges)
+		s.Equal(models.ExchangeDirectionReceiver, updated.ExchangeDirection)
+		s.EqualValues("{ORGANIZATION}", updated.FromOwnerID.String)
+		s.EqualValues("organization", updated.FromOwnerType.String)
+		s.EqualValues("Centers for Management Services", updated.ToOwnerName.String)
+		s.True(updated.ContainsPii)
+		s.Len(updated.TypeOfData, 1)
+
+		// the token from before the update is out of date
+		_, err = DeleteCedarExchange(teamMemberCtx, store, cedarCoreClient, models.DeleteCedarExchangeInput{
+			CedarSystemID:    cedarSystemID,
+			ExchangeID:       added.ExchangeID.String,
+			ConcurrencyToken: added.ConcurrencyToken(),
+		})
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+
+		exchanges, err = DeleteCedarExchange(teamMemberCtx, store, cedarCoreClient, models.DeleteCedarExchangeInput{
+			CedarSystemID:    cedarSystemID,
+			ExchangeID:       updated.ExchangeID.String,
+			ConcurrencyToken: updated.ConcurrencyToken(),
+		})
+		s.NoError(err)
+		s.Nil(findExchange(exchanges))
+	})
+}
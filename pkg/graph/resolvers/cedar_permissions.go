@@ -3,12 +3,15 @@ package resolvers
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
 	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
 )
 
 func userIsOnAnyCEDARSystemTeam(
@@ -120,3 +123,42 @@ func authorizeUserCanManageCEDARSystemTeam(
 
 	return nil
 }
+
+// authorizeUserCanEditCEDARSystemProfileSection checks that the user is on the system's team and holds the lock on the section of the
+// system profile being edited, so edits from different users can't overwrite each other
+func authorizeUserCanEditCEDARSystemProfileSection(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	cedarSystemID uuid.UUID,
+	section models.SystemProfileLockableSection,
+) error {
+	if err := authorizeUserCanAccessCEDARSystemWorkspace(ctx, cedarCoreClient, cedarSystemID); err != nil {
+		var unauthorizedErr *apperrors.UnauthorizedError
+		if !errors.As(err, &unauthorizedErr) {
+			return err
+		}
+
+		return &apperrors.UnauthorizedError{Err: errors.New("unauthorized to edit cedar system profile")}
+	}
+
+	account := appcontext.Principal(ctx).Account()
+	if account == nil {
+		return &apperrors.UnauthorizedError{Err: errors.New("unable to retrieve user account to edit cedar system profile")}
+	}
+
+	lock, err := store.SystemProfileSectionLock(ctx, cedarSystemID, section)
+	if err != nil {
+		return err
+	}
+
+	if lock == nil || lock.LockedBy != account.ID {
+		return &apperrors.ResourceConflictError{
+			Err:        fmt.Errorf("section [%v] must be locked by the user before it can be edited", section),
+			Resource:   models.SystemProfileSectionLock{},
+			ResourceID: cedarSystemID.String(),
+		}
+	}
+
+	return nil
+}
@@ -26,6 +26,8 @@ func (s *ResolverSuite) cedarMutationResolver() *mutationResolver {
 		"fake",
 		"1.0.0",
 		true,
+		cedarcore.TransportConfig{},
+		cedarcore.CacheConfig{},
 	)
 
 	return &mutationResolver{
@@ -48,6 +50,8 @@ func (s *ResolverSuite) cedarQueryResolver() *queryResolver {
 		"fake",
 		"1.0.0",
 		true,
+		cedarcore.TransportConfig{},
+		cedarcore.CacheConfig{},
 	)
 
 	return &queryResolver{
@@ -68,6 +72,8 @@ func (s *ResolverSuite) cedarTypeResolver() *cedarSystemResolver {
 		"fake",
 		"1.0.0",
 		true,
+		cedarcore.TransportConfig{},
+		cedarcore.CacheConfig{},
 	)
 
 	return &cedarSystemResolver{
@@ -15,7 +15,6 @@ import (
 	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
 	"github.com/cms-enterprise/easi-app/pkg/dataloaders"
 	"github.com/cms-enterprise/easi-app/pkg/models"
-	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 )
 
 var testCedarSystemID = uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC0A}")
@@ -35,6 +34,8 @@ func TestCedarReadQueryResolversRejectNonEASIUsers(t *testing.T) {
 			"fake",
 			"1.0.0",
 			true,
+			cedarcore.TransportConfig{},
+			cedarcore.CacheConfig{},
 		),
 	}}
 
@@ -154,6 +155,8 @@ func TestCedarSystemsAllowsGRTWithoutEASI(t *testing.T) {
 			"fake",
 			"1.0.0",
 			true,
+			cedarcore.TransportConfig{},
+			cedarcore.CacheConfig{},
 		),
 	}}
 
@@ -190,6 +193,8 @@ func TestCedarSystemsAllowsTRBAdminWithoutEASI(t *testing.T) {
 			"fake",
 			"1.0.0",
 			true,
+			cedarcore.TransportConfig{},
+			cedarcore.CacheConfig{},
 		),
 	}}
 
@@ -226,6 +231,8 @@ func TestCedarContactLookupAllowsTRBAdminWithoutEASI(t *testing.T) {
 			"fake",
 			"1.0.0",
 			true,
+			cedarcore.TransportConfig{},
+			cedarcore.CacheConfig{},
 		),
 	}}
 
@@ -257,6 +264,8 @@ func TestCedarContactLookupAllowsTeamMemberWithoutEASI(t *testing.T) {
 			"fake",
 			"1.0.0",
 			true,
+			cedarcore.TransportConfig{},
+			cedarcore.CacheConfig{},
 		),
 	}}
 
@@ -282,6 +291,8 @@ func TestCedarSystemWorkspaceAuthorizationIgnoresCapabilityFallback(t *testing.T
 		"fake",
 		"1.0.0",
 		true,
+		cedarcore.TransportConfig{},
+		cedarcore.CacheConfig{},
 	)
 
 	ctx := appcontext.WithPrincipal(context.Background(), &authentication.EUAPrincipal{
@@ -302,59 +313,6 @@ func TestCedarSystemWorkspaceAuthorizationIgnoresCapabilityFallback(t *testing.T
 	require.NoError(t, authorizeUserCanAccessCEDARSystemWorkspace(ctx, cedarCoreClient, testCedarSystemID))
 }
 
-func TestSystemProfileSectionLocksAllowProfileOnlyEASIUsers(t *testing.T) {
-	t.Parallel()
-
-	mutationResolver := &mutationResolver{&Resolver{
-		pubsub: pubsub.NewServicePubSub(),
-		cedarCoreClient: cedarcore.NewClient(
-			appcontext.WithLogger(context.Background(), zap.NewNop()),
-			"fake",
-			"fake",
-			"1.0.0",
-			true,
-		),
-	}}
-	queryResolver := &queryResolver{&Resolver{
-		cedarCoreClient: cedarcore.NewClient(
-			appcontext.WithLogger(context.Background(), zap.NewNop()),
-			"fake",
-			"fake",
-			"1.0.0",
-			true,
-		),
-	}}
-
-	section := models.SystemProfileLockableSectionTeam
-
-	profileOnlyCtx := appcontext.WithPrincipal(context.Background(), &authentication.EUAPrincipal{
-		EUAID:       "USR1",
-		JobCodeEASi: true,
-		UserAccount: &authentication.UserAccount{
-			ID:       uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
-			Username: "USR1",
-		},
-	})
-	nonEasiCtx := appcontext.WithPrincipal(context.Background(), &authentication.EUAPrincipal{
-		EUAID:       "WXYZ",
-		UserAccount: &authentication.UserAccount{Username: "WXYZ"},
-	})
-
-	locked, err := mutationResolver.LockSystemProfileSection(profileOnlyCtx, testCedarSystemID, section)
-	require.NoError(t, err)
-	require.True(t, locked)
-
-	locks, err := queryResolver.SystemProfileSectionLocks(profileOnlyCtx, testCedarSystemID)
-	require.NoError(t, err)
-	require.Len(t, locks, 1)
-
-	_, err = mutationResolver.LockSystemProfileSection(nonEasiCtx, testCedarSystemID, section)
-	require.Error(t, err)
-
-	var unauthorizedErr *apperrors.UnauthorizedError
-	require.True(t, errors.As(err, &unauthorizedErr))
-}
-
 func TestCedarSystemProfileQueriesRequireEASI(t *testing.T) {
 	t.Parallel()
 
@@ -370,6 +328,8 @@ func TestCedarSystemProfileQueriesRequireEASI(t *testing.T) {
 			"fake",
 			"1.0.0",
 			true,
+			cedarcore.TransportConfig{},
+			cedarcore.CacheConfig{},
 		),
 	}}
 
@@ -471,6 +431,8 @@ func TestCedarSystemWorkspaceAllowsTeamMemberWithoutEASI(t *testing.T) {
 			"fake",
 			"1.0.0",
 			true,
+			cedarcore.TransportConfig{},
+			cedarcore.CacheConfig{},
 		),
 	}}
 
@@ -506,6 +468,8 @@ func TestMyCedarSystemsAllowsTeamMemberWithoutEASI(t *testing.T) {
 			"fake",
 			"1.0.0",
 			true,
+			cedarcore.TransportConfig{},
+			cedarcore.CacheConfig{},
 		),
 	}}
 
@@ -528,6 +492,8 @@ func TestCedarSystemViewerCapabilitiesAccessMatrix(t *testing.T) {
 		"fake",
 		"1.0.0",
 		true,
+		cedarcore.TransportConfig{},
+		cedarcore.CacheConfig{},
 	)
 	queryResolver := &queryResolver{&Resolver{cedarCoreClient: cedarCoreClient}}
 	typeResolver := &cedarSystemResolver{&Resolver{cedarCoreClient: cedarCoreClient}}
@@ -614,6 +580,8 @@ func TestPreAuthorizedMyCedarSystemsBypassMembershipLookupForCapabilities(t *tes
 		"fake",
 		"1.0.0",
 		true,
+		cedarcore.TransportConfig{},
+		cedarcore.CacheConfig{},
 	)
 	queryResolver := &queryResolver{&Resolver{cedarCoreClient: cedarCoreClient}}
 	typeResolver := &cedarSystemResolver{&Resolver{cedarCoreClient: cedarCoreClient}}
@@ -660,6 +628,8 @@ func TestPreAuthorizedWorkspaceSystemBypassesMembershipLookupForProfile(t *testi
 		"fake",
 		"1.0.0",
 		true,
+		cedarcore.TransportConfig{},
+		cedarcore.CacheConfig{},
 	)
 	typeResolver := &cedarSystemWorkspaceSystemResolver{&Resolver{cedarCoreClient: cedarCoreClient}}
 
@@ -211,6 +211,11 @@ func (r *cedarSystemWorkspaceSystemResolver) LinkedSystemIntakes(ctx context.Con
 	return intakes, nil
 }
 
+// InvalidateCedarCache is the resolver for the invalidateCedarCache field.
+func (r *mutationResolver) InvalidateCedarCache(ctx context.Context, cedarSystemID *uuid.UUID) (bool, error) {
+	return InvalidateCedarCache(ctx, r.cedarCoreClient, cedarSystemID)
+}
+
 // CedarSystem is the resolver for the cedarSystem field.
 func (r *queryResolver) CedarSystem(ctx context.Context, cedarSystemID uuid.UUID) (*models.CedarSystem, error) {
 	return GetCedarSystem(ctx, r.cedarCoreClient, cedarSystemID)
@@ -68,6 +68,8 @@ func TestGetCedarSystemWorkspaceGracefullyHandlesRoleLookupFailure(t *testing.T)
 		"fake",
 		"1.0.0",
 		false,
+		cedarcore.TransportConfig{},
+		cedarcore.CacheConfig{},
 	)
 
 	ctx := appcontext.WithPrincipal(context.Background(), &authentication.EUAPrincipal{
@@ -0,0 +1,106 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// AddCedarURL adds a URL to a CEDAR system and returns the system's URLs
+func AddCedarURL(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.AddCedarURLInput,
+) ([]*models.CedarURL, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionImplementationDetails); err != nil {
+		return nil, err
+	}
+
+	if err := validateCedarURLInput(input.URL); err != nil {
+		return nil, err
+	}
+
+	url := &models.CedarURL{}
+	applyCedarURLInput(url, input.URL)
+	added, err := cedarCoreClient.AddURL(ctx, input.CedarSystemID, url)
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarURL, models.AuditChangeActionInsert, input.CedarSystemID, added.ID.String, nil, added)
+
+	return cedarCoreClient.GetURLsForSystem(ctx, input.CedarSystemID)
+}
+
+// UpdateCedarURL edits one of a CEDAR system's URLs and returns the system's URLs
+func UpdateCedarURL(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.UpdateCedarURLInput,
+) ([]*models.CedarURL, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionImplementationDetails); err != nil {
+		return nil, err
+	}
+
+	if err := validateCedarURLInput(input.URL); err != nil {
+		return nil, err
+	}
+
+	var previous models.CedarURL
+	updated, err := cedarCoreClient.UpdateURL(ctx, input.CedarSystemID, input.URLID, input.ConcurrencyToken, func(url *models.CedarURL) {
+		previous = *url
+		applyCedarURLInput(url, input.URL)
+	})
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarURL, models.AuditChangeActionUpdate, input.CedarSystemID, input.URLID, &previous, updated)
+
+	return cedarCoreClient.GetURLsForSystem(ctx, input.CedarSystemID)
+}
+
+// DeleteCedarURL removes one of a CEDAR system's URLs and returns the system's remaining URLs
+func DeleteCedarURL(
+	ctx context.Context,
+	store *storage.Store,
+	cedarCoreClient *cedarcore.Client,
+	input models.DeleteCedarURLInput,
+) ([]*models.CedarURL, error) {
+	if err := authorizeUserCanEditCEDARSystemProfileSection(ctx, store, cedarCoreClient, input.CedarSystemID, models.SystemProfileLockableSectionImplementationDetails); err != nil {
+		return nil, err
+	}
+
+	deleted, err := cedarCoreClient.DeleteURL(ctx, input.CedarSystemID, input.URLID, input.ConcurrencyToken)
+	if err != nil {
+		return nil, err
+	}
+	recordCEDARSystemProfileChange(ctx, store, models.AuditEntityTypeCedarURL, models.AuditChangeActionDelete, input.CedarSystemID, input.URLID, deleted, nil)
+
+	return cedarCoreClient.GetURLsForSystem(ctx, input.CedarSystemID)
+}
+
+func validateCedarURLInput(input *models.CedarURLInput) error {
+	if strings.TrimSpace(input.Address) == "" {
+		return &apperrors.BadRequestError{Err: errors.New("URL address is required")}
+	}
+
+	return nil
+}
+
+// applyCedarURLInput sets the editable fields of a URL from the input
+func applyCedarURLInput(url *models.CedarURL, input *models.CedarURLInput) {
+	url.Address = zero.StringFrom(strings.TrimSpace(input.Address))
+	url.IsBehindWebApplicationFirewall = lo.FromPtr(input.IsBehindWebApplicationFirewall)
+	url.IsAPIEndpoint = lo.FromPtr(input.IsAPIEndpoint)
+	url.IsVersionCodeRepository = lo.FromPtr(input.IsVersionCodeRepository)
+	url.URLHostingEnv = zero.StringFromPtr(input.URLHostingEnv)
+}
@@ -0,0 +1,26 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// AddCedarURL is the resolver for the addCedarURL field.
+func (r *mutationResolver) AddCedarURL(ctx context.Context, input models.AddCedarURLInput) ([]*models.CedarURL, error) {
+	return AddCedarURL(ctx, r.store, r.cedarCoreClient, input)
+}
+
+// UpdateCedarURL is the resolver for the updateCedarURL field.
+func (r *mutationResolver) UpdateCedarURL(ctx context.Context, input models.UpdateCedarURLInput) ([]*models.CedarURL, error) {
+	return UpdateCedarURL(ctx, r.store, r.cedarCoreClient, input)
+}
+
+// DeleteCedarURL is the resolver for the deleteCedarURL field.
+func (r *mutationResolver) DeleteCedarURL(ctx context.Context, input models.DeleteCedarURLInput) ([]*models.CedarURL, error) {
+	return DeleteCedarURL(ctx, r.store, r.cedarCoreClient, input)
+}
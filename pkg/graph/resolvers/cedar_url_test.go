@@ -0,0 +1,122 @@
+package resolvers
+
+import (
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+)
+
+func (s *ResolverSuite) TestCedarURLWrites() {
+	store := s.testConfigs.Store
+	cedarCoreClient := s.cedarMutationResolver().cedarCoreClient
+	ps := pubsub.NewServicePubSub()
+	cedarSystemID := uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC0A}")
+	section := models.SystemProfileLockableSectionImplementationDetails
+
+	teamMemberCtx, teamMember := s.getTestContextWithPrincipal("ABCD", false)
+	otherUserCtx, otherUser := s.getTestContextWithPrincipal("ZZZZ", false)
+
+	addInput := models.AddCedarURLInput{
+		CedarSystemID: cedarSystemID,
+		URL: &models.CedarURLInput{
+			Address:       "https://resolver-test.cms.gov",
+			URLHostingEnv: lo.ToPtr("Production"),
+		},
+	}
+	findURL := func(urls []*models.CedarURL) *models.CedarURL {
+		url, _ := lo.Find(urls, func(url *models.CedarURL) bool {
+			return url.Address.String == "https://resolver-test.cms.gov"
+		})
+		return url
+	}
+
+	s.Run("URLs can't be edited without holding the section lock", func() {
+		_, err := AddCedarURL(teamMemberCtx, store, cedarCoreClient, addInput)
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+	})
+
+	s.Run("users who aren't on the system's team can't edit URLs", func() {
+		locked, err := LockSystemProfileSection(otherUserCtx, store, ps, cedarSystemID, section, otherUser)
+		s.NoError(err)
+		s.True(locked)
+
+		_, err = AddCedarURL(otherUserCtx, store, cedarCoreClient, addInput)
+		var unauthorizedErr *apperrors.UnauthorizedError
+		s.ErrorAs(err, &unauthorizedErr)
+
+		_, err = UnlockSystemProfileSection(otherUserCtx, store, ps, cedarSystemID, section, otherUser.Account().ID)
+		s.NoError(err)
+	})
+
+	locked, err := LockSystemProfileSection(teamMemberCtx, store, ps, cedarSystemID, section, teamMember)
+	s.NoError(err)
+	s.True(locked)
+
+	s.Run("URLs must have an address", func() {
+		_, err := AddCedarURL(teamMemberCtx, store, cedarCoreClient, models.AddCedarURLInput{
+			CedarSystemID: cedarSystemID,
+			URL:           &models.CedarURLInput{Address: " "},
+		})
+		var badRequestErr *apperrors.BadRequestError
+		s.ErrorAs(err, &badRequestErr)
+	})
+
+	s.Run("the lock holder can add, edit, and remove URLs, and the changes are in the system's audit history", func() {
+		urls, err := AddCedarURL(teamMemberCtx, store, cedarCoreClient, addInput)
+		s.NoError(err)
+		added := findURL(urls)
+		s.NotNil(added)
+
+		urls, err = UpdateCedarURL(teamMemberCtx, store, cedarCoreClient, models.UpdateCedarURLInput{
+			CedarSystemID:    cedarSystemID,
+			URLID:            added.ID.String,
+			ConcurrencyToken: added.ConcurrencyToken(),
+			URL: &models.CedarURLInput{
+				Address:       "https://resolver-test.cms.gov",
+				URLHostingEnv: lo.ToPtr("Production"),
+				IsAPIEndpoint: lo.ToPtr(true),
+			},
+		})
+		s.NoError(err)
+		updated := findURL(urls)
+		s.True(updated.IsAPIEndpoint)
+
+		// the token from before the update is out of date
+		_, err = DeleteCedarURL(teamMemberCtx, store, cedarCoreClient, models.DeleteCedarURLInput{
+			CedarSystemID:    cedarSystemID,
+			URLID:            added.ID.String,
+			ConcurrencyToken: added.ConcurrencyToken(),
+		})
+		var conflictErr *apperrors.ResourceConflictError
+		s.ErrorAs(err, &conflictErr)
+
+		urls, err = DeleteCedarURL(teamMemberCtx, store, cedarCoreClient, models.DeleteCedarURLInput{
+			CedarSystemID:    cedarSystemID,
+			URLID:            updated.ID.String,
+			ConcurrencyToken: updated.ConcurrencyToken(),
+		})
+		s.NoError(err)
+		s.Nil(findURL(urls))
+
+		history, err := GetAuditHistory(teamMemberCtx, store, cedarSystemID, 25, nil)
+		s.NoError(err)
+		changes := lo.Filter(history.Edges, func(edge *models.AuditChangeEdge, _ int) bool {
+			return edge.Node.EntityType == models.AuditEntityTypeCedarURL && lo.FromPtr(edge.Node.ExternalID) == added.ID.String
+		})
+		s.Len(changes, 3)
+		s.Equal(models.AuditChangeActionDelete, changes[0].Node.Action)
+		s.Equal(models.AuditChangeActionInsert, changes[2].Node.Action)
+
+		update := changes[1].Node
+		s.Equal(models.AuditChangeActionUpdate, update.Action)
+		s.Equal(teamMember.Account().ID, *update.ModifiedBy)
+		s.Len(update.Fields, 1)
+		s.Equal("isAPIEndpoint", update.Fields[0].FieldName)
+		s.Equal("false", *update.Fields[0].OldValue())
+		s.Equal("true", *update.Fields[0].NewValue())
+	})
+}
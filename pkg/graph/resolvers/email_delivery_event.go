@@ -0,0 +1,29 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// GetEmailDeliveryEventsByOutboxID returns the delivery, bounce, and complaint notifications SES sent about an email in the outbox
+func GetEmailDeliveryEventsByOutboxID(ctx context.Context, store *storage.Store, emailOutboxID uuid.UUID) ([]*models.EmailDeliveryEvent, error) {
+	if err := authorizeUserCanManageEmailOutbox(ctx); err != nil {
+		return nil, err
+	}
+
+	return store.GetEmailDeliveryEventsByOutboxID(ctx, emailOutboxID)
+}
+
+// GetSystemIntakeEmailDeliveryStatuses returns the delivery status of emails to a system intake's contacts and GRB reviewers
+func GetSystemIntakeEmailDeliveryStatuses(ctx context.Context, store *storage.Store, systemIntakeID uuid.UUID) ([]*models.EmailRecipientDeliveryStatus, error) {
+	return store.GetEmailRecipientDeliveryStatusesBySystemIntakeID(ctx, systemIntakeID)
+}
+
+// GetTRBRequestEmailDeliveryStatuses returns the delivery status of emails to a TRB request's requester, TRB lead, and attendees
+func GetTRBRequestEmailDeliveryStatuses(ctx context.Context, store *storage.Store, trbRequestID uuid.UUID) ([]*models.EmailRecipientDeliveryStatus, error) {
+	return store.GetEmailRecipientDeliveryStatusesByTRBRequestID(ctx, trbRequestID)
+}
@@ -0,0 +1,111 @@
+package resolvers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *ResolverSuite) TestEmailDeliveryEvents() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+	requester := s.testConfigs.Principal.Account()
+	recipient := models.NewEmailAddress(requester.Email)
+	trbRequest := s.createNewTRBRequest()
+	occurredAt := time.Now().UTC().Truncate(time.Second)
+
+	bounce := func(notificationID string) *models.EmailDeliveryEvent {
+		return &models.EmailDeliveryEvent{
+			NotificationID:    notificationID,
+			ProviderMessageID: "provider-" + notificationID,
+			EventType:         models.EmailDeliveryEventTypeBounce,
+			Recipient:         recipient,
+			BounceType:        helpers.PointerTo("Permanent"),
+			Diagnostic:        helpers.PointerTo("smtp; 550 5.1.1 user unknown"),
+			OccurredAt:        occurredAt,
+		}
+	}
+
+	s.Run("events are linked to the email in the outbox they're about", func() {
+		s.NoError(email.NewOutboxSender(store).Send(ctx, email.NewEmail().
+			WithToAddresses([]models.EmailAddress{recipient}).
+			WithSubject("tracked").
+			WithBody("<p>body</p>"),
+		))
+		messages, err := GetEmailOutboxMessages(ctx, store, nil, 1, nil)
+		s.NoError(err)
+		message := messages.Edges[0].Node
+		message.RecordSent(occurredAt)
+		message.ProviderMessageID = helpers.PointerTo("provider-linked")
+		s.NoError(store.UpdateEmailOutboxMessageDelivery(ctx, store, message))
+
+		event := bounce("linked")
+		event.EventType = models.EmailDeliveryEventTypeDelivery
+		event.OccurredAt = occurredAt.Add(-time.Minute)
+		recorded, err := store.RecordEmailDeliveryEvents(ctx, []*models.EmailDeliveryEvent{event})
+		s.NoError(err)
+		s.Equal(1, recorded)
+
+		events, err := GetEmailDeliveryEventsByOutboxID(ctx, store, message.ID)
+		s.NoError(err)
+		s.Len(events, 1)
+		s.Equal(message.ID, *events[0].EmailOutboxID)
+		s.Equal(models.EmailDeliveryEventTypeDelivery, events[0].EventType)
+	})
+
+	s.Run("repeated bounces flag the recipient as undeliverable", func() {
+		for i := 1; i < models.EmailBouncesBeforeUndeliverable; i++ {
+			recorded, err := store.RecordEmailDeliveryEvents(ctx, []*models.EmailDeliveryEvent{bounce(fmt.Sprintf("bounce-%d", i))})
+			s.NoError(err)
+			s.Equal(1, recorded)
+		}
+
+		statuses, err := GetTRBRequestEmailDeliveryStatuses(ctx, store, trbRequest.ID)
+		s.NoError(err)
+		s.Len(statuses, 1)
+		s.Equal(requester.ID, statuses[0].UserAccountID)
+		s.Equal(models.EmailBouncesBeforeUndeliverable-1, statuses[0].BounceCount)
+		s.Nil(statuses[0].UndeliverableAt)
+
+		// SNS can send the same notification more than once, which is only counted once
+		recorded, err := store.RecordEmailDeliveryEvents(ctx, []*models.EmailDeliveryEvent{bounce("bounce-1")})
+		s.NoError(err)
+		s.Equal(0, recorded)
+
+		recorded, err = store.RecordEmailDeliveryEvents(ctx, []*models.EmailDeliveryEvent{bounce("bounce-last")})
+		s.NoError(err)
+		s.Equal(1, recorded)
+
+		statuses, err = GetTRBRequestEmailDeliveryStatuses(ctx, store, trbRequest.ID)
+		s.NoError(err)
+		s.Len(statuses, 1)
+		s.Equal(models.EmailBouncesBeforeUndeliverable, statuses[0].BounceCount)
+		s.NotNil(statuses[0].UndeliverableAt)
+		s.Equal(models.EmailDeliveryEventTypeBounce, *statuses[0].LastEventType)
+		s.Equal("smtp; 550 5.1.1 user unknown", *statuses[0].LastDiagnostic)
+
+		account, err := store.UserAccountGetByID(ctx, store, requester.ID)
+		s.NoError(err)
+		s.NotNil(account.EmailUndeliverableAt)
+	})
+
+	s.Run("a delivery clears the recipient's bounces", func() {
+		delivery := bounce("delivered")
+		delivery.EventType = models.EmailDeliveryEventTypeDelivery
+		delivery.BounceType = nil
+		delivery.Diagnostic = nil
+		delivery.OccurredAt = occurredAt.Add(time.Minute)
+		_, err := store.RecordEmailDeliveryEvents(ctx, []*models.EmailDeliveryEvent{delivery})
+		s.NoError(err)
+
+		statuses, err := GetTRBRequestEmailDeliveryStatuses(ctx, store, trbRequest.ID)
+		s.NoError(err)
+		s.Len(statuses, 1)
+		s.Equal(0, statuses[0].BounceCount)
+		s.Nil(statuses[0].UndeliverableAt)
+		s.Equal(models.EmailDeliveryEventTypeDelivery, *statuses[0].LastEventType)
+	})
+}
@@ -0,0 +1,92 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// authorizeUserCanManageEmailOutbox checks that the principal is a GRT admin. The outbox holds every email EASi sends, including ones
+// that don't belong to either GRT or TRB requests, such as help requests, and emails aren't recorded with the kind of request they're about,
+// so TRB admins can't be limited to only their own emails
+func authorizeUserCanManageEmailOutbox(ctx context.Context) error {
+	if !appcontext.Principal(ctx).AllowGRT() {
+		return &apperrors.UnauthorizedError{Err: errors.New("unauthorized to manage the email outbox")}
+	}
+	return nil
+}
+
+// GetEmailOutboxMessages returns a page of the emails in the outbox, optionally only those with the given status, newest first
+func GetEmailOutboxMessages(
+	ctx context.Context,
+	store *storage.Store,
+	status *models.EmailOutboxMessageStatus,
+	first int,
+	after *string,
+) (*models.EmailOutboxMessageConnection, error) {
+	if err := authorizeUserCanManageEmailOutbox(ctx); err != nil {
+		return nil, err
+	}
+
+	cursor, err := decodePageArgs(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, hasNextPage, err := fetchPage(
+		first,
+		cursor,
+		func(limit int, after *models.PageCursor) ([]*models.EmailOutboxMessage, error) {
+			return store.GetEmailOutboxMessages(ctx, status, limit, after)
+		},
+		(*models.EmailOutboxMessage).Cursor,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*models.EmailOutboxMessageEdge, len(messages))
+	cursors := make([]string, len(messages))
+	for i, message := range messages {
+		cursors[i] = message.Cursor().Encode()
+		edges[i] = &models.EmailOutboxMessageEdge{
+			Cursor: cursors[i],
+			Node:   message,
+		}
+	}
+
+	return &models.EmailOutboxMessageConnection{
+		Edges:    edges,
+		PageInfo: newPageInfo(cursors, hasNextPage),
+	}, nil
+}
+
+// ResendEmailOutboxMessage moves an email that failed to send back to pending, so the outbox dispatcher sends it again right away
+func ResendEmailOutboxMessage(ctx context.Context, store *storage.Store, id uuid.UUID) (*models.EmailOutboxMessage, error) {
+	if err := authorizeUserCanManageEmailOutbox(ctx); err != nil {
+		return nil, err
+	}
+
+	message, err := store.GetEmailOutboxMessageByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.Status == models.EmailOutboxMessageStatusSent {
+		return nil, &apperrors.BadRequestError{Err: errors.New("email has already been sent")}
+	}
+
+	var modifiedBy *uuid.UUID
+	if account := appcontext.Principal(ctx).Account(); account != nil {
+		modifiedBy = &account.ID
+	}
+
+	return store.ResendEmailOutboxMessage(ctx, id, modifiedBy)
+}
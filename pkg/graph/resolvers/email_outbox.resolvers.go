@@ -0,0 +1,63 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/graph/generated"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// ToAddresses is the resolver for the toAddresses field.
+func (r *emailOutboxMessageResolver) ToAddresses(ctx context.Context, obj *models.EmailOutboxMessage) ([]models.EmailAddress, error) {
+	return obj.ToAddresses, nil
+}
+
+// CcAddresses is the resolver for the ccAddresses field.
+func (r *emailOutboxMessageResolver) CcAddresses(ctx context.Context, obj *models.EmailOutboxMessage) ([]models.EmailAddress, error) {
+	return obj.CcAddresses, nil
+}
+
+// BccAddresses is the resolver for the bccAddresses field.
+func (r *emailOutboxMessageResolver) BccAddresses(ctx context.Context, obj *models.EmailOutboxMessage) ([]models.EmailAddress, error) {
+	return obj.BccAddresses, nil
+}
+
+// DeliveryEvents is the resolver for the deliveryEvents field.
+func (r *emailOutboxMessageResolver) DeliveryEvents(ctx context.Context, obj *models.EmailOutboxMessage) ([]*models.EmailDeliveryEvent, error) {
+	return GetEmailDeliveryEventsByOutboxID(ctx, r.store, obj.ID)
+}
+
+// UserAccount is the resolver for the userAccount field.
+func (r *emailRecipientDeliveryStatusResolver) UserAccount(ctx context.Context, obj *models.EmailRecipientDeliveryStatus) (*authentication.UserAccount, error) {
+	return GetUserAccountByID(ctx, obj.UserAccountID)
+}
+
+// ResendEmailOutboxMessage is the resolver for the resendEmailOutboxMessage field.
+func (r *mutationResolver) ResendEmailOutboxMessage(ctx context.Context, id uuid.UUID) (*models.EmailOutboxMessage, error) {
+	return ResendEmailOutboxMessage(ctx, r.store, id)
+}
+
+// EmailOutboxMessages is the resolver for the emailOutboxMessages field.
+func (r *queryResolver) EmailOutboxMessages(ctx context.Context, status *models.EmailOutboxMessageStatus, first int, after *string) (*models.EmailOutboxMessageConnection, error) {
+	return GetEmailOutboxMessages(ctx, r.store, status, first, after)
+}
+
+// EmailOutboxMessage returns generated.EmailOutboxMessageResolver implementation.
+func (r *Resolver) EmailOutboxMessage() generated.EmailOutboxMessageResolver {
+	return &emailOutboxMessageResolver{r}
+}
+
+// EmailRecipientDeliveryStatus returns generated.EmailRecipientDeliveryStatusResolver implementation.
+func (r *Resolver) EmailRecipientDeliveryStatus() generated.EmailRecipientDeliveryStatusResolver {
+	return &emailRecipientDeliveryStatusResolver{r}
+}
+
+type emailOutboxMessageResolver struct{ *Resolver }
+type emailRecipientDeliveryStatusResolver struct{ *Resolver }
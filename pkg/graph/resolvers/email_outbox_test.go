@@ -0,0 +1,114 @@
+package resolvers
+
+import (
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+func (s *ResolverSuite) TestEmailOutbox() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+	sender := email.NewOutboxSender(store)
+
+	queue := func(subject string) {
+		s.NoError(sender.Send(ctx, email.NewEmail().
+			WithToAddresses([]models.EmailAddress{"requester@local.fake"}).
+			WithSubject(subject).
+			WithBody("<p>body</p>"),
+		))
+	}
+
+	s.Run("emails are queued in the transaction they're sent in", func() {
+		errRollback := errors.New("rollback")
+		err := sqlutils.WithTransaction(ctx, store, func(tx *sqlx.Tx) error {
+			s.NoError(sender.Send(email.WithOutboxTransaction(ctx, tx), email.NewEmail().WithSubject("rolled back")))
+			return errRollback
+		})
+		s.ErrorIs(err, errRollback)
+
+		s.NoError(sqlutils.WithTransaction(ctx, store, func(tx *sqlx.Tx) error {
+			return sender.Send(email.WithOutboxTransaction(ctx, tx), email.NewEmail().WithSubject("committed"))
+		}))
+
+		messages, err := GetEmailOutboxMessages(ctx, store, nil, 25, nil)
+		s.NoError(err)
+		s.Len(messages.Edges, 1)
+		s.Equal("committed", messages.Edges[0].Node.Subject)
+		s.Equal(models.EmailOutboxMessageStatusPending, messages.Edges[0].Node.Status)
+		s.Equal(s.testConfigs.Principal.Account().ID, *messages.Edges[0].Node.CreatedBy)
+	})
+
+	queue("first")
+	queue("second")
+
+	s.Run("pages through the outbox newest first, filtered by status", func() {
+		firstPage, err := GetEmailOutboxMessages(ctx, store, nil, 2, nil)
+		s.NoError(err)
+		s.Len(firstPage.Edges, 2)
+		s.True(firstPage.PageInfo.HasNextPage)
+		s.Equal("second", firstPage.Edges[0].Node.Subject)
+		s.Equal([]models.EmailAddress{"requester@local.fake"}, []models.EmailAddress(firstPage.Edges[0].Node.ToAddresses))
+
+		secondPage, err := GetEmailOutboxMessages(ctx, store, nil, 2, firstPage.PageInfo.EndCursor)
+		s.NoError(err)
+		s.Len(secondPage.Edges, 1)
+		s.False(secondPage.PageInfo.HasNextPage)
+
+		deadLetters, err := GetEmailOutboxMessages(ctx, store, helpers.PointerTo(models.EmailOutboxMessageStatusDeadLetter), 25, nil)
+		s.NoError(err)
+		s.Empty(deadLetters.Edges)
+	})
+
+	s.Run("failed emails can be re-sent, but sent ones can't", func() {
+		messages, err := GetEmailOutboxMessages(ctx, store, nil, 2, nil)
+		s.NoError(err)
+		failed := messages.Edges[0].Node
+		sent := messages.Edges[1].Node
+
+		failed.Attempts = models.EmailOutboxMaxAttempts - 1
+		failed.RecordFailure(failed.NextAttemptAt, errors.New("rejected"))
+		s.NoError(store.UpdateEmailOutboxMessageDelivery(ctx, store, failed))
+
+		sent.RecordSent(sent.NextAttemptAt)
+		s.NoError(store.UpdateEmailOutboxMessageDelivery(ctx, store, sent))
+
+		resent, err := ResendEmailOutboxMessage(ctx, store, failed.ID)
+		s.NoError(err)
+		s.Equal(models.EmailOutboxMessageStatusPending, resent.Status)
+		s.Equal(0, resent.Attempts)
+		s.Equal("rejected", *resent.LastError)
+
+		_, err = ResendEmailOutboxMessage(ctx, store, sent.ID)
+		s.Error(err)
+	})
+
+	s.Run("requires an admin", func() {
+		requesterCtx, _ := s.getTestContextWithPrincipal("USR1", false)
+
+		_, err := GetEmailOutboxMessages(requesterCtx, store, nil, 25, nil)
+		s.Error(err)
+	})
+
+	s.Run("TRB admins can't see or re-send emails", func() {
+		messages, err := GetEmailOutboxMessages(ctx, store, nil, 1, nil)
+		s.NoError(err)
+		s.Len(messages.Edges, 1)
+
+		trbAdminCtx, trbAdmin := s.getTestContextWithPrincipal("TRBA", true)
+		trbAdmin.JobCodeGRT = false
+
+		_, err = GetEmailOutboxMessages(trbAdminCtx, store, nil, 25, nil)
+		var unauthorizedErr *apperrors.UnauthorizedError
+		s.ErrorAs(err, &unauthorizedErr)
+
+		_, err = ResendEmailOutboxMessage(trbAdminCtx, store, messages.Edges[0].Node.ID)
+		s.ErrorAs(err, &unauthorizedErr)
+	})
+}
@@ -0,0 +1,95 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// emailPreviewData returns the data to render email previews for a system intake with, or for a made up request if systemIntakeID is nil.
+// Fields the intake hasn't filled in yet keep their sample values
+func emailPreviewData(ctx context.Context, store *storage.Store, systemIntakeID *uuid.UUID) (email.EmailPreviewData, error) {
+	data := email.SampleEmailPreviewData()
+	if systemIntakeID == nil {
+		return data, nil
+	}
+
+	intake, err := store.FetchSystemIntakeByID(ctx, *systemIntakeID)
+	if err != nil {
+		return email.EmailPreviewData{}, err
+	}
+
+	data.SystemIntakeID = intake.ID
+	if intake.ProjectName.String != "" {
+		data.ProjectName = intake.ProjectName.String
+	}
+	if intake.Requester != "" {
+		data.RequesterName = intake.Requester
+	}
+	if intake.Component.String != "" {
+		data.RequesterComponent = intake.Component.String
+	}
+	return data, nil
+}
+
+// GetEmailPreview renders an email template without sending it
+func GetEmailPreview(
+	ctx context.Context,
+	store *storage.Store,
+	emailClient *email.Client,
+	templateName string,
+	systemIntakeID *uuid.UUID,
+) (*models.EmailPreview, error) {
+	data, err := emailPreviewData(ctx, store, systemIntakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := emailClient.PreviewEmail(ctx, templateName, data)
+	if err != nil {
+		return nil, &apperrors.BadRequestError{Err: err}
+	}
+
+	return &models.EmailPreview{
+		TemplateName: preview.TemplateName,
+		Subject:      preview.Subject,
+		Body:         preview.Body,
+	}, nil
+}
+
+// SendEmailPreview renders an email template and sends it only to the principal, so they can see it as recipients would
+func SendEmailPreview(
+	ctx context.Context,
+	store *storage.Store,
+	emailClient *email.Client,
+	templateName string,
+	systemIntakeID *uuid.UUID,
+) (*models.EmailPreview, error) {
+	account := appcontext.Principal(ctx).Account()
+	if account == nil || account.Email == "" {
+		return nil, &apperrors.BadRequestError{Err: errors.New("signed in user doesn't have an email address to send the preview to")}
+	}
+
+	data, err := emailPreviewData(ctx, store, systemIntakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := emailClient.SendEmailPreview(ctx, templateName, data, models.NewEmailAddress(account.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.EmailPreview{
+		TemplateName: preview.TemplateName,
+		Subject:      preview.Subject,
+		Body:         preview.Body,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// SendEmailPreview is the resolver for the sendEmailPreview field.
+func (r *mutationResolver) SendEmailPreview(ctx context.Context, templateName string, systemIntakeID *uuid.UUID) (*models.EmailPreview, error) {
+	return SendEmailPreview(ctx, r.store, r.emailClient, templateName, systemIntakeID)
+}
+
+// EmailPreviewTemplateNames is the resolver for the emailPreviewTemplateNames field.
+func (r *queryResolver) EmailPreviewTemplateNames(ctx context.Context) ([]string, error) {
+	return email.EmailPreviewTemplateNames(), nil
+}
+
+// EmailPreview is the resolver for the emailPreview field.
+func (r *queryResolver) EmailPreview(ctx context.Context, templateName string, systemIntakeID *uuid.UUID) (*models.EmailPreview, error) {
+	return GetEmailPreview(ctx, r.store, r.emailClient, templateName, systemIntakeID)
+}
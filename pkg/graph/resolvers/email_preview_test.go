@@ -0,0 +1,51 @@
+package resolvers
+
+import (
+	"github.com/guregu/null"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *ResolverSuite) TestEmailPreview() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+	emailClient := s.testConfigs.EmailClient
+
+	s.Run("renders a template with sample data without sending it", func() {
+		preview, err := GetEmailPreview(ctx, store, emailClient, "system_intake_submit_initial_form_requester", nil)
+		s.NoError(err)
+		s.Equal("system_intake_submit_initial_form_requester", preview.TemplateName)
+		s.Contains(preview.Subject, "Sample Project")
+		s.NotEmpty(preview.Body)
+		s.False(s.testConfigs.Sender.emailWasSent)
+	})
+
+	s.Run("renders a template with a system intake's data", func() {
+		intake := s.createNewIntake(func(intake *models.SystemIntake) {
+			intake.ProjectName = null.StringFrom("Previewed Project")
+			intake.Component = null.StringFrom("Office of the Administrator")
+		})
+
+		preview, err := GetEmailPreview(ctx, store, emailClient, "system_intake_submit_initial_form_reviewer", &intake.ID)
+		s.NoError(err)
+		s.Contains(preview.Subject, "Previewed Project")
+		s.Contains(preview.Body, intake.ID.String())
+		s.Contains(preview.Body, "Office of the Administrator")
+	})
+
+	s.Run("returns an error for a template that doesn't exist", func() {
+		_, err := GetEmailPreview(ctx, store, emailClient, "not_a_template", nil)
+		s.Error(err)
+	})
+
+	s.Run("sends the preview to the signed in user", func() {
+		preview, err := SendEmailPreview(ctx, store, emailClient, "system_intake_not_approved", nil)
+		s.NoError(err)
+		s.True(s.testConfigs.Sender.emailWasSent)
+		s.Equal([]models.EmailAddress{models.NewEmailAddress(s.testConfigs.Principal.Account().Email)}, s.testConfigs.Sender.toAddresses)
+		s.Empty(s.testConfigs.Sender.ccAddresses)
+		s.Empty(s.testConfigs.Sender.bccAddresses)
+		s.Equal("[TEST] "+preview.Subject, s.testConfigs.Sender.subject)
+		s.Equal(preview.Body, s.testConfigs.Sender.body)
+	})
+}
@@ -0,0 +1,415 @@
+package resolvers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/export"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// exportListSeparator separates the values of cells that list several values, such as funding sources
+const exportListSeparator = "; "
+
+// forEachSystemIntakesPage calls fn with each page of the system intakes an admin would see in the admin table with the given
+// filter and sort, until every intake has been passed
+func forEachSystemIntakesPage(
+	ctx context.Context,
+	store *storage.Store,
+	filter models.SystemIntakesFilter,
+	sort models.SystemIntakesSort,
+	fn func(intakes []*models.SystemIntake) error,
+) error {
+	var after *string
+	for {
+		connection, err := SystemIntakesConnection(ctx, store, maxPageSize, after, &filter, &sort)
+		if err != nil {
+			return err
+		}
+
+		intakes := lo.Map(connection.Edges, func(edge *models.SystemIntakeEdge, _ int) *models.SystemIntake {
+			return edge.Node
+		})
+		if err := fn(intakes); err != nil {
+			return err
+		}
+
+		if !connection.PageInfo.HasNextPage {
+			return nil
+		}
+		after = connection.PageInfo.EndCursor
+	}
+}
+
+// ExportSystemIntakes writes a row for each system intake matching filter, in the order of sort
+func ExportSystemIntakes(
+	ctx context.Context,
+	store *storage.Store,
+	filter models.SystemIntakesFilter,
+	sort models.SystemIntakesSort,
+	w export.Writer,
+) error {
+	if err := w.WriteRow(
+		"Request ID",
+		"Project name",
+		"Acronym",
+		"Requester",
+		"Component",
+		"Request type",
+		"State",
+		"Admin status",
+		"Admin lead",
+		"Submitted at",
+		"Updated at",
+		"LCID",
+		"LCID status",
+		"LCID issued at",
+		"LCID expires at",
+		"LCID retires at",
+		"Funding sources",
+		"Contract numbers",
+	); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return forEachSystemIntakesPage(ctx, store, filter, sort, func(intakes []*models.SystemIntake) error {
+		intakeIDs := lo.Map(intakes, func(intake *models.SystemIntake, _ int) uuid.UUID {
+			return intake.ID
+		})
+
+		fundingSources, err := store.FetchSystemIntakeFundingSourcesByIntakeIDs(ctx, intakeIDs)
+		if err != nil {
+			return err
+		}
+		fundingSourcesByIntakeID := lo.GroupBy(fundingSources, func(source *models.SystemIntakeFundingSource) uuid.UUID {
+			return source.SystemIntakeID
+		})
+
+		contractNumbers, err := store.SystemIntakeContractNumbersBySystemIntakeIDs(ctx, intakeIDs)
+		if err != nil {
+			return err
+		}
+		contractNumbersByIntakeID := lo.GroupBy(contractNumbers, func(contractNumber *models.SystemIntakeContractNumber) uuid.UUID {
+			return contractNumber.SystemIntakeID
+		})
+
+		for _, intake := range intakes {
+			// intakes in an unexpected state are still exported, without an admin status
+			var adminStatus *string
+			if status, err := CalculateSystemIntakeAdminStatus(ctx, intake); err == nil {
+				adminStatus = lo.ToPtr(string(status))
+			}
+
+			var lcidStatus *string
+			if status := intake.LCIDStatus(now); status != nil {
+				lcidStatus = lo.ToPtr(string(*status))
+			}
+
+			fundingSources := lo.Map(fundingSourcesByIntakeID[intake.ID], func(source *models.SystemIntakeFundingSource, _ int) string {
+				return formatFundingSource(source.ProjectNumber.String, source.Investment.String)
+			})
+			contractNumbers := lo.Map(contractNumbersByIntakeID[intake.ID], func(contractNumber *models.SystemIntakeContractNumber, _ int) string {
+				return contractNumber.ContractNumber
+			})
+
+			if err := w.WriteRow(
+				intake.ID.String(),
+				intake.ProjectName.Ptr(),
+				intake.ProjectAcronym.Ptr(),
+				intake.Requester,
+				intake.Component.Ptr(),
+				string(intake.RequestType),
+				string(intake.State),
+				adminStatus,
+				intake.AdminLead.Ptr(),
+				intake.SubmittedAt,
+				intake.UpdatedAt,
+				intake.LifecycleID.Ptr(),
+				lcidStatus,
+				intake.LifecycleIssuedAt,
+				intake.LifecycleExpiresAt,
+				intake.LifecycleRetiresAt,
+				strings.Join(fundingSources, exportListSeparator),
+				strings.Join(contractNumbers, exportListSeparator),
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ExportBusinessCases writes a row for the business case of each system intake matching filter, in the order of sort.
+// Intakes without a business case are skipped
+func ExportBusinessCases(
+	ctx context.Context,
+	store *storage.Store,
+	filter models.SystemIntakesFilter,
+	sort models.SystemIntakesSort,
+	w export.Writer,
+) error {
+	if err := w.WriteRow(
+		"Request ID",
+		"Business case ID",
+		"Project name",
+		"Acronym",
+		"Requester",
+		"Business owner",
+		"Status",
+		"Preferred solution",
+		"Alternative A",
+		"Alternative B",
+		"Created at",
+		"Updated at",
+	); err != nil {
+		return err
+	}
+
+	return forEachSystemIntakesPage(ctx, store, filter, sort, func(intakes []*models.SystemIntake) error {
+		businessCases, err := fetchBusinessCasesInIntakeOrder(ctx, store, intakes)
+		if err != nil {
+			return err
+		}
+
+		for _, businessCase := range businessCases {
+			if err := w.WriteRow(
+				businessCase.SystemIntakeID.String(),
+				businessCase.ID.String(),
+				businessCase.ProjectName.Ptr(),
+				businessCase.ProjectAcronym.Ptr(),
+				businessCase.Requester.Ptr(),
+				businessCase.BusinessOwner.Ptr(),
+				string(businessCase.Status),
+				businessCase.PreferredTitle.Ptr(),
+				businessCase.AlternativeATitle.Ptr(),
+				businessCase.AlternativeBTitle.Ptr(),
+				businessCase.CreatedAt,
+				businessCase.UpdatedAt,
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ExportLifecycleCosts writes a row for each estimated lifecycle cost in the business case of each system intake matching filter.
+// Business cases are in the order of sort, and their costs are ordered by solution, year and phase
+func ExportLifecycleCosts(
+	ctx context.Context,
+	store *storage.Store,
+	filter models.SystemIntakesFilter,
+	sort models.SystemIntakesSort,
+	w export.Writer,
+) error {
+	if err := w.WriteRow(
+		"Request ID",
+		"Business case ID",
+		"Project name",
+		"Solution",
+		"Phase",
+		"Year",
+		"Cost",
+	); err != nil {
+		return err
+	}
+
+	return forEachSystemIntakesPage(ctx, store, filter, sort, func(intakes []*models.SystemIntake) error {
+		businessCases, err := fetchBusinessCasesInIntakeOrder(ctx, store, intakes)
+		if err != nil {
+			return err
+		}
+
+		businessCaseIDs := lo.Map(businessCases, func(businessCase *models.BusinessCase, _ int) uuid.UUID {
+			return businessCase.ID
+		})
+		costs, err := store.GetLifecycleCostsByBizCaseIDs(ctx, businessCaseIDs)
+		if err != nil {
+			return err
+		}
+		costsByBusinessCaseID := lo.GroupBy(costs, func(cost *models.EstimatedLifecycleCost) uuid.UUID {
+			return cost.BusinessCaseID
+		})
+
+		for _, businessCase := range businessCases {
+			businessCaseCosts := costsByBusinessCaseID[businessCase.ID]
+			slices.SortFunc(businessCaseCosts, func(a, b *models.EstimatedLifecycleCost) int {
+				return cmp.Or(
+					cmp.Compare(a.Solution, b.Solution),
+					cmp.Compare(a.Year, b.Year),
+					cmp.Compare(lo.FromPtr(a.Phase), lo.FromPtr(b.Phase)),
+				)
+			})
+
+			for _, cost := range businessCaseCosts {
+				if err := w.WriteRow(
+					businessCase.SystemIntakeID.String(),
+					businessCase.ID.String(),
+					businessCase.ProjectName.Ptr(),
+					string(cost.Solution),
+					(*string)(cost.Phase),
+					string(cost.Year),
+					cost.Cost,
+				); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// fetchBusinessCasesInIntakeOrder returns the business cases of the intakes, in the same order as the intakes
+func fetchBusinessCasesInIntakeOrder(ctx context.Context, store *storage.Store, intakes []*models.SystemIntake) ([]*models.BusinessCase, error) {
+	intakeIDs := lo.Map(intakes, func(intake *models.SystemIntake, _ int) uuid.UUID {
+		return intake.ID
+	})
+
+	businessCases, err := store.GetBusinessCaseBySystemIntakeIDs(ctx, intakeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	businessCasesByIntakeID := lo.KeyBy(businessCases, func(businessCase *models.BusinessCase) uuid.UUID {
+		return businessCase.SystemIntakeID
+	})
+
+	var ordered []*models.BusinessCase
+	for _, intake := range intakes {
+		if businessCase, ok := businessCasesByIntakeID[intake.ID]; ok {
+			ordered = append(ordered, businessCase)
+		}
+	}
+	return ordered, nil
+}
+
+// ExportTRBRequests writes a row for each TRB request matching filter, in the order of sort
+func ExportTRBRequests(
+	ctx context.Context,
+	store *storage.Store,
+	filter models.TRBRequestsFilter,
+	sort models.TRBRequestsSort,
+	w export.Writer,
+) error {
+	if err := w.WriteRow(
+		"Request ID",
+		"Name",
+		"Type",
+		"State",
+		"Status",
+		"TRB lead",
+		"Requester",
+		"Component",
+		"Created at",
+		"Submitted at",
+		"Updated at",
+		"Consult meeting time",
+		"Funding sources",
+		"Contract numbers",
+	); err != nil {
+		return err
+	}
+
+	var after *string
+	for {
+		connection, err := GetTRBRequestsConnection(ctx, store, maxPageSize, after, &filter, &sort)
+		if err != nil {
+			return err
+		}
+
+		trbRequestIDs := lo.Map(connection.Edges, func(edge *models.TRBRequestEdge, _ int) uuid.UUID {
+			return edge.Node.ID
+		})
+
+		forms, err := store.GetTRBRequestFormsByTRBRequestIDs(ctx, trbRequestIDs)
+		if err != nil {
+			return err
+		}
+		formsByTRBRequestID := lo.KeyBy(forms, func(form *models.TRBRequestForm) uuid.UUID {
+			return form.TRBRequestID
+		})
+
+		fundingSources, err := store.GetTRBFundingSourcesByRequestIDs(ctx, trbRequestIDs)
+		if err != nil {
+			return err
+		}
+		fundingSourcesByTRBRequestID := lo.GroupBy(fundingSources, func(source *models.TRBFundingSource) uuid.UUID {
+			return source.TRBRequestID
+		})
+
+		contractNumbers, err := store.TRBRequestContractNumbersByTRBRequestIDs(ctx, trbRequestIDs)
+		if err != nil {
+			return err
+		}
+		contractNumbersByTRBRequestID := lo.GroupBy(contractNumbers, func(contractNumber *models.TRBRequestContractNumber) uuid.UUID {
+			return contractNumber.TRBRequestID
+		})
+
+		for _, edge := range connection.Edges {
+			trbRequest := edge.Node
+
+			status, err := GetTRBRequestStatus(ctx, *trbRequest)
+			if err != nil {
+				return err
+			}
+
+			var component *string
+			var submittedAt *time.Time
+			if form, ok := formsByTRBRequestID[trbRequest.ID]; ok {
+				component = form.Component
+				submittedAt = form.SubmittedAt
+			}
+
+			fundingSources := lo.Map(fundingSourcesByTRBRequestID[trbRequest.ID], func(source *models.TRBFundingSource, _ int) string {
+				return formatFundingSource(source.FundingNumber, source.Source)
+			})
+			contractNumbers := lo.Map(contractNumbersByTRBRequestID[trbRequest.ID], func(contractNumber *models.TRBRequestContractNumber, _ int) string {
+				return contractNumber.ContractNumber
+			})
+
+			if err := w.WriteRow(
+				trbRequest.ID.String(),
+				trbRequest.Name,
+				string(trbRequest.Type),
+				string(trbRequest.State),
+				string(status),
+				trbRequest.TRBLead,
+				trbRequest.CreatedBy,
+				component,
+				trbRequest.CreatedAt,
+				submittedAt,
+				trbRequest.ModifiedAt,
+				trbRequest.ConsultMeetingTime,
+				strings.Join(fundingSources, exportListSeparator),
+				strings.Join(contractNumbers, exportListSeparator),
+			); err != nil {
+				return err
+			}
+		}
+
+		if !connection.PageInfo.HasNextPage {
+			return nil
+		}
+		after = connection.PageInfo.EndCursor
+	}
+}
+
+// formatFundingSource formats a funding source as its number followed by its source, e.g. "123456 (Fed Admin)"
+func formatFundingSource(number string, source string) string {
+	if source == "" {
+		return number
+	}
+	return fmt.Sprintf("%s (%s)", number, source)
+}
@@ -0,0 +1,156 @@
+package resolvers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/cms-enterprise/easi-app/pkg/export"
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// exportCSV runs an export to CSV, and returns the rows that were written
+func (s *ResolverSuite) exportCSV(exportFunc func(w export.Writer) error) [][]string {
+	var buf bytes.Buffer
+	w, err := export.NewWriter(export.FormatCSV, &buf, "")
+	s.NoError(err)
+	s.NoError(exportFunc(w))
+	s.NoError(w.Close())
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	s.NoError(err)
+	return rows
+}
+
+func (s *ResolverSuite) TestExportSystemIntakes() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+
+	submittedAt := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	intake, err := storage.CreateSystemIntake(ctx, store, &models.SystemIntake{
+		State:       models.SystemIntakeStateOpen,
+		RequestType: models.SystemIntakeRequestTypeNEW,
+	})
+	s.NoError(err)
+	intake.ProjectName = null.StringFrom("Exported Project")
+	intake.AdminLead = null.StringFrom("Ann Rudolph")
+	intake.SubmittedAt = &submittedAt
+	intake, err = store.UpdateSystemIntake(ctx, intake)
+	s.NoError(err)
+
+	_, err = store.UpdateSystemIntakeFundingSources(ctx, intake.ID, []*models.SystemIntakeFundingSource{
+		{
+			SystemIntakeID: intake.ID,
+			ProjectNumber:  null.StringFrom("111111"),
+			Investment:     null.StringFrom("Fed Admin"),
+		},
+	})
+	s.NoError(err)
+	s.NoError(sqlutils.WithTransaction(ctx, store, func(tx *sqlx.Tx) error {
+		return store.SetSystemIntakeContractNumbers(ctx, tx, intake.ID, []string{"CN-1"})
+	}))
+
+	// not matched by the filter
+	_, err = storage.CreateSystemIntake(ctx, store, &models.SystemIntake{
+		State:       models.SystemIntakeStateOpen,
+		RequestType: models.SystemIntakeRequestTypeRECOMPETE,
+	})
+	s.NoError(err)
+
+	filter := models.SystemIntakesFilter{
+		RequestTypes: []models.SystemIntakeRequestType{models.SystemIntakeRequestTypeNEW},
+	}
+	sort := models.SystemIntakesSort{
+		Field:     models.SystemIntakesSortFieldSubmittedAt,
+		Direction: models.SortDirectionDesc,
+	}
+
+	s.Run("exports a row for each intake matching the filter", func() {
+		rows := s.exportCSV(func(w export.Writer) error {
+			return ExportSystemIntakes(s.ctxWithNewDataloaders(), store, filter, sort, w)
+		})
+
+		s.Len(rows, 2)
+		header, row := rows[0], rows[1]
+		s.Equal(len(header), len(row))
+
+		cell := func(column string) string {
+			for i, name := range header {
+				if name == column {
+					return row[i]
+				}
+			}
+			s.Failf("missing column", "column %s is not exported", column)
+			return ""
+		}
+		s.Equal(intake.ID.String(), cell("Request ID"))
+		s.Equal("Exported Project", cell("Project name"))
+		s.Equal("NEW", cell("Request type"))
+		s.Equal("Ann Rudolph", cell("Admin lead"))
+		s.Equal("2024-05-01 12:00:00", cell("Submitted at"))
+		s.Equal("111111 (Fed Admin)", cell("Funding sources"))
+		s.Equal("CN-1", cell("Contract numbers"))
+	})
+
+	s.Run("exports lifecycle costs ordered by solution, year and phase", func() {
+		dev := models.LifecycleCostPhaseDEVELOPMENT
+		oam := models.LifecycleCostPhaseOPERATIONMAINTENANCE
+		businessCase, err := store.CreateBusinessCase(ctx, &models.BusinessCaseWithCosts{
+			BusinessCase: models.BusinessCase{
+				SystemIntakeID: intake.ID,
+				Status:         models.BusinessCaseStatusOPEN,
+				EUAUserID:      "TEST",
+				ProjectName:    null.StringFrom("Exported Project"),
+			},
+			LifecycleCostLines: models.EstimatedLifecycleCosts{
+				{Solution: models.LifecycleCostSolutionPREFERRED, Phase: &oam, Year: models.LifecycleCostYear2, Cost: helpers.PointerTo(int64(300))},
+				{Solution: models.LifecycleCostSolutionPREFERRED, Phase: &dev, Year: models.LifecycleCostYear1, Cost: helpers.PointerTo(int64(100))},
+				{Solution: models.LifecycleCostSolutionA, Phase: &dev, Year: models.LifecycleCostYear1, Cost: helpers.PointerTo(int64(200))},
+			},
+		})
+		s.NoError(err)
+
+		rows := s.exportCSV(func(w export.Writer) error {
+			return ExportLifecycleCosts(s.ctxWithNewDataloaders(), store, filter, sort, w)
+		})
+
+		s.Equal([][]string{
+			{"Request ID", "Business case ID", "Project name", "Solution", "Phase", "Year", "Cost"},
+			{intake.ID.String(), businessCase.ID.String(), "Exported Project", "A", string(dev), "1", "200"},
+			{intake.ID.String(), businessCase.ID.String(), "Exported Project", "Preferred", string(dev), "1", "100"},
+			{intake.ID.String(), businessCase.ID.String(), "Exported Project", "Preferred", string(oam), "2", "300"},
+		}, rows)
+
+		businessCaseRows := s.exportCSV(func(w export.Writer) error {
+			return ExportBusinessCases(s.ctxWithNewDataloaders(), store, filter, sort, w)
+		})
+		s.Len(businessCaseRows, 2)
+		s.Equal(businessCase.ID.String(), businessCaseRows[1][1])
+	})
+}
+
+func (s *ResolverSuite) TestExportTRBRequests() {
+	store := s.testConfigs.Store
+
+	trbRequest := s.createNewTRBRequest(func(t *models.TRBRequest) {
+		t.Name = helpers.PointerTo("Exported TRB Request")
+	})
+
+	rows := s.exportCSV(func(w export.Writer) error {
+		return ExportTRBRequests(s.ctxWithNewDataloaders(), store, models.TRBRequestsFilter{}, models.TRBRequestsSort{
+			Field:     models.TRBRequestsSortFieldSubmittedAt,
+			Direction: models.SortDirectionDesc,
+		}, w)
+	})
+
+	s.Len(rows, 2)
+	s.Equal("Request ID", rows[0][0])
+	s.Equal(trbRequest.ID.String(), rows[1][0])
+	s.Equal("Exported TRB Request", rows[1][1])
+}
@@ -0,0 +1,143 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/graph/model/subscribers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/models/pubsubevents"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// notificationsAccount returns the user account of the principal, whose notifications are being accessed
+func notificationsAccount(ctx context.Context) (*authentication.UserAccount, error) {
+	account := appcontext.Principal(ctx).Account()
+	if account == nil {
+		return nil, &apperrors.UnauthorizedError{Err: errors.New("no user account found for notifications")}
+	}
+	return account, nil
+}
+
+// GetMyNotifications returns a page of the notifications in the current user's inbox, optionally only the unread ones, newest first
+func GetMyNotifications(
+	ctx context.Context,
+	store *storage.Store,
+	first int,
+	after *string,
+	unreadOnly bool,
+) (*models.NotificationConnection, error) {
+	account, err := notificationsAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := decodePageArgs(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, hasNextPage, err := fetchPage(
+		first,
+		cursor,
+		func(limit int, after *models.PageCursor) ([]*models.Notification, error) {
+			return store.GetNotificationsByUserID(ctx, account.ID, unreadOnly, limit, after)
+		},
+		(*models.Notification).Cursor,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	unreadCount, err := store.CountUnreadNotifications(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*models.NotificationEdge, len(notifications))
+	cursors := make([]string, len(notifications))
+	for i, notification := range notifications {
+		cursors[i] = notification.Cursor().Encode()
+		edges[i] = &models.NotificationEdge{
+			Cursor: cursors[i],
+			Node:   notification,
+		}
+	}
+
+	return &models.NotificationConnection{
+		Edges:       edges,
+		PageInfo:    newPageInfo(cursors, hasNextPage),
+		UnreadCount: unreadCount,
+	}, nil
+}
+
+// MarkNotificationsRead marks the given notifications in the current user's inbox read, or all of them if ids is nil
+func MarkNotificationsRead(ctx context.Context, store *storage.Store, ids []uuid.UUID) ([]*models.Notification, error) {
+	account, err := notificationsAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.MarkNotificationsRead(ctx, account.ID, ids)
+}
+
+// OnNotificationCreated subscribes the principal to notifications added to their inbox
+func OnNotificationCreated(
+	ctx context.Context,
+	store *storage.Store,
+	ps pubsub.PubSub,
+	onDisconnect <-chan struct{},
+) (<-chan *models.Notification, error) {
+	account, err := notificationsAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := appcontext.ZLogger(ctx)
+	subscriber := subscribers.NewNotificationCreatedSubscriber(appcontext.Principal(ctx), logger)
+	ps.Subscribe(account.ID, pubsubevents.NotificationCreated, subscriber, onDisconnect)
+
+	created := make(chan *models.Notification)
+	go func() {
+		defer close(created)
+
+		for {
+			select {
+			case <-onDisconnect:
+				return
+
+			case event := <-subscriber.GetChannel():
+				notification, err := store.GetNotificationByID(ctx, event.NotificationID)
+				if err != nil {
+					// a notification published outside of WithDeferredNotifications may be part of a transaction that was rolled back, and won't be found
+					logger.Warn("problem getting notification for subscriber, skipping it",
+						zap.Error(err),
+						zap.String("notification.id", event.NotificationID.String()),
+					)
+					continue
+				}
+
+				// events are published to the session of the user the notification belongs to, but check before sending it on
+				if notification.UserID != account.ID {
+					continue
+				}
+
+				select {
+				case <-onDisconnect:
+					return
+				case created <- notification:
+				}
+			}
+		}
+	}()
+
+	return created, nil
+}
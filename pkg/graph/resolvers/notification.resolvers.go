@@ -0,0 +1,28 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// MarkNotificationsRead is the resolver for the markNotificationsRead field.
+func (r *mutationResolver) MarkNotificationsRead(ctx context.Context, ids []uuid.UUID) ([]*models.Notification, error) {
+	return MarkNotificationsRead(ctx, r.store, ids)
+}
+
+// MyNotifications is the resolver for the myNotifications field.
+func (r *queryResolver) MyNotifications(ctx context.Context, first int, after *string, unreadOnly bool) (*models.NotificationConnection, error) {
+	return GetMyNotifications(ctx, r.store, first, after, unreadOnly)
+}
+
+// OnNotificationCreated is the resolver for the onNotificationCreated field.
+func (r *subscriptionResolver) OnNotificationCreated(ctx context.Context) (<-chan *models.Notification, error) {
+	return OnNotificationCreated(ctx, r.store, r.pubsub, ctx.Done())
+}
@@ -0,0 +1,57 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// GetMyNotificationPreferences returns how the current user receives each category of notification
+func GetMyNotificationPreferences(ctx context.Context, store *storage.Store) ([]*models.NotificationPreference, error) {
+	account := appcontext.Principal(ctx).Account()
+	if account == nil {
+		return nil, &apperrors.UnauthorizedError{Err: errors.New("no user account found for notification preferences")}
+	}
+
+	preferences, err := store.GetNotificationPreferencesByUserID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NotificationPreferencesWithDefaults(preferences), nil
+}
+
+// UpdateMyNotificationPreferences changes how the current user receives the given categories of notification
+func UpdateMyNotificationPreferences(
+	ctx context.Context,
+	store *storage.Store,
+	input []*models.UpdateNotificationPreferenceInput,
+) ([]*models.NotificationPreference, error) {
+	account := appcontext.Principal(ctx).Account()
+	if account == nil {
+		return nil, &apperrors.UnauthorizedError{Err: errors.New("no user account found for notification preferences")}
+	}
+
+	if err := sqlutils.WithTransaction(ctx, store, func(tx *sqlx.Tx) error {
+		for _, preference := range input {
+			if err := store.SetNotificationPreference(ctx, tx, account.ID, &models.NotificationPreference{
+				Category:  preference.Category,
+				Frequency: preference.Frequency,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return GetMyNotificationPreferences(ctx, store)
+}
@@ -0,0 +1,21 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// UpdateMyNotificationPreferences is the resolver for the updateMyNotificationPreferences field.
+func (r *mutationResolver) UpdateMyNotificationPreferences(ctx context.Context, input []*models.UpdateNotificationPreferenceInput) ([]*models.NotificationPreference, error) {
+	return UpdateMyNotificationPreferences(ctx, r.store, input)
+}
+
+// MyNotificationPreferences is the resolver for the myNotificationPreferences field.
+func (r *queryResolver) MyNotificationPreferences(ctx context.Context) ([]*models.NotificationPreference, error) {
+	return GetMyNotificationPreferences(ctx, r.store)
+}
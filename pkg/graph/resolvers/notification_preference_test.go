@@ -0,0 +1,47 @@
+package resolvers
+
+import (
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *ResolverSuite) TestNotificationPreferences() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+
+	s.Run("every category is sent immediately by default", func() {
+		preferences, err := GetMyNotificationPreferences(ctx, store)
+		s.NoError(err)
+		s.Equal(models.NotificationPreferencesWithDefaults(nil), preferences)
+	})
+
+	s.Run("preferences can be changed, and changed again", func() {
+		_, err := UpdateMyNotificationPreferences(ctx, store, []*models.UpdateNotificationPreferenceInput{
+			{Category: models.NotificationCategoryGRBDiscussions, Frequency: models.NotificationFrequencyOff},
+		})
+		s.NoError(err)
+
+		preferences, err := UpdateMyNotificationPreferences(ctx, store, []*models.UpdateNotificationPreferenceInput{
+			{Category: models.NotificationCategoryGRBDiscussions, Frequency: models.NotificationFrequencyDailyDigest},
+			{Category: models.NotificationCategoryTRBRequests, Frequency: models.NotificationFrequencyOff},
+		})
+		s.NoError(err)
+		s.Equal([]*models.NotificationPreference{
+			{Category: models.NotificationCategoryGRBDiscussions, Frequency: models.NotificationFrequencyDailyDigest},
+			{Category: models.NotificationCategoryGRBReviews, Frequency: models.NotificationFrequencyImmediate},
+			{Category: models.NotificationCategoryTRBRequests, Frequency: models.NotificationFrequencyOff},
+			{Category: models.NotificationCategorySystemWorkspaces, Frequency: models.NotificationFrequencyImmediate},
+		}, preferences)
+	})
+
+	s.Run("preferences are applied to recipients by their email address", func() {
+		account := s.testConfigs.Principal.Account()
+		recipients, err := store.GetNotificationRecipientPreferences(ctx, store, models.NotificationCategoryGRBDiscussions, []models.EmailAddress{
+			models.EmailAddress(account.Email),
+			"not-a-user@local.fake",
+		})
+		s.NoError(err)
+		s.Len(recipients, 1)
+		s.Equal(account.ID, recipients[0].UserID)
+		s.Equal(models.NotificationFrequencyDailyDigest, recipients[0].Frequency)
+	})
+}
@@ -0,0 +1,140 @@
+package resolvers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+func (s *ResolverSuite) TestNotifications() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+	ps := pubsub.NewServicePubSub()
+	account := s.testConfigs.Principal.Account()
+
+	disconnect := make(chan struct{})
+	defer close(disconnect)
+
+	created, err := OnNotificationCreated(ctx, store, ps, disconnect)
+	s.NoError(err)
+
+	sender := email.NewNotificationCenterSender(store, ps, s.testConfigs.Sender)
+	for _, subject := range []string{"first", "second"} {
+		s.NoError(sender.Send(ctx, email.NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBDiscussions).
+			WithToAddresses([]models.EmailAddress{models.EmailAddress(account.Email), "shared-mailbox@local.fake"}).
+			WithSubject(subject).
+			WithBody("<p>"+subject+"</p>"),
+		))
+	}
+
+	s.Run("new notifications are sent to subscribers", func() {
+		for _, subject := range []string{"first", "second"} {
+			select {
+			case notification := <-created:
+				s.Equal(subject, notification.Subject)
+				s.Equal(account.ID, notification.UserID)
+			case <-time.After(time.Second):
+				s.Fail("notification was not sent to subscriber")
+			}
+		}
+	})
+
+	s.Run("notifications are listed newest first with the unread count", func() {
+		notifications, err := GetMyNotifications(ctx, store, 1, nil, false)
+		s.NoError(err)
+		s.Equal(2, notifications.UnreadCount)
+		s.Len(notifications.Edges, 1)
+		s.Equal("second", notifications.Edges[0].Node.Subject)
+		s.EqualValues("<p>second</p>", notifications.Edges[0].Node.Body)
+		s.True(notifications.PageInfo.HasNextPage)
+
+		nextPage, err := GetMyNotifications(ctx, store, 1, notifications.PageInfo.EndCursor, false)
+		s.NoError(err)
+		s.Len(nextPage.Edges, 1)
+		s.Equal("first", nextPage.Edges[0].Node.Subject)
+		s.False(nextPage.PageInfo.HasNextPage)
+	})
+
+	s.Run("notifications can be marked read", func() {
+		notifications, err := GetMyNotifications(ctx, store, 25, nil, true)
+		s.NoError(err)
+		s.Len(notifications.Edges, 2)
+
+		// other users can't mark someone else's notifications read
+		otherCtx, _ := s.getTestContextWithPrincipal("BTMN", false)
+		marked, err := MarkNotificationsRead(otherCtx, store, nil)
+		s.NoError(err)
+		s.Empty(marked)
+
+		marked, err = MarkNotificationsRead(ctx, store, []uuid.UUID{notifications.Edges[0].Node.ID})
+		s.NoError(err)
+		s.Len(marked, 1)
+		s.NotNil(marked[0].ReadAt)
+
+		notifications, err = GetMyNotifications(ctx, store, 25, nil, true)
+		s.NoError(err)
+		s.Equal(1, notifications.UnreadCount)
+		s.Len(notifications.Edges, 1)
+		s.Equal("first", notifications.Edges[0].Node.Subject)
+
+		marked, err = MarkNotificationsRead(ctx, store, nil)
+		s.NoError(err)
+		s.Len(marked, 1)
+
+		notifications, err = GetMyNotifications(ctx, store, 25, nil, false)
+		s.NoError(err)
+		s.Zero(notifications.UnreadCount)
+		s.Len(notifications.Edges, 2)
+	})
+}
+
+func (s *ResolverSuite) TestNotificationsAddedInOutboxTransaction() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+	ps := pubsub.NewServicePubSub()
+	account := s.testConfigs.Principal.Account()
+
+	disconnect := make(chan struct{})
+	defer close(disconnect)
+
+	created, err := OnNotificationCreated(ctx, store, ps, disconnect)
+	s.NoError(err)
+
+	sender := email.NewNotificationCenterSender(store, ps, s.testConfigs.Sender)
+	deferredCtx, publishNotifications := email.WithDeferredNotifications(ctx)
+	err = sqlutils.WithTransaction(ctx, store, func(tx *sqlx.Tx) error {
+		if err := sender.Send(email.WithOutboxTransaction(deferredCtx, tx), email.NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBDiscussions).
+			WithToAddresses([]models.EmailAddress{models.EmailAddress(account.Email)}).
+			WithSubject("tagged").
+			WithBody("<p>tagged</p>"),
+		); err != nil {
+			return err
+		}
+
+		// nothing is published until the transaction commits, since subscribers couldn't load the notification yet
+		select {
+		case <-created:
+			s.Fail("notification was sent to subscriber before it was committed")
+		case <-time.After(100 * time.Millisecond):
+		}
+		return nil
+	})
+	s.NoError(err)
+
+	publishNotifications()
+	select {
+	case notification := <-created:
+		s.Equal("tagged", notification.Subject)
+		s.Equal(account.ID, notification.UserID)
+	case <-time.After(time.Second):
+		s.Fail("notification was not sent to subscriber")
+	}
+}
@@ -0,0 +1,99 @@
+package resolvers
+
+import (
+	"fmt"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// maxPageSize is the largest number of results that can be requested at once from a cursor paginated connection
+const maxPageSize = 100
+
+// validatePageSize checks the number of results requested from a cursor paginated connection
+func validatePageSize(first int) error {
+	if first < 1 || first > maxPageSize {
+		return &apperrors.BadRequestError{Err: fmt.Errorf("first must be between 1 and %d", maxPageSize)}
+	}
+
+	return nil
+}
+
+// decodePageArgs validates the size of a requested page, and decodes the cursor the page starts after, if any
+func decodePageArgs(first int, after *string) (*models.PageCursor, error) {
+	if err := validatePageSize(first); err != nil {
+		return nil, err
+	}
+
+	if after == nil {
+		return nil, nil
+	}
+
+	cursor, err := models.DecodePageCursor(*after)
+	if err != nil {
+		return nil, &apperrors.BadRequestError{Err: err}
+	}
+
+	return cursor, nil
+}
+
+// fetchPage returns up to first results after the given cursor, and whether there are more results after them.
+//
+// fetch should return up to limit results in order, starting after the given cursor. Results rejected by include are skipped,
+// which is used for filters on calculated values that can't be applied in SQL, and more results are fetched until the page is full.
+// include may be nil if every result should be included
+func fetchPage[T any, C any](
+	first int,
+	after *C,
+	fetch func(limit int, after *C) ([]T, error),
+	cursor func(T) C,
+	include func(T) (bool, error),
+) ([]T, bool, error) {
+	// fetch one more result than requested to know if there is another page
+	limit := first + 1
+
+	var page []T
+	for {
+		results, err := fetch(limit, after)
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, result := range results {
+			if include != nil {
+				ok, err := include(result)
+				if err != nil {
+					return nil, false, err
+				}
+				if !ok {
+					continue
+				}
+			}
+
+			page = append(page, result)
+			if len(page) > first {
+				return page[:first], true, nil
+			}
+		}
+
+		if len(results) < limit {
+			return page, false, nil
+		}
+
+		next := cursor(results[len(results)-1])
+		after = &next
+	}
+}
+
+// newPageInfo returns the page info for a page of results, given the cursors of its edges and whether there are more results after it
+func newPageInfo(cursors []string, hasNextPage bool) *models.PageInfo {
+	pageInfo := &models.PageInfo{
+		HasNextPage: hasNextPage,
+	}
+
+	if len(cursors) > 0 {
+		pageInfo.EndCursor = &cursors[len(cursors)-1]
+	}
+
+	return pageInfo
+}
@@ -29,6 +29,9 @@ type Resolver struct {
 	ldClient        *ldclient.LDClient
 	cedarCoreClient *cedarcore.Client
 	pubsub          pubsub.PubSub
+
+	// allowLocalWebhookURLs lets webhook subscriptions use plain HTTP URLs on the local machine, for local development and tests
+	allowLocalWebhookURLs bool
 }
 
 // ResolverService holds service methods for use in resolvers
@@ -48,6 +51,7 @@ func NewResolver(
 	ldClient *ldclient.LDClient,
 	cedarCoreClient *cedarcore.Client,
 	ps pubsub.PubSub,
+	allowLocalWebhookURLs bool,
 ) *Resolver {
 	return &Resolver{
 		store:           store,
@@ -57,6 +61,8 @@ func NewResolver(
 		ldClient:        ldClient,
 		cedarCoreClient: cedarCoreClient,
 		pubsub:          ps,
+
+		allowLocalWebhookURLs: allowLocalWebhookURLs,
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
 	"github.com/cms-enterprise/easi-app/pkg/dataloaders"
@@ -151,6 +152,11 @@ func (r *cedarSoftwareProductsResolver) SoftwareProducts(ctx context.Context, ob
 	return softwareProductItems, nil
 }
 
+// RequiredRoles is the resolver for the requiredRoles field.
+func (r *gRBQuorumPolicyResolver) RequiredRoles(ctx context.Context, obj *models.GRBQuorumPolicy) ([]models.SystemIntakeGRBReviewerRole, error) {
+	return obj.RequiredRoles, nil
+}
+
 // Author is the resolver for the author field.
 func (r *governanceRequestFeedbackResolver) Author(ctx context.Context, obj *models.GovernanceRequestFeedback) (*models.UserInfo, error) {
 	return GetGovernanceRequestFeedbackAuthor(ctx, obj.CreatedBy)
@@ -416,7 +422,7 @@ func (r *mutationResolver) UpdateSystemIntakeReviewDates(ctx context.Context, in
 		return nil, err
 	}
 
-	return UpdateSystemIntakeReviewDates(ctx, r.store, input)
+	return UpdateSystemIntakeReviewDates(ctx, r.store, r.emailClient, input)
 }
 
 // UpdateSystemIntakeContactDetails is the resolver for the updateSystemIntakeContactDetails field.
@@ -638,7 +644,7 @@ func (r *mutationResolver) CreateSystemIntakeGRBReviewers(ctx context.Context, i
 		return nil, err
 	}
 
-	return CreateSystemIntakeGRBReviewers(ctx, r.store, r.emailClient, userhelpers.GetUserInfoAccountInfosWrapperFunc(r.service.FetchUserInfos), &input)
+	return CreateSystemIntakeGRBReviewers(ctx, r.store, r.emailClient, r.pubsub, userhelpers.GetUserInfoAccountInfosWrapperFunc(r.service.FetchUserInfos), &input)
 }
 
 // UpdateSystemIntakeGRBReviewer is the resolver for the updateSystemIntakeGRBReviewer field.
@@ -647,7 +653,7 @@ func (r *mutationResolver) UpdateSystemIntakeGRBReviewer(ctx context.Context, in
 		return nil, err
 	}
 
-	return UpdateSystemIntakeGRBReviewer(ctx, r.store, &input)
+	return UpdateSystemIntakeGRBReviewer(ctx, r.store, r.pubsub, &input)
 }
 
 // DeleteSystemIntakeGRBReviewer is the resolver for the deleteSystemIntakeGRBReviewer field.
@@ -656,7 +662,7 @@ func (r *mutationResolver) DeleteSystemIntakeGRBReviewer(ctx context.Context, in
 		return uuid.Nil, err
 	}
 
-	return input.ReviewerID, DeleteSystemIntakeGRBReviewer(ctx, r.store, input.ReviewerID)
+	return input.ReviewerID, DeleteSystemIntakeGRBReviewer(ctx, r.store, r.pubsub, input.ReviewerID)
 }
 
 // CastSystemIntakeGRBReviewerVote is the resolver for the castSystemIntakeGRBReviewerVote field.
@@ -665,7 +671,7 @@ func (r *mutationResolver) CastSystemIntakeGRBReviewerVote(ctx context.Context,
 		return nil, err
 	}
 
-	return CastSystemIntakeGRBReviewerVote(ctx, r.store, r.emailClient, input)
+	return CastSystemIntakeGRBReviewerVote(ctx, r.store, r.emailClient, r.pubsub, input)
 }
 
 // SendSystemIntakeGRBReviewerReminder is the resolver for the sendSystemIntakeGRBReviewerReminder field.
@@ -683,7 +689,7 @@ func (r *mutationResolver) CreateSystemIntakeGRBDiscussionPost(ctx context.Conte
 		return nil, err
 	}
 
-	return CreateSystemIntakeGRBDiscussionPost(ctx, r.store, r.emailClient, input)
+	return CreateSystemIntakeGRBDiscussionPost(ctx, r.store, r.emailClient, r.pubsub, input)
 }
 
 // CreateSystemIntakeGRBDiscussionReply is the resolver for the createSystemIntakeGRBDiscussionReply field.
@@ -692,7 +698,7 @@ func (r *mutationResolver) CreateSystemIntakeGRBDiscussionReply(ctx context.Cont
 		return nil, err
 	}
 
-	return CreateSystemIntakeGRBDiscussionReply(ctx, r.store, r.emailClient, input)
+	return CreateSystemIntakeGRBDiscussionReply(ctx, r.store, r.emailClient, r.pubsub, input)
 }
 
 // UpdateSystemIntakeGRBReviewType is the resolver for the updateSystemIntakeGRBReviewType field.
@@ -731,13 +737,18 @@ func (r *mutationResolver) UpdateSystemIntakeGRBReviewFormTimeframeAsync(ctx con
 	return UpdateSystemIntakeGRBReviewFormInputTimeframeAsync(ctx, r.store, r.emailClient, input)
 }
 
+// UpdateSystemIntakeGRBReviewFormQuorumPolicy is the resolver for the updateSystemIntakeGRBReviewFormQuorumPolicy field.
+func (r *mutationResolver) UpdateSystemIntakeGRBReviewFormQuorumPolicy(ctx context.Context, input models.UpdateSystemIntakeGRBReviewFormInputQuorumPolicy) (*models.UpdateSystemIntakePayload, error) {
+	return UpdateSystemIntakeGRBReviewFormInputQuorumPolicy(ctx, r.store, r.pubsub, input)
+}
+
 // ExtendGRBReviewDeadlineAsync is the resolver for the extendGRBReviewDeadlineAsync field.
 func (r *mutationResolver) ExtendGRBReviewDeadlineAsync(ctx context.Context, input models.ExtendGRBReviewDeadlineInput) (*models.UpdateSystemIntakePayload, error) {
 	if err := r.guardSystemIntakeEditing(ctx); err != nil {
 		return nil, err
 	}
 
-	return ExtendGRBReviewDeadlineAsync(ctx, r.store, r.emailClient, input)
+	return ExtendGRBReviewDeadlineAsync(ctx, r.store, r.emailClient, r.pubsub, input)
 }
 
 // RestartGRBReviewAsync is the resolver for the restartGRBReviewAsync field.
@@ -746,7 +757,7 @@ func (r *mutationResolver) RestartGRBReviewAsync(ctx context.Context, input mode
 		return nil, err
 	}
 
-	return RestartGRBReviewAsync(ctx, r.store, r.emailClient, input)
+	return RestartGRBReviewAsync(ctx, r.store, r.emailClient, r.pubsub, input)
 }
 
 // SetSystemIntakeGRBPresentationLinks is the resolver for the setSystemIntakeGRBPresentationLinks field.
@@ -782,7 +793,7 @@ func (r *mutationResolver) ManuallyEndSystemIntakeGRBReviewAsyncVoting(ctx conte
 		return nil, err
 	}
 
-	return ManuallyEndSystemIntakeGRBReviewAsyncVoting(ctx, r.store, r.emailClient, systemIntakeID)
+	return ManuallyEndSystemIntakeGRBReviewAsyncVoting(ctx, r.store, r.emailClient, r.pubsub, systemIntakeID)
 }
 
 // ArchiveSystemIntake is the resolver for the archiveSystemIntake field.
@@ -1141,6 +1152,21 @@ func (r *mutationResolver) SendTRBGuidanceLetter(ctx context.Context, input mode
 		input.NotifyEuaIds)
 }
 
+// SendAmendedTRBGuidanceLetter is the resolver for the sendAmendedTRBGuidanceLetter field.
+func (r *mutationResolver) SendAmendedTRBGuidanceLetter(ctx context.Context, input models.SendAmendedTRBGuidanceLetterInput) (*models.TRBGuidanceLetter, error) {
+	return SendAmendedTRBGuidanceLetter(
+		ctx,
+		r.store,
+		input.ID,
+		input.AmendmentSummary,
+		r.emailClient,
+		r.service.FetchUserInfo,
+		r.service.FetchUserInfos,
+		input.CopyTrbMailbox,
+		input.CopyITGovMailbox,
+		input.NotifyEuaIds)
+}
+
 // CreateTRBGuidanceLetterInsight is the resolver for the createTRBGuidanceLetterInsight field.
 func (r *mutationResolver) CreateTRBGuidanceLetterInsight(ctx context.Context, input models.CreateTRBGuidanceLetterInsightInput) (*models.TRBGuidanceLetterInsight, error) {
 	links := models.ConvertEnums[string](input.Links)
@@ -1331,6 +1357,16 @@ func (r *queryResolver) TrbRequests(ctx context.Context, archived bool) ([]*mode
 	return GetTRBRequests(ctx, r.store, archived)
 }
 
+// TrbGuidanceLetterVersions is the resolver for the trbGuidanceLetterVersions field.
+func (r *queryResolver) TrbGuidanceLetterVersions(ctx context.Context, trbRequestID uuid.UUID) ([]*models.TRBGuidanceLetterVersion, error) {
+	return GetTRBGuidanceLetterVersions(ctx, r.store, trbRequestID)
+}
+
+// TrbGuidanceLetterVersionDiff is the resolver for the trbGuidanceLetterVersionDiff field.
+func (r *queryResolver) TrbGuidanceLetterVersionDiff(ctx context.Context, fromVersionID uuid.UUID, toVersionID uuid.UUID) (*models.TRBGuidanceLetterVersionDiff, error) {
+	return GetTRBGuidanceLetterVersionDiff(ctx, r.store, fromVersionID, toVersionID)
+}
+
 // MyTrbRequests is the resolver for the myTrbRequests field.
 func (r *queryResolver) MyTrbRequests(ctx context.Context, archived bool) ([]*models.TRBRequest, error) {
 	return GetMyTRBRequests(ctx, r.store, archived)
@@ -1361,6 +1397,16 @@ func (r *queryResolver) SystemIntakeSystems(ctx context.Context, systemIntakeID
 	return GetSystemIntakeSystems(ctx, systemIntakeID)
 }
 
+// OnSystemIntakeGRBDiscussionChanged is the resolver for the onSystemIntakeGRBDiscussionChanged field.
+func (r *subscriptionResolver) OnSystemIntakeGRBDiscussionChanged(ctx context.Context, systemIntakeID uuid.UUID) (<-chan *models.SystemIntakeGRBDiscussionChanged, error) {
+	return OnSystemIntakeGRBDiscussionChanged(ctx, r.store, r.pubsub, systemIntakeID, ctx.Done())
+}
+
+// OnGRBVotingInformationChanged is the resolver for the onGRBVotingInformationChanged field.
+func (r *subscriptionResolver) OnGRBVotingInformationChanged(ctx context.Context, systemIntakeID uuid.UUID) (<-chan *models.GRBVotingInformationChanged, error) {
+	return OnGRBVotingInformationChanged(ctx, r.store, r.pubsub, systemIntakeID, ctx.Done())
+}
+
 // Actions is the resolver for the actions field.
 func (r *systemIntakeResolver) Actions(ctx context.Context, obj *models.SystemIntake) ([]*models.SystemIntakeAction, error) {
 	if err := authorizeUserCanManageSystemIntakeAdminWorkflow(ctx); err != nil {
@@ -1780,6 +1826,11 @@ func (r *systemIntakeResolver) Contacts(ctx context.Context, obj *models.SystemI
 	return SystemIntakeContactsGetBySystemIntakeID(ctx, obj.ID)
 }
 
+// EmailDeliveryStatuses is the resolver for the emailDeliveryStatuses field.
+func (r *systemIntakeResolver) EmailDeliveryStatuses(ctx context.Context, obj *models.SystemIntake) ([]*models.EmailRecipientDeliveryStatus, error) {
+	return GetSystemIntakeEmailDeliveryStatuses(ctx, r.store, obj.ID)
+}
+
 // Component is the resolver for the component field.
 func (r *systemIntakeContactResolver) Component(ctx context.Context, obj *models.SystemIntakeContact) (*models.SystemIntakeContactComponent, error) {
 	if obj == nil {
@@ -1833,6 +1884,11 @@ func (r *systemIntakeDocumentResolver) CanView(ctx context.Context, obj *models.
 	return CanViewDocument(ctx, grbUsers, obj), nil
 }
 
+// Post is the resolver for the post field.
+func (r *systemIntakeGRBDiscussionChangedResolver) Post(ctx context.Context, obj *models.SystemIntakeGRBDiscussionChanged) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
+	return r.store.GetSystemIntakeGRBDiscussionPostByID(ctx, r.store, obj.PostID)
+}
+
 // TranscriptFileURL is the resolver for the transcriptFileURL field.
 func (r *systemIntakeGRBPresentationLinksResolver) TranscriptFileURL(ctx context.Context, obj *models.SystemIntakeGRBPresentationLinks) (*string, error) {
 	return SystemIntakeGRBPresentationLinksTranscriptFileURL(ctx, r.store, r.s3Client, obj.SystemIntakeID)
@@ -1931,6 +1987,16 @@ func (r *tRBGuidanceLetterInsightResolver) Author(ctx context.Context, obj *mode
 	return authorInfo, nil
 }
 
+// Insights is the resolver for the insights field.
+func (r *tRBGuidanceLetterVersionResolver) Insights(ctx context.Context, obj *models.TRBGuidanceLetterVersion) ([]*models.TRBGuidanceLetterVersionInsight, error) {
+	return lo.ToSlicePtr(obj.Insights), nil
+}
+
+// Author is the resolver for the author field.
+func (r *tRBGuidanceLetterVersionResolver) Author(ctx context.Context, obj *models.TRBGuidanceLetterVersion) (*models.UserInfo, error) {
+	return dataloaders.FetchUserInfoByEUAUserID(ctx, obj.CreatedBy)
+}
+
 // Status is the resolver for the status field.
 func (r *tRBRequestResolver) Status(ctx context.Context, obj *models.TRBRequest) (models.TRBRequestStatus, error) {
 	return GetTRBRequestStatus(ctx, *obj)
@@ -2006,6 +2072,11 @@ func (r *tRBRequestResolver) AdminNotes(ctx context.Context, obj *models.TRBRequ
 	return GetTRBAdminNotesByTRBRequestID(ctx, obj.ID)
 }
 
+// EmailDeliveryStatuses is the resolver for the emailDeliveryStatuses field.
+func (r *tRBRequestResolver) EmailDeliveryStatuses(ctx context.Context, obj *models.TRBRequest) ([]*models.EmailRecipientDeliveryStatus, error) {
+	return GetTRBRequestEmailDeliveryStatuses(ctx, r.store, obj.ID)
+}
+
 // IsRecent is the resolver for the isRecent field.
 func (r *tRBRequestResolver) IsRecent(ctx context.Context, obj *models.TRBRequest) (bool, error) {
 	return IsRecentTRBRequest(ctx, obj, time.Now()), nil
@@ -2145,6 +2216,11 @@ func (r *Resolver) CedarSoftwareProducts() generated.CedarSoftwareProductsResolv
 	return &cedarSoftwareProductsResolver{r}
 }
 
+// GRBQuorumPolicy returns generated.GRBQuorumPolicyResolver implementation.
+func (r *Resolver) GRBQuorumPolicy() generated.GRBQuorumPolicyResolver {
+	return &gRBQuorumPolicyResolver{r}
+}
+
 // GovernanceRequestFeedback returns generated.GovernanceRequestFeedbackResolver implementation.
 func (r *Resolver) GovernanceRequestFeedback() generated.GovernanceRequestFeedbackResolver {
 	return &governanceRequestFeedbackResolver{r}
@@ -2174,6 +2250,11 @@ func (r *Resolver) SystemIntakeDocument() generated.SystemIntakeDocumentResolver
 	return &systemIntakeDocumentResolver{r}
 }
 
+// SystemIntakeGRBDiscussionChanged returns generated.SystemIntakeGRBDiscussionChangedResolver implementation.
+func (r *Resolver) SystemIntakeGRBDiscussionChanged() generated.SystemIntakeGRBDiscussionChangedResolver {
+	return &systemIntakeGRBDiscussionChangedResolver{r}
+}
+
 // SystemIntakeGRBPresentationLinks returns generated.SystemIntakeGRBPresentationLinksResolver implementation.
 func (r *Resolver) SystemIntakeGRBPresentationLinks() generated.SystemIntakeGRBPresentationLinksResolver {
 	return &systemIntakeGRBPresentationLinksResolver{r}
@@ -2207,6 +2288,11 @@ func (r *Resolver) TRBGuidanceLetterInsight() generated.TRBGuidanceLetterInsight
 	return &tRBGuidanceLetterInsightResolver{r}
 }
 
+// TRBGuidanceLetterVersion returns generated.TRBGuidanceLetterVersionResolver implementation.
+func (r *Resolver) TRBGuidanceLetterVersion() generated.TRBGuidanceLetterVersionResolver {
+	return &tRBGuidanceLetterVersionResolver{r}
+}
+
 // TRBRequest returns generated.TRBRequestResolver implementation.
 func (r *Resolver) TRBRequest() generated.TRBRequestResolver { return &tRBRequestResolver{r} }
 
@@ -2236,6 +2322,7 @@ func (r *Resolver) UserInfo() generated.UserInfoResolver { return &userInfoResol
 type businessCaseResolver struct{ *Resolver }
 type cedarBudgetSystemCostResolver struct{ *Resolver }
 type cedarSoftwareProductsResolver struct{ *Resolver }
+type gRBQuorumPolicyResolver struct{ *Resolver }
 type governanceRequestFeedbackResolver struct{ *Resolver }
 type iTGovTaskStatusesResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
@@ -2243,6 +2330,7 @@ type queryResolver struct{ *Resolver }
 type systemIntakeResolver struct{ *Resolver }
 type systemIntakeContactResolver struct{ *Resolver }
 type systemIntakeDocumentResolver struct{ *Resolver }
+type systemIntakeGRBDiscussionChangedResolver struct{ *Resolver }
 type systemIntakeGRBPresentationLinksResolver struct{ *Resolver }
 type systemIntakeGRBReviewerResolver struct{ *Resolver }
 type systemIntakeNoteResolver struct{ *Resolver }
@@ -2250,6 +2338,7 @@ type systemIntakeSystemResolver struct{ *Resolver }
 type tRBAdminNoteResolver struct{ *Resolver }
 type tRBGuidanceLetterResolver struct{ *Resolver }
 type tRBGuidanceLetterInsightResolver struct{ *Resolver }
+type tRBGuidanceLetterVersionResolver struct{ *Resolver }
 type tRBRequestResolver struct{ *Resolver }
 type tRBRequestAttendeeResolver struct{ *Resolver }
 type tRBRequestDocumentResolver struct{ *Resolver }
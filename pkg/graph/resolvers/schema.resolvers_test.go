@@ -104,7 +104,7 @@ func TestGraphQLTestSuite(t *testing.T) {
 	}
 
 	oktaAPIClient := local.NewOktaAPIClient()
-	cedarCoreClient := cedarcore.NewClient(appcontext.WithLogger(context.Background(), logger), "fake", "fake", "1.0.0", true)
+	cedarCoreClient := cedarcore.NewClient(appcontext.WithLogger(context.Background(), logger), "fake", "fake", "1.0.0", true, cedarcore.TransportConfig{}, cedarcore.CacheConfig{})
 
 	directives := generated.DirectiveRoot{HasRole: func(ctx context.Context, obj interface{}, next graphql.Resolver, role models.Role) (res interface{}, err error) {
 		return next(ctx)
@@ -130,7 +130,7 @@ func TestGraphQLTestSuite(t *testing.T) {
 
 	pubsubService := pubsub.NewServicePubSub()
 
-	resolver := NewResolver(store, resolverService, &s3Client, &emailClient, ldClient, cedarCoreClient, pubsubService)
+	resolver := NewResolver(store, resolverService, &s3Client, &emailClient, ldClient, cedarCoreClient, pubsubService, false)
 	schema := generated.NewExecutableSchema(generated.Config{Resolvers: resolver, Directives: directives})
 
 	buildDataloaders := func() *dataloaders.Dataloaders {
@@ -0,0 +1,91 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/services"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+// Search returns a page of the content matching a full text search that the principal can view, best match first
+func Search(
+	ctx context.Context,
+	store *storage.Store,
+	query string,
+	types []models.SearchResultType,
+	first int,
+	after *string,
+) (*models.SearchResultConnection, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, &apperrors.BadRequestError{Err: errors.New("search query must not be empty")}
+	}
+
+	if err := validatePageSize(first); err != nil {
+		return nil, err
+	}
+
+	var cursor *models.SearchResultCursor
+	if after != nil {
+		decoded, err := models.DecodeSearchResultCursor(*after)
+		if err != nil {
+			return nil, &apperrors.BadRequestError{Err: err}
+		}
+		cursor = decoded
+	}
+
+	if len(types) == 0 {
+		types = models.AllSearchResultTypes
+	}
+
+	viewer := newSearchViewer(ctx)
+	results, hasNextPage, err := fetchPage(
+		first,
+		cursor,
+		func(limit int, after *models.SearchResultCursor) ([]*models.SearchResult, error) {
+			return store.Search(ctx, viewer, query, types, limit, after)
+		},
+		(*models.SearchResult).Cursor,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*models.SearchResultEdge, len(results))
+	cursors := make([]string, len(results))
+	for i, result := range results {
+		cursors[i] = result.Cursor().Encode()
+		edges[i] = &models.SearchResultEdge{
+			Cursor: cursors[i],
+			Node:   result,
+		}
+	}
+
+	return &models.SearchResultConnection{
+		Edges:    edges,
+		PageInfo: newPageInfo(cursors, hasNextPage),
+	}, nil
+}
+
+// newSearchViewer describes the principal to the search, which only returns the content they can view
+func newSearchViewer(ctx context.Context) models.SearchViewer {
+	principal := appcontext.Principal(ctx)
+	viewer := models.SearchViewer{
+		IsGRTAdmin: services.AuthorizeRequireGRTJobCode(ctx),
+		IsTRBAdmin: principal.AllowTRBAdmin(),
+		IsEASiUser: principal.AllowEASi(),
+	}
+
+	if account := principal.Account(); account != nil {
+		viewer.UserID = &account.ID
+		viewer.EUAUserID = &account.Username
+	}
+
+	return viewer
+}
@@ -0,0 +1,78 @@
+package resolvers
+
+import (
+	"github.com/guregu/null"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *ResolverSuite) TestSearch() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+
+	intake := s.createNewIntake(func(intake *models.SystemIntake) {
+		intake.ProjectName = null.StringFrom("Beneficiary Portal")
+		intake.BusinessNeed = null.StringFrom("Beneficiaries need to check the status of their <strong>claims</strong> online")
+	})
+	s.createNewIntake(func(intake *models.SystemIntake) {
+		intake.ProjectName = null.StringFrom("Provider Directory")
+		intake.BusinessNeed = null.StringFrom("Providers need a directory that supports checking claims")
+	})
+
+	s.Run("matches every word of the query, with highlighted snippets", func() {
+		results, err := Search(ctx, store, "beneficiary claims", nil, 25, nil)
+		s.NoError(err)
+		s.Len(results.Edges, 1)
+
+		result := results.Edges[0].Node
+		s.Equal(models.SearchResultTypeSystemIntake, result.Type)
+		s.Equal(intake.ID, result.RequestID)
+		s.Equal("Beneficiary Portal", result.Title)
+		s.Contains(result.Snippet(), "<mark>claims</mark>")
+		s.NotContains(result.Snippet(), "<strong>")
+
+		results, err = Search(ctx, store, "claims", nil, 25, nil)
+		s.NoError(err)
+		s.Len(results.Edges, 2)
+	})
+
+	s.Run("pages through the results", func() {
+		firstPage, err := Search(ctx, store, "claims", nil, 1, nil)
+		s.NoError(err)
+		s.Len(firstPage.Edges, 1)
+		s.True(firstPage.PageInfo.HasNextPage)
+
+		secondPage, err := Search(ctx, store, "claims", nil, 1, firstPage.PageInfo.EndCursor)
+		s.NoError(err)
+		s.Len(secondPage.Edges, 1)
+		s.False(secondPage.PageInfo.HasNextPage)
+		s.NotEqual(firstPage.Edges[0].Node.ID, secondPage.Edges[0].Node.ID)
+	})
+
+	s.Run("only searches the requested types", func() {
+		results, err := Search(ctx, store, "claims", []models.SearchResultType{models.SearchResultTypeBusinessCase}, 25, nil)
+		s.NoError(err)
+		s.Empty(results.Edges)
+	})
+
+	s.Run("only returns content the user can view", func() {
+		requesterCtx, _ := s.getTestContextWithPrincipal("USR1", false)
+
+		results, err := Search(requesterCtx, store, "claims", nil, 25, nil)
+		s.NoError(err)
+		s.Empty(results.Edges)
+	})
+
+	s.Run("returns the requester's own content without admin access", func() {
+		requesterCtx, _ := s.getTestContextWithPrincipal(s.testConfigs.Principal.ID(), false)
+
+		results, err := Search(requesterCtx, store, "claims", nil, 25, nil)
+		s.NoError(err)
+		s.Len(results.Edges, 2)
+	})
+
+	s.Run("rejects an empty query", func() {
+		_, err := Search(ctx, store, "  ", nil, 25, nil)
+		s.Error(err)
+	})
+}
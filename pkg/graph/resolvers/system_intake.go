@@ -68,6 +68,7 @@ func CreateSystemIntake(
 	return intakeRetFromTransaction, err
 }
 
+// the full text search in search.sql applies the same rules to its results, so they must change together
 func authorizeUserCanViewSystemIntake(
 	ctx context.Context,
 	store *storage.Store,
@@ -553,6 +554,72 @@ func SystemIntakes(ctx context.Context, store *storage.Store, openRequests bool)
 	return intakes, nil
 }
 
+// SystemIntakesConnection returns a page of the System Intakes relevant to admins, filtered and sorted on the server
+func SystemIntakesConnection(
+	ctx context.Context,
+	store *storage.Store,
+	first int,
+	after *string,
+	filter *models.SystemIntakesFilter,
+	sort *models.SystemIntakesSort,
+) (*models.SystemIntakeConnection, error) {
+	if err := authorizeUserCanManageSystemIntakeAdminWorkflow(ctx); err != nil {
+		return nil, err
+	}
+
+	cursor, err := decodePageArgs(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	intakesFilter := lo.FromPtr(filter)
+	intakesSort := lo.FromPtrOr(sort, models.SystemIntakesSort{
+		Field:     models.SystemIntakesSortFieldSubmittedAt,
+		Direction: models.SortDirectionDesc,
+	})
+
+	// the DB can only narrow down the admin status of intakes in an async GRB review, as it depends on their quorum
+	var include func(*models.SortedSystemIntake) (bool, error)
+	if len(intakesFilter.AdminStatuses) > 0 {
+		include = func(intake *models.SortedSystemIntake) (bool, error) {
+			status, err := CalculateSystemIntakeAdminStatus(ctx, &intake.SystemIntake)
+			if err != nil {
+				// intakes without a valid admin status can't match any status
+				return false, nil
+			}
+			return lo.Contains(intakesFilter.AdminStatuses, status), nil
+		}
+	}
+
+	intakes, hasNextPage, err := fetchPage(
+		first,
+		cursor,
+		func(limit int, after *models.PageCursor) ([]*models.SortedSystemIntake, error) {
+			return store.FetchSystemIntakesPageForAdmins(ctx, intakesFilter, intakesSort, limit, after)
+		},
+		(*models.SortedSystemIntake).Cursor,
+		include,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*models.SystemIntakeEdge, len(intakes))
+	cursors := make([]string, len(intakes))
+	for i, intake := range intakes {
+		cursors[i] = intake.Cursor().Encode()
+		edges[i] = &models.SystemIntakeEdge{
+			Cursor: cursors[i],
+			Node:   &intake.SystemIntake,
+		}
+	}
+
+	return &models.SystemIntakeConnection{
+		Edges:    edges,
+		PageInfo: newPageInfo(cursors, hasNextPage),
+	}, nil
+}
+
 func SystemIntakesWithReviewRequested(ctx context.Context, store *storage.Store) ([]*models.SystemIntake, error) {
 	userID := appcontext.Principal(ctx).Account().ID
 	return store.FetchSystemIntakesWithReviewRequested(ctx, userID)
@@ -663,16 +730,86 @@ func UpdateSystemIntakeAdminLead(
 func UpdateSystemIntakeReviewDates(
 	ctx context.Context,
 	store *storage.Store,
+	emailClient *email.Client,
 	input models.UpdateSystemIntakeReviewDatesInput,
 ) (*models.UpdateSystemIntakePayload, error) {
 	if err := authorizeUserCanManageSystemIntakeAdminWorkflow(ctx); err != nil {
 		return nil, err
 	}
 
+	previous, err := store.FetchSystemIntakeByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	intake, err := store.UpdateReviewDates(ctx, input.ID, input.GrbDate, input.GrtDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Email client can be nil when this is called from tests - the email client itself tests this
+	// separately in the email package test
+	if emailClient != nil && grbDateChanged(previous.GRBDate, intake.GRBDate) {
+		if err := sendGRBMeetingEmail(ctx, store, emailClient, intake); err != nil {
+			return nil, err
+		}
+	}
+
 	return &models.UpdateSystemIntakePayload{
 		SystemIntake: intake,
-	}, err
+	}, nil
+}
+
+// grbDateChanged returns whether a GRB meeting has been scheduled, rescheduled to a different day, or cancelled
+func grbDateChanged(previous *time.Time, current *time.Time) bool {
+	if previous == nil || current == nil {
+		return previous != current
+	}
+	return !previous.Equal(*current)
+}
+
+// sendGRBMeetingEmail sends the GRB reviewers of an intake a calendar invite for its GRB meeting, or a cancellation if its GRB date was removed
+func sendGRBMeetingEmail(ctx context.Context, store *storage.Store, emailClient *email.Client, intake *models.SystemIntake) error {
+	grbUsers, err := store.SystemIntakeGRBReviewersBySystemIntakeIDs(ctx, []uuid.UUID{intake.ID})
+	if err != nil {
+		return err
+	}
+
+	// there's no one to invite until reviewers are added
+	if len(grbUsers) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(grbUsers))
+	for i := range ids {
+		ids[i] = grbUsers[i].UserID
+	}
+
+	accounts, err := store.UserAccountsByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	emails := make([]models.EmailAddress, len(accounts))
+	for i := range emails {
+		emails[i] = models.EmailAddress(accounts[i].Email)
+	}
+
+	// rescheduling sends the invite again with the same UID, so it updates the event already in reviewers' calendars
+	sequence, err := store.NextCalendarInviteSequence(ctx, store, emailClient.GRBMeetingInviteUID(intake.ID))
+	if err != nil {
+		return err
+	}
+
+	return emailClient.SystemIntake.SendSystemIntakeGRBMeetingEmail(ctx, email.SendSystemIntakeGRBMeetingEmailInput{
+		SystemIntakeID:         intake.ID,
+		RequestName:            intake.ProjectName.ValueOrZero(),
+		RequesterName:          intake.Requester,
+		RequesterComponent:     intake.Component.ValueOrZero(),
+		GRBDate:                intake.GRBDate,
+		Recipients:             emails,
+		CalendarInviteSequence: sequence,
+	})
 }
 
 func GetSystemIntake(
@@ -16,6 +16,7 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 	"github.com/cms-enterprise/easi-app/pkg/storage"
+	"github.com/cms-enterprise/easi-app/pkg/webhooks"
 )
 
 // ProgressIntake handles a Progress to New Step action on an intake as part of Admin Actions v2
@@ -558,6 +559,8 @@ func IssueLCID(
 		return nil, err
 	}
 
+	emitWebhookEvent(ctx, store, models.WebhookEventTypeLcidIssued, webhooks.NewLCIDEventData(updatedIntake))
+
 	return updatedIntake, nil
 }
 
@@ -1245,6 +1248,8 @@ func ExpireLCID(
 		return nil, err
 	}
 
+	emitWebhookEvent(ctx, store, models.WebhookEventTypeLcidExpired, webhooks.NewLCIDEventData(updatedIntake))
+
 	return updatedIntake, nil
 }
 
@@ -1359,6 +1364,8 @@ func RetireLCID(
 		return nil, err
 	}
 
+	emitWebhookEvent(ctx, store, models.WebhookEventTypeLcidRetired, webhooks.NewLCIDEventData(updatedIntake))
+
 	return updatedIntake, nil
 }
 
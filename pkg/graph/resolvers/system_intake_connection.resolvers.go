@@ -0,0 +1,16 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// SystemIntakesConnection is the resolver for the systemIntakesConnection field.
+func (r *queryResolver) SystemIntakesConnection(ctx context.Context, first int, after *string, filter *models.SystemIntakesFilter, sort *models.SystemIntakesSort) (*models.SystemIntakeConnection, error) {
+	return SystemIntakesConnection(ctx, r.store, first, after, filter, sort)
+}
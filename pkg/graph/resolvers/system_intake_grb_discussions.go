@@ -14,7 +14,10 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
 	"github.com/cms-enterprise/easi-app/pkg/dataloaders"
 	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/graph/model/subscribers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/models/pubsubevents"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 	"github.com/cms-enterprise/easi-app/pkg/services"
 	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
 	"github.com/cms-enterprise/easi-app/pkg/storage"
@@ -25,9 +28,11 @@ func CreateSystemIntakeGRBDiscussionPost(
 	ctx context.Context,
 	store *storage.Store,
 	emailClient *email.Client,
+	ps pubsub.PubSub,
 	input models.CreateSystemIntakeGRBDiscussionPostInput,
 ) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
-	return sqlutils.WithTransactionRet(ctx, store, func(tx *sqlx.Tx) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
+	ctx, publishNotifications := email.WithDeferredNotifications(ctx)
+	post, err := sqlutils.WithTransactionRet(ctx, store, func(tx *sqlx.Tx) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
 		principal := appcontext.Principal(ctx)
 
 		intakeID := input.SystemIntakeID
@@ -83,8 +88,9 @@ func CreateSystemIntakeGRBDiscussionPost(
 			return nil, err
 		}
 
+		// queue the emails with the post, so they're only sent if it's created
 		err = sendDiscussionEmailsForTags(
-			ctx,
+			email.WithOutboxTransaction(ctx, tx),
 			store,
 			emailClient,
 			tx,
@@ -101,6 +107,14 @@ func CreateSystemIntakeGRBDiscussionPost(
 
 		return result, nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// publish once the post is committed, so subscribers are able to load it
+	publishNotifications()
+	publishSystemIntakeGRBDiscussionChange(ps, models.SystemIntakeGRBDiscussionChangeTypePostCreated, post, post.ID)
+	return post, nil
 }
 
 // CreateSystemIntakeGRBDiscussionReply creates a reply to a GRB Discussion post
@@ -108,9 +122,11 @@ func CreateSystemIntakeGRBDiscussionReply(
 	ctx context.Context,
 	store *storage.Store,
 	emailClient *email.Client,
+	ps pubsub.PubSub,
 	input models.CreateSystemIntakeGRBDiscussionReplyInput,
 ) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
-	return sqlutils.WithTransactionRet(ctx, store, func(tx *sqlx.Tx) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
+	ctx, publishNotifications := email.WithDeferredNotifications(ctx)
+	reply, err := sqlutils.WithTransactionRet(ctx, store, func(tx *sqlx.Tx) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
 		initialPost, err := store.GetSystemIntakeGRBDiscussionPostByID(ctx, tx, input.InitialPostID)
 		if err != nil {
 			return nil, err
@@ -195,9 +211,12 @@ func CreateSystemIntakeGRBDiscussionReply(
 			return nil, err
 		}
 
+		// queue the emails with the reply, so they're only sent if it's created
+		outboxCtx := email.WithOutboxTransaction(ctx, tx)
+
 		// don't send email to author if reply is from author
 		if initialPoster.ID != replyPoster.ID {
-			if err := emailClient.SystemIntake.SendGRBReviewDiscussionReplyEmail(ctx, email.SendGRBReviewDiscussionReplyEmailInput{
+			if err := emailClient.SystemIntake.SendGRBReviewDiscussionReplyEmail(outboxCtx, email.SendGRBReviewDiscussionReplyEmailInput{
 				SystemIntakeID:    intakeID,
 				UserName:          replyPoster.CommonName,
 				RequestName:       systemIntake.ProjectName.String,
@@ -219,13 +238,14 @@ func CreateSystemIntakeGRBDiscussionReply(
 			}
 
 			if err := emailClient.SystemIntake.SendGRBReviewDiscussionReplyRequesterEmail(
-				ctx,
+				outboxCtx,
 				email.SendGRBReviewDiscussionReplyRequesterEmailInput{
 					SystemIntakeID:    intakeID,
 					RequestName:       systemIntake.ProjectName.String,
 					ReplierName:       replyPoster.CommonName,
 					VotingRole:        authorRole,
 					GRBRole:           grbRole,
+					DiscussionID:      initialPost.ID,
 					DiscussionContent: input.Content.ToTemplate(),
 					Recipient:         models.EmailAddress(initialPoster.Email),
 				},
@@ -242,7 +262,7 @@ func CreateSystemIntakeGRBDiscussionReply(
 
 		// then handle emails for tags in the post
 		err = sendDiscussionEmailsForTags(
-			ctx,
+			outboxCtx,
 			store,
 			emailClient,
 			tx,
@@ -259,6 +279,60 @@ func CreateSystemIntakeGRBDiscussionReply(
 
 		return result, nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// publish once the reply is committed, so subscribers are able to load it
+	publishNotifications()
+	publishSystemIntakeGRBDiscussionChange(ps, models.SystemIntakeGRBDiscussionChangeTypeReplyCreated, reply, input.InitialPostID)
+	return reply, nil
+}
+
+// publishSystemIntakeGRBDiscussionChange notifies subscribers of a system intake's GRB discussions that a post was made
+func publishSystemIntakeGRBDiscussionChange(
+	ps pubsub.PubSub,
+	changeType models.SystemIntakeGRBDiscussionChangeType,
+	post *models.SystemIntakeGRBReviewDiscussionPost,
+	initialPostID uuid.UUID,
+) {
+	// posts are always created with a board type, but guard the dereference
+	if ps == nil || post == nil || post.DiscussionBoardType == nil {
+		return
+	}
+
+	ps.Publish(post.SystemIntakeID, pubsubevents.SystemIntakeGRBDiscussionChanged, models.SystemIntakeGRBDiscussionChanged{
+		ChangeType:          changeType,
+		SystemIntakeID:      post.SystemIntakeID,
+		DiscussionBoardType: *post.DiscussionBoardType,
+		InitialPostID:       initialPostID,
+		PostID:              post.ID,
+	})
+}
+
+// OnSystemIntakeGRBDiscussionChanged subscribes the principal to new posts and replies on a system intake's GRB discussions.
+// Only users who can participate in the discussions may subscribe, and only those who can view the Internal board
+// are sent its changes.
+func OnSystemIntakeGRBDiscussionChanged(
+	ctx context.Context,
+	store *storage.Store,
+	ps pubsub.PubSub,
+	systemIntakeID uuid.UUID,
+	onDisconnect <-chan struct{},
+) (<-chan *models.SystemIntakeGRBDiscussionChanged, error) {
+	if !canParticipateInDiscussions(ctx, store, systemIntakeID) {
+		return nil, errors.New("user is not authorized to view discussions")
+	}
+
+	subscriber := subscribers.NewSystemIntakeGRBDiscussionChangedSubscriber(
+		appcontext.Principal(ctx),
+		systemIntakeID,
+		isAuthorizedForInternalBoard(ctx, systemIntakeID),
+		appcontext.ZLogger(ctx),
+	)
+
+	ps.Subscribe(systemIntakeID, pubsubevents.SystemIntakeGRBDiscussionChanged, subscriber, onDisconnect)
+	return subscriber.GetChannel(), nil
 }
 
 // handles sending emails for various tags in a discussion post
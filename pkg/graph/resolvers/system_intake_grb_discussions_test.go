@@ -13,6 +13,7 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
 	"github.com/cms-enterprise/easi-app/pkg/email"
 	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 	"github.com/cms-enterprise/easi-app/pkg/userhelpers"
 )
 
@@ -106,6 +107,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBDiscussions() {
 			ctx,
 			store,
 			emailClient,
+			pubsub.NewServicePubSub(),
 			models.CreateSystemIntakeGRBDiscussionPostInput{
 				SystemIntakeID: intake.ID,
 				Content: models.TaggedHTML{
@@ -356,6 +358,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBDiscussionReplies() {
 			ctx,
 			store,
 			emailClient,
+			pubsub.NewServicePubSub(),
 			models.CreateSystemIntakeGRBDiscussionReplyInput{
 				InitialPostID: discussionPost.ID,
 				Content: models.TaggedHTML{
@@ -699,6 +702,7 @@ func (s *ResolverSuite) createGRBDiscussion(
 		ctx,
 		s.testConfigs.Store,
 		emailClient,
+		pubsub.NewServicePubSub(),
 		models.CreateSystemIntakeGRBDiscussionPostInput{
 			SystemIntakeID:      intakeID,
 			Content:             taggedHTMLContent,
@@ -729,6 +733,7 @@ func (s *ResolverSuite) createGRBDiscussionWithExpectedError(
 		ctx,
 		s.testConfigs.Store,
 		emailClient,
+		pubsub.NewServicePubSub(),
 		models.CreateSystemIntakeGRBDiscussionPostInput{
 			SystemIntakeID:      intakeID,
 			Content:             taggedHTMLContent,
@@ -754,6 +759,7 @@ func (s *ResolverSuite) createGRBDiscussionReply(
 		ctx,
 		s.testConfigs.Store,
 		emailClient,
+		pubsub.NewServicePubSub(),
 		models.CreateSystemIntakeGRBDiscussionReplyInput{
 			InitialPostID:       discussionPost.ID,
 			Content:             taggedHTMLContent,
@@ -783,6 +789,7 @@ func (s *ResolverSuite) createGRBDiscussionReplyWithExpectedError(
 		ctx,
 		s.testConfigs.Store,
 		emailClient,
+		pubsub.NewServicePubSub(),
 		models.CreateSystemIntakeGRBDiscussionReplyInput{
 			InitialPostID:       discussionPost.ID,
 			Content:             taggedHTMLContent,
@@ -810,7 +817,7 @@ func (s *ResolverSuite) createIntakeAndAddReviewersByEUAs(reviewerEuaIDs ...stri
 
 	var createdReviewers []*models.SystemIntakeGRBReviewer
 	if len(reviewers) > 0 {
-		payload, err := CreateSystemIntakeGRBReviewers(s.testConfigs.Context, s.testConfigs.Store, s.testConfigs.EmailClient, userhelpers.GetUserInfoAccountInfosWrapperFunc(s.testConfigs.UserSearchClient.FetchUserInfos), &models.CreateSystemIntakeGRBReviewersInput{
+		payload, err := CreateSystemIntakeGRBReviewers(s.testConfigs.Context, s.testConfigs.Store, s.testConfigs.EmailClient, pubsub.NewServicePubSub(), userhelpers.GetUserInfoAccountInfosWrapperFunc(s.testConfigs.UserSearchClient.FetchUserInfos), &models.CreateSystemIntakeGRBReviewersInput{
 			SystemIntakeID: intake.ID,
 			Reviewers:      reviewers,
 		})
@@ -832,3 +839,85 @@ func addTags(htmlString string, tags ...models.Tag) string {
 	}
 	return htmlString
 }
+
+func (s *ResolverSuite) TestOnSystemIntakeGRBDiscussionChanged() {
+	store := s.testConfigs.Store
+	ps := pubsub.NewServicePubSub()
+
+	intake, _ := s.createIntakeAndAddReviewersByEUAs("ABCD")
+	intake.Step = models.SystemIntakeStepGRBMEETING
+	intake, err := store.UpdateSystemIntake(s.ctxWithNewDataloaders(), intake)
+	s.NoError(err)
+
+	disconnect := make(chan struct{})
+	defer close(disconnect)
+
+	// reviewers can view both boards
+	reviewerCtx, _ := s.getTestContextWithPrincipal("ABCD", false)
+	reviewerChanges, err := OnSystemIntakeGRBDiscussionChanged(reviewerCtx, store, ps, intake.ID, disconnect)
+	s.NoError(err)
+
+	// the requester can only view the Primary board
+	requesterCtx, _ := s.getTestContextWithPrincipal(s.testConfigs.UserInfo.Username, false)
+	requesterChanges, err := OnSystemIntakeGRBDiscussionChanged(requesterCtx, store, ps, intake.ID, disconnect)
+	s.NoError(err)
+
+	// users who cannot participate in the discussions cannot subscribe
+	otherCtx, _ := s.getTestContextWithPrincipal("BTMN", false)
+	otherChanges, err := OnSystemIntakeGRBDiscussionChanged(otherCtx, store, ps, intake.ID, disconnect)
+	s.Error(err)
+	s.Nil(otherChanges)
+
+	adminCtx, _ := s.getTestContextWithPrincipal("USR1", true)
+	primaryContent, err := models.NewTaggedHTMLFromString("<p>primary</p>")
+	s.NoError(err)
+	primaryPost, err := CreateSystemIntakeGRBDiscussionPost(adminCtx, store, nil, ps, models.CreateSystemIntakeGRBDiscussionPostInput{
+		SystemIntakeID:      intake.ID,
+		Content:             primaryContent,
+		DiscussionBoardType: models.SystemIntakeGRBDiscussionBoardTypePrimary,
+	})
+	s.NoError(err)
+
+	for _, changes := range []<-chan *models.SystemIntakeGRBDiscussionChanged{reviewerChanges, requesterChanges} {
+		s.Len(changes, 1)
+		change := <-changes
+		s.Equal(models.SystemIntakeGRBDiscussionChangeTypePostCreated, change.ChangeType)
+		s.Equal(intake.ID, change.SystemIntakeID)
+		s.Equal(models.SystemIntakeGRBDiscussionBoardTypePrimary, change.DiscussionBoardType)
+		s.Equal(primaryPost.ID, change.InitialPostID)
+		s.Equal(primaryPost.ID, change.PostID)
+	}
+
+	internalContent, err := models.NewTaggedHTMLFromString("<p>internal</p>")
+	s.NoError(err)
+	internalPost, err := CreateSystemIntakeGRBDiscussionPost(adminCtx, store, nil, ps, models.CreateSystemIntakeGRBDiscussionPostInput{
+		SystemIntakeID:      intake.ID,
+		Content:             internalContent,
+		DiscussionBoardType: models.SystemIntakeGRBDiscussionBoardTypeInternal,
+	})
+	s.NoError(err)
+
+	// only the reviewer is sent the Internal board change
+	s.Len(reviewerChanges, 1)
+	change := <-reviewerChanges
+	s.Equal(models.SystemIntakeGRBDiscussionBoardTypeInternal, change.DiscussionBoardType)
+	s.Equal(internalPost.ID, change.PostID)
+	s.Empty(requesterChanges)
+
+	replyContent, err := models.NewTaggedHTMLFromString("<p>reply</p>")
+	s.NoError(err)
+	reply, err := CreateSystemIntakeGRBDiscussionReply(reviewerCtx, store, nil, ps, models.CreateSystemIntakeGRBDiscussionReplyInput{
+		InitialPostID:       primaryPost.ID,
+		Content:             replyContent,
+		DiscussionBoardType: models.SystemIntakeGRBDiscussionBoardTypePrimary,
+	})
+	s.NoError(err)
+
+	for _, changes := range []<-chan *models.SystemIntakeGRBDiscussionChanged{reviewerChanges, requesterChanges} {
+		s.Len(changes, 1)
+		change := <-changes
+		s.Equal(models.SystemIntakeGRBDiscussionChangeTypeReplyCreated, change.ChangeType)
+		s.Equal(primaryPost.ID, change.InitialPostID)
+		s.Equal(reply.ID, change.PostID)
+	}
+}
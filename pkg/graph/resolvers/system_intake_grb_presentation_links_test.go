@@ -11,6 +11,7 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/easiencoding"
 	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 	"github.com/cms-enterprise/easi-app/pkg/userhelpers"
 )
 
@@ -146,7 +147,7 @@ func (s *ResolverSuite) TestUploadSystemIntakeGRBPresentationDeckOnCompletedGRBR
 	intake, err = s.testConfigs.Store.UpdateSystemIntake(s.testConfigs.Context, intake)
 	s.NoError(err)
 
-	_, err = CreateSystemIntakeGRBReviewers(s.testConfigs.Context, s.testConfigs.Store, nil, userhelpers.GetUserInfoAccountInfosWrapperFunc(mock.FetchUserInfosMock), &models.CreateSystemIntakeGRBReviewersInput{
+	_, err = CreateSystemIntakeGRBReviewers(s.testConfigs.Context, s.testConfigs.Store, nil, pubsub.NewServicePubSub(), userhelpers.GetUserInfoAccountInfosWrapperFunc(mock.FetchUserInfosMock), &models.CreateSystemIntakeGRBReviewersInput{
 		SystemIntakeID: intake.ID,
 		Reviewers:      reviewers,
 	})
@@ -155,7 +156,7 @@ func (s *ResolverSuite) TestUploadSystemIntakeGRBPresentationDeckOnCompletedGRBR
 	// set votes for each one
 	for _, reviewer := range reviewers {
 		rctx, _ := s.getTestContextWithPrincipal(reviewer.EuaUserID, false)
-		_, err := CastSystemIntakeGRBReviewerVote(rctx, s.testConfigs.Store, s.testConfigs.EmailClient, models.CastSystemIntakeGRBReviewerVoteInput{
+		_, err := CastSystemIntakeGRBReviewerVote(rctx, s.testConfigs.Store, s.testConfigs.EmailClient, pubsub.NewServicePubSub(), models.CastSystemIntakeGRBReviewerVoteInput{
 			SystemIntakeID: intake.ID,
 			Vote:           models.SystemIntakeAsyncGRBVotingOptionNoObjection,
 			VoteComment:    nil,
@@ -6,15 +6,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/dataloaders"
 	"github.com/cms-enterprise/easi-app/pkg/email"
 	"github.com/cms-enterprise/easi-app/pkg/email/translation"
 	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 	"github.com/cms-enterprise/easi-app/pkg/storage"
+	"github.com/cms-enterprise/easi-app/pkg/webhooks"
 )
 
 // UpdateSystemIntakeGRBReviewType updates the GRB review type on a system intake
@@ -194,6 +198,47 @@ func UpdateSystemIntakeGRBReviewFormInputTimeframeAsync(
 	}, nil
 }
 
+// UpdateSystemIntakeGRBReviewFormInputQuorumPolicy is the resolver for
+// updating the quorum policy of an async GRB review
+func UpdateSystemIntakeGRBReviewFormInputQuorumPolicy(
+	ctx context.Context,
+	store *storage.Store,
+	ps pubsub.PubSub,
+	input models.UpdateSystemIntakeGRBReviewFormInputQuorumPolicy,
+) (*models.UpdateSystemIntakePayload, error) {
+	if err := authorizeUserCanManageSystemIntakeGRBReview(ctx); err != nil {
+		return nil, err
+	}
+
+	if input.MinimumVotes < 1 {
+		return nil, &apperrors.BadRequestError{
+			Err: errors.New("minimum votes for quorum must be at least 1"),
+		}
+	}
+
+	if input.VotingPercentage != nil && (*input.VotingPercentage < 1 || *input.VotingPercentage > 100) {
+		return nil, &apperrors.BadRequestError{
+			Err: errors.New("voting percentage for quorum must be between 1 and 100"),
+		}
+	}
+
+	updatedIntake, err := store.UpdateSystemIntakeGRBQuorumPolicy(ctx, input.SystemIntakeID, models.GRBQuorumPolicy{
+		MinimumVotes:      input.MinimumVotes,
+		VotingPercentage:  input.VotingPercentage,
+		RequiredRoles:     models.EnumArray[models.SystemIntakeGRBReviewerRole](lo.Uniq(input.RequiredRoles)),
+		RequiredRolesRule: input.RequiredRolesRule,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishGRBVotingInformationChange(ps, models.GRBVotingInformationChangeTypeQuorumPolicyUpdated, updatedIntake.ID)
+
+	return &models.UpdateSystemIntakePayload{
+		SystemIntake: updatedIntake,
+	}, nil
+}
+
 // CalcSystemIntakeGRBReviewAsyncStatus calculates the status of the GRB Review Async page
 func CalcSystemIntakeGRBReviewAsyncStatus(
 	ctx context.Context,
@@ -258,6 +303,7 @@ func ManuallyEndSystemIntakeGRBReviewAsyncVoting(
 	ctx context.Context,
 	store *storage.Store,
 	emailClient *email.Client,
+	ps pubsub.PubSub,
 	systemIntakeID uuid.UUID,
 ) (*models.UpdateSystemIntakePayload, error) {
 	if err := authorizeUserCanManageSystemIntakeGRBReview(ctx); err != nil {
@@ -280,6 +326,9 @@ func ManuallyEndSystemIntakeGRBReviewAsyncVoting(
 		return nil, err
 	}
 
+	publishGRBVotingInformationChange(ps, models.GRBVotingInformationChangeTypeVotingEnded, intake.ID)
+	emitWebhookEvent(ctx, store, models.WebhookEventTypeGrbReviewEnded, webhooks.NewGRBReviewEndedEventData(updatedIntake, currentTime, true))
+
 	if emailClient != nil && intake.GRBReviewStartedAt != nil && intake.GrbReviewAsyncEndDate != nil {
 		logger := appcontext.ZLogger(ctx)
 		// get GRB reviewers
@@ -332,6 +381,7 @@ func ExtendGRBReviewDeadlineAsync(
 	ctx context.Context,
 	store *storage.Store,
 	emailClient *email.Client,
+	ps pubsub.PubSub,
 	input models.ExtendGRBReviewDeadlineInput,
 ) (*models.UpdateSystemIntakePayload, error) {
 	if err := authorizeUserCanManageSystemIntakeGRBReview(ctx); err != nil {
@@ -354,6 +404,8 @@ func ExtendGRBReviewDeadlineAsync(
 		return nil, err
 	}
 
+	publishGRBVotingInformationChange(ps, models.GRBVotingInformationChangeTypeDeadlineExtended, intake.ID)
+
 	// send email if able
 	if intake.GRBReviewStartedAt != nil && intake.GrbReviewAsyncEndDate != nil {
 		logger := appcontext.ZLogger(ctx)
@@ -413,6 +465,7 @@ func RestartGRBReviewAsync(
 	ctx context.Context,
 	store *storage.Store,
 	emailClient *email.Client,
+	ps pubsub.PubSub,
 	input models.RestartGRBReviewInput,
 ) (*models.UpdateSystemIntakePayload, error) {
 	if err := authorizeUserCanManageSystemIntakeGRBReview(ctx); err != nil {
@@ -440,6 +493,8 @@ func RestartGRBReviewAsync(
 		return nil, err
 	}
 
+	publishGRBVotingInformationChange(ps, models.GRBVotingInformationChangeTypeReviewRestarted, intake.ID)
+
 	if intake.GRBReviewStartedAt != nil && intake.GrbReviewAsyncEndDate != nil {
 		if err := emailClient.SystemIntake.SendSystemIntakeGRBReviewRestarted(
 			ctx,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 )
 
 func (s *ResolverSuite) TestSystemIntakeUpdateGrbReviewType() {
@@ -174,6 +175,74 @@ func (s *ResolverSuite) TestSystemIntakeUpdateSystemIntakeGRBReviewFormInputTime
 
 }
 
+func (s *ResolverSuite) TestSystemIntakeUpdateSystemIntakeGRBReviewFormInputQuorumPolicy() {
+	systemIntake := s.createNewIntake()
+	s.NotNil(systemIntake)
+
+	// a new intake uses the default policy
+	s.Equal(models.DefaultGRBQuorumPolicy(), systemIntake.GRBQuorumPolicy())
+
+	updatedPayload, err := UpdateSystemIntakeGRBReviewFormInputQuorumPolicy(
+		s.testConfigs.Context,
+		s.testConfigs.Store,
+		pubsub.NewServicePubSub(),
+		models.UpdateSystemIntakeGRBReviewFormInputQuorumPolicy{
+			SystemIntakeID:   systemIntake.ID,
+			MinimumVotes:     3,
+			VotingPercentage: helpers.PointerTo(60),
+			RequiredRoles: []models.SystemIntakeGRBReviewerRole{
+				models.SystemIntakeGRBReviewerRoleCoChairCio,
+				models.SystemIntakeGRBReviewerRoleCoChairCfo,
+				models.SystemIntakeGRBReviewerRoleCoChairCio,
+			},
+			RequiredRolesRule: models.GRBQuorumRequiredRolesRuleAllRoles,
+		},
+	)
+	s.NoError(err)
+	s.NotNil(updatedPayload)
+	s.NotNil(updatedPayload.SystemIntake)
+
+	policy := updatedPayload.SystemIntake.GRBQuorumPolicy()
+	s.Equal(3, policy.MinimumVotes)
+	if s.Suite.NotNil(policy.VotingPercentage) {
+		s.Equal(60, *policy.VotingPercentage)
+	}
+	// duplicate roles are only stored once
+	s.ElementsMatch([]models.SystemIntakeGRBReviewerRole{
+		models.SystemIntakeGRBReviewerRoleCoChairCio,
+		models.SystemIntakeGRBReviewerRoleCoChairCfo,
+	}, policy.RequiredRoles)
+	s.Equal(models.GRBQuorumRequiredRolesRuleAllRoles, policy.RequiredRolesRule)
+
+	// invalid policies are rejected
+	invalidInputs := []models.UpdateSystemIntakeGRBReviewFormInputQuorumPolicy{
+		{
+			SystemIntakeID:    systemIntake.ID,
+			MinimumVotes:      0,
+			RequiredRoles:     []models.SystemIntakeGRBReviewerRole{},
+			RequiredRolesRule: models.GRBQuorumRequiredRolesRuleAnyRole,
+		},
+		{
+			SystemIntakeID:    systemIntake.ID,
+			MinimumVotes:      1,
+			VotingPercentage:  helpers.PointerTo(101),
+			RequiredRoles:     []models.SystemIntakeGRBReviewerRole{},
+			RequiredRolesRule: models.GRBQuorumRequiredRolesRuleAnyRole,
+		},
+	}
+
+	for _, input := range invalidInputs {
+		erroredPayload, err := UpdateSystemIntakeGRBReviewFormInputQuorumPolicy(
+			s.testConfigs.Context,
+			s.testConfigs.Store,
+			pubsub.NewServicePubSub(),
+			input,
+		)
+		s.Nil(erroredPayload)
+		s.Error(err)
+	}
+}
+
 func (s *ResolverSuite) TestCalcSystemIntakeGRBReviewAsyncStatus() {
 	ctx := s.ctxWithNewDataloaders()
 
@@ -321,6 +390,7 @@ func (s *ResolverSuite) TestManuallyEndSystemIntakeGRBReviewAsyncVoting() {
 		s.testConfigs.Context,
 		s.testConfigs.Store,
 		s.testConfigs.EmailClient,
+		pubsub.NewServicePubSub(),
 		systemIntake.ID,
 	)
 
@@ -367,6 +437,7 @@ func (s *ResolverSuite) TestExtendGRBReviewDeadlineAsync() {
 		s.testConfigs.Context,
 		s.testConfigs.Store,
 		s.testConfigs.EmailClient,
+		pubsub.NewServicePubSub(),
 		models.ExtendGRBReviewDeadlineInput{
 			SystemIntakeID:        systemIntake.ID,
 			GrbReviewAsyncEndDate: twoHoursLater,
@@ -420,6 +491,7 @@ func (s *ResolverSuite) TestRestartGRBReviewAsync() {
 		s.testConfigs.Context,
 		s.testConfigs.Store,
 		s.testConfigs.EmailClient,
+		pubsub.NewServicePubSub(),
 		input,
 	)
 
@@ -18,6 +18,7 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/email"
 	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
 	"github.com/cms-enterprise/easi-app/pkg/storage"
 	"github.com/cms-enterprise/easi-app/pkg/userhelpers"
@@ -36,12 +37,13 @@ func SystemIntakeGRBDiscussions(
 }
 
 // CreateSystemIntakeGRBReviewers creates GRB Reviewers for a System Intake
-func CreateSystemIntakeGRBReviewers(ctx context.Context, store *storage.Store, emailClient *email.Client, fetchUsers userhelpers.GetAccountInfosFunc, input *models.CreateSystemIntakeGRBReviewersInput) (*models.CreateSystemIntakeGRBReviewersPayload, error) {
+func CreateSystemIntakeGRBReviewers(ctx context.Context, store *storage.Store, emailClient *email.Client, ps pubsub.PubSub, fetchUsers userhelpers.GetAccountInfosFunc, input *models.CreateSystemIntakeGRBReviewersInput) (*models.CreateSystemIntakeGRBReviewersPayload, error) {
 	if err := authorizeUserCanManageSystemIntakeGRBReview(ctx); err != nil {
 		return nil, err
 	}
 
-	return sqlutils.WithTransactionRet(ctx, store, func(tx *sqlx.Tx) (*models.CreateSystemIntakeGRBReviewersPayload, error) {
+	ctx, publishNotifications := email.WithDeferredNotifications(ctx)
+	payload, err := sqlutils.WithTransactionRet(ctx, store, func(tx *sqlx.Tx) (*models.CreateSystemIntakeGRBReviewersPayload, error) {
 		// Fetch intake by ID
 		intake, err := storage.FetchSystemIntakeByIDNP(ctx, tx, input.SystemIntakeID)
 		if err != nil {
@@ -92,8 +94,9 @@ func CreateSystemIntakeGRBReviewers(ctx context.Context, store *storage.Store, e
 			}, nil
 		}
 
-		// send notification email to reviewer
+		// send notification email to reviewer, queued with the new reviewers so it's only sent if they're created
 		// Note: GRB review cannot be set to future date currently
+		outboxCtx := email.WithOutboxTransaction(ctx, tx)
 		if intake.GrbReviewType == models.SystemIntakeGRBReviewTypeStandard {
 			var emails []models.EmailAddress
 			for _, reviewer := range accts {
@@ -101,7 +104,7 @@ func CreateSystemIntakeGRBReviewers(ctx context.Context, store *storage.Store, e
 			}
 
 			if err := emailClient.SystemIntake.SendCreateGRBReviewerNotification(
-				ctx,
+				outboxCtx,
 				emails,
 				intake.ID,
 				intake.ProjectName.String,
@@ -114,7 +117,7 @@ func CreateSystemIntakeGRBReviewers(ctx context.Context, store *storage.Store, e
 			if intake.GrbReviewAsyncEndDate != nil {
 				for _, reviewer := range accts {
 					if err := emailClient.SystemIntake.SendGRBReviewerInvitedToVoteEmail(
-						ctx,
+						outboxCtx,
 						email.SendGRBReviewerInvitedToVoteInput{
 							Recipient:          models.EmailAddress(reviewer.Email),
 							StartDate:          *intake.GRBReviewStartedAt,
@@ -137,11 +140,19 @@ func CreateSystemIntakeGRBReviewers(ctx context.Context, store *storage.Store, e
 			Reviewers: createdReviewers,
 		}, nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishNotifications()
+	publishGRBVotingInformationChange(ps, models.GRBVotingInformationChangeTypeReviewerAdded, input.SystemIntakeID)
+	return payload, nil
 }
 
 func UpdateSystemIntakeGRBReviewer(
 	ctx context.Context,
 	store *storage.Store,
+	ps pubsub.PubSub,
 	input *models.UpdateSystemIntakeGRBReviewerInput,
 ) (*models.SystemIntakeGRBReviewer, error) {
 	if err := authorizeUserCanManageSystemIntakeGRBReview(ctx); err != nil {
@@ -162,14 +173,21 @@ func UpdateSystemIntakeGRBReviewer(
 		return nil, errors.New("cannot update GRB reviewer for completed GRB review")
 	}
 
-	return sqlutils.WithTransactionRet(ctx, store, func(tx *sqlx.Tx) (*models.SystemIntakeGRBReviewer, error) {
+	reviewer, err := sqlutils.WithTransactionRet(ctx, store, func(tx *sqlx.Tx) (*models.SystemIntakeGRBReviewer, error) {
 		return store.UpdateSystemIntakeGRBReviewer(ctx, tx, input)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishGRBVotingInformationChange(ps, models.GRBVotingInformationChangeTypeReviewerUpdated, intake.ID)
+	return reviewer, nil
 }
 
 func DeleteSystemIntakeGRBReviewer(
 	ctx context.Context,
 	store *storage.Store,
+	ps pubsub.PubSub,
 	reviewerID uuid.UUID,
 ) error {
 	if err := authorizeUserCanManageSystemIntakeGRBReview(ctx); err != nil {
@@ -190,12 +208,17 @@ func DeleteSystemIntakeGRBReviewer(
 		return errors.New("cannot delete GRB reviewer for completed GRB review")
 	}
 
-	return sqlutils.WithTransaction(ctx, store, func(tx *sqlx.Tx) error {
+	if err := sqlutils.WithTransaction(ctx, store, func(tx *sqlx.Tx) error {
 		return store.DeleteSystemIntakeGRBReviewer(ctx, tx, reviewerID)
-	})
+	}); err != nil {
+		return err
+	}
+
+	publishGRBVotingInformationChange(ps, models.GRBVotingInformationChangeTypeReviewerRemoved, intake.ID)
+	return nil
 }
 
-func CastSystemIntakeGRBReviewerVote(ctx context.Context, store *storage.Store, emailClient *email.Client, input models.CastSystemIntakeGRBReviewerVoteInput) (*models.SystemIntakeGRBReviewer, error) {
+func CastSystemIntakeGRBReviewerVote(ctx context.Context, store *storage.Store, emailClient *email.Client, ps pubsub.PubSub, input models.CastSystemIntakeGRBReviewerVoteInput) (*models.SystemIntakeGRBReviewer, error) {
 	// first, if "OBJECT" is the vote selection, confirm there is a comment (required for objections)
 	if input.Vote == models.SystemIntakeAsyncGRBVotingOptionObjection && (input.VoteComment == nil || len(*input.VoteComment) < 1) {
 		return nil, errors.New("vote comment is required with an `Objection` vote")
@@ -258,6 +281,8 @@ func CastSystemIntakeGRBReviewerVote(ctx context.Context, store *storage.Store,
 		return nil, err
 	}
 
+	publishGRBVotingInformationChange(ps, models.GRBVotingInformationChangeTypeVoteCast, input.SystemIntakeID)
+
 	// send email here
 	if emailClient != nil && systemIntake.GRBReviewStartedAt != nil && systemIntake.GrbReviewAsyncEndDate != nil && reviewer.Vote != nil {
 		// get email
@@ -423,7 +448,8 @@ func StartGRBReview(
 		return nil, err
 	}
 
-	return sqlutils.WithTransactionRet(ctx, store, func(tx *sqlx.Tx) (*string, error) {
+	ctx, publishNotifications := email.WithDeferredNotifications(ctx)
+	started, err := sqlutils.WithTransactionRet(ctx, store, func(tx *sqlx.Tx) (*string, error) {
 		intake, err := storage.FetchSystemIntakeByIDNP(ctx, tx, intakeID)
 		if err != nil {
 			return nil, err
@@ -459,7 +485,7 @@ func StartGRBReview(
 			})
 
 			if err := emailClient.SystemIntake.SendCreateGRBReviewerNotification(
-				ctx,
+				email.WithOutboxTransaction(ctx, tx),
 				emails,
 				intake.ID,
 				intake.ProjectName.String,
@@ -471,6 +497,13 @@ func StartGRBReview(
 		}
 		return helpers.PointerTo("started GRB review"), nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// publish once the review has started, so subscribers are able to load the notifications
+	publishNotifications()
+	return started, nil
 }
 
 func getPrincipalAsGRBReviewerBySystemIntakeID(ctx context.Context, systemIntakeID uuid.UUID) (*models.SystemIntakeGRBReviewer, error) {
@@ -9,10 +9,12 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/cms-enterprise/easi-app/cmd/devdata/mock"
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
 	"github.com/cms-enterprise/easi-app/pkg/authentication"
 	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/local"
 	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 	"github.com/cms-enterprise/easi-app/pkg/userhelpers"
 )
 
@@ -37,6 +39,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 			ctx,
 			store,
 			emailClient,
+			pubsub.NewServicePubSub(),
 			userhelpers.GetUserInfoAccountInfosWrapperFunc(okta.FetchUserInfos),
 			&models.CreateSystemIntakeGRBReviewersInput{
 				SystemIntakeID: intake.ID,
@@ -112,7 +115,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 				GrbRole:    models.SystemIntakeGRBReviewerRoleFedAdminBdgChair,
 			},
 		}
-		_, err = CreateSystemIntakeGRBReviewers(s.testConfigs.Context, s.testConfigs.Store, emailClient, userhelpers.GetUserInfoAccountInfosWrapperFunc(mock.FetchUserInfosMock), &models.CreateSystemIntakeGRBReviewersInput{
+		_, err = CreateSystemIntakeGRBReviewers(s.testConfigs.Context, s.testConfigs.Store, emailClient, pubsub.NewServicePubSub(), userhelpers.GetUserInfoAccountInfosWrapperFunc(mock.FetchUserInfosMock), &models.CreateSystemIntakeGRBReviewersInput{
 			SystemIntakeID: intake.ID,
 			Reviewers:      reviewers,
 		})
@@ -121,7 +124,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 		// set votes for each one
 		for _, reviewer := range reviewers {
 			rctx, _ := s.getTestContextWithPrincipal(reviewer.EuaUserID, false)
-			_, err := CastSystemIntakeGRBReviewerVote(rctx, s.testConfigs.Store, s.testConfigs.EmailClient, models.CastSystemIntakeGRBReviewerVoteInput{
+			_, err := CastSystemIntakeGRBReviewerVote(rctx, s.testConfigs.Store, s.testConfigs.EmailClient, pubsub.NewServicePubSub(), models.CastSystemIntakeGRBReviewerVoteInput{
 				SystemIntakeID: intake.ID,
 				Vote:           models.SystemIntakeAsyncGRBVotingOptionNoObjection,
 				VoteComment:    nil,
@@ -136,7 +139,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 		intake, err = store.UpdateSystemIntake(ctx, intake)
 		s.NoError(err)
 
-		_, err = CreateSystemIntakeGRBReviewers(s.ctxWithNewDataloaders(), store, emailClient, userhelpers.GetUserInfoAccountInfosWrapperFunc(okta.FetchUserInfos), &models.CreateSystemIntakeGRBReviewersInput{
+		_, err = CreateSystemIntakeGRBReviewers(s.ctxWithNewDataloaders(), store, emailClient, pubsub.NewServicePubSub(), userhelpers.GetUserInfoAccountInfosWrapperFunc(okta.FetchUserInfos), &models.CreateSystemIntakeGRBReviewersInput{
 			SystemIntakeID: intake.ID,
 			Reviewers: []*models.CreateGRBReviewerInput{
 				{
@@ -198,6 +201,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 		updatedReviewer, err := UpdateSystemIntakeGRBReviewer(
 			ctx,
 			store,
+			pubsub.NewServicePubSub(),
 			&models.UpdateSystemIntakeGRBReviewerInput{
 				ReviewerID: reviewer.ID,
 				VotingRole: newVotingRole,
@@ -247,7 +251,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 				GrbRole:    models.SystemIntakeGRBReviewerRoleFedAdminBdgChair,
 			},
 		}
-		res, err := CreateSystemIntakeGRBReviewers(s.testConfigs.Context, s.testConfigs.Store, s.testConfigs.EmailClient, userhelpers.GetUserInfoAccountInfosWrapperFunc(mock.FetchUserInfosMock), &models.CreateSystemIntakeGRBReviewersInput{
+		res, err := CreateSystemIntakeGRBReviewers(s.testConfigs.Context, s.testConfigs.Store, s.testConfigs.EmailClient, pubsub.NewServicePubSub(), userhelpers.GetUserInfoAccountInfosWrapperFunc(mock.FetchUserInfosMock), &models.CreateSystemIntakeGRBReviewersInput{
 			SystemIntakeID: intake.ID,
 			Reviewers:      reviewers,
 		})
@@ -266,7 +270,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 		// set votes for each one
 		for _, grbReviewer := range reviewers {
 			rctx, _ := s.getTestContextWithPrincipal(grbReviewer.EuaUserID, false)
-			_, err := CastSystemIntakeGRBReviewerVote(rctx, s.testConfigs.Store, s.testConfigs.EmailClient, models.CastSystemIntakeGRBReviewerVoteInput{
+			_, err := CastSystemIntakeGRBReviewerVote(rctx, s.testConfigs.Store, s.testConfigs.EmailClient, pubsub.NewServicePubSub(), models.CastSystemIntakeGRBReviewerVoteInput{
 				SystemIntakeID: intake.ID,
 				Vote:           models.SystemIntakeAsyncGRBVotingOptionNoObjection,
 				VoteComment:    nil,
@@ -282,6 +286,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 		_, err = UpdateSystemIntakeGRBReviewer(
 			ctx,
 			store,
+			pubsub.NewServicePubSub(),
 			&models.UpdateSystemIntakeGRBReviewerInput{
 				ReviewerID: res.Reviewers[0].ID,
 				VotingRole: newVotingRole,
@@ -310,6 +315,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 		err = DeleteSystemIntakeGRBReviewer(
 			ctx,
 			store,
+			pubsub.NewServicePubSub(),
 			reviewer.ID,
 		)
 		s.NoError(err)
@@ -350,7 +356,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 				GrbRole:    models.SystemIntakeGRBReviewerRoleFedAdminBdgChair,
 			},
 		}
-		res, err := CreateSystemIntakeGRBReviewers(s.testConfigs.Context, s.testConfigs.Store, s.testConfigs.EmailClient, userhelpers.GetUserInfoAccountInfosWrapperFunc(mock.FetchUserInfosMock), &models.CreateSystemIntakeGRBReviewersInput{
+		res, err := CreateSystemIntakeGRBReviewers(s.testConfigs.Context, s.testConfigs.Store, s.testConfigs.EmailClient, pubsub.NewServicePubSub(), userhelpers.GetUserInfoAccountInfosWrapperFunc(mock.FetchUserInfosMock), &models.CreateSystemIntakeGRBReviewersInput{
 			SystemIntakeID: intake.ID,
 			Reviewers:      reviewers,
 		})
@@ -369,7 +375,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 		// set votes for each one
 		for _, grbReviewer := range reviewers {
 			rctx, _ := s.getTestContextWithPrincipal(grbReviewer.EuaUserID, false)
-			_, err := CastSystemIntakeGRBReviewerVote(rctx, s.testConfigs.Store, s.testConfigs.EmailClient, models.CastSystemIntakeGRBReviewerVoteInput{
+			_, err := CastSystemIntakeGRBReviewerVote(rctx, s.testConfigs.Store, s.testConfigs.EmailClient, pubsub.NewServicePubSub(), models.CastSystemIntakeGRBReviewerVoteInput{
 				SystemIntakeID: intake.ID,
 				Vote:           models.SystemIntakeAsyncGRBVotingOptionNoObjection,
 				VoteComment:    nil,
@@ -385,6 +391,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewer() {
 		err = DeleteSystemIntakeGRBReviewer(
 			ctx,
 			store,
+			pubsub.NewServicePubSub(),
 			res.Reviewers[0].ID,
 		)
 		s.Error(err)
@@ -430,7 +437,7 @@ func (s *ResolverSuite) TestSystemIntakeStartGRBReview() {
 
 	s.Run("adding reviewers should not email them before start", func() {
 		emailClient, sender := NewEmailClient()
-		payload, err := CreateSystemIntakeGRBReviewers(ctx, store, emailClient, userhelpers.GetUserInfoAccountInfosWrapperFunc(okta.FetchUserInfos), &models.CreateSystemIntakeGRBReviewersInput{
+		payload, err := CreateSystemIntakeGRBReviewers(ctx, store, emailClient, pubsub.NewServicePubSub(), userhelpers.GetUserInfoAccountInfosWrapperFunc(okta.FetchUserInfos), &models.CreateSystemIntakeGRBReviewersInput{
 			SystemIntakeID: intake.ID,
 			Reviewers:      reviewers[0:2], //first two
 		})
@@ -461,7 +468,7 @@ func (s *ResolverSuite) TestSystemIntakeStartGRBReview() {
 
 	s.Run("adding a reviewer after review starts sends email", func() {
 		emailClient, sender := NewEmailClient()
-		payload, err := CreateSystemIntakeGRBReviewers(ctx, store, emailClient, userhelpers.GetUserInfoAccountInfosWrapperFunc(okta.FetchUserInfos), &models.CreateSystemIntakeGRBReviewersInput{
+		payload, err := CreateSystemIntakeGRBReviewers(ctx, store, emailClient, pubsub.NewServicePubSub(), userhelpers.GetUserInfoAccountInfosWrapperFunc(okta.FetchUserInfos), &models.CreateSystemIntakeGRBReviewersInput{
 			SystemIntakeID: intake.ID,
 			Reviewers:      reviewers[2:], //last
 		})
@@ -600,10 +607,118 @@ func (s *ResolverSuite) createIntakeAndAddReviewers(reviewers ...*models.CreateG
 	okta := local.NewOktaAPIClient()
 
 	intake := s.createNewIntake()
-	createPayload, err := CreateSystemIntakeGRBReviewers(ctx, store, s.testConfigs.EmailClient, userhelpers.GetUserInfoAccountInfosWrapperFunc(okta.FetchUserInfos), &models.CreateSystemIntakeGRBReviewersInput{
+	createPayload, err := CreateSystemIntakeGRBReviewers(ctx, store, s.testConfigs.EmailClient, pubsub.NewServicePubSub(), userhelpers.GetUserInfoAccountInfosWrapperFunc(okta.FetchUserInfos), &models.CreateSystemIntakeGRBReviewersInput{
 		SystemIntakeID: intake.ID,
 		Reviewers:      reviewers,
 	})
 	s.NoError(err)
 	return intake, createPayload.Reviewers
 }
+
+func (s *ResolverSuite) TestOnGRBVotingInformationChanged() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+	okta := local.NewOktaAPIClient()
+	ps := pubsub.NewServicePubSub()
+
+	intake := s.createNewIntake(func(intake *models.SystemIntake) {
+		intake.GrbReviewType = models.SystemIntakeGRBReviewTypeAsync
+		intake.GRBReviewStartedAt = helpers.PointerTo(time.Now().AddDate(0, 0, -1))
+		intake.GrbReviewAsyncEndDate = helpers.PointerTo(time.Now().AddDate(0, 0, 1))
+	})
+
+	disconnect := make(chan struct{})
+	defer close(disconnect)
+
+	awaitChange := func(changes <-chan *models.GRBVotingInformationChanged) *models.GRBVotingInformationChanged {
+		select {
+		case change := <-changes:
+			return change
+		case <-time.After(5 * time.Second):
+			s.FailNow("timed out waiting for GRB voting information change")
+			return nil
+		}
+	}
+
+	// the requester can follow voting, but cannot see who the reviewers are
+	requesterCtx, _ := s.getTestContextWithPrincipal(s.testConfigs.UserInfo.Username, false)
+	requesterChanges, err := OnGRBVotingInformationChanged(requesterCtx, store, ps, intake.ID, disconnect)
+	s.NoError(err)
+
+	// users who cannot view the intake cannot subscribe
+	otherCtx, _ := s.getTestContextWithPrincipal("BTMN", false)
+	otherChanges, err := OnGRBVotingInformationChanged(otherCtx, store, ps, intake.ID, disconnect)
+	s.Error(err)
+	s.Nil(otherChanges)
+
+	createPayload, err := CreateSystemIntakeGRBReviewers(ctx, store, nil, ps, userhelpers.GetUserInfoAccountInfosWrapperFunc(okta.FetchUserInfos), &models.CreateSystemIntakeGRBReviewersInput{
+		SystemIntakeID: intake.ID,
+		Reviewers: []*models.CreateGRBReviewerInput{
+			{
+				EuaUserID:  "ABCD",
+				VotingRole: models.SystemIntakeGRBReviewerVotingRoleVoting,
+				GrbRole:    models.SystemIntakeGRBReviewerRoleCmcsRep,
+			},
+			{
+				EuaUserID:  "A11Y",
+				VotingRole: models.SystemIntakeGRBReviewerVotingRoleVoting,
+				GrbRole:    models.SystemIntakeGRBReviewerRoleOther,
+			},
+		},
+	})
+	s.NoError(err)
+
+	change := awaitChange(requesterChanges)
+	s.Equal(models.GRBVotingInformationChangeTypeReviewerAdded, change.ChangeType)
+	s.Equal(intake.ID, change.SystemIntakeID)
+	s.Equal(0, change.GrbVotingInformation.NumberOfVoted())
+	s.Equal(2, change.GrbVotingInformation.NumberOfNotVoted())
+	s.False(change.GrbVotingInformation.QuorumReached())
+	s.Empty(change.GrbVotingInformation.GRBReviewers)
+
+	// reviewers can see who the other reviewers are
+	reviewerCtx, _ := s.getTestContextWithPrincipal("ABCD", false)
+	reviewerChanges, err := OnGRBVotingInformationChanged(reviewerCtx, store, ps, intake.ID, disconnect)
+	s.NoError(err)
+
+	_, err = CastSystemIntakeGRBReviewerVote(reviewerCtx, store, nil, ps, models.CastSystemIntakeGRBReviewerVoteInput{
+		SystemIntakeID: intake.ID,
+		Vote:           models.SystemIntakeAsyncGRBVotingOptionNoObjection,
+	})
+	s.NoError(err)
+
+	change = awaitChange(requesterChanges)
+	s.Equal(models.GRBVotingInformationChangeTypeVoteCast, change.ChangeType)
+	s.Equal(1, change.GrbVotingInformation.NumberOfVoted())
+	s.Equal(1, change.GrbVotingInformation.NumberOfNotVoted())
+	s.True(change.GrbVotingInformation.QuorumReached())
+	s.Empty(change.GrbVotingInformation.GRBReviewers)
+
+	change = awaitChange(reviewerChanges)
+	s.Equal(models.GRBVotingInformationChangeTypeVoteCast, change.ChangeType)
+	s.Equal(1, change.GrbVotingInformation.NumberOfVoted())
+	s.Len(change.GrbVotingInformation.GRBReviewers, 2)
+
+	_, err = ExtendGRBReviewDeadlineAsync(ctx, store, s.testConfigs.EmailClient, ps, models.ExtendGRBReviewDeadlineInput{
+		SystemIntakeID:        intake.ID,
+		GrbReviewAsyncEndDate: time.Now().AddDate(0, 0, 7),
+	})
+	s.NoError(err)
+
+	s.Equal(models.GRBVotingInformationChangeTypeDeadlineExtended, awaitChange(requesterChanges).ChangeType)
+	s.Equal(models.GRBVotingInformationChangeTypeDeadlineExtended, awaitChange(reviewerChanges).ChangeType)
+
+	// removing a reviewer ends their subscription, as they can no longer view the intake
+	reviewer, found := lo.Find(createPayload.Reviewers, func(reviewer *models.SystemIntakeGRBReviewer) bool {
+		return reviewer.UserID == appcontext.Principal(reviewerCtx).Account().ID
+	})
+	s.True(found)
+	s.NoError(DeleteSystemIntakeGRBReviewer(ctx, store, ps, reviewer.ID))
+
+	change = awaitChange(requesterChanges)
+	s.Equal(models.GRBVotingInformationChangeTypeReviewerRemoved, change.ChangeType)
+	s.Equal(0, change.GrbVotingInformation.NumberOfVoted())
+	s.Equal(1, change.GrbVotingInformation.NumberOfNotVoted())
+
+	s.Nil(awaitChange(reviewerChanges))
+}
@@ -2,9 +2,18 @@ package resolvers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/graph/model/subscribers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/models/pubsubevents"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
 )
 
 // GRBVotingInformationGetBySystemIntake wraps a system intake and its GRB reviewers.
@@ -19,16 +28,112 @@ func GRBVotingInformationGetBySystemIntake(ctx context.Context, intake *models.S
 		return nil, err
 	}
 
+	return newGRBVotingInformation(ctx, intake, reviewers), nil
+}
+
+// newGRBVotingInformation builds the voting information for an intake, only including the reviewers' identities if the
+// principal is allowed to see them. Counts are always calculated from all reviewers.
+func newGRBVotingInformation(ctx context.Context, intake *models.SystemIntake, reviewers []*models.SystemIntakeGRBReviewer) *models.GRBVotingInformation {
 	visibleReviewers := reviewers
 	if !userCanViewSystemIntakeGRBReviewerIdentities(ctx, reviewers) {
 		visibleReviewers = []*models.SystemIntakeGRBReviewer{}
 	}
 
-	votingInformation := &models.GRBVotingInformation{
+	return &models.GRBVotingInformation{
 		SystemIntake:    intake,
 		GRBReviewers:    visibleReviewers,
 		AllGRBReviewers: reviewers,
 	}
-	return votingInformation, nil
+}
+
+// publishGRBVotingInformationChange notifies subscribers of a system intake's GRB voting information that it changed
+func publishGRBVotingInformationChange(ps pubsub.PubSub, changeType models.GRBVotingInformationChangeType, systemIntakeID uuid.UUID) {
+	if ps == nil {
+		return
+	}
+
+	ps.Publish(systemIntakeID, pubsubevents.GRBVotingInformationChanged, models.GRBVotingInformationChangedEvent{
+		ChangeType:     changeType,
+		SystemIntakeID: systemIntakeID,
+	})
+}
+
+// OnGRBVotingInformationChanged subscribes the principal to changes in the voting information of a system intake's GRB review.
+//
+// Voting information is recalculated for the principal on each change, rather than being published, so that it is always
+// current and is limited to what the principal is allowed to see. The subscription ends if the principal loses access to
+// the intake.
+func OnGRBVotingInformationChanged(
+	ctx context.Context,
+	store *storage.Store,
+	ps pubsub.PubSub,
+	systemIntakeID uuid.UUID,
+	onDisconnect <-chan struct{},
+) (<-chan *models.GRBVotingInformationChanged, error) {
+	if _, err := getViewableGRBVotingInformation(ctx, store, systemIntakeID); err != nil {
+		return nil, err
+	}
+
+	logger := appcontext.ZLogger(ctx)
+	subscriber := subscribers.NewGRBVotingInformationChangedSubscriber(appcontext.Principal(ctx), systemIntakeID, logger)
+	ps.Subscribe(systemIntakeID, pubsubevents.GRBVotingInformationChanged, subscriber, onDisconnect)
+
+	changes := make(chan *models.GRBVotingInformationChanged)
+	go func() {
+		defer close(changes)
+
+		for {
+			select {
+			case <-onDisconnect:
+				return
+
+			case event := <-subscriber.GetChannel():
+				votingInformation, err := getViewableGRBVotingInformation(ctx, store, event.SystemIntakeID)
+				if err != nil {
+					logger.Error("problem getting GRB voting information for subscriber, ending subscription",
+						zap.Error(err),
+						zap.String("intake.id", event.SystemIntakeID.String()),
+					)
+					ps.Unsubscribe(systemIntakeID, pubsubevents.GRBVotingInformationChanged, subscriber.GetID())
+					return
+				}
+
+				select {
+				case <-onDisconnect:
+					return
+				case changes <- &models.GRBVotingInformationChanged{
+					ChangeType:           event.ChangeType,
+					SystemIntakeID:       event.SystemIntakeID,
+					GrbVotingInformation: votingInformation,
+				}:
+				}
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// getViewableGRBVotingInformation loads the current GRB voting information for an intake directly from the database,
+// bypassing dataloaders, which are held for the whole lifetime of a subscription and would return stale data
+func getViewableGRBVotingInformation(ctx context.Context, store *storage.Store, systemIntakeID uuid.UUID) (*models.GRBVotingInformation, error) {
+	intake, err := store.FetchSystemIntakeByID(ctx, systemIntakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if intake == nil {
+		return nil, errors.New("system intake not found")
+	}
+
+	if err := authorizeUserCanViewSystemIntake(ctx, store, intake); err != nil {
+		return nil, err
+	}
+
+	reviewers, err := store.SystemIntakeGRBReviewersBySystemIntakeIDs(ctx, []uuid.UUID{systemIntakeID})
+	if err != nil {
+		return nil, err
+	}
 
+	return newGRBVotingInformation(ctx, intake, reviewers), nil
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 	"github.com/cms-enterprise/easi-app/pkg/userhelpers"
 )
 
@@ -56,6 +57,7 @@ func (s *ResolverSuite) addReviewerToSystemIntake(intakeID uuid.UUID, reviewerEU
 		s.testConfigs.Context,
 		s.testConfigs.Store,
 		s.testConfigs.EmailClient,
+		pubsub.NewServicePubSub(),
 		userhelpers.GetUserInfoAccountInfosWrapperFunc(s.testConfigs.UserSearchClient.FetchUserInfos),
 		&models.CreateSystemIntakeGRBReviewersInput{
 			SystemIntakeID: intakeID,
@@ -997,6 +999,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBReviewerIdentityVisibility() {
 		reviewerCtx,
 		s.testConfigs.Store,
 		s.testConfigs.EmailClient,
+		pubsub.NewServicePubSub(),
 		models.CastSystemIntakeGRBReviewerVoteInput{
 			SystemIntakeID: intake.ID,
 			Vote:           models.SystemIntakeAsyncGRBVotingOptionNoObjection,
@@ -1056,6 +1059,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBDiscussionVisibility() {
 		ownerCtx,
 		s.testConfigs.Store,
 		s.testConfigs.EmailClient,
+		pubsub.NewServicePubSub(),
 		models.CreateSystemIntakeGRBDiscussionPostInput{
 			SystemIntakeID: intake.ID,
 			Content: models.TaggedHTML{
@@ -1070,6 +1074,7 @@ func (s *ResolverSuite) TestSystemIntakeGRBDiscussionVisibility() {
 		reviewerCtx,
 		s.testConfigs.Store,
 		s.testConfigs.EmailClient,
+		pubsub.NewServicePubSub(),
 		models.CreateSystemIntakeGRBDiscussionPostInput{
 			SystemIntakeID: intake.ID,
 			Content: models.TaggedHTML{
@@ -12,6 +12,9 @@ import (
 )
 
 // CalculateSystemIntakeAdminStatus calculates the status to display in the admin view for a System Intake Request, based on the current step, and the state of that step and the overall state
+//
+// The admin page query (pkg/sqlqueries/SQL/system_intake/get_admin_page.sql) repeats these rules in SQL to filter intakes by status,
+// so any change here must be made there too; TestAdminPageQueryMatchesCalculatedAdminStatus checks that the two agree.
 func CalculateSystemIntakeAdminStatus(ctx context.Context, intake *models.SystemIntake) (models.SystemIntakeStatusAdmin, error) {
 	if intake.Step == models.SystemIntakeStepDECISION && intake.DecisionState == models.SIDSNoDecision {
 		return "", fmt.Errorf("invalid state") // This status should not be returned in normal use of the application
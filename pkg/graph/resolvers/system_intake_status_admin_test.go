@@ -2,14 +2,17 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/guregu/null"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
 )
 
 type testSystemIntakeAdminStatusType struct {
@@ -598,3 +601,201 @@ func systemIntakeAdminStatusRunTestCollection(t *testing.T, tests []testSystemIn
 		}
 	})
 }
+
+// TestAdminPageQueryMatchesCalculatedAdminStatus checks that the admin page query, which filters intakes by admin status in SQL, matches each intake
+// by the status CalculateSystemIntakeAdminStatus calculates for it, for every combination of step, state, and decision, along with the fields
+// each step's status depends on
+func (s *ResolverSuite) TestAdminPageQueryMatchesCalculatedAdminStatus() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+	yesterday := time.Now().Add(-24 * time.Hour)
+	tomorrow := time.Now().Add(24 * time.Hour)
+
+	type variant struct {
+		name   string
+		update func(intake *models.SystemIntake)
+	}
+
+	formStateVariants := func(setFormState func(intake *models.SystemIntake, state models.SystemIntakeFormState)) []variant {
+		var variants []variant
+		for _, state := range []models.SystemIntakeFormState{models.SIRFSNotStarted, models.SIRFSInProgress, models.SIRFSEditsRequested, models.SIRFSSubmitted} {
+			variants = append(variants, variant{string(state), func(intake *models.SystemIntake) {
+				setFormState(intake, state)
+			}})
+		}
+		return variants
+	}
+	meetingDateVariants := func(date func(intake *models.SystemIntake) **time.Time) []variant {
+		return []variant{
+			{"no meeting date", func(intake *models.SystemIntake) {}},
+			{"meeting tomorrow", func(intake *models.SystemIntake) { *date(intake) = &tomorrow }},
+			{"meeting yesterday", func(intake *models.SystemIntake) { *date(intake) = &yesterday }},
+		}
+	}
+	asyncReview := func(startedAt *time.Time, endDate *time.Time, manualEndDate *time.Time) func(intake *models.SystemIntake) {
+		return func(intake *models.SystemIntake) {
+			intake.GrbReviewType = models.SystemIntakeGRBReviewTypeAsync
+			intake.GRBReviewStartedAt = startedAt
+			intake.GrbReviewAsyncEndDate = endDate
+			intake.GrbReviewAsyncManualEndDate = manualEndDate
+		}
+	}
+
+	steps := []struct {
+		step     models.SystemIntakeStep
+		variants []variant
+	}{
+		{models.SystemIntakeStepINITIALFORM, formStateVariants(func(intake *models.SystemIntake, state models.SystemIntakeFormState) {
+			intake.RequestFormState = state
+		})},
+		{models.SystemIntakeStepDRAFTBIZCASE, formStateVariants(func(intake *models.SystemIntake, state models.SystemIntakeFormState) {
+			intake.DraftBusinessCaseState = state
+		})},
+		{models.SystemIntakeStepGRTMEETING, meetingDateVariants(func(intake *models.SystemIntake) **time.Time {
+			return &intake.GRTDate
+		})},
+		{models.SystemIntakeStepFINALBIZCASE, formStateVariants(func(intake *models.SystemIntake, state models.SystemIntakeFormState) {
+			intake.FinalBusinessCaseState = state
+		})},
+		{models.SystemIntakeStepGRBMEETING, append(
+			meetingDateVariants(func(intake *models.SystemIntake) **time.Time {
+				return &intake.GRBDate
+			}),
+			variant{"async review not started", asyncReview(nil, nil, nil)},
+			variant{"async review starting tomorrow", asyncReview(&tomorrow, helpers.PointerTo(tomorrow.Add(24*time.Hour)), nil)},
+			variant{"async review in progress", asyncReview(&yesterday, &tomorrow, nil)},
+			variant{"async review ended early", asyncReview(&yesterday, &tomorrow, &yesterday)},
+			variant{"async review past its end date", asyncReview(helpers.PointerTo(yesterday.Add(-24*time.Hour)), &yesterday, nil)},
+		)},
+		{models.SystemIntakeStepDECISION, []variant{{"decision", func(intake *models.SystemIntake) {}}}},
+	}
+
+	// an intake's LCID dates can affect its status at any step, since an intake can be re-opened to an earlier step after an LCID is issued,
+	// so they're combined with the first variant of each step
+	lcidVariants := []variant{
+		{"LCID issued", func(intake *models.SystemIntake) {
+			intake.LifecycleID = null.StringFrom("123456")
+			intake.LifecycleExpiresAt = &tomorrow
+		}},
+		{"LCID expired", func(intake *models.SystemIntake) {
+			intake.LifecycleID = null.StringFrom("123456")
+			intake.LifecycleExpiresAt = &yesterday
+		}},
+		{"LCID retired", func(intake *models.SystemIntake) {
+			intake.LifecycleID = null.StringFrom("123456")
+			intake.LifecycleExpiresAt = &tomorrow
+			intake.LifecycleRetiresAt = &yesterday
+		}},
+		{"LCID retiring soon", func(intake *models.SystemIntake) {
+			intake.LifecycleID = null.StringFrom("123456")
+			intake.LifecycleExpiresAt = &tomorrow
+			intake.LifecycleRetiresAt = &tomorrow
+		}},
+	}
+
+	// every intake is given the same unique project name, so only the intakes created here are searched for
+	projectName := "Admin status " + uuid.NewString()
+	testCases := map[uuid.UUID]string{}
+	intakes := map[uuid.UUID]*models.SystemIntake{}
+	for _, step := range steps {
+		variants := step.variants
+		for _, lcidVariant := range lcidVariants {
+			variants = append(variants, variant{
+				name: step.variants[0].name + ", " + lcidVariant.name,
+				update: func(intake *models.SystemIntake) {
+					step.variants[0].update(intake)
+					lcidVariant.update(intake)
+				},
+			})
+		}
+
+		for _, state := range []models.SystemIntakeState{models.SystemIntakeStateOpen, models.SystemIntakeStateClosed} {
+			for _, decisionState := range []models.SystemIntakeDecisionState{models.SIDSNoDecision, models.SIDSLcidIssued, models.SIDSNotApproved, models.SIDSNotGovernance} {
+				// intakes can't be on the decision step without a decision
+				if step.step == models.SystemIntakeStepDECISION && decisionState == models.SIDSNoDecision {
+					continue
+				}
+
+				for _, variant := range variants {
+					intake, err := storage.CreateSystemIntake(ctx, store, &models.SystemIntake{
+						State:       state,
+						RequestType: models.SystemIntakeRequestTypeNEW,
+					})
+					s.NoError(err)
+
+					intake.ProjectName = null.StringFrom(projectName)
+					intake.SubmittedAt = &yesterday
+					intake.Step = step.step
+					intake.DecisionState = decisionState
+					intake.GrbReviewType = models.SystemIntakeGRBReviewTypeStandard
+					variant.update(intake)
+					intake, err = store.UpdateSystemIntake(ctx, intake)
+					s.NoError(err)
+
+					testCases[intake.ID] = fmt.Sprintf("%s, %s, %s, %s", step.step, state, decisionState, variant.name)
+					intakes[intake.ID] = intake
+				}
+			}
+		}
+	}
+
+	// the statuses the query matches each intake by
+	matchedStatuses := map[uuid.UUID][]models.SystemIntakeStatusAdmin{}
+	for _, status := range []models.SystemIntakeStatusAdmin{
+		models.SISAInitialRequestFormInProgress,
+		models.SISAInitialRequestFormSubmitted,
+		models.SISADraftBusinessCaseInProgress,
+		models.SISADraftBusinessCaseSubmitted,
+		models.SISAGrtMeetingReady,
+		models.SISAGrtMeetingComplete,
+		models.SISAGrbMeetingReady,
+		models.SISAGrbReviewInProgress,
+		models.SISAGrbReviewComplete,
+		models.SISAFinalBusinessCaseInProgress,
+		models.SISAFinalBusinessCaseSubmitted,
+		models.SISALcidIssued,
+		models.SISALcidExpired,
+		models.SISALcidRetired,
+		models.SISALcidRetiringSoon,
+		models.SISANotGovernance,
+		models.SISANotApproved,
+		models.SISAClosed,
+	} {
+		matched, err := store.FetchSystemIntakesPageForAdmins(ctx, models.SystemIntakesFilter{
+			AdminStatuses: []models.SystemIntakeStatusAdmin{status},
+			Search:        &projectName,
+		}, models.SystemIntakesSort{
+			Field:     models.SystemIntakesSortFieldSubmittedAt,
+			Direction: models.SortDirectionDesc,
+		}, len(intakes), nil)
+		s.NoError(err)
+
+		for _, intake := range matched {
+			matchedStatuses[intake.ID] = append(matchedStatuses[intake.ID], status)
+		}
+	}
+
+	for id, testCase := range testCases {
+		s.Run(testCase, func() {
+			status, err := CalculateSystemIntakeAdminStatus(ctx, intakes[id])
+			if err != nil {
+				s.Empty(matchedStatuses[id], "intakes without an admin status shouldn't match any status")
+				return
+			}
+
+			// an async GRB review past its end date is either in progress or complete, depending on its quorum,
+			// which the query can't check, so it matches both
+			intake := intakes[id]
+			if intake.Step == models.SystemIntakeStepGRBMEETING &&
+				intake.GrbReviewType == models.SystemIntakeGRBReviewTypeAsync &&
+				(status == models.SISAGrbReviewInProgress || status == models.SISAGrbReviewComplete) &&
+				intake.GrbReviewAsyncEndDate != nil && intake.GrbReviewAsyncEndDate.Before(time.Now()) &&
+				intake.GrbReviewAsyncManualEndDate == nil {
+				s.ElementsMatch([]models.SystemIntakeStatusAdmin{models.SISAGrbReviewInProgress, models.SISAGrbReviewComplete}, matchedStatuses[id])
+				return
+			}
+
+			s.Equal([]models.SystemIntakeStatusAdmin{status}, matchedStatuses[id])
+		})
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
 	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
 	"github.com/cms-enterprise/easi-app/pkg/dataloaders"
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 	"github.com/cms-enterprise/easi-app/pkg/storage"
 )
@@ -141,7 +143,7 @@ func (s *ResolverSuite) TestSystemIntakeByIDLoaderReturnsPerKeyErrors() {
 	intake := s.createNewIntake()
 	missingID := uuid.New()
 
-	coreClient := cedarcore.NewClient(s.testConfigs.Context, "", "", "", true)
+	coreClient := cedarcore.NewClient(s.testConfigs.Context, "", "", "", true, cedarcore.TransportConfig{}, cedarcore.CacheConfig{})
 	getCedarSystems := func(ctx context.Context) ([]*models.CedarSystem, error) {
 		return coreClient.GetSystemSummary(ctx)
 	}
@@ -292,3 +294,97 @@ func (s *ResolverSuite) TestRequesterUpdateEmailDataAuthorization() {
 	_, err = resolver.RequesterUpdateEmailData(adminCtx)
 	s.NoError(err)
 }
+
+func (s *ResolverSuite) TestSystemIntakesConnection() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+
+	createSubmittedIntake := func(projectName string, requestType models.SystemIntakeRequestType, submittedAt time.Time) *models.SystemIntake {
+		intake, err := storage.CreateSystemIntake(ctx, store, &models.SystemIntake{
+			State:       models.SystemIntakeStateOpen,
+			RequestType: requestType,
+		})
+		s.NoError(err)
+
+		intake.ProjectName = null.StringFrom(projectName)
+		intake.SubmittedAt = &submittedAt
+		intake, err = store.UpdateSystemIntake(ctx, intake)
+		s.NoError(err)
+		return intake
+	}
+
+	now := time.Now()
+	oldest := createSubmittedIntake("Oldest Project", models.SystemIntakeRequestTypeNEW, now.Add(-72*time.Hour))
+	middle := createSubmittedIntake("Middle Project", models.SystemIntakeRequestTypeRECOMPETE, now.Add(-48*time.Hour))
+	newest := createSubmittedIntake("Newest 100% Project", models.SystemIntakeRequestTypeNEW, now.Add(-24*time.Hour))
+
+	intakeIDs := func(connection *models.SystemIntakeConnection) []uuid.UUID {
+		ids := make([]uuid.UUID, len(connection.Edges))
+		for i, edge := range connection.Edges {
+			ids[i] = edge.Node.ID
+		}
+		return ids
+	}
+
+	s.Run("returns the most recently submitted first by default", func() {
+		connection, err := SystemIntakesConnection(ctx, store, 25, nil, nil, nil)
+		s.NoError(err)
+		s.Equal([]uuid.UUID{newest.ID, middle.ID, oldest.ID}, intakeIDs(connection))
+		s.False(connection.PageInfo.HasNextPage)
+	})
+
+	s.Run("pages through the results in the requested order", func() {
+		sort := &models.SystemIntakesSort{
+			Field:     models.SystemIntakesSortFieldSubmittedAt,
+			Direction: models.SortDirectionAsc,
+		}
+
+		firstPage, err := SystemIntakesConnection(ctx, store, 2, nil, nil, sort)
+		s.NoError(err)
+		s.Equal([]uuid.UUID{oldest.ID, middle.ID}, intakeIDs(firstPage))
+		s.True(firstPage.PageInfo.HasNextPage)
+
+		secondPage, err := SystemIntakesConnection(ctx, store, 2, firstPage.PageInfo.EndCursor, nil, sort)
+		s.NoError(err)
+		s.Equal([]uuid.UUID{newest.ID}, intakeIDs(secondPage))
+		s.False(secondPage.PageInfo.HasNextPage)
+	})
+
+	s.Run("filters by request type and submission date", func() {
+		connection, err := SystemIntakesConnection(ctx, store, 25, nil, &models.SystemIntakesFilter{
+			RequestTypes:   []models.SystemIntakeRequestType{models.SystemIntakeRequestTypeNEW},
+			SubmittedAfter: helpers.PointerTo(now.Add(-36 * time.Hour)),
+		}, nil)
+		s.NoError(err)
+		s.Equal([]uuid.UUID{newest.ID}, intakeIDs(connection))
+	})
+
+	s.Run("searches the project name, treating wildcards literally", func() {
+		connection, err := SystemIntakesConnection(ctx, store, 25, nil, &models.SystemIntakesFilter{
+			Search: helpers.PointerTo("100%"),
+		}, nil)
+		s.NoError(err)
+		s.Equal([]uuid.UUID{newest.ID}, intakeIDs(connection))
+	})
+
+	s.Run("filters by calculated admin status", func() {
+		connection, err := SystemIntakesConnection(ctx, store, 25, nil, &models.SystemIntakesFilter{
+			AdminStatuses: []models.SystemIntakeStatusAdmin{models.SISAInitialRequestFormInProgress},
+		}, nil)
+		s.NoError(err)
+		s.Len(connection.Edges, 3)
+
+		connection, err = SystemIntakesConnection(ctx, store, 25, nil, &models.SystemIntakesFilter{
+			AdminStatuses: []models.SystemIntakeStatusAdmin{models.SISALcidIssued},
+		}, nil)
+		s.NoError(err)
+		s.Empty(connection.Edges)
+	})
+
+	s.Run("requires an admin", func() {
+		requesterCtx, _ := s.getTestContextWithPrincipal("USR1", false)
+
+		_, err := SystemIntakesConnection(requesterCtx, store, 25, nil, nil, nil)
+		s.Error(err)
+	})
+}
@@ -27,7 +27,7 @@ func (r *mutationResolver) LockSystemProfileSection(ctx context.Context, cedarSy
 
 	principal := appcontext.Principal(ctx)
 
-	return LockSystemProfileSection(r.pubsub, cedarSystemID, section, principal)
+	return LockSystemProfileSection(ctx, r.store, r.pubsub, cedarSystemID, section, principal)
 }
 
 // UnlockSystemProfileSection is the resolver for the unlockSystemProfileSection field.
@@ -46,7 +46,7 @@ func (r *mutationResolver) UnlockSystemProfileSection(ctx context.Context, cedar
 		return false, fmt.Errorf("failed to unlock section [%v], unable to retrieve user account", section)
 	}
 
-	return UnlockSystemProfileSection(r.pubsub, cedarSystemID, section, account.ID)
+	return UnlockSystemProfileSection(ctx, r.store, r.pubsub, cedarSystemID, section, account.ID)
 }
 
 // UnlockAllSystemProfileSections is the resolver for the unlockAllSystemProfileSections field.
@@ -59,7 +59,16 @@ func (r *mutationResolver) UnlockAllSystemProfileSections(ctx context.Context, c
 		return nil, err
 	}
 
-	return UnlockAllSystemProfileSections(r.pubsub, cedarSystemID)
+	return UnlockAllSystemProfileSections(ctx, r.store, r.pubsub, cedarSystemID)
+}
+
+// ForceUnlockSystemProfileSection is the resolver for the forceUnlockSystemProfileSection field.
+func (r *mutationResolver) ForceUnlockSystemProfileSection(ctx context.Context, cedarSystemID uuid.UUID, section models.SystemProfileLockableSection) (bool, error) {
+	if cedarSystemID == uuid.Nil {
+		return false, fmt.Errorf("cedarSystemID cannot be empty")
+	}
+
+	return ForceUnlockSystemProfileSection(ctx, r.store, r.pubsub, cedarSystemID, section)
 }
 
 // SystemProfileSectionLocks is the resolver for the systemProfileSectionLocks field.
@@ -72,7 +81,7 @@ func (r *queryResolver) SystemProfileSectionLocks(ctx context.Context, cedarSyst
 		return nil, err
 	}
 
-	return GetSystemProfileSectionLocks(cedarSystemID)
+	return GetSystemProfileSectionLocks(ctx, r.store, cedarSystemID)
 }
 
 // OnSystemProfileSectionLockStatusChanged is the resolver for the onSystemProfileSectionLockStatusChanged field.
@@ -87,7 +96,7 @@ func (r *subscriptionResolver) OnSystemProfileSectionLockStatusChanged(ctx conte
 
 	principal := appcontext.Principal(ctx)
 
-	return OnSystemProfileSectionLockStatusChanged(ctx, r.pubsub, cedarSystemID, principal, ctx.Done())
+	return OnSystemProfileSectionLockStatusChanged(ctx, r.store, r.pubsub, cedarSystemID, principal, ctx.Done())
 }
 
 // Subscription returns generated.SubscriptionResolver implementation.
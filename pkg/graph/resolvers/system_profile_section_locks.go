@@ -3,7 +3,7 @@ package resolvers
 import (
 	"context"
 	"fmt"
-	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -15,35 +15,18 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/models"
 	"github.com/cms-enterprise/easi-app/pkg/models/pubsubevents"
 	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
 )
 
-type (
-	// sectionLockStatusMap maps each lockable section to its current lock status for a single system profile
-	sectionLockStatusMap map[models.SystemProfileLockableSection]models.SystemProfileSectionLockStatus
-	// systemLockStatusMap maps system IDs to their corresponding section lock status maps
-	systemLockStatusMap map[uuid.UUID]sectionLockStatusMap
+const (
+	// systemProfileSectionLockLeaseDuration is how long a section lock is held before it expires, unless it is renewed
+	systemProfileSectionLockLeaseDuration = 2 * time.Minute
 
-	sessionLockController struct {
-		systemLockStatuses systemLockStatusMap
-		sync.Mutex
-	}
-
-	// sectionLockEntry holds a lockable section and its corresponding lock status
-	// extracted from the section lock status map
-	sectionLockEntry struct {
-		section    models.SystemProfileLockableSection
-		lockStatus models.SystemProfileSectionLockStatus
-	}
+	// systemProfileSectionLockRenewalInterval is how often an open lock status subscription renews the subscriber's locks.
+	// It must be comfortably shorter than the lease duration so a connected user never loses a lock between heartbeats.
+	systemProfileSectionLockRenewalInterval = 30 * time.Second
 )
 
-var (
-	systemProfileSessionLocks sessionLockController
-)
-
-func init() {
-	systemProfileSessionLocks = sessionLockController{systemLockStatuses: make(systemLockStatusMap)}
-}
-
 // cedarSystemIDToSessionID converts a CEDAR system ID string to a UUID for pubsub sessions.
 // The same cedarSystemID always maps to the same sessionID for consistency.
 // TODO: Remove this conversion if/when cedarSystemId is migrated from string to UUID in the schema.
@@ -51,27 +34,84 @@ func cedarSystemIDToSessionID(cedarSystemID uuid.UUID) uuid.UUID {
 	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(cedarSystemID.String()))
 }
 
-// GetSystemProfileSectionLocks returns the list of locked system profile sections. Any sections not included should be considered as unlocked.
-func GetSystemProfileSectionLocks(cedarSystemID uuid.UUID) ([]*models.SystemProfileSectionLockStatus, error) {
-	systemProfileSessionLocks.Lock()
+// publishSystemProfileSectionLockChange notifies subscribers of a system profile that the lock status of a section changed
+func publishSystemProfileSectionLockChange(ps pubsub.PubSub, changeType models.LockChangeType, lockStatus *models.SystemProfileSectionLockStatus) {
+	sessionID := cedarSystemIDToSessionID(lockStatus.CedarSystemID)
+	ps.Publish(sessionID, pubsubevents.SystemProfileSectionLocksChanged, models.SystemProfileSectionLockStatusChanged{
+		ChangeType: changeType,
+		LockStatus: lockStatus,
+	})
+}
 
-	sectionLocks, found := systemProfileSessionLocks.systemLockStatuses[cedarSystemID]
-	if !found {
-		systemProfileSessionLocks.Unlock()
+// systemProfileSectionLockStatuses converts stored locks into lock statuses, populating the user account of each lock holder
+func systemProfileSectionLockStatuses(ctx context.Context, store *storage.Store, locks []*models.SystemProfileSectionLock) ([]*models.SystemProfileSectionLockStatus, error) {
+	if len(locks) == 0 {
 		return nil, nil
 	}
 
-	var lockStatuses []*models.SystemProfileSectionLockStatus
-	for section := range sectionLocks {
-		lockStatus := sectionLocks[section]
-		ls := lockStatus
-		lockStatuses = append(lockStatuses, &ls)
+	userIDs := make([]uuid.UUID, len(locks))
+	for i, lock := range locks {
+		userIDs[i] = lock.LockedBy
+	}
+
+	accounts, err := store.UserAccountsByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	accountsByID := make(map[uuid.UUID]*authentication.UserAccount, len(accounts))
+	for _, account := range accounts {
+		accountsByID[account.ID] = account
+	}
+
+	lockStatuses := make([]*models.SystemProfileSectionLockStatus, len(locks))
+	for i, lock := range locks {
+		account, found := accountsByID[lock.LockedBy]
+		if !found {
+			return nil, fmt.Errorf("unable to find user account [%v] holding lock on section [%v]", lock.LockedBy, lock.Section)
+		}
+
+		lockStatuses[i] = &models.SystemProfileSectionLockStatus{
+			CedarSystemID:       lock.CedarSystemID,
+			Section:             lock.Section,
+			LockedByUserAccount: account,
+		}
 	}
-	systemProfileSessionLocks.Unlock()
 
 	return lockStatuses, nil
 }
 
+// releaseExpiredSystemProfileSectionLocks removes locks whose lease has run out (e.g. because the holder's server went away
+// before it could unlock on disconnect) and notifies subscribers that those sections are no longer locked.
+// Reads already leave out expired locks, so this runs when locks are taken and renewed rather than on every read.
+func releaseExpiredSystemProfileSectionLocks(ctx context.Context, store *storage.Store, ps pubsub.PubSub, cedarSystemID uuid.UUID) error {
+	expiredLocks, err := store.DeleteExpiredSystemProfileSectionLocks(ctx, cedarSystemID)
+	if err != nil {
+		return err
+	}
+
+	expiredLockStatuses, err := systemProfileSectionLockStatuses(ctx, store, expiredLocks)
+	if err != nil {
+		return err
+	}
+
+	for _, lockStatus := range expiredLockStatuses {
+		publishSystemProfileSectionLockChange(ps, models.LockChangeTypeRemoved, lockStatus)
+	}
+
+	return nil
+}
+
+// GetSystemProfileSectionLocks returns the list of locked system profile sections. Any sections not included should be considered as unlocked.
+func GetSystemProfileSectionLocks(ctx context.Context, store *storage.Store, cedarSystemID uuid.UUID) ([]*models.SystemProfileSectionLockStatus, error) {
+	locks, err := store.SystemProfileSectionLocksByCedarSystemID(ctx, cedarSystemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return systemProfileSectionLockStatuses(ctx, store, locks)
+}
+
 // SubscribeSystemProfileSectionLockChanges creates a Subscriber and registers it for the pubsubevents.SystemProfileSectionLocksChanged event
 func SubscribeSystemProfileSectionLockChanges(ps pubsub.PubSub, cedarSystemID uuid.UUID, subscriber *subscribers.SystemProfileLockChangedSubscriber, onDisconnect <-chan struct{}) (<-chan *models.SystemProfileSectionLockStatusChanged, error) {
 	sessionID := cedarSystemIDToSessionID(cedarSystemID)
@@ -80,41 +120,42 @@ func SubscribeSystemProfileSectionLockChanges(ps pubsub.PubSub, cedarSystemID uu
 }
 
 // LockSystemProfileSection will lock the provided system profile section on the provided system
-func LockSystemProfileSection(ps pubsub.PubSub, cedarSystemID uuid.UUID, section models.SystemProfileLockableSection, principal authentication.Principal) (bool, error) {
-	systemProfileSessionLocks.Lock()
-
+//
+// Locking a section the principal already holds renews the lock's lease.
+func LockSystemProfileSection(ctx context.Context, store *storage.Store, ps pubsub.PubSub, cedarSystemID uuid.UUID, section models.SystemProfileLockableSection, principal authentication.Principal) (bool, error) {
 	account := principal.Account()
 	if account == nil {
-		systemProfileSessionLocks.Unlock()
 		return false, fmt.Errorf("failed to lock section [%v], unable to retrieve user account", section)
 	}
 
-	sectionLocks, found := systemProfileSessionLocks.systemLockStatuses[cedarSystemID]
-	if !found {
-		systemProfileSessionLocks.systemLockStatuses[cedarSystemID] = make(sectionLockStatusMap)
-		sectionLocks = systemProfileSessionLocks.systemLockStatuses[cedarSystemID]
+	if err := releaseExpiredSystemProfileSectionLocks(ctx, store, ps, cedarSystemID); err != nil {
+		return false, err
+	}
+
+	existingLock, err := store.SystemProfileSectionLock(ctx, cedarSystemID, section)
+	if err != nil {
+		return false, err
 	}
 
-	lockStatus, sectionWasLocked := sectionLocks[section]
-	if sectionWasLocked && lockStatus.LockedByUserAccount.ID != account.ID {
-		systemProfileSessionLocks.Unlock()
-		return false, fmt.Errorf("failed to lock section [%v], already locked by [%v]", section, lockStatus.LockedByUserAccount.ID)
+	if existingLock != nil && existingLock.LockedBy != account.ID {
+		return false, fmt.Errorf("failed to lock section [%v], already locked by [%v]", section, existingLock.LockedBy)
 	}
 
-	newLockStatus := models.SystemProfileSectionLockStatus{
-		CedarSystemID:       cedarSystemID,
-		Section:             section,
-		LockedByUserAccount: account,
+	lock, err := store.AcquireSystemProfileSectionLock(ctx, cedarSystemID, section, account.ID, systemProfileSectionLockLeaseDuration)
+	if err != nil {
+		return false, err
 	}
 
-	systemProfileSessionLocks.systemLockStatuses[cedarSystemID][section] = newLockStatus
-	systemProfileSessionLocks.Unlock()
+	// another user acquired the lock between our check and our attempt to acquire it
+	if lock == nil {
+		return false, fmt.Errorf("failed to lock section [%v], already locked by another user", section)
+	}
 
-	if !sectionWasLocked {
-		sessionID := cedarSystemIDToSessionID(cedarSystemID)
-		ps.Publish(sessionID, pubsubevents.SystemProfileSectionLocksChanged, models.SystemProfileSectionLockStatusChanged{
-			ChangeType: models.LockChangeTypeAdded,
-			LockStatus: &newLockStatus,
+	if existingLock == nil {
+		publishSystemProfileSectionLockChange(ps, models.LockChangeTypeAdded, &models.SystemProfileSectionLockStatus{
+			CedarSystemID:       cedarSystemID,
+			Section:             section,
+			LockedByUserAccount: account,
 		})
 	}
 
@@ -124,116 +165,90 @@ func LockSystemProfileSection(ps pubsub.PubSub, cedarSystemID uuid.UUID, section
 // UnlockSystemProfileSection will unlock the provided system profile section on the provided system
 //
 // This method will fail if the provided principal is not the person who locked the system profile section or if the section is not locked.
-func UnlockSystemProfileSection(ps pubsub.PubSub, cedarSystemID uuid.UUID, section models.SystemProfileLockableSection, userID uuid.UUID) (bool, error) {
-	systemProfileSessionLocks.Lock()
+func UnlockSystemProfileSection(ctx context.Context, store *storage.Store, ps pubsub.PubSub, cedarSystemID uuid.UUID, section models.SystemProfileLockableSection, userID uuid.UUID) (bool, error) {
+	lock, err := store.SystemProfileSectionLock(ctx, cedarSystemID, section)
+	if err != nil {
+		return false, err
+	}
 
-	sectionLocks, found := systemProfileSessionLocks.systemLockStatuses[cedarSystemID]
-	if !found {
-		systemProfileSessionLocks.Unlock()
+	if lock == nil {
 		return false, nil
 	}
 
-	lockStatus, sectionFound := sectionLocks[section]
-	if !sectionFound {
-		systemProfileSessionLocks.Unlock()
-		return false, nil
+	if !isUserAuthorizedToEditLock(lock, userID) {
+		return false, fmt.Errorf("failed to unlock section [%v], user [%v] not authorized to unlock section locked by user [%v]", section, userID, lock.LockedBy)
 	}
 
-	if !isUserAuthorizedToEditLock(lockStatus, userID) {
-		systemProfileSessionLocks.Unlock()
-		return false, fmt.Errorf("failed to unlock section [%v], user [%v] not authorized to unlock section locked by user [%v]", section, userID, lockStatus.LockedByUserAccount.ID)
+	// the lease may run out and another user may take the lock after the check above, so the delete only removes the lock if the
+	// user still holds it
+	deletedLock, err := store.DeleteSystemProfileSectionLockByOwner(ctx, cedarSystemID, section, userID)
+	if err != nil {
+		return false, err
 	}
 
-	delete(systemProfileSessionLocks.systemLockStatuses[cedarSystemID], section)
+	return publishReleasedSystemProfileSectionLock(ctx, store, ps, deletedLock)
+}
 
-	// Clean up empty system map
-	if len(systemProfileSessionLocks.systemLockStatuses[cedarSystemID]) == 0 {
-		delete(systemProfileSessionLocks.systemLockStatuses, cedarSystemID)
+// ForceUnlockSystemProfileSection unlocks the provided system profile section on the provided system,
+// regardless of who holds the lock. It is intended for admins releasing a lock that has been abandoned.
+func ForceUnlockSystemProfileSection(ctx context.Context, store *storage.Store, ps pubsub.PubSub, cedarSystemID uuid.UUID, section models.SystemProfileLockableSection) (bool, error) {
+	deletedLock, err := store.DeleteSystemProfileSectionLock(ctx, cedarSystemID, section)
+	if err != nil {
+		return false, err
 	}
 
-	systemProfileSessionLocks.Unlock()
+	return publishReleasedSystemProfileSectionLock(ctx, store, ps, deletedLock)
+}
 
-	// Publish outside the lock to avoid blocking other operations
-	sessionID := cedarSystemIDToSessionID(cedarSystemID)
-	ps.Publish(sessionID, pubsubevents.SystemProfileSectionLocksChanged, models.SystemProfileSectionLockStatusChanged{
-		ChangeType: models.LockChangeTypeRemoved,
-		LockStatus: &lockStatus,
-	})
+// publishReleasedSystemProfileSectionLock publishes a REMOVED event for a deleted lock, and returns whether a lock was deleted
+func publishReleasedSystemProfileSectionLock(ctx context.Context, store *storage.Store, ps pubsub.PubSub, deletedLock *models.SystemProfileSectionLock) (bool, error) {
+	if deletedLock == nil {
+		return false, nil
+	}
+
+	lockStatuses, err := systemProfileSectionLockStatuses(ctx, store, []*models.SystemProfileSectionLock{deletedLock})
+	if err != nil {
+		return false, err
+	}
+
+	publishSystemProfileSectionLockChange(ps, models.LockChangeTypeRemoved, lockStatuses[0])
 
 	return true, nil
 }
 
 // isUserAuthorizedToEditLock checks if a user is authorized to unlock a section.
 // Users can only unlock sections they personally locked.
-func isUserAuthorizedToEditLock(lockStatus models.SystemProfileSectionLockStatus, userID uuid.UUID) bool {
-	return userID == lockStatus.LockedByUserAccount.ID
+func isUserAuthorizedToEditLock(lock *models.SystemProfileSectionLock, userID uuid.UUID) bool {
+	return userID == lock.LockedBy
 }
 
 // UnlockAllSystemProfileSections unlocks all sections for a system.
 // Bypasses ownership checks - can unlock sections owned by any user.
 // Publishes REMOVED events for each unlocked section.
-func UnlockAllSystemProfileSections(ps pubsub.PubSub, cedarSystemID uuid.UUID) ([]*models.SystemProfileSectionLockStatus, error) {
-	systemProfileSessionLocks.Lock()
-
-	sectionLocks, found := systemProfileSessionLocks.systemLockStatuses[cedarSystemID]
-	if !found {
-		systemProfileSessionLocks.Unlock()
-		return nil, nil
+func UnlockAllSystemProfileSections(ctx context.Context, store *storage.Store, ps pubsub.PubSub, cedarSystemID uuid.UUID) ([]*models.SystemProfileSectionLockStatus, error) {
+	deletedLocks, err := store.DeleteSystemProfileSectionLocksByCedarSystemID(ctx, cedarSystemID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Copy sections to unlock while holding the lock
-	var sectionsToUnlock []sectionLockEntry
-
-	for section, lockStatus := range sectionLocks {
-		sectionsToUnlock = append(sectionsToUnlock, sectionLockEntry{
-			section:    section,
-			lockStatus: lockStatus,
-		})
+	unlockedSections, err := systemProfileSectionLockStatuses(ctx, store, deletedLocks)
+	if err != nil {
+		return nil, err
 	}
 
-	// Delete from map while holding lock
-	delete(systemProfileSessionLocks.systemLockStatuses, cedarSystemID)
-	systemProfileSessionLocks.Unlock()
-
-	// Publish events outside the lock
-	sessionID := cedarSystemIDToSessionID(cedarSystemID)
-	var unlockedSections []*models.SystemProfileSectionLockStatus
-	for _, item := range sectionsToUnlock {
-		lockStatus := item.lockStatus
-		ls := lockStatus
-		unlockedSections = append(unlockedSections, &ls)
-
-		ps.Publish(sessionID, pubsubevents.SystemProfileSectionLocksChanged, models.SystemProfileSectionLockStatusChanged{
-			ChangeType: models.LockChangeTypeRemoved,
-			LockStatus: &ls,
-		})
+	for _, lockStatus := range unlockedSections {
+		publishSystemProfileSectionLockChange(ps, models.LockChangeTypeRemoved, lockStatus)
 	}
 
 	return unlockedSections, nil
 }
 
-// getOwnedSections returns a list of system profile sections owned by a specific principal
-func getOwnedSections(sectionLocks sectionLockStatusMap, subscriber pubsub.Subscriber) []models.SystemProfileLockableSection {
-	var ownedSections []models.SystemProfileLockableSection
-
-	account := subscriber.GetPrincipal().Account()
-	if account == nil {
-		return ownedSections
-	}
-
-	for section, lockStatus := range sectionLocks {
-		if lockStatus.LockedByUserAccount.ID == account.ID {
-			ownedSections = append(ownedSections, section)
-		}
-	}
-
-	return ownedSections
-}
-
 // OnSystemProfileSectionLockStatusChanged subscribes to lock status change events for a system profile.
+// While the subscription is open, the leases on any sections the user holds are periodically renewed.
 // Automatically unlocks all sections owned by the user when the websocket connection closes.
 func OnSystemProfileSectionLockStatusChanged(
 	ctx context.Context,
+	store *storage.Store,
 	ps pubsub.PubSub,
 	cedarSystemID uuid.UUID,
 	principal authentication.Principal,
@@ -250,7 +265,18 @@ func OnSystemProfileSectionLockStatusChanged(
 	logger = logger.With(logfields.SystemProfileLockingAppSection)
 
 	subscriber := subscribers.NewSystemProfileLockChangedSubscriber(principal, cedarSystemID, logger)
-	subscriber.SetOnUnsubscribedCallback(onLockSystemProfileSectionUnsubscribeComplete)
+	subscriber.SetOnUnsubscribedCallback(newOnLockSystemProfileSectionUnsubscribeComplete(store))
+
+	if account := principal.Account(); account != nil {
+		go renewSystemProfileSectionLocksUntilDisconnect(
+			appcontext.WithLogger(context.WithoutCancel(ctx), logger),
+			store,
+			ps,
+			cedarSystemID,
+			account.ID,
+			onDisconnect,
+		)
+	}
 
 	return SubscribeSystemProfileSectionLockChanges(
 		ps,
@@ -260,42 +286,81 @@ func OnSystemProfileSectionLockStatusChanged(
 	)
 }
 
-// onLockSystemProfileSectionUnsubscribeComplete is a callback invoked when the lock status subscription
-// disconnects. It automatically unlocks all sections owned by the disconnected user to prevent
-// abandoned locks.
-func onLockSystemProfileSectionUnsubscribeComplete(
+// renewSystemProfileSectionLocksUntilDisconnect acts as the heartbeat for a user's locks on a system profile.
+// It renews the user's locks on every tick until the subscription disconnects, after which the locks are either
+// released by the unsubscribe callback or left to expire if this server goes away first. Each tick also releases
+// the system's expired locks, so subscribers hear about locks abandoned by other servers.
+func renewSystemProfileSectionLocksUntilDisconnect(
+	ctx context.Context,
+	store *storage.Store,
 	ps pubsub.PubSub,
-	subscriber *subscribers.SystemProfileLockChangedSubscriber,
+	cedarSystemID uuid.UUID,
+	userID uuid.UUID,
+	onDisconnect <-chan struct{},
 ) {
-	cedarSystemID := subscriber.CedarSystemID
-	systemProfileSessionLocks.Lock()
-	sectionLocks, found := systemProfileSessionLocks.systemLockStatuses[cedarSystemID]
-	if !found {
-		systemProfileSessionLocks.Unlock()
-		return
-	}
-
-	ownedSections := getOwnedSections(sectionLocks, subscriber)
-	systemProfileSessionLocks.Unlock()
-
-	account := subscriber.GetPrincipal().Account()
-	if account == nil {
-		subscriber.Logger.Error("Failed to get account from principal during auto-unlock on disconnect",
-			zap.String("cedar_system_id", cedarSystemID.String()),
-		)
-		return
+	ticker := time.NewTicker(systemProfileSectionLockRenewalInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-onDisconnect:
+			return
+		case <-ticker.C:
+			if err := releaseExpiredSystemProfileSectionLocks(ctx, store, ps, cedarSystemID); err != nil {
+				appcontext.ZLogger(ctx).Error("Failed to release expired system profile section locks",
+					zap.Error(err),
+					zap.String("cedar_system_id", cedarSystemID.String()),
+				)
+			}
+			if _, err := store.RenewSystemProfileSectionLocks(ctx, cedarSystemID, userID, systemProfileSectionLockLeaseDuration); err != nil {
+				appcontext.ZLogger(ctx).Error("Failed to renew system profile section locks",
+					zap.Error(err),
+					zap.String("cedar_system_id", cedarSystemID.String()),
+					zap.String("user_id", userID.String()),
+				)
+			}
+		}
 	}
+}
 
-	for _, section := range ownedSections {
-		_, err := UnlockSystemProfileSection(ps, cedarSystemID, section, account.ID)
+// newOnLockSystemProfileSectionUnsubscribeComplete returns a callback that is invoked when the lock status subscription
+// disconnects. It automatically unlocks all sections owned by the disconnected user to prevent
+// abandoned locks.
+func newOnLockSystemProfileSectionUnsubscribeComplete(store *storage.Store) subscribers.SystemProfileLockOnUnsubscribeCallback {
+	return func(ps pubsub.PubSub, subscriber *subscribers.SystemProfileLockChangedSubscriber) {
+		cedarSystemID := subscriber.CedarSystemID
+		ctx := appcontext.WithLogger(context.Background(), subscriber.Logger)
+
+		account := subscriber.GetPrincipal().Account()
+		if account == nil {
+			subscriber.Logger.Error("Failed to get account from principal during auto-unlock on disconnect",
+				zap.String("cedar_system_id", cedarSystemID.String()),
+			)
+			return
+		}
 
+		locks, err := store.SystemProfileSectionLocksByCedarSystemID(ctx, cedarSystemID)
 		if err != nil {
-			subscriber.Logger.Error("Failed to auto-unlock section on websocket disconnect",
+			subscriber.Logger.Error("Failed to fetch section locks during auto-unlock on disconnect",
 				zap.Error(err),
 				zap.String("cedar_system_id", cedarSystemID.String()),
-				zap.String("section", string(section)),
-				zap.String("user_id", account.ID.String()),
 			)
+			return
+		}
+
+		for _, lock := range locks {
+			if lock.LockedBy != account.ID {
+				continue
+			}
+
+			if _, err := UnlockSystemProfileSection(ctx, store, ps, cedarSystemID, lock.Section, account.ID); err != nil {
+				subscriber.Logger.Error("Failed to auto-unlock section on websocket disconnect",
+					zap.Error(err),
+					zap.String("cedar_system_id", cedarSystemID.String()),
+					zap.String("section", string(lock.Section)),
+					zap.String("user_id", account.ID.String()),
+				)
+			}
 		}
 	}
 }
@@ -1,161 +1,280 @@
 package resolvers
 
 import (
-	"testing"
+	"context"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 )
 
-// Helper to create a test principal
-func createTestPrincipal(euaID string, allowGRT bool) authentication.Principal {
-	return &authentication.EUAPrincipal{
-		EUAID:      euaID,
-		JobCodeGRT: allowGRT,
-		UserAccount: &authentication.UserAccount{
-			ID:         uuid.New(),
-			Username:   euaID,
-			CommonName: euaID + " Test",
-			Email:      euaID + "@test.local",
-		},
-	}
-}
-
-func TestSystemProfileSectionLock(t *testing.T) {
-	// Reset global state before test
-	systemProfileSessionLocks = sessionLockController{systemLockStatuses: make(systemLockStatusMap)}
+func (s *ResolverSuite) TestSystemProfileSectionLock() {
+	ctx, principal := s.getTestContextWithPrincipal("ABCD", false)
+	store := s.testConfigs.Store
 
 	cedarSystemID := uuid.MustParse("61469178-a474-445d-a6ef-db84fe425e02")
 	section := models.SystemProfileLockableSectionBusinessInformation
 	ps := pubsub.NewServicePubSub()
-	principal := createTestPrincipal("ABCD", false)
 
 	// Initial state - no locks
-	locks, err := GetSystemProfileSectionLocks(cedarSystemID)
-	require.NoError(t, err)
-	assert.Empty(t, locks)
+	locks, err := GetSystemProfileSectionLocks(ctx, store, cedarSystemID)
+	s.NoError(err)
+	s.Empty(locks)
 
 	// Lock a section
-	locked, err := LockSystemProfileSection(ps, cedarSystemID, section, principal)
-	require.NoError(t, err)
-	assert.True(t, locked)
+	locked, err := LockSystemProfileSection(ctx, store, ps, cedarSystemID, section, principal)
+	s.NoError(err)
+	s.True(locked)
+
+	// Locking the same section again renews the lock
+	locked, err = LockSystemProfileSection(ctx, store, ps, cedarSystemID, section, principal)
+	s.NoError(err)
+	s.True(locked)
 
 	// Verify section is locked
-	locks, err = GetSystemProfileSectionLocks(cedarSystemID)
-	require.NoError(t, err)
-	require.Len(t, locks, 1)
-	assert.Equal(t, cedarSystemID, locks[0].CedarSystemID)
-	assert.Equal(t, section, locks[0].Section)
-	assert.Equal(t, principal.Account().ID, locks[0].LockedByUserAccount.ID)
+	locks, err = GetSystemProfileSectionLocks(ctx, store, cedarSystemID)
+	s.NoError(err)
+	s.Len(locks, 1)
+	s.Equal(cedarSystemID, locks[0].CedarSystemID)
+	s.Equal(section, locks[0].Section)
+	s.Equal(principal.Account().ID, locks[0].LockedByUserAccount.ID)
 
 	// Unlock the section
 	userID := principal.Account().ID
-	unlocked, err := UnlockSystemProfileSection(ps, cedarSystemID, section, userID)
-	require.NoError(t, err)
-	assert.True(t, unlocked)
+	unlocked, err := UnlockSystemProfileSection(ctx, store, ps, cedarSystemID, section, userID)
+	s.NoError(err)
+	s.True(unlocked)
 
 	// Verify section is unlocked
-	locks, err = GetSystemProfileSectionLocks(cedarSystemID)
-	require.NoError(t, err)
-	assert.Empty(t, locks)
+	locks, err = GetSystemProfileSectionLocks(ctx, store, cedarSystemID)
+	s.NoError(err)
+	s.Empty(locks)
+
+	// Unlocking a section that is not locked reports that nothing was unlocked
+	unlocked, err = UnlockSystemProfileSection(ctx, store, ps, cedarSystemID, section, userID)
+	s.NoError(err)
+	s.False(unlocked)
 }
 
-func TestSystemProfileSectionLockConflict(t *testing.T) {
-	// Reset global state before test
-	systemProfileSessionLocks = sessionLockController{systemLockStatuses: make(systemLockStatusMap)}
+func (s *ResolverSuite) TestSystemProfileSectionLockConflict() {
+	ctx, userA := s.getTestContextWithPrincipal("ABCD", false)
+	_, userB := s.getTestContextWithPrincipal("USR1", false)
+	store := s.testConfigs.Store
 
 	cedarSystemID := uuid.MustParse("e321c11a-a720-490a-a51d-70a00256efcf")
 	section := models.SystemProfileLockableSectionData
 	ps := pubsub.NewServicePubSub()
 
-	userA := createTestPrincipal("ABCD", false)
-	userB := createTestPrincipal("USR1", false)
-
 	// User A locks the section
-	locked, err := LockSystemProfileSection(ps, cedarSystemID, section, userA)
-	require.NoError(t, err)
-	assert.True(t, locked)
+	locked, err := LockSystemProfileSection(ctx, store, ps, cedarSystemID, section, userA)
+	s.NoError(err)
+	s.True(locked)
 
 	// User B tries to lock the same section - should fail
-	locked, err = LockSystemProfileSection(ps, cedarSystemID, section, userB)
-	require.Error(t, err)
-	assert.False(t, locked)
-	assert.Contains(t, err.Error(), "already locked by")
+	locked, err = LockSystemProfileSection(ctx, store, ps, cedarSystemID, section, userB)
+	s.Error(err)
+	s.False(locked)
+	s.Contains(err.Error(), "already locked by")
+
+	// User B cannot unlock User A's section
+	unlocked, err := UnlockSystemProfileSection(ctx, store, ps, cedarSystemID, section, userB.Account().ID)
+	s.Error(err)
+	s.False(unlocked)
 
 	// Verify only User A's lock exists
-	locks, err := GetSystemProfileSectionLocks(cedarSystemID)
-	require.NoError(t, err)
-	require.Len(t, locks, 1)
-	assert.Equal(t, userA.Account().ID, locks[0].LockedByUserAccount.ID)
+	locks, err := GetSystemProfileSectionLocks(ctx, store, cedarSystemID)
+	s.NoError(err)
+	s.Len(locks, 1)
+	s.Equal(userA.Account().ID, locks[0].LockedByUserAccount.ID)
 
 	// User A unlocks
-	unlocked, err := UnlockSystemProfileSection(ps, cedarSystemID, section, userA.Account().ID)
-	require.NoError(t, err)
-	assert.True(t, unlocked)
+	unlocked, err = UnlockSystemProfileSection(ctx, store, ps, cedarSystemID, section, userA.Account().ID)
+	s.NoError(err)
+	s.True(unlocked)
 
 	// Now User B can lock the section
-	locked, err = LockSystemProfileSection(ps, cedarSystemID, section, userB)
-	require.NoError(t, err)
-	assert.True(t, locked)
+	locked, err = LockSystemProfileSection(ctx, store, ps, cedarSystemID, section, userB)
+	s.NoError(err)
+	s.True(locked)
 
 	// Verify User B's lock exists
-	locks, err = GetSystemProfileSectionLocks(cedarSystemID)
-	require.NoError(t, err)
-	require.Len(t, locks, 1)
-	assert.Equal(t, userB.Account().ID, locks[0].LockedByUserAccount.ID)
+	locks, err = GetSystemProfileSectionLocks(ctx, store, cedarSystemID)
+	s.NoError(err)
+	s.Len(locks, 1)
+	s.Equal(userB.Account().ID, locks[0].LockedByUserAccount.ID)
+}
+
+func (s *ResolverSuite) TestSystemProfileSectionLockExpiry() {
+	ctx, userA := s.getTestContextWithPrincipal("ABCD", false)
+	_, userB := s.getTestContextWithPrincipal("USR1", false)
+	store := s.testConfigs.Store
+
+	cedarSystemID := uuid.MustParse("0bd2d5b8-c1a4-4c23-9aa3-0f2b7f7f5b51")
+	section := models.SystemProfileLockableSectionTeam
+	ps := pubsub.NewServicePubSub()
+
+	// User A holds a lock whose lease has already run out, as if their server went away without unlocking
+	expiredLock, err := store.AcquireSystemProfileSectionLock(ctx, cedarSystemID, section, userA.Account().ID, -time.Second)
+	s.NoError(err)
+	s.NotNil(expiredLock)
+
+	// Renewing an expired lock does nothing
+	renewed, err := store.RenewSystemProfileSectionLocks(ctx, cedarSystemID, userA.Account().ID, systemProfileSectionLockLeaseDuration)
+	s.NoError(err)
+	s.Empty(renewed)
+
+	// Expired locks are not reported, but reading the locks doesn't remove them; that's left to locking and renewing
+	locks, err := GetSystemProfileSectionLocks(ctx, store, cedarSystemID)
+	s.NoError(err)
+	s.Empty(locks)
+
+	expiredLocks, err := store.DeleteExpiredSystemProfileSectionLocks(ctx, cedarSystemID)
+	s.NoError(err)
+	s.Len(expiredLocks, 1)
+
+	// User B can take over the section
+	locked, err := LockSystemProfileSection(ctx, store, ps, cedarSystemID, section, userB)
+	s.NoError(err)
+	s.True(locked)
+
+	locks, err = GetSystemProfileSectionLocks(ctx, store, cedarSystemID)
+	s.NoError(err)
+	s.Len(locks, 1)
+	s.Equal(userB.Account().ID, locks[0].LockedByUserAccount.ID)
+
+	// User A's unlock, if it raced with User B taking over the section, can't remove User B's lock
+	deletedLock, err := store.DeleteSystemProfileSectionLockByOwner(ctx, cedarSystemID, section, userA.Account().ID)
+	s.NoError(err)
+	s.Nil(deletedLock)
+
+	locks, err = GetSystemProfileSectionLocks(ctx, store, cedarSystemID)
+	s.NoError(err)
+	s.Len(locks, 1)
+
+	// Renewing an active lock extends it
+	renewed, err = store.RenewSystemProfileSectionLocks(ctx, cedarSystemID, userB.Account().ID, systemProfileSectionLockLeaseDuration)
+	s.NoError(err)
+	s.Len(renewed, 1)
+}
+
+func (s *ResolverSuite) TestForceUnlockSystemProfileSection() {
+	ctx, principal := s.getTestContextWithPrincipal("ABCD", false)
+	store := s.testConfigs.Store
+
+	cedarSystemID := uuid.MustParse("9a0a3d2e-53f4-4c5e-8f0a-7d7e3c1c3b9e")
+	section := models.SystemProfileLockableSectionImplementationDetails
+	ps := pubsub.NewServicePubSub()
+
+	locked, err := LockSystemProfileSection(ctx, store, ps, cedarSystemID, section, principal)
+	s.NoError(err)
+	s.True(locked)
+
+	// An admin can release a lock held by someone else
+	unlocked, err := ForceUnlockSystemProfileSection(s.testConfigs.Context, store, ps, cedarSystemID, section)
+	s.NoError(err)
+	s.True(unlocked)
+
+	locks, err := GetSystemProfileSectionLocks(ctx, store, cedarSystemID)
+	s.NoError(err)
+	s.Empty(locks)
+
+	// Releasing a section that is not locked reports that nothing was unlocked
+	unlocked, err = ForceUnlockSystemProfileSection(s.testConfigs.Context, store, ps, cedarSystemID, section)
+	s.NoError(err)
+	s.False(unlocked)
 }
 
-func TestUnlockAllSystemProfileSections(t *testing.T) {
-	// Reset global state before test
-	systemProfileSessionLocks = sessionLockController{systemLockStatuses: make(systemLockStatusMap)}
+func (s *ResolverSuite) TestUnlockAllSystemProfileSections() {
+	ctx, principal := s.getTestContextWithPrincipal("ABCD", false)
+	store := s.testConfigs.Store
 
 	cedarSystemID := uuid.MustParse("b105ddf3-c758-4f13-a520-976ffb1be680")
 	ps := pubsub.NewServicePubSub()
-	principal := createTestPrincipal("ABCD", false)
 
 	// Lock multiple sections
 	section1 := models.SystemProfileLockableSectionBusinessInformation
 	section2 := models.SystemProfileLockableSectionData
 	section3 := models.SystemProfileLockableSectionTeam
 
-	locked1, err := LockSystemProfileSection(ps, cedarSystemID, section1, principal)
-	require.NoError(t, err)
-	assert.True(t, locked1)
+	locked1, err := LockSystemProfileSection(ctx, store, ps, cedarSystemID, section1, principal)
+	s.NoError(err)
+	s.True(locked1)
 
-	locked2, err := LockSystemProfileSection(ps, cedarSystemID, section2, principal)
-	require.NoError(t, err)
-	assert.True(t, locked2)
+	locked2, err := LockSystemProfileSection(ctx, store, ps, cedarSystemID, section2, principal)
+	s.NoError(err)
+	s.True(locked2)
 
-	locked3, err := LockSystemProfileSection(ps, cedarSystemID, section3, principal)
-	require.NoError(t, err)
-	assert.True(t, locked3)
+	locked3, err := LockSystemProfileSection(ctx, store, ps, cedarSystemID, section3, principal)
+	s.NoError(err)
+	s.True(locked3)
 
 	// Verify all sections are locked
-	locks, err := GetSystemProfileSectionLocks(cedarSystemID)
-	require.NoError(t, err)
-	assert.Len(t, locks, 3)
+	locks, err := GetSystemProfileSectionLocks(ctx, store, cedarSystemID)
+	s.NoError(err)
+	s.Len(locks, 3)
 
 	// Unlock all sections
-	deletedSections, err := UnlockAllSystemProfileSections(ps, cedarSystemID)
-	require.NoError(t, err)
-	assert.Len(t, deletedSections, 3)
+	deletedSections, err := UnlockAllSystemProfileSections(ctx, store, ps, cedarSystemID)
+	s.NoError(err)
+	s.Len(deletedSections, 3)
 
 	// Verify all sections match what was deleted
 	deletedSectionTypes := make(map[models.SystemProfileLockableSection]bool)
 	for _, deleted := range deletedSections {
 		deletedSectionTypes[deleted.Section] = true
 	}
-	assert.True(t, deletedSectionTypes[section1])
-	assert.True(t, deletedSectionTypes[section2])
-	assert.True(t, deletedSectionTypes[section3])
+	s.True(deletedSectionTypes[section1])
+	s.True(deletedSectionTypes[section2])
+	s.True(deletedSectionTypes[section3])
 
 	// Verify no locks remain
-	locks, err = GetSystemProfileSectionLocks(cedarSystemID)
-	require.NoError(t, err)
-	assert.Empty(t, locks)
+	locks, err = GetSystemProfileSectionLocks(ctx, store, cedarSystemID)
+	s.NoError(err)
+	s.Empty(locks)
+}
+
+func (s *ResolverSuite) TestSystemProfileSectionLocksAllowProfileOnlyEASIUsers() {
+	cedarCoreClient := cedarcore.NewClient(
+		appcontext.WithLogger(context.Background(), s.testConfigs.Logger),
+		"fake",
+		"fake",
+		"1.0.0",
+		true,
+		cedarcore.TransportConfig{},
+		cedarcore.CacheConfig{},
+	)
+	resolver := &Resolver{
+		store:           s.testConfigs.Store,
+		pubsub:          pubsub.NewServicePubSub(),
+		cedarCoreClient: cedarCoreClient,
+	}
+	mutationResolver := &mutationResolver{resolver}
+	queryResolver := &queryResolver{resolver}
+
+	section := models.SystemProfileLockableSectionTeam
+
+	// USR1 has the EASi job code, but isn't on the system's team
+	profileOnlyCtx, _ := s.getTestContextWithPrincipal("USR1", false)
+	nonEasiCtx := appcontext.WithPrincipal(s.ctxWithNewDataloaders(), &authentication.EUAPrincipal{
+		EUAID:       "WXYZ",
+		UserAccount: &authentication.UserAccount{Username: "WXYZ"},
+	})
+
+	locked, err := mutationResolver.LockSystemProfileSection(profileOnlyCtx, testCedarSystemID, section)
+	s.NoError(err)
+	s.True(locked)
+
+	locks, err := queryResolver.SystemProfileSectionLocks(profileOnlyCtx, testCedarSystemID)
+	s.NoError(err)
+	s.Len(locks, 1)
+
+	_, err = mutationResolver.LockSystemProfileSection(nonEasiCtx, testCedarSystemID, section)
+	var unauthorizedErr *apperrors.UnauthorizedError
+	s.ErrorAs(err, &unauthorizedErr)
 }
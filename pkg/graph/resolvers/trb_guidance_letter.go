@@ -13,8 +13,10 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/email"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 	"github.com/cms-enterprise/easi-app/pkg/storage"
+	"github.com/cms-enterprise/easi-app/pkg/webhooks"
 )
 
+// the full text search in search.sql applies the same rules to its results, so they must change together
 func canViewTRBGuidanceLetter(ctx context.Context, letter *models.TRBGuidanceLetter) bool {
 	if letter == nil {
 		return false
@@ -90,6 +92,16 @@ func RequestReviewForTRBGuidanceLetter(
 		return nil, err
 	}
 
+	insights, err := store.GetTRBGuidanceLetterInsightsByTRBRequestID(ctx, letter.TRBRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = createTRBGuidanceLetterVersion(ctx, store, letter, insights, models.TRBGuidanceLetterVersionReasonReviewRequested, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	var leadName string
 	if trb.TRBLead != nil {
 		leadInfo, err2 := fetchUserInfo(ctx, *trb.TRBLead)
@@ -117,7 +129,7 @@ func RequestReviewForTRBGuidanceLetter(
 	return letter, nil
 }
 
-// SendTRBGuidanceLetter sends a TRB guidance letter, setting its DateSent field, and (TODO) notifies the given recipients.
+// SendTRBGuidanceLetter sends a TRB guidance letter, setting its DateSent field, saves a version of the letter as it was sent, and notifies the given recipients.
 func SendTRBGuidanceLetter(ctx context.Context,
 	store *storage.Store,
 	id uuid.UUID,
@@ -141,7 +153,7 @@ func SendTRBGuidanceLetter(ctx context.Context,
 
 	trbID := letter.TRBRequestID
 
-	// Query the TRB request, form, attendees in parallel
+	// Query the TRB request, form, insights in parallel
 	errGroup := new(errgroup.Group)
 
 	// Query the TRB request
@@ -160,10 +172,23 @@ func SendTRBGuidanceLetter(ctx context.Context,
 		return errForm
 	})
 
+	// Query the guidance letter's insights, which are attached to the email with the rest of the letter
+	var insights []*models.TRBGuidanceLetterInsight
+	var errInsights error
+	errGroup.Go(func() error {
+		insights, errInsights = store.GetTRBGuidanceLetterInsightsByTRBRequestID(ctx, trbID)
+		return errInsights
+	})
+
 	if errG := errGroup.Wait(); errG != nil {
 		return nil, errG
 	}
 
+	_, err = createTRBGuidanceLetterVersion(ctx, store, letter, insights, models.TRBGuidanceLetterVersionReasonSent, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	requester, err := fetchUserInfo(ctx, trb.CreatedBy)
 	if err != nil {
 		return nil, err
@@ -190,6 +215,8 @@ func SendTRBGuidanceLetter(ctx context.Context,
 		CopyTRBMailbox:   copyTRBMailbox,
 		CopyITGovMailbox: copyITGovMailbox,
 		Recipients:       recipientEmails,
+		Letter:           letter,
+		Insights:         insights,
 	}
 
 	// Email client can be nil when this is called from tests - the email client itself tests this
@@ -200,5 +227,8 @@ func SendTRBGuidanceLetter(ctx context.Context,
 			return nil, err
 		}
 	}
+
+	emitWebhookEvent(ctx, store, models.WebhookEventTypeTrbGuidanceLetterSent, webhooks.NewTRBGuidanceLetterSentEventData(trb, letter))
+
 	return letter, nil
 }
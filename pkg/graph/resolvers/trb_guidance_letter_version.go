@@ -0,0 +1,196 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+	"github.com/cms-enterprise/easi-app/pkg/webhooks"
+)
+
+// createTRBGuidanceLetterVersion saves a snapshot of a guidance letter and its insights, which should be in the order they appear in the letter
+func createTRBGuidanceLetterVersion(
+	ctx context.Context,
+	store *storage.Store,
+	letter *models.TRBGuidanceLetter,
+	insights []*models.TRBGuidanceLetterInsight,
+	reason models.TRBGuidanceLetterVersionReason,
+	amendmentSummary *models.HTML,
+) (*models.TRBGuidanceLetterVersion, error) {
+	version := models.NewTRBGuidanceLetterVersion(letter, insights, reason, appcontext.Principal(ctx).ID())
+	version.AmendmentSummary = amendmentSummary
+	return store.CreateTRBGuidanceLetterVersion(ctx, version)
+}
+
+// GetTRBGuidanceLetterVersions fetches the versions of a TRB request's guidance letter, newest first
+func GetTRBGuidanceLetterVersions(ctx context.Context, store *storage.Store, trbRequestID uuid.UUID) ([]*models.TRBGuidanceLetterVersion, error) {
+	return store.GetTRBGuidanceLetterVersionsByTRBRequestID(ctx, trbRequestID)
+}
+
+// GetTRBGuidanceLetterVersionDiff compares two versions of the same TRB guidance letter
+func GetTRBGuidanceLetterVersionDiff(
+	ctx context.Context,
+	store *storage.Store,
+	fromVersionID uuid.UUID,
+	toVersionID uuid.UUID,
+) (*models.TRBGuidanceLetterVersionDiff, error) {
+	errGroup := new(errgroup.Group)
+
+	var from *models.TRBGuidanceLetterVersion
+	errGroup.Go(func() error {
+		var err error
+		from, err = store.GetTRBGuidanceLetterVersionByID(ctx, fromVersionID)
+		return err
+	})
+
+	var to *models.TRBGuidanceLetterVersion
+	errGroup.Go(func() error {
+		var err error
+		to, err = store.GetTRBGuidanceLetterVersionByID(ctx, toVersionID)
+		return err
+	})
+
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+
+	if from.TRBGuidanceLetterID != to.TRBGuidanceLetterID {
+		return nil, &apperrors.BadRequestError{Err: errors.New("guidance letter versions must be of the same letter to be compared")}
+	}
+
+	return models.NewTRBGuidanceLetterVersionDiff(from, to), nil
+}
+
+// SendAmendedTRBGuidanceLetter re-sends a TRB guidance letter that was edited after it was sent, notifying the given recipients
+// of what changed since it was last sent
+func SendAmendedTRBGuidanceLetter(
+	ctx context.Context,
+	store *storage.Store,
+	id uuid.UUID,
+	amendmentSummary models.HTML,
+	emailClient *email.Client,
+	fetchUserInfo func(context.Context, string) (*models.UserInfo, error),
+	fetchUserInfos func(context.Context, []string) ([]*models.UserInfo, error),
+	copyTRBMailbox bool,
+	copyITGovMailbox bool,
+	notifyEUAIDs []string,
+) (*models.TRBGuidanceLetter, error) {
+	lastSent, err := store.GetLatestSentTRBGuidanceLetterVersion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if lastSent == nil {
+		return nil, &apperrors.BadRequestError{Err: errors.New("guidance letter must be sent before it can be amended")}
+	}
+
+	// Fetch user info for each EUA ID we want to notify
+	notifyUserInfos, err := fetchUserInfos(ctx, notifyEUAIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	trbID := lastSent.TRBRequestID
+
+	// Query the letter as it is now, its insights, the TRB request, and the TRB form in parallel
+	errGroup := new(errgroup.Group)
+
+	var current *models.TRBGuidanceLetter
+	var errCurrent error
+	errGroup.Go(func() error {
+		current, errCurrent = store.GetTRBGuidanceLetterByTRBRequestID(ctx, trbID)
+		return errCurrent
+	})
+
+	var insights []*models.TRBGuidanceLetterInsight
+	var errInsights error
+	errGroup.Go(func() error {
+		insights, errInsights = store.GetTRBGuidanceLetterInsightsByTRBRequestID(ctx, trbID)
+		return errInsights
+	})
+
+	var trb *models.TRBRequest
+	var errTRB error
+	errGroup.Go(func() error {
+		trb, errTRB = store.GetTRBRequestByID(ctx, trbID)
+		return errTRB
+	})
+
+	var form *models.TRBRequestForm
+	var errForm error
+	errGroup.Go(func() error {
+		form, errForm = store.GetTRBRequestFormByTRBRequestID(ctx, trbID)
+		return errForm
+	})
+
+	if errG := errGroup.Wait(); errG != nil {
+		return nil, errG
+	}
+
+	// Compare the letter as it is now to the letter as it was last sent before anything is saved, so an unchanged letter isn't re-sent
+	diff := models.NewTRBGuidanceLetterVersionDiff(
+		lastSent,
+		models.NewTRBGuidanceLetterVersion(current, insights, models.TRBGuidanceLetterVersionReasonAmended, appcontext.Principal(ctx).ID()),
+	)
+	if !diff.HasChanges {
+		return nil, &apperrors.BadRequestError{Err: errors.New("guidance letter has not changed since it was last sent")}
+	}
+
+	// Setting the letter as completed again updates its DateSent field
+	letter, err := store.UpdateTRBGuidanceLetterStatus(ctx, id, models.TRBGuidanceLetterStatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+
+	amended, err := createTRBGuidanceLetterVersion(ctx, store, letter, insights, models.TRBGuidanceLetterVersionReasonAmended, &amendmentSummary)
+	if err != nil {
+		return nil, err
+	}
+	diff.To = amended
+
+	requester, err := fetchUserInfo(ctx, trb.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientEmails := make([]models.EmailAddress, 0, len(notifyUserInfos))
+	for _, recipientInfo := range notifyUserInfos {
+		recipientEmails = append(recipientEmails, recipientInfo.Email)
+	}
+
+	emailInput := email.SendTRBGuidanceLetterAmendedEmailInput{
+		TRBRequestID:     trb.ID,
+		RequestName:      trb.GetName(),
+		RequestType:      string(trb.Type),
+		RequesterName:    requester.DisplayName,
+		Component:        lo.FromPtr(form.Component),
+		ConsultDate:      trb.ConsultMeetingTime,
+		CopyTRBMailbox:   copyTRBMailbox,
+		CopyITGovMailbox: copyITGovMailbox,
+		Recipients:       recipientEmails,
+		AmendmentSummary: amendmentSummary,
+		Diff:             diff,
+		Letter:           letter,
+		Insights:         insights,
+	}
+
+	// Email client can be nil when this is called from tests - the email client itself tests this
+	// separately in the email package test
+	if emailClient != nil {
+		err = emailClient.SendTRBGuidanceLetterAmendedEmail(ctx, emailInput)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	emitWebhookEvent(ctx, store, models.WebhookEventTypeTrbGuidanceLetterSent, webhooks.NewTRBGuidanceLetterSentEventData(trb, letter))
+
+	return letter, nil
+}
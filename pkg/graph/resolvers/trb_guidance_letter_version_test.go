@@ -0,0 +1,125 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// TestTRBGuidanceLetterVersions tests that guidance letters are versioned when they're sent for review, sent, and amended
+func (s *ResolverSuite) TestTRBGuidanceLetterVersions() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+
+	stubFetchUserInfo := func(context.Context, string) (*models.UserInfo, error) {
+		return &models.UserInfo{
+			Username:    "ANON",
+			DisplayName: "Anonymous",
+			Email:       models.NewEmailAddress("anon@local.fake"),
+		}, nil
+	}
+	stubFetchUserInfos := func(context.Context, []string) ([]*models.UserInfo, error) {
+		return []*models.UserInfo{}, nil
+	}
+
+	trb, err := CreateTRBRequest(ctx, models.TRBTBrainstorm, store)
+	s.NoError(err)
+
+	letter, err := CreateTRBGuidanceLetter(ctx, store, trb.ID)
+	s.NoError(err)
+	_, err = UpdateTRBGuidanceLetter(ctx, store, map[string]interface{}{
+		"trbRequestId":   trb.ID,
+		"meetingSummary": "<p>Talked about the cloud</p>",
+	})
+	s.NoError(err)
+
+	insight, err := CreateTRBGuidanceLetterInsight(ctx, store, &models.TRBGuidanceLetterInsight{
+		TRBRequestID: trb.ID,
+		Title:        "Restart your computer",
+		Insight:      "<p>I recommend you restart your computer</p>",
+		Links:        pq.StringArray{"google.com"},
+		Category:     models.TRBGuidanceLetterInsightCategoryRecommendation,
+	})
+	s.NoError(err)
+
+	s.Run("an unsent letter can't be amended", func() {
+		_, err := SendAmendedTRBGuidanceLetter(ctx, store, letter.ID, "<p>Fixed a typo</p>", nil, stubFetchUserInfo, stubFetchUserInfos, false, false, nil)
+		var badRequestErr *apperrors.BadRequestError
+		s.ErrorAs(err, &badRequestErr)
+	})
+
+	s.Run("requesting review and sending the letter each save a version", func() {
+		_, err := RequestReviewForTRBGuidanceLetter(ctx, store, nil, stubFetchUserInfo, letter.ID)
+		s.NoError(err)
+		_, err = SendTRBGuidanceLetter(ctx, store, letter.ID, nil, stubFetchUserInfo, stubFetchUserInfos, false, false, nil)
+		s.NoError(err)
+
+		versions, err := GetTRBGuidanceLetterVersions(ctx, store, trb.ID)
+		s.NoError(err)
+		s.Len(versions, 2)
+
+		// versions are listed newest first
+		s.Equal(2, versions[0].VersionNumber)
+		s.Equal(models.TRBGuidanceLetterVersionReasonSent, versions[0].Reason)
+		s.Equal(1, versions[1].VersionNumber)
+		s.Equal(models.TRBGuidanceLetterVersionReasonReviewRequested, versions[1].Reason)
+
+		s.EqualValues("<p>Talked about the cloud</p>", *versions[0].MeetingSummary)
+		s.Len(versions[0].Insights, 1)
+		s.Equal(insight.ID, versions[0].Insights[0].InsightID)
+		s.Equal(insight.Title, versions[0].Insights[0].Title)
+	})
+
+	s.Run("an unchanged letter can't be amended", func() {
+		_, err := SendAmendedTRBGuidanceLetter(ctx, store, letter.ID, "<p>Nothing changed</p>", nil, stubFetchUserInfo, stubFetchUserInfos, false, false, nil)
+		var badRequestErr *apperrors.BadRequestError
+		s.ErrorAs(err, &badRequestErr)
+	})
+
+	s.Run("an edited letter can be amended, and the versions compared", func() {
+		_, err := UpdateTRBGuidanceLetter(ctx, store, map[string]interface{}{
+			"trbRequestId":   trb.ID,
+			"meetingSummary": "<p>Talked about the cloud and containers</p>",
+		})
+		s.NoError(err)
+
+		_, err = SendAmendedTRBGuidanceLetter(ctx, store, letter.ID, "<p>Added containers</p>", nil, stubFetchUserInfo, stubFetchUserInfos, false, false, nil)
+		s.NoError(err)
+
+		versions, err := GetTRBGuidanceLetterVersions(ctx, store, trb.ID)
+		s.NoError(err)
+		s.Len(versions, 3)
+		s.Equal(models.TRBGuidanceLetterVersionReasonAmended, versions[0].Reason)
+		s.EqualValues("<p>Added containers</p>", *versions[0].AmendmentSummary)
+
+		diff, err := GetTRBGuidanceLetterVersionDiff(ctx, store, versions[1].ID, versions[0].ID)
+		s.NoError(err)
+		s.True(diff.HasChanges)
+		s.True(diff.MeetingSummaryChanged)
+		s.Contains(string(diff.MeetingSummary), "<ins>")
+		s.Len(diff.Insights, 1)
+		s.Equal(models.TRBGuidanceLetterInsightChangeTypeUnchanged, diff.Insights[0].ChangeType)
+	})
+
+	s.Run("versions of different letters can't be compared", func() {
+		otherTRB, err := CreateTRBRequest(ctx, models.TRBTBrainstorm, store)
+		s.NoError(err)
+		otherLetter, err := CreateTRBGuidanceLetter(ctx, store, otherTRB.ID)
+		s.NoError(err)
+		_, err = RequestReviewForTRBGuidanceLetter(ctx, store, nil, stubFetchUserInfo, otherLetter.ID)
+		s.NoError(err)
+
+		versions, err := GetTRBGuidanceLetterVersions(ctx, store, trb.ID)
+		s.NoError(err)
+		otherVersions, err := GetTRBGuidanceLetterVersions(ctx, store, otherTRB.ID)
+		s.NoError(err)
+		s.Len(otherVersions, 1)
+
+		_, err = GetTRBGuidanceLetterVersionDiff(ctx, store, versions[0].ID, otherVersions[0].ID)
+		var badRequestErr *apperrors.BadRequestError
+		s.ErrorAs(err, &badRequestErr)
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
@@ -178,6 +179,67 @@ func GetTRBRequests(ctx context.Context, store *storage.Store, archived bool) ([
 	return store.GetTRBRequests(ctx, archived)
 }
 
+// GetTRBRequestsConnection returns a page of TRB Requests for admins, filtered and sorted on the server
+func GetTRBRequestsConnection(
+	ctx context.Context,
+	store *storage.Store,
+	first int,
+	after *string,
+	filter *models.TRBRequestsFilter,
+	sort *models.TRBRequestsSort,
+) (*models.TRBRequestConnection, error) {
+	cursor, err := decodePageArgs(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	requestsFilter := lo.FromPtr(filter)
+	requestsSort := lo.FromPtrOr(sort, models.TRBRequestsSort{
+		Field:     models.TRBRequestsSortFieldSubmittedAt,
+		Direction: models.SortDirectionDesc,
+	})
+
+	// TRB request statuses are calculated from the request's form, feedback and guidance letter, so can't be filtered in the DB
+	var include func(*models.SortedTRBRequest) (bool, error)
+	if len(requestsFilter.Statuses) > 0 {
+		include = func(trbRequest *models.SortedTRBRequest) (bool, error) {
+			status, err := GetTRBRequestStatus(ctx, trbRequest.TRBRequest)
+			if err != nil {
+				return false, err
+			}
+			return lo.Contains(requestsFilter.Statuses, status), nil
+		}
+	}
+
+	trbRequests, hasNextPage, err := fetchPage(
+		first,
+		cursor,
+		func(limit int, after *models.PageCursor) ([]*models.SortedTRBRequest, error) {
+			return store.GetTRBRequestsPage(ctx, requestsFilter, requestsSort, limit, after)
+		},
+		(*models.SortedTRBRequest).Cursor,
+		include,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*models.TRBRequestEdge, len(trbRequests))
+	cursors := make([]string, len(trbRequests))
+	for i, trbRequest := range trbRequests {
+		cursors[i] = trbRequest.Cursor().Encode()
+		edges[i] = &models.TRBRequestEdge{
+			Cursor: cursors[i],
+			Node:   &trbRequest.TRBRequest,
+		}
+	}
+
+	return &models.TRBRequestConnection{
+		Edges:    edges,
+		PageInfo: newPageInfo(cursors, hasNextPage),
+	}, nil
+}
+
 // GetMyTRBRequests returns all TRB Requests that belong to the principal in the context
 func GetMyTRBRequests(ctx context.Context, store *storage.Store, archived bool) ([]*models.TRBRequest, error) {
 	return store.GetMyTRBRequests(ctx, archived)
@@ -243,6 +305,12 @@ func UpdateTRBRequestConsultMeetingTime(
 	// Email client can be nil when this is called from tests - the email client itself tests this
 	// separately in the email package test
 	if emailClient != nil {
+		// rescheduling sends the invite again with the same UID, so it updates the event already in recipients' calendars
+		emailInput.CalendarInviteSequence, err = store.NextCalendarInviteSequence(ctx, store, emailClient.TRBConsultMeetingInviteUID(trb.ID))
+		if err != nil {
+			return nil, err
+		}
+
 		err = emailClient.SendTRBRequestConsultMeetingEmail(ctx, emailInput)
 		if err != nil {
 			return nil, err
@@ -513,6 +581,7 @@ func GetTRBRequesterInfo(ctx context.Context, requesterEUA string) (*models.User
 	return requesterInfo, nil
 }
 
+// the full text search in search.sql applies the same rules to its results, so they must change together
 func authorizeUserCanViewTRBRequest(ctx context.Context, trbRequest *models.TRBRequest) error {
 	p := appcontext.Principal(ctx)
 
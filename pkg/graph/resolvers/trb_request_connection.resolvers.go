@@ -0,0 +1,16 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// TrbRequestsConnection is the resolver for the trbRequestsConnection field.
+func (r *queryResolver) TrbRequestsConnection(ctx context.Context, first int, after *string, filter *models.TRBRequestsFilter, sort *models.TRBRequestsSort) (*models.TRBRequestConnection, error) {
+	return GetTRBRequestsConnection(ctx, r.store, first, after, filter, sort)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
 	"github.com/cms-enterprise/easi-app/pkg/userhelpers"
@@ -392,6 +393,72 @@ func (s *ResolverSuite) TestGetTRBRequests() {
 	s.EqualValues(trbUpdate, col[0])
 }
 
+func (s *ResolverSuite) TestGetTRBRequestsConnection() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+
+	brainstorm := s.createNewTRBRequest(func(t *models.TRBRequest) {
+		t.Name = helpers.PointerTo("Brainstorm Request")
+	})
+	formalReview, err := CreateTRBRequest(ctx, models.TRBTFormalReview, store)
+	s.NoError(err)
+
+	trbRequestIDs := func(connection *models.TRBRequestConnection) []uuid.UUID {
+		ids := make([]uuid.UUID, len(connection.Edges))
+		for i, edge := range connection.Edges {
+			ids[i] = edge.Node.ID
+		}
+		return ids
+	}
+
+	s.Run("returns the most recently created first when nothing has been submitted", func() {
+		connection, err := GetTRBRequestsConnection(ctx, store, 25, nil, nil, nil)
+		s.NoError(err)
+		s.Equal([]uuid.UUID{formalReview.ID, brainstorm.ID}, trbRequestIDs(connection))
+	})
+
+	s.Run("pages through the results", func() {
+		firstPage, err := GetTRBRequestsConnection(ctx, store, 1, nil, nil, nil)
+		s.NoError(err)
+		s.Equal([]uuid.UUID{formalReview.ID}, trbRequestIDs(firstPage))
+		s.True(firstPage.PageInfo.HasNextPage)
+
+		secondPage, err := GetTRBRequestsConnection(ctx, store, 1, firstPage.PageInfo.EndCursor, nil, nil)
+		s.NoError(err)
+		s.Equal([]uuid.UUID{brainstorm.ID}, trbRequestIDs(secondPage))
+		s.False(secondPage.PageInfo.HasNextPage)
+	})
+
+	s.Run("filters by type and name", func() {
+		connection, err := GetTRBRequestsConnection(ctx, store, 25, nil, &models.TRBRequestsFilter{
+			RequestTypes: []models.TRBRequestType{models.TRBTFormalReview},
+		}, nil)
+		s.NoError(err)
+		s.Equal([]uuid.UUID{formalReview.ID}, trbRequestIDs(connection))
+
+		connection, err = GetTRBRequestsConnection(ctx, store, 25, nil, &models.TRBRequestsFilter{
+			Search: helpers.PointerTo("brainstorm"),
+		}, nil)
+		s.NoError(err)
+		s.Equal([]uuid.UUID{brainstorm.ID}, trbRequestIDs(connection))
+	})
+
+	s.Run("filters by calculated status", func() {
+		connection, err := GetTRBRequestsConnection(ctx, store, 1, nil, &models.TRBRequestsFilter{
+			Statuses: []models.TRBRequestStatus{models.TRBRequestStatusNew},
+		}, nil)
+		s.NoError(err)
+		s.Len(connection.Edges, 1)
+		s.True(connection.PageInfo.HasNextPage)
+
+		connection, err = GetTRBRequestsConnection(ctx, store, 25, nil, &models.TRBRequestsFilter{
+			Statuses: []models.TRBRequestStatus{models.TRBRequestStatusGuidanceLetterSent},
+		}, nil)
+		s.NoError(err)
+		s.Empty(connection.Edges)
+	})
+}
+
 // TestGetMyTRBRequests returns a users TRB Requests
 func (s *ResolverSuite) TestGetMyTRBRequests() {
 	ctxABCD, _ := s.getTestContextWithPrincipal("ABCD", true)
@@ -0,0 +1,279 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+	"github.com/cms-enterprise/easi-app/pkg/webhooks"
+)
+
+// webhookEventTypesUserCanManage returns the events whose subscriptions and deliveries the principal can manage:
+// GRT admins manage IT Governance events, and TRB admins manage TRB events
+func webhookEventTypesUserCanManage(ctx context.Context) []models.WebhookEventType {
+	principal := appcontext.Principal(ctx)
+
+	var eventTypes []models.WebhookEventType
+	if principal.AllowGRT() {
+		eventTypes = append(eventTypes, models.ITGovWebhookEventTypes...)
+	}
+	if principal.AllowTRBAdmin() {
+		eventTypes = append(eventTypes, models.TRBWebhookEventTypes...)
+	}
+	return eventTypes
+}
+
+// authorizeUserCanManageWebhooks checks that the principal administers either GRT or TRB requests, and can manage each of the given events,
+// so admins can only see and change subscriptions to the events of requests they administer
+func authorizeUserCanManageWebhooks(ctx context.Context, eventTypes ...models.WebhookEventType) error {
+	allowed := webhookEventTypesUserCanManage(ctx)
+	if len(allowed) == 0 {
+		return &apperrors.UnauthorizedError{Err: errors.New("unauthorized to manage webhooks")}
+	}
+	for _, eventType := range eventTypes {
+		if !lo.Contains(allowed, eventType) {
+			return &apperrors.UnauthorizedError{Err: fmt.Errorf("unauthorized to manage webhooks for %s events", eventType)}
+		}
+	}
+	return nil
+}
+
+// authorizeUserCanManageWebhookSubscription checks that the principal can manage every event the subscription with the given ID is subscribed to,
+// returning the subscription
+func authorizeUserCanManageWebhookSubscription(ctx context.Context, store *storage.Store, id uuid.UUID) (*models.WebhookSubscription, error) {
+	if err := authorizeUserCanManageWebhooks(ctx); err != nil {
+		return nil, err
+	}
+
+	subscription, err := store.GetWebhookSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeUserCanManageWebhooks(ctx, subscription.EventTypes...); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// validateWebhookSubscription checks that a subscription can be sent events. allowLocalURLs accepts plain HTTP URLs on the local machine,
+// and is only set in local and test environments
+func validateWebhookSubscription(name string, url string, eventTypes []models.WebhookEventType, allowLocalURLs bool) error {
+	if strings.TrimSpace(name) == "" {
+		return &apperrors.BadRequestError{Err: errors.New("webhook subscription must have a name")}
+	}
+	if err := webhooks.ValidateSubscriptionURL(url, allowLocalURLs); err != nil {
+		return &apperrors.BadRequestError{Err: err}
+	}
+	if len(eventTypes) == 0 {
+		return &apperrors.BadRequestError{Err: errors.New("webhook subscription must be subscribed to at least one event")}
+	}
+	return nil
+}
+
+// GetWebhookSubscriptions returns the webhook subscriptions the principal can manage every event of
+func GetWebhookSubscriptions(ctx context.Context, store *storage.Store) ([]*models.WebhookSubscription, error) {
+	if err := authorizeUserCanManageWebhooks(ctx); err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := store.GetWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Filter(subscriptions, func(subscription *models.WebhookSubscription, _ int) bool {
+		return authorizeUserCanManageWebhooks(ctx, subscription.EventTypes...) == nil
+	}), nil
+}
+
+// CreateWebhookSubscription creates an active webhook subscription with a new secret to sign its requests with,
+// returning the secret so it can be given to the subscriber
+func CreateWebhookSubscription(
+	ctx context.Context,
+	store *storage.Store,
+	input models.CreateWebhookSubscriptionInput,
+	allowLocalURLs bool,
+) (*models.WebhookSubscriptionWithSecret, error) {
+	if err := authorizeUserCanManageWebhooks(ctx, input.EventTypes...); err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhookSubscription(input.Name, input.URL, input.EventTypes, allowLocalURLs); err != nil {
+		return nil, err
+	}
+
+	secret, err := webhooks.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := models.NewWebhookSubscription(appcontext.Principal(ctx).Account().ID)
+	subscription.Name = strings.TrimSpace(input.Name)
+	subscription.URL = input.URL
+	subscription.Secret = secret
+	subscription.EventTypes = input.EventTypes
+
+	created, err := store.CreateWebhookSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WebhookSubscriptionWithSecret{
+		Subscription: created,
+		Secret:       created.Secret,
+	}, nil
+}
+
+// UpdateWebhookSubscription updates a webhook subscription's name, URL, event types, and whether it's active
+func UpdateWebhookSubscription(
+	ctx context.Context,
+	store *storage.Store,
+	input models.UpdateWebhookSubscriptionInput,
+	allowLocalURLs bool,
+) (*models.WebhookSubscription, error) {
+	if err := authorizeUserCanManageWebhooks(ctx, input.EventTypes...); err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhookSubscription(input.Name, input.URL, input.EventTypes, allowLocalURLs); err != nil {
+		return nil, err
+	}
+
+	subscription, err := authorizeUserCanManageWebhookSubscription(ctx, store, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Name = strings.TrimSpace(input.Name)
+	subscription.URL = input.URL
+	subscription.EventTypes = input.EventTypes
+	subscription.IsActive = input.IsActive
+	subscription.ModifiedBy = &appcontext.Principal(ctx).Account().ID
+
+	return store.UpdateWebhookSubscription(ctx, subscription)
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription along with its delivery log
+func DeleteWebhookSubscription(ctx context.Context, store *storage.Store, id uuid.UUID) (*models.WebhookSubscription, error) {
+	if _, err := authorizeUserCanManageWebhookSubscription(ctx, store, id); err != nil {
+		return nil, err
+	}
+
+	return store.DeleteWebhookSubscription(ctx, id)
+}
+
+// RotateWebhookSubscriptionSecret replaces the secret a webhook subscription's requests are signed with, returning the new secret.
+// Pending deliveries, including retries of events first sent with the old secret, are signed with the new one
+func RotateWebhookSubscriptionSecret(ctx context.Context, store *storage.Store, id uuid.UUID) (*models.WebhookSubscriptionWithSecret, error) {
+	if _, err := authorizeUserCanManageWebhookSubscription(ctx, store, id); err != nil {
+		return nil, err
+	}
+
+	secret, err := webhooks.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := store.UpdateWebhookSubscriptionSecret(ctx, id, secret, appcontext.Principal(ctx).Account().ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WebhookSubscriptionWithSecret{
+		Subscription: updated,
+		Secret:       updated.Secret,
+	}, nil
+}
+
+// GetWebhookDeliveries returns a page of the deliveries of events the principal can manage, optionally only the deliveries to one subscription
+// or with the given status, newest first
+func GetWebhookDeliveries(
+	ctx context.Context,
+	store *storage.Store,
+	subscriptionID *uuid.UUID,
+	status *models.WebhookDeliveryStatus,
+	first int,
+	after *string,
+) (*models.WebhookDeliveryConnection, error) {
+	if err := authorizeUserCanManageWebhooks(ctx); err != nil {
+		return nil, err
+	}
+
+	cursor, err := decodePageArgs(first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, hasNextPage, err := fetchPage(
+		first,
+		cursor,
+		func(limit int, after *models.PageCursor) ([]*models.WebhookDelivery, error) {
+			return store.GetWebhookDeliveries(ctx, webhookEventTypesUserCanManage(ctx), subscriptionID, status, limit, after)
+		},
+		(*models.WebhookDelivery).Cursor,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*models.WebhookDeliveryEdge, len(deliveries))
+	cursors := make([]string, len(deliveries))
+	for i, delivery := range deliveries {
+		cursors[i] = delivery.Cursor().Encode()
+		edges[i] = &models.WebhookDeliveryEdge{
+			Cursor: cursors[i],
+			Node:   delivery,
+		}
+	}
+
+	return &models.WebhookDeliveryConnection{
+		Edges:    edges,
+		PageInfo: newPageInfo(cursors, hasNextPage),
+	}, nil
+}
+
+// RedeliverWebhookDelivery moves a delivery that failed back to pending, so the webhook dispatcher sends it again right away
+func RedeliverWebhookDelivery(ctx context.Context, store *storage.Store, id uuid.UUID) (*models.WebhookDelivery, error) {
+	if err := authorizeUserCanManageWebhooks(ctx); err != nil {
+		return nil, err
+	}
+
+	delivery, err := store.GetWebhookDeliveryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeUserCanManageWebhooks(ctx, delivery.EventType); err != nil {
+		return nil, err
+	}
+
+	if delivery.Status == models.WebhookDeliveryStatusDelivered {
+		return nil, &apperrors.BadRequestError{Err: errors.New("webhook event has already been delivered")}
+	}
+
+	var modifiedBy *uuid.UUID
+	if account := appcontext.Principal(ctx).Account(); account != nil {
+		modifiedBy = &account.ID
+	}
+
+	return store.RedeliverWebhookDelivery(ctx, id, modifiedBy)
+}
+
+// emitWebhookEvent queues a governance lifecycle event for the webhook subscribers subscribed to it. It's called after the change
+// that caused the event has been saved, so a failure to queue the event is logged rather than failing the change
+func emitWebhookEvent(ctx context.Context, store *storage.Store, eventType models.WebhookEventType, data any) {
+	if _, err := webhooks.Emit(ctx, store, store, eventType, data); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to queue webhook event", zap.Error(err), zap.String("eventType", string(eventType)))
+	}
+}
@@ -0,0 +1,61 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/graph/generated"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// CreateWebhookSubscription is the resolver for the createWebhookSubscription field.
+func (r *mutationResolver) CreateWebhookSubscription(ctx context.Context, input models.CreateWebhookSubscriptionInput) (*models.WebhookSubscriptionWithSecret, error) {
+	return CreateWebhookSubscription(ctx, r.store, input, r.allowLocalWebhookURLs)
+}
+
+// UpdateWebhookSubscription is the resolver for the updateWebhookSubscription field.
+func (r *mutationResolver) UpdateWebhookSubscription(ctx context.Context, input models.UpdateWebhookSubscriptionInput) (*models.WebhookSubscription, error) {
+	return UpdateWebhookSubscription(ctx, r.store, input, r.allowLocalWebhookURLs)
+}
+
+// DeleteWebhookSubscription is the resolver for the deleteWebhookSubscription field.
+func (r *mutationResolver) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	return DeleteWebhookSubscription(ctx, r.store, id)
+}
+
+// RotateWebhookSubscriptionSecret is the resolver for the rotateWebhookSubscriptionSecret field.
+func (r *mutationResolver) RotateWebhookSubscriptionSecret(ctx context.Context, id uuid.UUID) (*models.WebhookSubscriptionWithSecret, error) {
+	return RotateWebhookSubscriptionSecret(ctx, r.store, id)
+}
+
+// RedeliverWebhookDelivery is the resolver for the redeliverWebhookDelivery field.
+func (r *mutationResolver) RedeliverWebhookDelivery(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
+	return RedeliverWebhookDelivery(ctx, r.store, id)
+}
+
+// WebhookSubscriptions is the resolver for the webhookSubscriptions field.
+func (r *queryResolver) WebhookSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	return GetWebhookSubscriptions(ctx, r.store)
+}
+
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, subscriptionID *uuid.UUID, status *models.WebhookDeliveryStatus, first int, after *string) (*models.WebhookDeliveryConnection, error) {
+	return GetWebhookDeliveries(ctx, r.store, subscriptionID, status, first, after)
+}
+
+// EventTypes is the resolver for the eventTypes field.
+func (r *webhookSubscriptionResolver) EventTypes(ctx context.Context, obj *models.WebhookSubscription) ([]models.WebhookEventType, error) {
+	return obj.EventTypes, nil
+}
+
+// WebhookSubscription returns generated.WebhookSubscriptionResolver implementation.
+func (r *Resolver) WebhookSubscription() generated.WebhookSubscriptionResolver {
+	return &webhookSubscriptionResolver{r}
+}
+
+type webhookSubscriptionResolver struct{ *Resolver }
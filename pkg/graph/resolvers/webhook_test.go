@@ -0,0 +1,236 @@
+package resolvers
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (s *ResolverSuite) TestWebhookSubscriptions() {
+	ctx := s.testConfigs.Context
+	store := s.testConfigs.Store
+
+	created, err := CreateWebhookSubscription(ctx, store, models.CreateWebhookSubscriptionInput{
+		Name:       " Lifecycle system ",
+		URL:        "https://lifecycle.cms.fake/webhooks",
+		EventTypes: []models.WebhookEventType{models.WebhookEventTypeLcidIssued},
+	}, false)
+	s.NoError(err)
+	s.Len(created.Secret, 64)
+	s.Equal("Lifecycle system", created.Subscription.Name)
+	s.True(created.Subscription.IsActive)
+	subscriptionID := created.Subscription.ID
+
+	s.Run("subscriptions must have a name, an https URL, and at least one event", func() {
+		for _, input := range []models.CreateWebhookSubscriptionInput{
+			{Name: "", URL: "https://lifecycle.cms.fake/webhooks", EventTypes: []models.WebhookEventType{models.WebhookEventTypeLcidIssued}},
+			{Name: "insecure", URL: "http://lifecycle.cms.fake/webhooks", EventTypes: []models.WebhookEventType{models.WebhookEventTypeLcidIssued}},
+			{Name: "no events", URL: "https://lifecycle.cms.fake/webhooks"},
+		} {
+			_, err := CreateWebhookSubscription(ctx, store, input, false)
+			s.Error(err, input.Name)
+		}
+	})
+
+	s.Run("plain HTTP URLs on the local machine are only allowed in local and test environments", func() {
+		input := models.CreateWebhookSubscriptionInput{
+			Name:       "local",
+			URL:        "http://localhost:3000/webhooks",
+			EventTypes: []models.WebhookEventType{models.WebhookEventTypeLcidIssued},
+		}
+
+		_, err := CreateWebhookSubscription(ctx, store, input, false)
+		s.Error(err)
+
+		local, err := CreateWebhookSubscription(ctx, store, input, true)
+		s.NoError(err)
+
+		_, err = DeleteWebhookSubscription(ctx, store, local.Subscription.ID)
+		s.NoError(err)
+	})
+
+	s.Run("issuing an LCID queues an event for subscribers to it", func() {
+		intake := s.createNewIntake()
+		lcid := "123456"
+		_, err := IssueLCID(ctx, store, s.testConfigs.EmailClient, s.fetchUserInfoStub, models.SystemIntakeIssueLCIDInput{
+			Lcid:            &lcid,
+			SystemIntakeID:  intake.ID,
+			ExpiresAt:       time.Now().AddDate(2, 0, 0),
+			Scope:           "test scope",
+			NextSteps:       "test next steps",
+			TrbFollowUp:     models.TRBFRStronglyRecommended,
+			LcidType:        models.LCIDTypeNewSystem,
+			LcidComponent:   models.SystemIntakeContactComponentOfficeOfInformationTechnologyOit,
+			LcidIsLowIt:     true,
+			LcidIsShortened: false,
+		})
+		s.NoError(err)
+
+		deliveries, err := GetWebhookDeliveries(ctx, store, &subscriptionID, nil, 25, nil)
+		s.NoError(err)
+		s.Len(deliveries.Edges, 1)
+		delivery := deliveries.Edges[0].Node
+		s.Equal(models.WebhookEventTypeLcidIssued, delivery.EventType)
+		s.Equal(models.WebhookDeliveryStatusPending, delivery.Status)
+		s.Equal(s.testConfigs.Principal.Account().ID, *delivery.CreatedBy)
+
+		var payload struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+			Data struct {
+				SystemIntakeID string `json:"systemIntakeId"`
+				LCID           string `json:"lcid"`
+			} `json:"data"`
+		}
+		s.NoError(json.Unmarshal([]byte(delivery.Payload), &payload))
+		s.Equal(delivery.EventID.String(), payload.ID)
+		s.Equal("LCID_ISSUED", payload.Type)
+		s.Equal(intake.ID.String(), payload.Data.SystemIntakeID)
+		s.Equal(lcid, payload.Data.LCID)
+	})
+
+	s.Run("inactive subscriptions and subscriptions to other events aren't queued events", func() {
+		_, err := UpdateWebhookSubscription(ctx, store, models.UpdateWebhookSubscriptionInput{
+			ID:         subscriptionID,
+			Name:       "Lifecycle system",
+			URL:        "https://lifecycle.cms.fake/webhooks",
+			EventTypes: []models.WebhookEventType{models.WebhookEventTypeLcidIssued},
+			IsActive:   false,
+		}, false)
+		s.NoError(err)
+		other, err := CreateWebhookSubscription(ctx, store, models.CreateWebhookSubscriptionInput{
+			Name:       "Retirements",
+			URL:        "https://retirements.cms.fake/webhooks",
+			EventTypes: []models.WebhookEventType{models.WebhookEventTypeLcidRetired},
+		}, false)
+		s.NoError(err)
+
+		emitWebhookEvent(ctx, store, models.WebhookEventTypeLcidIssued, map[string]string{})
+
+		deliveries, err := GetWebhookDeliveries(ctx, store, nil, nil, 25, nil)
+		s.NoError(err)
+		s.Len(deliveries.Edges, 1)
+
+		otherDeliveries, err := GetWebhookDeliveries(ctx, store, &other.Subscription.ID, nil, 25, nil)
+		s.NoError(err)
+		s.Empty(otherDeliveries.Edges)
+	})
+
+	s.Run("failed deliveries can be redelivered, but delivered ones can't", func() {
+		deliveries, err := GetWebhookDeliveries(ctx, store, &subscriptionID, helpers.PointerTo(models.WebhookDeliveryStatusPending), 25, nil)
+		s.NoError(err)
+		s.Len(deliveries.Edges, 1)
+		delivery := deliveries.Edges[0].Node
+
+		delivery.Attempts = models.WebhookDeliveryMaxAttempts - 1
+		delivery.RecordFailure(delivery.NextAttemptAt, helpers.PointerTo(500), errors.New("subscriber responded with 500 Internal Server Error"))
+		s.NoError(store.UpdateWebhookDelivery(ctx, store, delivery))
+
+		redelivered, err := RedeliverWebhookDelivery(ctx, store, delivery.ID)
+		s.NoError(err)
+		s.Equal(models.WebhookDeliveryStatusPending, redelivered.Status)
+		s.Equal(0, redelivered.Attempts)
+
+		redelivered.RecordDelivered(redelivered.NextAttemptAt, 200)
+		s.NoError(store.UpdateWebhookDelivery(ctx, store, redelivered))
+
+		_, err = RedeliverWebhookDelivery(ctx, store, delivery.ID)
+		s.Error(err)
+	})
+
+	s.Run("rotating the secret replaces it", func() {
+		rotated, err := RotateWebhookSubscriptionSecret(ctx, store, subscriptionID)
+		s.NoError(err)
+		s.NotEqual(created.Secret, rotated.Secret)
+		s.Equal(rotated.Secret, rotated.Subscription.Secret)
+	})
+
+	s.Run("deleting a subscription deletes its delivery log", func() {
+		_, err := DeleteWebhookSubscription(ctx, store, subscriptionID)
+		s.NoError(err)
+
+		subscriptions, err := GetWebhookSubscriptions(ctx, store)
+		s.NoError(err)
+		s.Len(subscriptions, 1)
+
+		deliveries, err := GetWebhookDeliveries(ctx, store, &subscriptionID, nil, 25, nil)
+		s.NoError(err)
+		s.Empty(deliveries.Edges)
+	})
+
+	s.Run("requires an admin", func() {
+		requesterCtx, _ := s.getTestContextWithPrincipal("USR1", false)
+
+		_, err := GetWebhookSubscriptions(requesterCtx, store)
+		s.Error(err)
+
+		_, err = CreateWebhookSubscription(requesterCtx, store, models.CreateWebhookSubscriptionInput{
+			Name:       "Lifecycle system",
+			URL:        "https://lifecycle.cms.fake/webhooks",
+			EventTypes: []models.WebhookEventType{models.WebhookEventTypeLcidIssued},
+		}, false)
+		s.Error(err)
+	})
+
+	s.Run("TRB admins only manage subscriptions to TRB events", func() {
+		grbSubscription, err := CreateWebhookSubscription(ctx, store, models.CreateWebhookSubscriptionInput{
+			Name:       "GRB reviews",
+			URL:        "https://reviews.cms.fake/webhooks",
+			EventTypes: []models.WebhookEventType{models.WebhookEventTypeGrbReviewEnded},
+		}, false)
+		s.NoError(err)
+		emitWebhookEvent(ctx, store, models.WebhookEventTypeGrbReviewEnded, map[string]string{})
+
+		trbAdminCtx, trbAdmin := s.getTestContextWithPrincipal("TRBA", true)
+		trbAdmin.JobCodeGRT = false
+		var unauthorizedErr *apperrors.UnauthorizedError
+
+		trbSubscription, err := CreateWebhookSubscription(trbAdminCtx, store, models.CreateWebhookSubscriptionInput{
+			Name:       "Guidance letters",
+			URL:        "https://guidance.cms.fake/webhooks",
+			EventTypes: []models.WebhookEventType{models.WebhookEventTypeTrbGuidanceLetterSent},
+		}, false)
+		s.NoError(err)
+
+		_, err = CreateWebhookSubscription(trbAdminCtx, store, models.CreateWebhookSubscriptionInput{
+			Name:       "Everything",
+			URL:        "https://everything.cms.fake/webhooks",
+			EventTypes: []models.WebhookEventType{models.WebhookEventTypeTrbGuidanceLetterSent, models.WebhookEventTypeLcidIssued},
+		}, false)
+		s.ErrorAs(err, &unauthorizedErr)
+
+		subscriptions, err := GetWebhookSubscriptions(trbAdminCtx, store)
+		s.NoError(err)
+		s.Len(subscriptions, 1)
+		s.Equal(trbSubscription.Subscription.ID, subscriptions[0].ID)
+
+		deliveries, err := GetWebhookDeliveries(trbAdminCtx, store, &grbSubscription.Subscription.ID, nil, 25, nil)
+		s.NoError(err)
+		s.Empty(deliveries.Edges)
+
+		grbDeliveries, err := GetWebhookDeliveries(ctx, store, &grbSubscription.Subscription.ID, nil, 25, nil)
+		s.NoError(err)
+		s.Len(grbDeliveries.Edges, 1)
+		_, err = RedeliverWebhookDelivery(trbAdminCtx, store, grbDeliveries.Edges[0].Node.ID)
+		s.ErrorAs(err, &unauthorizedErr)
+
+		_, err = UpdateWebhookSubscription(trbAdminCtx, store, models.UpdateWebhookSubscriptionInput{
+			ID:         grbSubscription.Subscription.ID,
+			Name:       "GRB reviews",
+			URL:        "https://reviews.cms.fake/webhooks",
+			EventTypes: []models.WebhookEventType{models.WebhookEventTypeTrbGuidanceLetterSent},
+			IsActive:   true,
+		}, false)
+		s.ErrorAs(err, &unauthorizedErr)
+
+		_, err = RotateWebhookSubscriptionSecret(trbAdminCtx, store, grbSubscription.Subscription.ID)
+		s.ErrorAs(err, &unauthorizedErr)
+
+		_, err = DeleteWebhookSubscription(trbAdminCtx, store, grbSubscription.Subscription.ID)
+		s.ErrorAs(err, &unauthorizedErr)
+	})
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+type renderBusinessCasePDF func(businessCase *models.BusinessCaseWithCosts) ([]byte, error)
+
+// NewBusinessCasePDFHandler is a constructor for BusinessCasePDFHandler
+func NewBusinessCasePDFHandler(
+	base HandlerBase,
+	fetch fetchBusinessCaseByID,
+	render renderBusinessCasePDF,
+) BusinessCasePDFHandler {
+	return BusinessCasePDFHandler{
+		HandlerBase:           base,
+		FetchBusinessCaseByID: fetch,
+		RenderBusinessCasePDF: render,
+	}
+}
+
+// BusinessCasePDFHandler is the handler for downloading a printable PDF of a Business Case
+type BusinessCasePDFHandler struct {
+	HandlerBase
+	FetchBusinessCaseByID fetchBusinessCaseByID
+	RenderBusinessCasePDF renderBusinessCasePDF
+}
+
+// Handle handles a request to download a Business Case as a PDF
+func (h BusinessCasePDFHandler) Handle() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			h.WriteErrorResponse(r.Context(), w, &apperrors.MethodNotAllowedError{Method: r.Method})
+			return
+		}
+
+		businessCaseID, err := requireBusinessCaseID(mux.Vars(r))
+		if err != nil {
+			h.WriteErrorResponse(r.Context(), w, err)
+			return
+		}
+
+		businessCase, err := h.FetchBusinessCaseByID(r.Context(), businessCaseID)
+		if err != nil {
+			h.WriteErrorResponse(r.Context(), w, err)
+			return
+		}
+
+		document, err := h.RenderBusinessCasePDF(businessCase)
+		if err != nil {
+			h.WriteErrorResponse(r.Context(), w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", email.BusinessCasePDFFilename(businessCaseID)))
+		if _, err := w.Write(document); err != nil {
+			h.WriteErrorResponse(r.Context(), w, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func newMockRenderBusinessCasePDF(err error) func(businessCase *models.BusinessCaseWithCosts) ([]byte, error) {
+	return func(businessCase *models.BusinessCaseWithCosts) ([]byte, error) {
+		if err != nil {
+			return nil, err
+		}
+		return []byte("%PDF-1.4 " + businessCase.ID.String()), nil
+	}
+}
+
+func (s *HandlerTestSuite) TestBusinessCasePDFHandler() {
+	requestContext := appcontext.WithPrincipal(context.Background(), &authentication.EUAPrincipal{EUAID: "FAKE", JobCodeEASi: true})
+	id := uuid.New()
+
+	serve := func(handler BusinessCasePDFHandler, method string, businessCaseID string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(requestContext, method, "/business_case/"+businessCaseID+"/pdf", nil)
+		s.NoError(err)
+		req = mux.SetURLVars(req, map[string]string{"business_case_id": businessCaseID})
+		handler.Handle()(rr, req)
+		return rr
+	}
+
+	s.Run("golden path GET downloads the PDF", func() {
+		rr := serve(NewBusinessCasePDFHandler(s.base, newMockFetchBusinessCaseByID(nil), newMockRenderBusinessCasePDF(nil)), http.MethodGet, id.String())
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("application/pdf", rr.Header().Get("Content-Type"))
+		s.Equal(`attachment; filename="business_case_`+id.String()+`.pdf"`, rr.Header().Get("Content-Disposition"))
+		s.Equal("%PDF-1.4 "+id.String(), rr.Body.String())
+	})
+
+	s.Run("GET returns an error if the uuid is not valid", func() {
+		rr := serve(NewBusinessCasePDFHandler(s.base, newMockFetchBusinessCaseByID(nil), newMockRenderBusinessCasePDF(nil)), http.MethodGet, "NON_EXISTENT")
+		s.Equal(http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	s.Run("GET returns an error if the user can't view the business case", func() {
+		fetch := newMockFetchBusinessCaseByID(&apperrors.UnauthorizedError{Err: errors.New("unauthorized")})
+		rr := serve(NewBusinessCasePDFHandler(s.base, fetch, newMockRenderBusinessCasePDF(nil)), http.MethodGet, id.String())
+		s.Equal(http.StatusUnauthorized, rr.Code)
+	})
+
+	s.Run("GET returns an error if rendering fails", func() {
+		rr := serve(NewBusinessCasePDFHandler(s.base, newMockFetchBusinessCaseByID(nil), newMockRenderBusinessCasePDF(errors.New("failed to render"))), http.MethodGet, id.String())
+		s.Equal(http.StatusInternalServerError, rr.Code)
+	})
+
+	s.Run("only allows GET", func() {
+		rr := serve(NewBusinessCasePDFHandler(s.base, newMockFetchBusinessCaseByID(nil), newMockRenderBusinessCasePDF(nil)), http.MethodPost, id.String())
+		s.Equal(http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/export"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+type exportSystemIntakes func(ctx context.Context, filter models.SystemIntakesFilter, sort models.SystemIntakesSort, w export.Writer) error
+type exportTRBRequests func(ctx context.Context, filter models.TRBRequestsFilter, sort models.TRBRequestsSort, w export.Writer) error
+
+// The reports that can be exported, as used in the export route
+const (
+	exportNameSystemIntakes  = "system_intakes"
+	exportNameBusinessCases  = "business_cases"
+	exportNameLifecycleCosts = "lifecycle_costs"
+	exportNameTRBRequests    = "trb_requests"
+)
+
+// NewExportHandler is a constructor for ExportHandler
+func NewExportHandler(
+	base HandlerBase,
+	systemIntakes exportSystemIntakes,
+	businessCases exportSystemIntakes,
+	lifecycleCosts exportSystemIntakes,
+	trbRequests exportTRBRequests,
+) ExportHandler {
+	return ExportHandler{
+		HandlerBase:          base,
+		ExportSystemIntakes:  systemIntakes,
+		ExportBusinessCases:  businessCases,
+		ExportLifecycleCosts: lifecycleCosts,
+		ExportTRBRequests:    trbRequests,
+	}
+}
+
+// ExportHandler is the handler for downloading spreadsheets of the requests in the admin tables.
+// The reports take the same filters as the admin tables as query parameters, and are streamed as CSV or XLSX
+type ExportHandler struct {
+	HandlerBase
+	ExportSystemIntakes  exportSystemIntakes
+	ExportBusinessCases  exportSystemIntakes
+	ExportLifecycleCosts exportSystemIntakes
+	ExportTRBRequests    exportTRBRequests
+}
+
+// Handle handles a request to export a report
+func (h ExportHandler) Handle() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if r.Method != http.MethodGet {
+			h.WriteErrorResponse(ctx, w, &apperrors.MethodNotAllowedError{Method: r.Method})
+			return
+		}
+
+		query := r.URL.Query()
+		format := export.Format(query.Get("format"))
+		if format == "" {
+			format = export.FormatCSV
+		}
+		if !format.IsValid() {
+			h.WriteErrorResponse(ctx, w, &apperrors.BadRequestError{Err: fmt.Errorf("unsupported export format %q", format)})
+			return
+		}
+
+		principal := appcontext.Principal(ctx)
+		name := mux.Vars(r)["export_name"]
+
+		var write func(w export.Writer) error
+		switch name {
+		case exportNameSystemIntakes, exportNameBusinessCases, exportNameLifecycleCosts:
+			if !principal.AllowGRT() {
+				h.WriteErrorResponse(ctx, w, &apperrors.UnauthorizedError{Err: errors.New("user is not authorized to export system intakes")})
+				return
+			}
+
+			filter, sort, err := parseSystemIntakesExportQuery(query)
+			if err != nil {
+				h.WriteErrorResponse(ctx, w, err)
+				return
+			}
+
+			exportFunc := map[string]exportSystemIntakes{
+				exportNameSystemIntakes:  h.ExportSystemIntakes,
+				exportNameBusinessCases:  h.ExportBusinessCases,
+				exportNameLifecycleCosts: h.ExportLifecycleCosts,
+			}[name]
+			write = func(w export.Writer) error {
+				return exportFunc(ctx, filter, sort, w)
+			}
+		case exportNameTRBRequests:
+			if !principal.AllowTRBAdmin() {
+				h.WriteErrorResponse(ctx, w, &apperrors.UnauthorizedError{Err: errors.New("user is not authorized to export TRB requests")})
+				return
+			}
+
+			filter, sort, err := parseTRBRequestsExportQuery(query)
+			if err != nil {
+				h.WriteErrorResponse(ctx, w, err)
+				return
+			}
+
+			write = func(w export.Writer) error {
+				return h.ExportTRBRequests(ctx, filter, sort, w)
+			}
+		default:
+			h.WriteErrorResponse(ctx, w, &apperrors.UnknownRouteError{Path: r.URL.Path})
+			return
+		}
+
+		filename := fmt.Sprintf("%s_%s.%s", name, h.clock.Now().UTC().Format("2006-01-02"), format)
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		writer, err := export.NewWriter(format, w, name)
+		if err != nil {
+			h.WriteErrorResponse(ctx, w, err)
+			return
+		}
+
+		// once rows have been streamed the status can't be changed, so failures part way through can only be logged,
+		// and result in a truncated file
+		if err := write(writer); err != nil {
+			appcontext.ZLogger(ctx).Error("failed to export report", zap.Error(err), zap.String("export", name))
+			return
+		}
+		if err := writer.Close(); err != nil {
+			appcontext.ZLogger(ctx).Error("failed to finish writing report", zap.Error(err), zap.String("export", name))
+		}
+	}
+}
+
+// parseSystemIntakesExportQuery reads the admin table filter and sort for system intakes from the query parameters.
+// The parameters have the same names as the fields of the SystemIntakesFilter and SystemIntakesSort GraphQL inputs, with list
+// fields repeated for each value, and sort fields prefixed with "sort", e.g. ?adminStatuses=READY_FOR_GRT&sortField=UPDATED_AT
+func parseSystemIntakesExportQuery(query url.Values) (models.SystemIntakesFilter, models.SystemIntakesSort, error) {
+	filter := models.SystemIntakesFilter{
+		State:         optionalQueryEnum[models.SystemIntakeState](query, "state"),
+		AdminStatuses: queryEnums[models.SystemIntakeStatusAdmin](query, "adminStatuses"),
+		AdminLead:     optionalQueryString(query, "adminLead"),
+		LcidStatuses:  queryEnums[models.SystemIntakeLCIDStatus](query, "lcidStatuses"),
+		RequestTypes:  queryEnums[models.SystemIntakeRequestType](query, "requestTypes"),
+		Search:        optionalQueryString(query, "search"),
+	}
+	sort := models.SystemIntakesSort{
+		Field:     models.SystemIntakesSortFieldSubmittedAt,
+		Direction: models.SortDirectionDesc,
+	}
+
+	var err error
+	if filter.SubmittedAfter, filter.SubmittedBefore, filter.UpdatedAfter, filter.UpdatedBefore, err = queryDateRanges(query); err != nil {
+		return filter, sort, err
+	}
+
+	if field := query.Get("sortField"); field != "" {
+		sort.Field = models.SystemIntakesSortField(field)
+		if !sort.Field.IsValid() {
+			return filter, sort, &apperrors.BadRequestError{Err: fmt.Errorf("invalid sortField %q", field)}
+		}
+	}
+	if sort.Direction, err = querySortDirection(query, sort.Direction); err != nil {
+		return filter, sort, err
+	}
+
+	return filter, sort, nil
+}
+
+// parseTRBRequestsExportQuery reads the admin table filter and sort for TRB requests from the query parameters, in the same
+// way as parseSystemIntakesExportQuery
+func parseTRBRequestsExportQuery(query url.Values) (models.TRBRequestsFilter, models.TRBRequestsSort, error) {
+	filter := models.TRBRequestsFilter{
+		State:        optionalQueryEnum[models.TRBRequestState](query, "state"),
+		Statuses:     queryEnums[models.TRBRequestStatus](query, "statuses"),
+		TrbLead:      optionalQueryString(query, "trbLead"),
+		RequestTypes: queryEnums[models.TRBRequestType](query, "requestTypes"),
+		Search:       optionalQueryString(query, "search"),
+	}
+	sort := models.TRBRequestsSort{
+		Field:     models.TRBRequestsSortFieldSubmittedAt,
+		Direction: models.SortDirectionDesc,
+	}
+
+	var err error
+	if archived := query.Get("archived"); archived != "" {
+		if filter.Archived, err = strconv.ParseBool(archived); err != nil {
+			return filter, sort, &apperrors.BadRequestError{Err: fmt.Errorf("invalid archived %q: %w", archived, err)}
+		}
+	}
+
+	if filter.SubmittedAfter, filter.SubmittedBefore, filter.UpdatedAfter, filter.UpdatedBefore, err = queryDateRanges(query); err != nil {
+		return filter, sort, err
+	}
+
+	if field := query.Get("sortField"); field != "" {
+		sort.Field = models.TRBRequestsSortField(field)
+		if !sort.Field.IsValid() {
+			return filter, sort, &apperrors.BadRequestError{Err: fmt.Errorf("invalid sortField %q", field)}
+		}
+	}
+	if sort.Direction, err = querySortDirection(query, sort.Direction); err != nil {
+		return filter, sort, err
+	}
+
+	return filter, sort, nil
+}
+
+func optionalQueryString(query url.Values, key string) *string {
+	if value := query.Get(key); value != "" {
+		return &value
+	}
+	return nil
+}
+
+// optionalQueryEnum and queryEnums don't validate the values, as an unknown value filters out every request,
+// the same as a value that no request has
+func optionalQueryEnum[T ~string](query url.Values, key string) *T {
+	if value := query.Get(key); value != "" {
+		enum := T(value)
+		return &enum
+	}
+	return nil
+}
+
+func queryEnums[T ~string](query url.Values, key string) []T {
+	var enums []T
+	for _, value := range query[key] {
+		enums = append(enums, T(value))
+	}
+	return enums
+}
+
+// queryDateRanges reads the submittedAfter, submittedBefore, updatedAfter and updatedBefore parameters, which are either RFC 3339
+// timestamps or dates
+func queryDateRanges(query url.Values) (submittedAfter, submittedBefore, updatedAfter, updatedBefore *time.Time, err error) {
+	ranges := []struct {
+		key   string
+		value **time.Time
+	}{
+		{"submittedAfter", &submittedAfter},
+		{"submittedBefore", &submittedBefore},
+		{"updatedAfter", &updatedAfter},
+		{"updatedBefore", &updatedBefore},
+	}
+
+	for _, dateRange := range ranges {
+		value := query.Get(dateRange.key)
+		if value == "" {
+			continue
+		}
+
+		parsed, parseErr := time.Parse(time.RFC3339, value)
+		if parseErr != nil {
+			parsed, parseErr = time.Parse(time.DateOnly, value)
+		}
+		if parseErr != nil {
+			return nil, nil, nil, nil, &apperrors.BadRequestError{Err: fmt.Errorf("invalid %s %q: must be a date or RFC 3339 time", dateRange.key, value)}
+		}
+		*dateRange.value = &parsed
+	}
+
+	return submittedAfter, submittedBefore, updatedAfter, updatedBefore, nil
+}
+
+func querySortDirection(query url.Values, defaultDirection models.SortDirection) (models.SortDirection, error) {
+	value := query.Get("sortDirection")
+	if value == "" {
+		return defaultDirection, nil
+	}
+
+	direction := models.SortDirection(value)
+	if !direction.IsValid() {
+		return defaultDirection, &apperrors.BadRequestError{Err: fmt.Errorf("invalid sortDirection %q", value)}
+	}
+	return direction, nil
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/export"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func newMockExportSystemIntakes(
+	filters *[]models.SystemIntakesFilter,
+	sorts *[]models.SystemIntakesSort,
+) func(ctx context.Context, filter models.SystemIntakesFilter, sort models.SystemIntakesSort, w export.Writer) error {
+	return func(ctx context.Context, filter models.SystemIntakesFilter, sort models.SystemIntakesSort, w export.Writer) error {
+		*filters = append(*filters, filter)
+		*sorts = append(*sorts, sort)
+		if err := w.WriteRow("Project name", "Cost"); err != nil {
+			return err
+		}
+		return w.WriteRow("Test project", 100)
+	}
+}
+
+func newMockExportTRBRequests(
+	filters *[]models.TRBRequestsFilter,
+) func(ctx context.Context, filter models.TRBRequestsFilter, sort models.TRBRequestsSort, w export.Writer) error {
+	return func(ctx context.Context, filter models.TRBRequestsFilter, sort models.TRBRequestsSort, w export.Writer) error {
+		*filters = append(*filters, filter)
+		return w.WriteRow("Name")
+	}
+}
+
+func (s *HandlerTestSuite) TestExportHandler() {
+	grtContext := appcontext.WithPrincipal(context.Background(), &authentication.EUAPrincipal{EUAID: "ABCD", JobCodeEASi: true, JobCodeGRT: true})
+	trbAdminContext := appcontext.WithPrincipal(context.Background(), &authentication.EUAPrincipal{EUAID: "TRBA", JobCodeEASi: true, JobCodeTRBAdmin: true})
+	requesterContext := appcontext.WithPrincipal(context.Background(), &authentication.EUAPrincipal{EUAID: "USR1", JobCodeEASi: true})
+
+	var intakeFilters []models.SystemIntakesFilter
+	var intakeSorts []models.SystemIntakesSort
+	var trbFilters []models.TRBRequestsFilter
+	handler := NewExportHandler(
+		s.base,
+		newMockExportSystemIntakes(&intakeFilters, &intakeSorts),
+		newMockExportSystemIntakes(&intakeFilters, &intakeSorts),
+		newMockExportSystemIntakes(&intakeFilters, &intakeSorts),
+		newMockExportTRBRequests(&trbFilters),
+	).Handle()
+
+	serve := func(ctx context.Context, method string, exportName string, query string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(ctx, method, "/api/v1/exports/"+exportName+query, nil)
+		s.NoError(err)
+		req = mux.SetURLVars(req, map[string]string{"export_name": exportName})
+		handler(rr, req)
+		return rr
+	}
+
+	s.Run("streams system intakes as CSV with the admin table filters", func() {
+		intakeFilters, intakeSorts = nil, nil
+
+		rr := serve(grtContext, http.MethodGet, "system_intakes",
+			"?adminStatuses=READY_FOR_GRT&adminStatuses=READY_FOR_GRB&requestTypes=NEW&adminLead=Ann+Rudolph&submittedAfter=2024-01-02&sortField=UPDATED_AT&sortDirection=ASC",
+		)
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+		s.Contains(rr.Header().Get("Content-Disposition"), `attachment; filename="system_intakes_`)
+		s.Equal("Project name,Cost\nTest project,100\n", rr.Body.String())
+
+		s.Len(intakeFilters, 1)
+		s.Equal([]models.SystemIntakeStatusAdmin{"READY_FOR_GRT", "READY_FOR_GRB"}, intakeFilters[0].AdminStatuses)
+		s.Equal([]models.SystemIntakeRequestType{models.SystemIntakeRequestTypeNEW}, intakeFilters[0].RequestTypes)
+		s.Equal("Ann Rudolph", *intakeFilters[0].AdminLead)
+		s.Equal(time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC), *intakeFilters[0].SubmittedAfter)
+		s.Nil(intakeFilters[0].State)
+		s.Nil(intakeFilters[0].Search)
+		s.Equal(models.SystemIntakesSort{Field: models.SystemIntakesSortFieldUpdatedAt, Direction: models.SortDirectionAsc}, intakeSorts[0])
+	})
+
+	s.Run("streams lifecycle costs as XLSX sorted by default", func() {
+		intakeFilters, intakeSorts = nil, nil
+
+		rr := serve(grtContext, http.MethodGet, "lifecycle_costs", "?format=xlsx")
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal(export.FormatXLSX.ContentType(), rr.Header().Get("Content-Type"))
+		s.Contains(rr.Header().Get("Content-Disposition"), ".xlsx")
+		// XLSX files are zip archives
+		s.Equal("PK", rr.Body.String()[:2])
+		s.Equal(models.SystemIntakesSort{Field: models.SystemIntakesSortFieldSubmittedAt, Direction: models.SortDirectionDesc}, intakeSorts[0])
+	})
+
+	s.Run("streams TRB requests for TRB admins", func() {
+		trbFilters = nil
+
+		rr := serve(trbAdminContext, http.MethodGet, "trb_requests", "?archived=true&statuses=CONSULT_SCHEDULED")
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("Name\n", rr.Body.String())
+		s.Len(trbFilters, 1)
+		s.True(trbFilters[0].Archived)
+		s.Equal([]models.TRBRequestStatus{models.TRBRequestStatusConsultScheduled}, trbFilters[0].Statuses)
+	})
+
+	s.Run("only allows governance admins to export system intakes", func() {
+		s.Equal(http.StatusUnauthorized, serve(trbAdminContext, http.MethodGet, "business_cases", "").Code)
+		s.Equal(http.StatusUnauthorized, serve(requesterContext, http.MethodGet, "system_intakes", "").Code)
+	})
+
+	s.Run("only allows TRB admins to export TRB requests", func() {
+		s.Equal(http.StatusUnauthorized, serve(grtContext, http.MethodGet, "trb_requests", "").Code)
+	})
+
+	s.Run("rejects invalid parameters", func() {
+		s.Equal(http.StatusBadRequest, serve(grtContext, http.MethodGet, "system_intakes", "?format=pdf").Code)
+		s.Equal(http.StatusBadRequest, serve(grtContext, http.MethodGet, "system_intakes", "?sortField=NAME").Code)
+		s.Equal(http.StatusBadRequest, serve(grtContext, http.MethodGet, "system_intakes", "?sortDirection=UP").Code)
+		s.Equal(http.StatusBadRequest, serve(grtContext, http.MethodGet, "system_intakes", "?updatedBefore=yesterday").Code)
+		s.Equal(http.StatusBadRequest, serve(trbAdminContext, http.MethodGet, "trb_requests", "?archived=maybe").Code)
+	})
+
+	s.Run("returns not found for unknown exports", func() {
+		s.Equal(http.StatusNotFound, serve(grtContext, http.MethodGet, "users", "").Code)
+	})
+
+	s.Run("only allows GET", func() {
+		s.Equal(http.StatusMethodNotAllowed, serve(grtContext, http.MethodPost, "system_intakes", "").Code)
+	})
+}
@@ -8,10 +8,11 @@ import (
 )
 
 // NewHealthCheckHandler is a constructor for HealthCheckHandler
-func NewHealthCheckHandler(base HandlerBase, config *viper.Viper) HealthCheckHandler {
+func NewHealthCheckHandler(base HandlerBase, config *viper.Viper, dependencies map[string]func() error) HealthCheckHandler {
 	return HealthCheckHandler{
-		HandlerBase: base,
-		Config:      config,
+		HandlerBase:  base,
+		Config:       config,
+		Dependencies: dependencies,
 	}
 }
 
@@ -19,19 +20,29 @@ func NewHealthCheckHandler(base HandlerBase, config *viper.Viper) HealthCheckHan
 type HealthCheckHandler struct {
 	HandlerBase
 	Config *viper.Viper
+	// Dependencies check the services the API depends on, by name. A failing check reports the API as degraded rather than down,
+	// since the API can still serve anything that doesn't need that service.
+	Dependencies map[string]func() error
 }
 
 type status string
 
 const (
 	statusPass status = "pass"
+	statusWarn status = "warn"
 )
 
 type healthCheck struct {
-	Status    status `json:"status"`
-	Datetime  string `json:"datetime"`
-	Version   string `json:"version"`
-	Timestamp string `json:"timestamp"`
+	Status    status                     `json:"status"`
+	Datetime  string                     `json:"datetime"`
+	Version   string                     `json:"version"`
+	Timestamp string                     `json:"timestamp"`
+	Checks    map[string]dependencyCheck `json:"checks,omitempty"`
+}
+
+type dependencyCheck struct {
+	Status status `json:"status"`
+	Output string `json:"output,omitempty"`
 }
 
 // Handle handles a web request and returns a healthcheck JSON payload
@@ -43,6 +54,19 @@ func (h HealthCheckHandler) Handle() http.HandlerFunc {
 			Datetime:  h.Config.GetString("APPLICATION_DATETIME"),
 			Timestamp: h.Config.GetString("APPLICATION_TS"),
 		}
+		for name, check := range h.Dependencies {
+			if statusReport.Checks == nil {
+				statusReport.Checks = map[string]dependencyCheck{}
+			}
+
+			if err := check(); err != nil {
+				statusReport.Status = statusWarn
+				statusReport.Checks[name] = dependencyCheck{Status: statusWarn, Output: err.Error()}
+				continue
+			}
+			statusReport.Checks[name] = dependencyCheck{Status: statusPass}
+		}
+
 		js, err := json.Marshal(statusReport)
 		if err != nil {
 			h.WriteErrorResponse(r.Context(), w, err)
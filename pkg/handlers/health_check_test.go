@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 
@@ -33,3 +34,28 @@ func (s *HandlerTestSuite) TestHealthcheckHandler() {
 	s.Equal("mockversion", healthCheckActual.Version)
 	s.Equal("mocktimestamp", healthCheckActual.Timestamp)
 }
+
+func (s *HandlerTestSuite) TestHealthcheckHandlerReportsDegradedDependencies() {
+	rr := httptest.NewRecorder()
+
+	healthCheckHandler := NewHealthCheckHandler(s.base, viper.New(), map[string]func() error{
+		"healthy": func() error {
+			return nil
+		},
+		"degraded": func() error {
+			return errors.New("circuit breaker is open")
+		},
+	})
+	healthCheckHandler.Handle()(rr, nil)
+
+	// a degraded dependency doesn't take the API down
+	s.Equal(http.StatusOK, rr.Code)
+
+	var healthCheckActual healthCheck
+	err := json.Unmarshal(rr.Body.Bytes(), &healthCheckActual)
+
+	s.NoError(err)
+	s.Equal(statusWarn, healthCheckActual.Status)
+	s.Equal(dependencyCheck{Status: statusPass}, healthCheckActual.Checks["healthy"])
+	s.Equal(dependencyCheck{Status: statusWarn, Output: "circuit breaker is open"}, healthCheckActual.Checks["degraded"])
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/appses"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// maxSNSMessageSize limits how much of a request is read. SNS messages are at most 256 KB
+const maxSNSMessageSize = 256 << 10
+
+type snsVerifier interface {
+	Verify(ctx context.Context, message *appses.SNSMessage) error
+	ConfirmSubscription(ctx context.Context, message *appses.SNSMessage) error
+}
+
+type recordEmailDeliveryEvents func(ctx context.Context, events []*models.EmailDeliveryEvent) (int, error)
+
+// NewSESNotificationHandler is a constructor for SESNotificationHandler
+func NewSESNotificationHandler(
+	base HandlerBase,
+	verifier snsVerifier,
+	record recordEmailDeliveryEvents,
+) SESNotificationHandler {
+	return SESNotificationHandler{
+		HandlerBase:               base,
+		Verifier:                  verifier,
+		RecordEmailDeliveryEvents: record,
+	}
+}
+
+// SESNotificationHandler is the handler for the SES delivery, bounce, and complaint notifications SNS posts to EASi
+type SESNotificationHandler struct {
+	HandlerBase
+	Verifier                  snsVerifier
+	RecordEmailDeliveryEvents recordEmailDeliveryEvents
+}
+
+// Handle handles a message from SNS. Messages are only acted on once they're verified as coming from EASi's SES notification topic
+func (h SESNotificationHandler) Handle() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if r.Method != http.MethodPost {
+			h.WriteErrorResponse(ctx, w, &apperrors.MethodNotAllowedError{Method: r.Method})
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxSNSMessageSize))
+		if err != nil {
+			h.WriteErrorResponse(ctx, w, &apperrors.BadRequestError{Err: err})
+			return
+		}
+
+		var message appses.SNSMessage
+		if err := json.Unmarshal(body, &message); err != nil {
+			h.WriteErrorResponse(ctx, w, &apperrors.BadRequestError{Err: err})
+			return
+		}
+
+		if err := h.Verifier.Verify(ctx, &message); err != nil {
+			if errors.Is(err, appses.ErrInvalidSNSMessage) {
+				h.WriteErrorResponse(ctx, w, &apperrors.UnauthorizedError{Err: err})
+				return
+			}
+			h.WriteErrorResponse(ctx, w, err)
+			return
+		}
+
+		logger := appcontext.ZLogger(ctx).With(zap.String("snsMessageID", message.MessageID))
+		switch message.Type {
+		case appses.SNSMessageTypeSubscriptionConfirmation:
+			if err := h.Verifier.ConfirmSubscription(ctx, &message); err != nil {
+				h.WriteErrorResponse(ctx, w, err)
+				return
+			}
+			logger.Info("confirmed subscription to SES notification topic", zap.String("topicARN", message.TopicARN))
+		case appses.SNSMessageTypeNotification:
+			events, err := appses.ParseSESNotification(message.MessageID, message.Message)
+			if err != nil {
+				h.WriteErrorResponse(ctx, w, &apperrors.BadRequestError{Err: err})
+				return
+			}
+
+			recorded, err := h.RecordEmailDeliveryEvents(ctx, events)
+			if err != nil {
+				h.WriteErrorResponse(ctx, w, err)
+				return
+			}
+			logger.Info("recorded SES delivery notification", zap.Int("recorded", recorded), zap.Int("events", len(events)))
+		default:
+			logger.Warn("ignoring SNS message", zap.String("type", message.Type))
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
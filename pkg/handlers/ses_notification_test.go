@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/cms-enterprise/easi-app/pkg/appses"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// stubSNSVerifier accepts every message, unless it's set to reject them
+type stubSNSVerifier struct {
+	verifyErr     error
+	confirmations int
+}
+
+func (v *stubSNSVerifier) Verify(ctx context.Context, message *appses.SNSMessage) error {
+	return v.verifyErr
+}
+
+func (v *stubSNSVerifier) ConfirmSubscription(ctx context.Context, message *appses.SNSMessage) error {
+	v.confirmations++
+	return nil
+}
+
+func (s *HandlerTestSuite) TestSESNotificationHandler() {
+	bounce, err := json.Marshal(appses.SNSMessage{
+		Type:      appses.SNSMessageTypeNotification,
+		MessageID: "notification-id",
+		Message: `{"notificationType":"Bounce","mail":{"messageId":"message-id"},"bounce":{"bounceType":"Permanent",` +
+			`"timestamp":"2025-01-06T14:00:02.000Z","bouncedRecipients":[{"emailAddress":"rock.lee@cms.fake"}]}}`,
+	})
+	s.NoError(err)
+
+	serve := func(handler SESNotificationHandler, method string, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/api/v1/email/notifications", strings.NewReader(body))
+		handler.Handle()(rr, req)
+		return rr
+	}
+
+	s.Run("records the events in a verified notification", func() {
+		var recorded []*models.EmailDeliveryEvent
+		handler := NewSESNotificationHandler(s.base, &stubSNSVerifier{}, func(ctx context.Context, events []*models.EmailDeliveryEvent) (int, error) {
+			recorded = events
+			return len(events), nil
+		})
+
+		rr := serve(handler, http.MethodPost, string(bounce))
+		s.Equal(http.StatusOK, rr.Code)
+		if s.Len(recorded, 1) {
+			s.Equal("notification-id", recorded[0].NotificationID)
+			s.Equal("message-id", recorded[0].ProviderMessageID)
+			s.Equal(models.EmailDeliveryEventTypeBounce, recorded[0].EventType)
+		}
+	})
+
+	s.Run("rejects messages that can't be verified", func() {
+		handler := NewSESNotificationHandler(s.base, &stubSNSVerifier{verifyErr: fmt.Errorf("%w: bad signature", appses.ErrInvalidSNSMessage)}, func(ctx context.Context, events []*models.EmailDeliveryEvent) (int, error) {
+			s.Fail("events from an unverified message should not be recorded")
+			return 0, nil
+		})
+
+		rr := serve(handler, http.MethodPost, string(bounce))
+		s.Equal(http.StatusUnauthorized, rr.Code)
+	})
+
+	s.Run("fails so SNS retries when events can't be recorded", func() {
+		handler := NewSESNotificationHandler(s.base, &stubSNSVerifier{}, func(ctx context.Context, events []*models.EmailDeliveryEvent) (int, error) {
+			return 0, errors.New("database unavailable")
+		})
+
+		rr := serve(handler, http.MethodPost, string(bounce))
+		s.Equal(http.StatusInternalServerError, rr.Code)
+	})
+
+	s.Run("confirms subscriptions", func() {
+		verifier := &stubSNSVerifier{}
+		handler := NewSESNotificationHandler(s.base, verifier, nil)
+
+		rr := serve(handler, http.MethodPost, `{"Type":"SubscriptionConfirmation","SubscribeURL":"https://sns.us-east-1.amazonaws.com/"}`)
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal(1, verifier.confirmations)
+	})
+
+	s.Run("rejects malformed requests", func() {
+		handler := NewSESNotificationHandler(s.base, &stubSNSVerifier{}, nil)
+
+		s.Equal(http.StatusBadRequest, serve(handler, http.MethodPost, "not json").Code)
+		s.Equal(http.StatusMethodNotAllowed, serve(handler, http.MethodGet, "").Code)
+	})
+}
@@ -0,0 +1,168 @@
+// Package htmldiff compares two versions of rich text, returning the newer version with the words that changed marked up
+package htmldiff
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cms-enterprise/easi-app/pkg/sanitization"
+)
+
+// maxComparisons limits the work done comparing two versions. Versions that differ in more places than this are
+// shown as the older version removed and the newer version added, instead of being compared word by word
+const maxComparisons = 4_000_000
+
+// tokenPattern splits HTML into tags, entities, words, whitespace, and other single characters
+var tokenPattern = regexp.MustCompile(`<[^>]*>|&[#a-zA-Z0-9]+;|[\p{L}\p{N}_'’-]+|\s+|.`)
+
+type operation int
+
+const (
+	equal operation = iota
+	insert
+	remove
+)
+
+type edit struct {
+	op    operation
+	token string
+}
+
+// Diff returns to, with the words added since from wrapped in <ins> and the words removed wrapped in <del>.
+// Tags are taken from to, so removed text takes on the formatting around where it was removed. The result is sanitized
+func Diff(from string, to string) string {
+	var builder strings.Builder
+	var open operation = equal
+
+	closeGroup := func() {
+		switch open {
+		case insert:
+			builder.WriteString("</ins>")
+		case remove:
+			builder.WriteString("</del>")
+		}
+		open = equal
+	}
+
+	for _, e := range diffTokens(tokenize(from), tokenize(to)) {
+		if e.op == equal || isTag(e.token) {
+			closeGroup()
+			// removed tags are left out, since their text is shown within the newer version's tags
+			if e.op != remove {
+				builder.WriteString(e.token)
+			}
+			continue
+		}
+
+		if e.op != open {
+			closeGroup()
+			// changed whitespace doesn't need to be marked
+			if strings.TrimSpace(e.token) == "" {
+				if e.op == insert {
+					builder.WriteString(e.token)
+				}
+				continue
+			}
+			if e.op == insert {
+				builder.WriteString("<ins>")
+			} else {
+				builder.WriteString("<del>")
+			}
+			open = e.op
+		}
+		builder.WriteString(e.token)
+	}
+	closeGroup()
+
+	return sanitization.SanitizeHTMLDiff(builder.String())
+}
+
+// Changed reports whether two versions of rich text differ in more than whitespace
+func Changed(from string, to string) bool {
+	return strings.Join(strings.Fields(from), " ") != strings.Join(strings.Fields(to), " ")
+}
+
+func tokenize(html string) []string {
+	return tokenPattern.FindAllString(html, -1)
+}
+
+func isTag(token string) bool {
+	return strings.HasPrefix(token, "<")
+}
+
+// diffTokens returns the edits that turn from into to, using the longest common subsequence of their tokens
+func diffTokens(from []string, to []string) []edit {
+	// the unchanged start and end of the versions don't need to be compared
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix && from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]edit, 0, len(from)+len(to))
+	for _, token := range from[:prefix] {
+		edits = append(edits, edit{op: equal, token: token})
+	}
+
+	changedFrom := from[prefix : len(from)-suffix]
+	changedTo := to[prefix : len(to)-suffix]
+	if len(changedFrom)*len(changedTo) > maxComparisons {
+		for _, token := range changedFrom {
+			edits = append(edits, edit{op: remove, token: token})
+		}
+		for _, token := range changedTo {
+			edits = append(edits, edit{op: insert, token: token})
+		}
+	} else {
+		edits = append(edits, longestCommonSubsequenceEdits(changedFrom, changedTo)...)
+	}
+
+	for _, token := range from[len(from)-suffix:] {
+		edits = append(edits, edit{op: equal, token: token})
+	}
+	return edits
+}
+
+func longestCommonSubsequenceEdits(from []string, to []string) []edit {
+	// lengths[i][j] is the length of the longest common subsequence of from[i:] and to[j:]
+	lengths := make([][]int32, len(from)+1)
+	for i := range lengths {
+		lengths[i] = make([]int32, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	edits := make([]edit, 0, len(from)+len(to))
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			edits = append(edits, edit{op: equal, token: from[i]})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			edits = append(edits, edit{op: remove, token: from[i]})
+			i++
+		default:
+			edits = append(edits, edit{op: insert, token: to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		edits = append(edits, edit{op: remove, token: from[i]})
+	}
+	for ; j < len(to); j++ {
+		edits = append(edits, edit{op: insert, token: to[j]})
+	}
+	return edits
+}
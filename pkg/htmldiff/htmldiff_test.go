@@ -0,0 +1,74 @@
+package htmldiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		testCase string
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			testCase: "unchanged text isn't marked",
+			from:     `<p>Use the <strong>enterprise</strong> cloud</p>`,
+			to:       `<p>Use the <strong>enterprise</strong> cloud</p>`,
+			expected: `<p>Use the <strong>enterprise</strong> cloud</p>`,
+		},
+		{
+			testCase: "changed words are marked as removed and added",
+			from:     `<p>Migrate to AWS by March</p>`,
+			to:       `<p>Migrate to Azure by June</p>`,
+			expected: `<p>Migrate to <del>AWS</del><ins>Azure</ins> by <del>March</del><ins>June</ins></p>`,
+		},
+		{
+			testCase: "added text is marked within the new formatting",
+			from:     `<p>Review the ATO</p>`,
+			to:       `<p>Review the ATO</p><ul><li>Update the SSP</li></ul>`,
+			expected: `<p>Review the ATO</p><ul><li><ins>Update the SSP</ins></li></ul>`,
+		},
+		{
+			testCase: "removed text keeps its place without its old tags",
+			from:     `<p>Keep this</p><p>Remove this</p>`,
+			to:       `<p>Keep this</p>`,
+			expected: `<p>Keep this</p><del>Remove this</del>`,
+		},
+		{
+			testCase: "text added to an empty section is all marked",
+			from:     ``,
+			to:       `<p>New summary</p>`,
+			expected: `<p><ins>New summary</ins></p>`,
+		},
+		{
+			testCase: "the result is sanitized",
+			from:     `<p>Hello</p>`,
+			to:       `<p>Hello <script>alert(1)</script>world</p>`,
+			expected: `<p>Hello <ins>world</ins></p>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testCase, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Diff(tc.from, tc.to))
+		})
+	}
+
+	t.Run("large rewrites are shown as the old text removed and new text added", func(t *testing.T) {
+		from := "<p>" + strings.Repeat("old ", 2500) + "</p>"
+		to := "<p>" + strings.Repeat("new ", 2500) + "</p>"
+		diff := Diff(from, to)
+		assert.True(t, strings.HasPrefix(diff, "<p><del>old old"))
+		assert.Contains(t, diff, "</del><ins>new new")
+	})
+}
+
+func TestChanged(t *testing.T) {
+	assert.False(t, Changed("<p>Same  text</p>", "<p>Same text</p>"))
+	assert.True(t, Changed("<p>Same text</p>", "<p>Other text</p>"))
+	assert.True(t, Changed("", "<p>New</p>"))
+}
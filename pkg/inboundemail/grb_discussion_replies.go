@@ -0,0 +1,173 @@
+package inboundemail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/dataloaders"
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// maxInboundEmailSize limits how much of a received email is read. Replies are short, but can carry large attachments we don't need
+const maxInboundEmailSize = 25 << 20
+
+// errDiscardEmail marks a received email that can never be posted, so it's removed from the mailbox instead of being retried
+var errDiscardEmail = errors.New("discarding inbound email")
+
+// GRBDiscussionReplyProcessor posts replies to GRB discussion emails to the discussions they were sent from
+type GRBDiscussionReplyProcessor struct {
+	mailbox                Mailbox
+	emailClient            *email.Client
+	buildDataloaders       dataloaders.BuildDataloaders
+	getUserAccountsByEmail func(ctx context.Context, email string) ([]*authentication.UserAccount, error)
+	getDiscussionPost      func(ctx context.Context, id uuid.UUID) (*models.SystemIntakeGRBReviewDiscussionPost, error)
+	createReply            func(ctx context.Context, input models.CreateSystemIntakeGRBDiscussionReplyInput) (*models.SystemIntakeGRBReviewDiscussionPost, error)
+}
+
+// NewGRBDiscussionReplyProcessor returns a processor for the emails in mailbox. Replies are posted with createReply,
+// which should be the same code path replies made in EASi are posted through, so they're authorized and notified the same way
+func NewGRBDiscussionReplyProcessor(
+	mailbox Mailbox,
+	emailClient *email.Client,
+	buildDataloaders dataloaders.BuildDataloaders,
+	getUserAccountsByEmail func(ctx context.Context, email string) ([]*authentication.UserAccount, error),
+	getDiscussionPost func(ctx context.Context, id uuid.UUID) (*models.SystemIntakeGRBReviewDiscussionPost, error),
+	createReply func(ctx context.Context, input models.CreateSystemIntakeGRBDiscussionReplyInput) (*models.SystemIntakeGRBReviewDiscussionPost, error),
+) *GRBDiscussionReplyProcessor {
+	return &GRBDiscussionReplyProcessor{
+		mailbox:                mailbox,
+		emailClient:            emailClient,
+		buildDataloaders:       buildDataloaders,
+		getUserAccountsByEmail: getUserAccountsByEmail,
+		getDiscussionPost:      getDiscussionPost,
+		createReply:            createReply,
+	}
+}
+
+// ProcessMailbox posts every reply in the mailbox to its discussion. Emails that can't be posted, because their Reply-To address
+// isn't valid for their sender, their sender can't reply to the discussion, or they have no reply text, are logged and removed.
+// Emails that fail because of a problem looking up their sender or discussion are left in the mailbox to try again
+func (p *GRBDiscussionReplyProcessor) ProcessMailbox(ctx context.Context) error {
+	keys, err := p.mailbox.List(ctx)
+	if err != nil {
+		return fmt.Errorf("problem listing inbound emails: %w", err)
+	}
+
+	for _, key := range keys {
+		logger := appcontext.ZLogger(ctx).With(zap.String("inboundEmailKey", key))
+
+		post, err := p.processEmail(appcontext.WithLogger(ctx, logger), key, time.Now())
+		switch {
+		case errors.Is(err, errDiscardEmail):
+			logger.Warn("GRB discussion reply email could not be posted", zap.Error(err))
+		case err != nil:
+			logger.Error("problem processing GRB discussion reply email, it will be retried", zap.Error(err))
+			continue
+		default:
+			logger.Info("posted GRB discussion reply from email", zap.String("discussionPostID", post.ID.String()))
+		}
+
+		if err := p.mailbox.Delete(ctx, key); err != nil {
+			logger.Error("problem removing processed inbound email", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// processEmail posts the reply in one received email. It returns an error wrapping errDiscardEmail if the email can never be posted
+func (p *GRBDiscussionReplyProcessor) processEmail(ctx context.Context, key string, now time.Time) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
+	raw, err := p.mailbox.Read(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("problem reading inbound email: %w", err)
+	}
+	defer raw.Close()
+
+	reply, err := email.ParseInboundReply(io.LimitReader(raw, maxInboundEmailSize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errDiscardEmail, err)
+	}
+
+	discussionID, err := p.discussionRepliedTo(reply, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errDiscardEmail, err)
+	}
+
+	accounts, err := p.getUserAccountsByEmail(ctx, reply.From.String())
+	if err != nil {
+		return nil, fmt.Errorf("problem getting user account for inbound email sender: %w", err)
+	}
+	// an email address shared by more than one account can't tell us who sent the reply
+	if len(accounts) != 1 {
+		return nil, fmt.Errorf("%w: found %d user accounts for the sender's email address", errDiscardEmail, len(accounts))
+	}
+	account := accounts[0]
+
+	discussion, err := p.getDiscussionPost(ctx, discussionID)
+	if err != nil {
+		return nil, fmt.Errorf("problem getting discussion replied to by email: %w", err)
+	}
+	if discussion == nil || discussion.DiscussionBoardType == nil {
+		return nil, fmt.Errorf("%w: discussion %s not found", errDiscardEmail, discussionID)
+	}
+
+	content, err := models.NewTaggedHTMLFromString(string(reply.Content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errDiscardEmail, err)
+	}
+
+	// the reply is posted as its sender, as if they'd replied in EASi. Job codes come from a user's Okta session, which we don't have here,
+	// so replies are authorized by the sender's GRB reviewer and requester roles; governance admins who aren't reviewers can't reply by email
+	replyCtx := appcontext.WithPrincipal(ctx, &authentication.EUAPrincipal{
+		EUAID:       account.Username,
+		JobCodeEASi: true,
+		UserAccount: account,
+	})
+	replyCtx = dataloaders.CTXWithLoaders(replyCtx, p.buildDataloaders)
+
+	post, err := p.createReply(replyCtx, models.CreateSystemIntakeGRBDiscussionReplyInput{
+		InitialPostID:       discussion.ID,
+		DiscussionBoardType: *discussion.DiscussionBoardType,
+		Content:             content,
+	})
+	if err != nil {
+		// replies are rejected for reasons that won't change on a retry, such as the sender no longer being a reviewer, or the request leaving the GRB meeting step
+		return nil, fmt.Errorf("%w: %w", errDiscardEmail, err)
+	}
+	return post, nil
+}
+
+// discussionRepliedTo returns the discussion identified by whichever of a reply's recipients is a valid Reply-To address for its sender
+func (p *GRBDiscussionReplyProcessor) discussionRepliedTo(reply *email.InboundReply, now time.Time) (uuid.UUID, error) {
+	for _, recipient := range reply.Recipients {
+		discussionID, err := p.emailClient.VerifyDiscussionReplyAddress(recipient, reply.From, now)
+		if err == nil {
+			return discussionID, nil
+		}
+	}
+	return uuid.Nil, email.ErrInvalidDiscussionReplyAddress
+}
+
+// StartGRBDiscussionReplyCheck starts a goroutine that processes the emails in the processor's mailbox once immediately,
+// then again at an interval specified by checkInterval. It returns no errors, and only logs when something goes wrong
+func StartGRBDiscussionReplyCheck(ctx context.Context, processor *GRBDiscussionReplyProcessor, checkInterval time.Duration) {
+	ticker := time.NewTicker(checkInterval)
+	go func(ctx context.Context) {
+		for {
+			if err := processor.ProcessMailbox(ctx); err != nil {
+				appcontext.ZLogger(ctx).Error("Failed to process GRB discussion reply emails", zap.Error(err))
+			}
+
+			// Wait for the ticker. This will block the current goroutine until the ticker sends a message over the channel
+			<-ticker.C
+		}
+	}(ctx)
+}
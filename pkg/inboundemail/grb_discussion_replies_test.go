@@ -0,0 +1,190 @@
+package inboundemail
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appconfig"
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/dataloaders"
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/testhelpers"
+)
+
+// memoryMailbox is a Mailbox of emails held in memory
+type memoryMailbox map[string]string
+
+func (m memoryMailbox) List(ctx context.Context) ([]string, error) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (m memoryMailbox) Read(ctx context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(m[key])), nil
+}
+
+func (m memoryMailbox) Delete(ctx context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+// capturingSender keeps the last email sent with it
+type capturingSender struct {
+	email email.Email
+}
+
+func (s *capturingSender) Send(ctx context.Context, sent email.Email) error {
+	s.email = sent
+	return nil
+}
+
+func TestGRBDiscussionReplyProcessor(t *testing.T) {
+	ctx := appcontext.WithLogger(context.Background(), zap.NewNop())
+	config := testhelpers.NewConfig()
+
+	sender := &capturingSender{}
+	emailClient, err := email.NewClient(email.Config{
+		GRTEmail:          models.NewEmailAddress("grt_email@cms.fake"),
+		URLHost:           config.GetString(appconfig.ClientHostKey),
+		URLScheme:         config.GetString(appconfig.ClientProtocolKey),
+		TemplateDirectory: config.GetString(appconfig.EmailTemplateDirectoryKey),
+		ReplyToAddress:    models.NewEmailAddress("reply@inbound.cms.fake"),
+		ReplyTokenSecret:  "test-reply-token-secret",
+	}, sender)
+	assert.NoError(t, err)
+
+	reviewer := &authentication.UserAccount{
+		ID:       uuid.New(),
+		Username: "ABCD",
+		Email:    "reviewer@cms.fake",
+	}
+	boardType := models.SystemIntakeGRBDiscussionBoardTypeInternal
+	discussion := &models.SystemIntakeGRBReviewDiscussionPost{DiscussionBoardType: &boardType}
+	discussion.ID = uuid.New()
+
+	// a real reply email gives the reviewer a Reply-To address for the discussion
+	err = emailClient.SystemIntake.SendGRBReviewDiscussionReplyEmail(ctx, email.SendGRBReviewDiscussionReplyEmailInput{
+		SystemIntakeID: uuid.New(),
+		DiscussionID:   discussion.ID,
+		Recipient:      models.NewEmailAddress(reviewer.Email),
+	})
+	assert.NoError(t, err)
+	replyToAddress := sender.email.ReplyToAddress
+	assert.NotEmpty(t, replyToAddress)
+
+	replyEmail := func(from string, to models.EmailAddress, text string) string {
+		return strings.Join([]string{
+			"From: " + from,
+			"To: " + to.String(),
+			"Subject: Re: discussion",
+			"",
+			text,
+			"",
+			"On Mon, Jan 6, 2025 at 9:00 AM EASi <no-reply@cms.fake> wrote:",
+			"> quoted discussion",
+		}, "\r\n")
+	}
+
+	type createdReply struct {
+		principal *authentication.UserAccount
+		input     models.CreateSystemIntakeGRBDiscussionReplyInput
+	}
+
+	newProcessor := func(mailbox Mailbox, created *[]createdReply, createErr error, lookupErr error) *GRBDiscussionReplyProcessor {
+		return NewGRBDiscussionReplyProcessor(
+			mailbox,
+			&emailClient,
+			func() *dataloaders.Dataloaders { return nil },
+			func(ctx context.Context, emailAddress string) ([]*authentication.UserAccount, error) {
+				if lookupErr != nil {
+					return nil, lookupErr
+				}
+				if strings.EqualFold(emailAddress, reviewer.Email) {
+					return []*authentication.UserAccount{reviewer}, nil
+				}
+				return nil, nil
+			},
+			func(ctx context.Context, id uuid.UUID) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
+				if id == discussion.ID {
+					return discussion, nil
+				}
+				return nil, nil
+			},
+			func(ctx context.Context, input models.CreateSystemIntakeGRBDiscussionReplyInput) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
+				if createErr != nil {
+					return nil, createErr
+				}
+				*created = append(*created, createdReply{
+					principal: appcontext.Principal(ctx).Account(),
+					input:     input,
+				})
+				return &models.SystemIntakeGRBReviewDiscussionPost{}, nil
+			},
+		)
+	}
+
+	t.Run("posts a reply as its sender and removes it from the mailbox", func(t *testing.T) {
+		mailbox := memoryMailbox{
+			"reply": replyEmail("Reviewer <REVIEWER@cms.fake>", replyToAddress, "Looks good to me"),
+		}
+		var created []createdReply
+
+		assert.NoError(t, newProcessor(mailbox, &created, nil, nil).ProcessMailbox(ctx))
+		assert.Empty(t, mailbox)
+		if assert.Len(t, created, 1) {
+			assert.Equal(t, reviewer, created[0].principal)
+			assert.Equal(t, discussion.ID, created[0].input.InitialPostID)
+			assert.Equal(t, boardType, created[0].input.DiscussionBoardType)
+			assert.Equal(t, models.HTML("<p>Looks good to me</p>"), created[0].input.Content.RawContent)
+		}
+	})
+
+	t.Run("discards replies that can't be posted", func(t *testing.T) {
+		mailbox := memoryMailbox{
+			"from someone else":  replyEmail("someone.else@cms.fake", replyToAddress, "Looks good to me"),
+			"not a reply":        replyEmail("reviewer@cms.fake", "reply@inbound.cms.fake", "Looks good to me"),
+			"only quoted text":   replyEmail("reviewer@cms.fake", replyToAddress, ""),
+			"not a valid email!": "not an email",
+		}
+		var created []createdReply
+
+		assert.NoError(t, newProcessor(mailbox, &created, nil, nil).ProcessMailbox(ctx))
+		assert.Empty(t, mailbox)
+		assert.Empty(t, created)
+	})
+
+	t.Run("discards replies the resolver rejects", func(t *testing.T) {
+		mailbox := memoryMailbox{
+			"reply": replyEmail("reviewer@cms.fake", replyToAddress, "Looks good to me"),
+		}
+		var created []createdReply
+
+		assert.NoError(t, newProcessor(mailbox, &created, errors.New("user is not authorized to create a discussion reply"), nil).ProcessMailbox(ctx))
+		assert.Empty(t, mailbox)
+	})
+
+	t.Run("keeps replies to retry when the sender can't be looked up", func(t *testing.T) {
+		mailbox := memoryMailbox{
+			"reply": replyEmail("reviewer@cms.fake", replyToAddress, "Looks good to me"),
+		}
+		var created []createdReply
+
+		assert.NoError(t, newProcessor(mailbox, &created, nil, errors.New("database unavailable")).ProcessMailbox(ctx))
+		assert.Len(t, mailbox, 1)
+		assert.Empty(t, created)
+	})
+}
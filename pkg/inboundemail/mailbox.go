@@ -0,0 +1,61 @@
+package inboundemail
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/cms-enterprise/easi-app/pkg/upload"
+)
+
+// Mailbox holds raw MIME emails that have been received and not yet processed
+type Mailbox interface {
+	// List returns the keys of the emails in the mailbox, oldest first
+	List(ctx context.Context) ([]string, error)
+	// Read returns the raw contents of an email. The caller must close it
+	Read(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes an email from the mailbox once it's been processed
+	Delete(ctx context.Context, key string) error
+}
+
+// S3Mailbox is a Mailbox of the emails an SES receipt rule stores in an S3 bucket
+type S3Mailbox struct {
+	client *upload.S3Client
+	prefix string
+}
+
+// NewS3Mailbox returns a Mailbox of the emails stored in client's bucket under prefix
+func NewS3Mailbox(client *upload.S3Client, prefix string) S3Mailbox {
+	return S3Mailbox{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// List returns the keys of the emails stored under the mailbox's prefix, oldest first
+func (m S3Mailbox) List(ctx context.Context) ([]string, error) {
+	keys, err := m.client.ListKeys(ctx, m.prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	emailKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		// SES writes a setup notification object when the receipt rule is created, which isn't an email
+		if strings.HasSuffix(key, "AMAZON_SES_SETUP_NOTIFICATION") {
+			continue
+		}
+		emailKeys = append(emailKeys, key)
+	}
+	return emailKeys, nil
+}
+
+// Read returns the raw contents of an email stored in the bucket
+func (m S3Mailbox) Read(ctx context.Context, key string) (io.ReadCloser, error) {
+	return m.client.DownloadFile(ctx, key)
+}
+
+// Delete removes an email from the bucket
+func (m S3Mailbox) Delete(ctx context.Context, key string) error {
+	return m.client.DeleteFile(ctx, key)
+}
@@ -1,13 +1,26 @@
 package cedarcoremock
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
 
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 )
 
+var (
+	budgetsMutex sync.Mutex
+	// budgetsBySystem holds the budgets of each mocked system that's been written to; the other systems have mockBudgets
+	budgetsBySystem = map[uuid.UUID][]*models.CedarBudget{}
+)
+
 var mockBudgets = []*models.CedarBudget{
 	{
 		FiscalYear:    zero.StringFrom("2023"),
@@ -36,7 +49,7 @@ var mockBudgets = []*models.CedarBudget{
 		Funding:       zero.StringFrom(""),
 		FundingID:     zero.StringFrom(""),
 		FundingSource: zero.StringFrom(""),
-		ID:            zero.StringFrom(""),
+		ID:            zero.StringFrom("9d2f7a5c-3b1e-4c8a-8f6d-2a4b6c8e0f13"),
 		Name:          zero.StringFrom(""),
 		ProjectID:     zero.StringFrom("12345"),
 		ProjectTitle:  zero.StringFrom(""),
@@ -44,7 +57,78 @@ var mockBudgets = []*models.CedarBudget{
 	},
 }
 
-// GetBudgets returns a mock Budget
-func GetBudgets() []*models.CedarBudget {
-	return mockBudgets
+// GetBudgets returns the mocked budgets for a system
+func GetBudgets(cedarSystemID uuid.UUID) []*models.CedarBudget {
+	budgetsMutex.Lock()
+	defer budgetsMutex.Unlock()
+	return systemBudgets(cedarSystemID)
+}
+
+// AddBudget adds a budget to a mocked system, giving it a new ID the way CEDAR does, and returns the added budget
+func AddBudget(cedarSystemID uuid.UUID, budget *models.CedarBudget) *models.CedarBudget {
+	budgetsMutex.Lock()
+	defer budgetsMutex.Unlock()
+
+	added := *budget
+	added.ID = zero.StringFrom(strings.ToLower(uuid.NewString()))
+	added.SystemID = &cedarSystemID
+
+	// budgets that have been returned may still be in use, so they're replaced rather than modified
+	budgetsBySystem[cedarSystemID] = append(slices.Clone(systemBudgets(cedarSystemID)), &added)
+	return &added
+}
+
+// UpdateBudget replaces a budget on a mocked system
+func UpdateBudget(cedarSystemID uuid.UUID, budget *models.CedarBudget) error {
+	budgetsMutex.Lock()
+	defer budgetsMutex.Unlock()
+
+	budgets := slices.Clone(systemBudgets(cedarSystemID))
+	i := slices.IndexFunc(budgets, func(existing *models.CedarBudget) bool {
+		return existing.ID.String == budget.ID.String
+	})
+	if i < 0 {
+		return noBudgetFoundError()
+	}
+
+	updated := *budget
+	budgets[i] = &updated
+	budgetsBySystem[cedarSystemID] = budgets
+	return nil
+}
+
+// DeleteBudgets removes budgets from a mocked system
+func DeleteBudgets(cedarSystemID uuid.UUID, budgetIDs []string) error {
+	budgetsMutex.Lock()
+	defer budgetsMutex.Unlock()
+
+	existing := systemBudgets(cedarSystemID)
+	remaining := lo.Reject(existing, func(budget *models.CedarBudget, _ int) bool {
+		return lo.Contains(budgetIDs, budget.ID.String)
+	})
+	if len(existing)-len(remaining) != len(lo.Uniq(budgetIDs)) {
+		return noBudgetFoundError()
+	}
+
+	budgetsBySystem[cedarSystemID] = remaining
+	return nil
+}
+
+// systemBudgets returns a system's budgets; budgetsMutex must be held
+func systemBudgets(cedarSystemID uuid.UUID) []*models.CedarBudget {
+	if budgets, ok := budgetsBySystem[cedarSystemID]; ok {
+		return budgets
+	}
+
+	budgets := []*models.CedarBudget{}
+	for i := range mockBudgets {
+		budget := *mockBudgets[i]
+		budget.SystemID = &cedarSystemID
+		budgets = append(budgets, &budget)
+	}
+	return budgets
+}
+
+func noBudgetFoundError() *apperrors.ResourceNotFoundError {
+	return &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no budget found"), Resource: models.CedarBudget{}}
 }
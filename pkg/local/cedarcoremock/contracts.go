@@ -1,16 +1,29 @@
 package cedarcoremock
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
 
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 )
 
+var (
+	contractsMutex sync.Mutex
+	// contractsBySystem holds the contracts of each mocked system that's been written to; the other systems have mockContracts
+	contractsBySystem = map[uuid.UUID][]*models.CedarContract{}
+)
+
 var mockContracts = []*models.CedarContract{
 	{
+		ID:              zero.StringFrom("{0A7C4F22-5B5E-4C1A-9D4B-3E1F0A2B6C01}"),
 		EndDate:         zero.TimeFrom(time.Now().AddDate(1, 0, 0)),
 		StartDate:       zero.TimeFrom(time.Now().AddDate(-1, 0, 0)),
 		ContractName:    zero.StringFrom("AB-12C-3456 / 12ABCD34E0001 Trendy Nano ABC"),
@@ -20,6 +33,7 @@ var mockContracts = []*models.CedarContract{
 		ServiceProvided: zero.StringFromPtr(nil),
 	},
 	{
+		ID:              zero.StringFrom("{0A7C4F22-5B5E-4C1A-9D4B-3E1F0A2B6C02}"),
 		EndDate:         zero.TimeFrom(time.Time{}),
 		StartDate:       zero.TimeFrom(time.Time{}),
 		ContractName:    zero.StringFrom("Cloud Arcade Cabinet & Pinball Services (Cloud ACAPS)"),
@@ -29,6 +43,7 @@ var mockContracts = []*models.CedarContract{
 		ServiceProvided: zero.StringFromPtr(nil),
 	},
 	{
+		ID:              zero.StringFrom("{0A7C4F22-5B5E-4C1A-9D4B-3E1F0A2B6C03}"),
 		EndDate:         zero.TimeFrom(time.Time{}),
 		StartDate:       zero.TimeFrom(time.Time{}),
 		ContractName:    zero.StringFrom("Mediocre Entertainment Division (MED)"),
@@ -38,6 +53,7 @@ var mockContracts = []*models.CedarContract{
 		ServiceProvided: zero.StringFrom("this is a mock data string"),
 	},
 	{
+		ID:              zero.StringFrom("{0A7C4F22-5B5E-4C1A-9D4B-3E1F0A2B6C04}"),
 		EndDate:         zero.TimeFrom(time.Now().AddDate(0, 3, 0)),
 		StartDate:       zero.TimeFrom(time.Now().AddDate(0, -6, 0)),
 		ContractName:    zero.StringFrom("Cool Products & Tools"),
@@ -47,6 +63,7 @@ var mockContracts = []*models.CedarContract{
 		ServiceProvided: zero.StringFromPtr(nil),
 	},
 	{
+		ID:              zero.StringFrom("{0A7C4F22-5B5E-4C1A-9D4B-3E1F0A2B6C05}"),
 		EndDate:         zero.TimeFrom(time.Time{}),
 		StartDate:       zero.TimeFrom(time.Time{}),
 		ContractName:    zero.StringFrom("Cautionary Security Occurences and Training (Cloud SecOops)"),
@@ -57,7 +74,69 @@ var mockContracts = []*models.CedarContract{
 	},
 }
 
+// GetContractsBySystem returns the mocked contracts for a system
 func GetContractsBySystem(cedarSystemID uuid.UUID) []*models.CedarContract {
+	contractsMutex.Lock()
+	defer contractsMutex.Unlock()
+	return systemContracts(cedarSystemID)
+}
+
+// AddContract adds a contract to a mocked system, giving it a new ID the way CEDAR does, and returns the added contract
+func AddContract(cedarSystemID uuid.UUID, contract *models.CedarContract) *models.CedarContract {
+	contractsMutex.Lock()
+	defer contractsMutex.Unlock()
+
+	added := *contract
+	added.ID = zero.StringFrom(fmt.Sprintf("{%s}", strings.ToUpper(uuid.NewString())))
+	added.SystemID = &cedarSystemID
+
+	// contracts that have been returned may still be in use, so they're replaced rather than modified
+	contractsBySystem[cedarSystemID] = append(slices.Clone(systemContracts(cedarSystemID)), &added)
+	return &added
+}
+
+// UpdateContract replaces a contract on a mocked system
+func UpdateContract(cedarSystemID uuid.UUID, contract *models.CedarContract) error {
+	contractsMutex.Lock()
+	defer contractsMutex.Unlock()
+
+	contracts := slices.Clone(systemContracts(cedarSystemID))
+	i := slices.IndexFunc(contracts, func(existing *models.CedarContract) bool {
+		return existing.ID.String == contract.ID.String
+	})
+	if i < 0 {
+		return noContractFoundError()
+	}
+
+	updated := *contract
+	contracts[i] = &updated
+	contractsBySystem[cedarSystemID] = contracts
+	return nil
+}
+
+// DeleteContracts removes contracts from a mocked system
+func DeleteContracts(cedarSystemID uuid.UUID, contractIDs []string) error {
+	contractsMutex.Lock()
+	defer contractsMutex.Unlock()
+
+	existing := systemContracts(cedarSystemID)
+	remaining := lo.Reject(existing, func(contract *models.CedarContract, _ int) bool {
+		return lo.Contains(contractIDs, contract.ID.String)
+	})
+	if len(existing)-len(remaining) != len(lo.Uniq(contractIDs)) {
+		return noContractFoundError()
+	}
+
+	contractsBySystem[cedarSystemID] = remaining
+	return nil
+}
+
+// systemContracts returns a system's contracts; contractsMutex must be held
+func systemContracts(cedarSystemID uuid.UUID) []*models.CedarContract {
+	if contracts, ok := contractsBySystem[cedarSystemID]; ok {
+		return contracts
+	}
+
 	contracts := []*models.CedarContract{}
 	for i := range mockContracts {
 		contract := *mockContracts[i]
@@ -66,3 +145,7 @@ func GetContractsBySystem(cedarSystemID uuid.UUID) []*models.CedarContract {
 	}
 	return contracts
 }
+
+func noContractFoundError() *apperrors.ResourceNotFoundError {
+	return &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no contract found"), Resource: models.CedarContract{}}
+}
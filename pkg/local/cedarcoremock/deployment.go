@@ -1,15 +1,27 @@
 package cedarcoremock
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
 
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/helpers"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 )
 
+var (
+	deploymentsMutex sync.Mutex
+	// deploymentsBySystem holds the deployments of each mocked system that's been written to; the other systems have mockDeployments
+	deploymentsBySystem = map[uuid.UUID][]*models.CedarDeployment{}
+)
+
 var mockDeployments = []*models.CedarDeployment{
 	{
 		ID:                       zero.StringFrom("{11AB1A00-1234-5678-ABC1-1A001B00CC0A}"),
@@ -183,6 +195,71 @@ var mockDeployments = []*models.CedarDeployment{
 	},
 }
 
-func GetDeployments() []*models.CedarDeployment {
+// GetDeployments returns the mocked deployments for a system
+func GetDeployments(cedarSystemID uuid.UUID) []*models.CedarDeployment {
+	deploymentsMutex.Lock()
+	defer deploymentsMutex.Unlock()
+	return systemDeployments(cedarSystemID)
+}
+
+// AddDeployment adds a deployment to a mocked system, giving it a new ID the way CEDAR does, and returns the added deployment
+func AddDeployment(cedarSystemID uuid.UUID, deployment *models.CedarDeployment) *models.CedarDeployment {
+	deploymentsMutex.Lock()
+	defer deploymentsMutex.Unlock()
+
+	added := *deployment
+	added.ID = zero.StringFrom(fmt.Sprintf("{%s}", strings.ToUpper(uuid.NewString())))
+	added.SystemID = &cedarSystemID
+
+	// deployments that have been returned may still be in use, so they're replaced rather than modified
+	deploymentsBySystem[cedarSystemID] = append(slices.Clone(systemDeployments(cedarSystemID)), &added)
+	return &added
+}
+
+// UpdateDeployment replaces a deployment on a mocked system
+func UpdateDeployment(cedarSystemID uuid.UUID, deployment *models.CedarDeployment) error {
+	deploymentsMutex.Lock()
+	defer deploymentsMutex.Unlock()
+
+	deployments := slices.Clone(systemDeployments(cedarSystemID))
+	i := slices.IndexFunc(deployments, func(existing *models.CedarDeployment) bool {
+		return existing.ID.String == deployment.ID.String
+	})
+	if i < 0 {
+		return noDeploymentFoundError()
+	}
+
+	updated := *deployment
+	deployments[i] = &updated
+	deploymentsBySystem[cedarSystemID] = deployments
+	return nil
+}
+
+// DeleteDeployments removes deployments from a mocked system
+func DeleteDeployments(cedarSystemID uuid.UUID, deploymentIDs []string) error {
+	deploymentsMutex.Lock()
+	defer deploymentsMutex.Unlock()
+
+	existing := systemDeployments(cedarSystemID)
+	remaining := lo.Reject(existing, func(deployment *models.CedarDeployment, _ int) bool {
+		return lo.Contains(deploymentIDs, deployment.ID.String)
+	})
+	if len(existing)-len(remaining) != len(lo.Uniq(deploymentIDs)) {
+		return noDeploymentFoundError()
+	}
+
+	deploymentsBySystem[cedarSystemID] = remaining
+	return nil
+}
+
+// systemDeployments returns a system's deployments; deploymentsMutex must be held
+func systemDeployments(cedarSystemID uuid.UUID) []*models.CedarDeployment {
+	if deployments, ok := deploymentsBySystem[cedarSystemID]; ok {
+		return deployments
+	}
 	return mockDeployments
 }
+
+func noDeploymentFoundError() *apperrors.ResourceNotFoundError {
+	return &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no deployment found"), Resource: models.CedarDeployment{}}
+}
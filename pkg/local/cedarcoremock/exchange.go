@@ -1,14 +1,23 @@
 package cedarcoremock
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
 
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 )
 
+// exchangesMutex guards mockExchanges, which is written to when exchanges are added, updated, or deleted
+var exchangesMutex sync.Mutex
+
 var mockExchanges = map[uuid.UUID][]*models.CedarExchange{
 	uuid.MustParse("{11AB1A00-1234-5678-ABC1-1A001B00CC0A}"): {
 		{
@@ -354,9 +363,68 @@ var mockExchanges = map[uuid.UUID][]*models.CedarExchange{
 
 // GetExchange returns a mock slice of CedarExchange structs
 func GetExchange(cedarSystemID uuid.UUID) []*models.CedarExchange {
+	exchangesMutex.Lock()
+	defer exchangesMutex.Unlock()
+
 	if val, ok := mockExchanges[cedarSystemID]; ok {
 		return val
 	}
 
 	return []*models.CedarExchange{}
 }
+
+// AddExchange adds an exchange to a mocked system, giving it a new ID the way CEDAR does, and returns the added exchange
+func AddExchange(cedarSystemID uuid.UUID, exchange *models.CedarExchange) *models.CedarExchange {
+	exchangesMutex.Lock()
+	defer exchangesMutex.Unlock()
+
+	added := *exchange
+	added.ExchangeID = zero.StringFrom(fmt.Sprintf("{%s}", strings.ToUpper(uuid.NewString())))
+
+	// exchanges that have been returned may still be in use, so they're replaced rather than modified
+	mockExchanges[cedarSystemID] = append(slices.Clone(mockExchanges[cedarSystemID]), &added)
+	return &added
+}
+
+// UpdateExchange replaces an exchange on a mocked system
+func UpdateExchange(cedarSystemID uuid.UUID, exchange *models.CedarExchange) error {
+	exchangesMutex.Lock()
+	defer exchangesMutex.Unlock()
+
+	exchanges := slices.Clone(mockExchanges[cedarSystemID])
+	i := slices.IndexFunc(exchanges, func(existing *models.CedarExchange) bool {
+		return existing.ExchangeID.String == exchange.ExchangeID.String
+	})
+	if i < 0 {
+		return noExchangeFoundError()
+	}
+
+	updated := *exchange
+	exchanges[i] = &updated
+	mockExchanges[cedarSystemID] = exchanges
+	return nil
+}
+
+// DeleteExchanges removes exchanges from a mocked system
+func DeleteExchanges(cedarSystemID uuid.UUID, exchangeIDs []string) error {
+	exchangesMutex.Lock()
+	defer exchangesMutex.Unlock()
+
+	existing := mockExchanges[cedarSystemID]
+	for _, exchangeID := range exchangeIDs {
+		if !lo.ContainsBy(existing, func(exchange *models.CedarExchange) bool {
+			return exchange.ExchangeID.String == exchangeID
+		}) {
+			return noExchangeFoundError()
+		}
+	}
+
+	mockExchanges[cedarSystemID] = lo.Reject(existing, func(exchange *models.CedarExchange, _ int) bool {
+		return lo.Contains(exchangeIDs, exchange.ExchangeID.String)
+	})
+	return nil
+}
+
+func noExchangeFoundError() *apperrors.ResourceNotFoundError {
+	return &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no exchange found"), Resource: models.CedarExchange{}}
+}
@@ -1,11 +1,25 @@
 package cedarcoremock
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
+	"github.com/samber/lo"
 
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 )
 
+var (
+	urlsMutex sync.Mutex
+	// urlsBySystem holds the URLs of each mocked system that's been written to; the other systems have mockURLs
+	urlsBySystem = map[uuid.UUID][]*models.CedarURL{}
+)
+
 var mockURLs = []*models.CedarURL{
 	{
 		ID:                             zero.StringFrom("{1234A56B-123A-12ab-1A2B-A123B4C50001}"),
@@ -73,6 +87,70 @@ var mockURLs = []*models.CedarURL{
 	},
 }
 
-func GetURLs() []*models.CedarURL {
+// GetURLs returns the mocked URLs for a system
+func GetURLs(cedarSystemID uuid.UUID) []*models.CedarURL {
+	urlsMutex.Lock()
+	defer urlsMutex.Unlock()
+	return systemURLs(cedarSystemID)
+}
+
+// AddURL adds a URL to a mocked system, giving it a new ID the way CEDAR does, and returns the added URL
+func AddURL(cedarSystemID uuid.UUID, url *models.CedarURL) *models.CedarURL {
+	urlsMutex.Lock()
+	defer urlsMutex.Unlock()
+
+	added := *url
+	added.ID = zero.StringFrom("{" + strings.ToUpper(uuid.NewString()) + "}")
+
+	// URLs that have been returned may still be in use, so they're replaced rather than modified
+	urlsBySystem[cedarSystemID] = append(slices.Clone(systemURLs(cedarSystemID)), &added)
+	return &added
+}
+
+// UpdateURL replaces a URL on a mocked system
+func UpdateURL(cedarSystemID uuid.UUID, url *models.CedarURL) error {
+	urlsMutex.Lock()
+	defer urlsMutex.Unlock()
+
+	urls := slices.Clone(systemURLs(cedarSystemID))
+	i := slices.IndexFunc(urls, func(existing *models.CedarURL) bool {
+		return existing.ID.String == url.ID.String
+	})
+	if i < 0 {
+		return noURLFoundError()
+	}
+
+	updated := *url
+	urls[i] = &updated
+	urlsBySystem[cedarSystemID] = urls
+	return nil
+}
+
+// DeleteURLs removes URLs from a mocked system
+func DeleteURLs(cedarSystemID uuid.UUID, urlIDs []string) error {
+	urlsMutex.Lock()
+	defer urlsMutex.Unlock()
+
+	existing := systemURLs(cedarSystemID)
+	remaining := lo.Reject(existing, func(url *models.CedarURL, _ int) bool {
+		return lo.Contains(urlIDs, url.ID.String)
+	})
+	if len(existing)-len(remaining) != len(lo.Uniq(urlIDs)) {
+		return noURLFoundError()
+	}
+
+	urlsBySystem[cedarSystemID] = remaining
+	return nil
+}
+
+// systemURLs returns a system's URLs; urlsMutex must be held
+func systemURLs(cedarSystemID uuid.UUID) []*models.CedarURL {
+	if urls, ok := urlsBySystem[cedarSystemID]; ok {
+		return urls
+	}
 	return mockURLs
 }
+
+func noURLFoundError() *apperrors.ResourceNotFoundError {
+	return &apperrors.ResourceNotFoundError{Err: fmt.Errorf("no URL found"), Resource: models.CedarURL{}}
+}
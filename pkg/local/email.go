@@ -27,18 +27,32 @@ type Sender struct {
 	environment appconfig.Environment
 }
 
-// Send logs an email
+// Send logs an email. Its attachments are validated as they would be by a real sender
 func (s Sender) Send(ctx context.Context, emailData easiemail.Email) error {
+	if err := easiemail.ValidateAttachments(emailData.Attachments); err != nil {
+		return err
+	}
+
 	appcontext.ZLogger(ctx).Info("Mock sending email",
 		zap.Strings("To", models.EmailAddressesToStrings(emailData.ToAddresses)),
 		zap.Strings("CC", models.EmailAddressesToStrings(emailData.CcAddresses)),
 		zap.Strings("BCC", models.EmailAddressesToStrings(emailData.BccAddresses)),
+		zap.String("ReplyTo", emailData.ReplyToAddress.String()),
 		zap.String("Subject", easiemail.AddNonProdEnvToSubject(emailData.Subject, s.environment)),
 		zap.String("Body", emailData.Body),
+		zap.Strings("Attachments", attachmentFilenames(emailData.Attachments)),
 	)
 	return nil
 }
 
+func attachmentFilenames(attachments []models.EmailAttachment) []string {
+	filenames := make([]string, len(attachments))
+	for i, attachment := range attachments {
+		filenames[i] = attachment.Filename
+	}
+	return filenames
+}
+
 // SMTPSender is a basic email sender that connects to an SMTP server; use with MailCatcher for testing locally
 type SMTPSender struct {
 	serverAddress string
@@ -68,13 +82,21 @@ func (sender SMTPSender) Send(ctx context.Context, emailData easiemail.Email) er
 		Subject: easiemail.AddNonProdEnvToSubject(emailData.Subject, sender.environment),
 		HTML:    []byte(emailData.Body),
 	}
+	if emailData.ReplyToAddress != "" {
+		e.ReplyTo = []string{emailData.ReplyToAddress.String()}
+	}
+	if err := easiemail.AttachToMessage(&e, emailData.Attachments); err != nil {
+		return err
+	}
 
 	appcontext.ZLogger(ctx).Info("Sending email using SMTP server",
 		zap.Strings("To", e.To),
 		zap.Strings("CC", e.Cc),
 		zap.Strings("BCC", e.Bcc),
+		zap.Strings("ReplyTo", e.ReplyTo),
 		zap.String("Subject", e.Subject),
 		zap.ByteString("Body", e.HTML),
+		zap.Strings("Attachments", attachmentFilenames(emailData.Attachments)),
 	)
 
 	err := e.Send(sender.serverAddress, nil)
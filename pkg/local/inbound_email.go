@@ -0,0 +1,71 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// NewDirectoryMailbox returns a mailbox of raw MIME emails saved as files in directory
+func NewDirectoryMailbox(directory string) DirectoryMailbox {
+	return DirectoryMailbox{
+		directory: directory,
+	}
+}
+
+// DirectoryMailbox stands in for the S3 bucket SES stores received emails in when running locally.
+// Emails to process are dropped into its directory as files, such as .eml files saved from a mail client
+type DirectoryMailbox struct {
+	directory string
+}
+
+// List returns the names of the email files in the directory, oldest first
+func (m DirectoryMailbox) List(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(m.directory)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	type emailFile struct {
+		name string
+		info fs.FileInfo
+	}
+	var files []emailFile
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, emailFile{name: entry.Name(), info: info})
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].info.ModTime().Before(files[j].info.ModTime())
+	})
+
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.name
+	}
+	return names, nil
+}
+
+// Read opens an email file in the directory
+func (m DirectoryMailbox) Read(ctx context.Context, key string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(m.directory, filepath.Base(key)))
+}
+
+// Delete removes an email file from the directory
+func (m DirectoryMailbox) Delete(ctx context.Context, key string) error {
+	return os.Remove(filepath.Join(m.directory, filepath.Base(key)))
+}
@@ -19,7 +19,7 @@ type MockSubscriber struct {
 
 // NotifyUnsubscribedCall records the arguments passed to NotifyUnsubscribed
 type NotifyUnsubscribedCall struct {
-	PubSub    *pubsub.ServicePubSub
+	PubSub    pubsub.PubSub
 	SessionID uuid.UUID
 }
 
@@ -53,7 +53,7 @@ func (m *MockSubscriber) Notify(payload interface{}) {
 }
 
 // NotifyUnsubscribed records the unsubscribe notification
-func (m *MockSubscriber) NotifyUnsubscribed(ps *pubsub.ServicePubSub, sessionID uuid.UUID) {
+func (m *MockSubscriber) NotifyUnsubscribed(ps pubsub.PubSub, sessionID uuid.UUID) {
 	if m.ShouldNotifyUnsubscribed {
 		m.NotifyUnsubscribedCalls = append(m.NotifyUnsubscribedCalls, NotifyUnsubscribedCall{
 			PubSub:    ps,
@@ -9,6 +9,7 @@ const (
 	CedarPublisherSectionKey       string = "cedar_publisher"
 	SchedularSectionKey            string = "scheduler"
 	SystemProfileLockingSectionKey string = "system_profile_locking"
+	PubSubSectionKey               string = "pubsub"
 )
 
 // CedarPublisherAppSection provides the zap field for specifying the part of the application is the CEDAR publisher
@@ -19,3 +20,6 @@ var SchedulerAppSection = zap.String(AppSectionKey, SchedularSectionKey)
 
 // SystemProfileLockingAppSection provides the zap field for specifying the part of the application is the system profile locking service
 var SystemProfileLockingAppSection = zap.String(AppSectionKey, SystemProfileLockingSectionKey)
+
+// PubSubAppSection provides the zap field for specifying the part of the application is the pubsub service
+var PubSubAppSection = zap.String(AppSectionKey, PubSubSectionKey)
@@ -0,0 +1,162 @@
+package models
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// auditIgnoredFields are fields that change on every write, and are already captured by the audit change itself
+var auditIgnoredFields = []string{
+	"createdAt",
+	"updatedAt",
+	"modifiedAt",
+	"modifiedBy",
+}
+
+// AuditChange is a record of a change made to an audited entity, and who made it
+type AuditChange struct {
+	modifiedByRelation
+	ID         uuid.UUID         `json:"id" db:"id"`
+	EntityType AuditEntityType   `json:"entityType" db:"entity_type"`
+	EntityID   uuid.UUID         `json:"entityId" db:"entity_id"`
+	ParentID   *uuid.UUID        `json:"parentId" db:"parent_id"`
+	ExternalID *string           `json:"externalId" db:"external_id"`
+	Action     AuditChangeAction `json:"action" db:"action"`
+	Fields     AuditFieldChanges `json:"fields" db:"fields"`
+}
+
+// AuditFieldChange holds the JSON encoded values of a single field before and after a change
+type AuditFieldChange struct {
+	FieldName string          `json:"fieldName"`
+	Old       json.RawMessage `json:"old"`
+	New       json.RawMessage `json:"new"`
+}
+
+// OldValue returns the JSON encoded value of the field before the change, or nil if it did not have one
+func (c AuditFieldChange) OldValue() *string {
+	return rawJSONValue(c.Old)
+}
+
+// NewValue returns the JSON encoded value of the field after the change, or nil if it no longer has one
+func (c AuditFieldChange) NewValue() *string {
+	return rawJSONValue(c.New)
+}
+
+func rawJSONValue(value json.RawMessage) *string {
+	if len(value) == 0 || bytes.Equal(value, []byte("null")) {
+		return nil
+	}
+
+	str := string(value)
+	return &str
+}
+
+// AuditFieldChanges is the list of fields changed by an AuditChange, stored as JSONB
+type AuditFieldChanges []AuditFieldChange
+
+// Scan implements the sql.Scanner interface
+func (c *AuditFieldChanges) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(source, c)
+}
+
+// Value implements the driver.Valuer interface
+func (c AuditFieldChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(c)
+}
+
+// NewAuditFieldChanges compares the JSON representations of an entity before and after a change, and returns the fields that differ.
+// before should be nil for an insert, and after should be nil for a delete
+func NewAuditFieldChanges(before any, after any) (AuditFieldChanges, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var fieldNames []string
+	for fieldName := range beforeFields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	for fieldName := range afterFields {
+		if _, ok := beforeFields[fieldName]; !ok {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+	slices.Sort(fieldNames)
+
+	changes := AuditFieldChanges{}
+	for _, fieldName := range fieldNames {
+		if slices.Contains(auditIgnoredFields, fieldName) {
+			continue
+		}
+
+		oldValue := beforeFields[fieldName]
+		newValue := afterFields[fieldName]
+		// a field that is missing on one side (e.g. for an insert) is the same as a null one
+		if bytes.Equal(oldValue, newValue) || (rawJSONValue(oldValue) == nil && rawJSONValue(newValue) == nil) {
+			continue
+		}
+
+		changes = append(changes, AuditFieldChange{
+			FieldName: fieldName,
+			Old:       oldValue,
+			New:       newValue,
+		})
+	}
+
+	return changes, nil
+}
+
+// auditFields returns the top level fields of the JSON representation of entity, in compact form so they can be compared
+func auditFields(entity any) (map[string]json.RawMessage, error) {
+	encoded, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+
+	for fieldName, value := range fields {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, value); err != nil {
+			return nil, err
+		}
+		fields[fieldName] = compacted.Bytes()
+	}
+
+	return fields, nil
+}
+
+// Cursor returns the cursor pointing at this change in the audit history, which is ordered newest first
+func (a *AuditChange) Cursor() PageCursor {
+	cursor := PageCursor{
+		ID: a.ID,
+	}
+
+	if a.ModifiedAt != nil {
+		cursor.SortValue = *a.ModifiedAt
+	}
+
+	return cursor
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *ModelTestSuite) TestNewAuditFieldChanges() {
+	type entity struct {
+		Name       string  `json:"name"`
+		Notes      *string `json:"notes"`
+		Count      int     `json:"count"`
+		ModifiedAt string  `json:"modifiedAt"`
+	}
+
+	notes := "some notes"
+	before := &entity{Name: "before", Count: 1, ModifiedAt: "yesterday"}
+	after := &entity{Name: "after", Notes: &notes, Count: 1, ModifiedAt: "today"}
+
+	s.Run("returns only the changed fields, sorted by name", func() {
+		changes, err := NewAuditFieldChanges(before, after)
+		s.NoError(err)
+		s.Len(changes, 2)
+
+		s.Equal("name", changes[0].FieldName)
+		s.Equal(`"before"`, *changes[0].OldValue())
+		s.Equal(`"after"`, *changes[0].NewValue())
+
+		s.Equal("notes", changes[1].FieldName)
+		s.Nil(changes[1].OldValue())
+		s.Equal(`"some notes"`, *changes[1].NewValue())
+	})
+
+	s.Run("returns every field with a value for an insert", func() {
+		changes, err := NewAuditFieldChanges(nil, before)
+		s.NoError(err)
+		s.Len(changes, 2)
+		s.Equal("count", changes[0].FieldName)
+		s.Equal("name", changes[1].FieldName)
+	})
+
+	s.Run("returns nothing when nothing changed", func() {
+		changes, err := NewAuditFieldChanges(before, before)
+		s.NoError(err)
+		s.Empty(changes)
+	})
+}
+
+func (s *ModelTestSuite) TestAuditChangeCursor() {
+	modifiedAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	change := &AuditChange{ID: uuid.New()}
+	change.ModifiedAt = &modifiedAt
+
+	cursor := change.Cursor()
+	s.Equal(change.ID, cursor.ID)
+	s.True(modifiedAt.Equal(cursor.SortValue))
+}
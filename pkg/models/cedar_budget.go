@@ -1,6 +1,10 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
 )
@@ -18,3 +22,12 @@ type CedarBudget struct {
 	ProjectTitle  zero.String `json:"projectTitle,omitempty"`
 	SystemID      *uuid.UUID  `json:"systemId,omitempty"`
 }
+
+// ConcurrencyToken identifies the version of a budget that was read, so an edit based on that version can be rejected if the budget
+// has changed in CEDAR since. CEDAR doesn't version budgets, so the token is a hash of the budget's fields.
+func (b *CedarBudget) ConcurrencyToken() string {
+	// marshaling a struct of strings, times, and bools can't fail
+	data, _ := json.Marshal(b)
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
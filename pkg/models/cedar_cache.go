@@ -0,0 +1,22 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// CEDARCacheEvictedEvent is the payload published when cached CEDAR Core responses are evicted, so every instance of the backend
+// evicts them from its own cache
+type CEDARCacheEvictedEvent struct {
+	// InstanceID identifies the instance that evicted the responses, which has already evicted them from its own cache
+	InstanceID uuid.UUID `json:"instanceId"`
+	// Rules are the responses to evict; a response is evicted if it matches any of them
+	Rules []CEDARCacheEvictionRule `json:"rules"`
+}
+
+// CEDARCacheEvictionRule matches cached CEDAR Core responses by the endpoint they came from and the system or user they're about.
+// Fields that are left empty match any response
+type CEDARCacheEvictionRule struct {
+	Endpoint  string    `json:"endpoint,omitempty"`
+	SystemID  uuid.UUID `json:"systemId"`
+	EUAUserID string    `json:"euaUserId,omitempty"`
+}
@@ -1,6 +1,10 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
 )
@@ -8,6 +12,7 @@ import (
 // CedarContract represents a single Contract object returned from the CEDAR API
 
 type CedarContract struct {
+	ID              zero.String `json:"id,omitempty"`
 	EndDate         zero.Time   `json:"endDate,omitempty"`
 	StartDate       zero.Time   `json:"startDate,omitempty"`
 	ContractNumber  zero.String `json:"contractNumber"`
@@ -18,3 +23,12 @@ type CedarContract struct {
 	IsDeliveryOrg   bool        `json:"isDeliveryOrg"`
 	SystemID        *uuid.UUID  `json:"systemId,omitempty"`
 }
+
+// ConcurrencyToken identifies the version of a contract that was read, so an edit based on that version can be rejected if the contract
+// has changed in CEDAR since. CEDAR doesn't version contracts, so the token is a hash of the contract's fields.
+func (c *CedarContract) ConcurrencyToken() string {
+	// marshaling a struct of strings, times, and bools can't fail
+	data, _ := json.Marshal(c)
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
@@ -1,6 +1,10 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	"github.com/google/uuid"
 	"github.com/guregu/null/zero"
 )
@@ -48,3 +52,12 @@ type CedarDeployment struct {
 	WanType                  zero.String
 	DataCenter               *CedarDataCenter
 }
+
+// ConcurrencyToken identifies the version of a deployment that was read, so an edit based on that version can be rejected if the deployment
+// has changed in CEDAR since. CEDAR doesn't version deployments, so the token is a hash of the deployment's fields.
+func (d *CedarDeployment) ConcurrencyToken() string {
+	// marshaling a struct of strings, times, and slices can't fail
+	data, _ := json.Marshal(d)
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
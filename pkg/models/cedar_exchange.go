@@ -1,6 +1,10 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	"github.com/guregu/null/zero"
 )
 
@@ -51,3 +55,12 @@ type CedarExchange struct {
 	ToOwnerType                 zero.String                    `json:"toOwnerType,omitempty"`
 	TypeOfData                  []*CedarExchangeTypeOfDataItem `json:"typeOfData"`
 }
+
+// ConcurrencyToken identifies the version of an exchange that was read, so an edit based on that version can be rejected if the exchange
+// has changed in CEDAR since. CEDAR's exchange version is only bumped by some of its own processes, so the token is a hash of the exchange's fields.
+func (e *CedarExchange) ConcurrencyToken() string {
+	// marshaling a struct of strings, times, and slices can't fail
+	data, _ := json.Marshal(e)
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
@@ -1,19 +1,32 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	"github.com/guregu/null/zero"
 )
 
 // CedarURL represents a single URL object returned from the CEDAR API
 type CedarURL struct {
 	// always-present field
-	ID zero.String
+	ID zero.String `json:"id,omitempty"`
 
 	// possibly-null fields
 
-	Address                        zero.String // The actual URL.
-	IsBehindWebApplicationFirewall bool
-	IsAPIEndpoint                  bool
-	IsVersionCodeRepository        bool        // Represents whether this URL provides access to a versioned code repository.
-	URLHostingEnv                  zero.String // This should correspond with .DeploymentType on a CedarDeployment object.
+	Address                        zero.String `json:"address"` // The actual URL.
+	IsBehindWebApplicationFirewall bool        `json:"isBehindWebApplicationFirewall"`
+	IsAPIEndpoint                  bool        `json:"isAPIEndpoint"`
+	IsVersionCodeRepository        bool        `json:"isVersionCodeRepository"` // Represents whether this URL provides access to a versioned code repository.
+	URLHostingEnv                  zero.String `json:"urlHostingEnv,omitempty"` // This should correspond with .DeploymentType on a CedarDeployment object.
+}
+
+// ConcurrencyToken identifies the version of a URL that was read, so an edit based on that version can be rejected if the URL
+// has changed in CEDAR since. CEDAR doesn't version URLs, so the token is a hash of the URL's fields.
+func (u *CedarURL) ConcurrencyToken() string {
+	// marshaling a struct of strings and bools can't fail
+	data, _ := json.Marshal(u)
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
 }
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// EmailAttachment is a file attached to an email
+type EmailAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Data        []byte `json:"data"`
+	// ContentID is set for images shown in the body of the email instead of as attachments, which reference them as cid:<ContentID>
+	ContentID string `json:"contentId,omitempty"`
+}
+
+// EmailAttachments are the files attached to an email, stored as JSONB
+type EmailAttachments []EmailAttachment
+
+// Scan implements the sql.Scanner interface
+func (a *EmailAttachments) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(source, a)
+}
+
+// Value implements the driver.Valuer interface
+func (a EmailAttachments) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(a)
+}
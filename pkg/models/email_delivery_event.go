@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailDeliveryEventType is the kind of delivery notification SES sent about an email to one of its recipients
+type EmailDeliveryEventType string
+
+// These are the kinds of delivery notifications SES sends about emails
+const (
+	EmailDeliveryEventTypeDelivery  EmailDeliveryEventType = "DELIVERY"
+	EmailDeliveryEventTypeBounce    EmailDeliveryEventType = "BOUNCE"
+	EmailDeliveryEventTypeComplaint EmailDeliveryEventType = "COMPLAINT"
+)
+
+// EmailBouncesBeforeUndeliverable is the number of emails to a user that have to bounce in a row before their address is flagged as undeliverable
+const EmailBouncesBeforeUndeliverable = 3
+
+// EmailDeliveryEvent is SES's notification that an email was delivered to, bounced from, or marked as spam by one of its recipients
+type EmailDeliveryEvent struct {
+	ID uuid.UUID `json:"id" db:"id"`
+	// NotificationID is the ID of the SNS notification the event was received in
+	NotificationID    string                 `json:"notificationID" db:"notification_id"`
+	ProviderMessageID string                 `json:"providerMessageID" db:"provider_message_id"`
+	EmailOutboxID     *uuid.UUID             `json:"emailOutboxID" db:"email_outbox_id"`
+	EventType         EmailDeliveryEventType `json:"eventType" db:"event_type"`
+	Recipient         EmailAddress           `json:"recipient" db:"recipient"`
+	BounceType        *string                `json:"bounceType" db:"bounce_type"`
+	BounceSubType     *string                `json:"bounceSubType" db:"bounce_sub_type"`
+	Diagnostic        *string                `json:"diagnostic" db:"diagnostic"`
+	OccurredAt        time.Time              `json:"occurredAt" db:"occurred_at"`
+	CreatedAt         time.Time              `json:"createdAt" db:"created_at"`
+}
+
+// EmailRecipientDeliveryStatus is the delivery status of the emails sent to one of the people on a request
+type EmailRecipientDeliveryStatus struct {
+	UserAccountID uuid.UUID    `json:"userAccountID" db:"user_account_id"`
+	EmailAddress  EmailAddress `json:"emailAddress" db:"email"`
+	// BounceCount is the number of emails to the person that have bounced since one was last delivered to them
+	BounceCount int `json:"bounceCount" db:"email_bounce_count"`
+	// UndeliverableAt is when emails to the person started bouncing repeatedly, if they have
+	UndeliverableAt *time.Time `json:"undeliverableAt" db:"email_undeliverable_at"`
+	// LastEventType and LastEventAt are the most recent delivery notification about an email to the person, if there's been one
+	LastEventType  *EmailDeliveryEventType `json:"lastEventType" db:"last_event_type"`
+	LastEventAt    *time.Time              `json:"lastEventAt" db:"last_event_at"`
+	LastDiagnostic *string                 `json:"lastDiagnostic" db:"last_diagnostic"`
+}
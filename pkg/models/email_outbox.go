@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailOutboxMessageStatus is the delivery status of an email in the outbox
+type EmailOutboxMessageStatus string
+
+// These are the delivery statuses of an email in the outbox
+const (
+	EmailOutboxMessageStatusPending    EmailOutboxMessageStatus = "PENDING"
+	EmailOutboxMessageStatusSent       EmailOutboxMessageStatus = "SENT"
+	EmailOutboxMessageStatusDeadLetter EmailOutboxMessageStatus = "DEAD_LETTER"
+)
+
+const (
+	// EmailOutboxMaxAttempts is the number of times sending an email is attempted before it's moved to the dead letter state
+	EmailOutboxMaxAttempts = 8
+
+	emailOutboxBaseRetryDelay = time.Minute
+	emailOutboxMaxRetryDelay  = 4 * time.Hour
+)
+
+// EmailOutboxMessage is an email queued to be sent by the outbox dispatcher, and the history of attempts to send it
+type EmailOutboxMessage struct {
+	modifiedByRelation
+	ID             uuid.UUID                `json:"id" db:"id"`
+	ToAddresses    EnumArray[EmailAddress]  `json:"toAddresses" db:"to_addresses"`
+	CcAddresses    EnumArray[EmailAddress]  `json:"ccAddresses" db:"cc_addresses"`
+	BccAddresses   EnumArray[EmailAddress]  `json:"bccAddresses" db:"bcc_addresses"`
+	Subject        string                   `json:"subject" db:"subject"`
+	Body           HTML                     `json:"body" db:"body"`
+	Attachments    EmailAttachments         `json:"attachments" db:"attachments"`
+	ReplyToAddress *EmailAddress            `json:"replyToAddress" db:"reply_to_address"`
+	Status         EmailOutboxMessageStatus `json:"status" db:"status"`
+	Attempts       int                      `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time                `json:"nextAttemptAt" db:"next_attempt_at"`
+	LastError      *string                  `json:"lastError" db:"last_error"`
+	SentAt         *time.Time               `json:"sentAt" db:"sent_at"`
+	// ProviderMessageID is the ID SES gave the email when it was sent, which its delivery notifications refer to it by
+	ProviderMessageID *string `json:"providerMessageID" db:"provider_message_id"`
+	// ClaimID identifies the dispatcher attempt that has claimed the email to send it, if any
+	ClaimID   *uuid.UUID `json:"-" db:"claim_id"`
+	CreatedBy *uuid.UUID `json:"createdBy" db:"created_by"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// RecordSent records a successful attempt to send the email
+func (m *EmailOutboxMessage) RecordSent(now time.Time) {
+	m.Attempts++
+	m.Status = EmailOutboxMessageStatusSent
+	m.SentAt = &now
+}
+
+// RecordFailure records a failed attempt to send the email. The next attempt is delayed exponentially,
+// and after EmailOutboxMaxAttempts failures the email is moved to the dead letter state instead
+func (m *EmailOutboxMessage) RecordFailure(now time.Time, sendErr error) {
+	m.Attempts++
+	lastError := sendErr.Error()
+	m.LastError = &lastError
+
+	if m.Attempts >= EmailOutboxMaxAttempts {
+		m.Status = EmailOutboxMessageStatusDeadLetter
+		return
+	}
+
+	m.Status = EmailOutboxMessageStatusPending
+	m.NextAttemptAt = now.Add(EmailOutboxRetryDelay(m.Attempts))
+}
+
+// EmailOutboxRetryDelay returns how long to wait before sending an email again after it has failed the given number of times.
+// The delay doubles with each failure, starting at a minute, up to a maximum of 4 hours
+func EmailOutboxRetryDelay(failedAttempts int) time.Duration {
+	return exponentialRetryDelay(failedAttempts, emailOutboxBaseRetryDelay, emailOutboxMaxRetryDelay)
+}
+
+// exponentialRetryDelay returns how long to wait before retrying something that has failed the given number of times,
+// doubling from baseDelay with each failure, up to maxDelay
+func exponentialRetryDelay(failedAttempts int, baseDelay time.Duration, maxDelay time.Duration) time.Duration {
+	if failedAttempts < 1 {
+		return 0
+	}
+
+	delay := baseDelay
+	for i := 1; i < failedAttempts; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return delay
+}
+
+// Cursor returns the cursor pointing at this email in the outbox, which is ordered newest first
+func (m *EmailOutboxMessage) Cursor() PageCursor {
+	return PageCursor{
+		SortValue: m.CreatedAt,
+		ID:        m.ID,
+	}
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+func (s *ModelTestSuite) TestEmailOutboxRetryDelay() {
+	s.Equal(time.Duration(0), EmailOutboxRetryDelay(0))
+	s.Equal(time.Minute, EmailOutboxRetryDelay(1))
+	s.Equal(2*time.Minute, EmailOutboxRetryDelay(2))
+	s.Equal(4*time.Minute, EmailOutboxRetryDelay(3))
+	s.Equal(64*time.Minute, EmailOutboxRetryDelay(7))
+	s.Equal(4*time.Hour, EmailOutboxRetryDelay(9))
+	s.Equal(4*time.Hour, EmailOutboxRetryDelay(100))
+}
+
+func (s *ModelTestSuite) TestEmailOutboxMessageDelivery() {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	s.Run("a failure schedules the next attempt with backoff", func() {
+		message := &EmailOutboxMessage{Status: EmailOutboxMessageStatusPending, NextAttemptAt: now}
+
+		message.RecordFailure(now, errors.New("throttled"))
+		s.Equal(EmailOutboxMessageStatusPending, message.Status)
+		s.Equal(1, message.Attempts)
+		s.Equal("throttled", *message.LastError)
+		s.Equal(now.Add(time.Minute), message.NextAttemptAt)
+
+		message.RecordFailure(now, errors.New("throttled again"))
+		s.Equal(2, message.Attempts)
+		s.Equal("throttled again", *message.LastError)
+		s.Equal(now.Add(2*time.Minute), message.NextAttemptAt)
+	})
+
+	s.Run("the last allowed failure moves the email to the dead letter state", func() {
+		message := &EmailOutboxMessage{Status: EmailOutboxMessageStatusPending, Attempts: EmailOutboxMaxAttempts - 1, NextAttemptAt: now}
+
+		message.RecordFailure(now, errors.New("rejected"))
+		s.Equal(EmailOutboxMessageStatusDeadLetter, message.Status)
+		s.Equal(EmailOutboxMaxAttempts, message.Attempts)
+		s.Equal(now, message.NextAttemptAt)
+	})
+
+	s.Run("a success marks the email as sent", func() {
+		message := &EmailOutboxMessage{Status: EmailOutboxMessageStatusPending, Attempts: 2}
+
+		message.RecordSent(now)
+		s.Equal(EmailOutboxMessageStatusSent, message.Status)
+		s.Equal(3, message.Attempts)
+		s.Equal(now, *message.SentAt)
+	})
+}
+
+func (s *ModelTestSuite) TestEmailAttachmentsRoundTrip() {
+	attachments := EmailAttachments{{
+		Filename:    "invite.ics",
+		ContentType: "text/calendar; charset=UTF-8; method=REQUEST",
+		Data:        []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"),
+	}}
+
+	value, err := attachments.Value()
+	s.NoError(err)
+
+	var scanned EmailAttachments
+	s.NoError(scanned.Scan(value))
+	s.Equal(attachments, scanned)
+
+	s.Run("no attachments are stored as an empty array", func() {
+		value, err := EmailAttachments(nil).Value()
+		s.NoError(err)
+		s.Equal([]byte("[]"), value)
+	})
+}
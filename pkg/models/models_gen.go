@@ -18,6 +18,36 @@ type TRBAdminNoteCategorySpecificData interface {
 	IsTRBAdminNoteCategorySpecificData()
 }
 
+// The data needed to add a budget to a CEDAR system
+type AddCedarBudgetInput struct {
+	CedarSystemID uuid.UUID         `json:"cedarSystemId"`
+	Budget        *CedarBudgetInput `json:"budget"`
+}
+
+// The data needed to add a contract to a CEDAR system
+type AddCedarContractInput struct {
+	CedarSystemID uuid.UUID           `json:"cedarSystemId"`
+	Contract      *CedarContractInput `json:"contract"`
+}
+
+// The data needed to add a deployment to a CEDAR system
+type AddCedarDeploymentInput struct {
+	CedarSystemID uuid.UUID             `json:"cedarSystemId"`
+	Deployment    *CedarDeploymentInput `json:"deployment"`
+}
+
+// The data needed to add an exchange to a CEDAR system
+type AddCedarExchangeInput struct {
+	CedarSystemID uuid.UUID           `json:"cedarSystemId"`
+	Exchange      *CedarExchangeInput `json:"exchange"`
+}
+
+// The data needed to add a URL to a CEDAR system
+type AddCedarURLInput struct {
+	CedarSystemID uuid.UUID      `json:"cedarSystemId"`
+	URL           *CedarURLInput `json:"url"`
+}
+
 // The input type for adding a new System Link
 type AddSystemLinkInput struct {
 	SystemIntakeID                     uuid.UUID                `json:"systemIntakeID"`
@@ -35,6 +65,17 @@ type AddSystemLinkPayload struct {
 	OtherSystemRelationshipDescription *string                  `json:"otherSystemRelationshipDescription,omitempty"`
 }
 
+// A page of the audit history of an entity, ordered newest first
+type AuditChangeConnection struct {
+	Edges    []*AuditChangeEdge `json:"edges"`
+	PageInfo *PageInfo          `json:"pageInfo"`
+}
+
+type AuditChangeEdge struct {
+	Cursor string       `json:"cursor"`
+	Node   *AuditChange `json:"node"`
+}
+
 // A solution proposal within a Business Case
 type BusinessCaseSolution struct {
 	AcquisitionApproach     *string    `json:"acquisitionApproach,omitempty"`
@@ -70,6 +111,14 @@ type CedarBudgetActualCost struct {
 	SystemID         *uuid.UUID `json:"systemId,omitempty"`
 }
 
+// The editable fields of a CedarBudget. The rest of a budget, such as its project's title and funding source, comes from the project in CEDAR.
+type CedarBudgetInput struct {
+	// The CEDAR ID of the project that funds the system
+	ProjectID string `json:"projectId"`
+	// How much of the project's funding goes to the system
+	Funding *string `json:"funding,omitempty"`
+}
+
 // BusinessOwnerInformation contains information about the Business Owner for a CEDAR system
 type CedarBusinessOwnerInformation struct {
 	BeneficiaryAddressPurpose      []string `json:"beneficiaryAddressPurpose"`
@@ -88,6 +137,72 @@ type CedarBusinessOwnerInformation struct {
 	StoresBeneficiaryAddress       *bool    `json:"storesBeneficiaryAddress,omitempty"`
 }
 
+// The editable fields of a CedarContract
+type CedarContractInput struct {
+	ContractNumber  string     `json:"contractNumber"`
+	OrderNumber     *string    `json:"orderNumber,omitempty"`
+	ContractName    *string    `json:"contractName,omitempty"`
+	Description     *string    `json:"description,omitempty"`
+	ServiceProvided *string    `json:"serviceProvided,omitempty"`
+	IsDeliveryOrg   *bool      `json:"isDeliveryOrg,omitempty"`
+	StartDate       *time.Time `json:"startDate,omitempty"`
+	EndDate         *time.Time `json:"endDate,omitempty"`
+}
+
+// The editable fields of a CedarDeployment
+type CedarDeploymentInput struct {
+	Name                     string     `json:"name"`
+	DeploymentType           *string    `json:"deploymentType,omitempty"`
+	Description              *string    `json:"description,omitempty"`
+	ContractorName           *string    `json:"contractorName,omitempty"`
+	SystemVersion            *string    `json:"systemVersion,omitempty"`
+	HasProductionData        *string    `json:"hasProductionData,omitempty"`
+	IsHotSite                *string    `json:"isHotSite,omitempty"`
+	StartDate                *time.Time `json:"startDate,omitempty"`
+	EndDate                  *time.Time `json:"endDate,omitempty"`
+	State                    *string    `json:"state,omitempty"`
+	Status                   *string    `json:"status,omitempty"`
+	WanType                  *string    `json:"wanType,omitempty"`
+	ReplicatedSystemElements []string   `json:"replicatedSystemElements,omitempty"`
+	// The ID of the CEDAR data center the deployment is hosted in
+	DataCenterID *string `json:"dataCenterId,omitempty"`
+}
+
+// The editable fields of a CedarExchange. The system being edited is filled in as the sender or receiver, depending on exchangeDirection,
+// and the partner is the system or organization on the other end of the exchange.
+type CedarExchangeInput struct {
+	ExchangeName        string            `json:"exchangeName"`
+	ExchangeDescription *string           `json:"exchangeDescription,omitempty"`
+	ExchangeDirection   ExchangeDirection `json:"exchangeDirection"`
+	// The CEDAR ID of the system or organization on the other end of the exchange
+	PartnerID *string `json:"partnerId,omitempty"`
+	// Whether the partner is a system ("application") or an "organization"
+	PartnerType                 *string                             `json:"partnerType,omitempty"`
+	ExchangeState               *string                             `json:"exchangeState,omitempty"`
+	ExchangeStartDate           *time.Time                          `json:"exchangeStartDate,omitempty"`
+	ExchangeEndDate             *time.Time                          `json:"exchangeEndDate,omitempty"`
+	ExchangeRetiredDate         *time.Time                          `json:"exchangeRetiredDate,omitempty"`
+	ConnectionFrequency         []string                            `json:"connectionFrequency,omitempty"`
+	DataExchangeAgreement       *string                             `json:"dataExchangeAgreement,omitempty"`
+	DataFormat                  *string                             `json:"dataFormat,omitempty"`
+	DataFormatOther             *string                             `json:"dataFormatOther,omitempty"`
+	NumOfRecords                *string                             `json:"numOfRecords,omitempty"`
+	ContainsBankingData         *bool                               `json:"containsBankingData,omitempty"`
+	ContainsBeneficiaryAddress  *bool                               `json:"containsBeneficiaryAddress,omitempty"`
+	ContainsPhi                 *bool                               `json:"containsPhi,omitempty"`
+	ContainsPii                 *bool                               `json:"containsPii,omitempty"`
+	ContainsHealthDisparityData *bool                               `json:"containsHealthDisparityData,omitempty"`
+	IsBeneficiaryMailingFile    *bool                               `json:"isBeneficiaryMailingFile,omitempty"`
+	SharedViaAPI                *bool                               `json:"sharedViaApi,omitempty"`
+	TypeOfData                  []*CedarExchangeTypeOfDataItemInput `json:"typeOfData,omitempty"`
+}
+
+// A type of data sent in a CEDAR exchange
+type CedarExchangeTypeOfDataItemInput struct {
+	ID   string  `json:"id"`
+	Name *string `json:"name,omitempty"`
+}
+
 // CedarSoftwareProductItem represents an individual software product; this information is returned from the CEDAR Core API
 // as a part of the CedarSoftwareProducts object
 type CedarSoftwareProductItem struct {
@@ -174,6 +289,15 @@ type CedarSystemWorkspaceSystem struct {
 	LinkedSystemIntakes    []*SystemIntake `json:"linkedSystemIntakes"`
 }
 
+// The editable fields of a CedarURL
+type CedarURLInput struct {
+	Address                        string  `json:"address"`
+	IsBehindWebApplicationFirewall *bool   `json:"isBehindWebApplicationFirewall,omitempty"`
+	IsAPIEndpoint                  *bool   `json:"isAPIEndpoint,omitempty"`
+	IsVersionCodeRepository        *bool   `json:"isVersionCodeRepository,omitempty"`
+	URLHostingEnv                  *string `json:"urlHostingEnv,omitempty"`
+}
+
 // The input needed to close a TRB request
 type CloseTRBRequestInput struct {
 	ID             uuid.UUID `json:"id"`
@@ -332,16 +456,63 @@ type CreateTRBRequestFeedbackInput struct {
 	Action          TRBFeedbackAction `json:"action"`
 }
 
+type CreateWebhookSubscriptionInput struct {
+	Name string `json:"name"`
+	// Must use https, except for subscribers on the local machine in local development and tests
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+}
+
 // The current user of the application
 type CurrentUser struct {
 	LaunchDarkly *LaunchDarklySettings `json:"launchDarkly"`
 }
 
+// The data needed to remove one of a CEDAR system's budgets
+type DeleteCedarBudgetInput struct {
+	CedarSystemID uuid.UUID `json:"cedarSystemId"`
+	BudgetID      string    `json:"budgetId"`
+	// The concurrencyToken of the budget being removed
+	ConcurrencyToken string `json:"concurrencyToken"`
+}
+
+// The data needed to remove one of a CEDAR system's contracts
+type DeleteCedarContractInput struct {
+	CedarSystemID uuid.UUID `json:"cedarSystemId"`
+	ContractID    string    `json:"contractId"`
+	// The concurrencyToken of the contract being removed
+	ConcurrencyToken string `json:"concurrencyToken"`
+}
+
+// The data needed to remove one of a CEDAR system's deployments
+type DeleteCedarDeploymentInput struct {
+	CedarSystemID uuid.UUID `json:"cedarSystemId"`
+	DeploymentID  string    `json:"deploymentId"`
+	// The concurrencyToken of the deployment being removed
+	ConcurrencyToken string `json:"concurrencyToken"`
+}
+
+// The data needed to remove one of a CEDAR system's exchanges
+type DeleteCedarExchangeInput struct {
+	CedarSystemID uuid.UUID `json:"cedarSystemId"`
+	ExchangeID    string    `json:"exchangeId"`
+	// The concurrencyToken of the exchange being removed
+	ConcurrencyToken string `json:"concurrencyToken"`
+}
+
 // The payload when deleting a bookmark for a cedar system
 type DeleteCedarSystemBookmarkPayload struct {
 	CedarSystemID uuid.UUID `json:"cedarSystemId"`
 }
 
+// The data needed to remove one of a CEDAR system's URLs
+type DeleteCedarURLInput struct {
+	CedarSystemID uuid.UUID `json:"cedarSystemId"`
+	URLID         string    `json:"urlId"`
+	// The concurrencyToken of the URL being removed
+	ConcurrencyToken string `json:"concurrencyToken"`
+}
+
 // The data needed to delete a system intake contact
 type DeleteSystemIntakeContactInput struct {
 	ID uuid.UUID `json:"id"`
@@ -381,6 +552,26 @@ type DeleteTRBRequestFundingSourcesInput struct {
 	FundingNumber string    `json:"fundingNumber"`
 }
 
+// A page of the emails in the outbox, ordered newest first
+type EmailOutboxMessageConnection struct {
+	Edges    []*EmailOutboxMessageEdge `json:"edges"`
+	PageInfo *PageInfo                 `json:"pageInfo"`
+}
+
+type EmailOutboxMessageEdge struct {
+	Cursor string              `json:"cursor"`
+	Node   *EmailOutboxMessage `json:"node"`
+}
+
+// An email rendered from one of the email templates, so its wording can be proofread without sending it to anyone
+type EmailPreview struct {
+	// The name of the email template the email was rendered from
+	TemplateName string `json:"templateName"`
+	Subject      string `json:"subject"`
+	// The HTML body of the email, exactly as it would be sent
+	Body string `json:"body"`
+}
+
 // GRBReviewerComparison represents an individual GRB Reviewer within the context of a
 // comparison operation between two system intakes.
 //
@@ -408,6 +599,13 @@ type GRBReviewerComparisonIntake struct {
 	IntakeCreatedAt *time.Time               `json:"intakeCreatedAt,omitempty"`
 }
 
+// Sent to subscribers of a GRB review's voting information when it changes
+type GRBVotingInformationChanged struct {
+	ChangeType           GRBVotingInformationChangeType `json:"changeType"`
+	SystemIntakeID       uuid.UUID                      `json:"systemIntakeID"`
+	GrbVotingInformation *GRBVotingInformation          `json:"grbVotingInformation"`
+}
+
 // The current user's Launch Darkly key
 type LaunchDarklySettings struct {
 	UserKey    string `json:"userKey"`
@@ -418,6 +616,27 @@ type LaunchDarklySettings struct {
 type Mutation struct {
 }
 
+// A page of the current user's notifications, ordered newest first
+type NotificationConnection struct {
+	Edges    []*NotificationEdge `json:"edges"`
+	PageInfo *PageInfo           `json:"pageInfo"`
+	// The number of unread notifications in the user's inbox, across every page
+	UnreadCount int `json:"unreadCount"`
+}
+
+type NotificationEdge struct {
+	Cursor string        `json:"cursor"`
+	Node   *Notification `json:"node"`
+}
+
+// Information about a page of results in a cursor paginated connection
+type PageInfo struct {
+	// Whether there are more results after this page
+	HasNextPage bool `json:"hasNextPage"`
+	// The cursor of the last result in this page, pass it as "after" to fetch the next page
+	EndCursor *string `json:"endCursor,omitempty"`
+}
+
 // Query definition for the schema
 type Query struct {
 }
@@ -446,6 +665,26 @@ type RestartGRBReviewInput struct {
 	NewGRBEndDate  time.Time `json:"newGRBEndDate"`
 }
 
+// A page of search results, best matches first
+type SearchResultConnection struct {
+	Edges    []*SearchResultEdge `json:"edges"`
+	PageInfo *PageInfo           `json:"pageInfo"`
+}
+
+type SearchResultEdge struct {
+	Cursor string        `json:"cursor"`
+	Node   *SearchResult `json:"node"`
+}
+
+// The data needed to send an amended TRB guidance letter, including why it was amended and who to notify
+type SendAmendedTRBGuidanceLetterInput struct {
+	ID               uuid.UUID `json:"id"`
+	AmendmentSummary HTML      `json:"amendmentSummary"`
+	CopyITGovMailbox bool      `json:"copyITGovMailbox"`
+	CopyTrbMailbox   bool      `json:"copyTrbMailbox"`
+	NotifyEuaIds     []string  `json:"notifyEuaIds"`
+}
+
 type SendCantFindSomethingEmailInput struct {
 	Body string `json:"body"`
 }
@@ -633,6 +872,12 @@ type SystemIntakeConfirmLCIDInput struct {
 	AdminNote              *HTML                        `json:"adminNote,omitempty"`
 }
 
+// A page of System Intakes in the admin view
+type SystemIntakeConnection struct {
+	Edges    []*SystemIntakeEdge `json:"edges"`
+	PageInfo *PageInfo           `json:"pageInfo"`
+}
+
 // Represents a contract for work on a system
 type SystemIntakeContract struct {
 	Contractor  *string       `json:"contractor,omitempty"`
@@ -672,6 +917,11 @@ type SystemIntakeDocumentType struct {
 	OtherTypeDescription *string                        `json:"otherTypeDescription,omitempty"`
 }
 
+type SystemIntakeEdge struct {
+	Cursor string        `json:"cursor"`
+	Node   *SystemIntake `json:"node"`
+}
+
 // Input for expiring an intake's LCID in IT Gov v2
 type SystemIntakeExpireLCIDInput struct {
 	SystemIntakeID         uuid.UUID                    `json:"systemIntakeID"`
@@ -884,6 +1134,28 @@ type SystemIntakeUpdateLCIDInput struct {
 	AdminNote              *HTML                         `json:"adminNote,omitempty"`
 }
 
+// Filters for System Intakes in the admin view. Every filter that is set must match
+type SystemIntakesFilter struct {
+	State         *SystemIntakeState        `json:"state,omitempty"`
+	AdminStatuses []SystemIntakeStatusAdmin `json:"adminStatuses,omitempty"`
+	// Matches the admin lead's name exactly
+	AdminLead       *string                   `json:"adminLead,omitempty"`
+	LcidStatuses    []SystemIntakeLCIDStatus  `json:"lcidStatuses,omitempty"`
+	RequestTypes    []SystemIntakeRequestType `json:"requestTypes,omitempty"`
+	SubmittedAfter  *time.Time                `json:"submittedAfter,omitempty"`
+	SubmittedBefore *time.Time                `json:"submittedBefore,omitempty"`
+	UpdatedAfter    *time.Time                `json:"updatedAfter,omitempty"`
+	UpdatedBefore   *time.Time                `json:"updatedBefore,omitempty"`
+	// Matches part of the project name or acronym, requester, component, admin lead or LCID, ignoring case
+	Search *string `json:"search,omitempty"`
+}
+
+// How to sort System Intakes in the admin view. Defaults to the most recently submitted first
+type SystemIntakesSort struct {
+	Field     SystemIntakesSortField `json:"field"`
+	Direction SortDirection          `json:"direction"`
+}
+
 // Status of a locked section of the system profile form
 type SystemProfileSectionLockStatus struct {
 	CedarSystemID       uuid.UUID                    `json:"cedarSystemId"`
@@ -949,6 +1221,12 @@ type TRBAdminNoteSupportingDocumentsCategoryData struct {
 
 func (TRBAdminNoteSupportingDocumentsCategoryData) IsTRBAdminNoteCategorySpecificData() {}
 
+// A page of TRB Requests in the admin view
+type TRBRequestConnection struct {
+	Edges    []*TRBRequestEdge `json:"edges"`
+	PageInfo *PageInfo         `json:"pageInfo"`
+}
+
 // Denotes the type of a document attached to a TRB request,
 // which can be one of a number of common types, or a free-text user-specified type
 type TRBRequestDocumentType struct {
@@ -956,6 +1234,84 @@ type TRBRequestDocumentType struct {
 	OtherTypeDescription *string               `json:"otherTypeDescription,omitempty"`
 }
 
+type TRBRequestEdge struct {
+	Cursor string      `json:"cursor"`
+	Node   *TRBRequest `json:"node"`
+}
+
+// Filters for TRB Requests in the admin view. Every filter that is set must match
+type TRBRequestsFilter struct {
+	Archived bool               `json:"archived"`
+	State    *TRBRequestState   `json:"state,omitempty"`
+	Statuses []TRBRequestStatus `json:"statuses,omitempty"`
+	// Matches the EUA ID of the TRB lead
+	TrbLead         *string          `json:"trbLead,omitempty"`
+	RequestTypes    []TRBRequestType `json:"requestTypes,omitempty"`
+	SubmittedAfter  *time.Time       `json:"submittedAfter,omitempty"`
+	SubmittedBefore *time.Time       `json:"submittedBefore,omitempty"`
+	UpdatedAfter    *time.Time       `json:"updatedAfter,omitempty"`
+	UpdatedBefore   *time.Time       `json:"updatedBefore,omitempty"`
+	// Matches part of the request name, contract name, requester EUA ID or component, ignoring case
+	Search *string `json:"search,omitempty"`
+}
+
+// How to sort TRB Requests in the admin view. Defaults to the most recently submitted first
+type TRBRequestsSort struct {
+	Field     TRBRequestsSortField `json:"field"`
+	Direction SortDirection        `json:"direction"`
+}
+
+// The data needed to edit one of a CEDAR system's budgets
+type UpdateCedarBudgetInput struct {
+	CedarSystemID uuid.UUID `json:"cedarSystemId"`
+	BudgetID      string    `json:"budgetId"`
+	// The concurrencyToken of the budget the edit is based on
+	ConcurrencyToken string            `json:"concurrencyToken"`
+	Budget           *CedarBudgetInput `json:"budget"`
+}
+
+// The data needed to edit one of a CEDAR system's contracts
+type UpdateCedarContractInput struct {
+	CedarSystemID uuid.UUID `json:"cedarSystemId"`
+	ContractID    string    `json:"contractId"`
+	// The concurrencyToken of the contract the edit is based on
+	ConcurrencyToken string              `json:"concurrencyToken"`
+	Contract         *CedarContractInput `json:"contract"`
+}
+
+// The data needed to edit one of a CEDAR system's deployments
+type UpdateCedarDeploymentInput struct {
+	CedarSystemID uuid.UUID `json:"cedarSystemId"`
+	DeploymentID  string    `json:"deploymentId"`
+	// The concurrencyToken of the deployment the edit is based on
+	ConcurrencyToken string                `json:"concurrencyToken"`
+	Deployment       *CedarDeploymentInput `json:"deployment"`
+}
+
+// The data needed to edit one of a CEDAR system's exchanges
+type UpdateCedarExchangeInput struct {
+	CedarSystemID uuid.UUID `json:"cedarSystemId"`
+	ExchangeID    string    `json:"exchangeId"`
+	// The concurrencyToken of the exchange the edit is based on
+	ConcurrencyToken string              `json:"concurrencyToken"`
+	Exchange         *CedarExchangeInput `json:"exchange"`
+}
+
+// The data needed to edit one of a CEDAR system's URLs
+type UpdateCedarURLInput struct {
+	CedarSystemID uuid.UUID `json:"cedarSystemId"`
+	URLID         string    `json:"urlId"`
+	// The concurrencyToken of the URL the edit is based on
+	ConcurrencyToken string         `json:"concurrencyToken"`
+	URL              *CedarURLInput `json:"url"`
+}
+
+// The parameters needed to change how the current user receives a category of notification
+type UpdateNotificationPreferenceInput struct {
+	Category  NotificationCategory  `json:"category"`
+	Frequency NotificationFrequency `json:"frequency"`
+}
+
 // Input data used to update the admin lead assigned to a system IT governance
 // request
 type UpdateSystemIntakeAdminLeadInput struct {
@@ -1085,6 +1441,14 @@ type UpdateTRBRequestTRBLeadInput struct {
 	TrbLead      string    `json:"trbLead"`
 }
 
+type UpdateWebhookSubscriptionInput struct {
+	ID         uuid.UUID          `json:"id"`
+	Name       string             `json:"name"`
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+	IsActive   bool               `json:"isActive"`
+}
+
 // Data needed to upload a presentation deck
 type UploadSystemIntakeGRBPresentationDeckInput struct {
 	SystemIntakeID           uuid.UUID       `json:"systemIntakeID"`
@@ -1098,6 +1462,17 @@ type UserError struct {
 	Path    []string `json:"path"`
 }
 
+// A page of the webhook delivery log, ordered newest first
+type WebhookDeliveryConnection struct {
+	Edges    []*WebhookDeliveryEdge `json:"edges"`
+	PageInfo *PageInfo              `json:"pageInfo"`
+}
+
+type WebhookDeliveryEdge struct {
+	Cursor string           `json:"cursor"`
+	Node   *WebhookDelivery `json:"node"`
+}
+
 type CreateSystemIntakeGRBDiscussionPostInput struct {
 	SystemIntakeID      uuid.UUID                          `json:"systemIntakeID"`
 	DiscussionBoardType SystemIntakeGRBDiscussionBoardType `json:"discussionBoardType"`
@@ -1124,6 +1499,14 @@ type UpdateSystemIntakeGRBReviewFormInputPresentationStandard struct {
 }
 
 // Input data used to set or update a System Intake's GRB Review Timeframe (Async) data
+type UpdateSystemIntakeGRBReviewFormInputQuorumPolicy struct {
+	SystemIntakeID    uuid.UUID                     `json:"systemIntakeID"`
+	MinimumVotes      int                           `json:"minimumVotes"`
+	VotingPercentage  *int                          `json:"votingPercentage,omitempty"`
+	RequiredRoles     []SystemIntakeGRBReviewerRole `json:"requiredRoles"`
+	RequiredRolesRule GRBQuorumRequiredRolesRule    `json:"requiredRolesRule"`
+}
+
 type UpdateSystemIntakeGRBReviewFormInputTimeframeAsync struct {
 	SystemIntakeID        uuid.UUID `json:"systemIntakeID"`
 	GrbReviewAsyncEndDate time.Time `json:"grbReviewAsyncEndDate"`
@@ -1137,6 +1520,256 @@ type UpdateSystemIntakeGRBReviewTypeInput struct {
 	GrbReviewType  SystemIntakeGRBReviewType `json:"grbReviewType"`
 }
 
+// The kind of change recorded in the audit history
+type AuditChangeAction string
+
+const (
+	AuditChangeActionInsert AuditChangeAction = "INSERT"
+	AuditChangeActionUpdate AuditChangeAction = "UPDATE"
+	AuditChangeActionDelete AuditChangeAction = "DELETE"
+)
+
+var AllAuditChangeAction = []AuditChangeAction{
+	AuditChangeActionInsert,
+	AuditChangeActionUpdate,
+	AuditChangeActionDelete,
+}
+
+func (e AuditChangeAction) IsValid() bool {
+	switch e {
+	case AuditChangeActionInsert, AuditChangeActionUpdate, AuditChangeActionDelete:
+		return true
+	}
+	return false
+}
+
+func (e AuditChangeAction) String() string {
+	return string(e)
+}
+
+func (e *AuditChangeAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuditChangeAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditChangeAction", str)
+	}
+	return nil
+}
+
+func (e AuditChangeAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *AuditChangeAction) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e AuditChangeAction) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// The types of entity whose changes are recorded in the audit history
+type AuditEntityType string
+
+const (
+	AuditEntityTypeSystemIntake    AuditEntityType = "SYSTEM_INTAKE"
+	AuditEntityTypeBusinessCase    AuditEntityType = "BUSINESS_CASE"
+	AuditEntityTypeTrbRequestForm  AuditEntityType = "TRB_REQUEST_FORM"
+	AuditEntityTypeCedarDeployment AuditEntityType = "CEDAR_DEPLOYMENT"
+	AuditEntityTypeCedarExchange   AuditEntityType = "CEDAR_EXCHANGE"
+	AuditEntityTypeCedarContract   AuditEntityType = "CEDAR_CONTRACT"
+	AuditEntityTypeCedarBudget     AuditEntityType = "CEDAR_BUDGET"
+	AuditEntityTypeCedarURL        AuditEntityType = "CEDAR_URL"
+)
+
+var AllAuditEntityType = []AuditEntityType{
+	AuditEntityTypeSystemIntake,
+	AuditEntityTypeBusinessCase,
+	AuditEntityTypeTrbRequestForm,
+	AuditEntityTypeCedarDeployment,
+	AuditEntityTypeCedarExchange,
+	AuditEntityTypeCedarContract,
+	AuditEntityTypeCedarBudget,
+	AuditEntityTypeCedarURL,
+}
+
+func (e AuditEntityType) IsValid() bool {
+	switch e {
+	case AuditEntityTypeSystemIntake, AuditEntityTypeBusinessCase, AuditEntityTypeTrbRequestForm, AuditEntityTypeCedarDeployment, AuditEntityTypeCedarExchange, AuditEntityTypeCedarContract, AuditEntityTypeCedarBudget, AuditEntityTypeCedarURL:
+		return true
+	}
+	return false
+}
+
+func (e AuditEntityType) String() string {
+	return string(e)
+}
+
+func (e *AuditEntityType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuditEntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditEntityType", str)
+	}
+	return nil
+}
+
+func (e AuditEntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *AuditEntityType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e AuditEntityType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Determines if a vote from any, or from all, of a quorum policy's required roles is needed to reach quorum
+type GRBQuorumRequiredRolesRule string
+
+const (
+	GRBQuorumRequiredRolesRuleAnyRole  GRBQuorumRequiredRolesRule = "ANY_ROLE"
+	GRBQuorumRequiredRolesRuleAllRoles GRBQuorumRequiredRolesRule = "ALL_ROLES"
+)
+
+var AllGRBQuorumRequiredRolesRule = []GRBQuorumRequiredRolesRule{
+	GRBQuorumRequiredRolesRuleAnyRole,
+	GRBQuorumRequiredRolesRuleAllRoles,
+}
+
+func (e GRBQuorumRequiredRolesRule) IsValid() bool {
+	switch e {
+	case GRBQuorumRequiredRolesRuleAnyRole, GRBQuorumRequiredRolesRuleAllRoles:
+		return true
+	}
+	return false
+}
+
+func (e GRBQuorumRequiredRolesRule) String() string {
+	return string(e)
+}
+
+func (e *GRBQuorumRequiredRolesRule) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GRBQuorumRequiredRolesRule(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GRBQuorumRequiredRolesRule", str)
+	}
+	return nil
+}
+
+func (e GRBQuorumRequiredRolesRule) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *GRBQuorumRequiredRolesRule) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e GRBQuorumRequiredRolesRule) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// The change to a GRB review that caused its voting information to change
+type GRBVotingInformationChangeType string
+
+const (
+	GRBVotingInformationChangeTypeVoteCast            GRBVotingInformationChangeType = "VOTE_CAST"
+	GRBVotingInformationChangeTypeReviewerAdded       GRBVotingInformationChangeType = "REVIEWER_ADDED"
+	GRBVotingInformationChangeTypeReviewerUpdated     GRBVotingInformationChangeType = "REVIEWER_UPDATED"
+	GRBVotingInformationChangeTypeReviewerRemoved     GRBVotingInformationChangeType = "REVIEWER_REMOVED"
+	GRBVotingInformationChangeTypeDeadlineExtended    GRBVotingInformationChangeType = "DEADLINE_EXTENDED"
+	GRBVotingInformationChangeTypeReviewRestarted     GRBVotingInformationChangeType = "REVIEW_RESTARTED"
+	GRBVotingInformationChangeTypeVotingEnded         GRBVotingInformationChangeType = "VOTING_ENDED"
+	GRBVotingInformationChangeTypeQuorumPolicyUpdated GRBVotingInformationChangeType = "QUORUM_POLICY_UPDATED"
+)
+
+var AllGRBVotingInformationChangeType = []GRBVotingInformationChangeType{
+	GRBVotingInformationChangeTypeVoteCast,
+	GRBVotingInformationChangeTypeReviewerAdded,
+	GRBVotingInformationChangeTypeReviewerUpdated,
+	GRBVotingInformationChangeTypeReviewerRemoved,
+	GRBVotingInformationChangeTypeDeadlineExtended,
+	GRBVotingInformationChangeTypeReviewRestarted,
+	GRBVotingInformationChangeTypeVotingEnded,
+	GRBVotingInformationChangeTypeQuorumPolicyUpdated,
+}
+
+func (e GRBVotingInformationChangeType) IsValid() bool {
+	switch e {
+	case GRBVotingInformationChangeTypeVoteCast, GRBVotingInformationChangeTypeReviewerAdded, GRBVotingInformationChangeTypeReviewerUpdated, GRBVotingInformationChangeTypeReviewerRemoved, GRBVotingInformationChangeTypeDeadlineExtended, GRBVotingInformationChangeTypeReviewRestarted, GRBVotingInformationChangeTypeVotingEnded, GRBVotingInformationChangeTypeQuorumPolicyUpdated:
+		return true
+	}
+	return false
+}
+
+func (e GRBVotingInformationChangeType) String() string {
+	return string(e)
+}
+
+func (e *GRBVotingInformationChangeType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GRBVotingInformationChangeType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GRBVotingInformationChangeType", str)
+	}
+	return nil
+}
+
+func (e GRBVotingInformationChangeType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *GRBVotingInformationChangeType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e GRBVotingInformationChangeType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type LockChangeType string
 
 const (
@@ -1255,6 +1888,62 @@ func (e Role) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// The direction results are sorted in
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "ASC"
+	SortDirectionDesc SortDirection = "DESC"
+)
+
+var AllSortDirection = []SortDirection{
+	SortDirectionAsc,
+	SortDirectionDesc,
+}
+
+func (e SortDirection) IsValid() bool {
+	switch e {
+	case SortDirectionAsc, SortDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e SortDirection) String() string {
+	return string(e)
+}
+
+func (e *SortDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortDirection", str)
+	}
+	return nil
+}
+
+func (e SortDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SortDirection) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SortDirection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 // Represents the type of an action that is being done to a system request
 type SystemIntakeActionType string
 
@@ -1535,6 +2224,61 @@ func (e SystemIntakeGRBDiscussionBoardType) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type SystemIntakeGRBDiscussionChangeType string
+
+const (
+	SystemIntakeGRBDiscussionChangeTypePostCreated  SystemIntakeGRBDiscussionChangeType = "POST_CREATED"
+	SystemIntakeGRBDiscussionChangeTypeReplyCreated SystemIntakeGRBDiscussionChangeType = "REPLY_CREATED"
+)
+
+var AllSystemIntakeGRBDiscussionChangeType = []SystemIntakeGRBDiscussionChangeType{
+	SystemIntakeGRBDiscussionChangeTypePostCreated,
+	SystemIntakeGRBDiscussionChangeTypeReplyCreated,
+}
+
+func (e SystemIntakeGRBDiscussionChangeType) IsValid() bool {
+	switch e {
+	case SystemIntakeGRBDiscussionChangeTypePostCreated, SystemIntakeGRBDiscussionChangeTypeReplyCreated:
+		return true
+	}
+	return false
+}
+
+func (e SystemIntakeGRBDiscussionChangeType) String() string {
+	return string(e)
+}
+
+func (e *SystemIntakeGRBDiscussionChangeType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SystemIntakeGRBDiscussionChangeType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SystemIntakeGRBDiscussionChangeType", str)
+	}
+	return nil
+}
+
+func (e SystemIntakeGRBDiscussionChangeType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SystemIntakeGRBDiscussionChangeType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SystemIntakeGRBDiscussionChangeType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 // The status type of the System Intake GRB Review (For Async Reviews only)
 type SystemIntakeGRBReviewAsyncStatusType string
 
@@ -1903,6 +2647,119 @@ func (e SystemIntakeStepToProgressTo) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// The dates System Intakes can be sorted by in the admin view
+type SystemIntakesSortField string
+
+const (
+	SystemIntakesSortFieldSubmittedAt SystemIntakesSortField = "SUBMITTED_AT"
+	SystemIntakesSortFieldUpdatedAt   SystemIntakesSortField = "UPDATED_AT"
+)
+
+var AllSystemIntakesSortField = []SystemIntakesSortField{
+	SystemIntakesSortFieldSubmittedAt,
+	SystemIntakesSortFieldUpdatedAt,
+}
+
+func (e SystemIntakesSortField) IsValid() bool {
+	switch e {
+	case SystemIntakesSortFieldSubmittedAt, SystemIntakesSortFieldUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e SystemIntakesSortField) String() string {
+	return string(e)
+}
+
+func (e *SystemIntakesSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SystemIntakesSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SystemIntakesSortField", str)
+	}
+	return nil
+}
+
+func (e SystemIntakesSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SystemIntakesSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SystemIntakesSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// The dates TRB Requests can be sorted by in the admin view
+type TRBRequestsSortField string
+
+const (
+	// When the request form was submitted. Requests that haven't been submitted are sorted by when they were created
+	TRBRequestsSortFieldSubmittedAt TRBRequestsSortField = "SUBMITTED_AT"
+	TRBRequestsSortFieldUpdatedAt   TRBRequestsSortField = "UPDATED_AT"
+)
+
+var AllTRBRequestsSortField = []TRBRequestsSortField{
+	TRBRequestsSortFieldSubmittedAt,
+	TRBRequestsSortFieldUpdatedAt,
+}
+
+func (e TRBRequestsSortField) IsValid() bool {
+	switch e {
+	case TRBRequestsSortFieldSubmittedAt, TRBRequestsSortFieldUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e TRBRequestsSortField) String() string {
+	return string(e)
+}
+
+func (e *TRBRequestsSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TRBRequestsSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TRBRequestsSortField", str)
+	}
+	return nil
+}
+
+func (e TRBRequestsSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TRBRequestsSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TRBRequestsSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type TagType string
 
 const (
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification is an entry in a user's in-app notification inbox. One is created for every user account an email is sent to
+type Notification struct {
+	ID     uuid.UUID `json:"id" db:"id"`
+	UserID uuid.UUID `json:"userId" db:"user_id"`
+	// Category is nil for notifications that are always sent immediately, such as legally required notices
+	Category  *NotificationCategory `json:"category" db:"category"`
+	Subject   string                `json:"subject" db:"subject"`
+	Body      HTML                  `json:"body" db:"body"`
+	ReadAt    *time.Time            `json:"readAt" db:"read_at"`
+	CreatedAt time.Time             `json:"createdAt" db:"created_at"`
+}
+
+// Cursor returns the position of the notification in its user's inbox, which is ordered newest first
+func (n *Notification) Cursor() PageCursor {
+	return PageCursor{
+		SortValue: n.CreatedAt,
+		ID:        n.ID,
+	}
+}
+
+// NotificationCreatedEvent is the payload published when a notification is added to a user's inbox.
+// Notifications can be too large to publish, so subscribers load the notification themselves
+type NotificationCreatedEvent struct {
+	NotificationID uuid.UUID `json:"notificationId"`
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationCategory is a group of related notifications that users can choose how to receive.
+// Notifications that are legally required, such as LCID issuance and decisions on requests, don't belong to a category,
+// so they are always sent immediately
+type NotificationCategory string
+
+// These are the categories of notification users can choose how to receive
+const (
+	NotificationCategoryGRBDiscussions   NotificationCategory = "GRB_DISCUSSIONS"
+	NotificationCategoryGRBReviews       NotificationCategory = "GRB_REVIEWS"
+	NotificationCategoryTRBRequests      NotificationCategory = "TRB_REQUESTS"
+	NotificationCategorySystemWorkspaces NotificationCategory = "SYSTEM_WORKSPACES"
+)
+
+// AllNotificationCategories are every category of notification, in the order they're shown to users
+var AllNotificationCategories = []NotificationCategory{
+	NotificationCategoryGRBDiscussions,
+	NotificationCategoryGRBReviews,
+	NotificationCategoryTRBRequests,
+	NotificationCategorySystemWorkspaces,
+}
+
+// Humanize returns the name of the category as it's shown to users
+func (c NotificationCategory) Humanize() string {
+	switch c {
+	case NotificationCategoryGRBDiscussions:
+		return "GRB discussions"
+	case NotificationCategoryGRBReviews:
+		return "GRB reviews"
+	case NotificationCategoryTRBRequests:
+		return "TRB requests"
+	case NotificationCategorySystemWorkspaces:
+		return "System workspaces"
+	}
+	return string(c)
+}
+
+// NotificationFrequency is how a user wants to receive a category of notification
+type NotificationFrequency string
+
+// These are the ways a user can receive a category of notification
+const (
+	NotificationFrequencyImmediate   NotificationFrequency = "IMMEDIATE"
+	NotificationFrequencyDailyDigest NotificationFrequency = "DAILY_DIGEST"
+	NotificationFrequencyOff         NotificationFrequency = "OFF"
+)
+
+// NotificationPreference is how a user wants to receive a category of notification
+type NotificationPreference struct {
+	Category  NotificationCategory  `json:"category" db:"category"`
+	Frequency NotificationFrequency `json:"frequency" db:"frequency"`
+}
+
+// NotificationRecipientPreference is the preference of the user account with a given email address for a category of notification
+type NotificationRecipientPreference struct {
+	UserID    uuid.UUID             `db:"user_id"`
+	Email     EmailAddress          `db:"email"`
+	Frequency NotificationFrequency `db:"frequency"`
+}
+
+// NotificationDigestItem is a notification held back to be sent in a user's daily digest email
+type NotificationDigestItem struct {
+	ID        uuid.UUID            `json:"id" db:"id"`
+	UserID    uuid.UUID            `json:"userId" db:"user_id"`
+	Category  NotificationCategory `json:"category" db:"category"`
+	Subject   string               `json:"subject" db:"subject"`
+	Body      HTML                 `json:"body" db:"body"`
+	CreatedAt time.Time            `json:"createdAt" db:"created_at"`
+	SentAt    *time.Time           `json:"sentAt" db:"sent_at"`
+}
+
+// NotificationPreferencesWithDefaults returns a preference for every category, in the order they're shown to users.
+// Categories the user hasn't set a preference for are sent immediately
+func NotificationPreferencesWithDefaults(preferences []*NotificationPreference) []*NotificationPreference {
+	frequencies := map[NotificationCategory]NotificationFrequency{}
+	for _, preference := range preferences {
+		frequencies[preference.Category] = preference.Frequency
+	}
+
+	withDefaults := make([]*NotificationPreference, len(AllNotificationCategories))
+	for i, category := range AllNotificationCategories {
+		frequency, ok := frequencies[category]
+		if !ok {
+			frequency = NotificationFrequencyImmediate
+		}
+		withDefaults[i] = &NotificationPreference{
+			Category:  category,
+			Frequency: frequency,
+		}
+	}
+	return withDefaults
+}
@@ -0,0 +1,15 @@
+package models
+
+func (s *ModelTestSuite) TestNotificationPreferencesWithDefaults() {
+	preferences := NotificationPreferencesWithDefaults([]*NotificationPreference{
+		{Category: NotificationCategoryTRBRequests, Frequency: NotificationFrequencyOff},
+		{Category: NotificationCategoryGRBDiscussions, Frequency: NotificationFrequencyDailyDigest},
+	})
+
+	s.Equal([]*NotificationPreference{
+		{Category: NotificationCategoryGRBDiscussions, Frequency: NotificationFrequencyDailyDigest},
+		{Category: NotificationCategoryGRBReviews, Frequency: NotificationFrequencyImmediate},
+		{Category: NotificationCategoryTRBRequests, Frequency: NotificationFrequencyOff},
+		{Category: NotificationCategorySystemWorkspaces, Frequency: NotificationFrequencyImmediate},
+	}, preferences)
+}
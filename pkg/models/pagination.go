@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PageCursor identifies the position of a result in a cursor paginated list, which is ordered by a timestamp and then by ID
+type PageCursor struct {
+	SortValue time.Time `json:"sortValue"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// Encode returns the opaque string representation of the cursor that is sent to clients
+func (c PageCursor) Encode() string {
+	return encodeCursor(c)
+}
+
+// DecodePageCursor parses a cursor previously returned by PageCursor.Encode
+func DecodePageCursor(cursor string) (*PageCursor, error) {
+	return decodeCursor[PageCursor](cursor)
+}
+
+// encodeCursor encodes a cursor as an opaque string, hiding its structure from clients
+func encodeCursor(cursor any) string {
+	encoded, _ := json.Marshal(cursor)
+	return base64.URLEncoding.EncodeToString(encoded)
+}
+
+// decodeCursor parses a cursor previously encoded by encodeCursor
+func decodeCursor[T any](cursor string) (*T, error) {
+	decoded, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var parsed T
+	if err := json.Unmarshal(decoded, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return &parsed, nil
+}
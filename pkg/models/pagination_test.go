@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *ModelTestSuite) TestPageCursor() {
+	cursor := PageCursor{
+		SortValue: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+		ID:        uuid.New(),
+	}
+
+	decoded, err := DecodePageCursor(cursor.Encode())
+	s.NoError(err)
+	s.Equal(cursor.ID, decoded.ID)
+	s.True(cursor.SortValue.Equal(decoded.SortValue))
+
+	_, err = DecodePageCursor("not a cursor")
+	s.Error(err)
+}
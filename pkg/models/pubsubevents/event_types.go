@@ -1,8 +1,32 @@
 package pubsubevents
 
-import "github.com/cms-enterprise/easi-app/pkg/pubsub"
+import (
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+)
 
 const (
 	// SystemProfileSectionLocksChanged is an event sent to subscribers indicating a change that has occurred
 	SystemProfileSectionLocksChanged pubsub.EventType = "system_profile_section.changed"
+
+	// SystemIntakeGRBDiscussionChanged is an event sent to subscribers indicating a post or reply was made on a GRB discussion board
+	SystemIntakeGRBDiscussionChanged pubsub.EventType = "system_intake_grb_discussion.changed"
+
+	// GRBVotingInformationChanged is an event sent to subscribers indicating a change that affects a GRB review's voting information
+	GRBVotingInformationChanged pubsub.EventType = "grb_voting_information.changed"
+
+	// NotificationCreated is an event sent to subscribers indicating a notification was added to a user's inbox
+	NotificationCreated pubsub.EventType = "notification.created"
+
+	// CEDARCacheEvicted is an event sent to every instance indicating cached CEDAR Core responses were evicted on one of them
+	CEDARCacheEvicted pubsub.EventType = "cedar_cache.evicted"
 )
+
+// register the payload published with each event so it can be rebuilt when events are carried between instances
+func init() {
+	pubsub.RegisterPayloadType(SystemProfileSectionLocksChanged, models.SystemProfileSectionLockStatusChanged{})
+	pubsub.RegisterPayloadType(SystemIntakeGRBDiscussionChanged, models.SystemIntakeGRBDiscussionChanged{})
+	pubsub.RegisterPayloadType(GRBVotingInformationChanged, models.GRBVotingInformationChangedEvent{})
+	pubsub.RegisterPayloadType(NotificationCreated, models.NotificationCreatedEvent{})
+	pubsub.RegisterPayloadType(CEDARCacheEvicted, models.CEDARCacheEvictedEvent{})
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"html"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SearchSnippetStart and SearchSnippetStop surround the matching words in a raw search snippet.
+// They are private use characters so they can't be confused with text in the content, and are replaced after the snippet is escaped
+const (
+	SearchSnippetStart = "\uE000"
+	SearchSnippetStop  = "\uE001"
+)
+
+// searchSnippetHighlighter replaces the markers around matching words in an escaped snippet with HTML tags
+var searchSnippetHighlighter = strings.NewReplacer(SearchSnippetStart, "<mark>", SearchSnippetStop, "</mark>")
+
+// SearchResultType is the kind of content a SearchResult matched
+type SearchResultType string
+
+// These are the kinds of content that can be searched
+const (
+	SearchResultTypeSystemIntake             SearchResultType = "SYSTEM_INTAKE"
+	SearchResultTypeBusinessCase             SearchResultType = "BUSINESS_CASE"
+	SearchResultTypeTRBRequestForm           SearchResultType = "TRB_REQUEST_FORM"
+	SearchResultTypeTRBGuidanceLetterInsight SearchResultType = "TRB_GUIDANCE_LETTER_INSIGHT"
+	SearchResultTypeSystemIntakeNote         SearchResultType = "SYSTEM_INTAKE_NOTE"
+	SearchResultTypeTRBAdminNote             SearchResultType = "TRB_ADMIN_NOTE"
+)
+
+// AllSearchResultTypes are every kind of content that can be searched
+var AllSearchResultTypes = []SearchResultType{
+	SearchResultTypeSystemIntake,
+	SearchResultTypeBusinessCase,
+	SearchResultTypeTRBRequestForm,
+	SearchResultTypeTRBGuidanceLetterInsight,
+	SearchResultTypeSystemIntakeNote,
+	SearchResultTypeTRBAdminNote,
+}
+
+// SearchViewer is who a full text search is run for, as the results are limited to the content they can view
+type SearchViewer struct {
+	IsGRTAdmin bool
+	IsTRBAdmin bool
+	IsEASiUser bool
+	// UserID and EUAUserID are nil for principals without a user account, who can only view content as an admin
+	UserID    *uuid.UUID
+	EUAUserID *string
+}
+
+// SearchResult is a piece of content matching a full text search
+type SearchResult struct {
+	ID         uuid.UUID        `json:"id" db:"id"`
+	Type       SearchResultType `json:"type" db:"type"`
+	RequestID  uuid.UUID        `json:"requestID" db:"request_id"`
+	Title      string           `json:"title" db:"title"`
+	RawSnippet string           `json:"-" db:"snippet"`
+	Rank       float64          `json:"rank" db:"rank"`
+}
+
+// Snippet returns the excerpt of the matching content as HTML, with the matching words wrapped in <mark> tags
+func (r *SearchResult) Snippet() string {
+	return searchSnippetHighlighter.Replace(html.EscapeString(r.RawSnippet))
+}
+
+// Cursor returns the cursor pointing at this result in the search results, which are ordered best match first
+func (r *SearchResult) Cursor() SearchResultCursor {
+	return SearchResultCursor{
+		Rank: r.Rank,
+		ID:   r.ID,
+	}
+}
+
+// SearchResultCursor identifies the position of a result in the search results
+type SearchResultCursor struct {
+	Rank float64   `json:"rank"`
+	ID   uuid.UUID `json:"id"`
+}
+
+// Encode returns the opaque string representation of the cursor that is sent to clients
+func (c SearchResultCursor) Encode() string {
+	return encodeCursor(c)
+}
+
+// DecodeSearchResultCursor parses a cursor previously returned by SearchResultCursor.Encode
+func DecodeSearchResultCursor(cursor string) (*SearchResultCursor, error) {
+	return decodeCursor[SearchResultCursor](cursor)
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+func (s *ModelTestSuite) TestSearchResultSnippet() {
+	result := &SearchResult{
+		RawSnippet: "a <script> about " + SearchSnippetStart + "cloud" + SearchSnippetStop + " hosting & more",
+	}
+
+	s.Equal("a &lt;script&gt; about <mark>cloud</mark> hosting &amp; more", result.Snippet())
+}
+
+func (s *ModelTestSuite) TestSearchResultCursor() {
+	result := &SearchResult{
+		ID:   uuid.New(),
+		Rank: 0.0607927106320858,
+	}
+
+	decoded, err := DecodeSearchResultCursor(result.Cursor().Encode())
+	s.NoError(err)
+	s.Equal(result.Rank, decoded.Rank)
+	s.Equal(result.ID, decoded.ID)
+
+	_, err = DecodeSearchResultCursor("not a cursor")
+	s.Error(err)
+}
@@ -188,6 +188,11 @@ type SystemIntake struct {
 	GrbReviewAsyncEndDate                             *time.Time                    `json:"grbReviewAsyncEndDate" db:"grb_review_async_end_date"`
 	GrbReviewAsyncManualEndDate                       *time.Time                    `json:"grbReviewAsyncManualEndDate" db:"grb_review_async_manual_end_date"`
 	GrbReviewReminderLastSent                         *time.Time                    `json:"grbReviewReminderLastSent" db:"grb_review_reminder_last_sent"`
+	// The quorum policy of the async GRB review, use GRBQuorumPolicy() to evaluate it with defaults applied
+	GrbQuorumMinimumVotes      int                                    `json:"grbQuorumMinimumVotes" db:"grb_quorum_minimum_votes"`
+	GrbQuorumVotingPercentage  *int                                   `json:"grbQuorumVotingPercentage" db:"grb_quorum_voting_percentage"`
+	GrbQuorumRequiredRoles     EnumArray[SystemIntakeGRBReviewerRole] `json:"grbQuorumRequiredRoles" db:"grb_quorum_required_roles"`
+	GrbQuorumRequiredRolesRule GRBQuorumRequiredRolesRule             `json:"grbQuorumRequiredRolesRule" db:"grb_quorum_required_roles_rule"`
 	// This bool says if an intake supports a system or not.
 	// It is set through setSystemSupportAndUnlinkSystemIntakeRelation mutation
 	DoesNotSupportSystems null.Bool `json:"doesNotSupportSystems" db:"does_not_support_systems"`
@@ -227,6 +232,27 @@ const (
 	SIMSNotScheduled SystemIntakeMeetingState = "NOT_SCHEDULED"
 )
 
+// GRBQuorumPolicy returns the quorum policy configured on the GRB review form, falling back to the defaults for anything unset
+func (si *SystemIntake) GRBQuorumPolicy() GRBQuorumPolicy {
+	policy := DefaultGRBQuorumPolicy()
+
+	if si.GrbQuorumMinimumVotes > 0 {
+		policy.MinimumVotes = si.GrbQuorumMinimumVotes
+	}
+
+	policy.VotingPercentage = si.GrbQuorumVotingPercentage
+
+	if si.GrbQuorumRequiredRoles != nil {
+		policy.RequiredRoles = si.GrbQuorumRequiredRoles
+	}
+
+	if si.GrbQuorumRequiredRolesRule != "" {
+		policy.RequiredRolesRule = si.GrbQuorumRequiredRolesRule
+	}
+
+	return policy
+}
+
 // GRTMeetingState returns if a GRTMeeting has been scheduled or not
 func (si *SystemIntake) GRTMeetingState() SystemIntakeMeetingState {
 	return isMeetingScheduled(si.GRTDate)
@@ -331,6 +357,21 @@ func (s RelatedSystemIntake) GetMappingVal() *SystemIntake {
 	return &s.SystemIntake
 }
 
+// SortedSystemIntake is used when intakes are selected from the DB for a cursor paginated list, with the value they are sorted by
+// added as an aliased column
+type SortedSystemIntake struct {
+	SystemIntake
+	SortValue time.Time `db:"sort_value"`
+}
+
+// Cursor returns the cursor pointing at this intake in the list it was selected for
+func (s SortedSystemIntake) Cursor() PageCursor {
+	return PageCursor{
+		SortValue: s.SortValue,
+		ID:        s.ID,
+	}
+}
+
 type SystemIntakesByCedarSystemIDsRequest struct {
 	CedarSystemID uuid.UUID
 	State         SystemIntakeState
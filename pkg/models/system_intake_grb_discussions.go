@@ -23,6 +23,16 @@ func NewSystemIntakeGRBReviewDiscussionPost(createdBy uuid.UUID) *SystemIntakeGR
 	}
 }
 
+// SystemIntakeGRBDiscussionChanged is the payload published when a post or reply is made on a GRB discussion board.
+// It only references the post, as the post's content can be larger than some PubSub implementations can carry.
+type SystemIntakeGRBDiscussionChanged struct {
+	ChangeType          SystemIntakeGRBDiscussionChangeType `json:"changeType"`
+	SystemIntakeID      uuid.UUID                           `json:"systemIntakeId"`
+	DiscussionBoardType SystemIntakeGRBDiscussionBoardType  `json:"discussionBoardType"`
+	InitialPostID       uuid.UUID                           `json:"initialPostId"`
+	PostID              uuid.UUID                           `json:"postId"`
+}
+
 // CreateGRBDiscussionsFromPosts sorts a slice of discussion posts (replies and initial) and sorts them into multiple discussions
 func CreateGRBDiscussionsFromPosts(posts []*SystemIntakeGRBReviewDiscussionPost, boardType SystemIntakeGRBDiscussionBoardType) ([]*SystemIntakeGRBReviewDiscussion, error) {
 	postMap := map[uuid.UUID][]*SystemIntakeGRBReviewDiscussionPost{}
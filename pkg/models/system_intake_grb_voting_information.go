@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // TODO: move to autogen
@@ -17,8 +19,85 @@ const (
 	GRBVSInconclusive GRBVotingInformationStatus = "INCONCLUSIVE"
 )
 
-// numberOfVotesForQuorum is the number of votes needed to reach quorum. If less than this amount of votes are cast, the GRB is considered inconclusive
-const numberOfVotesForQuorum = 1
+// GRBVotingInformationChangedEvent is the payload published when a change is made that affects the voting information
+// of a GRB review. Subscribers calculate the voting information themselves, so that what they are sent is limited to
+// what they are allowed to see.
+type GRBVotingInformationChangedEvent struct {
+	ChangeType     GRBVotingInformationChangeType `json:"changeType"`
+	SystemIntakeID uuid.UUID                      `json:"systemIntakeId"`
+}
+
+// defaultGRBQuorumMinimumVotes is the number of votes needed to reach quorum when a GRB review has not configured a different minimum
+const defaultGRBQuorumMinimumVotes = 1
+
+// GRBQuorumPolicy holds the rules a GRB review's votes must satisfy for it to reach quorum.
+// If quorum is not reached, the GRB is considered inconclusive
+type GRBQuorumPolicy struct {
+	// MinimumVotes is the minimum number of votes that must be cast by voting reviewers
+	MinimumVotes int `json:"minimumVotes"`
+	// VotingPercentage, when set, is the percentage (1-100) of voting reviewers that must cast a vote
+	VotingPercentage *int `json:"votingPercentage"`
+	// RequiredRoles are GRB reviewer roles that must have cast a vote, according to RequiredRolesRule
+	RequiredRoles EnumArray[SystemIntakeGRBReviewerRole] `json:"requiredRoles"`
+	// RequiredRolesRule determines if a vote from any, or from all, of the RequiredRoles is needed
+	RequiredRolesRule GRBQuorumRequiredRolesRule `json:"requiredRolesRule"`
+}
+
+// DefaultGRBQuorumPolicy returns the quorum policy used by GRB reviews that have not configured one
+func DefaultGRBQuorumPolicy() GRBQuorumPolicy {
+	return GRBQuorumPolicy{
+		MinimumVotes:      defaultGRBQuorumMinimumVotes,
+		RequiredRoles:     EnumArray[SystemIntakeGRBReviewerRole]{},
+		RequiredRolesRule: GRBQuorumRequiredRolesRuleAnyRole,
+	}
+}
+
+// Reached checks if the votes cast by the given reviewers satisfy the policy
+func (p GRBQuorumPolicy) Reached(reviewers []*SystemIntakeGRBReviewer) bool {
+	var numberOfVotingReviewers, numberOfVoted int
+	votedRoles := map[SystemIntakeGRBReviewerRole]struct{}{}
+
+	for _, reviewer := range reviewers {
+		// only count reviewers who have voting roles
+		if reviewer.GRBVotingRole != SystemIntakeGRBReviewerVotingRoleVoting {
+			continue
+		}
+
+		numberOfVotingReviewers++
+
+		if reviewer.Vote == nil {
+			continue
+		}
+
+		numberOfVoted++
+		votedRoles[reviewer.GRBReviewerRole] = struct{}{}
+	}
+
+	if numberOfVoted < p.MinimumVotes {
+		return false
+	}
+
+	if p.VotingPercentage != nil && numberOfVoted*100 < *p.VotingPercentage*numberOfVotingReviewers {
+		return false
+	}
+
+	if len(p.RequiredRoles) == 0 {
+		return true
+	}
+
+	var numberOfRequiredRolesVoted int
+	for _, role := range p.RequiredRoles {
+		if _, ok := votedRoles[role]; ok {
+			numberOfRequiredRolesVoted++
+		}
+	}
+
+	if p.RequiredRolesRule == GRBQuorumRequiredRolesRuleAllRoles {
+		return numberOfRequiredRolesVoted == len(p.RequiredRoles)
+	}
+
+	return numberOfRequiredRolesVoted > 0
+}
 
 // GRBVotingInformation is a struct that holds information about the GRB voting process
 // It is a convenience struct that holds a SystemIntake and its GRB reviewers
@@ -130,9 +209,18 @@ func (info *GRBVotingInformation) NumberOfVoted() int {
 	return count
 }
 
-// QuorumReached checks if the minimum number of votes have been cast
+// QuorumPolicy returns the quorum policy configured for the GRB review
+func (info *GRBVotingInformation) QuorumPolicy() GRBQuorumPolicy {
+	if info.SystemIntake == nil {
+		return DefaultGRBQuorumPolicy()
+	}
+
+	return info.SystemIntake.GRBQuorumPolicy()
+}
+
+// QuorumReached checks if the votes cast satisfy the GRB review's quorum policy
 func (info *GRBVotingInformation) QuorumReached() bool {
-	return info.NumberOfVoted() >= numberOfVotesForQuorum
+	return info.QuorumPolicy().Reached(info.reviewersForCounts())
 }
 
 func (info *GRBVotingInformation) votingEndedManually() bool {
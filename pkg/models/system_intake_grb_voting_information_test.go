@@ -143,7 +143,7 @@ func (s *ModelTestSuite) TestQuorumReached() {
 	s.False(quorumReached)
 
 	// add enough votes for quorum to be met
-	for i := 0; i < numberOfVotesForQuorum; i++ {
+	for i := 0; i < defaultGRBQuorumMinimumVotes; i++ {
 		info.GRBReviewers = append(info.GRBReviewers, &SystemIntakeGRBReviewer{
 			GRBVotingRole: SystemIntakeGRBReviewerVotingRoleVoting,
 			Vote:          helpers.PointerTo(SystemIntakeAsyncGRBVotingOptionNoObjection),
@@ -155,6 +155,148 @@ func (s *ModelTestSuite) TestQuorumReached() {
 	s.True(quorumReached)
 }
 
+func (s *ModelTestSuite) TestQuorumReachedWithPolicy() {
+	voted := func(role SystemIntakeGRBReviewerRole) *SystemIntakeGRBReviewer {
+		return &SystemIntakeGRBReviewer{
+			GRBVotingRole:   SystemIntakeGRBReviewerVotingRoleVoting,
+			GRBReviewerRole: role,
+			Vote:            helpers.PointerTo(SystemIntakeAsyncGRBVotingOptionNoObjection),
+		}
+	}
+	notVoted := func(role SystemIntakeGRBReviewerRole) *SystemIntakeGRBReviewer {
+		return &SystemIntakeGRBReviewer{
+			GRBVotingRole:   SystemIntakeGRBReviewerVotingRoleVoting,
+			GRBReviewerRole: role,
+		}
+	}
+
+	type testCase struct {
+		name      string
+		expected  bool
+		intake    *SystemIntake
+		reviewers []*SystemIntakeGRBReviewer
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Minimum votes not met",
+			expected: false,
+			intake: &SystemIntake{
+				GrbQuorumMinimumVotes: 3,
+			},
+			reviewers: []*SystemIntakeGRBReviewer{
+				voted(SystemIntakeGRBReviewerRoleOther),
+				voted(SystemIntakeGRBReviewerRoleOther),
+				notVoted(SystemIntakeGRBReviewerRoleOther),
+			},
+		},
+		{
+			name:     "Minimum votes met",
+			expected: true,
+			intake: &SystemIntake{
+				GrbQuorumMinimumVotes: 2,
+			},
+			reviewers: []*SystemIntakeGRBReviewer{
+				voted(SystemIntakeGRBReviewerRoleOther),
+				voted(SystemIntakeGRBReviewerRoleOther),
+				notVoted(SystemIntakeGRBReviewerRoleOther),
+			},
+		},
+		{
+			name:     "Voting percentage not met",
+			expected: false,
+			intake: &SystemIntake{
+				GrbQuorumMinimumVotes:     1,
+				GrbQuorumVotingPercentage: helpers.PointerTo(75),
+			},
+			reviewers: []*SystemIntakeGRBReviewer{
+				voted(SystemIntakeGRBReviewerRoleOther),
+				voted(SystemIntakeGRBReviewerRoleOther),
+				notVoted(SystemIntakeGRBReviewerRoleOther),
+			},
+		},
+		{
+			name:     "Voting percentage met",
+			expected: true,
+			intake: &SystemIntake{
+				GrbQuorumMinimumVotes:     1,
+				GrbQuorumVotingPercentage: helpers.PointerTo(50),
+			},
+			reviewers: []*SystemIntakeGRBReviewer{
+				voted(SystemIntakeGRBReviewerRoleOther),
+				notVoted(SystemIntakeGRBReviewerRoleOther),
+				{
+					// non-voting reviewers don't count towards the percentage
+					GRBVotingRole:   SystemIntakeGRBReviewerVotingRoleNonVoting,
+					GRBReviewerRole: SystemIntakeGRBReviewerRoleOther,
+				},
+			},
+		},
+		{
+			name:     "Any required role not met",
+			expected: false,
+			intake: &SystemIntake{
+				GrbQuorumMinimumVotes:      1,
+				GrbQuorumRequiredRoles:     EnumArray[SystemIntakeGRBReviewerRole]{SystemIntakeGRBReviewerRoleCoChairCio, SystemIntakeGRBReviewerRoleCoChairCfo},
+				GrbQuorumRequiredRolesRule: GRBQuorumRequiredRolesRuleAnyRole,
+			},
+			reviewers: []*SystemIntakeGRBReviewer{
+				voted(SystemIntakeGRBReviewerRoleOther),
+				notVoted(SystemIntakeGRBReviewerRoleCoChairCio),
+			},
+		},
+		{
+			name:     "Any required role met",
+			expected: true,
+			intake: &SystemIntake{
+				GrbQuorumMinimumVotes:      1,
+				GrbQuorumRequiredRoles:     EnumArray[SystemIntakeGRBReviewerRole]{SystemIntakeGRBReviewerRoleCoChairCio, SystemIntakeGRBReviewerRoleCoChairCfo},
+				GrbQuorumRequiredRolesRule: GRBQuorumRequiredRolesRuleAnyRole,
+			},
+			reviewers: []*SystemIntakeGRBReviewer{
+				voted(SystemIntakeGRBReviewerRoleCoChairCfo),
+				notVoted(SystemIntakeGRBReviewerRoleCoChairCio),
+			},
+		},
+		{
+			name:     "All required roles not met",
+			expected: false,
+			intake: &SystemIntake{
+				GrbQuorumMinimumVotes:      1,
+				GrbQuorumRequiredRoles:     EnumArray[SystemIntakeGRBReviewerRole]{SystemIntakeGRBReviewerRoleCoChairCio, SystemIntakeGRBReviewerRoleCoChairCfo},
+				GrbQuorumRequiredRolesRule: GRBQuorumRequiredRolesRuleAllRoles,
+			},
+			reviewers: []*SystemIntakeGRBReviewer{
+				voted(SystemIntakeGRBReviewerRoleCoChairCfo),
+				notVoted(SystemIntakeGRBReviewerRoleCoChairCio),
+			},
+		},
+		{
+			name:     "All required roles met",
+			expected: true,
+			intake: &SystemIntake{
+				GrbQuorumMinimumVotes:      1,
+				GrbQuorumRequiredRoles:     EnumArray[SystemIntakeGRBReviewerRole]{SystemIntakeGRBReviewerRoleCoChairCio, SystemIntakeGRBReviewerRoleCoChairCfo},
+				GrbQuorumRequiredRolesRule: GRBQuorumRequiredRolesRuleAllRoles,
+			},
+			reviewers: []*SystemIntakeGRBReviewer{
+				voted(SystemIntakeGRBReviewerRoleCoChairCfo),
+				voted(SystemIntakeGRBReviewerRoleCoChairCio),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			info := &GRBVotingInformation{
+				SystemIntake: tc.intake,
+				GRBReviewers: tc.reviewers,
+			}
+			s.Equal(tc.expected, info.QuorumReached())
+		})
+	}
+}
+
 func (s *ModelTestSuite) TestVotingStatus() {
 	type testCase struct {
 		name     string
@@ -1,5 +1,11 @@
 package models
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 // SystemProfileLockableSection represents Sections of the system profile form that can be locked for editing
 type SystemProfileLockableSection string
 
@@ -10,4 +16,16 @@ const (
 	SystemProfileLockableSectionToolsAndSoftware      SystemProfileLockableSection = "TOOLS_AND_SOFTWARE"
 	SystemProfileLockableSectionSubSystems            SystemProfileLockableSection = "SUB_SYSTEMS"
 	SystemProfileLockableSectionTeam                  SystemProfileLockableSection = "TEAM"
+	SystemProfileLockableSectionContracts             SystemProfileLockableSection = "CONTRACTS"
+	SystemProfileLockableSectionFundingAndBudget      SystemProfileLockableSection = "FUNDING_AND_BUDGET"
 )
+
+// SystemProfileSectionLock is the database representation of a lock held on a section of a system profile.
+// A lock is only considered held while ExpiresAt is in the future.
+type SystemProfileSectionLock struct {
+	CedarSystemID uuid.UUID                    `db:"cedar_system_id"`
+	Section       SystemProfileLockableSection `db:"section"`
+	LockedBy      uuid.UUID                    `db:"locked_by"`
+	LockedAt      time.Time                    `db:"locked_at"`
+	ExpiresAt     time.Time                    `db:"expires_at"`
+}
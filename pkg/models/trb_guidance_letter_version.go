@@ -0,0 +1,229 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"html"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/cms-enterprise/easi-app/pkg/htmldiff"
+)
+
+// TRBGuidanceLetterVersionReason is why a snapshot of a TRB guidance letter was taken
+type TRBGuidanceLetterVersionReason string
+
+// These are the reasons a snapshot of a TRB guidance letter is taken
+const (
+	TRBGuidanceLetterVersionReasonReviewRequested TRBGuidanceLetterVersionReason = "REVIEW_REQUESTED"
+	TRBGuidanceLetterVersionReasonSent            TRBGuidanceLetterVersionReason = "SENT"
+	TRBGuidanceLetterVersionReasonAmended         TRBGuidanceLetterVersionReason = "AMENDED"
+)
+
+// TRBGuidanceLetterVersion is a snapshot of a TRB guidance letter and its insights, taken each time the letter is sent for review, sent, or amended
+type TRBGuidanceLetterVersion struct {
+	ID                    uuid.UUID                        `json:"id" db:"id"`
+	TRBRequestID          uuid.UUID                        `json:"trbRequestId" db:"trb_request_id"`
+	TRBGuidanceLetterID   uuid.UUID                        `json:"trbGuidanceLetterId" db:"trb_guidance_letter_id"`
+	VersionNumber         int                              `json:"versionNumber" db:"version_number"`
+	Reason                TRBGuidanceLetterVersionReason   `json:"reason" db:"reason"`
+	MeetingSummary        *HTML                            `json:"meetingSummary" db:"meeting_summary"`
+	NextSteps             *HTML                            `json:"nextSteps" db:"next_steps"`
+	IsFollowupRecommended *bool                            `json:"isFollowupRecommended" db:"is_followup_recommended"`
+	FollowupPoint         *string                          `json:"followupPoint" db:"followup_point"`
+	Insights              TRBGuidanceLetterVersionInsights `json:"insights" db:"insights"`
+	AmendmentSummary      *HTML                            `json:"amendmentSummary" db:"amendment_summary"`
+	CreatedBy             string                           `json:"createdBy" db:"created_by"`
+	CreatedAt             time.Time                        `json:"createdAt" db:"created_at"`
+}
+
+// NewTRBGuidanceLetterVersion returns a snapshot of a letter and its insights, which should be in the order they appear in the letter
+func NewTRBGuidanceLetterVersion(
+	letter *TRBGuidanceLetter,
+	insights []*TRBGuidanceLetterInsight,
+	reason TRBGuidanceLetterVersionReason,
+	createdBy string,
+) *TRBGuidanceLetterVersion {
+	versionInsights := make(TRBGuidanceLetterVersionInsights, 0, len(insights))
+	for _, insight := range insights {
+		links := make([]string, len(insight.Links))
+		copy(links, insight.Links)
+		versionInsights = append(versionInsights, TRBGuidanceLetterVersionInsight{
+			InsightID: insight.ID,
+			Title:     insight.Title,
+			Insight:   insight.Insight,
+			Links:     links,
+			Category:  insight.Category,
+		})
+	}
+
+	return &TRBGuidanceLetterVersion{
+		ID:                    uuid.New(),
+		TRBRequestID:          letter.TRBRequestID,
+		TRBGuidanceLetterID:   letter.ID,
+		Reason:                reason,
+		MeetingSummary:        letter.MeetingSummary,
+		NextSteps:             letter.NextSteps,
+		IsFollowupRecommended: letter.IsFollowupRecommended,
+		FollowupPoint:         letter.FollowupPoint,
+		Insights:              versionInsights,
+		CreatedBy:             createdBy,
+	}
+}
+
+// WasSent reports whether the version is a letter that was sent to its recipients, rather than a draft sent for review
+func (v *TRBGuidanceLetterVersion) WasSent() bool {
+	return v.Reason == TRBGuidanceLetterVersionReasonSent || v.Reason == TRBGuidanceLetterVersionReasonAmended
+}
+
+// TRBGuidanceLetterVersionInsight is an insight as it was in a snapshot of a TRB guidance letter
+type TRBGuidanceLetterVersionInsight struct {
+	// InsightID is the insight the snapshot was taken of, which identifies the same insight across versions
+	InsightID uuid.UUID                        `json:"insightId"`
+	Title     string                           `json:"title"`
+	Insight   HTML                             `json:"insight"`
+	Links     []string                         `json:"links"`
+	Category  TRBGuidanceLetterInsightCategory `json:"category"`
+}
+
+// TRBGuidanceLetterVersionInsights are the insights in a snapshot of a TRB guidance letter, stored as JSONB
+type TRBGuidanceLetterVersionInsights []TRBGuidanceLetterVersionInsight
+
+// Scan implements the sql.Scanner interface
+func (i *TRBGuidanceLetterVersionInsights) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(source, i)
+}
+
+// Value implements the driver.Valuer interface
+func (i TRBGuidanceLetterVersionInsights) Value() (driver.Value, error) {
+	if i == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(i)
+}
+
+// TRBGuidanceLetterInsightChangeType is how an insight changed between two versions of a TRB guidance letter
+type TRBGuidanceLetterInsightChangeType string
+
+// These are the ways an insight can change between two versions of a TRB guidance letter
+const (
+	TRBGuidanceLetterInsightChangeTypeAdded     TRBGuidanceLetterInsightChangeType = "ADDED"
+	TRBGuidanceLetterInsightChangeTypeRemoved   TRBGuidanceLetterInsightChangeType = "REMOVED"
+	TRBGuidanceLetterInsightChangeTypeChanged   TRBGuidanceLetterInsightChangeType = "CHANGED"
+	TRBGuidanceLetterInsightChangeTypeUnchanged TRBGuidanceLetterInsightChangeType = "UNCHANGED"
+)
+
+// TRBGuidanceLetterVersionDiff is the difference between two versions of a TRB guidance letter. Each section is the newer version's text,
+// with the words added since the older version wrapped in <ins> and the words removed wrapped in <del>
+type TRBGuidanceLetterVersionDiff struct {
+	From                         *TRBGuidanceLetterVersion       `json:"from"`
+	To                           *TRBGuidanceLetterVersion       `json:"to"`
+	HasChanges                   bool                            `json:"hasChanges"`
+	MeetingSummary               HTML                            `json:"meetingSummary"`
+	MeetingSummaryChanged        bool                            `json:"meetingSummaryChanged"`
+	NextSteps                    HTML                            `json:"nextSteps"`
+	NextStepsChanged             bool                            `json:"nextStepsChanged"`
+	IsFollowupRecommendedChanged bool                            `json:"isFollowupRecommendedChanged"`
+	FollowupPoint                HTML                            `json:"followupPoint"`
+	FollowupPointChanged         bool                            `json:"followupPointChanged"`
+	Insights                     []*TRBGuidanceLetterInsightDiff `json:"insights"`
+}
+
+// TRBGuidanceLetterInsightDiff is the difference in one insight between two versions of a TRB guidance letter
+type TRBGuidanceLetterInsightDiff struct {
+	InsightID  uuid.UUID                          `json:"insightId"`
+	ChangeType TRBGuidanceLetterInsightChangeType `json:"changeType"`
+	Category   TRBGuidanceLetterInsightCategory   `json:"category"`
+	Title      HTML                               `json:"title"`
+	Insight    HTML                               `json:"insight"`
+	Links      HTML                               `json:"links"`
+}
+
+// NewTRBGuidanceLetterVersionDiff compares two versions of a TRB guidance letter. Insights are listed in the order they appear in the newer version,
+// followed by the insights that were removed
+func NewTRBGuidanceLetterVersionDiff(from *TRBGuidanceLetterVersion, to *TRBGuidanceLetterVersion) *TRBGuidanceLetterVersionDiff {
+	diff := &TRBGuidanceLetterVersionDiff{
+		From:                         from,
+		To:                           to,
+		MeetingSummary:               HTML(htmldiff.Diff(from.MeetingSummary.ValueOrEmptyString(), to.MeetingSummary.ValueOrEmptyString())),
+		MeetingSummaryChanged:        htmldiff.Changed(from.MeetingSummary.ValueOrEmptyString(), to.MeetingSummary.ValueOrEmptyString()),
+		NextSteps:                    HTML(htmldiff.Diff(from.NextSteps.ValueOrEmptyString(), to.NextSteps.ValueOrEmptyString())),
+		NextStepsChanged:             htmldiff.Changed(from.NextSteps.ValueOrEmptyString(), to.NextSteps.ValueOrEmptyString()),
+		IsFollowupRecommendedChanged: lo.FromPtr(from.IsFollowupRecommended) != lo.FromPtr(to.IsFollowupRecommended),
+		FollowupPoint:                HTML(htmldiff.Diff(plainTextHTML(from.FollowupPoint), plainTextHTML(to.FollowupPoint))),
+		FollowupPointChanged:         lo.FromPtr(from.FollowupPoint) != lo.FromPtr(to.FollowupPoint),
+	}
+	diff.HasChanges = diff.MeetingSummaryChanged || diff.NextStepsChanged || diff.IsFollowupRecommendedChanged || diff.FollowupPointChanged
+
+	fromInsights := lo.KeyBy(from.Insights, func(insight TRBGuidanceLetterVersionInsight) uuid.UUID {
+		return insight.InsightID
+	})
+	toInsights := lo.KeyBy(to.Insights, func(insight TRBGuidanceLetterVersionInsight) uuid.UUID {
+		return insight.InsightID
+	})
+
+	for _, toInsight := range to.Insights {
+		insightDiff := newTRBGuidanceLetterInsightDiff(fromInsights[toInsight.InsightID], toInsight)
+		if fromInsight, ok := fromInsights[toInsight.InsightID]; !ok {
+			insightDiff.ChangeType = TRBGuidanceLetterInsightChangeTypeAdded
+		} else if fromInsight.Category != toInsight.Category ||
+			fromInsight.Title != toInsight.Title ||
+			htmldiff.Changed(string(fromInsight.Insight), string(toInsight.Insight)) ||
+			!slices.Equal(fromInsight.Links, toInsight.Links) {
+			insightDiff.ChangeType = TRBGuidanceLetterInsightChangeTypeChanged
+		}
+		diff.Insights = append(diff.Insights, insightDiff)
+	}
+	for _, fromInsight := range from.Insights {
+		if _, ok := toInsights[fromInsight.InsightID]; !ok {
+			insightDiff := newTRBGuidanceLetterInsightDiff(fromInsight, TRBGuidanceLetterVersionInsight{})
+			insightDiff.ChangeType = TRBGuidanceLetterInsightChangeTypeRemoved
+			insightDiff.InsightID = fromInsight.InsightID
+			insightDiff.Category = fromInsight.Category
+			diff.Insights = append(diff.Insights, insightDiff)
+		}
+	}
+
+	for _, insightDiff := range diff.Insights {
+		if insightDiff.ChangeType != TRBGuidanceLetterInsightChangeTypeUnchanged {
+			diff.HasChanges = true
+		}
+	}
+	return diff
+}
+
+// newTRBGuidanceLetterInsightDiff compares two versions of an insight, either of which may be empty, and marks it as unchanged
+func newTRBGuidanceLetterInsightDiff(from TRBGuidanceLetterVersionInsight, to TRBGuidanceLetterVersionInsight) *TRBGuidanceLetterInsightDiff {
+	linksHTML := func(links []string) string {
+		var builder strings.Builder
+		for _, link := range links {
+			builder.WriteString("<p>" + html.EscapeString(link) + "</p>")
+		}
+		return builder.String()
+	}
+
+	return &TRBGuidanceLetterInsightDiff{
+		InsightID:  to.InsightID,
+		ChangeType: TRBGuidanceLetterInsightChangeTypeUnchanged,
+		Category:   to.Category,
+		Title:      HTML(htmldiff.Diff(html.EscapeString(from.Title), html.EscapeString(to.Title))),
+		Insight:    HTML(htmldiff.Diff(string(from.Insight), string(to.Insight))),
+		Links:      HTML(htmldiff.Diff(linksHTML(from.Links), linksHTML(to.Links))),
+	}
+}
+
+// plainTextHTML escapes optional plain text so it can be compared as HTML
+func plainTextHTML(text *string) string {
+	return html.EscapeString(lo.FromPtr(text))
+}
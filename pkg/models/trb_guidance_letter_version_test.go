@@ -0,0 +1,82 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+)
+
+func (s *ModelTestSuite) TestNewTRBGuidanceLetterVersionDiff() {
+	letter := &TRBGuidanceLetter{
+		TRBRequestID:          uuid.New(),
+		MeetingSummary:        helpers.PointerTo(HTML("<p>We discussed the cloud migration</p>")),
+		NextSteps:             helpers.PointerTo(HTML("<p>Schedule a follow-up</p>")),
+		IsFollowupRecommended: helpers.PointerTo(true),
+		FollowupPoint:         helpers.PointerTo("In 6 months"),
+	}
+	letter.ID = uuid.New()
+
+	kept := &TRBGuidanceLetterInsight{Title: "Use the enterprise cloud", Insight: "<p>Migrate to AWS</p>", Links: []string{"https://cloud.cms.gov"}, Category: TRBGuidanceLetterInsightCategoryRecommendation, PositionInLetter: null.IntFrom(0)}
+	kept.ID = uuid.New()
+	removed := &TRBGuidanceLetterInsight{Title: "Old requirement", Insight: "<p>No longer applies</p>", Category: TRBGuidanceLetterInsightCategoryRequirement}
+	removed.ID = uuid.New()
+	unchanged := &TRBGuidanceLetterInsight{Title: "Consider Section 508", Insight: "<p>Test with a screen reader</p>", Category: TRBGuidanceLetterInsightCategoryConsideration}
+	unchanged.ID = uuid.New()
+
+	from := NewTRBGuidanceLetterVersion(letter, []*TRBGuidanceLetterInsight{kept, removed, unchanged}, TRBGuidanceLetterVersionReasonSent, "ABCD")
+
+	s.Run("a snapshot copies the letter and its insights in order", func() {
+		s.Equal(letter.ID, from.TRBGuidanceLetterID)
+		s.Equal(letter.TRBRequestID, from.TRBRequestID)
+		s.Equal(letter.MeetingSummary, from.MeetingSummary)
+		s.Len(from.Insights, 3)
+		s.Equal(kept.ID, from.Insights[0].InsightID)
+		s.Equal([]string{"https://cloud.cms.gov"}, from.Insights[0].Links)
+		s.True(from.WasSent())
+	})
+
+	s.Run("identical versions have no changes", func() {
+		diff := NewTRBGuidanceLetterVersionDiff(from, from)
+		s.False(diff.HasChanges)
+		s.Equal(HTML("<p>We discussed the cloud migration</p>"), diff.MeetingSummary)
+		for _, insight := range diff.Insights {
+			s.Equal(TRBGuidanceLetterInsightChangeTypeUnchanged, insight.ChangeType)
+		}
+	})
+
+	s.Run("changes to the letter and its insights are marked", func() {
+		kept.Insight = "<p>Migrate to Azure</p>"
+		added := &TRBGuidanceLetterInsight{Title: "New requirement", Insight: "<p>Get an ATO</p>", Category: TRBGuidanceLetterInsightCategoryRequirement}
+		added.ID = uuid.New()
+		letter.NextSteps = helpers.PointerTo(HTML("<p>Schedule a follow-up in March</p>"))
+		letter.FollowupPoint = helpers.PointerTo("In 3 months")
+
+		to := NewTRBGuidanceLetterVersion(letter, []*TRBGuidanceLetterInsight{added, kept, unchanged}, TRBGuidanceLetterVersionReasonAmended, "ABCD")
+		diff := NewTRBGuidanceLetterVersionDiff(from, to)
+
+		s.True(diff.HasChanges)
+		s.False(diff.MeetingSummaryChanged)
+		s.True(diff.NextStepsChanged)
+		s.Equal(HTML("<p>Schedule a follow-up <ins>in March</ins></p>"), diff.NextSteps)
+		s.False(diff.IsFollowupRecommendedChanged)
+		s.True(diff.FollowupPointChanged)
+		s.Equal(HTML("In <del>6</del><ins>3</ins> months"), diff.FollowupPoint)
+
+		s.Len(diff.Insights, 4)
+		s.Equal(added.ID, diff.Insights[0].InsightID)
+		s.Equal(TRBGuidanceLetterInsightChangeTypeAdded, diff.Insights[0].ChangeType)
+		s.Equal(HTML("<ins>New requirement</ins>"), diff.Insights[0].Title)
+
+		s.Equal(kept.ID, diff.Insights[1].InsightID)
+		s.Equal(TRBGuidanceLetterInsightChangeTypeChanged, diff.Insights[1].ChangeType)
+		s.Equal(HTML("<p>Migrate to <del>AWS</del><ins>Azure</ins></p>"), diff.Insights[1].Insight)
+
+		s.Equal(TRBGuidanceLetterInsightChangeTypeUnchanged, diff.Insights[2].ChangeType)
+
+		s.Equal(removed.ID, diff.Insights[3].InsightID)
+		s.Equal(TRBGuidanceLetterInsightChangeTypeRemoved, diff.Insights[3].ChangeType)
+		s.Equal(TRBGuidanceLetterInsightCategoryRequirement, diff.Insights[3].Category)
+		s.Equal(HTML("<del>No longer applies</del>"), diff.Insights[3].Insight)
+	})
+}
@@ -27,6 +27,21 @@ func NewTRBRequest(createdBy string) *TRBRequest {
 	}
 }
 
+// SortedTRBRequest is used when TRB requests are selected from the DB for a cursor paginated list, with the value they are sorted by
+// added as an aliased column
+type SortedTRBRequest struct {
+	TRBRequest
+	SortValue time.Time `db:"sort_value"`
+}
+
+// Cursor returns the cursor pointing at this request in the list it was selected for
+func (s SortedTRBRequest) Cursor() PageCursor {
+	return PageCursor{
+		SortValue: s.SortValue,
+		ID:        s.ID,
+	}
+}
+
 // TRBRequestType represents the types of TRBRequestType types
 type TRBRequestType string
 
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEventType is a governance lifecycle event that webhook subscribers can be sent
+type WebhookEventType string
+
+// These are the governance lifecycle events that webhook subscribers can be sent
+const (
+	WebhookEventTypeLcidIssued            WebhookEventType = "LCID_ISSUED"
+	WebhookEventTypeLcidExpired           WebhookEventType = "LCID_EXPIRED"
+	WebhookEventTypeLcidRetired           WebhookEventType = "LCID_RETIRED"
+	WebhookEventTypeGrbReviewEnded        WebhookEventType = "GRB_REVIEW_ENDED"
+	WebhookEventTypeTrbGuidanceLetterSent WebhookEventType = "TRB_GUIDANCE_LETTER_SENT"
+)
+
+// ITGovWebhookEventTypes are the events about IT Governance requests
+var ITGovWebhookEventTypes = []WebhookEventType{
+	WebhookEventTypeLcidIssued,
+	WebhookEventTypeLcidExpired,
+	WebhookEventTypeLcidRetired,
+	WebhookEventTypeGrbReviewEnded,
+}
+
+// TRBWebhookEventTypes are the events about TRB requests
+var TRBWebhookEventTypes = []WebhookEventType{
+	WebhookEventTypeTrbGuidanceLetterSent,
+}
+
+// WebhookSubscription is a downstream system that is sent governance lifecycle events as signed HTTP requests
+type WebhookSubscription struct {
+	BaseStructUser
+	Name string `json:"name" db:"name"`
+	URL  string `json:"url" db:"url"`
+	// Secret is the key requests to the subscriber are signed with. It's only shown to users when it's created
+	Secret     string                      `json:"-" db:"secret"`
+	EventTypes EnumArray[WebhookEventType] `json:"eventTypes" db:"event_types"`
+	IsActive   bool                        `json:"isActive" db:"is_active"`
+}
+
+// NewWebhookSubscription returns a new, active webhook subscription
+func NewWebhookSubscription(createdBy uuid.UUID) *WebhookSubscription {
+	return &WebhookSubscription{
+		BaseStructUser: NewBaseStructUser(createdBy),
+		IsActive:       true,
+	}
+}
+
+// WebhookSubscriptionWithSecret is a webhook subscription along with the secret its requests are signed with,
+// which is only returned when the secret is created
+type WebhookSubscriptionWithSecret struct {
+	Subscription *WebhookSubscription `json:"subscription"`
+	Secret       string               `json:"secret"`
+}
+
+// WebhookDeliveryStatus is the delivery status of an event sent to a webhook subscriber
+type WebhookDeliveryStatus string
+
+// These are the delivery statuses of an event sent to a webhook subscriber
+const (
+	WebhookDeliveryStatusPending    WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusDelivered  WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryStatusDeadLetter WebhookDeliveryStatus = "DEAD_LETTER"
+)
+
+const (
+	// WebhookDeliveryMaxAttempts is the number of times delivering an event is attempted before it's moved to the dead letter state
+	WebhookDeliveryMaxAttempts = 12
+
+	webhookDeliveryBaseRetryDelay = 30 * time.Second
+	webhookDeliveryMaxRetryDelay  = 2 * time.Hour
+)
+
+// WebhookDelivery is an event to be sent to a webhook subscriber by the webhook dispatcher, and the history of attempts to send it
+type WebhookDelivery struct {
+	modifiedByRelation
+	ID                 uuid.UUID             `json:"id" db:"id"`
+	SubscriptionID     uuid.UUID             `json:"subscriptionID" db:"subscription_id"`
+	EventID            uuid.UUID             `json:"eventID" db:"event_id"`
+	EventType          WebhookEventType      `json:"eventType" db:"event_type"`
+	Payload            string                `json:"payload" db:"payload"`
+	Status             WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts           int                   `json:"attempts" db:"attempts"`
+	NextAttemptAt      time.Time             `json:"nextAttemptAt" db:"next_attempt_at"`
+	LastResponseStatus *int                  `json:"lastResponseStatus" db:"last_response_status"`
+	LastError          *string               `json:"lastError" db:"last_error"`
+	DeliveredAt        *time.Time            `json:"deliveredAt" db:"delivered_at"`
+	// ClaimID identifies the dispatcher attempt that has claimed the delivery to send it, if any
+	ClaimID   *uuid.UUID `json:"-" db:"claim_id"`
+	CreatedBy *uuid.UUID `json:"createdBy" db:"created_by"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
+
+	// SubscriptionURL and SubscriptionSecret are where and how the delivery is sent. They're only loaded for deliveries being dispatched
+	SubscriptionURL    string `json:"-" db:"subscription_url"`
+	SubscriptionSecret string `json:"-" db:"subscription_secret"`
+}
+
+// RecordDelivered records a successful attempt to deliver the event
+func (d *WebhookDelivery) RecordDelivered(now time.Time, responseStatus int) {
+	d.Attempts++
+	d.Status = WebhookDeliveryStatusDelivered
+	d.LastResponseStatus = &responseStatus
+	d.LastError = nil
+	d.DeliveredAt = &now
+}
+
+// RecordFailure records a failed attempt to deliver the event, and the status the subscriber responded with, if it responded.
+// The next attempt is delayed exponentially, and after WebhookDeliveryMaxAttempts failures
+// the delivery is moved to the dead letter state instead
+func (d *WebhookDelivery) RecordFailure(now time.Time, responseStatus *int, deliveryErr error) {
+	d.Attempts++
+	d.LastResponseStatus = responseStatus
+	lastError := deliveryErr.Error()
+	d.LastError = &lastError
+
+	if d.Attempts >= WebhookDeliveryMaxAttempts {
+		d.Status = WebhookDeliveryStatusDeadLetter
+		return
+	}
+
+	d.Status = WebhookDeliveryStatusPending
+	d.NextAttemptAt = now.Add(WebhookDeliveryRetryDelay(d.Attempts))
+}
+
+// WebhookDeliveryRetryDelay returns how long to wait before delivering an event again after it has failed the given number of times.
+// The delay doubles with each failure, starting at 30 seconds, up to a maximum of 2 hours, so events reach subscribers that are down
+// for up to about 8 hours
+func WebhookDeliveryRetryDelay(failedAttempts int) time.Duration {
+	return exponentialRetryDelay(failedAttempts, webhookDeliveryBaseRetryDelay, webhookDeliveryMaxRetryDelay)
+}
+
+// Cursor returns the cursor pointing at this delivery in the delivery log, which is ordered newest first
+func (d *WebhookDelivery) Cursor() PageCursor {
+	return PageCursor{
+		SortValue: d.CreatedAt,
+		ID:        d.ID,
+	}
+}
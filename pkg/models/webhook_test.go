@@ -0,0 +1,59 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+)
+
+func (s *ModelTestSuite) TestWebhookDeliveryRetryDelay() {
+	s.Equal(time.Duration(0), WebhookDeliveryRetryDelay(0))
+	s.Equal(30*time.Second, WebhookDeliveryRetryDelay(1))
+	s.Equal(time.Minute, WebhookDeliveryRetryDelay(2))
+	s.Equal(64*time.Minute, WebhookDeliveryRetryDelay(8))
+	s.Equal(2*time.Hour, WebhookDeliveryRetryDelay(9))
+	s.Equal(2*time.Hour, WebhookDeliveryRetryDelay(100))
+}
+
+func (s *ModelTestSuite) TestWebhookDeliveryAttempts() {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	s.Run("a failure records the response and schedules the next attempt with backoff", func() {
+		delivery := &WebhookDelivery{Status: WebhookDeliveryStatusPending, NextAttemptAt: now}
+
+		delivery.RecordFailure(now, helpers.PointerTo(http.StatusServiceUnavailable), errors.New("unexpected response status 503"))
+		s.Equal(WebhookDeliveryStatusPending, delivery.Status)
+		s.Equal(1, delivery.Attempts)
+		s.Equal(http.StatusServiceUnavailable, *delivery.LastResponseStatus)
+		s.Equal("unexpected response status 503", *delivery.LastError)
+		s.Equal(now.Add(30*time.Second), delivery.NextAttemptAt)
+
+		// a subscriber that can't be reached doesn't respond with a status
+		delivery.RecordFailure(now, nil, errors.New("connection refused"))
+		s.Equal(2, delivery.Attempts)
+		s.Nil(delivery.LastResponseStatus)
+		s.Equal(now.Add(time.Minute), delivery.NextAttemptAt)
+	})
+
+	s.Run("the last allowed failure moves the delivery to the dead letter state", func() {
+		delivery := &WebhookDelivery{Status: WebhookDeliveryStatusPending, Attempts: WebhookDeliveryMaxAttempts - 1, NextAttemptAt: now}
+
+		delivery.RecordFailure(now, nil, errors.New("connection refused"))
+		s.Equal(WebhookDeliveryStatusDeadLetter, delivery.Status)
+		s.Equal(WebhookDeliveryMaxAttempts, delivery.Attempts)
+		s.Equal(now, delivery.NextAttemptAt)
+	})
+
+	s.Run("a success marks the delivery as delivered", func() {
+		delivery := &WebhookDelivery{Status: WebhookDeliveryStatusPending, Attempts: 2, LastError: helpers.PointerTo("timeout")}
+
+		delivery.RecordDelivered(now, http.StatusNoContent)
+		s.Equal(WebhookDeliveryStatusDelivered, delivery.Status)
+		s.Equal(3, delivery.Attempts)
+		s.Equal(http.StatusNoContent, *delivery.LastResponseStatus)
+		s.Nil(delivery.LastError)
+		s.Equal(now, *delivery.DeliveredAt)
+	})
+}
@@ -0,0 +1,178 @@
+// Package pdf renders simple HTML documents, such as ones generated from html/template templates, as PDFs.
+// It only supports the text, headings, lists and tables needed for printable versions of requests, using the standard
+// Helvetica fonts
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// The page size (US Letter) and margins, in points
+const (
+	pageWidth    = 612.0
+	pageHeight   = 792.0
+	pageMargin   = 54.0
+	contentWidth = pageWidth - 2*pageMargin
+)
+
+// page holds the content stream of a single page, in PDF drawing operators
+type page struct {
+	content bytes.Buffer
+}
+
+// text draws WinAnsi encoded text with its baseline starting at x, y
+func (p *page) text(f font, size float64, x float64, y float64, text []byte) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td ", f.resourceName(), formatNumber(size), formatNumber(x), formatNumber(y))
+	writeString(&p.content, text)
+	p.content.WriteString(" Tj ET\n")
+}
+
+// rect outlines a rectangle with its bottom left corner at x, y, filling it with grey first if shade is set
+func (p *page) rect(x float64, y float64, width float64, height float64, shade bool) {
+	dimensions := fmt.Sprintf("%s %s %s %s re", formatNumber(x), formatNumber(y), formatNumber(width), formatNumber(height))
+	if shade {
+		p.content.WriteString("0.92 g " + dimensions + " f 0 g\n")
+	}
+	p.content.WriteString("0.5 w " + dimensions + " S\n")
+}
+
+// writeString writes text as a PDF literal string, escaping the characters that delimit it
+func writeString(buf *bytes.Buffer, text []byte) {
+	buf.WriteByte('(')
+	for _, c := range text {
+		switch c {
+		case '(', ')', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte(')')
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', 2, 64)
+}
+
+// countingWriter tracks how many bytes have been written, for the cross-reference table of object offsets
+type countingWriter struct {
+	w     io.Writer
+	count int
+	err   error
+}
+
+func (c *countingWriter) printf(format string, args ...any) {
+	if c.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(c.w, format, args...)
+	c.count += n
+	c.err = err
+}
+
+func (c *countingWriter) write(b []byte) {
+	if c.err != nil {
+		return
+	}
+	n, err := c.w.Write(b)
+	c.count += n
+	c.err = err
+}
+
+// writeDocument writes pages as a PDF document with the given title
+func writeDocument(w io.Writer, title string, pages []*page) error {
+	const (
+		catalogObject = iota + 1
+		pagesObject
+		regularFontObject
+		boldFontObject
+		infoObject
+		firstPageObject
+	)
+	objectCount := firstPageObject - 1 + 2*len(pages)
+	offsets := make([]int, objectCount+1)
+
+	out := &countingWriter{w: w}
+	beginObject := func(number int) {
+		offsets[number] = out.count
+		out.printf("%d 0 obj\n", number)
+	}
+	endObject := func() {
+		out.printf("endobj\n")
+	}
+
+	// the binary comment tells file transfer tools the file isn't plain text
+	out.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	beginObject(catalogObject)
+	out.printf("<< /Type /Catalog /Pages %d 0 R >>\n", pagesObject)
+	endObject()
+
+	beginObject(pagesObject)
+	out.printf("<< /Type /Pages /Count %d /Kids [", len(pages))
+	for i := range pages {
+		out.printf(" %d 0 R", firstPageObject+2*i)
+	}
+	out.printf(" ] >>\n")
+	endObject()
+
+	fonts := []struct {
+		object int
+		font   font
+	}{
+		{regularFontObject, fontRegular},
+		{boldFontObject, fontBold},
+	}
+	for _, f := range fonts {
+		beginObject(f.object)
+		out.printf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>\n", f.font.baseFont())
+		endObject()
+	}
+
+	var encodedTitle bytes.Buffer
+	writeString(&encodedTitle, encodeWinAnsi(title))
+	beginObject(infoObject)
+	out.printf("<< /Title %s /Producer (EASi) >>\n", encodedTitle.String())
+	endObject()
+
+	for i, p := range pages {
+		pageObject := firstPageObject + 2*i
+		contentObject := pageObject + 1
+
+		beginObject(pageObject)
+		out.printf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>\n",
+			pagesObject, formatNumber(pageWidth), formatNumber(pageHeight), regularFontObject, boldFontObject, contentObject,
+		)
+		endObject()
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(p.content.Bytes()); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+
+		beginObject(contentObject)
+		out.printf("<< /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+		out.write(compressed.Bytes())
+		out.printf("\nendstream\n")
+		endObject()
+	}
+
+	xrefOffset := out.count
+	out.printf("xref\n0 %d\n0000000000 65535 f \n", objectCount+1)
+	for _, offset := range offsets[1:] {
+		out.printf("%010d 00000 n \n", offset)
+	}
+	out.printf("trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", objectCount+1, catalogObject, infoObject, xrefOffset)
+
+	return out.err
+}
@@ -0,0 +1,111 @@
+package pdf
+
+// font is one of the standard PDF fonts, which every PDF reader has, so they don't need to be embedded in the document
+type font int
+
+const (
+	fontRegular font = iota
+	fontBold
+)
+
+// resourceName is the name the font is referred to by in page content
+func (f font) resourceName() string {
+	if f == fontBold {
+		return "F2"
+	}
+	return "F1"
+}
+
+func (f font) baseFont() string {
+	if f == fontBold {
+		return "Helvetica-Bold"
+	}
+	return "Helvetica"
+}
+
+// The widths of the printable ASCII characters (space to ~) in thousandths of the font size, from the Adobe font metrics
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+	// winAnsiWidths are the approximate widths of the punctuation outside ASCII that's common in pasted text
+	winAnsiWidths = map[byte]int{
+		0x85: 1000, // ellipsis
+		0x91: 222,  // left single quote
+		0x92: 222,  // right single quote
+		0x93: 333,  // left double quote
+		0x94: 333,  // right double quote
+		0x95: 350,  // bullet
+		0x96: 556,  // en dash
+		0x97: 1000, // em dash
+		0xA0: 278,  // non-breaking space
+	}
+)
+
+// defaultGlyphWidth is used for accented letters and symbols outside ASCII, most of which are the width of a digit
+const defaultGlyphWidth = 556
+
+// glyphWidth returns the width of a WinAnsi encoded character in thousandths of the font size
+func (f font) glyphWidth(c byte) int {
+	if c >= 32 && c <= 126 {
+		if f == fontBold {
+			return helveticaBoldWidths[c-32]
+		}
+		return helveticaWidths[c-32]
+	}
+	if width, ok := winAnsiWidths[c]; ok {
+		return width
+	}
+	return defaultGlyphWidth
+}
+
+// textWidth returns the width of WinAnsi encoded text at the given size, in points
+func (f font) textWidth(text []byte, size float64) float64 {
+	total := 0
+	for _, c := range text {
+		total += f.glyphWidth(c)
+	}
+	return float64(total) * size / 1000
+}
+
+// winAnsiSpecials are the characters the WinAnsi encoding has in the range that Latin-1 uses for control characters
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// encodeWinAnsi converts text to the WinAnsi encoding used by the standard fonts, replacing characters it doesn't have with '?'.
+// Line breaks are kept, so text can be wrapped after it's encoded
+func encodeWinAnsi(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == '\t':
+			encoded = append(encoded, ' ')
+		case r == '\n', r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+			encoded = append(encoded, byte(r))
+		default:
+			if c, ok := winAnsiSpecials[r]; ok {
+				encoded = append(encoded, c)
+			} else {
+				encoded = append(encoded, '?')
+			}
+		}
+	}
+	return encoded
+}
@@ -0,0 +1,574 @@
+package pdf
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	bodyFontSize   = 10.0
+	tableFontSize  = 9.0
+	footerFontSize = 8.0
+	// lineSpacing is the height of a line of text as a multiple of its font size
+	lineSpacing = 1.3
+	cellPadding = 4.0
+	listIndent  = 14.0
+	// footerHeight is kept clear at the bottom of every page for the title and page number
+	footerHeight = 20.0
+)
+
+// run is a piece of text in a single font
+type run struct {
+	text string
+	font font
+}
+
+// paragraph is a block of text that's wrapped to the width of the page
+type paragraph struct {
+	runs        []run
+	size        float64
+	spaceBefore float64
+	spaceAfter  float64
+	indent      float64
+	bullet      bool
+	// keepWithNext moves headings to the next page rather than leaving them at the bottom of a page without the text they introduce
+	keepWithNext bool
+}
+
+type tableCell struct {
+	runs       []run
+	header     bool
+	alignRight bool
+	// width is the fraction of the table's width the cell's column takes up, or 0 to share the width left by the other columns
+	width float64
+}
+
+type table struct {
+	// headerRows are repeated at the top of every page the table is split across
+	headerRows [][]tableCell
+	rows       [][]tableCell
+}
+
+// FromHTML renders an HTML document as a PDF, which is written to w.
+// The document's <title> is used as the PDF's title, and is shown in the footer of every page with the page number.
+//
+// Headings, paragraphs, lists, definition lists and tables are laid out as blocks, and <strong> and <b> text is bold.
+// Other elements are treated as plain containers, and styles are ignored, except for white-space: pre-line which keeps the
+// line breaks in the element's text
+func FromHTML(w io.Writer, document io.Reader) error {
+	root, err := html.Parse(document)
+	if err != nil {
+		return fmt.Errorf("failed to parse HTML document: %w", err)
+	}
+
+	title := ""
+	if titleNode := findElement(root, atom.Title); titleNode != nil {
+		title = strings.TrimSpace(textContent(titleNode))
+	}
+
+	body := findElement(root, atom.Body)
+	if body == nil {
+		return fmt.Errorf("HTML document has no body")
+	}
+
+	var blocks []any
+	collectBlocks(body, &blocks)
+
+	l := newLayout()
+	for _, block := range blocks {
+		switch b := block.(type) {
+		case *paragraph:
+			l.paragraph(b)
+		case *table:
+			l.table(b)
+		}
+	}
+	l.footers(title)
+
+	return writeDocument(w, title, l.pages)
+}
+
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var text strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		text.WriteString(textContent(c))
+	}
+	return text.String()
+}
+
+func attribute(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// isPreLine returns whether the element's style keeps the line breaks in its text
+func isPreLine(n *html.Node) bool {
+	style := attribute(n, "style")
+	return strings.Contains(style, "pre-line") || strings.Contains(style, "pre-wrap")
+}
+
+// collectBlocks appends the blocks of text and tables in n to blocks, in document order
+func collectBlocks(n *html.Node, blocks *[]any) {
+	addParagraph := func(p *paragraph) {
+		if strings.TrimSpace(runsText(p.runs)) != "" {
+			*blocks = append(*blocks, p)
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case html.TextNode:
+			addParagraph(&paragraph{
+				runs:       []run{{text: normalizeSpace(c.Data, false), font: fontRegular}},
+				size:       bodyFontSize,
+				spaceAfter: 6,
+			})
+		case html.ElementNode:
+			switch c.DataAtom {
+			case atom.H1:
+				addParagraph(&paragraph{runs: inlineRuns(c, fontBold), size: 18, spaceBefore: 6, spaceAfter: 8, keepWithNext: true})
+			case atom.H2:
+				addParagraph(&paragraph{runs: inlineRuns(c, fontBold), size: 14, spaceBefore: 14, spaceAfter: 6, keepWithNext: true})
+			case atom.H3, atom.H4, atom.H5, atom.H6:
+				addParagraph(&paragraph{runs: inlineRuns(c, fontBold), size: 11, spaceBefore: 10, spaceAfter: 4, keepWithNext: true})
+			case atom.P:
+				addParagraph(&paragraph{runs: inlineRuns(c, fontRegular), size: bodyFontSize, spaceAfter: 6})
+			case atom.Li:
+				addParagraph(&paragraph{runs: inlineRuns(c, fontRegular), size: bodyFontSize, spaceAfter: 3, indent: listIndent, bullet: true})
+			case atom.Dt:
+				addParagraph(&paragraph{runs: inlineRuns(c, fontBold), size: bodyFontSize, spaceBefore: 4, spaceAfter: 1, keepWithNext: true})
+			case atom.Dd:
+				addParagraph(&paragraph{runs: inlineRuns(c, fontRegular), size: bodyFontSize, spaceAfter: 4})
+			case atom.Table:
+				*blocks = append(*blocks, collectTable(c))
+			case atom.Head, atom.Script, atom.Style:
+				continue
+			default:
+				collectBlocks(c, blocks)
+			}
+		}
+	}
+}
+
+// inlineRuns returns the text in n, in f unless it's in a <strong> or <b> element
+func inlineRuns(n *html.Node, f font) []run {
+	var runs []run
+	var walk func(n *html.Node, f font, preLine bool)
+	walk = func(n *html.Node, f font, preLine bool) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch c.Type {
+			case html.TextNode:
+				runs = append(runs, run{text: normalizeSpace(c.Data, preLine), font: f})
+			case html.ElementNode:
+				switch c.DataAtom {
+				case atom.Br:
+					runs = append(runs, run{text: "\n", font: f})
+				case atom.Strong, atom.B:
+					walk(c, fontBold, preLine || isPreLine(c))
+				default:
+					walk(c, f, preLine || isPreLine(c))
+				}
+			}
+		}
+	}
+	walk(n, f, isPreLine(n))
+	return runs
+}
+
+func runsText(runs []run) string {
+	var text strings.Builder
+	for _, r := range runs {
+		text.WriteString(r.text)
+	}
+	return text.String()
+}
+
+// normalizeSpace turns line breaks and tabs into spaces, as HTML does, unless the text keeps its line breaks
+func normalizeSpace(text string, preLine bool) string {
+	if preLine {
+		return strings.NewReplacer("\r\n", "\n", "\r", "\n", "\t", " ").Replace(text)
+	}
+	return strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ", "\t", " ").Replace(text)
+}
+
+func collectTable(n *html.Node) *table {
+	t := &table{}
+
+	var walk func(n *html.Node, inHead bool)
+	walk = func(n *html.Node, inHead bool) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+
+			switch c.DataAtom {
+			case atom.Thead:
+				walk(c, true)
+			case atom.Tbody, atom.Tfoot:
+				walk(c, false)
+			case atom.Tr:
+				var row []tableCell
+				for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type != html.ElementNode || (cell.DataAtom != atom.Th && cell.DataAtom != atom.Td) {
+						continue
+					}
+
+					header := cell.DataAtom == atom.Th
+					f := fontRegular
+					if header {
+						f = fontBold
+					}
+					row = append(row, tableCell{
+						runs:       inlineRuns(cell, f),
+						header:     header,
+						alignRight: attribute(cell, "align") == "right",
+						width:      parseWidth(attribute(cell, "width")),
+					})
+				}
+
+				if inHead {
+					t.headerRows = append(t.headerRows, row)
+				} else {
+					t.rows = append(t.rows, row)
+				}
+			}
+		}
+	}
+	walk(n, false)
+
+	return t
+}
+
+// parseWidth parses a percentage width attribute as a fraction, returning 0 for anything else
+func parseWidth(width string) float64 {
+	percentage, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(width), "%"), 64)
+	if err != nil || !strings.HasSuffix(width, "%") || percentage <= 0 || percentage > 100 {
+		return 0
+	}
+	return percentage / 100
+}
+
+// word is a piece of text that isn't split across lines, unless it's too long for a line of its own
+type word struct {
+	text        []byte
+	font        font
+	spaceBefore bool
+}
+
+// wrap splits runs into lines no wider than width, breaking them at spaces and line breaks
+func wrap(runs []run, size float64, width float64) [][]word {
+	var lines [][]word
+	var current []word
+	currentWidth := 0.0
+
+	flush := func() {
+		lines = append(lines, current)
+		current = nil
+		currentWidth = 0
+	}
+
+	addWord := func(w word) {
+		wordWidth := w.font.textWidth(w.text, size)
+		spaceWidth := 0.0
+		if len(current) > 0 && w.spaceBefore {
+			spaceWidth = w.font.textWidth([]byte(" "), size)
+		}
+		if len(current) > 0 && currentWidth+spaceWidth+wordWidth > width {
+			flush()
+			spaceWidth = 0
+		}
+
+		// words too long for a line of their own, like URLs, are split wherever they reach the end of the line
+		for wordWidth > width && len(w.text) > 1 {
+			fits := 1
+			for fits < len(w.text) && w.font.textWidth(w.text[:fits+1], size) <= width {
+				fits++
+			}
+			current = append(current, word{text: w.text[:fits], font: w.font})
+			flush()
+			w.text = w.text[fits:]
+			wordWidth = w.font.textWidth(w.text, size)
+		}
+
+		if len(current) == 0 {
+			w.spaceBefore = false
+		}
+		current = append(current, w)
+		currentWidth += spaceWidth + wordWidth
+	}
+
+	pendingSpace := false
+	for _, r := range runs {
+		encoded := encodeWinAnsi(r.text)
+		start := -1
+		for i := 0; i <= len(encoded); i++ {
+			if i < len(encoded) && encoded[i] != ' ' && encoded[i] != '\n' {
+				if start < 0 {
+					start = i
+				}
+				continue
+			}
+
+			if start >= 0 {
+				addWord(word{text: encoded[start:i], font: r.font, spaceBefore: pendingSpace})
+				pendingSpace = false
+				start = -1
+			}
+			if i < len(encoded) {
+				switch encoded[i] {
+				case ' ':
+					pendingSpace = true
+				case '\n':
+					flush()
+					pendingSpace = false
+				}
+			}
+		}
+	}
+	if len(current) > 0 {
+		flush()
+	}
+
+	return lines
+}
+
+func lineWidth(line []word, size float64) float64 {
+	total := 0.0
+	for i, w := range line {
+		if i > 0 && w.spaceBefore {
+			total += w.font.textWidth([]byte(" "), size)
+		}
+		total += w.font.textWidth(w.text, size)
+	}
+	return total
+}
+
+// layout places blocks on pages from top to bottom, starting new pages as they fill up
+type layout struct {
+	pages []*page
+	// y is the top of the space left on the current page
+	y float64
+}
+
+func newLayout() *layout {
+	l := &layout{}
+	l.newPage()
+	return l
+}
+
+func (l *layout) current() *page {
+	return l.pages[len(l.pages)-1]
+}
+
+func (l *layout) newPage() {
+	l.pages = append(l.pages, &page{})
+	l.y = pageHeight - pageMargin
+}
+
+func (l *layout) atTopOfPage() bool {
+	return l.y == pageHeight-pageMargin
+}
+
+// reserve starts a new page if height won't fit in the space left on the current page
+func (l *layout) reserve(height float64) {
+	if l.y-height < pageMargin+footerHeight && !l.atTopOfPage() {
+		l.newPage()
+	}
+}
+
+func (l *layout) drawLine(line []word, size float64, x float64, baseline float64) {
+	for i, w := range line {
+		if i > 0 && w.spaceBefore {
+			x += w.font.textWidth([]byte(" "), size)
+		}
+		l.current().text(w.font, size, x, baseline, w.text)
+		x += w.font.textWidth(w.text, size)
+	}
+}
+
+func (l *layout) paragraph(p *paragraph) {
+	lineHeight := p.size * lineSpacing
+	x := pageMargin + p.indent
+	lines := wrap(p.runs, p.size, contentWidth-p.indent)
+
+	if !l.atTopOfPage() {
+		l.y -= p.spaceBefore
+	}
+	if p.keepWithNext {
+		l.reserve(float64(len(lines))*lineHeight + 3*bodyFontSize*lineSpacing)
+	}
+
+	for i, line := range lines {
+		l.reserve(lineHeight)
+		baseline := l.y - p.size
+		if i == 0 && p.bullet {
+			l.current().text(fontRegular, p.size, x-listIndent+4, baseline, []byte{0x95})
+		}
+		l.drawLine(line, p.size, x, baseline)
+		l.y -= lineHeight
+	}
+	l.y -= p.spaceAfter
+}
+
+// laidOutRow is a table row with its cells wrapped to the widths of their columns
+type laidOutRow struct {
+	cells  []tableCell
+	lines  [][][]word
+	height float64
+}
+
+func (l *layout) table(t *table) {
+	widths := columnWidths(t)
+	if len(widths) == 0 {
+		return
+	}
+	lineHeight := tableFontSize * lineSpacing
+
+	layOut := func(row []tableCell) laidOutRow {
+		laidOut := laidOutRow{
+			cells: row,
+			lines: make([][][]word, len(widths)),
+		}
+		maxLines := 1
+		for i, cell := range row {
+			if i >= len(widths) {
+				break
+			}
+			laidOut.lines[i] = wrap(cell.runs, tableFontSize, widths[i]-2*cellPadding)
+			maxLines = max(maxLines, len(laidOut.lines[i]))
+		}
+		laidOut.height = float64(maxLines)*lineHeight + 2*cellPadding
+		return laidOut
+	}
+
+	draw := func(row laidOutRow, header bool) {
+		x := pageMargin
+		for i, width := range widths {
+			var cell tableCell
+			if i < len(row.cells) {
+				cell = row.cells[i]
+			}
+			l.current().rect(x, l.y-row.height, width, row.height, header || cell.header)
+
+			for j, line := range row.lines[i] {
+				lineX := x + cellPadding
+				if cell.alignRight {
+					lineX = x + width - cellPadding - lineWidth(line, tableFontSize)
+				}
+				l.drawLine(line, tableFontSize, lineX, l.y-cellPadding-tableFontSize-float64(j)*lineHeight)
+			}
+			x += width
+		}
+		l.y -= row.height
+	}
+
+	var headerRows []laidOutRow
+	headerHeight := 0.0
+	for _, row := range t.headerRows {
+		laidOut := layOut(row)
+		headerRows = append(headerRows, laidOut)
+		headerHeight += laidOut.height
+	}
+	drawHeader := func() {
+		for _, row := range headerRows {
+			draw(row, true)
+		}
+	}
+
+	if !l.atTopOfPage() {
+		l.y -= 4
+	}
+	firstRowHeight := 0.0
+	if len(t.rows) > 0 {
+		firstRowHeight = layOut(t.rows[0]).height
+	}
+	l.reserve(headerHeight + firstRowHeight)
+	drawHeader()
+
+	for _, row := range t.rows {
+		laidOut := layOut(row)
+		if l.y-laidOut.height < pageMargin+footerHeight {
+			l.newPage()
+			drawHeader()
+		}
+		draw(laidOut, false)
+	}
+	l.y -= 10
+}
+
+// columnWidths returns the width of each of the table's columns, in points
+func columnWidths(t *table) []float64 {
+	var first []tableCell
+	columns := 0
+	for _, row := range append(append([][]tableCell{}, t.headerRows...), t.rows...) {
+		if first == nil {
+			first = row
+		}
+		columns = max(columns, len(row))
+	}
+	if columns == 0 {
+		return nil
+	}
+
+	fractions := make([]float64, columns)
+	remaining := 1.0
+	unsized := columns
+	for i, cell := range first {
+		if cell.width > 0 && cell.width <= remaining {
+			fractions[i] = cell.width
+			remaining -= cell.width
+			unsized--
+		}
+	}
+
+	widths := make([]float64, columns)
+	for i, fraction := range fractions {
+		if fraction == 0 && unsized > 0 {
+			fraction = remaining / float64(unsized)
+		}
+		widths[i] = fraction * contentWidth
+	}
+	return widths
+}
+
+// footers adds the title and page number to the bottom of every page
+func (l *layout) footers(title string) {
+	encodedTitle := encodeWinAnsi(strings.ReplaceAll(title, "\n", " "))
+	// leave room for the page number
+	maxTitleWidth := contentWidth - 80
+	for len(encodedTitle) > 0 && fontRegular.textWidth(encodedTitle, footerFontSize) > maxTitleWidth {
+		encodedTitle = encodedTitle[:len(encodedTitle)-1]
+	}
+
+	for i, p := range l.pages {
+		baseline := pageMargin
+		p.text(fontRegular, footerFontSize, pageMargin, baseline, encodedTitle)
+
+		pageNumber := encodeWinAnsi(fmt.Sprintf("Page %d of %d", i+1, len(l.pages)))
+		p.text(fontRegular, footerFontSize, pageWidth-pageMargin-fontRegular.textWidth(pageNumber, footerFontSize), baseline, pageNumber)
+	}
+}
@@ -0,0 +1,163 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PDFTestSuite struct {
+	suite.Suite
+}
+
+func TestPDFTestSuite(t *testing.T) {
+	suite.Run(t, new(PDFTestSuite))
+}
+
+var (
+	objectPattern = regexp.MustCompile(`(?s)(\d+) 0 obj\n<< /Length (\d+) /Filter /FlateDecode >>\nstream\n`)
+	xrefPattern   = regexp.MustCompile(`(?s)xref\n0 (\d+)\n0000000000 65535 f \n(.*)trailer`)
+)
+
+// pageContents checks the structure of the PDF, and returns the decompressed content stream of each page
+func (s *PDFTestSuite) pageContents(document []byte) []string {
+	s.True(bytes.HasPrefix(document, []byte("%PDF-1.4\n")))
+	s.True(bytes.HasSuffix(document, []byte("%%EOF\n")))
+
+	// every object in the cross-reference table must be at the offset it says
+	xref := xrefPattern.FindSubmatch(document)
+	s.Require().NotNil(xref)
+	entries := strings.Split(strings.TrimSpace(string(xref[2])), "\n")
+	count, err := strconv.Atoi(string(xref[1]))
+	s.NoError(err)
+	s.Len(entries, count-1)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[:10])
+		s.NoError(err)
+		s.True(bytes.HasPrefix(document[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d is not at offset %d", i+1, offset)
+	}
+
+	var contents []string
+	for _, match := range objectPattern.FindAllSubmatchIndex(document, -1) {
+		length, err := strconv.Atoi(string(document[match[4]:match[5]]))
+		s.NoError(err)
+
+		reader, err := zlib.NewReader(bytes.NewReader(document[match[1] : match[1]+length]))
+		s.NoError(err)
+		content, err := io.ReadAll(reader)
+		s.NoError(err)
+		contents = append(contents, string(content))
+	}
+	return contents
+}
+
+func (s *PDFTestSuite) TestFromHTML() {
+	document := `<!DOCTYPE html>
+<html>
+<head><title>Business Case: Test (Project)</title><style>h1 { color: red; }</style></head>
+<body>
+	<h1>Test Project</h1>
+	<p><strong>Requester:</strong> Jane Doe</p>
+	<p style="white-space: pre-line">First line
+Second line</p>
+	<ul><li>A list item</li></ul>
+	<table>
+		<thead><tr><th width="50%">Phase</th><th>Year 1</th></tr></thead>
+		<tbody><tr><td>Development</td><td align="right">$1,200</td></tr></tbody>
+	</table>
+</body>
+</html>`
+
+	var buf bytes.Buffer
+	s.NoError(FromHTML(&buf, strings.NewReader(document)))
+
+	s.Contains(buf.String(), `/Title (Business Case: Test \(Project\))`)
+
+	contents := s.pageContents(buf.Bytes())
+	s.Len(contents, 1)
+	content := contents[0]
+
+	s.Contains(content, "/F2 18.00 Tf 54.00 720.00 Td (Test) Tj")
+	s.Contains(content, "/F2 10.00 Tf 54.00")
+	s.Contains(content, "(Requester:) Tj")
+	s.Contains(content, "(Jane) Tj")
+	s.Contains(content, "(First) Tj")
+	s.Contains(content, "(Second) Tj")
+	s.Contains(content, "(\x95) Tj")
+	s.Contains(content, "(Phase) Tj")
+	s.Contains(content, "($1,200) Tj")
+	s.Contains(content, "(Page 1 of 1) Tj")
+	s.NotContains(content, "color")
+
+	// the pre-line paragraph keeps its line break
+	first := regexp.MustCompile(`([\d.]+) Td \(First\)`).FindStringSubmatch(content)
+	second := regexp.MustCompile(`([\d.]+) Td \(Second\)`).FindStringSubmatch(content)
+	s.Require().NotNil(first)
+	s.Require().NotNil(second)
+	s.NotEqual(first[1], second[1])
+}
+
+func (s *PDFTestSuite) TestFromHTMLPaginatesLongTables() {
+	var rows strings.Builder
+	for i := 0; i < 120; i++ {
+		fmt.Fprintf(&rows, "<tr><td>Row %d</td><td>Value</td></tr>", i)
+	}
+	document := "<html><head><title>Long</title></head><body><table><thead><tr><th>Name</th><th>Value</th></tr></thead><tbody>" +
+		rows.String() + "</tbody></table></body></html>"
+
+	var buf bytes.Buffer
+	s.NoError(FromHTML(&buf, strings.NewReader(document)))
+
+	contents := s.pageContents(buf.Bytes())
+	s.Greater(len(contents), 1)
+	for i, content := range contents {
+		// the header is repeated on every page
+		s.Contains(content, "(Name) Tj")
+		s.Contains(content, fmt.Sprintf("(Page %d of %d) Tj", i+1, len(contents)))
+	}
+	s.Contains(contents[len(contents)-1], "(119) Tj")
+}
+
+func (s *PDFTestSuite) TestWrap() {
+	runs := []run{
+		{text: "Label:", font: fontBold},
+		{text: " some words that need wrapping", font: fontRegular},
+	}
+
+	lines := wrap(runs, 10, 80)
+	s.Greater(len(lines), 1)
+	s.Equal("Label:", string(lines[0][0].text))
+	s.Equal(fontBold, lines[0][0].font)
+	s.Equal(fontRegular, lines[0][1].font)
+	s.True(lines[0][1].spaceBefore)
+	for _, line := range lines {
+		s.LessOrEqual(lineWidth(line, 10), 80.0)
+		s.False(line[0].spaceBefore)
+	}
+
+	s.Run("splits words too long for a line", func() {
+		lines := wrap([]run{{text: strings.Repeat("x", 100), font: fontRegular}}, 10, 50)
+		s.Greater(len(lines), 1)
+		for _, line := range lines {
+			s.LessOrEqual(lineWidth(line, 10), 50.0)
+		}
+	})
+
+	s.Run("keeps blank lines", func() {
+		lines := wrap([]run{{text: "one\n\ntwo", font: fontRegular}}, 10, 500)
+		s.Len(lines, 3)
+		s.Empty(lines[1])
+	})
+}
+
+func (s *PDFTestSuite) TestEncodeWinAnsi() {
+	s.Equal([]byte("caf\xe9 \x93quoted\x94 \x97 ?"), encodeWinAnsi("café “quoted” — 漢"))
+	s.Equal([]byte("tab and\nline"), encodeWinAnsi("tab\tand\nline"))
+}
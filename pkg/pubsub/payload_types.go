@@ -0,0 +1,43 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// payloadTypes maps each EventType to the Go type of the payload published with it.
+// Implementations that carry events outside of the current process need this to rebuild payloads after
+// they have been serialized, since subscribers assert on the concrete payload type they receive.
+var payloadTypes = struct {
+	types map[EventType]reflect.Type
+	sync.RWMutex
+}{types: make(map[EventType]reflect.Type)}
+
+// RegisterPayloadType records the type of payload published for an EventType. The payload argument is only used
+// for its type, e.g. RegisterPayloadType(myEvent, models.MyPayload{}).
+func RegisterPayloadType(eventType EventType, payload any) {
+	payloadTypes.Lock()
+	defer payloadTypes.Unlock()
+
+	payloadTypes.types[eventType] = reflect.TypeOf(payload)
+}
+
+// decodePayload unmarshals a serialized payload into a value of the type registered for the EventType
+func decodePayload(eventType EventType, data json.RawMessage) (any, error) {
+	payloadTypes.RLock()
+	payloadType, ok := payloadTypes.types[eventType]
+	payloadTypes.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no payload type registered for event type [%v]", eventType)
+	}
+
+	payload := reflect.New(payloadType)
+	if err := json.Unmarshal(data, payload.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to decode payload for event type [%v]: %w", eventType, err)
+	}
+
+	return payload.Elem().Interface(), nil
+}
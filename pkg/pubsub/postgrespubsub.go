@@ -0,0 +1,265 @@
+package pubsub
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+const (
+	// postgresPubSubChannel is the Postgres notification channel every instance listens on
+	postgresPubSubChannel = "easi_pubsub"
+
+	// postgresNotifyMaxPayloadBytes is the largest payload Postgres accepts in a NOTIFY (8000 bytes by default)
+	postgresNotifyMaxPayloadBytes = 7999
+
+	postgresListenerMinReconnectInterval = 1 * time.Second
+	postgresListenerMaxReconnectInterval = 1 * time.Minute
+
+	// postgresListenerPingInterval is how often an idle listener checks that its connection is still alive
+	postgresListenerPingInterval = 90 * time.Second
+)
+
+// NamedExecer executes a statement with named arguments. *storage.Store satisfies this interface.
+type NamedExecer interface {
+	NamedExecContext(ctx context.Context, sqlStatement string, arguments any) (sql.Result, error)
+}
+
+// DataSourceNameFunc returns a connection string for a dedicated listener connection.
+// It is called each time the listener (re)connects so that short-lived credentials (e.g. IAM auth tokens) stay fresh.
+type DataSourceNameFunc func(ctx context.Context) (string, error)
+
+// postgresEnvelope is the message sent over the notification channel
+type postgresEnvelope struct {
+	SessionID uuid.UUID       `json:"sessionId"`
+	EventType EventType       `json:"eventType"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// PostgresPubSub is a PubSub implementation that carries events between application instances using Postgres LISTEN/NOTIFY.
+//
+// Subscriptions are held in memory on the instance the subscriber is connected to. Published events are sent as a
+// NOTIFY and delivered to local subscribers when each instance (including the publisher) receives the notification.
+// Payload types must be registered with RegisterPayloadType so they can be rebuilt on the receiving side.
+type PostgresPubSub struct {
+	local          *ServicePubSub
+	db             NamedExecer
+	dataSourceName DataSourceNameFunc
+	logger         *zap.Logger
+	cancel         context.CancelFunc
+	done           chan struct{}
+}
+
+// NewPostgresPubSub creates a PubSub service backed by Postgres LISTEN/NOTIFY.
+// It returns once the instance is listening for events, and keeps listening until Close is called.
+func NewPostgresPubSub(ctx context.Context, db NamedExecer, dataSourceName DataSourceNameFunc, logger *zap.Logger) (*PostgresPubSub, error) {
+	ps := &PostgresPubSub{
+		db:             db,
+		dataSourceName: dataSourceName,
+		logger:         logger,
+		done:           make(chan struct{}),
+	}
+	ps.local = &ServicePubSub{
+		sessions: make(SessionMap),
+		owner:    ps,
+	}
+
+	listener, connectionFailed, err := ps.newListener(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listenCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	ps.cancel = cancel
+
+	go ps.listen(listenCtx, listener, connectionFailed)
+
+	return ps, nil
+}
+
+// Subscribe registers the subscriber for notifications of a given eventType within a session
+func (ps *PostgresPubSub) Subscribe(sessionID uuid.UUID, eventType EventType, subscriber Subscriber, onDisconnect <-chan struct{}) {
+	ps.local.Subscribe(sessionID, eventType, subscriber, onDisconnect)
+}
+
+// Unsubscribe unregisters a subscriber from notifications of a given eventType within a session
+func (ps *PostgresPubSub) Unsubscribe(sessionID uuid.UUID, eventType EventType, subscriberID string) {
+	ps.local.Unsubscribe(sessionID, eventType, subscriberID)
+}
+
+// Publish sends an event and corresponding payload to the registered Subscriber entities on every instance.
+//
+// If the event cannot be sent through Postgres it is still delivered to the subscribers on this instance.
+func (ps *PostgresPubSub) Publish(sessionID uuid.UUID, eventType EventType, payload interface{}) {
+	if err := ps.notify(sessionID, eventType, payload); err != nil {
+		ps.logger.Error("Failed to publish event through Postgres, delivering to local subscribers only",
+			zap.Error(err),
+			zap.String("session_id", sessionID.String()),
+			zap.String("event_type", string(eventType)),
+		)
+		ps.local.Publish(sessionID, eventType, payload)
+	}
+}
+
+// Close stops listening for events
+func (ps *PostgresPubSub) Close() {
+	ps.cancel()
+	<-ps.done
+}
+
+func (ps *PostgresPubSub) notify(sessionID uuid.UUID, eventType EventType, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	message, err := json.Marshal(postgresEnvelope{
+		SessionID: sessionID,
+		EventType: eventType,
+		Payload:   payloadJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	if len(message) > postgresNotifyMaxPayloadBytes {
+		return fmt.Errorf("event is %d bytes, which exceeds the Postgres NOTIFY limit of %d bytes", len(message), postgresNotifyMaxPayloadBytes)
+	}
+
+	_, err = ps.db.NamedExecContext(context.Background(), `SELECT pg_notify(:channel, :message)`, map[string]any{
+		"channel": postgresPubSubChannel,
+		"message": string(message),
+	})
+	return err
+}
+
+// newListener opens a listener connection and starts listening on the notification channel.
+// The returned channel is closed if the listener gives up on its connection, so that it can be rebuilt with fresh credentials.
+func (ps *PostgresPubSub) newListener(ctx context.Context) (*pq.Listener, <-chan struct{}, error) {
+	dsn, err := ps.dataSourceName(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build listener connection string: %w", err)
+	}
+
+	connectionFailed := make(chan struct{})
+	failed := false
+
+	listener := pq.NewListener(dsn, postgresListenerMinReconnectInterval, postgresListenerMaxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventConnectionAttemptFailed:
+			ps.logger.Warn("Postgres pubsub listener failed to connect", zap.Error(err))
+			// events are emitted from a single goroutine, so there is no need to guard this flag
+			if !failed {
+				failed = true
+				close(connectionFailed)
+			}
+		case pq.ListenerEventDisconnected:
+			ps.logger.Warn("Postgres pubsub listener disconnected", zap.Error(err))
+		case pq.ListenerEventReconnected:
+			ps.logger.Info("Postgres pubsub listener reconnected")
+		case pq.ListenerEventConnected:
+		}
+	})
+
+	// Listen blocks until the listener is connected, so give up on this listener if its first connection attempt fails
+	listenResult := make(chan error, 1)
+	go func() {
+		listenResult <- listener.Listen(postgresPubSubChannel)
+	}()
+
+	select {
+	case err := <-listenResult:
+		if err != nil {
+			_ = listener.Close()
+			return nil, nil, fmt.Errorf("failed to listen on channel [%v]: %w", postgresPubSubChannel, err)
+		}
+	case <-connectionFailed:
+		_ = listener.Close()
+		return nil, nil, fmt.Errorf("failed to connect listener for channel [%v]", postgresPubSubChannel)
+	}
+
+	return listener, connectionFailed, nil
+}
+
+// listen delivers incoming notifications to local subscribers until the context is cancelled.
+// If the listener can no longer connect, it is replaced with a new one.
+func (ps *PostgresPubSub) listen(ctx context.Context, listener *pq.Listener, connectionFailed <-chan struct{}) {
+	defer close(ps.done)
+
+	ticker := time.NewTicker(postgresListenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			_ = listener.Close()
+			return
+
+		case notification := <-listener.NotificationChannel():
+			// a nil notification is sent after the connection is re-established; anything sent while disconnected is lost
+			if notification == nil {
+				ps.logger.Warn("Postgres pubsub listener reconnected, events published while disconnected were not received")
+				continue
+			}
+			ps.deliver(notification.Extra)
+
+		case <-ticker.C:
+			if err := listener.Ping(); err != nil {
+				ps.logger.Warn("Postgres pubsub listener ping failed", zap.Error(err))
+			}
+
+		case <-connectionFailed:
+			_ = listener.Close()
+
+			listener, connectionFailed = ps.reconnect(ctx)
+			if listener == nil {
+				return
+			}
+		}
+	}
+}
+
+// reconnect replaces a listener that could not stay connected, retrying until it succeeds or the context is cancelled.
+// It returns a nil listener if the context is cancelled first.
+func (ps *PostgresPubSub) reconnect(ctx context.Context) (*pq.Listener, <-chan struct{}) {
+	for {
+		listener, connectionFailed, err := ps.newListener(ctx)
+		if err == nil {
+			return listener, connectionFailed
+		}
+
+		ps.logger.Error("Failed to recreate Postgres pubsub listener", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-time.After(postgresListenerMaxReconnectInterval):
+		}
+	}
+}
+
+// deliver decodes a notification and publishes it to the subscribers on this instance
+func (ps *PostgresPubSub) deliver(message string) {
+	var envelope postgresEnvelope
+	if err := json.Unmarshal([]byte(message), &envelope); err != nil {
+		ps.logger.Error("Failed to decode Postgres pubsub event", zap.Error(err))
+		return
+	}
+
+	payload, err := decodePayload(envelope.EventType, envelope.Payload)
+	if err != nil {
+		ps.logger.Error("Failed to decode Postgres pubsub payload",
+			zap.Error(err),
+			zap.String("event_type", string(envelope.EventType)),
+		)
+		return
+	}
+
+	ps.local.Publish(envelope.SessionID, envelope.EventType, payload)
+}
@@ -0,0 +1,187 @@
+package pubsub_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // required for postgres driver in sqlx
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appconfig"
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/local/pubsubmock"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+	"github.com/cms-enterprise/easi-app/pkg/testhelpers"
+)
+
+// postgresTestPayload is the payload published in these tests, so that delivery across instances exercises
+// encoding and decoding a struct
+type postgresTestPayload struct {
+	Message string
+	Count   int
+}
+
+// channelSubscriber is a Subscriber that forwards notifications to a channel, as notifications from Postgres
+// arrive on the listener's goroutine rather than the publisher's
+type channelSubscriber struct {
+	id       string
+	payloads chan interface{}
+}
+
+func newChannelSubscriber(id string) *channelSubscriber {
+	return &channelSubscriber{
+		id:       id,
+		payloads: make(chan interface{}, 10),
+	}
+}
+
+func (c *channelSubscriber) GetID() string {
+	return c.id
+}
+
+func (c *channelSubscriber) GetPrincipal() authentication.Principal {
+	return &authentication.EUAPrincipal{EUAID: "ABCD"}
+}
+
+func (c *channelSubscriber) Notify(payload interface{}) {
+	c.payloads <- payload
+}
+
+func (c *channelSubscriber) NotifyUnsubscribed(ps pubsub.PubSub, sessionID uuid.UUID) {}
+
+type PostgresPubSubTestSuite struct {
+	suite.Suite
+	db       *sqlx.DB
+	dbConfig storage.DBConfig
+}
+
+func TestPostgresPubSubTestSuite(t *testing.T) {
+	config := testhelpers.NewConfig()
+
+	dbConfig := storage.DBConfig{
+		Host:     config.GetString(appconfig.DBHostConfigKey),
+		Port:     config.GetString(appconfig.DBPortConfigKey),
+		Database: config.GetString(appconfig.DBNameConfigKey),
+		Username: config.GetString(appconfig.DBUsernameConfigKey),
+		Password: config.GetString(appconfig.DBPasswordConfigKey),
+		SSLMode:  config.GetString(appconfig.DBSSLModeConfigKey),
+	}
+
+	dsn, err := dbConfig.DataSourceName(context.Background())
+	if err != nil {
+		t.Fatalf("failed to build data source name: %v", err)
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	pubsub.RegisterPayloadType(pubsubmock.MockEvent, postgresTestPayload{})
+
+	suite.Run(t, &PostgresPubSubTestSuite{
+		db:       db,
+		dbConfig: dbConfig,
+	})
+}
+
+func (s *PostgresPubSubTestSuite) newPostgresPubSub() *pubsub.PostgresPubSub {
+	ps, err := pubsub.NewPostgresPubSub(context.Background(), s.db, s.dbConfig.DataSourceName, zap.NewNop())
+	s.Require().NoError(err)
+	return ps
+}
+
+func (s *PostgresPubSubTestSuite) awaitPayload(subscriber *channelSubscriber) interface{} {
+	select {
+	case payload := <-subscriber.payloads:
+		return payload
+	case <-time.After(5 * time.Second):
+		s.FailNow("timed out waiting for notification", "subscriber [%v] was not notified", subscriber.id)
+		return nil
+	}
+}
+
+func (s *PostgresPubSubTestSuite) TestEventsAreSharedBetweenInstances() {
+	instanceA := s.newPostgresPubSub()
+	defer instanceA.Close()
+
+	instanceB := s.newPostgresPubSub()
+	defer instanceB.Close()
+
+	sessionID := uuid.New()
+	disconnect := make(chan struct{})
+	defer close(disconnect)
+
+	subscriberA := newChannelSubscriber("subscriber-a")
+	subscriberB := newChannelSubscriber("subscriber-b")
+	instanceA.Subscribe(sessionID, pubsubmock.MockEvent, subscriberA, disconnect)
+	instanceB.Subscribe(sessionID, pubsubmock.MockEvent, subscriberB, disconnect)
+
+	payload := postgresTestPayload{Message: "published on A", Count: 1}
+	instanceA.Publish(sessionID, pubsubmock.MockEvent, payload)
+
+	// subscribers on both the publishing instance and the other instance receive the event, with the payload's original type
+	s.Equal(payload, s.awaitPayload(subscriberA))
+	s.Equal(payload, s.awaitPayload(subscriberB))
+
+	payload = postgresTestPayload{Message: "published on B", Count: 2}
+	instanceB.Publish(sessionID, pubsubmock.MockEvent, payload)
+
+	s.Equal(payload, s.awaitPayload(subscriberA))
+	s.Equal(payload, s.awaitPayload(subscriberB))
+}
+
+func (s *PostgresPubSubTestSuite) TestEventsAreScopedToSession() {
+	instanceA := s.newPostgresPubSub()
+	defer instanceA.Close()
+
+	instanceB := s.newPostgresPubSub()
+	defer instanceB.Close()
+
+	disconnect := make(chan struct{})
+	defer close(disconnect)
+
+	subscribedSessionID := uuid.New()
+	otherSessionID := uuid.New()
+
+	subscriber := newChannelSubscriber("subscriber")
+	instanceB.Subscribe(subscribedSessionID, pubsubmock.MockEvent, subscriber, disconnect)
+
+	instanceA.Publish(otherSessionID, pubsubmock.MockEvent, postgresTestPayload{Message: "other session"})
+
+	payload := postgresTestPayload{Message: "subscribed session"}
+	instanceA.Publish(subscribedSessionID, pubsubmock.MockEvent, payload)
+
+	// notifications are delivered in order, so the first one received must be for the subscribed session
+	s.Equal(payload, s.awaitPayload(subscriber))
+}
+
+func (s *PostgresPubSubTestSuite) TestUnsubscribe() {
+	instanceA := s.newPostgresPubSub()
+	defer instanceA.Close()
+
+	instanceB := s.newPostgresPubSub()
+	defer instanceB.Close()
+
+	sessionID := uuid.New()
+	disconnect := make(chan struct{})
+	defer close(disconnect)
+
+	unsubscribed := newChannelSubscriber("unsubscribed")
+	subscribed := newChannelSubscriber("subscribed")
+	instanceB.Subscribe(sessionID, pubsubmock.MockEvent, unsubscribed, disconnect)
+	instanceB.Subscribe(sessionID, pubsubmock.MockEvent, subscribed, disconnect)
+	instanceB.Unsubscribe(sessionID, pubsubmock.MockEvent, unsubscribed.GetID())
+
+	payload := postgresTestPayload{Message: "after unsubscribe"}
+	instanceA.Publish(sessionID, pubsubmock.MockEvent, payload)
+
+	s.Equal(payload, s.awaitPayload(subscribed))
+	s.Empty(unsubscribed.payloads)
+}
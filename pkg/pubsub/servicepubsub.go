@@ -10,6 +10,11 @@ import (
 type ServicePubSub struct {
 	sessions SessionMap
 	lock     sync.Mutex
+
+	// owner is the PubSub handed to subscribers when they are unsubscribed. It is nil unless this ServicePubSub is
+	// managing local subscriptions on behalf of another PubSub implementation (e.g. PostgresPubSub), in which case
+	// anything a subscriber publishes on unsubscribe must go through that implementation.
+	owner PubSub
 }
 
 // NewServicePubSub creates a new instance of a PubSub service
@@ -76,7 +81,15 @@ func (ps *ServicePubSub) Unsubscribe(sessionID uuid.UUID, eventType EventType, s
 
 	ps.lock.Unlock()
 
-	subscriber.NotifyUnsubscribed(ps, sessionID)
+	subscriber.NotifyUnsubscribed(ps.unsubscribeNotifier(), sessionID)
+}
+
+// unsubscribeNotifier returns the PubSub that subscribers should use from their unsubscribe callbacks
+func (ps *ServicePubSub) unsubscribeNotifier() PubSub {
+	if ps.owner != nil {
+		return ps.owner
+	}
+	return ps
 }
 
 // Publish dispatches an event and corresponding payload to all registered Subscriber entities
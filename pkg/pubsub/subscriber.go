@@ -11,5 +11,5 @@ type Subscriber interface {
 	GetID() string
 	GetPrincipal() authentication.Principal
 	Notify(payload interface{})
-	NotifyUnsubscribed(ps *ServicePubSub, sessionID uuid.UUID)
+	NotifyUnsubscribed(ps PubSub, sessionID uuid.UUID)
 }
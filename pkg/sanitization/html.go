@@ -9,6 +9,9 @@ import (
 var (
 	htmlInitOnce        sync.Once
 	htmlSanitizerPolicy *bluemonday.Policy
+
+	htmlDiffInitOnce        sync.Once
+	htmlDiffSanitizerPolicy *bluemonday.Policy
 )
 
 // SanitizeHTML takes a string representation of HTML and sanitizes it
@@ -20,6 +23,16 @@ func SanitizeHTML[stringType ~string](input stringType) stringType {
 	return stringType(output)
 }
 
+// SanitizeHTMLDiff sanitizes a diff between two pieces of HTML, which is the standard policy plus the <ins> and <del> elements the diff marks changes with
+func SanitizeHTMLDiff[stringType ~string](input stringType) stringType {
+	htmlDiffInitOnce.Do(func() {
+		htmlDiffSanitizerPolicy = createHTMLPolicy()
+		htmlDiffSanitizerPolicy.AllowElements("ins", "del")
+	})
+
+	return stringType(htmlDiffSanitizerPolicy.Sanitize(string(input)))
+}
+
 func getHTMLSanitizerPolicy() *bluemonday.Policy {
 	htmlInitOnce.Do(func() {
 		htmlSanitizerPolicy = createHTMLPolicy()
@@ -5,6 +5,7 @@ import "errors"
 var (
 	errGettingStore            = errors.New("error getting store from scheduler")
 	errGettingEmailClient      = errors.New("error getting email client from scheduler")
+	errGettingEmailSender      = errors.New("error getting email sender from scheduler")
 	errBuildingDataloaders     = errors.New("error building dataloaders")
 	errFetchingIntakes         = errors.New("error fetching intakes")
 	errProblemSendingEmail     = errors.New("problem sending email")
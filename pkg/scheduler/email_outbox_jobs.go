@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/scheduler/timing"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+const (
+	// emailOutboxBatchSize is the most emails the dispatcher sends each time it runs
+	emailOutboxBatchSize = 50
+
+	// emailOutboxClaimLease is how long an email is claimed for while it's being sent. It's much longer than sending an email takes,
+	// so an email is only claimed again if the dispatcher sending it stopped before recording the result
+	emailOutboxClaimLease = 5 * time.Minute
+)
+
+type emailOutboxJobs struct {
+	// DispatchEmailOutboxJob is a job that sends the emails queued in the outbox, retrying ones that fail
+	DispatchEmailOutboxJob ScheduledJob
+}
+
+// EmailOutboxJobs is the exported representation of all email outbox scheduled jobs
+// this line initializes email outbox jobs
+var EmailOutboxJobs = getEmailOutboxJobs(SharedScheduler)
+
+// getEmailOutboxJobs initializes all email outbox jobs
+func getEmailOutboxJobs(scheduler *Scheduler) *emailOutboxJobs {
+	return &emailOutboxJobs{
+		DispatchEmailOutboxJob: NewScheduledJob(
+			"DispatchEmailOutboxJob",
+			scheduler,
+			timing.Every30Seconds,
+			dispatchEmailOutboxJobFunction,
+		),
+	}
+}
+
+func dispatchEmailOutboxJobFunction(ctx context.Context, scheduledJob *ScheduledJob) error {
+	logger, err := scheduledJob.logger(ctx)
+	if err != nil {
+		return err
+	}
+
+	store, err := scheduledJob.store()
+	if err != nil {
+		wrappedErr := fmt.Errorf("%[1]w: %[2]w", errGettingStore, err)
+		logger.Error(errGettingStore.Error(), zap.Error(wrappedErr))
+		return wrappedErr
+	}
+
+	sender, err := scheduledJob.emailSender()
+	if err != nil {
+		wrappedErr := fmt.Errorf("%[1]w: %[2]w", errGettingEmailSender, err)
+		logger.Error(errGettingEmailSender.Error(), zap.Error(wrappedErr))
+		return wrappedErr
+	}
+
+	_, err = dispatchEmailOutbox(ctx, store, sender, logger, emailOutboxBatchSize)
+	return err
+}
+
+// dispatchEmailOutbox sends up to limit of the emails in the outbox whose next attempt is due, and records the result of each attempt.
+// Each email is claimed before it's sent, so running this concurrently doesn't send any email twice. Emails are sent outside of any
+// transaction, and each result is recorded on its own, so a problem recording one doesn't cause the others to be sent again.
+// It returns the emails that were attempted
+func dispatchEmailOutbox(
+	ctx context.Context,
+	store *storage.Store,
+	sender emailSender,
+	logger *zap.Logger,
+	limit int,
+) ([]*models.EmailOutboxMessage, error) {
+	var attempted []*models.EmailOutboxMessage
+	var recordErrs []error
+	for len(attempted) < limit {
+		message, err := store.ClaimDueEmailOutboxMessage(ctx, emailOutboxClaimLease)
+		if err != nil {
+			return attempted, errors.Join(append(recordErrs, err)...)
+		}
+		if message == nil {
+			break
+		}
+		attempted = append(attempted, message)
+
+		messageLogger := logger.With(zap.String("emailOutboxMessageID", message.ID.String()), zap.Int("attempt", message.Attempts+1))
+
+		providerMessageID, sendErr := sendOutboxMessage(ctx, sender, message)
+		if sendErr != nil {
+			message.RecordFailure(time.Now(), sendErr)
+			if message.Status == models.EmailOutboxMessageStatusDeadLetter {
+				messageLogger.Error("email could not be sent, moving it to the dead letter state", zap.Error(sendErr))
+			} else {
+				messageLogger.Warn("email could not be sent, it will be retried", zap.Error(sendErr), zap.Time("nextAttemptAt", message.NextAttemptAt))
+			}
+		} else {
+			message.RecordSent(time.Now())
+			if providerMessageID != "" {
+				message.ProviderMessageID = &providerMessageID
+			}
+			messageLogger.Info(emailSent)
+		}
+
+		// if the result can't be recorded, the email stays claimed until its lease passes, then it's attempted again
+		if err := store.UpdateEmailOutboxMessageDelivery(ctx, store, message); err != nil {
+			messageLogger.Error("problem recording the attempt to send an email", zap.Error(err))
+			recordErrs = append(recordErrs, err)
+		}
+	}
+
+	return attempted, errors.Join(recordErrs...)
+}
+
+// sendOutboxMessage sends an email from the outbox. If the sender is an email.TrackedSender, it returns the ID the email provider gave the email,
+// so the provider's delivery notifications can be matched to it
+func sendOutboxMessage(ctx context.Context, sender emailSender, message *models.EmailOutboxMessage) (string, error) {
+	if trackedSender, ok := sender.(email.TrackedSender); ok {
+		return trackedSender.SendTracked(ctx, email.OutboxMessageEmail(message))
+	}
+	return "", sender.Send(ctx, email.OutboxMessageEmail(message))
+}
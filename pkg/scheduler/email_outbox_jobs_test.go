@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// failingSender fails to send emails with the given subject, and sends the rest with the wrapped sender
+type failingSender struct {
+	emailSender
+	failingSubject string
+}
+
+func (s failingSender) Send(ctx context.Context, emailData email.Email) error {
+	if emailData.Subject == s.failingSubject {
+		return errors.New("rejected")
+	}
+	return s.emailSender.Send(ctx, emailData)
+}
+
+func (suite *SchedulerTestSuite) TestDispatchEmailOutbox() {
+	ctx := suite.testConfigs.Context
+	store := suite.testConfigs.Store
+	suite.testConfigs.Sender.Clear()
+
+	outboxSender := email.NewOutboxSender(store)
+	for _, subject := range []string{"delivered", "failing"} {
+		suite.NoError(outboxSender.Send(ctx, email.NewEmail().
+			WithToAddresses([]models.EmailAddress{"requester@local.fake"}).
+			WithSubject(subject).
+			WithBody("body"),
+		))
+	}
+
+	sender := failingSender{emailSender: suite.testConfigs.Sender, failingSubject: "failing"}
+
+	attempted, err := dispatchEmailOutbox(ctx, store, sender, suite.testConfigs.Logger, emailOutboxBatchSize)
+	suite.NoError(err)
+	suite.Len(attempted, 2)
+
+	sent, err := store.GetEmailOutboxMessages(ctx, helpers.PointerTo(models.EmailOutboxMessageStatusSent), 25, nil)
+	suite.NoError(err)
+	suite.Len(sent, 1)
+	suite.Equal("delivered", sent[0].Subject)
+	suite.Equal(1, sent[0].Attempts)
+	suite.NotNil(sent[0].SentAt)
+
+	pending, err := store.GetEmailOutboxMessages(ctx, helpers.PointerTo(models.EmailOutboxMessageStatusPending), 25, nil)
+	suite.NoError(err)
+	suite.Len(pending, 1)
+	suite.Equal("failing", pending[0].Subject)
+	suite.Equal(1, pending[0].Attempts)
+	suite.Equal("rejected", *pending[0].LastError)
+	suite.True(pending[0].NextAttemptAt.After(pending[0].CreatedAt))
+
+	// the failed email isn't retried until its backoff has passed, and the sent one isn't sent again
+	attempted, err = dispatchEmailOutbox(ctx, store, sender, suite.testConfigs.Logger, emailOutboxBatchSize)
+	suite.NoError(err)
+	suite.Empty(attempted)
+
+	suite.Run("emails claimed by another dispatcher aren't sent until their claim is released or expires", func() {
+		suite.NoError(outboxSender.Send(ctx, email.NewEmail().
+			WithToAddresses([]models.EmailAddress{"requester@local.fake"}).
+			WithSubject("claimed").
+			WithBody("body"),
+		))
+
+		claimed, err := store.ClaimDueEmailOutboxMessage(ctx, time.Minute)
+		suite.NoError(err)
+		suite.Equal("claimed", claimed.Subject)
+		suite.NotNil(claimed.ClaimID)
+
+		attempted, err := dispatchEmailOutbox(ctx, store, sender, suite.testConfigs.Logger, emailOutboxBatchSize)
+		suite.NoError(err)
+		suite.Empty(attempted)
+
+		// only the dispatcher holding the claim can record the attempt
+		stale := *claimed
+		stale.ClaimID = helpers.PointerTo(uuid.New())
+		stale.RecordSent(time.Now())
+		suite.Error(store.UpdateEmailOutboxMessageDelivery(ctx, store, &stale))
+
+		claimed.RecordSent(time.Now())
+		suite.NoError(store.UpdateEmailOutboxMessageDelivery(ctx, store, claimed))
+		suite.Nil(claimed.ClaimID)
+
+		message, err := store.GetEmailOutboxMessageByID(ctx, claimed.ID)
+		suite.NoError(err)
+		suite.Equal(models.EmailOutboxMessageStatusSent, message.Status)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/models"
 	"github.com/cms-enterprise/easi-app/pkg/scheduler/timing"
 	"github.com/cms-enterprise/easi-app/pkg/storage"
+	"github.com/cms-enterprise/easi-app/pkg/webhooks"
 )
 
 type grbEmailJobs struct {
@@ -195,7 +196,7 @@ func sendAsyncPastDueNoQuorumEmailJobFunction(ctx context.Context, scheduledJob
 
 	logger.Info(runningJob)
 
-	intakes, err := storage.GetSystemIntakesWithGRBReviewPastDueNoQuorum(ctx, store, logger)
+	intakes, err := storage.GetSystemIntakesWithGRBReviewAsyncEndDatePassed(ctx, store, logger)
 	if err != nil {
 		wrappedErr := fmt.Errorf("%[1]w: %[2]w", errFetchingIntakes, err)
 		logger.Error(errFetchingIntakes.Error(), zap.Error(wrappedErr))
@@ -220,6 +221,11 @@ func sendAsyncPastDueNoQuorumEmailJobFunction(ctx context.Context, scheduledJob
 				GRBReviewers: reviewers,
 			}
 
+			// quorum is determined by the intake's quorum policy, the review complete email is sent instead if it was reached
+			if votingInformation.QuorumReached() {
+				return nil
+			}
+
 			if err := emailClient.SystemIntake.SendGRBReviewPastDueNoQuorum(ctx, email.SendGRBReviewPastDueNoQuorumInput{
 				SystemIntakeID:     intake.ID,
 				ProjectTitle:       intake.ProjectName.String,
@@ -288,6 +294,12 @@ func sendGRBReviewEndedEmailJobFunction(ctx context.Context, scheduledJob *Sched
 	}
 
 	for _, intake := range intakes {
+		// subscribers are sent reviews that ended on their deadline here; reviews ended early are sent when they're ended
+		if _, err := webhooks.Emit(ctx, store, store, models.WebhookEventTypeGrbReviewEnded, webhooks.NewGRBReviewEndedEventData(intake, *intake.GrbReviewAsyncEndDate, false)); err != nil {
+			// don't exit with error, just log
+			logger.Error("problem queuing GRB review ended webhook event", zap.Error(err), logfields.IntakeID(intake.ID))
+		}
+
 		// get GRB reviewers
 		reviewers, err := store.SystemIntakeGRBReviewersBySystemIntakeIDs(ctx, []uuid.UUID{intake.ID})
 		if err != nil {
@@ -505,7 +517,7 @@ func sendAsyncReviewCompleteQuorumMetJobFunction(ctx context.Context, scheduledJ
 
 	logger.Info(runningJob)
 
-	intakes, err := storage.GetSystemIntakesWithGRBReviewCompleteQuorumMet(ctx, store, logger)
+	intakes, err := storage.GetSystemIntakesWithGRBReviewAsyncEndDatePassed(ctx, store, logger)
 	if err != nil {
 		wrappedErr := fmt.Errorf("%[1]w: %[2]w", errFetchingIntakes, err)
 		logger.Error(errFetchingIntakes.Error(), zap.Error(wrappedErr))
@@ -530,6 +542,11 @@ func sendAsyncReviewCompleteQuorumMetJobFunction(ctx context.Context, scheduledJ
 				GRBReviewers: reviewers,
 			}
 
+			// quorum is determined by the intake's quorum policy, the past due email is sent instead if it was not reached
+			if !votingInformation.QuorumReached() {
+				return nil
+			}
+
 			if err := emailClient.SystemIntake.SendGRBReviewCompleteQuorumMet(ctx, email.SendGRBReviewCompleteQuorumMetInput{
 				SystemIntakeID:     intake.ID,
 				ProjectTitle:       intake.ProjectName.String,
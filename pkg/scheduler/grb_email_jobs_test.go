@@ -83,3 +83,25 @@ func (suite *SchedulerTestSuite) TestSendGRBReviewEndedEmailJobFunction() {
 	err = sendGRBReviewEndedEmailJobFunction(suite.testConfigs.Context, stubJob)
 	suite.NoError(err)
 }
+
+func (suite *SchedulerTestSuite) TestSendAsyncReviewCompleteQuorumMetEmailJobFunction() {
+	testScheduler := suite.NewTestScheduler()
+
+	stubJob := suite.NewScheduledJobStub(testScheduler)
+
+	now := time.Now()
+	testIntake := &models.SystemIntake{
+		GRBReviewStartedAt:    helpers.PointerTo(now.AddDate(0, 0, -7)),
+		GrbReviewAsyncEndDate: helpers.PointerTo(now.AddDate(0, 0, -1)),
+		Step:                  models.SystemIntakeStepINITIALFORM,
+		RequestType:           models.SystemIntakeRequestTypeNEW,
+		GrbReviewType:         models.SystemIntakeGRBReviewTypeAsync,
+	}
+
+	createdIntake, err := storage.CreateSystemIntake(suite.testConfigs.Context, suite.testConfigs.Store, testIntake)
+	suite.NoError(err)
+	suite.NotNil(createdIntake)
+
+	err = sendAsyncReviewCompleteQuorumMetJobFunction(suite.testConfigs.Context, stubJob)
+	suite.NoError(err)
+}
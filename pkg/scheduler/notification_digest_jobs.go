@@ -0,0 +1,114 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/scheduler/timing"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+)
+
+type notificationDigestJobs struct {
+	// SendNotificationDigestJob is a job that sends each user who receives notifications in a daily digest the notifications held back for them
+	SendNotificationDigestJob ScheduledJob
+}
+
+// NotificationDigestJobs is the exported representation of all notification digest scheduled jobs
+// this line initializes notification digest jobs
+var NotificationDigestJobs = getNotificationDigestJobs(SharedScheduler)
+
+// getNotificationDigestJobs initializes all notification digest jobs
+func getNotificationDigestJobs(scheduler *Scheduler) *notificationDigestJobs {
+	return &notificationDigestJobs{
+		SendNotificationDigestJob: NewScheduledJob(
+			"SendNotificationDigestJob",
+			scheduler,
+			timing.DailyAt1PMUTC,
+			sendNotificationDigestJobFunction,
+		),
+	}
+}
+
+func sendNotificationDigestJobFunction(ctx context.Context, scheduledJob *ScheduledJob) error {
+	logger, err := scheduledJob.logger(ctx)
+	if err != nil {
+		return err
+	}
+
+	store, err := scheduledJob.store()
+	if err != nil {
+		wrappedErr := fmt.Errorf("%[1]w: %[2]w", errGettingStore, err)
+		logger.Error(errGettingStore.Error(), zap.Error(wrappedErr))
+		return wrappedErr
+	}
+
+	emailClient, err := scheduledJob.emailClient()
+	if err != nil {
+		wrappedErr := fmt.Errorf("%[1]w: %[2]w", errGettingEmailClient, err)
+		logger.Error(errGettingEmailClient.Error(), zap.Error(wrappedErr))
+		return wrappedErr
+	}
+
+	logger.Info(runningJob)
+
+	_, err = sendNotificationDigests(ctx, store, emailClient, logger)
+	return err
+}
+
+// sendNotificationDigests sends every user with notifications waiting for their digest a single email containing them, and marks them sent.
+// Digests are queued in the outbox in the same transaction the notifications are marked sent in, so no notification is lost or sent twice.
+// It returns the notifications that were sent
+func sendNotificationDigests(
+	ctx context.Context,
+	store *storage.Store,
+	emailClient *email.Client,
+	logger *zap.Logger,
+) ([]*models.NotificationDigestItem, error) {
+	return sqlutils.WithTransactionRet(ctx, store, func(tx *sqlx.Tx) ([]*models.NotificationDigestItem, error) {
+		items, err := store.GetPendingNotificationDigestItemsForUpdate(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return nil, nil
+		}
+
+		itemsByUserID := lo.GroupBy(items, func(item *models.NotificationDigestItem) uuid.UUID {
+			return item.UserID
+		})
+
+		accounts, err := store.UserAccountsByIDsNP(ctx, tx, lo.Keys(itemsByUserID))
+		if err != nil {
+			wrappedErr := fmt.Errorf("%[1]w: %[2]w", errProblemGettingAccounts, err)
+			logger.Error(errProblemGettingAccounts.Error(), zap.Error(wrappedErr))
+			return nil, wrappedErr
+		}
+
+		outboxCtx := email.WithOutboxTransaction(ctx, tx)
+		for _, account := range accounts {
+			userItems := itemsByUserID[account.ID]
+			if err := emailClient.SendNotificationDigestEmail(outboxCtx, models.EmailAddress(account.Email), userItems); err != nil {
+				wrappedErr := fmt.Errorf("%[1]w: %[2]w", errProblemSendingEmail, err)
+				logger.Error(errProblemSendingEmail.Error(), zap.Error(wrappedErr), zap.String("userID", account.ID.String()))
+				return nil, wrappedErr
+			}
+			logger.Info(emailSent, zap.String("userID", account.ID.String()), zap.Int("notifications", len(userItems)))
+		}
+
+		if err := store.MarkNotificationDigestItemsSent(ctx, tx, lo.Map(items, func(item *models.NotificationDigestItem, _ int) uuid.UUID {
+			return item.ID
+		})); err != nil {
+			return nil, err
+		}
+
+		return items, nil
+	})
+}
@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+func (suite *SchedulerTestSuite) TestSendNotificationDigests() {
+	ctx := suite.testConfigs.Context
+	store := suite.testConfigs.Store
+	account := suite.testConfigs.Principal.Account()
+
+	suite.NoError(store.SetNotificationPreference(ctx, store, account.ID, &models.NotificationPreference{
+		Category:  models.NotificationCategoryGRBDiscussions,
+		Frequency: models.NotificationFrequencyDailyDigest,
+	}))
+
+	sender := email.NewNotificationPreferencesSender(store, email.NewOutboxSender(store))
+	for _, subject := range []string{"first reply", "second reply"} {
+		suite.NoError(sender.Send(ctx, email.NewEmail().
+			WithNotificationCategory(models.NotificationCategoryGRBDiscussions).
+			WithToAddresses([]models.EmailAddress{models.EmailAddress(account.Email)}).
+			WithSubject(subject).
+			WithBody("<p>"+subject+"</p>"),
+		))
+	}
+
+	// the notifications were held back for the digest instead of being queued
+	queued, err := store.GetEmailOutboxMessages(ctx, helpers.PointerTo(models.EmailOutboxMessageStatusPending), 25, nil)
+	suite.NoError(err)
+	suite.Empty(queued)
+
+	suite.testConfigs.Sender.Clear()
+	sent, err := sendNotificationDigests(ctx, store, suite.testConfigs.EmailClient, suite.testConfigs.Logger)
+	suite.NoError(err)
+	suite.Len(sent, 2)
+
+	digests := suite.testConfigs.Sender.SentEmails()
+	suite.Len(digests, 1)
+	suite.Equal([]models.EmailAddress{models.EmailAddress(account.Email)}, digests[0].ToAddresses)
+	suite.Contains(digests[0].Body, "first reply")
+	suite.Contains(digests[0].Body, "second reply")
+
+	// notifications are only sent in one digest
+	sent, err = sendNotificationDigests(ctx, store, suite.testConfigs.EmailClient, suite.testConfigs.Logger)
+	suite.NoError(err)
+	suite.Empty(sent)
+}
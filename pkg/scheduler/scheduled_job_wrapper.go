@@ -62,6 +62,14 @@ func (sjw *ScheduledJobWrapper[input]) emailClient() (*email.Client, error) {
 	return sjw.scheduler.emailClient, nil
 }
 
+// emailSender returns the sender that delivers emails from the outbox from the scheduler
+func (sjw *ScheduledJobWrapper[input]) emailSender() (emailSender, error) {
+	if sjw.scheduler == nil || sjw.scheduler.emailSender == nil {
+		return nil, errors.New("scheduler is not initialized")
+	}
+	return sjw.scheduler.emailSender, nil
+}
+
 // userSearchClient returns the userSearchClient from the scheduler
 func (sjw *ScheduledJobWrapper[input]) userSearchClient() (usersearch.Client, error) {
 	if sjw.scheduler == nil || sjw.scheduler.userSearchClient == nil {
@@ -18,6 +18,11 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/usersearch"
 )
 
+// emailSender sends emails drained from the outbox. It matches the sender interface the email client sends with
+type emailSender interface {
+	Send(ctx context.Context, email email.Email) error
+}
+
 type Scheduler struct {
 	gocron.Scheduler
 	context          context.Context
@@ -26,6 +31,7 @@ type Scheduler struct {
 	mutex            sync.Mutex
 	logger           *zap.Logger
 	emailClient      *email.Client
+	emailSender      emailSender
 	userSearchClient usersearch.Client
 	buildDataLoaders dataloaders.BuildDataloaders
 	initialized      bool
@@ -52,13 +58,14 @@ func NewScheduler(panicOnError bool) (*Scheduler, error) {
 
 var SharedScheduler, _ = NewScheduler(true)
 
-// Initialize sets the logger, store, an email client, and a userSearchClient(Okta) for the shared scheduler
-func (s *Scheduler) Initialize(ctx context.Context, logger *zap.Logger, store *storage.Store, buildDataLoaders dataloaders.BuildDataloaders, emailClient *email.Client, userSearchClient usersearch.Client) {
+// Initialize sets the logger, store, an email client, the sender that delivers emails from the outbox, and a userSearchClient(Okta) for the shared scheduler
+func (s *Scheduler) Initialize(ctx context.Context, logger *zap.Logger, store *storage.Store, buildDataLoaders dataloaders.BuildDataloaders, emailClient *email.Client, emailSender emailSender, userSearchClient usersearch.Client) {
 	l := logger.With(logfields.SchedulerAppSection)
 	s.logger = l
 	s.context = appcontext.WithLogger(ctx, l)
 	s.store = store
 	s.emailClient = emailClient
+	s.emailSender = emailSender
 	s.userSearchClient = userSearchClient
 	s.buildDataLoaders = buildDataLoaders
 	s.initialized = true
@@ -20,7 +20,7 @@ import (
 func (suite *SchedulerTestSuite) NewTestScheduler() *Scheduler {
 	testScheduler, err := NewScheduler(false)
 	suite.NoError(err)
-	testScheduler.Initialize(suite.testConfigs.Context, suite.testConfigs.Logger, suite.testConfigs.Store, suite.buildDataLoaders(), suite.testConfigs.EmailClient, local.NewOktaAPIClient())
+	testScheduler.Initialize(suite.testConfigs.Context, suite.testConfigs.Logger, suite.testConfigs.Store, suite.buildDataLoaders(), suite.testConfigs.EmailClient, suite.testConfigs.Sender, local.NewOktaAPIClient())
 	return testScheduler
 }
 
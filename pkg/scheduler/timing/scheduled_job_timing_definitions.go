@@ -8,6 +8,9 @@ var (
 	// Every5Seconds is a cron expression that will run every 5 seconds, useful for testing
 	Every5Seconds = gocron.CronJob("*/5 * * * * *", true)
 
+	// Every30Seconds is a cron expression that will run every 30 seconds
+	Every30Seconds = gocron.CronJob("*/30 * * * * *", true)
+
 	// DailyAt2AM is a cron expression that runs every day at 2 AM
 	DailyAt2AM = gocron.CronJob("0 2 * * *", false)
 
@@ -0,0 +1,157 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/scheduler/timing"
+	"github.com/cms-enterprise/easi-app/pkg/storage"
+	"github.com/cms-enterprise/easi-app/pkg/webhooks"
+)
+
+const (
+	// webhookDeliveryBatchSize is the most events the dispatcher sends each time it runs. Subscribers can take up to
+	// the webhook client's timeout to respond, so this is smaller than the email outbox's batch
+	webhookDeliveryBatchSize = 20
+
+	// webhookDeliveryWorkers is how many deliveries the dispatcher sends at once
+	webhookDeliveryWorkers = 4
+
+	// webhookDeliveryClaimLease is how long a delivery is claimed for while it's being sent. It's much longer than the webhook client's
+	// timeout, so a delivery is only claimed again if the dispatcher sending it stopped before recording the result
+	webhookDeliveryClaimLease = 5 * time.Minute
+)
+
+type webhookJobs struct {
+	// DeliverWebhooksJob is a job that sends queued governance lifecycle events to webhook subscribers, retrying ones that fail
+	DeliverWebhooksJob ScheduledJob
+}
+
+// WebhookJobs is the exported representation of all webhook scheduled jobs
+// this line initializes webhook jobs
+var WebhookJobs = getWebhookJobs(SharedScheduler)
+
+// getWebhookJobs initializes all webhook jobs
+func getWebhookJobs(scheduler *Scheduler) *webhookJobs {
+	return &webhookJobs{
+		DeliverWebhooksJob: NewScheduledJob(
+			"DeliverWebhooksJob",
+			scheduler,
+			timing.Every30Seconds,
+			deliverWebhooksJobFunction,
+		),
+	}
+}
+
+func deliverWebhooksJobFunction(ctx context.Context, scheduledJob *ScheduledJob) error {
+	logger, err := scheduledJob.logger(ctx)
+	if err != nil {
+		return err
+	}
+
+	store, err := scheduledJob.store()
+	if err != nil {
+		wrappedErr := fmt.Errorf("%[1]w: %[2]w", errGettingStore, err)
+		logger.Error(errGettingStore.Error(), zap.Error(wrappedErr))
+		return wrappedErr
+	}
+
+	_, err = deliverWebhooks(ctx, store, webhooks.NewClient(), logger, webhookDeliveryBatchSize)
+	return err
+}
+
+// deliverWebhooks sends up to limit of the queued webhook deliveries whose next attempt is due, and records the result of each attempt.
+// Each delivery is claimed before it's sent, so running this concurrently doesn't send any event to a subscriber twice. Deliveries are
+// sent by several workers, outside of any transaction, and each result is recorded on its own, so a problem recording one doesn't cause
+// the others to be sent again, and a slow subscriber doesn't hold up the rest.
+// It returns the deliveries that were attempted
+func deliverWebhooks(
+	ctx context.Context,
+	store *storage.Store,
+	client *webhooks.Client,
+	logger *zap.Logger,
+	limit int,
+) ([]*models.WebhookDelivery, error) {
+	var mutex sync.Mutex
+	var attempted []*models.WebhookDelivery
+	var errs []error
+
+	// claimNext claims the next delivery for a worker, or returns nil once limit deliveries have been claimed or none are due
+	claimNext := func() *models.WebhookDelivery {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if len(attempted) >= limit {
+			return nil
+		}
+		delivery, err := store.ClaimDueWebhookDelivery(ctx, webhookDeliveryClaimLease)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		if delivery != nil {
+			attempted = append(attempted, delivery)
+		}
+		return delivery
+	}
+
+	var wg sync.WaitGroup
+	for range webhookDeliveryWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := claimNext(); delivery != nil; delivery = claimNext() {
+				if err := deliverWebhook(ctx, store, client, logger, delivery); err != nil {
+					mutex.Lock()
+					errs = append(errs, err)
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return attempted, errors.Join(errs...)
+}
+
+// deliverWebhook sends a claimed delivery to its subscriber, and records the result of the attempt
+func deliverWebhook(
+	ctx context.Context,
+	store *storage.Store,
+	client *webhooks.Client,
+	logger *zap.Logger,
+	delivery *models.WebhookDelivery,
+) error {
+	deliveryLogger := logger.With(
+		zap.String("webhookDeliveryID", delivery.ID.String()),
+		zap.String("webhookSubscriptionID", delivery.SubscriptionID.String()),
+		zap.String("eventType", string(delivery.EventType)),
+		zap.Int("attempt", delivery.Attempts+1),
+	)
+
+	responseStatus, deliveryErr := client.Deliver(ctx, delivery, time.Now())
+	if deliveryErr != nil {
+		delivery.RecordFailure(time.Now(), responseStatus, deliveryErr)
+		if delivery.Status == models.WebhookDeliveryStatusDeadLetter {
+			deliveryLogger.Error("webhook event could not be delivered, moving it to the dead letter state", zap.Error(deliveryErr))
+		} else {
+			deliveryLogger.Warn("webhook event could not be delivered, it will be retried", zap.Error(deliveryErr), zap.Time("nextAttemptAt", delivery.NextAttemptAt))
+		}
+	} else {
+		delivery.RecordDelivered(time.Now(), *responseStatus)
+		deliveryLogger.Info("webhook event delivered")
+	}
+
+	// if the result can't be recorded, the delivery stays claimed until its lease passes, then it's attempted again
+	if err := store.UpdateWebhookDelivery(ctx, store, delivery); err != nil {
+		deliveryLogger.Error("problem recording the attempt to deliver a webhook event", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/webhooks"
+)
+
+func (suite *SchedulerTestSuite) TestDeliverWebhooks() {
+	ctx := suite.testConfigs.Context
+	store := suite.testConfigs.Store
+	createdBy := suite.testConfigs.Principal.Account().ID
+
+	var receivedSignatures []string
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedSignatures = append(receivedSignatures, r.Header.Get(webhooks.SignatureHeader))
+	}))
+	defer subscriber.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	newSubscription := func(name string, url string, eventTypes ...models.WebhookEventType) *models.WebhookSubscription {
+		subscription := models.NewWebhookSubscription(createdBy)
+		subscription.Name = name
+		subscription.URL = url
+		subscription.Secret = "secret"
+		subscription.EventTypes = eventTypes
+		created, err := store.CreateWebhookSubscription(ctx, subscription)
+		suite.NoError(err)
+		return created
+	}
+	subscribed := newSubscription("subscribed", subscriber.URL, models.WebhookEventTypeLcidIssued)
+	failingSubscription := newSubscription("failing", failing.URL, models.WebhookEventTypeLcidIssued)
+	// not subscribed to the event, so it isn't sent it
+	newSubscription("other events", subscriber.URL, models.WebhookEventTypeLcidRetired)
+
+	event, err := webhooks.Emit(ctx, store, store, models.WebhookEventTypeLcidIssued, webhooks.LCIDEventData{SystemIntakeID: uuid.New()})
+	suite.NoError(err)
+
+	attempted, err := deliverWebhooks(ctx, store, webhooks.NewClient(), suite.testConfigs.Logger, webhookDeliveryBatchSize)
+	suite.NoError(err)
+	suite.Len(attempted, 2)
+	suite.Len(receivedSignatures, 1)
+
+	delivered, err := store.GetWebhookDeliveries(ctx, models.ITGovWebhookEventTypes, &subscribed.ID, helpers.PointerTo(models.WebhookDeliveryStatusDelivered), 25, nil)
+	suite.NoError(err)
+	suite.Len(delivered, 1)
+	suite.Equal(event.ID, delivered[0].EventID)
+	suite.Equal(1, delivered[0].Attempts)
+	suite.Equal(http.StatusOK, *delivered[0].LastResponseStatus)
+	suite.NotNil(delivered[0].DeliveredAt)
+
+	pending, err := store.GetWebhookDeliveries(ctx, models.ITGovWebhookEventTypes, &failingSubscription.ID, helpers.PointerTo(models.WebhookDeliveryStatusPending), 25, nil)
+	suite.NoError(err)
+	suite.Len(pending, 1)
+	suite.Equal(1, pending[0].Attempts)
+	suite.Equal(http.StatusInternalServerError, *pending[0].LastResponseStatus)
+	suite.Equal("subscriber responded with 500 Internal Server Error", *pending[0].LastError)
+	suite.True(pending[0].NextAttemptAt.After(pending[0].CreatedAt))
+
+	// the failed delivery isn't retried until its backoff has passed, and the delivered one isn't sent again
+	attempted, err = deliverWebhooks(ctx, store, webhooks.NewClient(), suite.testConfigs.Logger, webhookDeliveryBatchSize)
+	suite.NoError(err)
+	suite.Empty(attempted)
+
+	// redelivering the failed delivery sends it right away
+	_, err = store.RedeliverWebhookDelivery(ctx, pending[0].ID, &createdBy)
+	suite.NoError(err)
+	attempted, err = deliverWebhooks(ctx, store, webhooks.NewClient(), suite.testConfigs.Logger, webhookDeliveryBatchSize)
+	suite.NoError(err)
+	suite.Len(attempted, 1)
+	suite.Equal(1, attempted[0].Attempts)
+
+	suite.Run("a slow subscriber doesn't hold up deliveries to the others", func() {
+		release := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer slow.Close()
+		// deferred calls run last first, so the slow subscriber is released before its server is closed
+		defer close(release)
+
+		fastReceived := make(chan struct{}, 2)
+		fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fastReceived <- struct{}{}
+		}))
+		defer fast.Close()
+
+		newSubscription("slow", slow.URL, models.WebhookEventTypeLcidExpired)
+		newSubscription("fast", fast.URL, models.WebhookEventTypeLcidExpired)
+		for range 2 {
+			_, err := webhooks.Emit(ctx, store, store, models.WebhookEventTypeLcidExpired, webhooks.LCIDEventData{SystemIntakeID: uuid.New()})
+			suite.NoError(err)
+		}
+
+		done := make(chan []*models.WebhookDelivery)
+		go func() {
+			attempted, err := deliverWebhooks(ctx, store, webhooks.NewClient(), suite.testConfigs.Logger, webhookDeliveryBatchSize)
+			suite.NoError(err)
+			done <- attempted
+		}()
+
+		for range 2 {
+			select {
+			case <-fastReceived:
+			case <-time.After(5 * time.Second):
+				suite.Fail("deliveries to the fast subscriber were held up by the slow one")
+			}
+		}
+
+		release <- struct{}{}
+		release <- struct{}{}
+		select {
+		case attempted := <-done:
+			suite.Len(attempted, 4)
+		case <-time.After(30 * time.Second):
+			suite.Fail("deliveries to the slow subscriber weren't finished")
+		}
+	})
+}
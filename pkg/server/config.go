@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"time"
@@ -9,9 +10,13 @@ import (
 
 	"github.com/cms-enterprise/easi-app/pkg/appconfig"
 	"github.com/cms-enterprise/easi-app/pkg/appses"
+	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
 	"github.com/cms-enterprise/easi-app/pkg/email"
 	"github.com/cms-enterprise/easi-app/pkg/flags"
+	"github.com/cms-enterprise/easi-app/pkg/helpers"
+	"github.com/cms-enterprise/easi-app/pkg/logfields"
 	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 	"github.com/cms-enterprise/easi-app/pkg/storage"
 	"github.com/cms-enterprise/easi-app/pkg/upload"
 )
@@ -73,6 +78,41 @@ func (s Server) NewEmailConfig() email.Config {
 		URLHost:                     s.Config.GetString(appconfig.ClientHostKey),
 		URLScheme:                   s.Config.GetString(appconfig.ClientProtocolKey),
 		TemplateDirectory:           s.Config.GetString(appconfig.EmailTemplateDirectoryKey),
+		ReplyToAddress:              models.NewEmailAddress(s.Config.GetString(appconfig.EmailReplyAddressKey)),
+		ReplyTokenSecret:            s.Config.GetString(appconfig.EmailReplyTokenSecretKey),
+	}
+}
+
+// NewCEDARCoreTransportConfig returns a new cedarcore.TransportConfig. Anything that isn't set uses the client's default.
+func (s Server) NewCEDARCoreTransportConfig() cedarcore.TransportConfig {
+	// retries can be turned off with 0, so an unset max is told apart from a 0 one
+	var maxRetries *int
+	if s.Config.IsSet(appconfig.CEDARCoreMaxRetries) {
+		maxRetries = helpers.PointerTo(s.Config.GetInt(appconfig.CEDARCoreMaxRetries))
+	}
+
+	return cedarcore.TransportConfig{
+		AttemptTimeout:   time.Duration(s.Config.GetInt(appconfig.CEDARCoreTimeoutSeconds)) * time.Second,
+		MaxRetries:       maxRetries,
+		BreakerThreshold: s.Config.GetInt(appconfig.CEDARCoreCircuitBreakerThreshold),
+		BreakerCooldown:  time.Duration(s.Config.GetInt(appconfig.CEDARCoreCircuitBreakerCooldownSeconds)) * time.Second,
+	}
+}
+
+// NewCEDARCoreCacheConfig returns a new cedarcore.CacheConfig. Any TTL that isn't set uses the client's default.
+func (s Server) NewCEDARCoreCacheConfig() cedarcore.CacheConfig {
+	seconds := func(key string) time.Duration {
+		return time.Duration(s.Config.GetInt(key)) * time.Second
+	}
+
+	return cedarcore.CacheConfig{
+		Disabled:         s.Config.GetBool(appconfig.CEDARCoreCacheDisabled),
+		SystemSummaryTTL: seconds(appconfig.CEDARCoreSystemSummaryCacheTTLSeconds),
+		SystemDetailTTL:  seconds(appconfig.CEDARCoreSystemDetailCacheTTLSeconds),
+		RoleTTL:          seconds(appconfig.CEDARCoreRoleCacheTTLSeconds),
+		RoleTypeTTL:      seconds(appconfig.CEDARCoreRoleTypeCacheTTLSeconds),
+		TTL:              seconds(appconfig.CEDARCoreCacheTTLSeconds),
+		StaleFor:         seconds(appconfig.CEDARCoreCacheStaleSeconds),
 	}
 }
 
@@ -91,6 +131,7 @@ func (s Server) NewSESConfig() appses.Config {
 		SourceARN:               s.Config.GetString(appconfig.AWSSESSourceARNKey),
 		Source:                  s.Config.GetString(appconfig.AWSSESSourceKey),
 		RecipientAllowListRegex: sesRegex,
+		ConfigurationSetName:    s.Config.GetString(appconfig.AWSSESConfigurationSetKey),
 	}
 }
 
@@ -105,6 +146,17 @@ func (s Server) NewS3Config() upload.Config {
 	}
 }
 
+// NewInboundEmailS3Config returns the s3 config for the bucket SES stores received emails in, and checks required fields
+func (s Server) NewInboundEmailS3Config() upload.Config {
+	s.checkRequiredConfig(appconfig.AWSS3InboundEmailBucketKey)
+	s.checkRequiredConfig(appconfig.AWSRegion)
+
+	return upload.Config{
+		Bucket: s.Config.GetString(appconfig.AWSS3InboundEmailBucketKey),
+		Region: s.Config.GetString(appconfig.AWSRegion),
+	}
+}
+
 // NewCEDARClientCheck checks if CEDAR clients are not connectable
 func (s Server) NewCEDARClientCheck() {
 	s.checkRequiredConfig(appconfig.CEDARAPIURL)
@@ -180,3 +232,27 @@ func (s Server) NewFlagConfig() flags.Config {
 		FlagValuesFile: flagValuesFile,
 	}
 }
+
+// NewPubSub returns the PubSub service selected by config, defaulting to the in-process implementation when none is set
+func (s Server) NewPubSub(store *storage.Store, dbConfig storage.DBConfig) pubsub.PubSub {
+	backend := appconfig.PubSubBackendOption(s.Config.GetString(appconfig.PubSubBackendKey))
+
+	switch backend {
+	case appconfig.PubSubBackendPostgres:
+		ps, err := pubsub.NewPostgresPubSub(
+			context.Background(),
+			store,
+			dbConfig.DataSourceName,
+			s.logger.With(logfields.PubSubAppSection),
+		)
+		if err != nil {
+			s.logger.Fatal("Failed to create Postgres pubsub service", zap.Error(err))
+		}
+		return ps
+	case appconfig.PubSubBackendLocal, "":
+		return pubsub.NewServicePubSub()
+	default:
+		s.logger.Fatal(fmt.Sprintf("Unknown pubsub backend: %v", backend))
+		return nil
+	}
+}
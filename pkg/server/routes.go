@@ -15,6 +15,7 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 	_ "github.com/lib/pq" // pq is required to get the postgres driver into sqlx
@@ -27,10 +28,10 @@ import (
 	"github.com/cms-enterprise/easi-app/pkg/apperrors"
 	"github.com/cms-enterprise/easi-app/pkg/appses"
 	"github.com/cms-enterprise/easi-app/pkg/appvalidation"
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
 	"github.com/cms-enterprise/easi-app/pkg/authorization"
 	"github.com/cms-enterprise/easi-app/pkg/dataloaders"
 	"github.com/cms-enterprise/easi-app/pkg/oktaapi"
-	"github.com/cms-enterprise/easi-app/pkg/pubsub"
 	"github.com/cms-enterprise/easi-app/pkg/scheduler"
 	"github.com/cms-enterprise/easi-app/pkg/userhelpers"
 	"github.com/cms-enterprise/easi-app/pkg/usersearch"
@@ -38,10 +39,12 @@ import (
 	cedarcore "github.com/cms-enterprise/easi-app/pkg/cedar/core"
 	cedarintake "github.com/cms-enterprise/easi-app/pkg/cedar/intake"
 	"github.com/cms-enterprise/easi-app/pkg/email"
+	"github.com/cms-enterprise/easi-app/pkg/export"
 	"github.com/cms-enterprise/easi-app/pkg/flags"
 	"github.com/cms-enterprise/easi-app/pkg/graph/generated"
 	"github.com/cms-enterprise/easi-app/pkg/graph/resolvers"
 	"github.com/cms-enterprise/easi-app/pkg/handlers"
+	"github.com/cms-enterprise/easi-app/pkg/inboundemail"
 	"github.com/cms-enterprise/easi-app/pkg/local"
 	"github.com/cms-enterprise/easi-app/pkg/models"
 	"github.com/cms-enterprise/easi-app/pkg/okta"
@@ -63,8 +66,9 @@ func (s *Server) routes() {
 	if err != nil {
 		s.logger.Fatal("Failed to create LaunchDarkly client", zap.Error(err))
 	}
+	dbConfig := s.NewDBConfig()
 	store, storeErr := storage.NewStore(
-		s.NewDBConfig(),
+		dbConfig,
 		ldClient,
 	)
 	if storeErr != nil {
@@ -94,9 +98,16 @@ func (s *Server) routes() {
 	base := handlers.NewHandlerBase()
 
 	// endpoints that dont require authorization go directly on the main router
-	s.router.HandleFunc("/api/v1/healthcheck", handlers.NewHealthCheckHandler(base, s.Config).Handle())
 	s.router.HandleFunc("/api/graph/playground", playground.Handler("GraphQL playground", "/api/graph/query"))
 
+	// SES delivery notifications are posted by SNS, and are authenticated by their signatures instead of a user's session
+	if topicARN := s.Config.GetString(appconfig.AWSSESNotificationTopicARNKey); topicARN != "" {
+		s.router.HandleFunc(
+			"/api/v1/email/notifications",
+			handlers.NewSESNotificationHandler(base, appses.NewSNSVerifier(topicARN), store.RecordEmailDeliveryEvents).Handle(),
+		)
+	}
+
 	// set up CEDAR intake client
 	publisher := cedarintake.NewClient(
 		s.Config.GetString(appconfig.CEDARAPIURL),
@@ -133,29 +144,54 @@ func (s *Server) routes() {
 		s.Config.GetString(appconfig.CEDARAPIKey),
 		s.Config.GetString(appconfig.CEDARCoreAPIVersion),
 		s.Config.GetBool(appconfig.CEDARCoreMock),
+		s.NewCEDARCoreTransportConfig(),
+		s.NewCEDARCoreCacheConfig(),
 	)
 
+	// the healthcheck doesn't require authorization either, but reports whether CEDAR core is degraded, so it's set up once the client is
+	s.router.HandleFunc("/api/v1/healthcheck", handlers.NewHealthCheckHandler(base, s.Config, map[string]func() error{
+		"cedarCore": coreClient.CheckHealth,
+	}).Handle())
+
 	// set up Email Client
 	emailConfig := s.NewEmailConfig()
 
-	var emailClient email.Client
+	// emailSender delivers emails. The app's email client doesn't use it directly, it queues emails in the outbox,
+	// which the scheduler drains through emailSender
+	var emailSender interface {
+		Send(ctx context.Context, email email.Email) error
+	}
 	switch {
 	case s.environment.Deployed():
 		sesConfig := s.NewSESConfig()
 		sesSender := appses.NewSender(context.Background(), sesConfig, s.environment)
-		emailClient, err = email.NewClient(emailConfig, sesSender)
-		if err != nil {
-			s.logger.Fatal("Failed to create email client", zap.Error(err))
+		// the startup check sends directly, so it tests the SES configuration
+		sesEmailClient, sesErr := email.NewClient(emailConfig, sesSender)
+		if sesErr != nil {
+			s.logger.Fatal("Failed to create email client", zap.Error(sesErr))
 		}
-		s.CheckEmailClient(emailClient)
+		s.CheckEmailClient(sesEmailClient)
+		emailSender = sesSender
 
 	default:
 		// default to test/local
-		smtpSender := local.NewSMTPSender("email:1025", s.environment)
-		emailClient, err = email.NewClient(emailConfig, smtpSender)
-		if err != nil {
-			s.logger.Fatal("Failed to create email client", zap.Error(err))
-		}
+		emailSender = local.NewSMTPSender("email:1025", s.environment)
+	}
+
+	// set up PubSub service for real-time subscriptions
+	pubsubService := s.NewPubSub(store, dbConfig)
+
+	// each instance caches CEDAR Core responses, so evictions are sent to every instance
+	coreClient.BroadcastCacheEvictions(appcontext.WithLogger(context.Background(), s.logger), pubsubService)
+
+	// every email is added to recipients' in-app notifications, then recipients' notification preferences are applied before
+	// it's queued, so held back notifications are left out of the outbox
+	emailClient, err := email.NewClient(
+		emailConfig,
+		email.NewNotificationCenterSender(store, pubsubService, email.NewNotificationPreferencesSender(store, email.NewOutboxSender(store))),
+	)
+	if err != nil {
+		s.logger.Fatal("Failed to create email client", zap.Error(err))
 	}
 
 	// set up S3 client
@@ -166,9 +202,6 @@ func (s *Server) routes() {
 
 	serviceConfig := services.NewConfig(s.logger, ldClient)
 
-	// set up PubSub service for real-time subscriptions
-	pubsubService := pubsub.NewServicePubSub()
-
 	// set up GraphQL routes
 	gql := s.router.PathPrefix("/api/graph").Subrouter()
 
@@ -203,6 +236,7 @@ func (s *Server) routes() {
 		ldClient,
 		coreClient,
 		pubsubService,
+		s.environment.Local() || s.environment.Test(),
 	)
 	gqlDirectives := generated.DirectiveRoot{HasRole: func(ctx context.Context, obj interface{}, next graphql.Resolver, role models.Role) (res interface{}, err error) {
 		if !services.HasRole(ctx, role) {
@@ -273,6 +307,34 @@ func (s *Server) routes() {
 	api.Handle("/business_case/{business_case_id}", businessCaseHandler.Handle())
 	api.Handle("/business_case", businessCaseHandler.Handle())
 
+	businessCasePDFHandler := handlers.NewBusinessCasePDFHandler(
+		base,
+		services.NewFetchBusinessCaseByID(
+			serviceConfig,
+			store.FetchBusinessCaseByID,
+			services.AuthorizeHasEASiRole,
+		),
+		emailClient.BusinessCasePDF,
+	)
+	api.Handle("/business_case/{business_case_id}/pdf", businessCasePDFHandler.Handle())
+
+	exportHandler := handlers.NewExportHandler(
+		base,
+		func(ctx context.Context, filter models.SystemIntakesFilter, sort models.SystemIntakesSort, w export.Writer) error {
+			return resolvers.ExportSystemIntakes(ctx, store, filter, sort, w)
+		},
+		func(ctx context.Context, filter models.SystemIntakesFilter, sort models.SystemIntakesSort, w export.Writer) error {
+			return resolvers.ExportBusinessCases(ctx, store, filter, sort, w)
+		},
+		func(ctx context.Context, filter models.SystemIntakesFilter, sort models.SystemIntakesSort, w export.Writer) error {
+			return resolvers.ExportLifecycleCosts(ctx, store, filter, sort, w)
+		},
+		func(ctx context.Context, filter models.TRBRequestsFilter, sort models.TRBRequestsSort, w export.Writer) error {
+			return resolvers.ExportTRBRequests(ctx, store, filter, sort, w)
+		},
+	)
+	api.Handle("/exports/{export_name}", exportHandler.Handle())
+
 	actionHandler := handlers.NewActionHandler(
 		base,
 		services.NewTakeAction(
@@ -374,8 +436,43 @@ func (s *Server) routes() {
 		store.UpdateSystemIntake,
 		emailClient.SendLCIDExpirationAlertEmail,
 		time.Hour*24)
+
+	// Post replies to GRB discussion emails to their discussions. SES stores the emails it receives at the reply address in S3;
+	// locally, emails dropped into a directory stand in for them
+	if emailClient.DiscussionRepliesEnabled() {
+		var inboundMailbox inboundemail.Mailbox
+		switch {
+		case s.environment.Deployed():
+			inboundS3Client := upload.NewS3Client(context.Background(), s.NewInboundEmailS3Config())
+			inboundMailbox = inboundemail.NewS3Mailbox(&inboundS3Client, s.Config.GetString(appconfig.AWSS3InboundEmailPrefixKey))
+		case s.Config.GetString(appconfig.LocalInboundEmailDirectoryKey) != "":
+			inboundMailbox = local.NewDirectoryMailbox(s.Config.GetString(appconfig.LocalInboundEmailDirectoryKey))
+		}
+
+		if inboundMailbox != nil {
+			inboundemail.StartGRBDiscussionReplyCheck(
+				appcontext.WithLogger(context.Background(), s.logger),
+				inboundemail.NewGRBDiscussionReplyProcessor(
+					inboundMailbox,
+					&emailClient,
+					buildDataloaders,
+					func(ctx context.Context, emailAddress string) ([]*authentication.UserAccount, error) {
+						return store.UserAccountsGetByEmail(ctx, store, emailAddress)
+					},
+					func(ctx context.Context, id uuid.UUID) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
+						return store.GetSystemIntakeGRBDiscussionPostByID(ctx, store, id)
+					},
+					func(ctx context.Context, input models.CreateSystemIntakeGRBDiscussionReplyInput) (*models.SystemIntakeGRBReviewDiscussionPost, error) {
+						return resolvers.CreateSystemIntakeGRBDiscussionReply(ctx, store, &emailClient, pubsubService, input)
+					},
+				),
+				time.Minute,
+			)
+		}
+	}
+
 	// start the scheduler
-	scheduler.SharedScheduler.Initialize(context.Background(), s.logger, store, buildDataloaders, &emailClient, userSearchClient)
+	scheduler.SharedScheduler.Initialize(context.Background(), s.logger, store, buildDataloaders, &emailClient, emailSender, userSearchClient)
 	scheduler.SharedScheduler.Start()
 
 	// note, we defer shutdown the scheduler in server.Serve
@@ -204,6 +204,7 @@ func NewSubmitBusinessCase(
 		requestName string,
 		isResubmitted bool,
 		isDraft bool,
+		businessCase *models.BusinessCaseWithCosts,
 	) error,
 	submitToCEDAR func(ctx context.Context, bc models.BusinessCaseWithCosts) error,
 ) ActionExecuter {
@@ -297,6 +298,7 @@ func NewSubmitBusinessCase(
 			intake.ProjectName.String,
 			isResubmitted,
 			isDraft,
+			businessCase,
 		)
 		if err != nil {
 			appcontext.ZLogger(ctx).Error("Submit Business Case email failed to send: ", zap.Error(err))
@@ -288,6 +288,7 @@ func (s *ServicesTestSuite) TestNewSubmitBizCase() {
 			requestName string,
 			isResubmitted bool,
 			isDraft bool,
+			businessCase *models.BusinessCaseWithCosts,
 		) error {
 			sendReviewerEmailCount++
 			return nil
@@ -339,6 +340,7 @@ func (s *ServicesTestSuite) TestNewSubmitBizCase() {
 			requestName string,
 			isResubmitted bool,
 			isDraft bool,
+			businessCase *models.BusinessCaseWithCosts,
 		) error {
 			return nil
 		}
@@ -387,6 +389,7 @@ func (s *ServicesTestSuite) TestNewSubmitBizCase() {
 			requestName string,
 			isResubmitted bool,
 			isDraft bool,
+			businessCase *models.BusinessCaseWithCosts,
 		) error {
 			return nil
 		}
@@ -435,6 +438,7 @@ func (s *ServicesTestSuite) TestNewSubmitBizCase() {
 			requestName string,
 			isResubmitted bool,
 			isDraft bool,
+			businessCase *models.BusinessCaseWithCosts,
 		) error {
 			return nil
 		}
@@ -486,6 +490,7 @@ func (s *ServicesTestSuite) TestNewSubmitBizCase() {
 			requestName string,
 			isResubmitted bool,
 			isDraft bool,
+			businessCase *models.BusinessCaseWithCosts,
 		) error {
 			sendReviewerEmailCount++
 			return nil
@@ -544,6 +549,7 @@ func (s *ServicesTestSuite) TestNewSubmitBizCase() {
 			requestName string,
 			isResubmitted bool,
 			isDraft bool,
+			businessCase *models.BusinessCaseWithCosts,
 		) error {
 			sendReviewerEmailCount++
 			return nil
@@ -602,6 +608,7 @@ func (s *ServicesTestSuite) TestNewSubmitBizCase() {
 			requestName string,
 			isResubmitted bool,
 			isDraft bool,
+			businessCase *models.BusinessCaseWithCosts,
 		) error {
 			return nil
 		}
@@ -650,6 +657,7 @@ func (s *ServicesTestSuite) TestNewSubmitBizCase() {
 			requestName string,
 			isResubmitted bool,
 			isDraft bool,
+			businessCase *models.BusinessCaseWithCosts,
 		) error {
 			return nil
 		}
@@ -698,6 +706,7 @@ func (s *ServicesTestSuite) TestNewSubmitBizCase() {
 			requestName string,
 			isResubmitted bool,
 			isDraft bool,
+			businessCase *models.BusinessCaseWithCosts,
 		) error {
 			return nil
 		}
@@ -751,6 +760,7 @@ func (s *ServicesTestSuite) TestNewSubmitBizCase() {
 			requestName string,
 			isResubmitted bool,
 			isDraft bool,
+			businessCase *models.BusinessCaseWithCosts,
 		) error {
 			return nil
 		}
@@ -805,6 +815,7 @@ func (s *ServicesTestSuite) TestNewSubmitBizCase() {
 			requestName string,
 			isResubmitted bool,
 			isDraft bool,
+			businessCase *models.BusinessCaseWithCosts,
 		) error {
 			sendReviewerEmailCount++
 			s.False(isDraft)
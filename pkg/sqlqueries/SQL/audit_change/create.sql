@@ -0,0 +1,20 @@
+INSERT INTO audit_changes (
+    id,
+    entity_type,
+    entity_id,
+    parent_id,
+    external_id,
+    action,
+    fields,
+    modified_by
+)
+VALUES (
+    :id,
+    :entity_type,
+    :entity_id,
+    :parent_id,
+    :external_id,
+    :action,
+    :fields,
+    :modified_by
+);
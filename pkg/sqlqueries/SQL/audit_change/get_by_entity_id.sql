@@ -0,0 +1,20 @@
+SELECT
+    id,
+    entity_type,
+    entity_id,
+    parent_id,
+    external_id,
+    action,
+    fields,
+    modified_by,
+    modified_at
+FROM audit_changes
+WHERE
+    (entity_id = :entity_id OR parent_id = :entity_id)
+    AND entity_type = ANY(:entity_types)
+    AND (
+        CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE) IS NULL
+        OR (modified_at, id) < (CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE), CAST(:after_id AS UUID))
+    )
+ORDER BY modified_at DESC, id DESC
+LIMIT :limit;
@@ -0,0 +1,7 @@
+INSERT INTO calendar_invites (uid)
+VALUES (:uid)
+ON CONFLICT (uid) DO UPDATE
+SET
+    sequence = calendar_invites.sequence + 1,
+    modified_at = CURRENT_TIMESTAMP
+RETURNING sequence;
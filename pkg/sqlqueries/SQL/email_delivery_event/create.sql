@@ -0,0 +1,37 @@
+INSERT INTO email_delivery_events (
+    id,
+    notification_id,
+    provider_message_id,
+    email_outbox_id,
+    event_type,
+    recipient,
+    bounce_type,
+    bounce_sub_type,
+    diagnostic,
+    occurred_at
+)
+VALUES (
+    :id,
+    :notification_id,
+    :provider_message_id,
+    (SELECT email_outbox.id FROM email_outbox WHERE email_outbox.provider_message_id = :provider_message_id),
+    :event_type,
+    :recipient,
+    :bounce_type,
+    :bounce_sub_type,
+    :diagnostic,
+    :occurred_at
+)
+ON CONFLICT (notification_id, recipient) DO NOTHING
+RETURNING
+    id,
+    notification_id,
+    provider_message_id,
+    email_outbox_id,
+    event_type,
+    recipient,
+    bounce_type,
+    bounce_sub_type,
+    diagnostic,
+    occurred_at,
+    created_at;
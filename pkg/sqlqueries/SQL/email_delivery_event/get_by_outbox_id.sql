@@ -0,0 +1,15 @@
+SELECT
+    id,
+    notification_id,
+    provider_message_id,
+    email_outbox_id,
+    event_type,
+    recipient,
+    bounce_type,
+    bounce_sub_type,
+    diagnostic,
+    occurred_at,
+    created_at
+FROM email_delivery_events
+WHERE email_outbox_id = :email_outbox_id
+ORDER BY occurred_at DESC, recipient;
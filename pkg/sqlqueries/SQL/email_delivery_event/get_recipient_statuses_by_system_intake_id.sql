@@ -0,0 +1,27 @@
+WITH recipients AS (
+    SELECT user_id FROM system_intake_contacts WHERE system_intake_id = :system_intake_id
+    UNION
+    SELECT user_id FROM system_intake_grb_reviewers WHERE system_intake_id = :system_intake_id
+)
+
+SELECT
+    user_account.id AS user_account_id,
+    user_account.email,
+    user_account.email_bounce_count,
+    user_account.email_undeliverable_at,
+    last_event.event_type AS last_event_type,
+    last_event.occurred_at AS last_event_at,
+    last_event.diagnostic AS last_diagnostic
+FROM recipients
+INNER JOIN user_account ON user_account.id = recipients.user_id
+LEFT JOIN LATERAL (
+    SELECT
+        email_delivery_events.event_type,
+        email_delivery_events.occurred_at,
+        email_delivery_events.diagnostic
+    FROM email_delivery_events
+    WHERE LOWER(email_delivery_events.recipient) = LOWER(TRIM(user_account.email))
+    ORDER BY email_delivery_events.occurred_at DESC
+    LIMIT 1
+) AS last_event ON TRUE
+ORDER BY user_account.email_undeliverable_at IS NULL, user_account.email_bounce_count DESC, user_account.email;
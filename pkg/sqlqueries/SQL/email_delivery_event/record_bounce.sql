@@ -0,0 +1,8 @@
+UPDATE user_account
+SET
+    email_bounce_count = email_bounce_count + 1,
+    email_undeliverable_at = CASE
+        WHEN email_bounce_count + 1 >= :bounces_before_undeliverable THEN COALESCE(email_undeliverable_at, :occurred_at)
+        ELSE email_undeliverable_at
+    END
+WHERE LOWER(TRIM(email)) = LOWER(TRIM(:recipient));
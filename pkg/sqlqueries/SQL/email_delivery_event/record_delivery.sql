@@ -0,0 +1,7 @@
+UPDATE user_account
+SET
+    email_bounce_count = 0,
+    email_undeliverable_at = NULL
+WHERE
+    LOWER(TRIM(email)) = LOWER(TRIM(:recipient))
+    AND (email_bounce_count > 0 OR email_undeliverable_at IS NOT NULL);
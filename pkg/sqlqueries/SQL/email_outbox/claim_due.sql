@@ -0,0 +1,37 @@
+-- claims the next pending email that's due, skipping ones another dispatcher is claiming at the same time, and pushes its next attempt
+-- back by the lease, so no other dispatcher claims it while it's being sent. If the claim isn't released by recording the result of
+-- the attempt, the email is claimed again once the lease has passed
+UPDATE email_outbox
+SET
+    claim_id = :claim_id,
+    next_attempt_at = CURRENT_TIMESTAMP + MAKE_INTERVAL(secs => :lease_seconds)
+WHERE id = (
+    SELECT id
+    FROM email_outbox
+    WHERE
+        status = 'PENDING'
+        AND next_attempt_at <= CURRENT_TIMESTAMP
+    ORDER BY next_attempt_at, id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING
+    id,
+    to_addresses,
+    cc_addresses,
+    bcc_addresses,
+    subject,
+    body,
+    attachments,
+    reply_to_address,
+    status,
+    attempts,
+    next_attempt_at,
+    last_error,
+    sent_at,
+    provider_message_id,
+    claim_id,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at;
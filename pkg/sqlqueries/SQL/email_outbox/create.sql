@@ -0,0 +1,22 @@
+INSERT INTO email_outbox (
+    id,
+    to_addresses,
+    cc_addresses,
+    bcc_addresses,
+    subject,
+    body,
+    attachments,
+    reply_to_address,
+    created_by
+)
+VALUES (
+    :id,
+    :to_addresses,
+    :cc_addresses,
+    :bcc_addresses,
+    :subject,
+    :body,
+    :attachments,
+    :reply_to_address,
+    :created_by
+);
@@ -0,0 +1,22 @@
+SELECT
+    id,
+    to_addresses,
+    cc_addresses,
+    bcc_addresses,
+    subject,
+    body,
+    attachments,
+    reply_to_address,
+    status,
+    attempts,
+    next_attempt_at,
+    last_error,
+    sent_at,
+    provider_message_id,
+    claim_id,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at
+FROM email_outbox
+WHERE id = :id;
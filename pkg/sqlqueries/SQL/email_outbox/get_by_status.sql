@@ -0,0 +1,29 @@
+SELECT
+    id,
+    to_addresses,
+    cc_addresses,
+    bcc_addresses,
+    subject,
+    body,
+    attachments,
+    reply_to_address,
+    status,
+    attempts,
+    next_attempt_at,
+    last_error,
+    sent_at,
+    provider_message_id,
+    claim_id,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at
+FROM email_outbox
+WHERE
+    (CAST(:status AS EMAIL_OUTBOX_STATUS) IS NULL OR status = :status)
+    AND (
+        CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE) IS NULL
+        OR (created_at, id) < (CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE), CAST(:after_id AS UUID))
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT :limit;
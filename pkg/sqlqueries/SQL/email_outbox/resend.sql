@@ -0,0 +1,32 @@
+-- emails that have already been sent, or that a dispatcher is sending right now, can't be re-sent, to avoid sending them twice
+UPDATE email_outbox
+SET
+    status = 'PENDING',
+    attempts = 0,
+    next_attempt_at = CURRENT_TIMESTAMP,
+    modified_by = :modified_by,
+    modified_at = CURRENT_TIMESTAMP
+WHERE
+    id = :id
+    AND status != 'SENT'
+    AND (claim_id IS NULL OR next_attempt_at <= CURRENT_TIMESTAMP)
+RETURNING
+    id,
+    to_addresses,
+    cc_addresses,
+    bcc_addresses,
+    subject,
+    body,
+    attachments,
+    reply_to_address,
+    status,
+    attempts,
+    next_attempt_at,
+    last_error,
+    sent_at,
+    provider_message_id,
+    claim_id,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at;
@@ -0,0 +1,14 @@
+-- only the dispatcher holding the email's claim, if it has one, can record an attempt to send it, and doing so releases the claim
+UPDATE email_outbox
+SET
+    status = :status,
+    attempts = :attempts,
+    next_attempt_at = :next_attempt_at,
+    last_error = :last_error,
+    sent_at = :sent_at,
+    provider_message_id = :provider_message_id,
+    claim_id = NULL,
+    modified_at = CURRENT_TIMESTAMP
+WHERE
+    id = :id
+    AND claim_id IS NOT DISTINCT FROM :claim_id;
@@ -0,0 +1,5 @@
+SELECT COUNT(*)
+FROM notifications
+WHERE
+    user_id = :user_id
+    AND read_at IS NULL;
@@ -0,0 +1,25 @@
+-- every user account with one of the recipients' email addresses gets its own notification.
+-- email addresses without a user account, such as shared mailboxes, don't get one
+INSERT INTO notifications (
+    id,
+    user_id,
+    category,
+    subject,
+    body
+)
+SELECT
+    GEN_RANDOM_UUID(),
+    user_account.id,
+    :category,
+    :subject,
+    :body
+FROM user_account
+WHERE LOWER(user_account.email) = ANY(CAST(:emails AS TEXT[]))
+RETURNING
+    id,
+    user_id,
+    category,
+    subject,
+    body,
+    read_at,
+    created_at;
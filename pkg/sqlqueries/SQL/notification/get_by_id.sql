@@ -0,0 +1,10 @@
+SELECT
+    id,
+    user_id,
+    category,
+    subject,
+    body,
+    read_at,
+    created_at
+FROM notifications
+WHERE id = :id;
@@ -0,0 +1,18 @@
+SELECT
+    id,
+    user_id,
+    category,
+    subject,
+    body,
+    read_at,
+    created_at
+FROM notifications
+WHERE
+    user_id = :user_id
+    AND (NOT :unread_only OR read_at IS NULL)
+    AND (
+        CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE) IS NULL
+        OR (created_at, id) < (CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE), CAST(:after_id AS UUID))
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT :limit;
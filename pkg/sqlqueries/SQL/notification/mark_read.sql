@@ -0,0 +1,15 @@
+-- when no IDs are given, all of the user's unread notifications are marked read
+UPDATE notifications
+SET read_at = CURRENT_TIMESTAMP
+WHERE
+    user_id = :user_id
+    AND read_at IS NULL
+    AND (CAST(:ids AS UUID[]) IS NULL OR id = ANY(:ids))
+RETURNING
+    id,
+    user_id,
+    category,
+    subject,
+    body,
+    read_at,
+    created_at;
@@ -0,0 +1,14 @@
+INSERT INTO notification_digest_items (
+    id,
+    user_id,
+    category,
+    subject,
+    body
+)
+VALUES (
+    :id,
+    :user_id,
+    :category,
+    :subject,
+    :body
+);
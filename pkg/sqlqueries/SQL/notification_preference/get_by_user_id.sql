@@ -0,0 +1,5 @@
+SELECT
+    category,
+    frequency
+FROM user_notification_preferences
+WHERE user_id = :user_id;
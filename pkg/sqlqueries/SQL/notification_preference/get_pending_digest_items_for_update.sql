@@ -0,0 +1,13 @@
+-- locks the returned items until the end of the transaction, so each item is only sent in one digest
+SELECT
+    id,
+    user_id,
+    category,
+    subject,
+    body,
+    created_at,
+    sent_at
+FROM notification_digest_items
+WHERE sent_at IS NULL
+ORDER BY user_id, created_at, id
+FOR UPDATE SKIP LOCKED;
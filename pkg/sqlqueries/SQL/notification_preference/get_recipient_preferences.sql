@@ -0,0 +1,13 @@
+-- email addresses without a user account, such as shared mailboxes, aren't returned.
+-- if more than one account has the same email address, the preference that sends the most is used
+SELECT DISTINCT ON (LOWER(user_account.email))
+    user_account.id AS user_id,
+    user_account.email,
+    COALESCE(user_notification_preferences.frequency, 'IMMEDIATE') AS frequency
+FROM user_account
+LEFT JOIN user_notification_preferences
+    ON
+        user_account.id = user_notification_preferences.user_id
+        AND user_notification_preferences.category = :category
+WHERE LOWER(user_account.email) = ANY(CAST(:emails AS TEXT[]))
+ORDER BY LOWER(user_account.email), COALESCE(user_notification_preferences.frequency, 'IMMEDIATE');
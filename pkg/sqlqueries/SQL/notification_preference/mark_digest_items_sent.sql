@@ -0,0 +1,3 @@
+UPDATE notification_digest_items
+SET sent_at = CURRENT_TIMESTAMP
+WHERE id = ANY(:ids);
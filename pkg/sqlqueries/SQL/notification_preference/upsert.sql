@@ -0,0 +1,17 @@
+INSERT INTO user_notification_preferences (
+    user_id,
+    category,
+    frequency,
+    created_by
+)
+VALUES (
+    :user_id,
+    :category,
+    :frequency,
+    :user_id
+)
+ON CONFLICT (user_id, category) DO UPDATE
+SET
+    frequency = :frequency,
+    modified_by = :user_id,
+    modified_at = CURRENT_TIMESTAMP;
@@ -0,0 +1,285 @@
+-- the search_document_vector calls must match the expressions of the indexes added in V231__Add_Search_Indexes.sql
+WITH search_query AS (
+    SELECT websearch_to_tsquery('english', :query) AS query
+),
+
+-- the visibility rules below must change together with the resolvers returning the content:
+-- authorizeUserCanViewSystemIntake, authorizeUserCanViewTRBRequest, canViewTRBGuidanceLetter, and the admin checks on notes
+
+-- the intakes the user can view without being a GRT admin, as their requester or a GRB reviewer.
+-- Intakes without a requester linked to a user account fall back to the EUA ID they were submitted with
+viewable_system_intakes AS (
+    SELECT system_intake_contacts.system_intake_id AS id
+    FROM system_intake_contacts
+    WHERE
+        CAST(:is_easi_user AS BOOLEAN)
+        AND system_intake_contacts.is_requester
+        AND system_intake_contacts.user_id = CAST(:user_id AS UUID)
+
+    UNION
+
+    SELECT system_intakes.id
+    FROM system_intakes
+    WHERE
+        CAST(:is_easi_user AS BOOLEAN)
+        AND system_intakes.eua_user_id = :eua_user_id
+        AND NOT EXISTS (
+            SELECT 1
+            FROM system_intake_contacts
+            WHERE
+                system_intake_contacts.system_intake_id = system_intakes.id
+                AND system_intake_contacts.is_requester
+                AND system_intake_contacts.user_id IS NOT NULL
+        )
+
+    UNION
+
+    SELECT system_intake_grb_reviewers.system_intake_id AS id
+    FROM system_intake_grb_reviewers
+    WHERE system_intake_grb_reviewers.user_id = CAST(:user_id AS UUID)
+),
+
+-- the TRB requests the user can view without being a TRB admin, as their creator or lead
+viewable_trb_requests AS (
+    SELECT trb_request.id
+    FROM trb_request
+    WHERE
+        trb_request.created_by = :eua_user_id
+        OR trb_request.trb_lead = :eua_user_id
+),
+
+results AS (
+    SELECT
+        'SYSTEM_INTAKE' AS type,
+        system_intakes.id,
+        system_intakes.id AS request_id,
+        COALESCE(system_intakes.project_name, '') AS title,
+        system_intake_search_body(system_intakes.business_need, system_intakes.solution) AS body,
+        ts_rank(
+            search_document_vector(
+                COALESCE(system_intakes.project_name, '') || ' ' || COALESCE(system_intakes.project_acronym, ''),
+                system_intake_search_body(system_intakes.business_need, system_intakes.solution)
+            ),
+            search_query.query
+        )::FLOAT8 AS rank
+    FROM system_intakes, search_query
+    WHERE
+        'SYSTEM_INTAKE' = ANY(CAST(:types AS TEXT[]))
+        AND system_intakes.archived_at IS NULL
+        AND (
+            CAST(:is_grt_admin AS BOOLEAN)
+            OR system_intakes.id IN (SELECT viewable_system_intakes.id FROM viewable_system_intakes)
+        )
+        AND search_document_vector(
+            COALESCE(system_intakes.project_name, '') || ' ' || COALESCE(system_intakes.project_acronym, ''),
+            system_intake_search_body(system_intakes.business_need, system_intakes.solution)
+        ) @@ search_query.query
+
+    UNION ALL
+
+    SELECT
+        'BUSINESS_CASE' AS type,
+        business_cases.id,
+        business_cases.system_intake AS request_id,
+        COALESCE(business_cases.project_name, '') AS title,
+        business_case_search_body(
+            business_cases.business_need,
+            business_cases.current_solution_summary,
+            business_cases.cms_benefit,
+            business_cases.success_indicators,
+            business_cases.collaboration_needed,
+            business_cases.response_to_grt_feedback,
+            business_cases.preferred_title,
+            business_cases.preferred_summary,
+            business_cases.alternative_a_title,
+            business_cases.alternative_a_summary,
+            business_cases.alternative_b_title,
+            business_cases.alternative_b_summary
+        ) AS body,
+        ts_rank(
+            search_document_vector(
+                COALESCE(business_cases.project_name, '') || ' ' || COALESCE(business_cases.project_acronym, ''),
+                business_case_search_body(
+                    business_cases.business_need,
+                    business_cases.current_solution_summary,
+                    business_cases.cms_benefit,
+                    business_cases.success_indicators,
+                    business_cases.collaboration_needed,
+                    business_cases.response_to_grt_feedback,
+                    business_cases.preferred_title,
+                    business_cases.preferred_summary,
+                    business_cases.alternative_a_title,
+                    business_cases.alternative_a_summary,
+                    business_cases.alternative_b_title,
+                    business_cases.alternative_b_summary
+                )
+            ),
+            search_query.query
+        )::FLOAT8 AS rank
+    FROM business_cases
+    INNER JOIN system_intakes ON system_intakes.id = business_cases.system_intake
+    CROSS JOIN search_query
+    WHERE
+        'BUSINESS_CASE' = ANY(CAST(:types AS TEXT[]))
+        AND system_intakes.archived_at IS NULL
+        AND (
+            CAST(:is_grt_admin AS BOOLEAN)
+            OR system_intakes.id IN (SELECT viewable_system_intakes.id FROM viewable_system_intakes)
+        )
+        AND search_document_vector(
+            COALESCE(business_cases.project_name, '') || ' ' || COALESCE(business_cases.project_acronym, ''),
+            business_case_search_body(
+                business_cases.business_need,
+                business_cases.current_solution_summary,
+                business_cases.cms_benefit,
+                business_cases.success_indicators,
+                business_cases.collaboration_needed,
+                business_cases.response_to_grt_feedback,
+                business_cases.preferred_title,
+                business_cases.preferred_summary,
+                business_cases.alternative_a_title,
+                business_cases.alternative_a_summary,
+                business_cases.alternative_b_title,
+                business_cases.alternative_b_summary
+            )
+        ) @@ search_query.query
+
+    UNION ALL
+
+    SELECT
+        'TRB_REQUEST_FORM' AS type,
+        trb_request_forms.id,
+        trb_request_forms.trb_request_id AS request_id,
+        COALESCE(trb_request.name, '') AS title,
+        trb_request_form_search_body(
+            trb_request_forms.component,
+            trb_request_forms.needs_assistance_with,
+            trb_request_forms.proposed_solution,
+            trb_request_forms.where_in_process_other,
+            trb_request_forms.subject_area_option_other,
+            trb_request_forms.collab_group_other
+        ) AS body,
+        ts_rank(
+            search_document_vector(
+                '',
+                trb_request_form_search_body(
+                    trb_request_forms.component,
+                    trb_request_forms.needs_assistance_with,
+                    trb_request_forms.proposed_solution,
+                    trb_request_forms.where_in_process_other,
+                    trb_request_forms.subject_area_option_other,
+                    trb_request_forms.collab_group_other
+                )
+            ),
+            search_query.query
+        )::FLOAT8 AS rank
+    FROM trb_request_forms
+    INNER JOIN trb_request ON trb_request.id = trb_request_forms.trb_request_id
+    CROSS JOIN search_query
+    WHERE
+        'TRB_REQUEST_FORM' = ANY(CAST(:types AS TEXT[]))
+        AND (
+            CAST(:is_trb_admin AS BOOLEAN)
+            OR trb_request.id IN (SELECT viewable_trb_requests.id FROM viewable_trb_requests)
+        )
+        AND search_document_vector(
+            '',
+            trb_request_form_search_body(
+                trb_request_forms.component,
+                trb_request_forms.needs_assistance_with,
+                trb_request_forms.proposed_solution,
+                trb_request_forms.where_in_process_other,
+                trb_request_forms.subject_area_option_other,
+                trb_request_forms.collab_group_other
+            )
+        ) @@ search_query.query
+
+    UNION ALL
+
+    SELECT
+        'TRB_GUIDANCE_LETTER_INSIGHT' AS type,
+        trb_guidance_letter_insights.id,
+        trb_guidance_letter_insights.trb_request_id AS request_id,
+        trb_guidance_letter_insights.title,
+        search_document_text(trb_guidance_letter_insights.insight) AS body,
+        ts_rank(
+            search_document_vector(trb_guidance_letter_insights.title, trb_guidance_letter_insights.insight),
+            search_query.query
+        )::FLOAT8 AS rank
+    FROM trb_guidance_letter_insights, search_query
+    WHERE
+        'TRB_GUIDANCE_LETTER_INSIGHT' = ANY(CAST(:types AS TEXT[]))
+        AND trb_guidance_letter_insights.deleted_at IS NULL
+        AND (
+            CAST(:is_trb_admin AS BOOLEAN)
+            OR (
+                trb_guidance_letter_insights.trb_request_id IN (SELECT viewable_trb_requests.id FROM viewable_trb_requests)
+                AND EXISTS (
+                    SELECT 1
+                    FROM trb_guidance_letters
+                    WHERE
+                        trb_guidance_letters.trb_request_id = trb_guidance_letter_insights.trb_request_id
+                        AND trb_guidance_letters.status = 'COMPLETED'
+                )
+            )
+        )
+        AND search_document_vector(trb_guidance_letter_insights.title, trb_guidance_letter_insights.insight) @@ search_query.query
+
+    UNION ALL
+
+    SELECT
+        'SYSTEM_INTAKE_NOTE' AS type,
+        notes.id,
+        notes.system_intake AS request_id,
+        COALESCE(system_intakes.project_name, '') AS title,
+        search_document_text(notes.content) AS body,
+        ts_rank(search_document_vector('', notes.content), search_query.query)::FLOAT8 AS rank
+    FROM notes
+    INNER JOIN system_intakes ON system_intakes.id = notes.system_intake
+    CROSS JOIN search_query
+    WHERE
+        'SYSTEM_INTAKE_NOTE' = ANY(CAST(:types AS TEXT[]))
+        AND CAST(:is_grt_admin AS BOOLEAN)
+        AND NOT notes.is_archived
+        AND system_intakes.archived_at IS NULL
+        AND search_document_vector('', notes.content) @@ search_query.query
+
+    UNION ALL
+
+    SELECT
+        'TRB_ADMIN_NOTE' AS type,
+        trb_admin_notes.id,
+        trb_admin_notes.trb_request_id AS request_id,
+        COALESCE(trb_request.name, '') AS title,
+        search_document_text(trb_admin_notes.note_text) AS body,
+        ts_rank(search_document_vector('', trb_admin_notes.note_text), search_query.query)::FLOAT8 AS rank
+    FROM trb_admin_notes
+    INNER JOIN trb_request ON trb_request.id = trb_admin_notes.trb_request_id
+    CROSS JOIN search_query
+    WHERE
+        'TRB_ADMIN_NOTE' = ANY(CAST(:types AS TEXT[]))
+        AND CAST(:is_trb_admin AS BOOLEAN)
+        AND NOT trb_admin_notes.is_archived
+        AND search_document_vector('', trb_admin_notes.note_text) @@ search_query.query
+),
+
+page AS (
+    SELECT *
+    FROM results
+    WHERE
+        CAST(:after_id AS UUID) IS NULL
+        OR (results.rank, results.id) < (CAST(:after_rank AS FLOAT8), CAST(:after_id AS UUID))
+    ORDER BY results.rank DESC, results.id DESC
+    LIMIT :limit
+)
+
+-- snippets are only built for the page of results, as building them is relatively slow
+SELECT
+    page.type,
+    page.id,
+    page.request_id,
+    page.title,
+    ts_headline('english', page.body, search_query.query, :headline_options) AS snippet,
+    page.rank
+FROM page, search_query
+ORDER BY page.rank DESC, page.id DESC;
@@ -0,0 +1,124 @@
+SELECT
+    system_intakes.*,
+    sort.value AS sort_value
+FROM system_intakes
+CROSS JOIN LATERAL (
+    SELECT CASE CAST(:sort_field AS TEXT)
+        WHEN 'UPDATED_AT' THEN COALESCE(system_intakes.updated_at, system_intakes.created_at, system_intakes.submitted_at)
+        ELSE system_intakes.submitted_at
+    END AS value
+) AS sort
+-- the admin statuses an intake can have, following CalculateSystemIntakeAdminStatus in the resolvers package.
+-- these rules must be changed together with that function; TestAdminPageQueryMatchesCalculatedAdminStatus checks that the two agree.
+-- an async GRB review whose end date has passed can be either in progress or complete depending on its quorum,
+-- which is only known in Go, so both are matched here and the results are filtered again by the caller
+CROSS JOIN LATERAL (
+    SELECT CASE
+        WHEN system_intakes.step = 'DECISION_AND_NEXT_STEPS' AND system_intakes.decision_state = 'NO_DECISION' THEN '{}'
+        WHEN system_intakes.lcid_retires_at > NOW() THEN '{LCID_RETIRING_SOON}'
+        WHEN system_intakes.state = 'CLOSED' AND system_intakes.decision_state = 'NO_DECISION' THEN '{CLOSED}'
+        WHEN system_intakes.state = 'CLOSED' AND system_intakes.step != 'DECISION_AND_NEXT_STEPS' THEN '{CLOSED}'
+        WHEN system_intakes.step = 'INITIAL_REQUEST_FORM' THEN CASE
+            WHEN system_intakes.request_form_state = 'SUBMITTED' THEN '{INITIAL_REQUEST_FORM_SUBMITTED}'
+            ELSE '{INITIAL_REQUEST_FORM_IN_PROGRESS}'
+        END
+        WHEN system_intakes.step = 'DRAFT_BUSINESS_CASE' THEN CASE
+            WHEN system_intakes.draft_business_case_state = 'SUBMITTED' THEN '{DRAFT_BUSINESS_CASE_SUBMITTED}'
+            ELSE '{DRAFT_BUSINESS_CASE_IN_PROGRESS}'
+        END
+        WHEN system_intakes.step = 'GRT_MEETING' THEN CASE
+            WHEN system_intakes.grt_date IS NULL OR system_intakes.grt_date > NOW() THEN '{GRT_MEETING_READY}'
+            ELSE '{GRT_MEETING_COMPLETE}'
+        END
+        WHEN system_intakes.step = 'FINAL_BUSINESS_CASE' THEN CASE
+            WHEN system_intakes.final_business_case_state = 'SUBMITTED' THEN '{FINAL_BUSINESS_CASE_SUBMITTED}'
+            ELSE '{FINAL_BUSINESS_CASE_IN_PROGRESS}'
+        END
+        WHEN system_intakes.step = 'GRB_MEETING' AND system_intakes.grb_review_type = 'STANDARD' THEN CASE
+            WHEN system_intakes.grb_date IS NULL OR system_intakes.grb_date > NOW() THEN '{GRB_MEETING_READY}'
+            ELSE '{GRB_REVIEW_COMPLETE}'
+        END
+        WHEN system_intakes.step = 'GRB_MEETING' AND system_intakes.grb_review_type = 'ASYNC' THEN CASE
+            WHEN system_intakes.grb_review_started_at IS NULL OR system_intakes.grb_review_async_end_date IS NULL THEN '{GRB_MEETING_READY}'
+            WHEN system_intakes.grb_review_async_manual_end_date IS NOT NULL THEN '{GRB_REVIEW_COMPLETE}'
+            WHEN system_intakes.grb_review_async_end_date > NOW() AND system_intakes.grb_review_started_at < NOW() THEN '{GRB_REVIEW_IN_PROGRESS}'
+            WHEN system_intakes.grb_review_async_end_date > NOW() THEN '{GRB_REVIEW_COMPLETE}'
+            ELSE '{GRB_REVIEW_IN_PROGRESS,GRB_REVIEW_COMPLETE}'
+        END
+        WHEN system_intakes.step = 'GRB_MEETING' THEN '{GRB_REVIEW_COMPLETE}'
+        WHEN system_intakes.step = 'DECISION_AND_NEXT_STEPS' THEN CASE system_intakes.decision_state
+            WHEN 'LCID_ISSUED' THEN CASE
+                WHEN COALESCE(system_intakes.lcid, '') != '' AND system_intakes.lcid_retires_at < NOW() THEN '{LCID_RETIRED}'
+                WHEN COALESCE(system_intakes.lcid, '') != '' AND system_intakes.lcid_expires_at < NOW() THEN '{LCID_EXPIRED}'
+                ELSE '{LCID_ISSUED}'
+            END
+            WHEN 'NOT_GOVERNANCE' THEN '{NOT_GOVERNANCE}'
+            WHEN 'NOT_APPROVED' THEN '{NOT_APPROVED}'
+            ELSE '{}'
+        END
+        ELSE '{}'
+    END::TEXT[] AS candidates
+) AS admin_status
+WHERE
+    system_intakes.archived_at IS NULL
+    AND system_intakes.submitted_at IS NOT NULL
+    AND (CAST(:state AS TEXT) IS NULL OR system_intakes.state::TEXT = :state)
+    AND (
+        CARDINALITY(CAST(:admin_statuses AS TEXT[])) = 0
+        OR admin_status.candidates && CAST(:admin_statuses AS TEXT[])
+    )
+    AND (CAST(:admin_lead AS TEXT) IS NULL OR system_intakes.admin_lead = :admin_lead)
+    AND (
+        CARDINALITY(CAST(:lcid_statuses AS TEXT[])) = 0
+        OR (
+            COALESCE(system_intakes.lcid, '') != ''
+            AND CASE
+                WHEN system_intakes.lcid_retires_at < NOW() THEN 'RETIRED'
+                WHEN system_intakes.lcid_expires_at < NOW() THEN 'EXPIRED'
+                ELSE 'ISSUED'
+            END = ANY(CAST(:lcid_statuses AS TEXT[]))
+        )
+    )
+    AND (
+        CARDINALITY(CAST(:request_types AS TEXT[])) = 0
+        OR system_intakes.request_type::TEXT = ANY(CAST(:request_types AS TEXT[]))
+    )
+    AND (CAST(:submitted_after AS TIMESTAMP WITH TIME ZONE) IS NULL OR system_intakes.submitted_at >= :submitted_after)
+    AND (CAST(:submitted_before AS TIMESTAMP WITH TIME ZONE) IS NULL OR system_intakes.submitted_at < :submitted_before)
+    AND (
+        CAST(:updated_after AS TIMESTAMP WITH TIME ZONE) IS NULL
+        OR COALESCE(system_intakes.updated_at, system_intakes.created_at) >= :updated_after
+    )
+    AND (
+        CAST(:updated_before AS TIMESTAMP WITH TIME ZONE) IS NULL
+        OR COALESCE(system_intakes.updated_at, system_intakes.created_at) < :updated_before
+    )
+    AND (
+        CAST(:search_pattern AS TEXT) IS NULL
+        OR CONCAT_WS(
+            ' ',
+            system_intakes.project_name,
+            system_intakes.project_acronym,
+            system_intakes.requester,
+            system_intakes.component,
+            system_intakes.admin_lead,
+            system_intakes.lcid
+        ) ILIKE :search_pattern
+    )
+    AND (
+        CAST(:after_id AS UUID) IS NULL
+        OR (
+            CAST(:sort_ascending AS BOOLEAN)
+            AND (sort.value, system_intakes.id) > (CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE), CAST(:after_id AS UUID))
+        )
+        OR (
+            NOT CAST(:sort_ascending AS BOOLEAN)
+            AND (sort.value, system_intakes.id) < (CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE), CAST(:after_id AS UUID))
+        )
+    )
+ORDER BY
+    CASE WHEN CAST(:sort_ascending AS BOOLEAN) THEN sort.value END ASC,
+    CASE WHEN CAST(:sort_ascending AS BOOLEAN) THEN system_intakes.id END ASC,
+    CASE WHEN NOT CAST(:sort_ascending AS BOOLEAN) THEN sort.value END DESC,
+    CASE WHEN NOT CAST(:sort_ascending AS BOOLEAN) THEN system_intakes.id END DESC
+LIMIT :limit;
@@ -0,0 +1,8 @@
+UPDATE system_intakes
+SET
+    grb_quorum_minimum_votes = :grb_quorum_minimum_votes,
+    grb_quorum_voting_percentage = :grb_quorum_voting_percentage,
+    grb_quorum_required_roles = :grb_quorum_required_roles,
+    grb_quorum_required_roles_rule = :grb_quorum_required_roles_rule,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = :system_intake_id;
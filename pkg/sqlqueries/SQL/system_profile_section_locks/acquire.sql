@@ -0,0 +1,25 @@
+INSERT INTO system_profile_section_locks AS locks (
+    cedar_system_id,
+    section,
+    locked_by,
+    locked_at,
+    expires_at
+)
+VALUES (
+    :cedar_system_id,
+    :section,
+    :locked_by,
+    now(),
+    now() + make_interval(secs => :lease_seconds)
+)
+ON CONFLICT (cedar_system_id, section) DO UPDATE
+SET
+    locked_by = EXCLUDED.locked_by,
+    locked_at = CASE
+        WHEN locks.locked_by = EXCLUDED.locked_by AND locks.expires_at > now() THEN locks.locked_at
+        ELSE EXCLUDED.locked_at
+    END,
+    expires_at = EXCLUDED.expires_at
+-- only renew our own lock, or take over a lock whose lease has run out
+WHERE locks.locked_by = EXCLUDED.locked_by OR locks.expires_at <= now()
+RETURNING *;
@@ -0,0 +1,5 @@
+DELETE
+FROM system_profile_section_locks
+WHERE cedar_system_id = :cedar_system_id
+AND section = :section
+RETURNING *;
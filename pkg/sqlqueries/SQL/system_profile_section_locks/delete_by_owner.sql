@@ -0,0 +1,8 @@
+-- only removes the lock while the given user still holds it, so a user whose lease has run out can't remove a lock someone else has since taken
+DELETE
+FROM system_profile_section_locks
+WHERE cedar_system_id = :cedar_system_id
+AND section = :section
+AND locked_by = :locked_by
+AND expires_at > now()
+RETURNING *;
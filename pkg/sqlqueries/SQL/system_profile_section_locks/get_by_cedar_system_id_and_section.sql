@@ -0,0 +1,5 @@
+SELECT *
+FROM system_profile_section_locks
+WHERE cedar_system_id = :cedar_system_id
+AND section = :section
+AND expires_at > now();
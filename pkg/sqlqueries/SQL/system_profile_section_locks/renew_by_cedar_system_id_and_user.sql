@@ -0,0 +1,6 @@
+UPDATE system_profile_section_locks
+SET expires_at = now() + make_interval(secs => :lease_seconds)
+WHERE cedar_system_id = :cedar_system_id
+AND locked_by = :locked_by
+AND expires_at > now()
+RETURNING *;
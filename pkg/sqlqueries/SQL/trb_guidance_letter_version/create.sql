@@ -0,0 +1,43 @@
+INSERT INTO trb_guidance_letter_versions (
+    id,
+    trb_request_id,
+    trb_guidance_letter_id,
+    version_number,
+    reason,
+    meeting_summary,
+    next_steps,
+    is_followup_recommended,
+    followup_point,
+    insights,
+    amendment_summary,
+    created_by
+)
+SELECT
+    CAST(:id AS UUID),
+    CAST(:trb_request_id AS UUID),
+    CAST(:trb_guidance_letter_id AS UUID),
+    COALESCE(MAX(version_number), 0) + 1,
+    CAST(:reason AS TRB_GUIDANCE_LETTER_VERSION_REASON),
+    CAST(:meeting_summary AS TEXT),
+    CAST(:next_steps AS TEXT),
+    CAST(:is_followup_recommended AS BOOLEAN),
+    CAST(:followup_point AS TEXT),
+    CAST(:insights AS JSONB),
+    CAST(:amendment_summary AS TEXT),
+    CAST(:created_by AS TEXT)
+FROM trb_guidance_letter_versions
+WHERE trb_guidance_letter_id = :trb_guidance_letter_id
+RETURNING
+    id,
+    trb_request_id,
+    trb_guidance_letter_id,
+    version_number,
+    reason,
+    meeting_summary,
+    next_steps,
+    is_followup_recommended,
+    followup_point,
+    insights,
+    amendment_summary,
+    created_by,
+    created_at;
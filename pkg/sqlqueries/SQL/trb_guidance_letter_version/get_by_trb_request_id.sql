@@ -0,0 +1,17 @@
+SELECT
+    id,
+    trb_request_id,
+    trb_guidance_letter_id,
+    version_number,
+    reason,
+    meeting_summary,
+    next_steps,
+    is_followup_recommended,
+    followup_point,
+    insights,
+    amendment_summary,
+    created_by,
+    created_at
+FROM trb_guidance_letter_versions
+WHERE trb_request_id = :trb_request_id
+ORDER BY version_number DESC;
@@ -0,0 +1,74 @@
+SELECT
+    trb_request.id,
+    trb_request.name,
+    trb_request.archived,
+    trb_request.type,
+    trb_request.state,
+    trb_request.consult_meeting_time,
+    trb_request.trb_lead,
+    trb_request.contract_name,
+    trb_request.system_relation_type,
+    trb_request.created_by,
+    trb_request.created_at,
+    trb_request.modified_by,
+    trb_request.modified_at,
+    sort.value AS sort_value
+FROM trb_request
+LEFT JOIN trb_request_forms ON trb_request_forms.trb_request_id = trb_request.id
+CROSS JOIN LATERAL (
+    SELECT CASE CAST(:sort_field AS TEXT)
+        WHEN 'UPDATED_AT' THEN COALESCE(trb_request.modified_at, trb_request.created_at)
+        ELSE COALESCE(trb_request_forms.submitted_at, trb_request.created_at)
+    END AS value
+) AS sort
+WHERE
+    trb_request.archived = :archived
+    AND (CAST(:state AS TEXT) IS NULL OR trb_request.state::TEXT = :state)
+    AND (CAST(:trb_lead AS TEXT) IS NULL OR trb_request.trb_lead = :trb_lead)
+    AND (
+        CARDINALITY(CAST(:request_types AS TEXT[])) = 0
+        OR trb_request.type::TEXT = ANY(CAST(:request_types AS TEXT[]))
+    )
+    AND (
+        CAST(:submitted_after AS TIMESTAMP WITH TIME ZONE) IS NULL
+        OR trb_request_forms.submitted_at >= :submitted_after
+    )
+    AND (
+        CAST(:submitted_before AS TIMESTAMP WITH TIME ZONE) IS NULL
+        OR trb_request_forms.submitted_at < :submitted_before
+    )
+    AND (
+        CAST(:updated_after AS TIMESTAMP WITH TIME ZONE) IS NULL
+        OR COALESCE(trb_request.modified_at, trb_request.created_at) >= :updated_after
+    )
+    AND (
+        CAST(:updated_before AS TIMESTAMP WITH TIME ZONE) IS NULL
+        OR COALESCE(trb_request.modified_at, trb_request.created_at) < :updated_before
+    )
+    AND (
+        CAST(:search_pattern AS TEXT) IS NULL
+        OR CONCAT_WS(
+            ' ',
+            trb_request.name,
+            trb_request.contract_name,
+            trb_request.created_by,
+            trb_request_forms.component
+        ) ILIKE :search_pattern
+    )
+    AND (
+        CAST(:after_id AS UUID) IS NULL
+        OR (
+            CAST(:sort_ascending AS BOOLEAN)
+            AND (sort.value, trb_request.id) > (CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE), CAST(:after_id AS UUID))
+        )
+        OR (
+            NOT CAST(:sort_ascending AS BOOLEAN)
+            AND (sort.value, trb_request.id) < (CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE), CAST(:after_id AS UUID))
+        )
+    )
+ORDER BY
+    CASE WHEN CAST(:sort_ascending AS BOOLEAN) THEN sort.value END ASC,
+    CASE WHEN CAST(:sort_ascending AS BOOLEAN) THEN trb_request.id END ASC,
+    CASE WHEN NOT CAST(:sort_ascending AS BOOLEAN) THEN sort.value END DESC,
+    CASE WHEN NOT CAST(:sort_ascending AS BOOLEAN) THEN trb_request.id END DESC
+LIMIT :limit;
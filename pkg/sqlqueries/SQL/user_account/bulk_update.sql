@@ -6,7 +6,10 @@ SET
     given_name = accounts.given_name,
     family_name = accounts.family_name,
     zone_info = accounts.zone_info,
-    has_logged_in = accounts.has_logged_in
+    has_logged_in = accounts.has_logged_in,
+    -- bounces from a user's old email address don't count against their new one
+    email_bounce_count = CASE WHEN LOWER(TRIM(user_account.email)) = LOWER(TRIM(accounts.email)) THEN user_account.email_bounce_count ELSE 0 END,
+    email_undeliverable_at = CASE WHEN LOWER(TRIM(user_account.email)) = LOWER(TRIM(accounts.email)) THEN user_account.email_undeliverable_at END
 FROM (
     SELECT
         UNNEST(CAST(:ids AS UUID[])) AS id,
@@ -28,4 +31,6 @@ RETURNING
     user_account.given_name,
     user_account.family_name,
     user_account.zone_info,
-    user_account.has_logged_in;
+    user_account.has_logged_in,
+    user_account.email_bounce_count,
+    user_account.email_undeliverable_at;
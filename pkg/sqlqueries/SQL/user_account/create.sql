@@ -30,4 +30,6 @@ RETURNING
     given_name,
     family_name,
     zone_info,
-    has_logged_in;
+    has_logged_in,
+    email_bounce_count,
+    email_undeliverable_at;
@@ -0,0 +1,14 @@
+SELECT
+    id,
+    username,
+    common_name,
+    locale,
+    email,
+    given_name,
+    family_name,
+    zone_info,
+    has_logged_in,
+    email_bounce_count,
+    email_undeliverable_at
+FROM user_account
+WHERE LOWER(TRIM(email)) = LOWER(TRIM(:email)) -- This ensures case-insensitive matching and trims whitespace
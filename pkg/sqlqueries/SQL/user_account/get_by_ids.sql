@@ -7,6 +7,8 @@ SELECT
     given_name,
     family_name,
     zone_info,
-    has_logged_in
+    has_logged_in,
+    email_bounce_count,
+    email_undeliverable_at
 FROM user_account
 WHERE id = ANY(:user_ids);
@@ -6,7 +6,10 @@ SET
     given_name = :given_name,
     family_name = :family_name,
     zone_info = :zone_info,
-    has_logged_in = :has_logged_in
+    has_logged_in = :has_logged_in,
+    -- bounces from a user's old email address don't count against their new one
+    email_bounce_count = CASE WHEN LOWER(TRIM(email)) = LOWER(TRIM(:email)) THEN email_bounce_count ELSE 0 END,
+    email_undeliverable_at = CASE WHEN LOWER(TRIM(email)) = LOWER(TRIM(:email)) THEN email_undeliverable_at END
 
 WHERE id = :id
 RETURNING
@@ -18,4 +21,6 @@ RETURNING
     given_name,
     family_name,
     zone_info,
-    has_logged_in;
+    has_logged_in,
+    email_bounce_count,
+    email_undeliverable_at;
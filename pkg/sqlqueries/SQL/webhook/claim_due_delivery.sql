@@ -0,0 +1,52 @@
+-- claims the next pending delivery to an active subscription that's due, skipping ones another dispatcher is claiming at the same time,
+-- and pushes its next attempt back by the lease, so no other dispatcher claims it while it's being sent. If the claim isn't released by
+-- recording the result of the attempt, the delivery is claimed again once the lease has passed.
+-- Deliveries to a subscriber that's still being sent an earlier delivery are skipped, so a slow subscriber only holds up its own deliveries
+WITH claimable AS (
+    SELECT webhook_deliveries.id
+    FROM webhook_deliveries
+    INNER JOIN webhook_subscriptions ON webhook_subscriptions.id = webhook_deliveries.subscription_id
+    WHERE
+        webhook_deliveries.status = 'PENDING'
+        AND webhook_deliveries.next_attempt_at <= CURRENT_TIMESTAMP
+        AND webhook_subscriptions.is_active
+        AND NOT EXISTS (
+            SELECT 1
+            FROM webhook_deliveries AS in_flight
+            WHERE
+                in_flight.subscription_id = webhook_deliveries.subscription_id
+                AND in_flight.claim_id IS NOT NULL
+                AND in_flight.next_attempt_at > CURRENT_TIMESTAMP
+        )
+    ORDER BY webhook_deliveries.next_attempt_at, webhook_deliveries.id
+    LIMIT 1
+    FOR UPDATE OF webhook_deliveries SKIP LOCKED
+)
+
+UPDATE webhook_deliveries
+SET
+    claim_id = :claim_id,
+    next_attempt_at = CURRENT_TIMESTAMP + MAKE_INTERVAL(secs => :lease_seconds)
+FROM claimable, webhook_subscriptions
+WHERE
+    webhook_deliveries.id = claimable.id
+    AND webhook_subscriptions.id = webhook_deliveries.subscription_id
+RETURNING
+    webhook_deliveries.id,
+    webhook_deliveries.subscription_id,
+    webhook_deliveries.event_id,
+    webhook_deliveries.event_type,
+    webhook_deliveries.payload,
+    webhook_deliveries.status,
+    webhook_deliveries.attempts,
+    webhook_deliveries.next_attempt_at,
+    webhook_deliveries.last_response_status,
+    webhook_deliveries.last_error,
+    webhook_deliveries.delivered_at,
+    webhook_deliveries.claim_id,
+    webhook_deliveries.created_by,
+    webhook_deliveries.created_at,
+    webhook_deliveries.modified_by,
+    webhook_deliveries.modified_at,
+    webhook_subscriptions.url AS subscription_url,
+    webhook_subscriptions.secret AS subscription_secret;
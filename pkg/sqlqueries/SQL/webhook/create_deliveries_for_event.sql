@@ -0,0 +1,37 @@
+-- queues a delivery of the event to each active subscription that's subscribed to its type
+INSERT INTO webhook_deliveries (
+    id,
+    subscription_id,
+    event_id,
+    event_type,
+    payload,
+    created_by
+)
+-- the values are cast because their types can't be inferred from a SELECT list
+SELECT
+    gen_random_uuid(),
+    id,
+    CAST(:event_id AS UUID),
+    CAST(:event_type AS WEBHOOK_EVENT_TYPE),
+    CAST(:payload AS JSONB),
+    CAST(:created_by AS UUID)
+FROM webhook_subscriptions
+WHERE
+    is_active
+    AND CAST(:event_type AS WEBHOOK_EVENT_TYPE) = ANY(event_types)
+RETURNING
+    id,
+    subscription_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    next_attempt_at,
+    last_response_status,
+    last_error,
+    delivered_at,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at;
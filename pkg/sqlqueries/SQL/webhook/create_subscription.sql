@@ -0,0 +1,29 @@
+INSERT INTO webhook_subscriptions (
+    id,
+    name,
+    url,
+    secret,
+    event_types,
+    is_active,
+    created_by
+)
+VALUES (
+    :id,
+    :name,
+    :url,
+    :secret,
+    :event_types,
+    :is_active,
+    :created_by
+)
+RETURNING
+    id,
+    name,
+    url,
+    secret,
+    event_types,
+    is_active,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at;
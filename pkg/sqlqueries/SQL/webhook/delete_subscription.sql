@@ -0,0 +1,14 @@
+-- the subscription's delivery log is deleted with it
+DELETE FROM webhook_subscriptions
+WHERE id = :id
+RETURNING
+    id,
+    name,
+    url,
+    secret,
+    event_types,
+    is_active,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at;
@@ -0,0 +1,27 @@
+SELECT
+    id,
+    subscription_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    next_attempt_at,
+    last_response_status,
+    last_error,
+    delivered_at,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at
+FROM webhook_deliveries
+WHERE
+    event_type = ANY(CAST(:event_types AS WEBHOOK_EVENT_TYPE[]))
+    AND (CAST(:subscription_id AS UUID) IS NULL OR subscription_id = :subscription_id)
+    AND (CAST(:status AS WEBHOOK_DELIVERY_STATUS) IS NULL OR status = :status)
+    AND (
+        CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE) IS NULL
+        OR (created_at, id) < (CAST(:after_sort_value AS TIMESTAMP WITH TIME ZONE), CAST(:after_id AS UUID))
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT :limit;
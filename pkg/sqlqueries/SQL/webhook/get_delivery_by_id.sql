@@ -0,0 +1,18 @@
+SELECT
+    id,
+    subscription_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    next_attempt_at,
+    last_response_status,
+    last_error,
+    delivered_at,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at
+FROM webhook_deliveries
+WHERE id = :id;
@@ -0,0 +1,13 @@
+SELECT
+    id,
+    name,
+    url,
+    secret,
+    event_types,
+    is_active,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at
+FROM webhook_subscriptions
+WHERE id = :id;
@@ -0,0 +1,28 @@
+-- events that have already been delivered, or that a dispatcher is delivering right now, can't be redelivered, to avoid subscribers receiving them twice
+UPDATE webhook_deliveries
+SET
+    status = 'PENDING',
+    attempts = 0,
+    next_attempt_at = CURRENT_TIMESTAMP,
+    modified_by = :modified_by,
+    modified_at = CURRENT_TIMESTAMP
+WHERE
+    id = :id
+    AND status != 'DELIVERED'
+    AND (claim_id IS NULL OR next_attempt_at <= CURRENT_TIMESTAMP)
+RETURNING
+    id,
+    subscription_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    next_attempt_at,
+    last_response_status,
+    last_error,
+    delivered_at,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at;
@@ -0,0 +1,14 @@
+-- only the dispatcher holding the delivery's claim, if it has one, can record an attempt to send it, and doing so releases the claim
+UPDATE webhook_deliveries
+SET
+    status = :status,
+    attempts = :attempts,
+    next_attempt_at = :next_attempt_at,
+    last_response_status = :last_response_status,
+    last_error = :last_error,
+    delivered_at = :delivered_at,
+    claim_id = NULL,
+    modified_at = CURRENT_TIMESTAMP
+WHERE
+    id = :id
+    AND claim_id IS NOT DISTINCT FROM :claim_id;
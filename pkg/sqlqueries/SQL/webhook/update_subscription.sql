@@ -0,0 +1,20 @@
+UPDATE webhook_subscriptions
+SET
+    name = :name,
+    url = :url,
+    event_types = :event_types,
+    is_active = :is_active,
+    modified_by = :modified_by,
+    modified_at = CURRENT_TIMESTAMP
+WHERE id = :id
+RETURNING
+    id,
+    name,
+    url,
+    secret,
+    event_types,
+    is_active,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at;
@@ -0,0 +1,17 @@
+UPDATE webhook_subscriptions
+SET
+    secret = :secret,
+    modified_by = :modified_by,
+    modified_at = CURRENT_TIMESTAMP
+WHERE id = :id
+RETURNING
+    id,
+    name,
+    url,
+    secret,
+    event_types,
+    is_active,
+    created_by,
+    created_at,
+    modified_by,
+    modified_at;
@@ -0,0 +1,22 @@
+package sqlqueries
+
+import (
+	_ "embed"
+)
+
+//go:embed SQL/audit_change/create.sql
+var createAuditChangeSQL string
+
+//go:embed SQL/audit_change/get_by_entity_id.sql
+var getAuditChangesByEntityIDSQL string
+
+// AuditChange holds all relevant SQL scripts for the audit history
+var AuditChange = auditChangeScripts{
+	Create:        createAuditChangeSQL,
+	GetByEntityID: getAuditChangesByEntityIDSQL,
+}
+
+type auditChangeScripts struct {
+	Create        string
+	GetByEntityID string
+}
@@ -0,0 +1,17 @@
+package sqlqueries
+
+import (
+	_ "embed"
+)
+
+//go:embed SQL/calendar_invite/next_sequence.sql
+var nextCalendarInviteSequenceSQL string
+
+// CalendarInvite holds all relevant SQL scripts for calendar invites
+var CalendarInvite = calendarInviteScripts{
+	NextSequence: nextCalendarInviteSequenceSQL,
+}
+
+type calendarInviteScripts struct {
+	NextSequence string
+}
@@ -0,0 +1,42 @@
+package sqlqueries
+
+import (
+	_ "embed"
+)
+
+//go:embed SQL/email_delivery_event/create.sql
+var createEmailDeliveryEventSQL string
+
+//go:embed SQL/email_delivery_event/record_bounce.sql
+var recordEmailBounceSQL string
+
+//go:embed SQL/email_delivery_event/record_delivery.sql
+var recordEmailDeliverySQL string
+
+//go:embed SQL/email_delivery_event/get_by_outbox_id.sql
+var getEmailDeliveryEventsByOutboxIDSQL string
+
+//go:embed SQL/email_delivery_event/get_recipient_statuses_by_system_intake_id.sql
+var getEmailRecipientDeliveryStatusesBySystemIntakeIDSQL string
+
+//go:embed SQL/email_delivery_event/get_recipient_statuses_by_trb_request_id.sql
+var getEmailRecipientDeliveryStatusesByTRBRequestIDSQL string
+
+// EmailDeliveryEvent holds all relevant SQL scripts for email delivery events
+var EmailDeliveryEvent = emailDeliveryEventScripts{
+	Create:                               createEmailDeliveryEventSQL,
+	RecordBounce:                         recordEmailBounceSQL,
+	RecordDelivery:                       recordEmailDeliverySQL,
+	GetByOutboxID:                        getEmailDeliveryEventsByOutboxIDSQL,
+	GetRecipientStatusesBySystemIntakeID: getEmailRecipientDeliveryStatusesBySystemIntakeIDSQL,
+	GetRecipientStatusesByTRBRequestID:   getEmailRecipientDeliveryStatusesByTRBRequestIDSQL,
+}
+
+type emailDeliveryEventScripts struct {
+	Create                               string
+	RecordBounce                         string
+	RecordDelivery                       string
+	GetByOutboxID                        string
+	GetRecipientStatusesBySystemIntakeID string
+	GetRecipientStatusesByTRBRequestID   string
+}
@@ -0,0 +1,42 @@
+package sqlqueries
+
+import (
+	_ "embed"
+)
+
+//go:embed SQL/email_outbox/create.sql
+var createEmailOutboxMessageSQL string
+
+//go:embed SQL/email_outbox/claim_due.sql
+var claimDueEmailOutboxMessageSQL string
+
+//go:embed SQL/email_outbox/update_delivery.sql
+var updateEmailOutboxMessageDeliverySQL string
+
+//go:embed SQL/email_outbox/get_by_id.sql
+var getEmailOutboxMessageByIDSQL string
+
+//go:embed SQL/email_outbox/get_by_status.sql
+var getEmailOutboxMessagesByStatusSQL string
+
+//go:embed SQL/email_outbox/resend.sql
+var resendEmailOutboxMessageSQL string
+
+// EmailOutbox holds all relevant SQL scripts for the email outbox
+var EmailOutbox = emailOutboxScripts{
+	Create:         createEmailOutboxMessageSQL,
+	ClaimDue:       claimDueEmailOutboxMessageSQL,
+	UpdateDelivery: updateEmailOutboxMessageDeliverySQL,
+	GetByID:        getEmailOutboxMessageByIDSQL,
+	GetByStatus:    getEmailOutboxMessagesByStatusSQL,
+	Resend:         resendEmailOutboxMessageSQL,
+}
+
+type emailOutboxScripts struct {
+	Create         string
+	ClaimDue       string
+	UpdateDelivery string
+	GetByID        string
+	GetByStatus    string
+	Resend         string
+}
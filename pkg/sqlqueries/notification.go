@@ -0,0 +1,37 @@
+package sqlqueries
+
+import (
+	_ "embed"
+)
+
+//go:embed SQL/notification/create_for_recipients.sql
+var createNotificationsForRecipientsSQL string
+
+//go:embed SQL/notification/get_by_id.sql
+var getNotificationByIDSQL string
+
+//go:embed SQL/notification/get_by_user_id.sql
+var getNotificationsByUserIDSQL string
+
+//go:embed SQL/notification/count_unread.sql
+var countUnreadNotificationsSQL string
+
+//go:embed SQL/notification/mark_read.sql
+var markNotificationsReadSQL string
+
+// Notification holds all relevant SQL scripts for in-app notifications
+var Notification = notificationScripts{
+	CreateForRecipients: createNotificationsForRecipientsSQL,
+	GetByID:             getNotificationByIDSQL,
+	GetByUserID:         getNotificationsByUserIDSQL,
+	CountUnread:         countUnreadNotificationsSQL,
+	MarkRead:            markNotificationsReadSQL,
+}
+
+type notificationScripts struct {
+	CreateForRecipients string
+	GetByID             string
+	GetByUserID         string
+	CountUnread         string
+	MarkRead            string
+}
@@ -0,0 +1,42 @@
+package sqlqueries
+
+import (
+	_ "embed"
+)
+
+//go:embed SQL/notification_preference/get_by_user_id.sql
+var getNotificationPreferencesByUserIDSQL string
+
+//go:embed SQL/notification_preference/upsert.sql
+var upsertNotificationPreferenceSQL string
+
+//go:embed SQL/notification_preference/get_recipient_preferences.sql
+var getNotificationRecipientPreferencesSQL string
+
+//go:embed SQL/notification_preference/create_digest_item.sql
+var createNotificationDigestItemSQL string
+
+//go:embed SQL/notification_preference/get_pending_digest_items_for_update.sql
+var getPendingNotificationDigestItemsForUpdateSQL string
+
+//go:embed SQL/notification_preference/mark_digest_items_sent.sql
+var markNotificationDigestItemsSentSQL string
+
+// NotificationPreference holds all relevant SQL scripts for notification preferences and daily digests
+var NotificationPreference = notificationPreferenceScripts{
+	GetByUserID:                    getNotificationPreferencesByUserIDSQL,
+	Upsert:                         upsertNotificationPreferenceSQL,
+	GetRecipientPreferences:        getNotificationRecipientPreferencesSQL,
+	CreateDigestItem:               createNotificationDigestItemSQL,
+	GetPendingDigestItemsForUpdate: getPendingNotificationDigestItemsForUpdateSQL,
+	MarkDigestItemsSent:            markNotificationDigestItemsSentSQL,
+}
+
+type notificationPreferenceScripts struct {
+	GetByUserID                    string
+	Upsert                         string
+	GetRecipientPreferences        string
+	CreateDigestItem               string
+	GetPendingDigestItemsForUpdate string
+	MarkDigestItemsSent            string
+}
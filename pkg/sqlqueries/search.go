@@ -0,0 +1,17 @@
+package sqlqueries
+
+import (
+	_ "embed"
+)
+
+//go:embed SQL/search/search.sql
+var searchSQL string
+
+// Search holds all relevant SQL scripts for full text search
+var Search = searchScripts{
+	Search: searchSQL,
+}
+
+type searchScripts struct {
+	Search string
+}
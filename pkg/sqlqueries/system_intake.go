@@ -12,15 +12,10 @@ var getByUser string
 //go:embed SQL/system_intake/get_where_grb_voting_halfway_through.sql
 var getWhereGRBReviewIsHalfwayThrough string
 
-// getWhereGRBReviewPastDueNoQuorum holds the SQL query to get intakes with a past due GRB review but no quorum
+// getWhereGRBReviewAsyncEndDatePassed holds the SQL query to get intakes with an async GRB review whose end date passed in the last day
 //
-//go:embed SQL/system_intake/get_where_grb_review_past_due_no_quorum.sql
-var getWhereGRBReviewPastDueNoQuorum string
-
-// getWhereGRBReviewCompleteQuorumMet holds the SQL query to get intakes with completed GRB Reviews where quorum has been met
-//
-//go:embed SQL/system_intake/get_where_grb_review_complete_quorum_met.sql
-var getWhereGRBReviewCompleteQuorumMet string
+//go:embed SQL/system_intake/get_where_grb_review_async_end_date_passed.sql
+var getWhereGRBReviewAsyncEndDatePassed string
 
 // getWhereGRBReviewEnded holds the SQL query to get intakes that have ended in the last day
 //
@@ -42,24 +37,36 @@ var getSystemIntakeByGRBReviewerID string
 //go:embed SQL/system_intake/get_lcid_options.sql
 var getLCIDOptions string
 
+// updateGRBQuorumPolicy holds the SQL query to update the quorum policy of a system intake's GRB review
+//
+//go:embed SQL/system_intake/update_grb_quorum_policy.sql
+var updateGRBQuorumPolicy string
+
+// getAdminPage holds the SQL query to get a filtered and sorted page of the system intakes in the admin view
+//
+//go:embed SQL/system_intake/get_admin_page.sql
+var getAdminPage string
+
 var SystemIntake = systemIntakeScripts{
 	GetByUser:                         getByUser,
 	GetWhereGRBReviewIsHalfwayThrough: getWhereGRBReviewIsHalfwayThrough,
-	GetWhereGRBPastDueNoQuorum:        getWhereGRBReviewPastDueNoQuorum,
-	GetWhereReviewCompleteQuorumMet:   getWhereGRBReviewCompleteQuorumMet,
+	GetWhereGRBAsyncEndDatePassed:     getWhereGRBReviewAsyncEndDatePassed,
 	GetWhereGRBReviewEnded:            getWhereGRBReviewEnded,
 	GetRequesterUpdateEmailData:       getRequesterUpdateEmailData,
 	GetSystemIntakeByGRBReviewerID:    getSystemIntakeByGRBReviewerID,
 	GetLCIDOptions:                    getLCIDOptions,
+	UpdateGRBQuorumPolicy:             updateGRBQuorumPolicy,
+	GetAdminPage:                      getAdminPage,
 }
 
 type systemIntakeScripts struct {
 	GetByUser                         string
 	GetWhereGRBReviewIsHalfwayThrough string
-	GetWhereGRBPastDueNoQuorum        string
-	GetWhereReviewCompleteQuorumMet   string
+	GetWhereGRBAsyncEndDatePassed     string
 	GetWhereGRBReviewEnded            string
 	GetRequesterUpdateEmailData       string
 	GetSystemIntakeByGRBReviewerID    string
 	GetLCIDOptions                    string
+	UpdateGRBQuorumPolicy             string
+	GetAdminPage                      string
 }
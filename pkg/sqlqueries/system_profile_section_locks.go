@@ -0,0 +1,52 @@
+package sqlqueries
+
+import (
+	_ "embed"
+)
+
+//go:embed SQL/system_profile_section_locks/acquire.sql
+var acquireSystemProfileSectionLockSQL string
+
+//go:embed SQL/system_profile_section_locks/delete.sql
+var deleteSystemProfileSectionLockSQL string
+
+//go:embed SQL/system_profile_section_locks/delete_by_owner.sql
+var deleteSystemProfileSectionLockByOwnerSQL string
+
+//go:embed SQL/system_profile_section_locks/delete_by_cedar_system_id.sql
+var deleteSystemProfileSectionLocksByCedarSystemIDSQL string
+
+//go:embed SQL/system_profile_section_locks/delete_expired_by_cedar_system_id.sql
+var deleteExpiredSystemProfileSectionLocksByCedarSystemIDSQL string
+
+//go:embed SQL/system_profile_section_locks/get_by_cedar_system_id.sql
+var getSystemProfileSectionLocksByCedarSystemIDSQL string
+
+//go:embed SQL/system_profile_section_locks/get_by_cedar_system_id_and_section.sql
+var getSystemProfileSectionLockByCedarSystemIDAndSectionSQL string
+
+//go:embed SQL/system_profile_section_locks/renew_by_cedar_system_id_and_user.sql
+var renewSystemProfileSectionLocksByCedarSystemIDAndUserSQL string
+
+// SystemProfileSectionLocks holds all relevant SQL scripts for system profile section locks
+var SystemProfileSectionLocks = systemProfileSectionLocksScripts{
+	Acquire:                       acquireSystemProfileSectionLockSQL,
+	Delete:                        deleteSystemProfileSectionLockSQL,
+	DeleteByOwner:                 deleteSystemProfileSectionLockByOwnerSQL,
+	DeleteByCedarSystemID:         deleteSystemProfileSectionLocksByCedarSystemIDSQL,
+	DeleteExpiredByCedarSystemID:  deleteExpiredSystemProfileSectionLocksByCedarSystemIDSQL,
+	GetByCedarSystemID:            getSystemProfileSectionLocksByCedarSystemIDSQL,
+	GetByCedarSystemIDAndSection:  getSystemProfileSectionLockByCedarSystemIDAndSectionSQL,
+	RenewByCedarSystemIDAndUserID: renewSystemProfileSectionLocksByCedarSystemIDAndUserSQL,
+}
+
+type systemProfileSectionLocksScripts struct {
+	Acquire                       string
+	Delete                        string
+	DeleteByOwner                 string
+	DeleteByCedarSystemID         string
+	DeleteExpiredByCedarSystemID  string
+	GetByCedarSystemID            string
+	GetByCedarSystemIDAndSection  string
+	RenewByCedarSystemIDAndUserID string
+}
@@ -0,0 +1,32 @@
+package sqlqueries
+
+import (
+	_ "embed"
+)
+
+//go:embed SQL/trb_guidance_letter_version/create.sql
+var createTRBGuidanceLetterVersionSQL string
+
+//go:embed SQL/trb_guidance_letter_version/get_by_id.sql
+var getTRBGuidanceLetterVersionByIDSQL string
+
+//go:embed SQL/trb_guidance_letter_version/get_by_trb_request_id.sql
+var getTRBGuidanceLetterVersionsByTRBRequestIDSQL string
+
+//go:embed SQL/trb_guidance_letter_version/get_latest_sent_by_letter_id.sql
+var getLatestSentTRBGuidanceLetterVersionSQL string
+
+// TRBGuidanceLetterVersion holds all relevant SQL scripts for TRB guidance letter versions
+var TRBGuidanceLetterVersion = trbGuidanceLetterVersionScripts{
+	Create:                  createTRBGuidanceLetterVersionSQL,
+	GetByID:                 getTRBGuidanceLetterVersionByIDSQL,
+	GetByTRBRequestID:       getTRBGuidanceLetterVersionsByTRBRequestIDSQL,
+	GetLatestSentByLetterID: getLatestSentTRBGuidanceLetterVersionSQL,
+}
+
+type trbGuidanceLetterVersionScripts struct {
+	Create                  string
+	GetByID                 string
+	GetByTRBRequestID       string
+	GetLatestSentByLetterID string
+}
@@ -27,6 +27,11 @@ var trbRequestCollectionGetByUserAndArchivedStateSQL string
 //go:embed SQL/trb_request/get_by_id.sql
 var trbRequestGetByIDSQL string
 
+// trbRequestGetAdminPageSQL holds the SQL query to get a filtered and sorted page of TRB Requests in the admin view
+//
+//go:embed SQL/trb_request/get_admin_page.sql
+var trbRequestGetAdminPageSQL string
+
 // TRBRequest holds all relevant SQL scripts for a TRB Request
 var TRBRequest = trbRequestScripts{
 	Create:                              trbRequestCreateSQL,
@@ -34,6 +39,7 @@ var TRBRequest = trbRequestScripts{
 	GetByID:                             trbRequestGetByIDSQL,
 	CollectionGet:                       trbRequestCollectionGetSQL,
 	CollectionGetByUserAndArchivedState: trbRequestCollectionGetByUserAndArchivedStateSQL,
+	GetAdminPage:                        trbRequestGetAdminPageSQL,
 }
 
 type trbRequestScripts struct {
@@ -49,4 +55,6 @@ type trbRequestScripts struct {
 	// Holds the SQL query to get all TRB Requests for a giver user.
 	// It matches the created_by and the archived fields with the provided parameters
 	CollectionGetByUserAndArchivedState string
+	// Holds the SQL query to get a filtered and sorted page of TRB Requests for admins
+	GetAdminPage string
 }
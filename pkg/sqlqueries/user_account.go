@@ -17,6 +17,11 @@ var userAccountGetByUsernames string
 //go:embed SQL/user_account/get_by_common_name.sql
 var userAccountGetByCommonName string
 
+// Holds the SQL query to return the user accounts with a given email address
+//
+//go:embed SQL/user_account/get_by_email.sql
+var userAccountGetByEmail string
+
 // Holds the SQL to return a user account for a given internal UUID
 //
 //go:embed SQL/user_account/get_by_id.sql
@@ -48,6 +53,7 @@ var UserAccount = userAccount{
 	GetByUsername:   userAccountGetByUsername,
 	GetByUsernames:  userAccountGetByUsernames,
 	GetByCommonName: userAccountGetByCommonName,
+	GetByEmail:      userAccountGetByEmail,
 	GetByID:         userAccountGetByID,
 	GetByIDs:        userAccountGetByIDs,
 	Create:          userAccountCreate,
@@ -62,6 +68,8 @@ type userAccount struct {
 	GetByUsernames string
 	// Holds the SQL query to return a user account by a common name
 	GetByCommonName string
+	// Holds the SQL query to return the user accounts with a given email address
+	GetByEmail string
 	// Holds the SQL to return a user account for a given internal UUID
 	GetByID string
 	// Holds the SQL to return a collection of user accounts for a collection of internal UUIDs
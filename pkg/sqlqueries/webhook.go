@@ -0,0 +1,72 @@
+package sqlqueries
+
+import (
+	_ "embed"
+)
+
+//go:embed SQL/webhook/create_subscription.sql
+var createWebhookSubscriptionSQL string
+
+//go:embed SQL/webhook/update_subscription.sql
+var updateWebhookSubscriptionSQL string
+
+//go:embed SQL/webhook/update_subscription_secret.sql
+var updateWebhookSubscriptionSecretSQL string
+
+//go:embed SQL/webhook/delete_subscription.sql
+var deleteWebhookSubscriptionSQL string
+
+//go:embed SQL/webhook/get_subscription_by_id.sql
+var getWebhookSubscriptionByIDSQL string
+
+//go:embed SQL/webhook/get_subscriptions.sql
+var getWebhookSubscriptionsSQL string
+
+//go:embed SQL/webhook/create_deliveries_for_event.sql
+var createWebhookDeliveriesForEventSQL string
+
+//go:embed SQL/webhook/claim_due_delivery.sql
+var claimDueWebhookDeliverySQL string
+
+//go:embed SQL/webhook/update_delivery.sql
+var updateWebhookDeliverySQL string
+
+//go:embed SQL/webhook/get_delivery_by_id.sql
+var getWebhookDeliveryByIDSQL string
+
+//go:embed SQL/webhook/get_deliveries.sql
+var getWebhookDeliveriesSQL string
+
+//go:embed SQL/webhook/redeliver.sql
+var redeliverWebhookDeliverySQL string
+
+// Webhook holds all relevant SQL scripts for webhook subscriptions and their deliveries
+var Webhook = webhookScripts{
+	CreateSubscription:       createWebhookSubscriptionSQL,
+	UpdateSubscription:       updateWebhookSubscriptionSQL,
+	UpdateSubscriptionSecret: updateWebhookSubscriptionSecretSQL,
+	DeleteSubscription:       deleteWebhookSubscriptionSQL,
+	GetSubscriptionByID:      getWebhookSubscriptionByIDSQL,
+	GetSubscriptions:         getWebhookSubscriptionsSQL,
+	CreateDeliveriesForEvent: createWebhookDeliveriesForEventSQL,
+	ClaimDueDelivery:         claimDueWebhookDeliverySQL,
+	UpdateDelivery:           updateWebhookDeliverySQL,
+	GetDeliveryByID:          getWebhookDeliveryByIDSQL,
+	GetDeliveries:            getWebhookDeliveriesSQL,
+	Redeliver:                redeliverWebhookDeliverySQL,
+}
+
+type webhookScripts struct {
+	CreateSubscription       string
+	UpdateSubscription       string
+	UpdateSubscriptionSecret string
+	DeleteSubscription       string
+	GetSubscriptionByID      string
+	GetSubscriptions         string
+	CreateDeliveriesForEvent string
+	ClaimDueDelivery         string
+	UpdateDelivery           string
+	GetDeliveryByID          string
+	GetDeliveries            string
+	Redeliver                string
+}
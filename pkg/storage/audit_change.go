@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlqueries"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// recordAuditChange records the fields that differ between before and after in the audit history, attributed to the principal in ctx.
+// before should be nil for an insert, and after should be nil for a delete. Updates that don't change any audited field are not recorded.
+//
+// It should be called with the same NamedPreparer as the change itself, so the change and its audit record are committed together
+func recordAuditChange(
+	ctx context.Context,
+	np sqlutils.NamedPreparer,
+	entityType models.AuditEntityType,
+	action models.AuditChangeAction,
+	entityID uuid.UUID,
+	parentID *uuid.UUID,
+	before any,
+	after any,
+) error {
+	return recordAuditChangeWithExternalID(ctx, np, entityType, action, entityID, parentID, nil, before, after)
+}
+
+// RecordCEDARSystemProfileChange records a change made to one of a CEDAR system's records through EASi in the audit history, attributed
+// to the principal in ctx. CEDAR records aren't identified by UUIDs, so the change is recorded against the CEDAR system, with the record's
+// CEDAR ID as its external ID; CEDAR doesn't return the IDs of records it adds, so externalID may be empty for an insert.
+// before should be nil for an insert, and after should be nil for a delete.
+func (s *Store) RecordCEDARSystemProfileChange(
+	ctx context.Context,
+	entityType models.AuditEntityType,
+	action models.AuditChangeAction,
+	cedarSystemID uuid.UUID,
+	externalID string,
+	before any,
+	after any,
+) error {
+	var externalIDArg *string
+	if externalID != "" {
+		externalIDArg = &externalID
+	}
+
+	return recordAuditChangeWithExternalID(ctx, s.db, entityType, action, cedarSystemID, nil, externalIDArg, before, after)
+}
+
+func recordAuditChangeWithExternalID(
+	ctx context.Context,
+	np sqlutils.NamedPreparer,
+	entityType models.AuditEntityType,
+	action models.AuditChangeAction,
+	entityID uuid.UUID,
+	parentID *uuid.UUID,
+	externalID *string,
+	before any,
+	after any,
+) error {
+	fields, err := models.NewAuditFieldChanges(before, after)
+	if err != nil {
+		return err
+	}
+
+	if action == models.AuditChangeActionUpdate && len(fields) == 0 {
+		return nil
+	}
+
+	// changes made without a signed in user, such as by scheduled jobs, are recorded without an actor
+	var modifiedBy *uuid.UUID
+	if account := appcontext.Principal(ctx).Account(); account != nil && account.ID != uuid.Nil {
+		modifiedBy = &account.ID
+	}
+
+	if _, err := namedExec(ctx, np, sqlqueries.AuditChange.Create, args{
+		"id":          uuid.New(),
+		"entity_type": entityType,
+		"entity_id":   entityID,
+		"parent_id":   parentID,
+		"external_id": externalID,
+		"action":      action,
+		"fields":      fields,
+		"modified_by": modifiedBy,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to record audit change",
+			zap.Error(err),
+			zap.String("entityType", string(entityType)),
+			zap.String("entityID", entityID.String()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// GetAuditChangesByEntityID returns up to limit audit changes of the given types made to the entity or the entities that belong to it,
+// newest first. If after is set, only the changes after that position in the history are returned
+func (s *Store) GetAuditChangesByEntityID(
+	ctx context.Context,
+	entityID uuid.UUID,
+	entityTypes []models.AuditEntityType,
+	limit int,
+	after *models.PageCursor,
+) ([]*models.AuditChange, error) {
+	arguments := args{
+		"entity_id":    entityID,
+		"entity_types": models.EnumArray[models.AuditEntityType](entityTypes),
+		"limit":        limit,
+	}
+	addPageCursorArgs(arguments, after)
+
+	var changes []*models.AuditChange
+	if err := namedSelect(ctx, s.db, &changes, sqlqueries.AuditChange.GetByEntityID, arguments); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get audit changes", zap.Error(err), zap.String("entityID", entityID.String()))
+		return nil, err
+	}
+
+	return changes, nil
+}
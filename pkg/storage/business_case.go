@@ -1,14 +1,17 @@
 package storage
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/cms-enterprise/easi-app/pkg/appcontext"
@@ -33,6 +36,13 @@ const UniqueIntakeMsg = "pq: duplicate key value violates unique constraint \"un
 // FetchBusinessCaseByID queries the DB for a Business Case matching the given ID
 // This is legacy code used in REST endpoints
 func (s *Store) FetchBusinessCaseByID(ctx context.Context, businessCaseID uuid.UUID) (*models.BusinessCaseWithCosts, error) {
+	// Unsafe() is used to avoid errors from the initial_submitted_at and last_submitted_at columns that are in the database, but not in the Go model
+	// see https://jiraent.cms.gov/browse/EASI-1693
+	return fetchBusinessCaseByID(ctx, s.db.Unsafe(), businessCaseID)
+}
+
+// fetchBusinessCaseByID queries the DB for a Business Case matching the given ID, allowing the fetch to be part of a transaction
+func fetchBusinessCaseByID(ctx context.Context, q sqlx.QueryerContext, businessCaseID uuid.UUID) (*models.BusinessCaseWithCosts, error) {
 	businessCase := models.BusinessCaseWithCosts{}
 	const fetchBusinessCaseSQL = `
 		SELECT
@@ -46,9 +56,7 @@ func (s *Store) FetchBusinessCaseByID(ctx context.Context, businessCaseID uuid.U
 			business_cases.id = $1
 		GROUP BY estimated_lifecycle_costs.business_case, business_cases.id, system_intakes.id`
 
-	// Unsafe() is used to avoid errors from the initial_submitted_at and last_submitted_at columns that are in the database, but not in the Go model
-	// see https://jiraent.cms.gov/browse/EASI-1693
-	err := s.db.Unsafe().Get(&businessCase, fetchBusinessCaseSQL, businessCaseID)
+	err := sqlx.GetContext(ctx, q, &businessCase, fetchBusinessCaseSQL, businessCaseID)
 	if err != nil {
 		appcontext.ZLogger(ctx).Error(
 			fmt.Sprintf("Failed to fetch Business Case %s", err),
@@ -390,6 +398,10 @@ func (s *Store) CreateBusinessCase(ctx context.Context, businessCase *models.Bus
 			}
 		}
 
+		if err := auditBusinessCaseChange(ctx, tx, models.AuditChangeActionInsert, businessCase.ID, nil); err != nil {
+			return nil, err
+		}
+
 		return businessCase, nil
 	})
 }
@@ -474,6 +486,12 @@ func (s *Store) UpdateBusinessCase(ctx context.Context, businessCase *models.Bus
 	`
 
 		logger := appcontext.ZLogger(ctx)
+
+		before, err := fetchBusinessCaseByID(ctx, tx.Unsafe(), businessCase.ID)
+		if err != nil {
+			return businessCase, err
+		}
+
 		result, err := tx.NamedExec(updateBusinessCaseSQL, &businessCase)
 		if err != nil {
 			logger.Error(
@@ -505,6 +523,49 @@ func (s *Store) UpdateBusinessCase(ctx context.Context, businessCase *models.Bus
 			return businessCase, err
 		}
 
+		if err := auditBusinessCaseChange(ctx, tx, models.AuditChangeActionUpdate, businessCase.ID, before); err != nil {
+			return businessCase, err
+		}
+
 		return businessCase, nil
 	})
 }
+
+// auditBusinessCaseChange records the change made to a Business Case in the audit history, comparing it as it now is in the
+// transaction with before, which should be nil for an insert
+func auditBusinessCaseChange(ctx context.Context, tx *sqlx.Tx, action models.AuditChangeAction, businessCaseID uuid.UUID, before *models.BusinessCaseWithCosts) error {
+	after, err := fetchBusinessCaseByID(ctx, tx.Unsafe(), businessCaseID)
+	if err != nil {
+		return err
+	}
+
+	var auditedBefore *models.BusinessCaseWithCosts
+	if before != nil {
+		auditedBefore = auditableBusinessCase(before)
+	}
+
+	return recordAuditChange(ctx, tx, models.AuditEntityTypeBusinessCase, action, businessCaseID, &after.SystemIntakeID, auditedBefore, auditableBusinessCase(after))
+}
+
+// auditableBusinessCase returns a copy of a Business Case for comparison in the audit history.
+// Lifecycle cost lines are recreated on every update, so their generated IDs are cleared and they are sorted,
+// leaving only changes to the costs themselves
+func auditableBusinessCase(businessCase *models.BusinessCaseWithCosts) *models.BusinessCaseWithCosts {
+	audited := *businessCase
+	audited.LifecycleCostLines = make(models.EstimatedLifecycleCosts, len(businessCase.LifecycleCostLines))
+
+	for i, line := range businessCase.LifecycleCostLines {
+		line.ID = uuid.Nil
+		audited.LifecycleCostLines[i] = line
+	}
+
+	slices.SortFunc(audited.LifecycleCostLines, func(a, b models.EstimatedLifecycleCost) int {
+		return cmp.Or(
+			cmp.Compare(a.Solution, b.Solution),
+			cmp.Compare(a.Year, b.Year),
+			cmp.Compare(lo.FromPtr(a.Phase), lo.FromPtr(b.Phase)),
+		)
+	})
+
+	return &audited
+}
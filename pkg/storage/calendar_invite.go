@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/sqlqueries"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// NextCalendarInviteSequence returns the sequence to send the next invite for the event with the given UID with.
+// The first invite for an event has a sequence of 0, and each invite after it has a sequence one higher than the last
+func (s *Store) NextCalendarInviteSequence(ctx context.Context, np sqlutils.NamedPreparer, uid string) (int, error) {
+	var sequence int
+	if err := namedGet(ctx, np, &sequence, sqlqueries.CalendarInvite.NextSequence, args{
+		"uid": uid,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get next calendar invite sequence", zap.Error(err), zap.String("uid", uid))
+		return 0, err
+	}
+
+	return sequence, nil
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlqueries"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// RecordEmailDeliveryEvents saves delivery events from SES, linking each to the email in the outbox it's about, and updates the bounce counts
+// of the users the events are for. SNS can send a notification more than once, so events already recorded are skipped.
+// It returns the number of events that were recorded
+func (s *Store) RecordEmailDeliveryEvents(ctx context.Context, events []*models.EmailDeliveryEvent) (int, error) {
+	return sqlutils.WithTransactionRet(ctx, s.db, func(tx *sqlx.Tx) (int, error) {
+		recorded := 0
+		for _, event := range events {
+			if event.ID == uuid.Nil {
+				event.ID = uuid.New()
+			}
+
+			if err := namedGet(ctx, tx, event, sqlqueries.EmailDeliveryEvent.Create, event); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
+				appcontext.ZLogger(ctx).Error("failed to record email delivery event", zap.Error(err), zap.String("notificationID", event.NotificationID))
+				return 0, err
+			}
+			recorded++
+
+			var err error
+			switch event.EventType {
+			case models.EmailDeliveryEventTypeBounce:
+				_, err = namedExec(ctx, tx, sqlqueries.EmailDeliveryEvent.RecordBounce, args{
+					"recipient":                    event.Recipient,
+					"occurred_at":                  event.OccurredAt,
+					"bounces_before_undeliverable": models.EmailBouncesBeforeUndeliverable,
+				})
+			case models.EmailDeliveryEventTypeDelivery:
+				_, err = namedExec(ctx, tx, sqlqueries.EmailDeliveryEvent.RecordDelivery, args{
+					"recipient": event.Recipient,
+				})
+			}
+			if err != nil {
+				appcontext.ZLogger(ctx).Error("failed to update email bounce count", zap.Error(err), zap.String("notificationID", event.NotificationID))
+				return 0, err
+			}
+		}
+		return recorded, nil
+	})
+}
+
+// GetEmailDeliveryEventsByOutboxID returns the delivery events for an email sent from the outbox, newest first
+func (s *Store) GetEmailDeliveryEventsByOutboxID(ctx context.Context, emailOutboxID uuid.UUID) ([]*models.EmailDeliveryEvent, error) {
+	events := []*models.EmailDeliveryEvent{}
+	if err := namedSelect(ctx, s.db, &events, sqlqueries.EmailDeliveryEvent.GetByOutboxID, args{
+		"email_outbox_id": emailOutboxID,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get email delivery events", zap.Error(err), zap.String("emailOutboxID", emailOutboxID.String()))
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetEmailRecipientDeliveryStatusesBySystemIntakeID returns the delivery status of emails to the contacts and GRB reviewers of a system intake,
+// with the people whose emails are bouncing first
+func (s *Store) GetEmailRecipientDeliveryStatusesBySystemIntakeID(ctx context.Context, systemIntakeID uuid.UUID) ([]*models.EmailRecipientDeliveryStatus, error) {
+	statuses := []*models.EmailRecipientDeliveryStatus{}
+	if err := namedSelect(ctx, s.db, &statuses, sqlqueries.EmailDeliveryEvent.GetRecipientStatusesBySystemIntakeID, args{
+		"system_intake_id": systemIntakeID,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get email delivery statuses for system intake", zap.Error(err), zap.String("systemIntakeID", systemIntakeID.String()))
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+// GetEmailRecipientDeliveryStatusesByTRBRequestID returns the delivery status of emails to the requester, TRB lead, and attendees of a TRB request,
+// with the people whose emails are bouncing first
+func (s *Store) GetEmailRecipientDeliveryStatusesByTRBRequestID(ctx context.Context, trbRequestID uuid.UUID) ([]*models.EmailRecipientDeliveryStatus, error) {
+	statuses := []*models.EmailRecipientDeliveryStatus{}
+	if err := namedSelect(ctx, s.db, &statuses, sqlqueries.EmailDeliveryEvent.GetRecipientStatusesByTRBRequestID, args{
+		"trb_request_id": trbRequestID,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get email delivery statuses for TRB request", zap.Error(err), zap.String("trbRequestID", trbRequestID.String()))
+		return nil, err
+	}
+
+	return statuses, nil
+}
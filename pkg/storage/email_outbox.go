@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlqueries"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// CreateEmailOutboxMessage queues an email in the outbox. It should be called with the same NamedPreparer as the change that
+// caused the email, so the email is only sent if that change is committed
+func (s *Store) CreateEmailOutboxMessage(ctx context.Context, np sqlutils.NamedPreparer, message *models.EmailOutboxMessage) error {
+	if message.ID == uuid.Nil {
+		message.ID = uuid.New()
+	}
+
+	if _, err := namedExec(ctx, np, sqlqueries.EmailOutbox.Create, message); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to queue email in outbox", zap.Error(err), zap.String("subject", message.Subject))
+		return err
+	}
+
+	return nil
+}
+
+// ClaimDueEmailOutboxMessage claims the next pending email whose next attempt is due, so no other dispatcher sends it for the length
+// of lease. It returns nil if no email is due. The claim is released by recording the attempt with UpdateEmailOutboxMessageDelivery
+func (s *Store) ClaimDueEmailOutboxMessage(ctx context.Context, lease time.Duration) (*models.EmailOutboxMessage, error) {
+	var message models.EmailOutboxMessage
+	if err := namedGet(ctx, s.db, &message, sqlqueries.EmailOutbox.ClaimDue, args{
+		"claim_id":      uuid.New(),
+		"lease_seconds": lease.Seconds(),
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		appcontext.ZLogger(ctx).Error("failed to claim due email from outbox", zap.Error(err))
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// UpdateEmailOutboxMessageDelivery saves the result of an attempt to send an email from the outbox, releasing the claim on it.
+// If the email was claimed, the claim must still be held; if it has since been claimed again, the result isn't saved and an error is returned
+func (s *Store) UpdateEmailOutboxMessageDelivery(ctx context.Context, np sqlutils.NamedPreparer, message *models.EmailOutboxMessage) error {
+	result, err := namedExec(ctx, np, sqlqueries.EmailOutbox.UpdateDelivery, message)
+	if err != nil {
+		appcontext.ZLogger(ctx).Error("failed to update email outbox delivery", zap.Error(err), zap.String("id", message.ID.String()))
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return fmt.Errorf("email %s is no longer claimed by this attempt", message.ID)
+	}
+
+	message.ClaimID = nil
+	return nil
+}
+
+// GetEmailOutboxMessageByID returns a single email from the outbox
+func (s *Store) GetEmailOutboxMessageByID(ctx context.Context, id uuid.UUID) (*models.EmailOutboxMessage, error) {
+	var message models.EmailOutboxMessage
+	if err := namedGet(ctx, s.db, &message, sqlqueries.EmailOutbox.GetByID, args{
+		"id": id,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get email from outbox", zap.Error(err), zap.String("id", id.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.ResourceNotFoundError{Err: err, Resource: models.EmailOutboxMessage{}}
+		}
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// GetEmailOutboxMessages returns up to limit emails from the outbox, optionally only those with the given status, newest first.
+// If after is set, only the emails after that position in the outbox are returned
+func (s *Store) GetEmailOutboxMessages(
+	ctx context.Context,
+	status *models.EmailOutboxMessageStatus,
+	limit int,
+	after *models.PageCursor,
+) ([]*models.EmailOutboxMessage, error) {
+	arguments := args{
+		"status": status,
+		"limit":  limit,
+	}
+	addPageCursorArgs(arguments, after)
+
+	var messages []*models.EmailOutboxMessage
+	if err := namedSelect(ctx, s.db, &messages, sqlqueries.EmailOutbox.GetByStatus, arguments); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get emails from outbox", zap.Error(err))
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// ResendEmailOutboxMessage moves an email that hasn't been sent back to pending, with a fresh set of attempts, so the
+// dispatcher sends it again right away. Emails that have already been sent, or are being sent, are not changed, and a ResourceNotFoundError is returned
+func (s *Store) ResendEmailOutboxMessage(ctx context.Context, id uuid.UUID, modifiedBy *uuid.UUID) (*models.EmailOutboxMessage, error) {
+	var message models.EmailOutboxMessage
+	if err := namedGet(ctx, s.db, &message, sqlqueries.EmailOutbox.Resend, args{
+		"id":          id,
+		"modified_by": modifiedBy,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to re-send email from outbox", zap.Error(err), zap.String("id", id.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.ResourceNotFoundError{Err: err, Resource: models.EmailOutboxMessage{}}
+		}
+		return nil, err
+	}
+
+	return &message, nil
+}
@@ -26,34 +26,44 @@ type iamDB struct {
 // Connections made with an auth token will _NOT_ drop when the token expires, so the 15 minute expiry should never matter
 // This function helps satisfy the driver.Connector interface
 func (idb *iamDB) Connect(ctx context.Context) (driver.Conn, error) {
-	awsRegion := idb.awsConfig.Region
-	awsCreds := idb.awsConfig.Credentials
-	dbEndpoint := fmt.Sprintf("%s:%s", idb.dbConfig.Host, idb.dbConfig.Port)
+	dataSourceName, err := iamDataSourceName(ctx, idb.awsConfig, idb.dbConfig)
+	if err != nil {
+		return nil, err
+	}
 
-	authToken, err := auth.BuildAuthToken(ctx, dbEndpoint, awsRegion, idb.dbConfig.Username, awsCreds)
+	psqlDriver := &pq.Driver{}
+	connector, err := psqlDriver.Open(dataSourceName)
 	if err != nil {
 		return nil, err
 	}
 
+	return connector, nil
+}
+
+// iamDataSourceName builds a connection string that authenticates with a newly generated IAM auth token
+func iamDataSourceName(ctx context.Context, awsConfig aws.Config, dbConfig DBConfig) (string, error) {
+	awsRegion := awsConfig.Region
+	awsCreds := awsConfig.Credentials
+	dbEndpoint := fmt.Sprintf("%s:%s", dbConfig.Host, dbConfig.Port)
+
+	authToken, err := auth.BuildAuthToken(ctx, dbEndpoint, awsRegion, dbConfig.Username, awsCreds)
+	if err != nil {
+		return "", err
+	}
+
 	psqlURL := url.URL{
 		Scheme: "postgres",
 		Host:   dbEndpoint,
-		User:   url.UserPassword(idb.dbConfig.Username, authToken),
-		Path:   idb.dbConfig.Database,
+		User:   url.UserPassword(dbConfig.Username, authToken),
+		Path:   dbConfig.Database,
 	}
 
 	q := psqlURL.Query()
-	q.Add("sslmode", idb.dbConfig.SSLMode)
+	q.Add("sslmode", dbConfig.SSLMode)
 
 	psqlURL.RawQuery = q.Encode()
 
-	psqlDriver := &pq.Driver{}
-	connector, err := psqlDriver.Open(psqlURL.String())
-	if err != nil {
-		return nil, err
-	}
-
-	return connector, nil
+	return psqlURL.String(), nil
 }
 
 // Driver returns IAM DB instance, as it satisfies the driver.Driver interface
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlqueries"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// CreateNotificationsForRecipients adds a copy of notification to the inbox of every user account with one of the given email addresses,
+// matched case insensitively, and returns the created notifications. Email addresses that don't belong to a user account are skipped
+func (s *Store) CreateNotificationsForRecipients(
+	ctx context.Context,
+	np sqlutils.NamedPreparer,
+	emails []models.EmailAddress,
+	notification *models.Notification,
+) ([]*models.Notification, error) {
+	lowerEmails := lo.Map(emails, func(email models.EmailAddress, _ int) string {
+		return strings.ToLower(email.String())
+	})
+
+	var notifications []*models.Notification
+	if err := namedSelect(ctx, np, &notifications, sqlqueries.Notification.CreateForRecipients, args{
+		"category": notification.Category,
+		"subject":  notification.Subject,
+		"body":     notification.Body,
+		"emails":   pq.Array(lowerEmails),
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to create notifications", zap.Error(err), zap.String("subject", notification.Subject))
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// GetNotificationByID returns a single notification
+func (s *Store) GetNotificationByID(ctx context.Context, id uuid.UUID) (*models.Notification, error) {
+	var notification models.Notification
+	if err := namedGet(ctx, s.db, &notification, sqlqueries.Notification.GetByID, args{
+		"id": id,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get notification", zap.Error(err), zap.String("id", id.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.ResourceNotFoundError{Err: err, Resource: models.Notification{}}
+		}
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// GetNotificationsByUserID returns up to limit of the notifications in a user's inbox, optionally only the unread ones, newest first.
+// If after is set, only the notifications after that position in the inbox are returned
+func (s *Store) GetNotificationsByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+	unreadOnly bool,
+	limit int,
+	after *models.PageCursor,
+) ([]*models.Notification, error) {
+	arguments := args{
+		"user_id":     userID,
+		"unread_only": unreadOnly,
+		"limit":       limit,
+	}
+	addPageCursorArgs(arguments, after)
+
+	var notifications []*models.Notification
+	if err := namedSelect(ctx, s.db, &notifications, sqlqueries.Notification.GetByUserID, arguments); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get notifications", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// CountUnreadNotifications returns the number of unread notifications in a user's inbox
+func (s *Store) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	if err := namedGet(ctx, s.db, &count, sqlqueries.Notification.CountUnread, args{
+		"user_id": userID,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to count unread notifications", zap.Error(err), zap.String("userID", userID.String()))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkNotificationsRead marks the given unread notifications in a user's inbox read, or all of them if ids is nil,
+// and returns the notifications that were marked. Notifications in other users' inboxes are never changed
+func (s *Store) MarkNotificationsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) ([]*models.Notification, error) {
+	var idsArg any
+	if ids != nil {
+		idsArg = pq.Array(ids)
+	}
+
+	var notifications []*models.Notification
+	if err := namedSelect(ctx, s.db, &notifications, sqlqueries.Notification.MarkRead, args{
+		"user_id": userID,
+		"ids":     idsArg,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to mark notifications read", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, err
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlqueries"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// GetNotificationPreferencesByUserID returns the notification preferences a user has set. Categories they haven't set a preference for are not returned
+func (s *Store) GetNotificationPreferencesByUserID(ctx context.Context, userID uuid.UUID) ([]*models.NotificationPreference, error) {
+	var preferences []*models.NotificationPreference
+	if err := namedSelect(ctx, s.db, &preferences, sqlqueries.NotificationPreference.GetByUserID, args{
+		"user_id": userID,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get notification preferences", zap.Error(err), zap.String("userID", userID.String()))
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// SetNotificationPreference sets how a user wants to receive a category of notification
+func (s *Store) SetNotificationPreference(ctx context.Context, np sqlutils.NamedPreparer, userID uuid.UUID, preference *models.NotificationPreference) error {
+	if _, err := namedExec(ctx, np, sqlqueries.NotificationPreference.Upsert, args{
+		"user_id":   userID,
+		"category":  preference.Category,
+		"frequency": preference.Frequency,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to set notification preference",
+			zap.Error(err),
+			zap.String("userID", userID.String()),
+			zap.String("category", string(preference.Category)),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// GetNotificationRecipientPreferences returns how the user accounts with the given email addresses want to receive a category of notification.
+// Email addresses are matched case insensitively, and ones that don't belong to a user account are not returned
+func (s *Store) GetNotificationRecipientPreferences(
+	ctx context.Context,
+	np sqlutils.NamedPreparer,
+	category models.NotificationCategory,
+	emails []models.EmailAddress,
+) ([]*models.NotificationRecipientPreference, error) {
+	lowerEmails := lo.Map(emails, func(email models.EmailAddress, _ int) string {
+		return strings.ToLower(email.String())
+	})
+
+	var preferences []*models.NotificationRecipientPreference
+	if err := namedSelect(ctx, np, &preferences, sqlqueries.NotificationPreference.GetRecipientPreferences, args{
+		"category": category,
+		"emails":   pq.Array(lowerEmails),
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get notification recipient preferences", zap.Error(err), zap.String("category", string(category)))
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// CreateNotificationDigestItem holds a notification back to be sent in a user's next daily digest
+func (s *Store) CreateNotificationDigestItem(ctx context.Context, np sqlutils.NamedPreparer, item *models.NotificationDigestItem) error {
+	if item.ID == uuid.Nil {
+		item.ID = uuid.New()
+	}
+
+	if _, err := namedExec(ctx, np, sqlqueries.NotificationPreference.CreateDigestItem, item); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to create notification digest item", zap.Error(err), zap.String("userID", item.UserID.String()))
+		return err
+	}
+
+	return nil
+}
+
+// GetPendingNotificationDigestItemsForUpdate returns every notification waiting to be sent in a digest, ordered by user and then oldest first,
+// locking them until tx is committed
+func (s *Store) GetPendingNotificationDigestItemsForUpdate(ctx context.Context, tx sqlutils.NamedPreparer) ([]*models.NotificationDigestItem, error) {
+	var items []*models.NotificationDigestItem
+	if err := namedSelect(ctx, tx, &items, sqlqueries.NotificationPreference.GetPendingDigestItemsForUpdate, args{}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get pending notification digest items", zap.Error(err))
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// MarkNotificationDigestItemsSent records that the given notifications have been sent in a digest
+func (s *Store) MarkNotificationDigestItemsSent(ctx context.Context, np sqlutils.NamedPreparer, ids []uuid.UUID) error {
+	if _, err := namedExec(ctx, np, sqlqueries.NotificationPreference.MarkDigestItemsSent, args{
+		"ids": pq.Array(ids),
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to mark notification digest items sent", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
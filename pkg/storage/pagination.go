@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"strings"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// likePatternEscaper escapes the characters with special meaning in a LIKE pattern
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns the LIKE pattern matching text that contains search, or nil if there is nothing to search for
+func containsPattern(search *string) *string {
+	if search == nil {
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(*search)
+	if trimmed == "" {
+		return nil
+	}
+
+	pattern := "%" + likePatternEscaper.Replace(trimmed) + "%"
+	return &pattern
+}
+
+// addPageCursorArgs adds the arguments used by paginated queries to start after a cursor, which are null for the first page
+func addPageCursorArgs(arguments args, after *models.PageCursor) {
+	arguments["after_sort_value"] = nil
+	arguments["after_id"] = nil
+
+	if after != nil {
+		arguments["after_sort_value"] = after.SortValue
+		arguments["after_id"] = after.ID
+	}
+}
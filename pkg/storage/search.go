@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlqueries"
+)
+
+// searchHeadlineOptions configures the snippets of search results, marking the matching words so they can be highlighted once the snippet is escaped
+var searchHeadlineOptions = fmt.Sprintf(
+	`StartSel=%s, StopSel=%s, MinWords=15, MaxWords=35, MaxFragments=2, FragmentDelimiter=" ... "`,
+	models.SearchSnippetStart,
+	models.SearchSnippetStop,
+)
+
+// Search returns up to limit of the results of the given types matching a full text search that the viewer can view, best match first,
+// starting after the given cursor. The query is parsed as a web search, so it supports quoted phrases, "or" and excluding words with "-"
+func (s *Store) Search(
+	ctx context.Context,
+	viewer models.SearchViewer,
+	query string,
+	types []models.SearchResultType,
+	limit int,
+	after *models.SearchResultCursor,
+) ([]*models.SearchResult, error) {
+	arguments := args{
+		"query":            query,
+		"types":            models.EnumArray[models.SearchResultType](types),
+		"headline_options": searchHeadlineOptions,
+		"is_grt_admin":     viewer.IsGRTAdmin,
+		"is_trb_admin":     viewer.IsTRBAdmin,
+		"is_easi_user":     viewer.IsEASiUser,
+		"user_id":          viewer.UserID,
+		"eua_user_id":      viewer.EUAUserID,
+		"after_rank":       nil,
+		"after_id":         nil,
+		"limit":            limit,
+	}
+
+	if after != nil {
+		arguments["after_rank"] = after.Rank
+		arguments["after_id"] = after.ID
+	}
+
+	var results []*models.SearchResult
+	if err := namedSelect(ctx, s.db, &results, sqlqueries.Search.Search, arguments); err != nil {
+		appcontext.ZLogger(ctx).Error("Failed to search", zap.Error(err))
+		return nil, &apperrors.QueryError{
+			Err:       err,
+			Model:     models.SearchResult{},
+			Operation: apperrors.QueryFetch,
+		}
+	}
+
+	return results, nil
+}
@@ -35,6 +35,37 @@ type DBConfig struct {
 	MaxConnections int
 }
 
+// DataSourceName builds a connection string for opening a connection outside of the Store's connection pool,
+// such as a dedicated connection to LISTEN for notifications.
+// When dbConfig.UseIAM is true, the connection string contains a newly generated IAM auth token, which is only valid for
+// establishing a connection for the next 15 minutes, so callers should build a new one each time they connect.
+func (dbConfig DBConfig) DataSourceName(ctx context.Context) (string, error) {
+	if !dbConfig.UseIAM {
+		return passwordDataSourceName(dbConfig), nil
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return iamDataSourceName(ctx, awsConfig, dbConfig)
+}
+
+// passwordDataSourceName builds a connection string that authenticates with the configured user/pass
+func passwordDataSourceName(dbConfig DBConfig) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s "+
+			"password=%s dbname=%s sslmode=%s",
+		dbConfig.Host,
+		dbConfig.Port,
+		dbConfig.Username,
+		dbConfig.Password,
+		dbConfig.Database,
+		dbConfig.SSLMode,
+	)
+}
+
 // PrepareNamed implements the NamedPreparer interface
 // Implementing the  sqlutils.NamedPreparer interface allows us to use a sqlx.Tx or a storage.Store as a parameter in our DB calls
 // (the former for when we want to implement transactions, the latter for when we don't)
@@ -84,18 +115,7 @@ func NewStore(
 		}
 	} else {
 		// Connect via normal user/pass
-		dataSourceName := fmt.Sprintf(
-			"host=%s port=%s user=%s "+
-				"password=%s dbname=%s sslmode=%s",
-			dbConfig.Host,
-			dbConfig.Port,
-			dbConfig.Username,
-			dbConfig.Password,
-			dbConfig.Database,
-			dbConfig.SSLMode,
-		)
-
-		db, err = sqlx.Connect("postgres", dataSourceName)
+		db, err = sqlx.Connect("postgres", passwordDataSourceName(dbConfig))
 		if err != nil {
 			return nil, err
 		}
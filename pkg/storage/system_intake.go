@@ -215,7 +215,16 @@ func CreateSystemIntake(ctx context.Context, np sqlutils.NamedPreparer, intake *
 		return nil, err
 	}
 
-	return FetchSystemIntakeByIDNP(ctx, np, intake.ID)
+	created, err := FetchSystemIntakeByIDNP(ctx, np, intake.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordAuditChange(ctx, np, models.AuditEntityTypeSystemIntake, models.AuditChangeActionInsert, created.ID, nil, nil, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 // UpdateSystemIntake serves as a wrapper for UpdateSystemIntakeNP, which is the actual implementation
@@ -236,6 +245,11 @@ func (s *Store) UpdateSystemIntake(ctx context.Context, intake *models.SystemInt
 // The "NP" suffix stands for "NamedPreparer", as this function was written to avoid the need to update all
 // of the existing code that uses UpdateSystemIntake to use a transactional wrapper.
 func (s *Store) UpdateSystemIntakeNP(ctx context.Context, np sqlutils.NamedPreparer, intake *models.SystemIntake) (*models.SystemIntake, error) {
+	before, err := FetchSystemIntakeByIDNP(ctx, np, intake.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	// We are explicitly not updating ID, EUAUserID and SystemIntakeID
 	const updateSystemIntakeSQL = `
 		UPDATE system_intakes
@@ -355,7 +369,57 @@ func (s *Store) UpdateSystemIntakeNP(ctx context.Context, np sqlutils.NamedPrepa
 	// the un-filtered fetch to return the saved object
 	//
 	// Using the "NP" version of the fetch method to allow the update and fetch to be part of a transaction
-	return FetchSystemIntakeByIDNP(ctx, np, intake.ID)
+	updated, err := FetchSystemIntakeByIDNP(ctx, np, intake.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordAuditChange(ctx, np, models.AuditEntityTypeSystemIntake, models.AuditChangeActionUpdate, updated.ID, nil, before, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// UpdateSystemIntakeGRBQuorumPolicy sets the quorum policy of a system intake's GRB review, returning the updated intake
+func (s *Store) UpdateSystemIntakeGRBQuorumPolicy(ctx context.Context, systemIntakeID uuid.UUID, policy models.GRBQuorumPolicy) (*models.SystemIntake, error) {
+	return sqlutils.WithTransactionRet[*models.SystemIntake](ctx, s, func(tx *sqlx.Tx) (*models.SystemIntake, error) {
+		return UpdateSystemIntakeGRBQuorumPolicyNP(ctx, tx, systemIntakeID, policy)
+	})
+}
+
+// UpdateSystemIntakeGRBQuorumPolicyNP sets the quorum policy of a system intake's GRB review, returning the updated intake
+func UpdateSystemIntakeGRBQuorumPolicyNP(ctx context.Context, np sqlutils.NamedPreparer, systemIntakeID uuid.UUID, policy models.GRBQuorumPolicy) (*models.SystemIntake, error) {
+	before, err := FetchSystemIntakeByIDNP(ctx, np, systemIntakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := namedExec(ctx, np, sqlqueries.SystemIntake.UpdateGRBQuorumPolicy, args{
+		"system_intake_id":               systemIntakeID,
+		"grb_quorum_minimum_votes":       policy.MinimumVotes,
+		"grb_quorum_voting_percentage":   policy.VotingPercentage,
+		"grb_quorum_required_roles":      policy.RequiredRoles,
+		"grb_quorum_required_roles_rule": policy.RequiredRolesRule,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to update system intake GRB quorum policy", zap.Error(err), zap.String("system_intake_id", systemIntakeID.String()))
+		return nil, &apperrors.QueryError{
+			Err:       err,
+			Model:     models.SystemIntake{},
+			Operation: apperrors.QueryUpdate,
+		}
+	}
+
+	updated, err := FetchSystemIntakeByIDNP(ctx, np, systemIntakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordAuditChange(ctx, np, models.AuditEntityTypeSystemIntake, models.AuditChangeActionUpdate, updated.ID, nil, before, updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
 const fetchSystemIntakeSQL = `
@@ -543,6 +607,47 @@ func (s *Store) FetchSystemIntakesByStateForAdmins(ctx context.Context, state mo
 	return intakes, nil
 }
 
+// FetchSystemIntakesPageForAdmins queries the DB for up to limit of the system intakes relevant to admins that match the filter,
+// in the given order, starting after the given cursor.
+// Intakes whose admin status depends on their GRB review's quorum match either of the statuses it can have,
+// so callers filtering by admin status should check the calculated status of the intakes returned
+func (s *Store) FetchSystemIntakesPageForAdmins(
+	ctx context.Context,
+	filter models.SystemIntakesFilter,
+	sort models.SystemIntakesSort,
+	limit int,
+	after *models.PageCursor,
+) ([]*models.SortedSystemIntake, error) {
+	arguments := args{
+		"state":            filter.State,
+		"admin_statuses":   models.EnumArray[models.SystemIntakeStatusAdmin](filter.AdminStatuses),
+		"admin_lead":       filter.AdminLead,
+		"lcid_statuses":    models.EnumArray[models.SystemIntakeLCIDStatus](filter.LcidStatuses),
+		"request_types":    models.EnumArray[models.SystemIntakeRequestType](filter.RequestTypes),
+		"submitted_after":  filter.SubmittedAfter,
+		"submitted_before": filter.SubmittedBefore,
+		"updated_after":    filter.UpdatedAfter,
+		"updated_before":   filter.UpdatedBefore,
+		"search_pattern":   containsPattern(filter.Search),
+		"sort_field":       sort.Field,
+		"sort_ascending":   sort.Direction == models.SortDirectionAsc,
+		"limit":            limit,
+	}
+	addPageCursorArgs(arguments, after)
+
+	var intakes []*models.SortedSystemIntake
+	if err := namedSelect(ctx, s.db, &intakes, sqlqueries.SystemIntake.GetAdminPage, arguments); err != nil {
+		appcontext.ZLogger(ctx).Error("Failed to fetch page of system intakes for admins", zap.Error(err))
+		return nil, &apperrors.QueryError{
+			Err:       err,
+			Model:     models.SystemIntake{},
+			Operation: apperrors.QueryFetch,
+		}
+	}
+
+	return intakes, nil
+}
+
 func generateLifecyclePrefix(t time.Time, loc *time.Location) string {
 	return t.In(loc).Format("06002")
 }
@@ -586,11 +691,13 @@ func (s *Store) UpdateAdminLead(ctx context.Context, id uuid.UUID, adminLead str
 			admin_lead = :admin_lead
 		WHERE system_intakes.id = :id
 	`
-	_, err := s.db.NamedExec(
-		updateSystemIntakeSQL,
-		intake,
-	)
-	return adminLead, err
+	return sqlutils.WithTransactionRet[string](ctx, s, func(tx *sqlx.Tx) (string, error) {
+		if _, err := updateSystemIntakeColumnsNP(ctx, tx, id, updateSystemIntakeSQL, intake); err != nil {
+			return "", err
+		}
+
+		return adminLead, nil
+	})
 }
 
 // UpdateReviewDates updates the admin lead for an intake
@@ -621,16 +728,9 @@ func (s *Store) UpdateReviewDates(ctx context.Context, id uuid.UUID, grbDate *ti
 			grt_date = :grt_date
 		WHERE system_intakes.id = :id
 	`
-	_, err := s.db.NamedExec(
-		updateSystemIntakeSQL,
-		intake,
-	)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return s.FetchSystemIntakeByID(ctx, intake.ID)
+	return sqlutils.WithTransactionRet[*models.SystemIntake](ctx, s, func(tx *sqlx.Tx) (*models.SystemIntake, error) {
+		return updateSystemIntakeColumnsNP(ctx, tx, id, updateSystemIntakeSQL, intake)
+	})
 }
 
 // UpdateSystemIntakeLinkedCedarSystem updates the CEDAR system ID that is linked to a system intake
@@ -653,16 +753,33 @@ func (s *Store) UpdateSystemIntakeLinkedCedarSystem(ctx context.Context, id uuid
 		WHERE system_intakes.id = :id
 	`
 
-	_, err := s.db.NamedExec(
-		updateSystemIntakeSQL,
-		intake,
-	)
+	return sqlutils.WithTransactionRet[*models.SystemIntake](ctx, s, func(tx *sqlx.Tx) (*models.SystemIntake, error) {
+		return updateSystemIntakeColumnsNP(ctx, tx, id, updateSystemIntakeSQL, intake)
+	})
+}
 
+// updateSystemIntakeColumnsNP runs an update statement that changes only some of a system intake's columns,
+// records the change in the audit history, and returns the updated intake
+func updateSystemIntakeColumnsNP(ctx context.Context, np sqlutils.NamedPreparer, id uuid.UUID, updateSQL string, arguments any) (*models.SystemIntake, error) {
+	before, err := FetchSystemIntakeByIDNP(ctx, np, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.FetchSystemIntakeByID(ctx, id)
+	if _, err := namedExec(ctx, np, updateSQL, arguments); err != nil {
+		return nil, err
+	}
+
+	after, err := FetchSystemIntakeByIDNP(ctx, np, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordAuditChange(ctx, np, models.AuditEntityTypeSystemIntake, models.AuditChangeActionUpdate, id, nil, before, after); err != nil {
+		return nil, err
+	}
+
+	return after, nil
 }
 
 // GetSystemIntakesWithLCIDs retrieves all LCIDs that are in use
@@ -706,22 +823,13 @@ func GetSystemIntakesWithGRBReviewHalfwayThrough(ctx context.Context, np sqlutil
 
 }
 
-func GetSystemIntakesWithGRBReviewPastDueNoQuorum(ctx context.Context, np sqlutils.NamedPreparer, logger *zap.Logger) ([]*models.SystemIntake, error) {
-	var intakes []*models.SystemIntake
-
-	if err := namedSelect(ctx, np, &intakes, sqlqueries.SystemIntake.GetWhereGRBPastDueNoQuorum, nil); err != nil {
-		logger.Error("Failed to fetch system intakes with GRB review past due with no quorum met", zap.Error(err))
-		return nil, err
-	}
-
-	return intakes, nil
-}
-
-func GetSystemIntakesWithGRBReviewCompleteQuorumMet(ctx context.Context, np sqlutils.NamedPreparer, logger *zap.Logger) ([]*models.SystemIntake, error) {
+// GetSystemIntakesWithGRBReviewAsyncEndDatePassed returns intakes with an async GRB review whose end date passed in the last day.
+// Whether those reviews reached quorum depends on each intake's quorum policy, so that is left to the caller to evaluate.
+func GetSystemIntakesWithGRBReviewAsyncEndDatePassed(ctx context.Context, np sqlutils.NamedPreparer, logger *zap.Logger) ([]*models.SystemIntake, error) {
 	var intakes []*models.SystemIntake
 
-	if err := namedSelect(ctx, np, &intakes, sqlqueries.SystemIntake.GetWhereReviewCompleteQuorumMet, nil); err != nil {
-		logger.Error("Failed to fetch system intakes with GRB review complete with quorum met", zap.Error(err))
+	if err := namedSelect(ctx, np, &intakes, sqlqueries.SystemIntake.GetWhereGRBAsyncEndDatePassed, nil); err != nil {
+		logger.Error("Failed to fetch system intakes with GRB review async end date passed", zap.Error(err))
 		return nil, err
 	}
 
@@ -125,12 +125,11 @@ func UpdateSystemIntakeGRBReviewType(
 	systemIntakeID uuid.UUID,
 	reviewType models.SystemIntakeGRBReviewType,
 ) (*models.UpdateSystemIntakePayload, error) {
-	updatedIntake := &models.SystemIntake{}
-
-	if err := namedGet(ctx, np, updatedIntake, sqlqueries.SystemIntakeGRBReviewType.Update, args{
+	updatedIntake, err := updateSystemIntakeColumnsNP(ctx, np, systemIntakeID, sqlqueries.SystemIntakeGRBReviewType.Update, args{
 		"system_intake_id": systemIntakeID,
 		"grb_review_type":  reviewType,
-	}); err != nil {
+	})
+	if err != nil {
 		appcontext.ZLogger(ctx).Error(
 			"error updating system intake GRB reviewer",
 			zap.String("system_intake_id", systemIntakeID.String()),
@@ -145,6 +144,8 @@ func UpdateSystemIntakeGRBReviewType(
 	}, nil
 }
 
+// SetSystemIntakeGRBReviewerReminderSent records when GRB reviewers were last reminded. The reminder time is bookkeeping rather than
+// an edit to the request, so it's written directly instead of through the audited update path.
 func SetSystemIntakeGRBReviewerReminderSent(ctx context.Context, np sqlutils.NamedPreparer, systemIntakeID uuid.UUID, sendTime time.Time) error {
 	if _, err := namedExec(ctx, np, sqlqueries.SystemIntakeGRBReviewer.SetLastReminderSentTime, args{
 		"time_sent": sendTime,
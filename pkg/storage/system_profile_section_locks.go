@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlqueries"
+)
+
+// AcquireSystemProfileSectionLock locks a system profile section for the given user for the duration of the lease.
+// If the user already holds the lock, the lease is renewed. If the lock is held by another user whose lease has not
+// yet expired, nil is returned without an error.
+func (s *Store) AcquireSystemProfileSectionLock(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	section models.SystemProfileLockableSection,
+	userID uuid.UUID,
+	lease time.Duration,
+) (*models.SystemProfileSectionLock, error) {
+	var lock models.SystemProfileSectionLock
+	err := namedGet(ctx, s.db, &lock, sqlqueries.SystemProfileSectionLocks.Acquire, args{
+		"cedar_system_id": cedarSystemID,
+		"section":         section,
+		"locked_by":       userID,
+		"lease_seconds":   lease.Seconds(),
+	})
+	if err != nil {
+		// no row is returned when the section is actively locked by someone else
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		appcontext.ZLogger(ctx).Error("failed to acquire system profile section lock",
+			zap.Error(err),
+			zap.String("cedarSystemID", cedarSystemID.String()),
+			zap.String("section", string(section)),
+		)
+		return nil, err
+	}
+
+	return &lock, nil
+}
+
+// RenewSystemProfileSectionLocks extends the lease on every unexpired lock the given user holds on a system profile
+func (s *Store) RenewSystemProfileSectionLocks(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	userID uuid.UUID,
+	lease time.Duration,
+) ([]*models.SystemProfileSectionLock, error) {
+	var locks []*models.SystemProfileSectionLock
+	if err := namedSelect(ctx, s.db, &locks, sqlqueries.SystemProfileSectionLocks.RenewByCedarSystemIDAndUserID, args{
+		"cedar_system_id": cedarSystemID,
+		"locked_by":       userID,
+		"lease_seconds":   lease.Seconds(),
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to renew system profile section locks",
+			zap.Error(err),
+			zap.String("cedarSystemID", cedarSystemID.String()),
+			zap.String("userID", userID.String()),
+		)
+		return nil, err
+	}
+
+	return locks, nil
+}
+
+// SystemProfileSectionLock returns the unexpired lock on a system profile section, or nil if the section is not locked
+func (s *Store) SystemProfileSectionLock(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	section models.SystemProfileLockableSection,
+) (*models.SystemProfileSectionLock, error) {
+	var lock models.SystemProfileSectionLock
+	err := namedGet(ctx, s.db, &lock, sqlqueries.SystemProfileSectionLocks.GetByCedarSystemIDAndSection, args{
+		"cedar_system_id": cedarSystemID,
+		"section":         section,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		appcontext.ZLogger(ctx).Error("failed to fetch system profile section lock",
+			zap.Error(err),
+			zap.String("cedarSystemID", cedarSystemID.String()),
+			zap.String("section", string(section)),
+		)
+		return nil, err
+	}
+
+	return &lock, nil
+}
+
+// SystemProfileSectionLocksByCedarSystemID returns all unexpired locks on a system profile
+func (s *Store) SystemProfileSectionLocksByCedarSystemID(ctx context.Context, cedarSystemID uuid.UUID) ([]*models.SystemProfileSectionLock, error) {
+	var locks []*models.SystemProfileSectionLock
+	if err := namedSelect(ctx, s.db, &locks, sqlqueries.SystemProfileSectionLocks.GetByCedarSystemID, args{
+		"cedar_system_id": cedarSystemID,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to fetch system profile section locks",
+			zap.Error(err),
+			zap.String("cedarSystemID", cedarSystemID.String()),
+		)
+		return nil, err
+	}
+
+	return locks, nil
+}
+
+// DeleteSystemProfileSectionLock removes the lock on a system profile section regardless of who holds it.
+// It returns the removed lock, or nil if the section was not locked.
+func (s *Store) DeleteSystemProfileSectionLock(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	section models.SystemProfileLockableSection,
+) (*models.SystemProfileSectionLock, error) {
+	var lock models.SystemProfileSectionLock
+	err := namedGet(ctx, s.db, &lock, sqlqueries.SystemProfileSectionLocks.Delete, args{
+		"cedar_system_id": cedarSystemID,
+		"section":         section,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		appcontext.ZLogger(ctx).Error("failed to delete system profile section lock",
+			zap.Error(err),
+			zap.String("cedarSystemID", cedarSystemID.String()),
+			zap.String("section", string(section)),
+		)
+		return nil, err
+	}
+
+	return &lock, nil
+}
+
+// DeleteSystemProfileSectionLockByOwner removes the lock on a system profile section only if the given user holds it and its lease
+// hasn't run out. It returns the removed lock, or nil if the section wasn't locked by the user.
+func (s *Store) DeleteSystemProfileSectionLockByOwner(
+	ctx context.Context,
+	cedarSystemID uuid.UUID,
+	section models.SystemProfileLockableSection,
+	userID uuid.UUID,
+) (*models.SystemProfileSectionLock, error) {
+	var lock models.SystemProfileSectionLock
+	err := namedGet(ctx, s.db, &lock, sqlqueries.SystemProfileSectionLocks.DeleteByOwner, args{
+		"cedar_system_id": cedarSystemID,
+		"section":         section,
+		"locked_by":       userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		appcontext.ZLogger(ctx).Error("failed to delete system profile section lock",
+			zap.Error(err),
+			zap.String("cedarSystemID", cedarSystemID.String()),
+			zap.String("section", string(section)),
+			zap.String("userID", userID.String()),
+		)
+		return nil, err
+	}
+
+	return &lock, nil
+}
+
+// DeleteSystemProfileSectionLocksByCedarSystemID removes every unexpired lock on a system profile and returns the removed locks
+func (s *Store) DeleteSystemProfileSectionLocksByCedarSystemID(ctx context.Context, cedarSystemID uuid.UUID) ([]*models.SystemProfileSectionLock, error) {
+	var locks []*models.SystemProfileSectionLock
+	if err := namedSelect(ctx, s.db, &locks, sqlqueries.SystemProfileSectionLocks.DeleteByCedarSystemID, args{
+		"cedar_system_id": cedarSystemID,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to delete system profile section locks",
+			zap.Error(err),
+			zap.String("cedarSystemID", cedarSystemID.String()),
+		)
+		return nil, err
+	}
+
+	return locks, nil
+}
+
+// DeleteExpiredSystemProfileSectionLocks removes the locks on a system profile whose lease has run out and returns the removed locks
+func (s *Store) DeleteExpiredSystemProfileSectionLocks(ctx context.Context, cedarSystemID uuid.UUID) ([]*models.SystemProfileSectionLock, error) {
+	var locks []*models.SystemProfileSectionLock
+	if err := namedSelect(ctx, s.db, &locks, sqlqueries.SystemProfileSectionLocks.DeleteExpiredByCedarSystemID, args{
+		"cedar_system_id": cedarSystemID,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to delete expired system profile section locks",
+			zap.Error(err),
+			zap.String("cedarSystemID", cedarSystemID.String()),
+		)
+		return nil, err
+	}
+
+	return locks, nil
+}
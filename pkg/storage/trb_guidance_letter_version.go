@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlqueries"
+)
+
+// CreateTRBGuidanceLetterVersion saves a snapshot of a TRB guidance letter, numbering it after the letter's previous versions
+func (s *Store) CreateTRBGuidanceLetterVersion(ctx context.Context, version *models.TRBGuidanceLetterVersion) (*models.TRBGuidanceLetterVersion, error) {
+	if version.ID == uuid.Nil {
+		version.ID = uuid.New()
+	}
+
+	var created models.TRBGuidanceLetterVersion
+	if err := namedGet(ctx, s.db, &created, sqlqueries.TRBGuidanceLetterVersion.Create, version); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to create TRB guidance letter version", zap.Error(err), zap.String("trbGuidanceLetterID", version.TRBGuidanceLetterID.String()))
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// GetTRBGuidanceLetterVersionByID returns a single version of a TRB guidance letter
+func (s *Store) GetTRBGuidanceLetterVersionByID(ctx context.Context, id uuid.UUID) (*models.TRBGuidanceLetterVersion, error) {
+	var version models.TRBGuidanceLetterVersion
+	if err := namedGet(ctx, s.db, &version, sqlqueries.TRBGuidanceLetterVersion.GetByID, args{
+		"id": id,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get TRB guidance letter version", zap.Error(err), zap.String("id", id.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.ResourceNotFoundError{Err: err, Resource: models.TRBGuidanceLetterVersion{}}
+		}
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// GetTRBGuidanceLetterVersionsByTRBRequestID returns the versions of a TRB request's guidance letter, newest first
+func (s *Store) GetTRBGuidanceLetterVersionsByTRBRequestID(ctx context.Context, trbRequestID uuid.UUID) ([]*models.TRBGuidanceLetterVersion, error) {
+	versions := []*models.TRBGuidanceLetterVersion{}
+	if err := namedSelect(ctx, s.db, &versions, sqlqueries.TRBGuidanceLetterVersion.GetByTRBRequestID, args{
+		"trb_request_id": trbRequestID,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get TRB guidance letter versions", zap.Error(err), zap.String("trbRequestID", trbRequestID.String()))
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// GetLatestSentTRBGuidanceLetterVersion returns the version of a TRB guidance letter that was most recently sent or amended,
+// or nil if the letter hasn't been sent
+func (s *Store) GetLatestSentTRBGuidanceLetterVersion(ctx context.Context, trbGuidanceLetterID uuid.UUID) (*models.TRBGuidanceLetterVersion, error) {
+	var version models.TRBGuidanceLetterVersion
+	if err := namedGet(ctx, s.db, &version, sqlqueries.TRBGuidanceLetterVersion.GetLatestSentByLetterID, args{
+		"trb_guidance_letter_id": trbGuidanceLetterID,
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		appcontext.ZLogger(ctx).Error("failed to get latest sent TRB guidance letter version", zap.Error(err), zap.String("trbGuidanceLetterID", trbGuidanceLetterID.String()))
+		return nil, err
+	}
+
+	return &version, nil
+}
@@ -156,6 +156,44 @@ func (s *Store) GetTRBRequests(ctx context.Context, archived bool) ([]*models.TR
 	return trbRequests, err
 }
 
+// GetTRBRequestsPage returns up to limit of the TRB requests that match the filter, in the given order, starting after the given cursor.
+// The statuses filter is not applied, as TRB request statuses are calculated outside of the DB
+func (s *Store) GetTRBRequestsPage(
+	ctx context.Context,
+	filter models.TRBRequestsFilter,
+	sort models.TRBRequestsSort,
+	limit int,
+	after *models.PageCursor,
+) ([]*models.SortedTRBRequest, error) {
+	arguments := args{
+		"archived":         filter.Archived,
+		"state":            filter.State,
+		"trb_lead":         filter.TrbLead,
+		"request_types":    models.EnumArray[models.TRBRequestType](filter.RequestTypes),
+		"submitted_after":  filter.SubmittedAfter,
+		"submitted_before": filter.SubmittedBefore,
+		"updated_after":    filter.UpdatedAfter,
+		"updated_before":   filter.UpdatedBefore,
+		"search_pattern":   containsPattern(filter.Search),
+		"sort_field":       sort.Field,
+		"sort_ascending":   sort.Direction == models.SortDirectionAsc,
+		"limit":            limit,
+	}
+	addPageCursorArgs(arguments, after)
+
+	var trbRequests []*models.SortedTRBRequest
+	if err := namedSelect(ctx, s.db, &trbRequests, sqlqueries.TRBRequest.GetAdminPage, arguments); err != nil {
+		appcontext.ZLogger(ctx).Error("Failed to fetch page of trb requests", zap.Error(err))
+		return nil, &apperrors.QueryError{
+			Err:       err,
+			Model:     models.TRBRequest{},
+			Operation: apperrors.QueryFetch,
+		}
+	}
+
+	return trbRequests, nil
+}
+
 // GetMyTRBRequests returns the collection of TRB requests that belong to the user in the context
 func (s *Store) GetMyTRBRequests(ctx context.Context, archived bool) ([]*models.TRBRequest, error) {
 	trbRequests := []*models.TRBRequest{}
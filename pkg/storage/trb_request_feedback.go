@@ -76,6 +76,11 @@ func (s *Store) CreateTRBRequestFeedback(ctx context.Context, feedback *models.T
 
 		// If the feedback requests edits, update the form status to "in progress"
 		if formToUpdate != nil {
+			formBefore, formErr := getTRBRequestFormByTRBRequestIDNP(ctx, tx, formToUpdate.TRBRequestID)
+			if formErr != nil {
+				return nil, formErr
+			}
+
 			formStmt, formErr := tx.PrepareNamed(`
 			UPDATE trb_request_forms
 			SET
@@ -103,6 +108,10 @@ func (s *Store) CreateTRBRequestFeedback(ctx context.Context, feedback *models.T
 				)
 				return nil, formErr
 			}
+
+			if formErr := recordTRBRequestFormChange(ctx, tx, models.AuditChangeActionUpdate, formBefore, &updatedForm); formErr != nil {
+				return nil, formErr
+			}
 		}
 
 		return &createdFeedback, nil
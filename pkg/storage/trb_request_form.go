@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 
 	"go.uber.org/zap"
@@ -42,13 +43,29 @@ func (s *Store) CreateTRBRequestForm(ctx context.Context, np sqlutils.NamedPrepa
 		appcontext.ZLogger(ctx).Error("Failed to create TRB request form with error %s", zap.Error(err))
 		return nil, err
 	}
+
+	if err := recordTRBRequestFormChange(ctx, np, models.AuditChangeActionInsert, nil, &created); err != nil {
+		return nil, err
+	}
+
 	return &created, err
 
 }
 
 // UpdateTRBRequestForm updates a TRB request form record in the database
 func (s *Store) UpdateTRBRequestForm(ctx context.Context, form *models.TRBRequestForm) (*models.TRBRequestForm, error) {
-	stmt, err := s.db.PrepareNamed(`
+	return sqlutils.WithTransactionRet[*models.TRBRequestForm](ctx, s, func(tx *sqlx.Tx) (*models.TRBRequestForm, error) {
+		return updateTRBRequestFormNP(ctx, tx, form)
+	})
+}
+
+func updateTRBRequestFormNP(ctx context.Context, np sqlutils.NamedPreparer, form *models.TRBRequestForm) (*models.TRBRequestForm, error) {
+	before, err := getTRBRequestFormByTRBRequestIDNP(ctx, np, form.TRBRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := np.PrepareNamed(`
 		UPDATE trb_request_forms
 		SET
 			status = :status,
@@ -101,13 +118,21 @@ func (s *Store) UpdateTRBRequestForm(ctx context.Context, form *models.TRBReques
 		}
 	}
 
+	if err := recordTRBRequestFormChange(ctx, np, models.AuditChangeActionUpdate, before, &updated); err != nil {
+		return nil, err
+	}
+
 	return &updated, err
 }
 
 // GetTRBRequestFormByTRBRequestID queries the DB for the TRB request form record matching the given TRB request ID
 func (s *Store) GetTRBRequestFormByTRBRequestID(ctx context.Context, trbRequestID uuid.UUID) (*models.TRBRequestForm, error) {
+	return getTRBRequestFormByTRBRequestIDNP(ctx, s.db, trbRequestID)
+}
+
+func getTRBRequestFormByTRBRequestIDNP(ctx context.Context, np sqlutils.NamedPreparer, trbRequestID uuid.UUID) (*models.TRBRequestForm, error) {
 	var form models.TRBRequestForm
-	err := namedGet(ctx, s.db, &form, sqlqueries.TRBRequestForm.GetByID, args{
+	err := namedGet(ctx, np, &form, sqlqueries.TRBRequestForm.GetByID, args{
 		"trb_request_id": trbRequestID,
 	})
 	if err != nil {
@@ -184,7 +209,13 @@ func (s *Store) GetTRBFundingSourcesByRequestIDs(ctx context.Context, trbRequest
 
 // DeleteTRBRequestForm deletes an existing TRB request form record in the database
 func (s *Store) DeleteTRBRequestForm(ctx context.Context, trbRequestID uuid.UUID) (*models.TRBRequestForm, error) {
-	stmt, err := s.db.PrepareNamed(`
+	return sqlutils.WithTransactionRet[*models.TRBRequestForm](ctx, s, func(tx *sqlx.Tx) (*models.TRBRequestForm, error) {
+		return deleteTRBRequestFormNP(ctx, tx, trbRequestID)
+	})
+}
+
+func deleteTRBRequestFormNP(ctx context.Context, np sqlutils.NamedPreparer, trbRequestID uuid.UUID) (*models.TRBRequestForm, error) {
+	stmt, err := np.PrepareNamed(`
 		DELETE FROM trb_request_forms
 		WHERE trb_request_id = :trb_request_id
 		RETURNING *;`)
@@ -198,7 +229,7 @@ func (s *Store) DeleteTRBRequestForm(ctx context.Context, trbRequestID uuid.UUID
 	defer stmt.Close()
 
 	toDelete := models.TRBRequestForm{}
-	toDelete.ID = trbRequestID
+	toDelete.TRBRequestID = trbRequestID
 	deleted := models.TRBRequestForm{}
 
 	err = stmt.Get(&deleted, &toDelete)
@@ -214,5 +245,19 @@ func (s *Store) DeleteTRBRequestForm(ctx context.Context, trbRequestID uuid.UUID
 		}
 	}
 
+	if err := recordTRBRequestFormChange(ctx, np, models.AuditChangeActionDelete, &deleted, nil); err != nil {
+		return nil, err
+	}
+
 	return &deleted, err
 }
+
+// recordTRBRequestFormChange records a change to a TRB request form in the audit history, under its TRB request
+func recordTRBRequestFormChange(ctx context.Context, np sqlutils.NamedPreparer, action models.AuditChangeAction, before *models.TRBRequestForm, after *models.TRBRequestForm) error {
+	form := after
+	if form == nil {
+		form = before
+	}
+
+	return recordAuditChange(ctx, np, models.AuditEntityTypeTrbRequestForm, action, form.ID, &form.TRBRequestID, before, after)
+}
@@ -10,6 +10,15 @@ import (
 // NOTE: we DO NOT truncate the `user_account` table - it would remove the default users, which is behavior we do not want
 func (s *Store) TruncateAllTablesDANGEROUS(logger *zap.Logger) error {
 	tables := `
+	audit_changes,
+	email_delivery_events,
+	email_outbox,
+	webhook_deliveries,
+	webhook_subscriptions,
+	notifications,
+	calendar_invites,
+	notification_digest_items,
+	user_notification_preferences,
 	cedar_system_bookmarks,
 	accessibility_request_status_records,
 	accessibility_request_notes,
@@ -31,6 +40,7 @@ func (s *Store) TruncateAllTablesDANGEROUS(logger *zap.Logger) error {
 	system_intake_internal_grb_review_discussion_posts,
 	system_intake_systems,
 	system_intakes,
+	system_profile_section_locks,
 	trb_admin_notes_trb_request_documents_links,
 	trb_admin_notes_trb_admin_note_insights_links,
 	trb_lead_options,
@@ -39,6 +49,7 @@ func (s *Store) TruncateAllTablesDANGEROUS(logger *zap.Logger) error {
 	trb_request_forms,
 	trb_request_attendees,
 	trb_request_feedback,
+	trb_guidance_letter_versions,
 	trb_guidance_letter_insights,
 	trb_admin_notes,
 	trb_guidance_letters,
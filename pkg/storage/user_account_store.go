@@ -73,6 +73,14 @@ func UserAccountGetByUsernames(ctx context.Context, np sqlutils.NamedPreparer, u
 	return users, nil
 }
 
+// UserAccountsGetByEmail gets the user accounts with a given email address, ignoring case
+func (s *Store) UserAccountsGetByEmail(ctx context.Context, np sqlutils.NamedPreparer, email string) ([]*authentication.UserAccount, error) {
+	var accounts []*authentication.UserAccount
+	return accounts, namedSelect(ctx, np, &accounts, sqlqueries.UserAccount.GetByEmail, args{
+		"email": email,
+	})
+}
+
 // UserAccountGetByID gets a User account from the database by its internal id.
 func (s *Store) UserAccountGetByID(ctx context.Context, np sqlutils.NamedPreparer, id uuid.UUID) (*authentication.UserAccount, error) {
 	user := &authentication.UserAccount{}
@@ -0,0 +1,234 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/apperrors"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlqueries"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// CreateWebhookSubscription creates a webhook subscription
+func (s *Store) CreateWebhookSubscription(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	if subscription.ID == uuid.Nil {
+		subscription.ID = uuid.New()
+	}
+
+	var created models.WebhookSubscription
+	if err := namedGet(ctx, s.db, &created, sqlqueries.Webhook.CreateSubscription, subscription); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to create webhook subscription", zap.Error(err), zap.String("url", subscription.URL))
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// UpdateWebhookSubscription updates a webhook subscription's name, URL, event types, and whether it's active
+func (s *Store) UpdateWebhookSubscription(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	var updated models.WebhookSubscription
+	if err := namedGet(ctx, s.db, &updated, sqlqueries.Webhook.UpdateSubscription, subscription); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to update webhook subscription", zap.Error(err), zap.String("id", subscription.ID.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.ResourceNotFoundError{Err: err, Resource: models.WebhookSubscription{}}
+		}
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// UpdateWebhookSubscriptionSecret replaces the secret a webhook subscription's requests are signed with
+func (s *Store) UpdateWebhookSubscriptionSecret(ctx context.Context, id uuid.UUID, secret string, modifiedBy uuid.UUID) (*models.WebhookSubscription, error) {
+	var updated models.WebhookSubscription
+	if err := namedGet(ctx, s.db, &updated, sqlqueries.Webhook.UpdateSubscriptionSecret, args{
+		"id":          id,
+		"secret":      secret,
+		"modified_by": modifiedBy,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to update webhook subscription secret", zap.Error(err), zap.String("id", id.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.ResourceNotFoundError{Err: err, Resource: models.WebhookSubscription{}}
+		}
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription and its delivery log, returning the deleted subscription
+func (s *Store) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	var deleted models.WebhookSubscription
+	if err := namedGet(ctx, s.db, &deleted, sqlqueries.Webhook.DeleteSubscription, args{
+		"id": id,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to delete webhook subscription", zap.Error(err), zap.String("id", id.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.ResourceNotFoundError{Err: err, Resource: models.WebhookSubscription{}}
+		}
+		return nil, err
+	}
+
+	return &deleted, nil
+}
+
+// GetWebhookSubscriptionByID returns a single webhook subscription
+func (s *Store) GetWebhookSubscriptionByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	if err := namedGet(ctx, s.db, &subscription, sqlqueries.Webhook.GetSubscriptionByID, args{
+		"id": id,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get webhook subscription", zap.Error(err), zap.String("id", id.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.ResourceNotFoundError{Err: err, Resource: models.WebhookSubscription{}}
+		}
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// GetWebhookSubscriptions returns all webhook subscriptions, ordered by name
+func (s *Store) GetWebhookSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	if err := namedSelect(ctx, s.db, &subscriptions, sqlqueries.Webhook.GetSubscriptions, args{}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get webhook subscriptions", zap.Error(err))
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// CreateWebhookDeliveriesForEvent queues an event for delivery to every active subscription subscribed to its type,
+// returning the queued deliveries. It should be called with the same NamedPreparer as the change that caused the event,
+// if there is one, so the event is only sent if that change is committed
+func (s *Store) CreateWebhookDeliveriesForEvent(
+	ctx context.Context,
+	np sqlutils.NamedPreparer,
+	eventID uuid.UUID,
+	eventType models.WebhookEventType,
+	payload string,
+	createdBy *uuid.UUID,
+) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	if err := namedSelect(ctx, np, &deliveries, sqlqueries.Webhook.CreateDeliveriesForEvent, args{
+		"event_id":   eventID,
+		"event_type": eventType,
+		"payload":    payload,
+		"created_by": createdBy,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to queue webhook event", zap.Error(err), zap.String("eventType", string(eventType)))
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDueWebhookDelivery claims the next pending delivery to an active subscription whose next attempt is due, with the URL and secret
+// it's sent with, so no other dispatcher sends it for the length of lease. It returns nil if no delivery is due. The claim is released
+// by recording the attempt with UpdateWebhookDelivery
+func (s *Store) ClaimDueWebhookDelivery(ctx context.Context, lease time.Duration) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := namedGet(ctx, s.db, &delivery, sqlqueries.Webhook.ClaimDueDelivery, args{
+		"claim_id":      uuid.New(),
+		"lease_seconds": lease.Seconds(),
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		appcontext.ZLogger(ctx).Error("failed to claim due webhook delivery", zap.Error(err))
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// UpdateWebhookDelivery saves the result of an attempt to deliver an event to a webhook subscriber, releasing the claim on it.
+// If the delivery was claimed, the claim must still be held; if it has since been claimed again, the result isn't saved and an error is returned
+func (s *Store) UpdateWebhookDelivery(ctx context.Context, np sqlutils.NamedPreparer, delivery *models.WebhookDelivery) error {
+	result, err := namedExec(ctx, np, sqlqueries.Webhook.UpdateDelivery, delivery)
+	if err != nil {
+		appcontext.ZLogger(ctx).Error("failed to update webhook delivery", zap.Error(err), zap.String("id", delivery.ID.String()))
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return fmt.Errorf("webhook delivery %s is no longer claimed by this attempt", delivery.ID)
+	}
+
+	delivery.ClaimID = nil
+	return nil
+}
+
+// GetWebhookDeliveryByID returns a single webhook delivery
+func (s *Store) GetWebhookDeliveryByID(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := namedGet(ctx, s.db, &delivery, sqlqueries.Webhook.GetDeliveryByID, args{
+		"id": id,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get webhook delivery", zap.Error(err), zap.String("id", id.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.ResourceNotFoundError{Err: err, Resource: models.WebhookDelivery{}}
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// GetWebhookDeliveries returns up to limit webhook deliveries of the given event types, optionally only those to one subscription or with
+// the given status, newest first. If after is set, only the deliveries after that position in the delivery log are returned
+func (s *Store) GetWebhookDeliveries(
+	ctx context.Context,
+	eventTypes []models.WebhookEventType,
+	subscriptionID *uuid.UUID,
+	status *models.WebhookDeliveryStatus,
+	limit int,
+	after *models.PageCursor,
+) ([]*models.WebhookDelivery, error) {
+	arguments := args{
+		"event_types":     models.EnumArray[models.WebhookEventType](eventTypes),
+		"subscription_id": subscriptionID,
+		"status":          status,
+		"limit":           limit,
+	}
+	addPageCursorArgs(arguments, after)
+
+	var deliveries []*models.WebhookDelivery
+	if err := namedSelect(ctx, s.db, &deliveries, sqlqueries.Webhook.GetDeliveries, arguments); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to get webhook deliveries", zap.Error(err))
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// RedeliverWebhookDelivery moves a delivery that hasn't succeeded back to pending, with a fresh set of attempts, so the
+// dispatcher sends it again right away. Deliveries that have already succeeded, or are being sent, are not changed, and a ResourceNotFoundError is returned
+func (s *Store) RedeliverWebhookDelivery(ctx context.Context, id uuid.UUID, modifiedBy *uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := namedGet(ctx, s.db, &delivery, sqlqueries.Webhook.Redeliver, args{
+		"id":          id,
+		"modified_by": modifiedBy,
+	}); err != nil {
+		appcontext.ZLogger(ctx).Error("failed to redeliver webhook delivery", zap.Error(err), zap.String("id", id.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &apperrors.ResourceNotFoundError{Err: err, Resource: models.WebhookDelivery{}}
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
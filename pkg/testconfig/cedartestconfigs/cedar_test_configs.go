@@ -8,7 +8,7 @@ import (
 )
 
 func GetCedarMockClient(ctx context.Context) *cedarcore.Client {
-	return cedarcore.NewClient(ctx, "", "", "", true)
+	return cedarcore.NewClient(ctx, "", "", "", true, cedarcore.TransportConfig{}, cedarcore.CacheConfig{})
 }
 
 func StubGetCedarSystems(ctx context.Context) ([]*models.CedarSystem, error) {
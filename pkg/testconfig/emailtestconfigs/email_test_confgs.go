@@ -61,3 +61,8 @@ func (s *MockSender) Clear() {
 	s.emailWasSent = false
 	s.sentEmails = []email.Email{}
 }
+
+// SentEmails returns every email sent since the sender was last cleared
+func (s *MockSender) SentEmails() []email.Email {
+	return s.sentEmails
+}
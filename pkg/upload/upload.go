@@ -6,9 +6,11 @@ import (
 	"io"
 	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -172,3 +174,53 @@ func (c S3Client) UploadFile(ctx context.Context, key string, body io.ReadSeeker
 
 	return err
 }
+
+// ListKeys returns the keys of the files in the configured bucket that start with prefix, oldest first
+func (c S3Client) ListKeys(ctx context.Context, prefix string) ([]string, error) {
+	var objects []types.Object
+
+	paginator := s3.NewListObjectsV2Paginator(c.client, &s3.ListObjectsV2Input{
+		Bucket: &c.config.Bucket,
+		Prefix: &prefix,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, page.Contents...)
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		return aws.ToTime(objects[i].LastModified).Before(aws.ToTime(objects[j].LastModified))
+	})
+
+	keys := make([]string, 0, len(objects))
+	for _, object := range objects {
+		keys = append(keys, aws.ToString(object.Key))
+	}
+	return keys, nil
+}
+
+// DownloadFile returns the contents of a file in the configured bucket. The caller must close it
+func (c S3Client) DownloadFile(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &c.config.Bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
+// DeleteFile deletes a file from the configured bucket
+func (c S3Client) DeleteFile(ctx context.Context, key string) error {
+	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: &c.config.Bucket,
+		Key:    &key,
+	})
+
+	return err
+}
@@ -0,0 +1,139 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cms-enterprise/easi-app/pkg/models"
+)
+
+// These are the headers sent with every request to a webhook subscriber
+const (
+	EventTypeHeader = "X-EASi-Event"
+	EventIDHeader   = "X-EASi-Event-ID"
+	DeliveryHeader  = "X-EASi-Delivery"
+	TimestampHeader = "X-EASi-Timestamp"
+	SignatureHeader = "X-EASi-Signature"
+)
+
+const (
+	// signaturePrefix names the algorithm used to sign requests, so it can be changed without breaking subscribers
+	signaturePrefix = "sha256="
+
+	deliveryTimeout = 10 * time.Second
+
+	// maxErrorResponseSize limits how much of an unsuccessful response is kept in the delivery log
+	maxErrorResponseSize = 512
+)
+
+// Sign returns the signature sent in the X-EASi-Signature header of a request to a webhook subscriber, which is the HMAC-SHA256 of
+// the request's X-EASi-Timestamp header, a period, and its body, keyed with the subscription's secret. Subscribers should compute the
+// same signature and compare it to the header, and reject requests with old timestamps so captured requests can't be replayed
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateSecret returns a new random secret to sign a subscription's requests with
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("problem generating webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// ValidateSubscriptionURL checks that a subscription's URL can be sent events. Events are only sent over HTTPS;
+// allowLocalHTTP also accepts plain HTTP to the local machine, where subscribers are run in local development and tests
+func ValidateSubscriptionURL(rawURL string, allowLocalHTTP bool) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || !parsed.IsAbs() || parsed.Hostname() == "" {
+		return errors.New("webhook URL must be an absolute URL")
+	}
+	if parsed.User != nil {
+		return errors.New("webhook URL must not contain credentials")
+	}
+
+	switch parsed.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := parsed.Hostname()
+		if allowLocalHTTP && (host == "localhost" || net.ParseIP(host).IsLoopback()) {
+			return nil
+		}
+	}
+	return errors.New("webhook URL must use https")
+}
+
+// Client sends events to webhook subscribers
+type Client struct {
+	httpClient *http.Client
+}
+
+// NewClient returns a client for sending events to webhook subscribers
+func NewClient() *Client {
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: deliveryTimeout,
+			// redirects aren't followed, so events are only sent to the URL registered for the subscription
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Deliver makes one attempt to send a delivery's event to its subscriber, signed with the subscription's secret.
+// Any 2xx response is a success. It returns the status code the subscriber responded with, if it responded
+func (c *Client) Deliver(ctx context.Context, delivery *models.WebhookDelivery, now time.Time) (*int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.SubscriptionURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("problem creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EASi-Webhooks")
+	req.Header.Set(EventTypeHeader, string(delivery.EventType))
+	req.Header.Set(EventIDHeader, delivery.EventID.String())
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(delivery.SubscriptionSecret, timestamp, body))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("problem sending webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	status := resp.StatusCode
+	if status >= 200 && status < 300 {
+		return &status, nil
+	}
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorResponseSize))
+	// the response is kept as text, so anything that isn't is dropped
+	message := strings.ReplaceAll(strings.ToValidUTF8(string(responseBody), ""), "\x00", "")
+	if message = strings.TrimSpace(message); message != "" {
+		return &status, fmt.Errorf("subscriber responded with %s: %s", resp.Status, message)
+	}
+	return &status, fmt.Errorf("subscriber responded with %s", resp.Status)
+}
@@ -0,0 +1,130 @@
+// Package webhooks sends governance lifecycle events to the downstream systems subscribed to them, as signed HTTP requests
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// Event is the body of every request sent to a webhook subscriber. ID is the same for every subscriber sent the event,
+// and for every attempt to send it, so subscribers can ignore events they've already received
+type Event struct {
+	ID         uuid.UUID               `json:"id"`
+	Type       models.WebhookEventType `json:"type"`
+	OccurredAt time.Time               `json:"occurredAt"`
+	Data       any                     `json:"data"`
+}
+
+// LCIDEventData is the data sent with LCID_ISSUED, LCID_EXPIRED, and LCID_RETIRED events
+type LCIDEventData struct {
+	SystemIntakeID uuid.UUID  `json:"systemIntakeId"`
+	ProjectName    string     `json:"projectName"`
+	LCID           string     `json:"lcid"`
+	IssuedAt       *time.Time `json:"issuedAt"`
+	ExpiresAt      *time.Time `json:"expiresAt"`
+	RetiresAt      *time.Time `json:"retiresAt"`
+}
+
+// NewLCIDEventData returns the data sent with an LCID event for a system intake, after the LCID has been updated
+func NewLCIDEventData(intake *models.SystemIntake) LCIDEventData {
+	return LCIDEventData{
+		SystemIntakeID: intake.ID,
+		ProjectName:    intake.ProjectName.ValueOrZero(),
+		LCID:           intake.LifecycleID.ValueOrZero(),
+		IssuedAt:       intake.LifecycleIssuedAt,
+		ExpiresAt:      intake.LifecycleExpiresAt,
+		RetiresAt:      intake.LifecycleRetiresAt,
+	}
+}
+
+// GRBReviewEndedEventData is the data sent with GRB_REVIEW_ENDED events
+type GRBReviewEndedEventData struct {
+	SystemIntakeID     uuid.UUID  `json:"systemIntakeId"`
+	ProjectName        string     `json:"projectName"`
+	GRBReviewStartedAt *time.Time `json:"grbReviewStartedAt"`
+	EndedAt            time.Time  `json:"endedAt"`
+	// EndedEarly is true when a governance admin ended the review before its end date
+	EndedEarly bool `json:"endedEarly"`
+}
+
+// NewGRBReviewEndedEventData returns the data sent with a GRB_REVIEW_ENDED event for a system intake
+func NewGRBReviewEndedEventData(intake *models.SystemIntake, endedAt time.Time, endedEarly bool) GRBReviewEndedEventData {
+	return GRBReviewEndedEventData{
+		SystemIntakeID:     intake.ID,
+		ProjectName:        intake.ProjectName.ValueOrZero(),
+		GRBReviewStartedAt: intake.GRBReviewStartedAt,
+		EndedAt:            endedAt,
+		EndedEarly:         endedEarly,
+	}
+}
+
+// TRBGuidanceLetterSentEventData is the data sent with TRB_GUIDANCE_LETTER_SENT events
+type TRBGuidanceLetterSentEventData struct {
+	TRBRequestID          uuid.UUID  `json:"trbRequestId"`
+	RequestName           string     `json:"requestName"`
+	GuidanceLetterID      uuid.UUID  `json:"guidanceLetterId"`
+	IsFollowupRecommended bool       `json:"isFollowupRecommended"`
+	SentAt                *time.Time `json:"sentAt"`
+}
+
+// NewTRBGuidanceLetterSentEventData returns the data sent with a TRB_GUIDANCE_LETTER_SENT event, after the letter has been sent
+func NewTRBGuidanceLetterSentEventData(request *models.TRBRequest, letter *models.TRBGuidanceLetter) TRBGuidanceLetterSentEventData {
+	data := TRBGuidanceLetterSentEventData{
+		TRBRequestID:     request.ID,
+		RequestName:      request.GetName(),
+		GuidanceLetterID: letter.ID,
+		SentAt:           letter.DateSent,
+	}
+	if letter.IsFollowupRecommended != nil {
+		data.IsFollowupRecommended = *letter.IsFollowupRecommended
+	}
+	return data
+}
+
+// eventStore is the storage events are queued for delivery in
+type eventStore interface {
+	CreateWebhookDeliveriesForEvent(
+		ctx context.Context,
+		np sqlutils.NamedPreparer,
+		eventID uuid.UUID,
+		eventType models.WebhookEventType,
+		payload string,
+		createdBy *uuid.UUID,
+	) ([]*models.WebhookDelivery, error)
+}
+
+// Emit queues an event for delivery to every active subscription subscribed to its type, attributed to the principal in ctx.
+// The webhook dispatcher in the scheduler package sends the event, retrying deliveries that fail.
+// If np is a transaction, the event is only sent if the transaction is committed
+func Emit(ctx context.Context, store eventStore, np sqlutils.NamedPreparer, eventType models.WebhookEventType, data any) (*Event, error) {
+	event := &Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("problem encoding %s webhook event: %w", eventType, err)
+	}
+
+	// events emitted without a signed in user, such as by scheduled jobs, are queued without a creator
+	var createdBy *uuid.UUID
+	if account := appcontext.Principal(ctx).Account(); account != nil && account.ID != uuid.Nil {
+		createdBy = &account.ID
+	}
+
+	if _, err := store.CreateWebhookDeliveriesForEvent(ctx, np, event.ID, eventType, string(payload), createdBy); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
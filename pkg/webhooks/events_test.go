@@ -0,0 +1,94 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cms-enterprise/easi-app/pkg/appcontext"
+	"github.com/cms-enterprise/easi-app/pkg/authentication"
+	"github.com/cms-enterprise/easi-app/pkg/models"
+	"github.com/cms-enterprise/easi-app/pkg/sqlutils"
+)
+
+// queuedEvent is an event queued with a memoryEventStore
+type queuedEvent struct {
+	eventID   uuid.UUID
+	eventType models.WebhookEventType
+	payload   string
+	createdBy *uuid.UUID
+}
+
+// memoryEventStore keeps the events queued with it
+type memoryEventStore struct {
+	events []queuedEvent
+}
+
+func (s *memoryEventStore) CreateWebhookDeliveriesForEvent(
+	ctx context.Context,
+	np sqlutils.NamedPreparer,
+	eventID uuid.UUID,
+	eventType models.WebhookEventType,
+	payload string,
+	createdBy *uuid.UUID,
+) ([]*models.WebhookDelivery, error) {
+	s.events = append(s.events, queuedEvent{eventID, eventType, payload, createdBy})
+	return nil, nil
+}
+
+func TestEmit(t *testing.T) {
+	issuedAt := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	expiresAt := issuedAt.AddDate(1, 0, 0)
+	intake := &models.SystemIntake{
+		ID:                 uuid.MustParse("a5689bec-e4cf-4f2b-a7de-72020e8d65be"),
+		ProjectName:        null.StringFrom("Easy Access to System Information"),
+		LifecycleID:        null.StringFrom("250006"),
+		LifecycleIssuedAt:  &issuedAt,
+		LifecycleExpiresAt: &expiresAt,
+	}
+
+	t.Run("queues the event attributed to the signed in user", func(t *testing.T) {
+		account := &authentication.UserAccount{ID: uuid.New()}
+		ctx := appcontext.WithPrincipal(context.Background(), &authentication.EUAPrincipal{EUAID: "ABCD", UserAccount: account})
+		store := &memoryEventStore{}
+
+		event, err := Emit(ctx, store, nil, models.WebhookEventTypeLcidIssued, NewLCIDEventData(intake))
+		assert.NoError(t, err)
+		if !assert.Len(t, store.events, 1) {
+			return
+		}
+		queued := store.events[0]
+		assert.Equal(t, event.ID, queued.eventID)
+		assert.Equal(t, models.WebhookEventTypeLcidIssued, queued.eventType)
+		assert.Equal(t, &account.ID, queued.createdBy)
+
+		var payload map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(queued.payload), &payload))
+		assert.Equal(t, event.ID.String(), payload["id"])
+		assert.Equal(t, "LCID_ISSUED", payload["type"])
+		assert.NotEmpty(t, payload["occurredAt"])
+		assert.Equal(t, map[string]any{
+			"systemIntakeId": "a5689bec-e4cf-4f2b-a7de-72020e8d65be",
+			"projectName":    "Easy Access to System Information",
+			"lcid":           "250006",
+			"issuedAt":       "2025-01-06T00:00:00Z",
+			"expiresAt":      "2026-01-06T00:00:00Z",
+			"retiresAt":      nil,
+		}, payload["data"])
+	})
+
+	t.Run("events emitted without a signed in user have no creator", func(t *testing.T) {
+		store := &memoryEventStore{}
+
+		_, err := Emit(context.Background(), store, nil, models.WebhookEventTypeGrbReviewEnded, NewGRBReviewEndedEventData(intake, issuedAt, false))
+		assert.NoError(t, err)
+		if assert.Len(t, store.events, 1) {
+			assert.Nil(t, store.events[0].createdBy)
+		}
+	})
+}